@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"image"
 	"image/draw"
+	"math"
 	"reflect"
+	"time"
+	"unicode/utf8"
 	"unsafe"
 )
 
@@ -59,12 +62,88 @@ type Area interface {
 
 	// OnTextFieldDismissed is an event that is fired when the OpenTextFieldAt TextField is dismissed.
 	OnTextFieldDismissed(f func())
+
+	// StartDrag starts a drag-and-drop operation carrying the given data, which can then be dropped on anything that accepts it, including other programs and this Area.
+	// It may only be called from the AreaHandler's Mouse method while handling an event where a mouse button is held or has just been pressed; it panics otherwise.
+	// allowed says what the drop target may do with the data; it panics if allowed is zero or data is empty.
+	// f is called with the operation the drop target performed, or with 0 if the drag was cancelled or refused.
+	// Depending on the system, f may be called before StartDrag returns.
+	// If the operation is DragMove, removing the original is up to you.
+	// The Area does not receive a MouseEvent for the release of the button that started the drag.
+	StartDrag(data DragData, allowed DragOperation, f func(op DragOperation))
+
+	// SetFocus gives the Area keyboard focus.
+	// The AreaHandler's FocusGained method, if any, is called as a result; depending on the system, this may happen before or after SetFocus returns.
+	SetFocus()
+
+	// ScrollTo scrolls the Area so that pos is at the top-left corner of the visible part of the Area, or as close to it as the Area's size allows.
+	// ScrollPos returns the point at the top-left corner of the visible part of the Area.
+	// Both use Area coordinates, as MouseEvent.Pos does.
+	// On some systems, a ScrollTo right after SetSize does not take the new size into account until the Area is next laid out.
+	ScrollTo(pos image.Point)
+	ScrollPos() image.Point
+
+	// ShowPopupMenu shows m with its top-left corner at pos, in Area coordinates.
+	// Call it from Mouse() when MouseEvent.Down is 3 for a context menu at the click, or from Key() for the Menu key.
+	// As with PopupMenu.Show(), the chosen item's function may be called before or after ShowPopupMenu returns.
+	ShowPopupMenu(m PopupMenu, pos image.Point)
+
+	// SetCursor sets the Cursor shown while the mouse is over the Area; nil, the default, shows the usual arrow.
+	// To show different Cursors over different parts of the Area, call SetCursor from Mouse() as the mouse moves.
+	// An override cursor (see SetOverrideCursor) is shown instead, if one is set.
+	SetCursor(c *Cursor)
+
+	// Kinetic and SetKinetic get and set whether the Area follows the system's scrolling physics for touch and trackpad scrolling: continuing to scroll after a flick, and (on Mac OS X and with touch on GTK+) stretching past its edges and bouncing back.
+	// The default is true, which matches the rest of the system; precision tools, where the Area should stop exactly when the fingers do, can turn it off.
+	// On Windows, this is for touch panning on Windows 7 and newer, which has no stretching past the edges; on GTK+, it only affects touchscreens.
+	// Scrolling with the scrollbars or the mouse wheel is not affected.
+	Kinetic() bool
+	SetKinetic(kinetic bool)
+
+	// PixelRatio returns the number of device pixels (the pixels of the screen) per pixel of the Area on the display the Area is on: 1 on most displays and 2 on Retina and other HiDPI displays, where the system shows each pixel of the Area as 2x2 device pixels.
+	// It can change when the Window moves to another display or the user changes their display settings; see AreaPixelRatioHandler.
+	// On Windows, it is always 1, since Windows does not scale individual controls; programs that aren't marked as DPI-aware in their manifest are instead scaled by the system as a whole, which package ui has no way to tell.
+	PixelRatio() float64
+
+	// HiDPI and SetHiDPI get and set whether Paint draws in device pixels.
+	// Normally, what Paint draws is scaled up by PixelRatio, which makes it look blurry on HiDPI displays.
+	// With HiDPI on, Paint is instead given cliprect in device pixels (that is, the cliprect it would have gotten, multiplied by PixelRatio) and must return an image of that size, which is shown as is.
+	// Everything else about the Area, including its size and the positions in MouseEvents, stays in Area pixels; multiply by PixelRatio to get device pixels.
+	// The default is false.
+	HiDPI() bool
+	SetHiDPI(hidpi bool)
+
+	// Post sends a CustomEvent carrying data to the Area, which hands it to its AreaHandler's Custom method if the AreaHandler implements AreaCustomHandler.
+	// It works like Window.Post; see that for details.
+	Post(data interface{})
+
+	// for ScrollSync, which has to get at the areabase of Areas wrapped by other types (such as LiveView)
+	base() *areabase
 }
 
 type areabase struct {
 	width   int
 	height  int
 	handler AreaHandler
+
+	mouseinside bool // for AreaHoverHandler and SetCursor()
+
+	cursor *Cursor
+
+	syncmember *syncMember // for ScrollSync
+
+	kinetic bool
+
+	hidpi bool
+	ratio float64 // last PixelRatio() given to the AreaPixelRatioHandler; see pixelRatioChanged()
+
+	paintbuf *image.RGBA // see paint()
+
+	lasttooltip string // see tooltipAt()
+
+	colorblindness ColorBlindness // see Window.SimulateColorBlindness()
+
+	keysdown map[keyID]bool // see key()
 }
 
 // AreaHandler represents the events that an Area should respond to.
@@ -76,16 +155,19 @@ type AreaHandler interface {
 	// Before Paint() is called, this region is cleared with a system-defined background color.
 	// You MUST handle this event, and you MUST return a valid image, otherwise deadlocks and panicking will occur.
 	// The image returned must have the same size as rect (but does not have to have the same origin points).
+	// Any image.Image will do; an *image.RGBA is drawn directly, and anything else is converted to one first (an *image.NRGBA or *image.YCbCr quickly, other types less so).
+	// The conversion is done on the CPU, in full, on every Paint; only its memory is reused from one Paint to the next.
+	// There is no faster path for video: an Area showing *image.YCbCr frames pays for converting each frame to RGBA.
 	// Example:
 	// 	imgFromFile, _, err := image.Decode(file)
 	// 	if err != nil { panic(err) }
-	// 	img := image.NewRGBA(imgFromFile.Rect)
-	// 	draw.Draw(img, img.Rect, imgFromFile, image.ZP, draw.Over)
+	// 	img := image.NewNRGBA(imgFromFile.Bounds())
+	// 	draw.Draw(img, img.Rect, imgFromFile, imgFromFile.Bounds().Min, draw.Src)
 	// 	// ...
-	// 	func (h *myAreaHandler) Paint(rect image.Rectangle) *image.RGBA {
-	// 		return img.SubImage(rect).(*image.RGBA)
+	// 	func (h *myAreaHandler) Paint(rect image.Rectangle) image.Image {
+	// 		return img.SubImage(rect)
 	// 	}
-	Paint(cliprect image.Rectangle) *image.RGBA
+	Paint(cliprect image.Rectangle) image.Image
 
 	// Mouse is called when the Area receives a mouse event.
 	// You are allowed to do nothing in this handler (to ignore mouse events).
@@ -100,15 +182,111 @@ type AreaHandler interface {
 	Key(e KeyEvent) (handled bool)
 }
 
+// AreaFocusHandler can optionally be implemented by an AreaHandler to be told when the Area gains and loses keyboard focus, for instance to show, blink, and hide a custom caret.
+// Opening the TextField from OpenTextFieldAt() moves the focus away from the Area, so FocusLost is called then too.
+// As with the rest of AreaHandler, these are executed on the main goroutine.
+type AreaFocusHandler interface {
+	FocusGained()
+	FocusLost()
+}
+
+// AreaHoverHandler can optionally be implemented by an AreaHandler to be told when the mouse enters and leaves the Area and when it rests over the Area, for instance to implement hover highlighting or custom tooltips.
+//
+// MouseEntered is called when the mouse enters the Area, before the MouseEvent for that movement; MouseLeft is called when it leaves.
+// The two always alternate, starting with MouseEntered.
+// If the mouse leaves while a button is held, whether MouseLeft is called right away or only after the button is released is system-defined, as is whether moving over the TextField from OpenTextFieldAt() counts as leaving.
+//
+// MouseHovered is called with the position of the mouse once it has rested over the Area for a short time (the system's hover time, where it has one) with no buttons held.
+// It is called only once until the mouse moves again.
+//
+// As with the rest of AreaHandler, these are executed on the main goroutine.
+type AreaHoverHandler interface {
+	MouseEntered()
+	MouseLeft()
+	MouseHovered(pos image.Point)
+}
+
+// AreaTooltipHandler can optionally be implemented by an AreaHandler to give different parts of the Area different tooltips, such as one for each tool of a toolbar drawn by the Area.
+// Tooltip is called with the position of the mouse as it moves over the Area and returns the text of the tooltip there, or "" to use the tooltip set with SetTooltip, if any.
+// When the text changes while a tooltip is showing, whether the tooltip changes in place or goes away and comes back with the new text after the usual delay is system-defined.
+// As with the rest of AreaHandler, Tooltip is executed on the main goroutine; it is called often, so it should be fast.
+type AreaTooltipHandler interface {
+	Tooltip(pos image.Point) string
+}
+
+// AreaScrollHandler can optionally be implemented by an AreaHandler to be told when the Area scrolls, whether the user scrolled it or the program called ScrollTo.
+// pos is the new value of ScrollPos.
+// Scrolling both horizontally and vertically at once may result in two calls.
+// As with the rest of AreaHandler, Scrolled is executed on the main goroutine.
+type AreaScrollHandler interface {
+	Scrolled(pos image.Point)
+}
+
+// AreaPaintIntoHandler can optionally be implemented by an AreaHandler to draw into memory the Area provides instead of returning a new image from each Paint, which cuts down on garbage for Areas that redraw often, such as animations.
+// If the AreaHandler implements it, PaintInto is called instead of Paint (which the AreaHandler still needs to have to be an AreaHandler).
+// buf is cliprect in size and has cliprect as its Rect, so drawing can use Area coordinates as is.
+// buf is reused from one call to the next and may be larger underneath, so it holds whatever was drawn into it last; PaintInto must draw over every pixel in cliprect, as with draw.Src.
+// buf is only valid until PaintInto returns; don't keep it.
+// As with Paint, cliprect is in device pixels in HiDPI mode, and PaintInto is executed on the main goroutine.
+type AreaPaintIntoHandler interface {
+	PaintInto(buf *image.RGBA, cliprect image.Rectangle)
+}
+
+// AreaRegionHandler can optionally be implemented by an AreaHandler to find out exactly which parts of the Area need to be redrawn.
+// The system collects everything that needs redrawing between one screen refresh and the next (from Repaint, RepaintAll, scrolling, other windows moving away, and so on), and Paint (or PaintInto) is called once for all of it, with cliprect the smallest rectangle that covers it.
+// When the parts are far apart, such as two small indicators in opposite corners, most of cliprect doesn't actually need drawing.
+// If the AreaHandler implements AreaRegionHandler, PaintRegion is called right before each Paint with the rectangles that do, in the same coordinates as cliprect and all inside it; pixels of the image outside them are never shown, so Paint can leave them as they are.
+// How the system breaks the region up into rectangles is system-defined; a region may also come as just cliprect.
+// As with the rest of AreaHandler, PaintRegion is executed on the main goroutine.
+type AreaRegionHandler interface {
+	PaintRegion(region []image.Rectangle)
+}
+
+// AreaPixelRatioHandler can optionally be implemented by an AreaHandler to be told when the Area's PixelRatio changes, for instance to load artwork made for the new ratio.
+// If the Area is in HiDPI mode, it is repainted afterward.
+// As with the rest of AreaHandler, PixelRatioChanged is executed on the main goroutine.
+type AreaPixelRatioHandler interface {
+	PixelRatioChanged(ratio float64)
+}
+
+// AreaPenHandler can optionally be implemented by an AreaHandler to get the pressure and tilt of a pen on a graphics tablet or a pen-enabled screen, for drawing programs.
+// A pen also moves the mouse pointer, and touching it to the tablet presses button 1 (and pressing the buttons on its side presses the others), so MouseEvents come from a pen just as they do from a mouse.
+// If the AreaHandler implements AreaPenHandler, those MouseEvents go to Pen, along with the state of the pen, instead of to Mouse.
+// MouseEvents from a mouse (or from a touch screen) still go to Mouse, so Pen can draw with pressure and Mouse without.
+// Pen input is read from the device events of GTK+, from WM_POINTER messages on Windows 8 and newer (before that, Windows reports pens as a mouse only), and from the tablet fields of NSEvent on Mac OS X.
+// As with the rest of AreaHandler, Pen is executed on the main goroutine.
+type AreaPenHandler interface {
+	Pen(me MouseEvent, pen PenState)
+}
+
+// PenState is the state of a pen at the time of a MouseEvent; see AreaPenHandler.
+// Fields the pen doesn't report are 0.
+type PenState struct {
+	// Pressure is how hard the pen is pressed against the tablet, from 0 (not touching it) to 1 (as hard as the tablet can tell).
+	Pressure float64
+
+	// TiltX and TiltY are how far the pen leans from upright, from -1 to 1, where 1 is lying flat.
+	// TiltX is positive when the pen leans to the right, and TiltY when it leans toward the user.
+	TiltX float64
+	TiltY float64
+
+	// Eraser is whether the pen is being used upside down, with its eraser end, which drawing programs take to mean erase.
+	Eraser bool
+}
+
 // MouseEvent contains all the information for a mous event sent by Area.Mouse.
 // Mouse button IDs start at 1, with 1 being the left mouse button, 2 being the middle mouse button, and 3 being the right mouse button.
 // If additional buttons are supported, they will be returned with 4 being the first additional button.
 // For example, on Unix systems where mouse buttons 4 through 7 are pseudobuttons for the scroll wheel directions, the next button, button 8, will be returned as 4, 9 as 5, etc.
 // The association between button numbers and physical buttons are system-defined.
-// For example, on Windows, buttons 4 and 5 are mapped to what are internally referred to as "XBUTTON1" and "XBUTTON2", which often correspond to the dedicated back/forward navigation buttons on the sides of many mice.
+// Buttons 4 and 5 are the back and forward navigation buttons on the sides of many mice, on every system: "XBUTTON1" and "XBUTTON2" on Windows, X11 buttons 8 and 9 on GTK+, and NSEvent button numbers 3 and 4 on Mac OS X.
 // The examples here are NOT a guarantee as to how many buttons maximum will be available on a given system.
 //
 // If the user clicked on the Area to switch to the Window it is contained in from another window in the OS, the Area will receive a MouseEvent for that click.
+//
+// Pressing a mouse button over the Area captures the mouse, as with the system's own controls: until every held button is released, the Area keeps receiving MouseEvents wherever the mouse goes, even outside the Area and its Window, with Pos outside the Area's bounds (and possibly negative).
+// So a drag that starts in the Area always gets its Up, unless the system takes the mouse away first (for instance, to show a menu, or for Area.StartDrag).
+// Apart from this, MouseEvents are only sent while the mouse is over the Area.
 type MouseEvent struct {
 	// Pos is the position of the mouse in the Area at the time of the event.
 	Pos image.Point
@@ -126,6 +304,7 @@ type MouseEvent struct {
 
 	// If Down is nonzero, Count indicates the number of clicks: 1 for single-click, 2 for double-click, 3 for triple-click, and so on.
 	// The order of events will be Down:Count=1 -> Up -> Down:Count=2 -> Up -> Down:Count=3 -> Up -> ...
+	// A click adds to the count if it is of the same button as the last one, comes within DoubleClickTime() of it, and is within DoubleClickDistance() of it; there is no upper limit.
 	Count uint
 
 	// Modifiers is a bit mask indicating the modifier keys being held during the event.
@@ -134,13 +313,20 @@ type MouseEvent struct {
 	// Held is a slice of button IDs that indicate which mouse buttons are being held during the event.
 	// Held will not include Down and Up.
 	// Held will be sorted.
-	// Only buttons 1, 2, and 3 are guaranteed to be detected by Held properly; whether or not any others are is implementation-defined.
+	// Buttons 1 through 5 are detected by Held on every system; whether or not any others are is implementation-defined.
+	// (GTK+ doesn't report buttons 4 and 5 as held, so package ui keeps track of them itself from Down and Up; if the Area loses keyboard focus while they are held, they stop being reported as held.)
 	//
 	// If Held is non-empty but Up and Down are both zero, the mouse is being dragged, with all the buttons in Held being held.
 	// Whether or not a drag into an Area generates MouseEvents is implementation-defined.
 	// Whether or not a drag over an Area when the program is inactive generates MouseEvents is also implementation-defined.
 	// Moving the mouse over an Area when the program is inactive and no buttons are held will, however, generate MouseEvents.
 	Held []uint
+
+	// Timestamp is the time at which package ui received the event from the system, measured on a monotonic clock from when package ui was initialized.
+	// KeyEvent and DropEvent have the same field, measured on the same clock, so events sent to different Areas can be compared.
+	// Because all events are handled one at a time on the main goroutine, in the order the system delivers them, Timestamps never decrease from one event to the next, even across Areas and Windows; two events may have the same Timestamp.
+	// The system's own event times are not used: they have different units and starting points on each system, and on some systems they wrap around.
+	Timestamp time.Duration
 }
 
 // HeldBits returns Held as a bit mask.
@@ -152,6 +338,18 @@ func (e MouseEvent) HeldBits() (h uintptr) {
 	return h
 }
 
+// DoubleClickTime returns the longest time the user's settings allow between two clicks for them to count as a double-click (or the clicks after them as a triple-click, and so on).
+// MouseEvent.Count already follows it; it is for custom controls in an Area that need to time things of their own the same way, such as telling a click that starts renaming an item from the first click of a double-click that opens it.
+func DoubleClickTime() time.Duration {
+	return doubleClickTime()
+}
+
+// DoubleClickDistance returns how far, in pixels in each direction, the mouse can move between two clicks for them to still count as a double-click.
+// Mac OS X has no such setting and decides for itself in MouseEvent.Count; there DoubleClickDistance returns a small fixed distance close to what it uses.
+func DoubleClickDistance() int {
+	return doubleClickDistance()
+}
+
 // A KeyEvent represents a keypress in an Area.
 //
 // Key presses are based on their positions on a standard
@@ -205,12 +403,67 @@ type KeyEvent struct {
 	Modifiers Modifiers
 
 	// If Up is true, the key was released; if not, the key was pressed.
-	// There is no guarantee that all pressed keys shall have
-	// corresponding release events (for instance, if the user switches
-	// programs while holding the key down, then releases the key).
+	// Every key press is followed by a release: when the Area loses
+	// the keyboard focus (including when the user switches to another
+	// Window or program) with keys still held, it is sent releases for
+	// them right away, as the system won't send them when the keys
+	// are actually released. These releases have Modifiers and Rune
+	// zero.
 	// Keys that have been held down are reported as multiple
-	// key press events.
+	// key press events; see Repeat.
 	Up bool
+
+	// Repeat is true for the key presses that come from holding a
+	// key down, after the first one. A game or anything else that
+	// keeps track of which keys are held can ignore these, while
+	// text input and key navigation take them as more presses.
+	// It is always false if Up is true.
+	Repeat bool
+
+	// Rune is the character the key press types, taking the
+	// keyboard layout, Shift, Caps Lock, and dead keys into account,
+	// where the system can provide it.
+	// It is zero for key releases, for keys that do not type a
+	// character (including Tab, Enter, and Backspace, which are
+	// reported only through Key), and for the key press that starts
+	// a dead key sequence.
+	// Use Key and ExtKey for keyboard shortcuts and Rune for text input.
+	// Input methods that compose several key presses into text (such
+	// as those for Chinese or Japanese) are not supported yet.
+	Rune rune
+
+	// Scancode is the code the system gives the physical key, before
+	// any keyboard layout is applied: on GTK+, the X11 keycode (on
+	// Linux, the kernel's key code plus 8); on Windows, the PC
+	// keyboard scancode, with 0xE000 added for the extended keys
+	// (such as the arrow keys outside the numeric keypad); on Mac
+	// OS X, the virtual key code of NSEvent.
+	// Like Key, it stays the same whatever the layout is (so WASD
+	// controls are in the same place on an AZERTY or Dvorak
+	// keyboard), but it also tells apart keys that Key and ExtKey
+	// can't name, such as the key to the left of 'z' on some
+	// keyboards; use it to remember key bindings the user made by
+	// pressing keys.
+	// The same key has different Scancodes on different systems
+	// (and may on different keyboards), so don't write them into
+	// files shared between systems.
+	Scancode uintptr
+
+	// Timestamp is the time at which package ui received the
+	// event; see MouseEvent.Timestamp for details.
+	Timestamp time.Duration
+}
+
+// keyRune filters a character reported by the system for KeyEvent.Rune.
+func keyRune(r rune) rune {
+	if r < 0x20 || r == 0x7F || !utf8.ValidRune(r) {
+		return 0
+	}
+	// the Unicode private use area U+F700..U+F8FF is where Mac OS X puts function keys; nothing else types it from a key press
+	if r >= 0xF700 && r <= 0xF8FF {
+		return 0
+	}
+	return r
 }
 
 // ExtKey represents keys that are not in the typewriter section of the keyboard.
@@ -256,6 +509,18 @@ const (
 	NSubtract
 	NMultiply
 	NDivide
+	PrintScreen // on Windows, only the release is reported; the system handles the press itself
+	Pause       // also reported for Break (Ctrl+Pause)
+	Menu        // the context menu key found on many PC keyboards, usually to the right of the space bar
+	// The following media keys are, on most systems, also handled by the system or desktop environment (to change the volume, for instance) regardless of whether the KeyEvent was handled.
+	// Some Unix desktops take them for themselves entirely, and Mac OS X only reports the volume keys.
+	VolumeMute
+	VolumeDown
+	VolumeUp
+	MediaPlayPause
+	MediaStop
+	MediaNext
+	MediaPrevious
 	_nextkeys // for sanity check
 )
 
@@ -291,6 +556,11 @@ func (e KeyEvent) EffectiveKey() byte {
 // Modifiers indicates modifier keys being held during an event.
 // There is no way to differentiate between left and right modifier keys.
 // As such, what KeyEvents get sent if the user does something unusual with both of a certain modifier key at once is undefined.
+//
+// Super is reported in both KeyEvents and MouseEvents on all platforms.
+// The usual shortcut modifier is Super (Command) on Mac OS X and Ctrl elsewhere, so a program that wants Command+S on Mac OS X and Ctrl+S on other platforms has to check for each itself.
+// Note that the system reserves many Super combinations for itself on Windows and on some Unix desktops (for instance, Windows+L on Windows); these never reach the program.
+// On Windows, pressing and releasing a Windows key by itself opens the Start menu regardless of whether the KeyEvent was handled.
 type Modifiers uintptr
 
 const (
@@ -317,9 +587,222 @@ func NewArea(width int, height int, handler AreaHandler) Area {
 		width:   width,
 		height:  height,
 		handler: handler,
+		kinetic: true,
+		ratio:   1,
 	})
 }
 
+// internal function, but shared by all system implementations
+func (a *areabase) focusChanged(gained bool) {
+	if !gained {
+		a.releaseKeys()
+	}
+	if f, ok := a.handler.(AreaFocusHandler); ok {
+		if gained {
+			f.FocusGained()
+		} else {
+			f.FocusLost()
+		}
+	}
+}
+
+// internal functions, but shared by all system implementations
+// mouseCrossed() ignores repeats so the backends don't have to
+func (a *areabase) mouseCrossed(entered bool) {
+	if a.mouseinside == entered {
+		return
+	}
+	a.mouseinside = entered
+	if h, ok := a.handler.(AreaHoverHandler); ok {
+		if entered {
+			h.MouseEntered()
+		} else {
+			h.MouseLeft()
+		}
+	}
+}
+
+func (a *areabase) mouseHovered(pos image.Point) {
+	if h, ok := a.handler.(AreaHoverHandler); ok && a.mouseinside {
+		h.MouseHovered(pos)
+	}
+}
+
+// mouse sends me to the Area's AreaHandler; pen is nil if me didn't come from a pen
+func (a *areabase) mouse(me MouseEvent, pen *PenState) {
+	if pen != nil {
+		if h, ok := a.handler.(AreaPenHandler); ok {
+			h.Pen(me, *pen)
+			return
+		}
+	}
+	a.handler.Mouse(me)
+}
+
+// keyID is the key a KeyEvent is about, for keeping track of which keys are held
+type keyID struct {
+	key      byte
+	extkey   ExtKey
+	modifier Modifiers
+	scancode uintptr
+}
+
+// internal function, but shared by all system implementations: sets ke.Repeat and sends ke to the handler, returning whether it was handled
+// not every system marks repeated key presses (GTK+ doesn't), so we keep track of which keys are held ourselves
+func (a *areabase) key(ke KeyEvent) bool {
+	id := keyID{ke.Key, ke.ExtKey, ke.Modifier, ke.Scancode}
+	if ke.Up {
+		delete(a.keysdown, id)
+	} else {
+		if a.keysdown == nil {
+			a.keysdown = make(map[keyID]bool)
+		}
+		ke.Repeat = a.keysdown[id]
+		a.keysdown[id] = true
+	}
+	return a.handler.Key(ke)
+}
+
+// internal function, but shared by all system implementations
+// the backends call this when the Area stops getting key events while keys are still held, which is when it loses the focus (focusChanged() calls it then) and, on Mac OS X, when its window stops being the key window
+func (a *areabase) releaseKeys() {
+	for id := range a.keysdown {
+		a.handler.Key(KeyEvent{
+			Key:       id.key,
+			ExtKey:    id.extkey,
+			Modifier:  id.modifier,
+			Scancode:  id.scancode,
+			Up:        true,
+			Timestamp: eventTime(),
+		})
+	}
+	a.keysdown = nil
+}
+
+// internal function, but shared by all system implementations: show shows a.cursor's current frame, and is called again each time an animated Cursor changes frames
+func (a *areabase) setCursor(c *Cursor, show func()) {
+	a.cursor.unuse(a)
+	a.cursor = c
+	c.use(a, show)
+	show()
+}
+
+// internal function, but shared by all system implementations: calls Paint() and gets the result into the form the per-platform code wants
+// unlike toRGBA(), images that need converting are converted into a buffer kept from one call to the next, so an Area showing a video (which usually comes as one *image.YCbCr after another) doesn't allocate a whole new image each frame
+// image/draw has a fast path for *image.YCbCr (as well as *image.NRGBA) to *image.RGBA, so the color conversion at least doesn't go pixel by pixel through color.Color; it is still done on the CPU every time, though (see assortednotes.md)
+// in HiDPI mode, the image is in device pixels; the backends draw it into cliprect, which stays in Area pixels, and the system takes care of the rest
+// region is the rectangles in Area pixels the system says need drawing, for AreaRegionHandler; backends only need to collect it if a.wantsRegion(), and can pass nil if the system doesn't say
+func (a *areabase) paint(cliprect image.Rectangle, region []image.Rectangle, ratio float64) *image.RGBA {
+	if h, ok := a.handler.(AreaRegionHandler); ok {
+		h.PaintRegion(a.clipRegion(region, cliprect, ratio))
+	}
+	if a.hidpi && ratio != 1 {
+		cliprect = scaleRect(cliprect, ratio)
+	}
+	if p, ok := a.handler.(AreaPaintIntoHandler); ok {
+		buf := a.paintBuffer(cliprect)
+		p.PaintInto(buf, cliprect)
+		a.colorblindness.simulate(buf)
+		return buf
+	}
+	i := a.handler.Paint(cliprect)
+	// the handler may keep drawing into the image it returned, so the simulation has to work on a copy
+	if i, ok := i.(*image.RGBA); ok && a.colorblindness == NormalVision {
+		return i
+	}
+	r := i.Bounds()
+	buf := a.paintBuffer(image.Rectangle{Max: r.Size()})
+	draw.Draw(buf, buf.Rect, i, r.Min, draw.Src)
+	a.colorblindness.simulate(buf)
+	return buf
+}
+
+// internal function, but shared by all system implementations
+func (a *areabase) wantsRegion() bool {
+	_, ok := a.handler.(AreaRegionHandler)
+	return ok
+}
+
+// clipRegion puts region into the form AreaRegionHandler.PaintRegion promises: in device pixels in HiDPI mode, inside cliprect, with nothing empty
+func (a *areabase) clipRegion(region []image.Rectangle, cliprect image.Rectangle, ratio float64) []image.Rectangle {
+	clipped := make([]image.Rectangle, 0, len(region))
+	for _, r := range region {
+		r = r.Intersect(cliprect)
+		if r.Empty() {
+			continue
+		}
+		if a.hidpi && ratio != 1 {
+			r = scaleRect(r, ratio)
+		}
+		clipped = append(clipped, r)
+	}
+	if len(clipped) == 0 { // the system didn't say, so it's all of cliprect
+		if a.hidpi && ratio != 1 {
+			cliprect = scaleRect(cliprect, ratio)
+		}
+		clipped = append(clipped, cliprect)
+	}
+	return clipped
+}
+
+// paintBuffer returns a.paintbuf with its Rect set to r, growing it if it is too small
+// the memory is reused from one call to the next, so it only grows to the largest rectangle ever painted; Pix can be longer than needed
+func (a *areabase) paintBuffer(r image.Rectangle) *image.RGBA {
+	size := r.Size()
+	if a.paintbuf == nil || len(a.paintbuf.Pix) < 4*size.X*size.Y {
+		a.paintbuf = image.NewRGBA(r)
+		return a.paintbuf
+	}
+	a.paintbuf.Stride = 4 * size.X
+	a.paintbuf.Rect = r
+	return a.paintbuf
+}
+
+// scaleRect multiplies r by ratio, rounding outward so that the result covers all of r
+func scaleRect(r image.Rectangle, ratio float64) image.Rectangle {
+	return image.Rect(
+		int(math.Floor(float64(r.Min.X)*ratio)),
+		int(math.Floor(float64(r.Min.Y)*ratio)),
+		int(math.Ceil(float64(r.Max.X)*ratio)),
+		int(math.Ceil(float64(r.Max.Y)*ratio)))
+}
+
+// internal function, but shared by all system implementations
+// the backends call this whenever the system says the ratio might have changed; it ignores repeats so they don't have to check
+// it returns whether the Area needs to be repainted
+func (a *areabase) pixelRatioChanged(ratio float64) (repaint bool) {
+	if ratio == a.ratio {
+		return false
+	}
+	a.ratio = ratio
+	if h, ok := a.handler.(AreaPixelRatioHandler); ok {
+		h.PixelRatioChanged(ratio)
+	}
+	return a.hidpi
+}
+
+func (a *areabase) HiDPI() bool {
+	return a.hidpi
+}
+
+// internal function, but shared by all system implementations
+func (a *areabase) scrolled(pos image.Point) {
+	if s, ok := a.handler.(AreaScrollHandler); ok {
+		s.Scrolled(pos)
+	}
+	if a.syncmember != nil {
+		a.syncmember.sync.scrolled(a.syncmember, pos)
+	}
+}
+
+func (a *areabase) Kinetic() bool {
+	return a.kinetic
+}
+
+func (a *areabase) base() *areabase {
+	return a
+}
+
 // internal function, but shared by all system implementations: &img.Pix[0] is not necessarily the first pixel in the image
 func pixelDataPos(img *image.RGBA) int {
 	return img.PixOffset(img.Rect.Min.X, img.Rect.Min.Y)
@@ -329,6 +812,37 @@ func pixelData(img *image.RGBA) *uint8 {
 	return &img.Pix[pixelDataPos(img)]
 }
 
+// tooltipAt returns the tooltip to show at pos: the AreaTooltipHandler's, or static (the text given to SetTooltip) if it has none there
+// changed is whether this is different from the last call, so the backends only bother the system when they need to
+func (a *areabase) tooltipAt(pos image.Point, static string) (text string, changed bool) {
+	text = static
+	if t, ok := a.handler.(AreaTooltipHandler); ok {
+		if s := t.Tooltip(pos); s != "" {
+			text = s
+		}
+	}
+	changed = text != a.lasttooltip
+	a.lasttooltip = text
+	return text, changed
+}
+
+// toRGBA converts any image given to package ui (or read back from the system) into the *image.RGBA the per-platform code works with.
+// *image.RGBA is returned as is; everything else is copied, with image/draw's fast paths (such as the one for *image.NRGBA) doing the conversion.
+// The copy has its origin at (0,0), which is fine since package ui only cares about the size of images it is given.
+// nil is returned as nil.
+func toRGBA(i image.Image) *image.RGBA {
+	if i == nil {
+		return nil
+	}
+	if i, ok := i.(*image.RGBA); ok {
+		return i
+	}
+	r := i.Bounds().Sub(i.Bounds().Min)
+	img := image.NewRGBA(r)
+	draw.Draw(img, r, i, i.Bounds().Min, draw.Src)
+	return img
+}
+
 // some platforms require pixels in ARGB order in their native endianness (because they treat the pixel array as an array of uint32s)
 // this does the conversion
 // you need to convert somewhere (Windows and cairo give us memory to use; Windows has stride==width but cairo might not)
@@ -5,6 +5,7 @@ package ui
 import (
 	"fmt"
 	"image"
+	"time"
 	"unsafe"
 )
 
@@ -18,6 +19,13 @@ type area struct {
 	*scroller
 	textfield     C.id
 	textfielddone *event
+
+	// filled in piecemeal during a drop; see drop_darwin.go
+	drop DropEvent
+
+	// for StartDrag(); mouseevent is only valid during Mouse()
+	mouseevent C.id
+	dragdone   func(op DragOperation)
 }
 
 func newArea(ab *areabase) Area {
@@ -27,13 +35,22 @@ func newArea(ab *areabase) Area {
 	}
 	id := C.newArea(unsafe.Pointer(a))
 	a.scroller = newScroller(id, false) // no border on Area
+	C.areaWatchScroll(a.id)
 	a.fpreferredSize = a.xpreferredSize
+	a.fsetTooltip = a.setAreaTooltip
 	a.SetSize(a.width, a.height)
 	a.textfield = C.newTextField()
 	C.areaSetTextField(a.id, a.textfield)
+	a.setDropTarget()
 	return a
 }
 
+// the AreaTooltipHandler may have changed the tooltip since the last SetTooltip(), so keep tooltipAt() up to date with what the NSView has
+func (a *area) setAreaTooltip(text string) {
+	a.lasttooltip = text
+	setTooltip(a.id, text)
+}
+
 func (a *area) SetSize(width, height int) {
 	a.width = width
 	a.height = height
@@ -80,6 +97,93 @@ func (a *area) OnTextFieldDismissed(f func()) {
 	a.textfielddone.set(f)
 }
 
+func (a *area) SetFocus() {
+	C.areaSetFocus(a.id)
+}
+
+func (a *area) ScrollTo(pos image.Point) {
+	C.areaScrollTo(a.id, C.intptr_t(pos.X), C.intptr_t(pos.Y))
+}
+
+func (a *area) ScrollPos() image.Point {
+	p := C.areaScrollPos(a.id)
+	return image.Pt(int(p.x), int(p.y))
+}
+
+func (a *area) ShowPopupMenu(m PopupMenu, pos image.Point) {
+	m.popup(a, pos)
+}
+
+func (a *area) SetKinetic(kinetic bool) {
+	a.kinetic = kinetic
+	C.areaSetKinetic(a.id, a.scroller.scroller.id, toBOOL(kinetic))
+}
+
+func (a *area) PixelRatio() float64 {
+	return float64(C.areaPixelRatio(a.id))
+}
+
+func (a *area) SetHiDPI(hidpi bool) {
+	a.hidpi = hidpi
+	a.RepaintAll()
+}
+
+//export areaView_pixelRatioChanged
+func areaView_pixelRatioChanged(data unsafe.Pointer) {
+	a := (*area)(data)
+	if a.pixelRatioChanged(a.PixelRatio()) {
+		a.RepaintAll()
+	}
+}
+
+func (a *area) SetCursor(c *Cursor) {
+	a.setCursor(c, func() {
+		C.areaResetCursorRects(a.id)
+		// the cursor rect only takes effect when the mouse next moves; show it now, especially so animated Cursors animate
+		if a.mouseinside && overrideCursor == nil {
+			if cur := a.cursor.current(); cur != nil {
+				C.setCursor(C.id(cur))
+			}
+		}
+	})
+}
+
+//export areaView_cursor
+func areaView_cursor(data unsafe.Pointer) C.id {
+	a := (*area)(data)
+	return C.id(a.cursor.current())
+}
+
+//export areaView_mouseCrossed
+func areaView_mouseCrossed(data unsafe.Pointer, entered C.BOOL) {
+	a := (*area)(data)
+	a.mouseCrossed(entered != C.NO)
+}
+
+//export areaView_hovered
+func areaView_hovered(data unsafe.Pointer, x C.intptr_t, y C.intptr_t) {
+	a := (*area)(data)
+	a.mouseHovered(image.Pt(int(x), int(y)))
+}
+
+//export areaView_scrolled
+func areaView_scrolled(data unsafe.Pointer) {
+	a := (*area)(data)
+	a.scrolled(a.ScrollPos())
+}
+
+//export areaView_focusChanged
+func areaView_focusChanged(data unsafe.Pointer, gained C.BOOL) {
+	a := (*area)(data)
+	a.focusChanged(gained != C.NO)
+}
+
+//export areaView_releaseKeys
+func areaView_releaseKeys(data unsafe.Pointer) {
+	a := (*area)(data)
+	a.releaseKeys()
+}
+
 //export areaTextFieldDismissed
 func areaTextFieldDismissed(data unsafe.Pointer) {
 	a := (*area)(unsafe.Pointer(data))
@@ -87,8 +191,14 @@ func areaTextFieldDismissed(data unsafe.Pointer) {
 	a.textfielddone.fire()
 }
 
+//export areaView_wantsRegion
+func areaView_wantsRegion(data unsafe.Pointer) C.BOOL {
+	a := (*area)(data)
+	return toBOOL(a.wantsRegion())
+}
+
 //export areaView_drawRect
-func areaView_drawRect(self C.id, rect C.struct_xrect, data unsafe.Pointer) {
+func areaView_drawRect(self C.id, rect C.struct_xrect, rects *C.struct_xrect, nrects C.intptr_t, data unsafe.Pointer) {
 	a := (*area)(data)
 	// no need to clear the clip rect; the NSScrollView does that for us (see the setDrawsBackground: call in objc_darwin.m)
 	// rectangles in Cocoa are origin/size, not point0/point1; if we don't watch for this, weird things will happen when scrolling
@@ -97,10 +207,19 @@ func areaView_drawRect(self C.id, rect C.struct_xrect, data unsafe.Pointer) {
 	if cliprect.Empty() { // no intersection; nothing to paint
 		return
 	}
-	i := a.handler.Paint(cliprect)
+	var region []image.Rectangle
+	if rects != nil { // only if areaView_wantsRegion() said to get them
+		n := int(nrects)
+		r := (*[1 << 16]C.struct_xrect)(unsafe.Pointer(rects))[:n:n]
+		region = make([]image.Rectangle, n)
+		for j := range r {
+			region[j] = image.Rect(int(r[j].x), int(r[j].y), int(r[j].x+r[j].width), int(r[j].y+r[j].height))
+		}
+	}
+	i := a.paint(cliprect, region, a.PixelRatio())
 	success := C.drawImage(
 		unsafe.Pointer(pixelData(i)), C.intptr_t(i.Rect.Dx()), C.intptr_t(i.Rect.Dy()), C.intptr_t(i.Stride),
-		C.intptr_t(cliprect.Min.X), C.intptr_t(cliprect.Min.Y))
+		C.intptr_t(cliprect.Min.X), C.intptr_t(cliprect.Min.Y), C.intptr_t(cliprect.Dx()), C.intptr_t(cliprect.Dy()))
 	if success == C.NO {
 		panic("error drawing into Area (exactly what is unknown)")
 	}
@@ -127,12 +246,11 @@ func areaMouseEvent(self C.id, e C.id, click bool, up bool, data unsafe.Pointer)
 	var me MouseEvent
 
 	a := (*area)(data)
-	xp := C.getTranslatedEventPoint(self, e)
-	me.Pos = image.Pt(int(xp.x), int(xp.y))
-	// for the most part, Cocoa won't geenerate an event outside the Area... except when dragging outside the Area, so check for this
-	if !me.Pos.In(image.Rect(0, 0, int(a.width), int(a.height))) {
+	if !a.enabled() { // NSViews can't be disabled, so ignore the user ourselves
 		return
 	}
+	xp := C.getTranslatedEventPoint(self, e)
+	me.Pos = image.Pt(int(xp.x), int(xp.y))
 	me.Modifiers = parseModifiers(e)
 	which := uint(C.buttonNumber(e)) + 1
 	if which == 3 { // swap middle and right button numbers
@@ -167,7 +285,27 @@ func areaMouseEvent(self C.id, e C.id, click bool, up bool, data unsafe.Pointer)
 		}
 		held >>= 1
 	}
-	a.handler.Mouse(me)
+	// Cocoa only sends events outside the Area while dragging from a mouseDown: in it, and we pass those on (see MouseEvent)
+	if !me.Pos.In(image.Rect(0, 0, int(a.width), int(a.height))) && (me.Down != 0 || me.Up == 0 && len(me.Held) == 0) {
+		return
+	}
+	// StartDrag() needs the event, but only if a button is involved
+	if me.Down != 0 || len(me.Held) != 0 {
+		a.mouseevent = e
+	}
+	me.Timestamp = eventTime()
+	var pen *PenState
+	var cpen C.struct_areaPen
+	if C.penState(e, &cpen) != C.NO {
+		pen = &PenState{
+			Pressure: float64(cpen.pressure),
+			TiltX:    float64(cpen.tiltX),
+			TiltY:    float64(cpen.tiltY),
+			Eraser:   cpen.eraser != C.NO,
+		}
+	}
+	a.mouse(me, pen)
+	a.mouseevent = nil
 }
 
 //export areaView_mouseMoved_mouseDragged
@@ -175,6 +313,11 @@ func areaView_mouseMoved_mouseDragged(self C.id, e C.id, data unsafe.Pointer) {
 	// for moving, this is handled by the tracking rect stuff above
 	// for dragging, if multiple buttons are held, only one of their xxxMouseDragged: messages will be sent, so this is OK to do
 	areaMouseEvent(self, e, false, false, data)
+	a := (*area)(data)
+	xp := C.getTranslatedEventPoint(self, e)
+	if text, changed := a.tooltipAt(image.Pt(int(xp.x), int(xp.y)), a.tooltip); changed {
+		setTooltip(a.id, text)
+	}
 }
 
 //export areaView_mouseDown
@@ -190,7 +333,11 @@ func areaView_mouseUp(self C.id, e C.id, data unsafe.Pointer) {
 
 func sendKeyEvent(self C.id, ke KeyEvent, data unsafe.Pointer) C.BOOL {
 	a := (*area)(data)
-	handled := a.handler.Key(ke)
+	if !a.enabled() {
+		return C.NO
+	}
+	ke.Timestamp = eventTime()
+	handled := a.key(ke)
 	return toBOOL(handled)
 }
 
@@ -206,6 +353,10 @@ func areaKeyEvent(self C.id, e C.id, up bool, data unsafe.Pointer) C.BOOL {
 	// either ke.Key or ke.ExtKey will be set at this point
 	ke.Modifiers = parseModifiers(e)
 	ke.Up = up
+	if !up {
+		ke.Rune = keyRune(rune(C.keyCharacter(e)))
+	}
+	ke.Scancode = keyCode
 	return sendKeyEvent(self, ke, data)
 }
 
@@ -235,6 +386,7 @@ func areaView_flagsChanged(self C.id, e C.id, data unsafe.Pointer) C.BOOL {
 	ke.Modifier = mod
 	// don't include the modifier in ke.Modifiers
 	ke.Modifiers &^= mod
+	ke.Scancode = keyCode
 	return sendKeyEvent(self, ke, data)
 }
 
@@ -242,3 +394,12 @@ func (a *area) xpreferredSize(d *sizing) (width, height int) {
 	// the preferred size of an Area is its size
 	return a.width, a.height
 }
+
+func doubleClickTime() time.Duration {
+	return time.Duration(float64(C.doubleClickInterval()) * float64(time.Second))
+}
+
+// AppKit doesn't say; this is what it seems to use
+func doubleClickDistance() int {
+	return 4
+}
@@ -14,10 +14,13 @@
 #define toNSUInteger(x) ((NSUInteger) (x))
 #define fromNSUInteger(x) ((uintptr_t) (x))
 
-@interface goAreaView : NSView <NSTextFieldDelegate> {
+@interface goAreaView : NSView <NSTextFieldDelegate, NSDraggingSource> {
 @public
 	void *goarea;
 	NSTrackingArea *trackingArea;
+	NSDragOperation dragOperations;		// for the current areaStartDrag()
+	NSPoint hoverPoint;
+	BOOL noMomentum;		// for areaSetKinetic()
 }
 @end
 
@@ -31,15 +34,34 @@
 	return self;
 }
 
+// cliprect is only the bounding box of what needs drawing; the rectangles themselves are for AreaRegionHandler
 - (void)drawRect:(NSRect)cliprect
 {
 	struct xrect rect;
+	const NSRect *drawn;
+	NSInteger i, n;
+	struct xrect *rects;
 
 	rect.x = (intptr_t) cliprect.origin.x;
 	rect.y = (intptr_t) cliprect.origin.y;
 	rect.width = (intptr_t) cliprect.size.width;
 	rect.height = (intptr_t) cliprect.size.height;
-	areaView_drawRect(self, rect, self->goarea);
+	if (!areaView_wantsRegion(self->goarea)) {
+		areaView_drawRect(self, rect, NULL, 0, self->goarea);
+		return;
+	}
+	[self getRectsBeingDrawn:&drawn count:&n];
+	rects = (struct xrect *) malloc(n * sizeof (struct xrect));
+	if (rects == NULL)
+		[NSException raise:@"memory exhausted getting rectangles to draw in Area" format:@""];
+	for (i = 0; i < n; i++) {
+		rects[i].x = (intptr_t) drawn[i].origin.x;
+		rects[i].y = (intptr_t) drawn[i].origin.y;
+		rects[i].width = (intptr_t) drawn[i].size.width;
+		rects[i].height = (intptr_t) drawn[i].size.height;
+	}
+	areaView_drawRect(self, rect, rects, (intptr_t) n, self->goarea);
+	free(rects);
 }
 
 - (BOOL)isFlipped
@@ -52,6 +74,62 @@
 	return YES;
 }
 
+- (BOOL)becomeFirstResponder
+{
+	BOOL r;
+
+	r = [super becomeFirstResponder];
+	if (r)
+		areaView_focusChanged(self->goarea, YES);
+	return r;
+}
+
+- (BOOL)resignFirstResponder
+{
+	BOOL r;
+
+	r = [super resignFirstResponder];
+	if (r)
+		areaView_focusChanged(self->goarea, NO);
+	return r;
+}
+
+// a window stops getting keyUp: once it's no longer the key window, but its first responder stays the same, so the Area isn't told through resignFirstResponder; the Go side needs to know to send releases for the keys still held
+- (void)viewWillMoveToWindow:(NSWindow *)w
+{
+	[[NSNotificationCenter defaultCenter] removeObserver:self name:NSWindowDidResignKeyNotification object:nil];
+	if (w != nil)
+		[[NSNotificationCenter defaultCenter] addObserver:self
+			selector:@selector(windowResignedKey:)
+			name:NSWindowDidResignKeyNotification
+			object:w];
+	[super viewWillMoveToWindow:w];
+}
+
+- (void)windowResignedKey:(NSNotification *)note
+{
+	areaView_releaseKeys(self->goarea);
+}
+
+// this is sent when the view moves to a window, and when its window moves to a display with a different backing scale factor
+- (void)viewDidChangeBackingProperties
+{
+	[super viewDidChangeBackingProperties];
+	areaView_pixelRatioChanged(self->goarea);
+}
+
+// see areaWatchScroll() below
+- (void)boundsChanged:(NSNotification *)note
+{
+	areaView_scrolled(self->goarea);
+}
+
+- (void)dealloc
+{
+	[[NSNotificationCenter defaultCenter] removeObserver:self];
+	[super dealloc];
+}
+
 // this will have the Area receive a click that switches to the Window it is in from another one
 - (BOOL)acceptsFirstMouse:(NSEvent *)e
 {
@@ -69,6 +147,23 @@
 	[self addTrackingArea:self->trackingArea];
 }
 
+// momentum scroll events are the ones that keep coming after the fingers leave the trackpad; the NSScrollView can't be told to ignore them, so we don't pass them on
+- (void)scrollWheel:(NSEvent *)e
+{
+	if (self->noMomentum && [e momentumPhase] != NSEventPhaseNone)
+		return;
+	[super scrollWheel:e];
+}
+
+- (void)resetCursorRects
+{
+	id c;
+
+	c = areaView_cursor(self->goarea);
+	if (c != nil)
+		[self addCursorRect:[self visibleRect] cursor:((NSCursor *) c)];
+}
+
 - (void)updateTrackingAreas
 {
 	[self removeTrackingArea:self->trackingArea];
@@ -76,12 +171,43 @@
 	[self retrack];
 }
 
+// Cocoa has no hover event; we use a delayed perform, restarted on each movement and cancelled by everything else
+// 0.5 seconds is what GTK+ uses for tooltips; Cocoa's tooltip delay isn't public
+- (void)hover
+{
+	areaView_hovered(self->goarea, (intptr_t) self->hoverPoint.x, (intptr_t) self->hoverPoint.y);
+}
+
+- (void)cancelHover
+{
+	[NSObject cancelPreviousPerformRequestsWithTarget:self selector:@selector(hover) object:nil];
+}
+
+- (void)mouseMoved:(NSEvent *)e
+{
+	[self cancelHover];
+	self->hoverPoint = [self convertPoint:[e locationInWindow] fromView:nil];
+	[self performSelector:@selector(hover) withObject:nil afterDelay:0.5];
+	areaView_mouseMoved_mouseDragged(self, e, self->goarea);
+}
+
+- (void)mouseEntered:(NSEvent *)e
+{
+	areaView_mouseCrossed(self->goarea, YES);
+}
+
+- (void)mouseExited:(NSEvent *)e
+{
+	[self cancelHover];
+	areaView_mouseCrossed(self->goarea, NO);
+}
+
 #define event(m, f) \
 	- (void)m:(NSEvent *)e \
 	{ \
+		[self cancelHover]; \
 		f(self, e, self->goarea); \
 	}
-event(mouseMoved, areaView_mouseMoved_mouseDragged)
 event(mouseDragged, areaView_mouseMoved_mouseDragged)
 event(rightMouseDragged, areaView_mouseMoved_mouseDragged)
 event(otherMouseDragged, areaView_mouseMoved_mouseDragged)
@@ -101,6 +227,130 @@ retevent(doKeyDown, areaView_keyDown)
 retevent(doKeyUp, areaView_keyUp)
 retevent(doFlagsChanged, areaView_flagsChanged)
 
+// drag and drop; see drop_darwin.go
+// we only get these if areaSetDropTarget() was called
+
+- (struct xpoint)dropPoint:(id<NSDraggingInfo>)sender
+{
+	NSPoint p;
+	struct xpoint q;
+
+	p = [self convertPoint:[sender draggingLocation] fromView:nil];
+	q.x = (intptr_t) p.x;
+	q.y = (intptr_t) p.y;
+	return q;
+}
+
+// see DropEvent.Operation; the system has already narrowed the mask down to what the modifier keys ask for (Command asks for NSDragOperationGeneric, which we treat as a move)
+- (NSDragOperation)dropOperation:(id<NSDraggingInfo>)sender
+{
+	NSDragOperation mask;
+
+	mask = [sender draggingSourceOperationMask];
+	if ((mask & NSDragOperationCopy) != 0)
+		return NSDragOperationCopy;
+	if ((mask & NSDragOperationMove) != 0)
+		return NSDragOperationMove;
+	if ((mask & NSDragOperationGeneric) != 0)
+		return NSDragOperationGeneric;
+	if ((mask & NSDragOperationLink) != 0)
+		return NSDragOperationLink;
+	return NSDragOperationNone;
+}
+
+- (NSDragOperation)draggingEntered:(id<NSDraggingInfo>)sender
+{
+	NSDragOperation op;
+
+	op = [self dropOperation:sender];
+	if (!areaView_dragEnter(self->goarea, dropFormats(sender), [self dropPoint:sender], (uintptr_t) op))
+		return NSDragOperationNone;
+	return op;
+}
+
+- (NSDragOperation)draggingUpdated:(id<NSDraggingInfo>)sender
+{
+	NSDragOperation op;
+
+	op = [self dropOperation:sender];
+	if (!areaView_dragOver(self->goarea, dropFormats(sender), [self dropPoint:sender], (uintptr_t) op))
+		return NSDragOperationNone;
+	return op;
+}
+
+- (void)draggingExited:(id<NSDraggingInfo>)sender
+{
+	areaView_dragLeave(self->goarea);
+}
+
+- (BOOL)performDragOperation:(id<NSDraggingInfo>)sender
+{
+	NSPasteboard *pb;
+	uintptr_t formats;
+	NSArray *files;
+	NSString *file;
+	NSImage *image;
+
+	pb = [sender draggingPasteboard];
+	formats = dropFormats(sender);
+	// Cocoa doesn't send draggingExited: for a drop; we promise one, though
+	areaView_dragLeave(self->goarea);
+	switch (areaBestDropFormat(formats)) {
+	case dropFormatFiles:
+		files = (NSArray *) [pb propertyListForType:NSFilenamesPboardType];
+		for (file in files)
+			areaView_dropAddFile(self->goarea, (char *) [file UTF8String]);
+		break;
+	case dropFormatText:
+		areaView_dropSetText(self->goarea, (char *) [[pb stringForType:NSPasteboardTypeString] UTF8String]);
+		break;
+	case dropFormatImage:
+		image = [[NSImage alloc] initWithPasteboard:pb];
+		if (image != nil) {
+			dropSetImage(self->goarea, image);
+			[image release];
+		}
+		break;
+	}
+	return areaView_drop(self->goarea, formats, [self dropPoint:sender], (uintptr_t) [self dropOperation:sender]);
+}
+
+- (NSDragOperation)draggingSession:(NSDraggingSession *)session sourceOperationMaskForDraggingContext:(NSDraggingContext)context
+{
+	return self->dragOperations;
+}
+
+- (void)draggingSession:(NSDraggingSession *)session endedAtPoint:(NSPoint)p operation:(NSDragOperation)op
+{
+	areaView_dragEnded(self->goarea, (uintptr_t) op);
+}
+
+// NSFilePromiseProviderDelegate, for FilePromises; see areaStartDrag() below
+// the provider's userInfo is the index of the FilePromise and its name, in that order
+// these run on the main thread, since we don't give the providers an operation queue of their own
+
+- (NSString *)filePromiseProvider:(id)provider fileNameForType:(NSString *)type
+{
+	return (NSString *) [((NSArray *) [provider userInfo]) objectAtIndex:1];
+}
+
+- (void)filePromiseProvider:(id)provider writePromiseToURL:(NSURL *)url completionHandler:(void (^)(NSError *))done
+{
+	NSNumber *i;
+	char *err;
+
+	i = (NSNumber *) [((NSArray *) [provider userInfo]) objectAtIndex:0];
+	err = dragWritePromise((intptr_t) [i integerValue], (char *) [[url path] fileSystemRepresentation]);
+	if (err == NULL) {
+		done(nil);
+		return;
+	}
+	done([NSError errorWithDomain:NSCocoaErrorDomain
+		code:NSFileWriteUnknownError
+		userInfo:[NSDictionary dictionaryWithObject:[NSString stringWithUTF8String:err] forKey:NSLocalizedDescriptionKey]]);
+	free(err);
+}
+
 // seems to be triggered when the user would have finished editing the NSTextField anyway according to the system's rules on that (at least on Mountain Lion)
 - (void)observeValueForKeyPath:(NSString *)keyPath ofObject:(id)object change:(NSDictionary *)change context:(void *)context
 {
@@ -115,16 +365,212 @@ Class getAreaClass(void)
 	return [goAreaView class];
 }
 
+// only the events that say the pen came near the tablet or left it say which end of the pen it is, so we watch for those from the first Area on; there is only one pen, so this is shared by all Areas
+static BOOL penIsEraser = NO;
+
+static void watchPenProximity(void)
+{
+	static id monitor = nil;
+
+	if (monitor != nil)
+		return;
+	monitor = [NSEvent addLocalMonitorForEventsMatchingMask:NSTabletProximityMask handler:^(NSEvent *e) {
+		penIsEraser = [e isEnteringProximity] && [e pointingDeviceType] == NSEraserPointingDevice;
+		return e;
+	}];
+}
+
 id newArea(void *goarea)
 {
 	goAreaView *a;
 
+	watchPenProximity();
 	a = [[goAreaView alloc] initWithFrame:NSZeroRect];
 	a->goarea = goarea;
 	return (id) a;
 }
 
-BOOL drawImage(void *pixels, intptr_t width, intptr_t height, intptr_t stride, intptr_t xdest, intptr_t ydest)
+// DragData.Local is marked by this type, which only says that the Go side has the data; table_darwin.m uses it too
+NSString *const localDragType = @"com.github.andlabs.ui.local";
+
+void areaSetDropTarget(id area)
+{
+	[toNSView(area) registerForDraggedTypes:[NSArray arrayWithObjects:
+		NSFilenamesPboardType,
+		NSPasteboardTypeString,
+		NSPasteboardTypeTIFF,
+		NSPasteboardTypePNG,
+		localDragType,
+		nil]];
+}
+
+// info is the id<NSDraggingInfo>; a drag from this program has a source
+uintptr_t dropFormats(id info)
+{
+	id<NSDraggingInfo> sender = (id<NSDraggingInfo>) info;
+	NSPasteboard *pb = [sender draggingPasteboard];
+	uintptr_t formats = 0;
+
+	if ([[pb types] containsObject:NSFilenamesPboardType])
+		formats |= dropFormatFiles;
+	if ([pb availableTypeFromArray:[NSArray arrayWithObject:NSPasteboardTypeString]] != nil)
+		formats |= dropFormatText;
+	if ([NSImage canInitWithPasteboard:pb])
+		formats |= dropFormatImage;
+	if ([sender draggingSource] != nil && [[pb types] containsObject:localDragType])
+		formats |= dropFormatLocal;
+	return formats;
+}
+
+// we draw the image into a bitmap of our own so we get the exact format image.RGBA uses (alpha-premultiplied RGBA)
+void dropSetImage(void *goarea, id img)
+{
+	NSImage *image = (NSImage *) img;
+	NSBitmapImageRep *bitmap;
+	NSGraphicsContext *context;
+	NSInteger width, height;
+
+	width = (NSInteger) [image size].width;
+	height = (NSInteger) [image size].height;
+	if (width <= 0 || height <= 0)
+		return;
+	bitmap = [[NSBitmapImageRep alloc]
+		initWithBitmapDataPlanes:NULL		// let it allocate the memory
+		pixelsWide:width
+		pixelsHigh:height
+		bitsPerSample:8
+		samplesPerPixel:4
+		hasAlpha:YES
+		isPlanar:NO
+		colorSpaceName:NSDeviceRGBColorSpace		// see drawImage() below
+		bitmapFormat:0
+		bytesPerRow:0
+		bitsPerPixel:32];
+	context = [NSGraphicsContext graphicsContextWithBitmapImageRep:bitmap];
+	[NSGraphicsContext saveGraphicsState];
+	[NSGraphicsContext setCurrentContext:context];
+	[image drawInRect:NSMakeRect(0, 0, (CGFloat) width, (CGFloat) height)
+		fromRect:NSZeroRect
+		operation:NSCompositeCopy
+		fraction:1.0];
+	[context flushGraphics];
+	[NSGraphicsContext restoreGraphicsState];
+	areaView_dropSetImage(goarea, [bitmap bitmapData], fromNSInteger(width), fromNSInteger(height), fromNSInteger([bitmap bytesPerRow]));
+	[bitmap release];
+}
+
+const uintptr_t cNSDragOperationCopy = (uintptr_t) NSDragOperationCopy;
+const uintptr_t cNSDragOperationMove = (uintptr_t) NSDragOperationMove;
+const uintptr_t cNSDragOperationLink = (uintptr_t) NSDragOperationLink;
+const uintptr_t cNSDragOperationGeneric = (uintptr_t) NSDragOperationGeneric;
+
+// the drag images are centered on the mouse, and stacked a bit if there's more than one
+static void addDragItem(NSMutableArray *items, id<NSPasteboardWriting> writer, NSImage *image, NSPoint p)
+{
+	NSDraggingItem *item;
+	NSSize size;
+	CGFloat offset;
+
+	size = [image size];
+	// don't let large images cover the whole screen
+	if (size.width > 128 || size.height > 128) {
+		CGFloat scale;
+
+		scale = 128 / MAX(size.width, size.height);
+		size.width *= scale;
+		size.height *= scale;
+	}
+	offset = 8 * (CGFloat) [items count];
+	item = [[NSDraggingItem alloc] initWithPasteboardWriter:writer];
+	[item setDraggingFrame:NSMakeRect(p.x - size.width / 2 + offset, p.y - size.height / 2 + offset, size.width, size.height)
+		contents:image];
+	[items addObject:item];
+	[item release];
+}
+
+// NSFilePromiseProvider wants a UTI; the file's extension is all we have to go on
+static NSString *promiseFileType(NSString *name)
+{
+	CFStringRef uti;
+
+	if ([[name pathExtension] length] == 0)
+		return (NSString *) kUTTypeData;
+	uti = UTTypeCreatePreferredIdentifierForTag(kUTTagClassFilenameExtension, (CFStringRef) [name pathExtension], kUTTypeData);
+	return [((NSString *) uti) autorelease];
+}
+
+// dataimage and dragimage are +1 NSImages or nil; this releases them
+// NSFilePromiseProvider is new in 10.12, so promises are left out before that
+void areaStartDrag(id area, id e, char **files, intptr_t nfiles, char *text, id dataimage, id dragimage, intptr_t hotx, intptr_t hoty, char **promises, intptr_t npromises, BOOL local, uintptr_t ops)
+{
+	goAreaView *a = (goAreaView *) area;
+	NSMutableArray *items;
+	NSPasteboardItem *pbitem;
+	NSImage *image = (NSImage *) dataimage;
+	NSString *path;
+	NSDraggingItem *item;
+	NSSize size;
+	NSPoint p;
+	intptr_t i;
+	Class providerClass;
+	id provider;
+	NSString *name;
+
+	items = [NSMutableArray new];
+	p = [a convertPoint:[toNSEvent(e) locationInWindow] fromView:nil];
+	// the text, image, and Local go together in one item; each file is its own item, which is what the Finder does
+	if (text != NULL || image != nil || local) {
+		pbitem = [NSPasteboardItem new];
+		if (text != NULL)
+			[pbitem setString:[NSString stringWithUTF8String:text] forType:NSPasteboardTypeString];
+		if (image != nil)
+			[pbitem setData:[image TIFFRepresentation] forType:NSPasteboardTypeTIFF];
+		if (local)
+			[pbitem setString:@"" forType:localDragType];
+		addDragItem(items, pbitem,
+			(image != nil) ? image : [[NSWorkspace sharedWorkspace] iconForFileType:@"txt"],
+			p);
+		[pbitem release];
+	}
+	for (i = 0; i < nfiles; i++) {
+		path = [NSString stringWithUTF8String:files[i]];
+		addDragItem(items, [NSURL fileURLWithPath:path],
+			[[NSWorkspace sharedWorkspace] iconForFile:path],
+			p);
+	}
+	providerClass = NSClassFromString(@"NSFilePromiseProvider");
+	if (providerClass != nil)
+		for (i = 0; i < npromises; i++) {
+			name = [NSString stringWithUTF8String:promises[i]];
+			provider = [[providerClass alloc] initWithFileType:promiseFileType(name) delegate:a];
+			[provider setUserInfo:[NSArray arrayWithObjects:[NSNumber numberWithInteger:((NSInteger) i)], name, nil]];
+			addDragItem(items, provider,
+				[[NSWorkspace sharedWorkspace] iconForFileType:[name pathExtension]],
+				p);
+			[provider release];
+		}
+	// a DragImage replaces all the pictures above with one; the view is flipped, so the frame's origin is its top-left corner
+	if (dragimage != nil) {
+		size = [((NSImage *) dragimage) size];
+		for (i = 0; i < (intptr_t) [items count]; i++) {
+			item = (NSDraggingItem *) [items objectAtIndex:(NSUInteger) i];
+			[item setDraggingFrame:NSMakeRect(p.x - hotx, p.y - hoty, size.width, size.height)
+				contents:((i == 0) ? dragimage : nil)];
+		}
+		[dragimage release];
+	}
+	a->dragOperations = (NSDragOperation) ops;
+	if ([items count] != 0)
+		[a beginDraggingSessionWithItems:items event:toNSEvent(e) source:a];
+	else		// only FilePromises, before 10.12
+		areaView_dragEnded(a->goarea, (uintptr_t) NSDragOperationNone);
+	[items release];
+	if (image != nil)
+		[image release];
+}
+
+// the image is drawn into the destwidth by destheight rectangle at xdest,ydest; this is the image's size except in HiDPI mode, where the image has all the pixels of the backing store
+BOOL drawImage(void *pixels, intptr_t width, intptr_t height, intptr_t stride, intptr_t xdest, intptr_t ydest, intptr_t destwidth, intptr_t destheight)
 {
 	unsigned char *planes[1];			// NSBitmapImageRep wants an array of planes; we have one plane
 	NSBitmapImageRep *bitmap;
@@ -145,7 +591,7 @@ BOOL drawImage(void *pixels, intptr_t width, intptr_t height, intptr_t stride, i
 		bitmapFormat:0		// this is where the flag for placing alpha first would go if alpha came first; the default is alpha last, which is how we're doing things (otherwise the docs say "Color planes are arranged in the standard order—for example, red before green before blue for RGB color."); this is also where the flag for non-premultiplied colors would go if we used it (the default is alpha-premultiplied)
 		bytesPerRow:toNSInteger(stride)
 		bitsPerPixel:32];
-	success = [bitmap drawInRect:NSMakeRect((CGFloat) xdest, (CGFloat) ydest, (CGFloat) width, (CGFloat) height)
+	success = [bitmap drawInRect:NSMakeRect((CGFloat) xdest, (CGFloat) ydest, (CGFloat) destwidth, (CGFloat) destheight)
 		fromRect:NSZeroRect		// draw whole image
 		operation:NSCompositeSourceOver
 		fraction:1.0
@@ -187,6 +633,29 @@ intptr_t clickCount(id e)
 	return fromNSInteger([toNSEvent(e) clickCount]);
 }
 
+// mouse events from a pen are tablet point events underneath; returns NO for any other event
+BOOL penState(id e, struct areaPen *pen)
+{
+	NSEvent *event;
+	NSPoint tilt;
+
+	event = toNSEvent(e);
+	if ([event subtype] != NSTabletPointEventSubtype)
+		return NO;
+	pen->pressure = (double) [event pressure];
+	// these are already -1 to 1, with positive y toward the user
+	tilt = [event tilt];
+	pen->tiltX = (double) tilt.x;
+	pen->tiltY = (double) tilt.y;
+	pen->eraser = penIsEraser;
+	return YES;
+}
+
+double doubleClickInterval(void)
+{
+	return (double) [NSEvent doubleClickInterval];
+}
+
 uintptr_t pressedMouseButtons(void)
 {
 	return fromNSUInteger([NSEvent pressedMouseButtons]);
@@ -197,6 +666,24 @@ uintptr_t keyCode(id e)
 	return (uintptr_t) ([toNSEvent(e) keyCode]);
 }
 
+// -[e characters] accounts for dead keys (it's empty for the dead key itself) and the keyboard layout
+// -[e charactersIgnoringModifiers] would ignore Shift too, which we don't want
+uint32_t keyCharacter(id e)
+{
+	NSString *s;
+	unichar c, d;
+
+	s = [toNSEvent(e) characters];
+	if ([s length] == 0)
+		return 0;
+	c = [s characterAtIndex:0];
+	if (CFStringIsSurrogateHighCharacter(c) && [s length] > 1) {
+		d = [s characterAtIndex:1];
+		return (uint32_t) CFStringGetLongCharacterForSurrogatePair(c, d);
+	}
+	return (uint32_t) c;
+}
+
 void areaRepaint(id view, struct xrect r)
 {
 	NSRect s;
@@ -213,6 +700,69 @@ void areaRepaintAll(id view)
 	[toNSView(view) display];
 }
 
+void areaSetKinetic(id area, id scrollview, BOOL kinetic)
+{
+	NSScrollElasticity elasticity;
+
+	elasticity = NSScrollElasticityAutomatic;
+	if (!kinetic)
+		elasticity = NSScrollElasticityNone;
+	[((NSScrollView *) scrollview) setHorizontalScrollElasticity:elasticity];
+	[((NSScrollView *) scrollview) setVerticalScrollElasticity:elasticity];
+	((goAreaView *) area)->noMomentum = !kinetic;
+}
+
+// before the view is in a window, go by the main screen, which is where the window will most likely show up
+double areaPixelRatio(id area)
+{
+	NSWindow *w;
+
+	w = [toNSView(area) window];
+	if (w != nil)
+		return (double) [w backingScaleFactor];
+	return (double) [[NSScreen mainScreen] backingScaleFactor];
+}
+
+void areaResetCursorRects(id area)
+{
+	[[toNSView(area) window] invalidateCursorRectsForView:toNSView(area)];
+}
+
+void areaSetFocus(id area)
+{
+	[[toNSView(area) window] makeFirstResponder:toNSView(area)];
+}
+
+// the NSClipView's bounds origin is the scroll position; it has to be told to send notifications when this changes
+void areaWatchScroll(id area)
+{
+	NSClipView *cv;
+
+	cv = [[toNSView(area) enclosingScrollView] contentView];
+	[cv setPostsBoundsChangedNotifications:YES];
+	[[NSNotificationCenter defaultCenter] addObserver:toNSView(area)
+		selector:@selector(boundsChanged:)
+		name:NSViewBoundsDidChangeNotification
+		object:cv];
+}
+
+// since the view is flipped, these are in the same coordinates as the rest of Area
+void areaScrollTo(id area, intptr_t x, intptr_t y)
+{
+	[toNSView(area) scrollPoint:NSMakePoint((CGFloat) x, (CGFloat) y)];
+}
+
+struct xpoint areaScrollPos(id area)
+{
+	NSRect r;
+	struct xpoint p;
+
+	r = [toNSView(area) visibleRect];
+	p.x = (intptr_t) r.origin.x;
+	p.y = (intptr_t) r.origin.y;
+	return p;
+}
+
 void areaSetTextField(id area, id textfield)
 {
 	goAreaView *a = (goAreaView *) area;
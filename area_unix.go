@@ -7,6 +7,7 @@ package ui
 import (
 	"fmt"
 	"image"
+	"time"
 	"unsafe"
 )
 
@@ -21,6 +22,14 @@ import (
 // extern gboolean our_area_enterleave_notify_event_callback(GtkWidget *, GdkEvent *, gpointer);
 // extern gboolean our_area_key_press_event_callback(GtkWidget *, GdkEvent *, gpointer);
 // extern gboolean our_area_key_release_event_callback(GtkWidget *, GdkEvent *, gpointer);
+// extern gboolean our_area_focus_in_event_callback(GtkWidget *, GdkEvent *, gpointer);
+// extern gboolean our_area_focus_out_event_callback(GtkWidget *, GdkEvent *, gpointer);
+// extern gboolean our_area_hover_callback(gpointer);
+// extern void our_area_realize_callback(GtkWidget *, gpointer);
+// extern void our_area_scrolled_callback(GtkAdjustment *, gpointer);
+// extern gboolean our_area_query_tooltip_callback(GtkWidget *, gint, gint, gboolean, GtkTooltip *, gpointer);
+// extern void our_area_scale_factor_callback(GObject *, GParamSpec *, gpointer);
+// extern void our_area_imcontext_commit_callback(GtkIMContext *, gchar *, gpointer);
 // /* because cgo doesn't like ... */
 // static inline void gtkGetDoubleClickSettings(GtkSettings *settings, gint *maxTime, gint *maxDistance)
 // {
@@ -45,6 +54,28 @@ type area struct {
 	textfieldy    int
 	textfielddone *event
 	inmenu        bool
+
+	// for drag and drop; see drop_unix.go
+	dragging    bool
+	droppos     image.Point
+	dropformats DropFormats
+
+	// for StartDrag(); mouseevent is only valid during Mouse()
+	mouseevent  *C.GdkEvent
+	mousebutton uint
+	drag        *dragSource
+
+	// GDK has no GdkModifierType bits for buttons 8 and 9, so we keep track of them ourselves for Held; see finishMouseEvent()
+	heldextra [2]bool
+
+	// for AreaHoverHandler; GTK+ has no hover event, so we use a timer
+	hovertimer C.guint
+	hoverpos   image.Point
+
+	// for KeyEvent.Rune; GtkIMContextSimple composes dead keys and Compose key sequences for us
+	// imcommit is set by our_area_imcontext_commit_callback(), which runs inside gtk_im_context_filter_keypress()
+	imcontext *C.GtkIMContext
+	imcommit  rune
 }
 
 func newArea(ab *areabase) Area {
@@ -64,8 +95,19 @@ func newArea(ab *areabase) Area {
 		textfieldw:    textfieldw,
 		textfield:     (*C.GtkEntry)(unsafe.Pointer(textfieldw)),
 		textfielddone: newEvent(),
+		imcontext:     C.gtk_im_context_simple_new(),
 	}
 	a.fpreferredSize = a.xpreferredSize
+	destroyscroller := a.fdestroy
+	a.fdestroy = func() {
+		destroyscroller()
+		C.g_object_unref(C.gpointer(unsafe.Pointer(a.imcontext)))
+	}
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(a.imcontext)),
+		"commit",
+		area_imcontext_commit_callback,
+		C.gpointer(unsafe.Pointer(a)))
 	for _, c := range areaCallbacks {
 		g_signal_connect(
 			C.gpointer(unsafe.Pointer(a.drawingarea)),
@@ -73,7 +115,19 @@ func newArea(ab *areabase) Area {
 			c.callback,
 			C.gpointer(unsafe.Pointer(a)))
 	}
+	// for AreaTooltipHandler; GTK+ asks us for the tooltip each time the mouse moves
+	C.gtk_widget_set_has_tooltip(widget, C.TRUE)
 	a.SetSize(a.width, a.height)
+	a.setDropTarget()
+	a.drag = newDragSource(widget)
+	// the viewport uses the GtkScrolledWindow's adjustments, so these see all scrolling
+	for _, adj := range []*C.GtkAdjustment{a.hadjustment(), a.vadjustment()} {
+		g_signal_connect(
+			C.gpointer(unsafe.Pointer(adj)),
+			"value-changed",
+			area_scrolled_callback,
+			C.gpointer(unsafe.Pointer(a)))
+	}
 	C.gtk_overlay_add_overlay(a.scroller.overlayoverlay, a.textfieldw)
 	g_signal_connect(
 		C.gpointer(unsafe.Pointer(a.scroller.overlayoverlay)),
@@ -144,6 +198,106 @@ func (a *area) OnTextFieldDismissed(f func()) {
 	a.textfielddone.set(f)
 }
 
+func (a *area) SetKinetic(kinetic bool) {
+	a.kinetic = kinetic
+	C.gtk_scrolled_window_set_kinetic_scrolling(a.scrollwindow, togbool(kinetic))
+}
+
+// GTK+ scale factors are whole numbers
+func (a *area) PixelRatio() float64 {
+	return float64(C.gtk_widget_get_scale_factor(a.widget))
+}
+
+func (a *area) SetHiDPI(hidpi bool) {
+	a.hidpi = hidpi
+	a.RepaintAll()
+}
+
+//export our_area_scale_factor_callback
+func our_area_scale_factor_callback(object *C.GObject, pspec *C.GParamSpec, data C.gpointer) {
+	a := (*area)(unsafe.Pointer(data))
+	if a.pixelRatioChanged(a.PixelRatio()) {
+		a.RepaintAll()
+	}
+}
+
+var area_scale_factor_callback = C.GCallback(C.our_area_scale_factor_callback)
+
+func (a *area) SetCursor(c *Cursor) {
+	a.setCursor(c, a.showCursor)
+}
+
+func (a *area) showCursor() {
+	w := C.gtk_widget_get_window(a.widget)
+	if w == nil { // not realized yet; our_area_realize_callback() will do it
+		return
+	}
+	C.setWindowCursor(w, a.cursor.current())
+}
+
+//export our_area_realize_callback
+func our_area_realize_callback(widget *C.GtkWidget, data C.gpointer) {
+	a := (*area)(unsafe.Pointer(data))
+	a.showCursor()
+	C.gtk_im_context_set_client_window(a.imcontext, C.gtk_widget_get_window(widget))
+}
+
+var area_realize_callback = C.GCallback(C.our_area_realize_callback)
+
+func (a *area) SetFocus() {
+	C.gtk_widget_grab_focus(a.widget)
+}
+
+func (a *area) hadjustment() *C.GtkAdjustment {
+	return C.gtk_scrolled_window_get_hadjustment(a.scrollwindow)
+}
+
+func (a *area) vadjustment() *C.GtkAdjustment {
+	return C.gtk_scrolled_window_get_vadjustment(a.scrollwindow)
+}
+
+func (a *area) ScrollTo(pos image.Point) {
+	// gtk_adjustment_set_value() clamps for us
+	C.gtk_adjustment_set_value(a.hadjustment(), C.gdouble(pos.X))
+	C.gtk_adjustment_set_value(a.vadjustment(), C.gdouble(pos.Y))
+}
+
+func (a *area) ScrollPos() image.Point {
+	return image.Pt(
+		int(C.gtk_adjustment_get_value(a.hadjustment())),
+		int(C.gtk_adjustment_get_value(a.vadjustment())))
+}
+
+func (a *area) ShowPopupMenu(m PopupMenu, pos image.Point) {
+	m.popup(a, pos)
+}
+
+//export our_area_scrolled_callback
+func our_area_scrolled_callback(adj *C.GtkAdjustment, data C.gpointer) {
+	a := (*area)(unsafe.Pointer(data))
+	a.scrolled(a.ScrollPos())
+}
+
+var area_scrolled_callback = C.GCallback(C.our_area_scrolled_callback)
+
+//export our_area_query_tooltip_callback
+func our_area_query_tooltip_callback(widget *C.GtkWidget, x C.gint, y C.gint, keyboard C.gboolean, tooltip *C.GtkTooltip, data C.gpointer) C.gboolean {
+	a := (*area)(unsafe.Pointer(data))
+	text := a.tooltip
+	if keyboard == C.FALSE { // the coordinates are meaningless for keyboard tooltips
+		text, _ = a.tooltipAt(image.Pt(int(x), int(y)), a.tooltip)
+	}
+	if text == "" {
+		return C.FALSE
+	}
+	ctext := togstr(text)
+	defer freegstr(ctext)
+	C.gtk_tooltip_set_text(tooltip, ctext)
+	return C.TRUE
+}
+
+var area_query_tooltip_callback = C.GCallback(C.our_area_query_tooltip_callback)
+
 //export our_area_get_child_position_callback
 func our_area_get_child_position_callback(overlay *C.GtkOverlay, widget *C.GtkWidget, rect *C.GdkRectangle, data C.gpointer) C.gboolean {
 	var nat C.GtkRequisition
@@ -192,6 +346,11 @@ var areaCallbacks = []struct {
 	{"leave-notify-event", area_enterleave_notify_event_callback},
 	{"key-press-event", area_key_press_event_callback},
 	{"key-release-event", area_key_release_event_callback},
+	{"focus-in-event", area_focus_in_event_callback},
+	{"focus-out-event", area_focus_out_event_callback},
+	{"realize", area_realize_callback},
+	{"query-tooltip", area_query_tooltip_callback},
+	{"notify::scale-factor", area_scale_factor_callback},
 }
 
 //export our_area_draw_callback
@@ -209,7 +368,12 @@ func our_area_draw_callback(widget *C.GtkWidget, cr *C.cairo_t, data C.gpointer)
 	if cliprect.Empty() { // no intersection; nothing to paint
 		return C.FALSE // signals handled without stopping the event chain (thanks to desrt again)
 	}
-	i := a.handler.Paint(cliprect)
+	var region []image.Rectangle
+	if a.wantsRegion() {
+		region = cairoClipRegion(cr)
+	}
+	ratio := a.PixelRatio()
+	i := a.paint(cliprect, region, ratio)
 	surface := C.cairo_image_surface_create(
 		C.CAIRO_FORMAT_ARGB32, // alpha-premultiplied; native byte order
 		C.int(i.Rect.Dx()),
@@ -223,6 +387,10 @@ func our_area_draw_callback(widget *C.GtkWidget, cr *C.cairo_t, data C.gpointer)
 	toARGB(i, uintptr(unsafe.Pointer(C.cairo_image_surface_get_data(surface))),
 		int(C.cairo_image_surface_get_stride(surface)), false) // not NRGBA
 	C.cairo_surface_mark_dirty(surface)
+	if a.hidpi {
+		// this makes the surface its size in Area pixels, so it goes in the same place as usual, only with all its pixels
+		C.cairo_surface_set_device_scale(surface, C.double(ratio), C.double(ratio))
+	}
 	C.cairo_set_source_surface(cr,
 		surface,
 		x0, y0) // point on cairo_t where we want to draw (thanks Company in irc.gimp.net/#gtk+)
@@ -236,6 +404,23 @@ func our_area_draw_callback(widget *C.GtkWidget, cr *C.cairo_t, data C.gpointer)
 
 var area_draw_callback = C.GCallback(C.our_area_draw_callback)
 
+// the clip of the cairo_t GTK+ gives us is the region that needs drawing; cairo_clip_extents() is only its bounding box
+// this returns nil if the clip can't be represented as rectangles, which only happens if something transformed cr
+func cairoClipRegion(cr *C.cairo_t) []image.Rectangle {
+	list := C.cairo_copy_clip_rectangle_list(cr)
+	defer C.cairo_rectangle_list_destroy(list)
+	if list.status != C.CAIRO_STATUS_SUCCESS {
+		return nil
+	}
+	n := int(list.num_rectangles)
+	rects := (*[1 << 16]C.cairo_rectangle_t)(unsafe.Pointer(list.rectangles))[:n:n]
+	region := make([]image.Rectangle, n)
+	for i, r := range rects {
+		region[i] = image.Rect(int(r.x), int(r.y), int(r.x+r.width), int(r.y+r.height))
+	}
+	return region
+}
+
 func translateModifiers(state C.guint, window *C.GdkWindow) C.guint {
 	// GDK doesn't initialize the modifier flags fully; we have to explicitly tell it to (thanks to Daniel_S and daniels (two different people) in irc.gimp.net/#gtk+)
 	C.gdk_keymap_add_virtual_modifiers(
@@ -264,7 +449,7 @@ func makeModifiers(state C.guint) (m Modifiers) {
 }
 
 // shared code for finishing up and sending a mouse event
-func finishMouseEvent(widget *C.GtkWidget, data C.gpointer, me MouseEvent, mb uint, x C.gdouble, y C.gdouble, state C.guint, gdkwindow *C.GdkWindow) {
+func finishMouseEvent(widget *C.GtkWidget, event *C.GdkEvent, data C.gpointer, me MouseEvent, mb uint, x C.gdouble, y C.gdouble, state C.guint, gdkwindow *C.GdkWindow) {
 	var areawidth, areaheight C.gint
 
 	// on GTK+, mouse buttons 4-7 are for scrolling; if we got here, that's a mistake
@@ -272,6 +457,10 @@ func finishMouseEvent(widget *C.GtkWidget, data C.gpointer, me MouseEvent, mb ui
 		return
 	}
 	a := (*area)(unsafe.Pointer(data))
+	// this has to happen before the check for being outside the Area below, since the release can come from anywhere
+	if mb == 8 || mb == 9 {
+		a.heldextra[mb-8] = me.Down != 0
+	}
 	state = translateModifiers(state, gdkwindow)
 	me.Modifiers = makeModifiers(state)
 	// the mb != # checks exclude the Up/Down button from Held
@@ -286,9 +475,16 @@ func finishMouseEvent(widget *C.GtkWidget, data C.gpointer, me MouseEvent, mb ui
 	}
 	// don't check GDK_BUTTON4_MASK or GDK_BUTTON5_MASK because those are for the scrolling buttons mentioned above
 	// GDK expressly does not support any more buttons in the GdkModifierType; see https://git.gnome.org/browse/gtk+/tree/gdk/x11/gdkdevice-xi2.c#n763 (thanks mclasen in irc.gimp.net/#gtk+)
+	// so buttons 8 and 9 (our 4 and 5) come from heldextra instead
+	for i, held := range a.heldextra {
+		if held && mb != uint(i)+8 {
+			me.Held = append(me.Held, uint(i)+4)
+		}
+	}
 	me.Pos = image.Pt(int(x), int(y))
 	C.gtk_widget_get_size_request(widget, &areawidth, &areaheight)
-	if !me.Pos.In(image.Rect(0, 0, int(areawidth), int(areaheight))) { // outside the actual Area; no event
+	// outside the actual Area, we only get events from the implicit grab GDK takes when a button is pressed, and we pass those on (see MouseEvent)
+	if !me.Pos.In(image.Rect(0, 0, int(areawidth), int(areaheight))) && (me.Down != 0 || me.Up == 0 && len(me.Held) == 0) {
 		return
 	}
 	// and finally, if the button ID >= 8, continue counting from 4, as above and as in the MouseEvent spec
@@ -298,7 +494,49 @@ func finishMouseEvent(widget *C.GtkWidget, data C.gpointer, me MouseEvent, mb ui
 	if me.Up >= 8 {
 		me.Up -= 4
 	}
-	a.handler.Mouse(me)
+	// StartDrag() needs the GDK button number and the event itself
+	a.mousebutton = 0
+	if me.Down != 0 {
+		a.mousebutton = mb
+	} else if len(me.Held) != 0 {
+		a.mousebutton = me.Held[0]
+		if a.mousebutton >= 4 { // back to the GDK button number
+			a.mousebutton += 4
+		}
+	}
+	a.mouseevent = event
+	me.Timestamp = eventTime()
+	a.mouse(me, penState(event))
+	a.mouseevent = nil
+}
+
+// GTK+ 3 gives every widget the axes of the device an event came from; we only need to see if it's a pen
+// returns nil if event didn't come from a pen
+func penState(event *C.GdkEvent) *PenState {
+	var v C.gdouble
+
+	device := C.gdk_event_get_source_device(event)
+	if device == nil {
+		return nil
+	}
+	source := C.gdk_device_get_source(device)
+	if source != C.GDK_SOURCE_PEN && source != C.GDK_SOURCE_ERASER {
+		return nil
+	}
+	pen := &PenState{
+		Eraser: source == C.GDK_SOURCE_ERASER,
+	}
+	if C.gdk_event_get_axis(event, C.GDK_AXIS_PRESSURE, &v) != C.FALSE {
+		pen.Pressure = float64(v)
+	}
+	// these are already -1 to 1
+	if C.gdk_event_get_axis(event, C.GDK_AXIS_XTILT, &v) != C.FALSE {
+		pen.TiltX = float64(v)
+	}
+	if C.gdk_event_get_axis(event, C.GDK_AXIS_YTILT, &v) != C.FALSE {
+		pen.TiltY = float64(v)
+	}
+	return pen
 }
 
 // convenience name to make our intent clear
@@ -337,12 +575,30 @@ func our_area_button_press_event_callback(widget *C.GtkWidget, event *C.GdkEvent
 		uintptr(e.time), uintptr(maxTime),
 		int(maxDistance), int(maxDistance))
 
-	finishMouseEvent(widget, data, me, me.Down, e.x, e.y, e.state, e.window)
+	a.stopHover()
+	finishMouseEvent(widget, event, data, me, me.Down, e.x, e.y, e.state, e.window)
 	return continueEventChain
 }
 
 var area_button_press_event_callback = C.GCallback(C.our_area_button_press_event_callback)
 
+// these use the default screen's settings; an Area uses its own screen's, but nobody sets those differently
+func doubleClickTime() time.Duration {
+	var maxTime C.gint
+	var maxDistance C.gint
+
+	C.gtkGetDoubleClickSettings(C.gtk_settings_get_default(), &maxTime, &maxDistance)
+	return time.Duration(maxTime) * time.Millisecond
+}
+
+func doubleClickDistance() int {
+	var maxTime C.gint
+	var maxDistance C.gint
+
+	C.gtkGetDoubleClickSettings(C.gtk_settings_get_default(), &maxTime, &maxDistance)
+	return int(maxDistance)
+}
+
 //export our_area_button_release_event_callback
 func our_area_button_release_event_callback(widget *C.GtkWidget, event *C.GdkEvent, data C.gpointer) C.gboolean {
 	e := (*C.GdkEventButton)(unsafe.Pointer(event))
@@ -350,7 +606,7 @@ func our_area_button_release_event_callback(widget *C.GtkWidget, event *C.GdkEve
 		// GDK button ID == our button ID with some exceptions taken care of by finishMouseEvent()
 		Up: uint(e.button),
 	}
-	finishMouseEvent(widget, data, me, me.Up, e.x, e.y, e.state, e.window)
+	finishMouseEvent(widget, event, data, me, me.Up, e.x, e.y, e.state, e.window)
 	return continueEventChain
 }
 
@@ -359,32 +615,64 @@ var area_button_release_event_callback = C.GCallback(C.our_area_button_release_e
 //export our_area_motion_notify_event_callback
 func our_area_motion_notify_event_callback(widget *C.GtkWidget, event *C.GdkEvent, data C.gpointer) C.gboolean {
 	e := (*C.GdkEventMotion)(unsafe.Pointer(event))
+	a := (*area)(unsafe.Pointer(data))
 	me := MouseEvent{}
-	finishMouseEvent(widget, data, me, 0, e.x, e.y, e.state, e.window)
+	a.stopHover()
+	if (e.state&(C.GDK_BUTTON1_MASK|C.GDK_BUTTON2_MASK|C.GDK_BUTTON3_MASK)) == 0 && !a.heldextra[0] && !a.heldextra[1] {
+		a.startHover(image.Pt(int(e.x), int(e.y)))
+	}
+	finishMouseEvent(widget, event, data, me, 0, e.x, e.y, e.state, e.window)
 	return continueEventChain
 }
 
+// this is what GTK+ uses for tooltips
+const areaHoverTime = 500
+
+func (a *area) startHover(pos image.Point) {
+	a.hoverpos = pos
+	a.hovertimer = C.g_timeout_add(areaHoverTime, C.GSourceFunc(C.our_area_hover_callback), C.gpointer(unsafe.Pointer(a)))
+}
+
+func (a *area) stopHover() {
+	if a.hovertimer != 0 {
+		C.g_source_remove(a.hovertimer)
+		a.hovertimer = 0
+	}
+}
+
+//export our_area_hover_callback
+func our_area_hover_callback(data C.gpointer) C.gboolean {
+	a := (*area)(unsafe.Pointer(data))
+	a.hovertimer = 0
+	a.mouseHovered(a.hoverpos)
+	return C.FALSE // only once
+}
+
 var area_motion_notify_event_callback = C.GCallback(C.our_area_motion_notify_event_callback)
 
 // we want switching away from the control to reset the double-click counter, like with WM_ACTIVATE on Windows
 // according to tristan in irc.gimp.net/#gtk+, doing this on enter-notify-event and leave-notify-event is correct (and it seems to be true in my own tests; plus the events DO get sent when switching programs with the keyboard (just pointing that out))
-// differentiating between enter-notify-event and leave-notify-event is unimportant
+// differentiating between enter-notify-event and leave-notify-event is unimportant for that, but not for AreaHoverHandler
 
 //export our_area_enterleave_notify_event_callback
 func our_area_enterleave_notify_event_callback(widget *C.GtkWidget, event *C.GdkEvent, data C.gpointer) C.gboolean {
+	e := (*C.GdkEventCrossing)(unsafe.Pointer(event))
 	a := (*area)(unsafe.Pointer(data))
 	a.clickCounter.reset()
+	if e._type == C.GDK_ENTER_NOTIFY {
+		a.mouseCrossed(true)
+	} else {
+		a.stopHover()
+		a.mouseCrossed(false)
+	}
 	return continueEventChain
 }
 
 var area_enterleave_notify_event_callback = C.GCallback(C.our_area_enterleave_notify_event_callback)
 
 // shared code for doing a key event
-func doKeyEvent(widget *C.GtkWidget, event *C.GdkEvent, data C.gpointer, up bool) bool {
-	var ke KeyEvent
-
-	e := (*C.GdkEventKey)(unsafe.Pointer(event))
-	a := (*area)(unsafe.Pointer(data))
+// this is also used by Window.RegisterShortcut(); see window_unix.go
+func toKeyEvent(e *C.GdkEventKey, up bool) (ke KeyEvent, ok bool) {
 	keyval := e.keyval
 	// get modifiers now in case a modifier was pressed
 	state := translateModifiers(e.state, e.window)
@@ -401,12 +689,45 @@ func doKeyEvent(widget *C.GtkWidget, event *C.GdkEvent, data C.gpointer, up bool
 		ke.Key = xke.Key
 		ke.ExtKey = xke.ExtKey
 	} else { // no match
-		return false
+		return ke, false
 	}
 	ke.Up = up
-	return a.handler.Key(ke)
+	if !up {
+		ke.Rune = keyRune(rune(C.gdk_keyval_to_unicode(keyval)))
+	}
+	ke.Scancode = uintptr(e.hardware_keycode)
+	ke.Timestamp = eventTime()
+	return ke, true
+}
+
+func doKeyEvent(widget *C.GtkWidget, event *C.GdkEvent, data C.gpointer, up bool) bool {
+	a := (*area)(unsafe.Pointer(data))
+	e := (*C.GdkEventKey)(unsafe.Pointer(event))
+	// the input method sees releases too, as it needs them for some sequences
+	a.imcommit = 0
+	filtered := fromgbool(C.gtk_im_context_filter_keypress(a.imcontext, e))
+	ke, ok := toKeyEvent(e, up)
+	if !ok {
+		return false
+	}
+	if !up && filtered {
+		// either the key press finished a character, or it started or continued a sequence, in which case it types nothing yet
+		ke.Rune = a.imcommit
+	}
+	return a.key(ke)
+}
+
+//export our_area_imcontext_commit_callback
+func our_area_imcontext_commit_callback(context *C.GtkIMContext, str *C.gchar, data C.gpointer) {
+	a := (*area)(unsafe.Pointer(data))
+	// a sequence that doesn't compose to anything can commit more than one character; KeyEvent.Rune only has room for the last one, which is the one this key press typed
+	for _, r := range fromgstr(str) {
+		a.imcommit = keyRune(r)
+	}
 }
 
+var area_imcontext_commit_callback = C.GCallback(C.our_area_imcontext_commit_callback)
+
 //export our_area_key_press_event_callback
 func our_area_key_press_event_callback(widget *C.GtkWidget, event *C.GdkEvent, data C.gpointer) C.gboolean {
 	if doKeyEvent(widget, event, data, false) == true {
@@ -427,6 +748,30 @@ func our_area_key_release_event_callback(widget *C.GtkWidget, event *C.GdkEvent,
 
 var area_key_release_event_callback = C.GCallback(C.our_area_key_release_event_callback)
 
+//export our_area_focus_in_event_callback
+func our_area_focus_in_event_callback(widget *C.GtkWidget, event *C.GdkEvent, data C.gpointer) C.gboolean {
+	a := (*area)(unsafe.Pointer(data))
+	C.gtk_im_context_focus_in(a.imcontext)
+	a.focusChanged(true)
+	return continueEventChain
+}
+
+var area_focus_in_event_callback = C.GCallback(C.our_area_focus_in_event_callback)
+
+//export our_area_focus_out_event_callback
+func our_area_focus_out_event_callback(widget *C.GtkWidget, event *C.GdkEvent, data C.gpointer) C.gboolean {
+	a := (*area)(unsafe.Pointer(data))
+	// if the window lost focus with button 8 or 9 held, we may never see it released
+	a.heldextra = [2]bool{}
+	// and a half-typed dead key sequence shouldn't carry over to when the Area gets the focus back
+	C.gtk_im_context_focus_out(a.imcontext)
+	C.gtk_im_context_reset(a.imcontext)
+	a.focusChanged(false)
+	return continueEventChain
+}
+
+var area_focus_out_event_callback = C.GCallback(C.our_area_focus_out_event_callback)
+
 var extkeys = map[C.guint]ExtKey{
 	C.GDK_KEY_Escape:    Escape,
 	C.GDK_KEY_Insert:    Insert,
@@ -457,6 +802,20 @@ var extkeys = map[C.guint]ExtKey{
 	C.GDK_KEY_KP_Subtract: NSubtract,
 	C.GDK_KEY_KP_Multiply: NMultiply,
 	C.GDK_KEY_KP_Divide:   NDivide,
+	C.GDK_KEY_Print:       PrintScreen,
+	C.GDK_KEY_Sys_Req:     PrintScreen, // Alt+Print Screen
+	C.GDK_KEY_Pause:       Pause,
+	C.GDK_KEY_Break:       Pause, // Ctrl+Pause
+	C.GDK_KEY_Menu:        Menu,
+	// these are the XF86 keysyms; on most keyboards Play/Pause is one key that sends AudioPlay
+	C.GDK_KEY_AudioMute:        VolumeMute,
+	C.GDK_KEY_AudioLowerVolume: VolumeDown,
+	C.GDK_KEY_AudioRaiseVolume: VolumeUp,
+	C.GDK_KEY_AudioPlay:        MediaPlayPause,
+	C.GDK_KEY_AudioPause:       MediaPlayPause,
+	C.GDK_KEY_AudioStop:        MediaStop,
+	C.GDK_KEY_AudioNext:        MediaNext,
+	C.GDK_KEY_AudioPrev:        MediaPrevious,
 }
 
 // sanity check
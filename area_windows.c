@@ -41,6 +41,9 @@ static void paintArea(HWND hwnd, void *data)
 	void *i;
 	intptr_t dx, dy;
 	int hscroll, vscroll;
+	HRGN rgn;
+	RGNDATA *rgndata;
+	DWORD rgnsize;
 
 	// FALSE here indicates don't send WM_ERASEBKGND
 	if (GetUpdateRect(hwnd, &xrect, FALSE) == 0)
@@ -48,6 +51,27 @@ static void paintArea(HWND hwnd, void *data)
 
 	getScrollPos(hwnd, &hscroll, &vscroll);
 
+	// the update rect is only the bounding box of the update region; get the region too, for AreaRegionHandler
+	// this has to happen before BeginPaint(), which empties the update region
+	rgndata = NULL;
+	if (areaWantsRegion(data)) {
+		rgn = CreateRectRgn(0, 0, 0, 0);
+		if (rgn == NULL)
+			xpanic("error creating region to get Area update region", GetLastError());
+		if (GetUpdateRgn(hwnd, rgn, FALSE) == ERROR)
+			xpanic("error getting Area update region", GetLastError());
+		rgnsize = GetRegionData(rgn, 0, NULL);
+		if (rgnsize == 0)
+			xpanic("error getting size of Area update region data", GetLastError());
+		rgndata = (RGNDATA *) malloc(rgnsize);
+		if (rgndata == NULL)
+			xpanic("memory exhausted allocating Area update region data", GetLastError());
+		if (GetRegionData(rgn, rgnsize, rgndata) == 0)
+			xpanic("error getting Area update region data", GetLastError());
+		if (DeleteObject(rgn) == 0)
+			xpanic("error deleting region used to get Area update region", GetLastError());
+	}
+
 	hdc = BeginPaint(hwnd, &ps);
 	if (hdc == NULL)
 		xpanic("error beginning Area repaint", GetLastError());
@@ -75,7 +99,11 @@ static void paintArea(HWND hwnd, void *data)
 	if (FillRect(rdc, &rrect, areaBackgroundBrush) == 0)
 		xpanic("error filling off-screen rendering bitmap with the system background color", GetLastError());
 
-	i = doPaint(&xrect, hscroll, vscroll, data, &dx, &dy);
+	if (rgndata != NULL) {
+		i = doPaint(&xrect, (RECT *) (rgndata->Buffer), rgndata->rdh.nCount, hscroll, vscroll, data, &dx, &dy);
+		free(rgndata);
+	} else
+		i = doPaint(&xrect, NULL, 0, hscroll, vscroll, data, &dx, &dy);
 	if (i == NULL)			// cliprect empty
 		goto nobitmap;		// we need to blit the background no matter what
 
@@ -160,71 +188,45 @@ static SIZE getAreaControlSize(HWND hwnd)
 	return size;
 }
 
-static void scrollArea(HWND hwnd, void *data, WPARAM wParam, int which)
+static void getScrollPageAndMax(HWND hwnd, void *data, int which, LONG *pagesize, LONG *maxsize)
 {
-	SCROLLINFO si;
 	SIZE size;
-	LONG cwid, cht;
-	LONG pagesize, maxsize;
-	LONG newpos;
-	LONG delta;
-	LONG dx, dy;
 
 	size = getAreaControlSize(hwnd);
-	cwid = size.cx;
-	cht = size.cy;
 	if (which == SB_HORZ) {
-		pagesize = cwid;
-		maxsize = areaWidthLONG(data);
+		*pagesize = size.cx;
+		*maxsize = areaWidthLONG(data);
 	} else if (which == SB_VERT) {
-		pagesize = cht;
-		maxsize = areaHeightLONG(data);
+		*pagesize = size.cy;
+		*maxsize = areaHeightLONG(data);
 	} else
-		xpanic("invalid which sent to scrollArea()", 0);
+		xpanic("invalid which sent to getScrollPageAndMax()", 0);
+}
+
+// this is also used by Area.ScrollTo() via msgAreaScrollTo
+static void scrollAreaTo(HWND hwnd, void *data, int which, LONG newpos)
+{
+	SCROLLINFO si;
+	LONG pagesize, maxsize;
+	LONG delta;
+	LONG dx, dy;
+
+	getScrollPageAndMax(hwnd, data, which, &pagesize, &maxsize);
 
 	ZeroMemory(&si, sizeof (SCROLLINFO));
 	si.cbSize = sizeof (SCROLLINFO);
-	si.fMask = SIF_POS | SIF_TRACKPOS;
+	si.fMask = SIF_POS;
 	if (GetScrollInfo(hwnd, which, &si) == 0)
 		xpanic("error getting current scroll position for scrolling", GetLastError());
 
-	newpos = (LONG) si.nPos;
-	switch (LOWORD(wParam)) {
-	case SB_LEFT:			// also SB_TOP; C won't let me have both (C89 §6.6.4.2; C99 §6.8.4.2)
-		newpos = 0;
-		break;
-	case SB_RIGHT:		// also SB_BOTTOM
-		// see comment in adjustAreaScrollbars() below
-		newpos = maxsize - pagesize;
-		break;
-	case SB_LINELEFT:		// also SB_LINEUP
-		newpos--;
-		break;
-	case SB_LINERIGHT:		// also SB_LINEDOWN
-		newpos++;
-		break;
-	case SB_PAGELEFT:		// also SB_PAGEUP
-		newpos -= pagesize;
-		break;
-	case SB_PAGERIGHT:	// also SB_PAGEDOWN
-		newpos += pagesize;
-		break;
-	case SB_THUMBPOSITION:
-		// raymond chen says to just set the newpos to the SCROLLINFO nPos for this message; see http://blogs.msdn.com/b/oldnewthing/archive/2003/07/31/54601.aspx and http://blogs.msdn.com/b/oldnewthing/archive/2003/08/05/54602.aspx
-		// do nothing here; newpos already has nPos
-		break;
-	case SB_THUMBTRACK:
-		newpos = (LONG) si.nTrackPos;
-	}
-	// otherwise just keep the current position (that's what MSDN example code says, anyway)
-
 	// make sure we're not out of range
-	if (newpos < 0)
-		newpos = 0;
+	// check the upper limit first: if the Area is smaller than the control, maxsize - pagesize is negative and we want 0
 	if (newpos > (maxsize - pagesize))
 		newpos = maxsize - pagesize;
+	if (newpos < 0)
+		newpos = 0;
 
-	// this would be where we would put a check to not scroll if the scroll position changed, but see the note about SB_THUMBPOSITION above: Raymond Chen's code always does the scrolling anyway in this case
+	// this would be where we would put a check to not scroll if the scroll position changed, but see the note about SB_THUMBPOSITION in scrollArea(): Raymond Chen's code always does the scrolling anyway in this case
 
 	delta = -(newpos - si.nPos);		// negative because ScrollWindowEx() scrolls in the opposite direction
 	dx = delta;
@@ -258,6 +260,56 @@ static void scrollArea(HWND hwnd, void *data, WPARAM wParam, int which)
 	if ((HWND) GetWindowLongPtrW(hwnd, 0) != NULL)
 		if (UpdateWindow((HWND) GetWindowLongPtrW(hwnd, 0)) == 0)
 			xpanic("error updating Area TextField after scrolling", GetLastError());
+
+	if (delta != 0)
+		areaScrolled(data);
+}
+
+static void scrollArea(HWND hwnd, void *data, WPARAM wParam, int which)
+{
+	SCROLLINFO si;
+	LONG pagesize, maxsize;
+	LONG newpos;
+
+	getScrollPageAndMax(hwnd, data, which, &pagesize, &maxsize);
+
+	ZeroMemory(&si, sizeof (SCROLLINFO));
+	si.cbSize = sizeof (SCROLLINFO);
+	si.fMask = SIF_POS | SIF_TRACKPOS;
+	if (GetScrollInfo(hwnd, which, &si) == 0)
+		xpanic("error getting current scroll position for scrolling", GetLastError());
+
+	newpos = (LONG) si.nPos;
+	switch (LOWORD(wParam)) {
+	case SB_LEFT:			// also SB_TOP; C won't let me have both (C89 §6.6.4.2; C99 §6.8.4.2)
+		newpos = 0;
+		break;
+	case SB_RIGHT:		// also SB_BOTTOM
+		// see comment in adjustAreaScrollbars() below
+		newpos = maxsize - pagesize;
+		break;
+	case SB_LINELEFT:		// also SB_LINEUP
+		newpos--;
+		break;
+	case SB_LINERIGHT:		// also SB_LINEDOWN
+		newpos++;
+		break;
+	case SB_PAGELEFT:		// also SB_PAGEUP
+		newpos -= pagesize;
+		break;
+	case SB_PAGERIGHT:	// also SB_PAGEDOWN
+		newpos += pagesize;
+		break;
+	case SB_THUMBPOSITION:
+		// raymond chen says to just set the newpos to the SCROLLINFO nPos for this message; see http://blogs.msdn.com/b/oldnewthing/archive/2003/07/31/54601.aspx and http://blogs.msdn.com/b/oldnewthing/archive/2003/08/05/54602.aspx
+		// do nothing here; newpos already has nPos
+		break;
+	case SB_THUMBTRACK:
+		newpos = (LONG) si.nTrackPos;
+	}
+	// otherwise just keep the current position (that's what MSDN example code says, anyway)
+
+	scrollAreaTo(hwnd, data, which, newpos);
 }
 
 static void adjustAreaScrollbars(HWND hwnd, void *data)
@@ -303,15 +355,160 @@ void repaintArea(HWND hwnd, RECT *r)
 		xpanic("error repainting Area after event", GetLastError());
 }
 
+// WM_MOUSELEAVE and WM_MOUSEHOVER have to be asked for again after each one is sent; it's harmless to ask while already tracking
+static void trackMouse(HWND hwnd)
+{
+	TRACKMOUSEEVENT tme;
+
+	ZeroMemory(&tme, sizeof (TRACKMOUSEEVENT));
+	tme.cbSize = sizeof (TRACKMOUSEEVENT);
+	tme.dwFlags = TME_LEAVE | TME_HOVER;
+	tme.hwndTrack = hwnd;
+	tme.dwHoverTime = HOVER_DEFAULT;
+	if (TrackMouseEvent(&tme) == 0)
+		xpanic("error tracking mouse for Area hover and leave events", GetLastError());
+}
+
+static void areaMouseHover(HWND hwnd, void *data, WPARAM wParam, LPARAM lParam)
+{
+	int xpos, ypos;
+
+	if ((wParam & (MK_LBUTTON | MK_MBUTTON | MK_RBUTTON | MK_XBUTTON1 | MK_XBUTTON2)) != 0)
+		return;
+	getScrollPos(hwnd, &xpos, &ypos);
+	xpos += GET_X_LPARAM(lParam);
+	ypos += GET_Y_LPARAM(lParam);
+	areaHovered(data, xpos, ypos);
+}
+
+// while a mouse button is held, we capture the mouse so the Area keeps getting events outside itself, as other controls do; see MouseEvent
+// capture is per-thread, so don't release it if something else has it
+static void releaseMouse(HWND hwnd, uintptr_t heldButtons)
+{
+	if ((heldButtons & (MK_LBUTTON | MK_MBUTTON | MK_RBUTTON | MK_XBUTTON1 | MK_XBUTTON2)) != 0)
+		return;
+	if (GetCapture() == hwnd)
+		if (ReleaseCapture() == 0)
+			xpanic("error releasing Area mouse capture", GetLastError());
+}
+
+static BOOL inClient(HWND hwnd, LPARAM lParam)
+{
+	RECT r;
+	POINT pt;
+
+	if (GetClientRect(hwnd, &r) == 0)
+		xpanic("error getting Area client rect for mouse capture", GetLastError());
+	pt.x = GET_X_LPARAM(lParam);
+	pt.y = GET_Y_LPARAM(lParam);
+	return PtInRect(&r, pt);
+}
+
+// Windows 8 and newer tell us about pens with WM_POINTER messages; the mouse messages DefWindowProc() makes out of those only say that they came from a pen, so we remember the rest for AreaPenHandler
+// the pointer functions and everything they take are Windows 8 and newer, so as with SetGestureConfig() below, we load them ourselves and define what we need
+#define xWM_POINTERUPDATE 0x0245
+#define xWM_POINTERDOWN 0x0246
+#define xWM_POINTERUP 0x0247
+#define xPT_PEN 3
+#define xPEN_FLAG_INVERTED 0x00000002
+#define xPEN_FLAG_ERASER 0x00000004
+#define xPEN_MASK_PRESSURE 0x00000001
+#define xPEN_MASK_TILT_X 0x00000004
+#define xPEN_MASK_TILT_Y 0x00000008
+// see "System Events and Mouse Messages" on MSDN; the 0x80 bit is set for touch, which we don't count as a pen
+#define xMI_WP_SIGNATURE 0xFF515700
+#define xSIGNATURE_MASK 0xFFFFFF00
+#define xSIGNATURE_TOUCH 0x80
+
+typedef struct xPOINTER_PEN_INFO xPOINTER_PEN_INFO;
+struct xPOINTER_PEN_INFO {
+	// first, POINTER_INFO
+	DWORD pointerType;
+	UINT32 pointerId;
+	UINT32 frameId;
+	UINT32 pointerFlags;
+	HANDLE sourceDevice;
+	HWND hwndTarget;
+	POINT ptPixelLocation;
+	POINT ptHimetricLocation;
+	POINT ptPixelLocationRaw;
+	POINT ptHimetricLocationRaw;
+	DWORD dwTime;
+	UINT32 historyCount;
+	INT32 InputData;
+	DWORD dwKeyStates;
+	UINT64 PerformanceCount;
+	int ButtonChangeType;
+	// and then the pen parts
+	UINT32 penFlags;
+	UINT32 penMask;
+	UINT32 pressure;		// 0 to 1024
+	UINT32 rotation;
+	INT32 tiltX;		// -90 to 90 degrees
+	INT32 tiltY;
+};
+
+typedef BOOL (WINAPI *getPointerTypeFunc)(UINT32, DWORD *);
+typedef BOOL (WINAPI *getPointerPenInfoFunc)(UINT32, xPOINTER_PEN_INFO *);
+
+// there is only one pen, so this is shared by all Areas
+static struct areaPen lastPen;
+static BOOL lastPenValid = FALSE;
+
+static void areaPointer(WPARAM wParam)
+{
+	static getPointerTypeFunc getPointerType = NULL;
+	static getPointerPenInfoFunc getPointerPenInfo = NULL;
+	static BOOL loaded = FALSE;
+	HMODULE user32;
+	UINT32 id;
+	DWORD type;
+	xPOINTER_PEN_INFO info;
+
+	if (!loaded) {
+		user32 = GetModuleHandleW(L"user32.dll");
+		if (user32 == NULL)
+			xpanic("error getting user32.dll to look for GetPointerPenInfo()", GetLastError());
+		// GetProcAddress() only takes a multibyte string
+		getPointerType = (getPointerTypeFunc) GetProcAddress(user32, "GetPointerType");
+		getPointerPenInfo = (getPointerPenInfoFunc) GetProcAddress(user32, "GetPointerPenInfo");
+		loaded = TRUE;
+	}
+	if (getPointerType == NULL || getPointerPenInfo == NULL)
+		return;
+	id = (UINT32) LOWORD(wParam);		// GET_POINTERID_WPARAM()
+	// these fail if the pointer went away in the meantime; there's nothing to do about that
+	if ((*getPointerType)(id, &type) == 0 || type != xPT_PEN)
+		return;
+	ZeroMemory(&info, sizeof (xPOINTER_PEN_INFO));
+	if ((*getPointerPenInfo)(id, &info) == 0)
+		return;
+	ZeroMemory(&lastPen, sizeof (struct areaPen));
+	if ((info.penMask & xPEN_MASK_PRESSURE) != 0)
+		lastPen.pressure = ((double) (info.pressure)) / 1024;
+	if ((info.penMask & xPEN_MASK_TILT_X) != 0)
+		lastPen.tiltX = ((double) (info.tiltX)) / 90;
+	if ((info.penMask & xPEN_MASK_TILT_Y) != 0)
+		lastPen.tiltY = ((double) (info.tiltY)) / 90;
+	lastPen.eraser = (info.penFlags & (xPEN_FLAG_INVERTED | xPEN_FLAG_ERASER)) != 0;
+	lastPenValid = TRUE;
+}
+
 void areaMouseEvent(HWND hwnd, void *data, DWORD button, BOOL up, uintptr_t heldButtons, LPARAM lParam)
 {
 	int xpos, ypos;
+	DWORD extra;
+	struct areaPen *pen;
 
 	// mouse coordinates are relative to control; make them relative to Area
 	getScrollPos(hwnd, &xpos, &ypos);
 	xpos += GET_X_LPARAM(lParam);
 	ypos += GET_Y_LPARAM(lParam);
-	finishAreaMouseEvent(data, button, up, heldButtons, xpos, ypos);
+	pen = NULL;
+	extra = (DWORD) GetMessageExtraInfo();
+	if (lastPenValid && (extra & xSIGNATURE_MASK) == xMI_WP_SIGNATURE && (extra & xSIGNATURE_TOUCH) == 0)
+		pen = &lastPen;
+	finishAreaMouseEvent(data, button, up, heldButtons, xpos, ypos, pen);
 }
 
 static LRESULT CALLBACK areaWndProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM lParam)
@@ -320,6 +517,7 @@ static LRESULT CALLBACK areaWndProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM
 	DWORD which;
 	uintptr_t heldButtons = (uintptr_t) wParam;
 	LRESULT lResult;
+	HCURSOR c;
 
 	data = getWindowData(hwnd, uMsg, wParam, lParam, &lResult);
 	if (data == NULL)
@@ -345,32 +543,73 @@ static LRESULT CALLBACK areaWndProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM
 		// don't keep the double-click timer running if the user switched programs in between clicks
 		areaResetClickCounter(data);
 		return 0;
+	case WM_SETFOCUS:
+		areaFocusChanged(data, TRUE);
+		return 0;
+	case WM_KILLFOCUS:
+		areaFocusChanged(data, FALSE);
+		return 0;
 	case WM_MOUSEMOVE:
+		// there is no WM_MOUSEENTER; the first WM_MOUSEMOVE after a WM_MOUSELEAVE is it
+		// ...unless we have the mouse captured, in which case it can be anywhere
+		if (inClient(hwnd, lParam)) {
+			trackMouse(hwnd);
+			areaMouseCrossed(data, TRUE);
+		}
 		areaMouseEvent(hwnd, data, 0, FALSE, heldButtons, lParam);
 		return 0;
+	case WM_MOUSELEAVE:
+		areaMouseCrossed(data, FALSE);
+		return 0;
+	case WM_SETCURSOR:
+		// DefWindowProcW() asks our parent first, which is what shows the override cursor (see cursor_windows.c), so areaCursor() returns NULL when there is one
+		if (LOWORD(lParam) == HTCLIENT) {
+			c = areaCursor(data);
+			if (c != NULL) {
+				SetCursor(c);
+				return TRUE;
+			}
+		}
+		return DefWindowProcW(hwnd, uMsg, wParam, lParam);
+	case xWM_POINTERUPDATE:
+	case xWM_POINTERDOWN:
+	case xWM_POINTERUP:
+		areaPointer(wParam);
+		// and let DefWindowProcW() make the mouse messages
+		return DefWindowProcW(hwnd, uMsg, wParam, lParam);
+	case WM_MOUSEHOVER:
+		areaMouseHover(hwnd, data, wParam, lParam);
+		return 0;
 	case WM_LBUTTONDOWN:
 		SetFocus(hwnd);
+		SetCapture(hwnd);
 		areaMouseEvent(hwnd, data, 1, FALSE, heldButtons, lParam);
 		return 0;
 	case WM_LBUTTONUP:
 		areaMouseEvent(hwnd, data, 1, TRUE, heldButtons, lParam);
+		releaseMouse(hwnd, heldButtons);
 		return 0;
 	case WM_MBUTTONDOWN:
 		SetFocus(hwnd);
+		SetCapture(hwnd);
 		areaMouseEvent(hwnd, data, 2, FALSE, heldButtons, lParam);
 		return 0;
 	case WM_MBUTTONUP:
 		areaMouseEvent(hwnd, data, 2, TRUE, heldButtons, lParam);
+		releaseMouse(hwnd, heldButtons);
 		return 0;
 	case WM_RBUTTONDOWN:
 		SetFocus(hwnd);
+		SetCapture(hwnd);
 		areaMouseEvent(hwnd, data, 3, FALSE, heldButtons, lParam);
 		return 0;
 	case WM_RBUTTONUP:
 		areaMouseEvent(hwnd, data, 3, TRUE, heldButtons, lParam);
+		releaseMouse(hwnd, heldButtons);
 		return 0;
 	case WM_XBUTTONDOWN:
 		SetFocus(hwnd);
+		SetCapture(hwnd);
 		// values start at 1; we want them to start at 4
 		which = (DWORD) GET_XBUTTON_WPARAM(wParam) + 3;
 		heldButtons = (uintptr_t) GET_KEYSTATE_WPARAM(wParam);
@@ -380,6 +619,7 @@ static LRESULT CALLBACK areaWndProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM
 		which = (DWORD) GET_XBUTTON_WPARAM(wParam) + 3;
 		heldButtons = (uintptr_t) GET_KEYSTATE_WPARAM(wParam);
 		areaMouseEvent(hwnd, data, which, TRUE, heldButtons, lParam);
+		releaseMouse(hwnd, heldButtons);
 		return TRUE;
 	case msgAreaKeyDown:
 		return (LRESULT) areaKeyEvent(data, FALSE, wParam, lParam);
@@ -398,6 +638,14 @@ static LRESULT CALLBACK areaWndProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM
 	case msgAreaRepaintAll:
 		repaintArea(hwnd, NULL);
 		return 0;
+	case msgAreaScrollTo:
+		scrollAreaTo(hwnd, data, SB_HORZ, (LONG) wParam);
+		scrollAreaTo(hwnd, data, SB_VERT, (LONG) lParam);
+		return 0;
+	case WM_DESTROY:
+		// this fails harmlessly if the Area was never registered as a drop target (see drop_windows.c)
+		RevokeDragDrop(hwnd);
+		return 0;
 	default:
 		return DefWindowProcW(hwnd, uMsg, wParam, lParam);
 	}
@@ -475,6 +723,18 @@ HWND newAreaTextField(HWND area, void *goarea)
 	return tf;
 }
 
+// converts a point in Area coordinates to screen coordinates
+void areaToScreen(HWND area, POINT *pt)
+{
+	int sx, sy;
+
+	getScrollPos(area, &sx, &sy);
+	pt->x -= sx;
+	pt->y -= sy;
+	if (ClientToScreen(area, pt) == 0)
+		xpanic("error converting Area coordinates to screen coordinates", GetLastError());
+}
+
 void areaOpenTextField(HWND area, HWND textfield, int x, int y, int width, int height)
 {
 	int sx, sy;
@@ -498,3 +758,47 @@ void areaMarkTextFieldDone(HWND area)
 {
 	SetWindowLongPtrW(area, 0, (LONG_PTR) NULL);
 }
+
+// Windows 7 turns touch panning into WM_HSCROLL and WM_VSCROLL for windows with scrollbars (like ours) on its own, with inertia unless told otherwise
+// SetGestureConfig() and everything it takes are Windows 7 and newer, so we load it ourselves and define what we need
+typedef struct xGESTURECONFIG xGESTURECONFIG;
+struct xGESTURECONFIG {
+	DWORD dwID;
+	DWORD dwWant;
+	DWORD dwBlock;
+};
+#define xGID_PAN 4
+#define xGC_PAN 0x00000001
+#define xGC_PAN_WITH_SINGLE_FINGER_VERTICALLY 0x00000002
+#define xGC_PAN_WITH_SINGLE_FINGER_HORIZONTALLY 0x00000004
+#define xGC_PAN_WITH_INERTIA 0x00000010
+
+typedef BOOL (WINAPI *setGestureConfigFunc)(HWND, DWORD, UINT, xGESTURECONFIG *, UINT);
+
+void areaSetKinetic(HWND hwnd, BOOL kinetic)
+{
+	static setGestureConfigFunc setGestureConfig = NULL;
+	static BOOL loaded = FALSE;
+	HMODULE user32;
+	xGESTURECONFIG gc;
+
+	if (!loaded) {
+		user32 = GetModuleHandleW(L"user32.dll");
+		if (user32 == NULL)
+			xpanic("error getting user32.dll to look for SetGestureConfig()", GetLastError());
+		// GetProcAddress() only takes a multibyte string
+		setGestureConfig = (setGestureConfigFunc) GetProcAddress(user32, "SetGestureConfig");
+		loaded = TRUE;
+	}
+	if (setGestureConfig == NULL)		// before Windows 7; no touch panning to configure
+		return;
+	ZeroMemory(&gc, sizeof (xGESTURECONFIG));
+	gc.dwID = xGID_PAN;
+	gc.dwWant = xGC_PAN | xGC_PAN_WITH_SINGLE_FINGER_VERTICALLY | xGC_PAN_WITH_SINGLE_FINGER_HORIZONTALLY;
+	if (kinetic)
+		gc.dwWant |= xGC_PAN_WITH_INERTIA;
+	else
+		gc.dwBlock = xGC_PAN_WITH_INERTIA;
+	if ((*setGestureConfig)(hwnd, 0, 1, &gc, sizeof (xGESTURECONFIG)) == 0)
+		xpanic("error setting Area touch panning inertia", GetLastError());
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"image"
 	"syscall"
+	"time"
 	"unsafe"
 )
 
@@ -21,6 +22,12 @@ type area struct {
 
 	textfield     C.HWND
 	textfielddone *event
+
+	// for StartDrag(); only nonzero during Mouse()
+	mousebutton uint
+
+	// the image returned by doPaint(); Paint() may have made it just for this call, in which case nothing else refers to it while the C side is still using it, so hold on to it until the next paint
+	painted *image.RGBA
 }
 
 func makeAreaWindowClass() error {
@@ -41,9 +48,11 @@ func newArea(ab *areabase) Area {
 	}
 	a.controlSingleHWND = newControlSingleHWND(C.newArea(unsafe.Pointer(a)))
 	a.fpreferredSize = a.xpreferredSize
+	a.fsetTooltip = a.setAreaTooltip
 	a.SetSize(a.width, a.height)
 	a.textfield = C.newAreaTextField(a.hwnd, unsafe.Pointer(a))
 	C.controlSetControlFont(a.textfield)
+	a.setDropTarget()
 	return a
 }
 
@@ -94,6 +103,86 @@ func (a *area) OnTextFieldDismissed(f func()) {
 	a.textfielddone.set(f)
 }
 
+func (a *area) SetFocus() {
+	// this sends WM_SETFOCUS before returning
+	C.SetFocus(a.hwnd)
+}
+
+func (a *area) ScrollTo(pos image.Point) {
+	C.SendMessageW(a.hwnd, C.msgAreaScrollTo, C.WPARAM(pos.X), C.LPARAM(pos.Y))
+}
+
+func (a *area) ScrollPos() image.Point {
+	var hscroll, vscroll C.int
+
+	C.SendMessageW(a.hwnd, C.msgAreaGetScroll, C.WPARAM(uintptr(unsafe.Pointer(&hscroll))), C.LPARAM(uintptr(unsafe.Pointer(&vscroll))))
+	return image.Pt(int(hscroll), int(vscroll))
+}
+
+func (a *area) ShowPopupMenu(m PopupMenu, pos image.Point) {
+	m.popup(a, pos)
+}
+
+func (a *area) SetKinetic(kinetic bool) {
+	a.kinetic = kinetic
+	C.areaSetKinetic(a.hwnd, toBOOL(kinetic))
+}
+
+// see the Area documentation
+func (a *area) PixelRatio() float64 {
+	return 1
+}
+
+func (a *area) SetHiDPI(hidpi bool) {
+	a.hidpi = hidpi // nothing else to do; see PixelRatio()
+}
+
+func (a *area) SetCursor(c *Cursor) {
+	a.setCursor(c, func() {
+		// otherwise the next WM_SETCURSOR takes care of it
+		if a.mouseinside && overrideCursor == nil {
+			cur := C.HCURSOR(a.cursor.current())
+			if cur == nil {
+				cur = C.hArrowCursor
+			}
+			C.SetCursor(cur)
+		}
+	})
+}
+
+//export areaCursor
+func areaCursor(data unsafe.Pointer) C.HCURSOR {
+	a := (*area)(data)
+	if overrideCursor != nil {
+		return nil
+	}
+	return C.HCURSOR(a.cursor.current())
+}
+
+//export areaMouseCrossed
+func areaMouseCrossed(data unsafe.Pointer, entered C.BOOL) {
+	a := (*area)(data)
+	a.mouseCrossed(entered != C.FALSE)
+}
+
+//export areaHovered
+func areaHovered(data unsafe.Pointer, x C.int, y C.int) {
+	a := (*area)(data)
+	a.mouseHovered(image.Pt(int(x), int(y)))
+}
+
+//export areaScrolled
+func areaScrolled(data unsafe.Pointer) {
+	a := (*area)(data)
+	a.scrolled(a.ScrollPos())
+}
+
+//export areaFocusChanged
+func areaFocusChanged(data unsafe.Pointer, gained C.BOOL) {
+	a := (*area)(data)
+	a.focusChanged(gained != C.FALSE)
+}
+
 //export areaTextFieldDone
 func areaTextFieldDone(data unsafe.Pointer) {
 	a := (*area)(data)
@@ -101,8 +190,14 @@ func areaTextFieldDone(data unsafe.Pointer) {
 	a.textfielddone.fire()
 }
 
+//export areaWantsRegion
+func areaWantsRegion(data unsafe.Pointer) C.BOOL {
+	a := (*area)(data)
+	return toBOOL(a.wantsRegion())
+}
+
 //export doPaint
-func doPaint(xrect *C.RECT, hscroll C.int, vscroll C.int, data unsafe.Pointer, dx *C.intptr_t, dy *C.intptr_t) unsafe.Pointer {
+func doPaint(xrect *C.RECT, rects *C.RECT, nrects C.DWORD, hscroll C.int, vscroll C.int, data unsafe.Pointer, dx *C.intptr_t, dy *C.intptr_t) unsafe.Pointer {
 	a := (*area)(data)
 	// both Windows RECT and Go image.Rect are point..point, so the following is correct
 	cliprect := image.Rect(int(xrect.left), int(xrect.top), int(xrect.right), int(xrect.bottom))
@@ -110,7 +205,17 @@ func doPaint(xrect *C.RECT, hscroll C.int, vscroll C.int, data unsafe.Pointer, d
 	// make sure the cliprect doesn't fall outside the size of the Area
 	cliprect = cliprect.Intersect(image.Rect(0, 0, a.width, a.height))
 	if !cliprect.Empty() { // we have an update rect
-		i := a.handler.Paint(cliprect)
+		var region []image.Rectangle
+		if rects != nil { // only if areaWantsRegion() said to get the update region
+			n := int(nrects)
+			r := (*[1 << 16]C.RECT)(unsafe.Pointer(rects))[:n:n]
+			region = make([]image.Rectangle, n)
+			for j := range r {
+				region[j] = image.Rect(int(r[j].left), int(r[j].top), int(r[j].right), int(r[j].bottom)).Add(image.Pt(int(hscroll), int(vscroll)))
+			}
+		}
+		i := a.paint(cliprect, region, a.PixelRatio())
+		a.painted = i
 		*dx = C.intptr_t(i.Rect.Dx())
 		*dy = C.intptr_t(i.Rect.Dy())
 		return unsafe.Pointer(i)
@@ -161,14 +266,18 @@ func getModifiers() (m Modifiers) {
 }
 
 //export finishAreaMouseEvent
-func finishAreaMouseEvent(data unsafe.Pointer, cbutton C.DWORD, up C.BOOL, heldButtons C.uintptr_t, xpos C.int, ypos C.int) {
+func finishAreaMouseEvent(data unsafe.Pointer, cbutton C.DWORD, up C.BOOL, heldButtons C.uintptr_t, xpos C.int, ypos C.int, cpen *C.struct_areaPen) {
 	var me MouseEvent
 
 	a := (*area)(data)
 	button := uint(cbutton)
 	me.Pos = image.Pt(int(xpos), int(ypos))
-	if !me.Pos.In(image.Rect(0, 0, a.width, a.height)) { // outside the actual Area; no event
-		return
+	// outside the actual Area, only the mouse capture taken when a button was pressed gets us events, and we pass those on; see releaseMouse() in area_windows.c
+	if !me.Pos.In(image.Rect(0, 0, a.width, a.height)) {
+		held := heldButtons & (C.MK_LBUTTON | C.MK_MBUTTON | C.MK_RBUTTON | C.MK_XBUTTON1 | C.MK_XBUTTON2)
+		if up == C.FALSE && (button != 0 || held == 0) {
+			return
+		}
 	}
 	if up != C.FALSE {
 		me.Up = button
@@ -178,11 +287,9 @@ func finishAreaMouseEvent(data unsafe.Pointer, cbutton C.DWORD, up C.BOOL, heldB
 		// signedness isn't much of an issue for these calls anyway because http://stackoverflow.com/questions/24022225/what-are-the-sign-extension-rules-for-calling-windows-api-functions-stdcall-t and that we're only using unsigned values (think back to how you (didn't) handle signedness in assembly language) AND because of the above AND because the statistics below (time interval and width/height) really don't make sense if negative
 		time := C.GetMessageTime()
 		maxTime := C.GetDoubleClickTime()
-		// ignore zero returns and errors; MSDN says zero will be returned on error but that GetLastError() is meaningless
-		xdist := C.GetSystemMetrics(C.SM_CXDOUBLECLK)
-		ydist := C.GetSystemMetrics(C.SM_CYDOUBLECLK)
+		xdist, ydist := doubleClickRect()
 		me.Count = a.clickCounter.click(button, me.Pos.X, me.Pos.Y,
-			uintptr(time), uintptr(maxTime), int(xdist/2), int(ydist/2))
+			uintptr(time), uintptr(maxTime), xdist, ydist)
 	}
 	// though wparam will contain control and shift state, let's use just one function to get modifiers for both keyboard and mouse events; it'll work the same anyway since we have to do this for alt and windows key (super)
 	me.Modifiers = getModifiers()
@@ -201,14 +308,38 @@ func finishAreaMouseEvent(data unsafe.Pointer, cbutton C.DWORD, up C.BOOL, heldB
 	if button != 5 && (heldButtons&C.MK_XBUTTON2) != 0 {
 		me.Held = append(me.Held, 5)
 	}
-	a.handler.Mouse(me)
+	a.mousebutton = me.Down
+	if a.mousebutton == 0 && len(me.Held) != 0 {
+		a.mousebutton = me.Held[0]
+	}
+	me.Timestamp = eventTime()
+	var pen *PenState
+	if cpen != nil {
+		pen = &PenState{
+			Pressure: float64(cpen.pressure),
+			TiltX:    float64(cpen.tiltX),
+			TiltY:    float64(cpen.tiltY),
+			Eraser:   cpen.eraser != C.FALSE,
+		}
+	}
+	a.mouse(me, pen)
+	a.mousebutton = 0
+	if me.Down == 0 && me.Up == 0 {
+		if text, changed := a.tooltipAt(me.Pos, a.tooltip); changed {
+			a.controlSingleHWND.xsetTooltip(text)
+			C.controlPopTooltip(a.tooltipHWND)
+		}
+	}
 }
 
-//export areaKeyEvent
-func areaKeyEvent(data unsafe.Pointer, up C.BOOL, wParam C.WPARAM, lParam C.LPARAM) C.BOOL {
-	var ke KeyEvent
+// the AreaTooltipHandler may have changed the text since the last SetTooltip(), so keep tooltipAt() up to date with what the tooltip control has
+func (a *area) setAreaTooltip(text string) {
+	a.lasttooltip = text
+	a.controlSingleHWND.xsetTooltip(text)
+}
 
-	a := (*area)(data)
+// this is also used by Window.RegisterShortcut(); see window_windows.go
+func toKeyEvent(up C.BOOL, wParam C.WPARAM, lParam C.LPARAM) (ke KeyEvent, ok bool) {
 	lp := uint32(lParam) // to be safe
 	// the numeric keypad keys when Num Lock is off are considered left-hand keys as the separate navigation buttons were added later
 	// the numeric keypad enter, however, is a right-hand key because it has the same virtual-key code as the typewriter enter
@@ -233,10 +364,29 @@ func areaKeyEvent(data unsafe.Pointer, up C.BOOL, wParam C.WPARAM, lParam C.LPAR
 		ke.ExtKey = xke.ExtKey
 	} else if ke.Modifiers == 0 {
 		// no key, extkey, or modifiers; do nothing
-		return C.FALSE
+		return ke, false
 	}
 	ke.Up = up != C.FALSE
-	handled := a.handler.Key(ke)
+	ke.Scancode = uintptr(scancode)
+	if righthand { // the extended key flag; see "Keystroke Message Flags" on MSDN
+		ke.Scancode |= 0xE000
+	}
+	ke.Timestamp = eventTime()
+	return ke, true
+}
+
+//export areaKeyEvent
+func areaKeyEvent(data unsafe.Pointer, up C.BOOL, wParam C.WPARAM, lParam C.LPARAM) C.BOOL {
+	a := (*area)(data)
+	ke, ok := toKeyEvent(up, wParam, lParam)
+	if !ok {
+		return C.FALSE
+	}
+	if !ke.Up {
+		// set by uimsgloop_area(); see uitask_windows.c
+		ke.Rune = keyRune(rune(C.areaKeyRune))
+	}
+	handled := a.key(ke)
 	if handled {
 		return C.TRUE
 	}
@@ -284,10 +434,21 @@ var extkeys = map[C.WPARAM]ExtKey{
 	C.VK_F12:    F12,
 	// numpad numeric keys and . are handled in events_notdarwin.go
 	// numpad enter is handled in code above
-	C.VK_ADD:      NAdd,
-	C.VK_SUBTRACT: NSubtract,
-	C.VK_MULTIPLY: NMultiply,
-	C.VK_DIVIDE:   NDivide,
+	C.VK_ADD:              NAdd,
+	C.VK_SUBTRACT:         NSubtract,
+	C.VK_MULTIPLY:         NMultiply,
+	C.VK_DIVIDE:           NDivide,
+	C.VK_SNAPSHOT:         PrintScreen,
+	C.VK_PAUSE:            Pause,
+	C.VK_CANCEL:           Pause, // Ctrl+Pause gives Break, which has the same virtual-key code as Ctrl+Break
+	C.VK_APPS:             Menu,
+	C.VK_VOLUME_MUTE:      VolumeMute,
+	C.VK_VOLUME_DOWN:      VolumeDown,
+	C.VK_VOLUME_UP:        VolumeUp,
+	C.VK_MEDIA_PLAY_PAUSE: MediaPlayPause,
+	C.VK_MEDIA_STOP:       MediaStop,
+	C.VK_MEDIA_NEXT_TRACK: MediaNext,
+	C.VK_MEDIA_PREV_TRACK: MediaPrevious,
 }
 
 // sanity check
@@ -335,3 +496,22 @@ func (a *area) xpreferredSize(d *sizing) (width, height int) {
 	// the preferred size of an Area is its size
 	return a.width, a.height
 }
+
+// the system metrics are the size of the whole rectangle around the first click that the second click must be in, so halve them
+// ignore zero returns and errors; MSDN says zero will be returned on error but that GetLastError() is meaningless
+func doubleClickRect() (xdist int, ydist int) {
+	return int(C.GetSystemMetrics(C.SM_CXDOUBLECLK) / 2), int(C.GetSystemMetrics(C.SM_CYDOUBLECLK) / 2)
+}
+
+func doubleClickTime() time.Duration {
+	return time.Duration(C.GetDoubleClickTime()) * time.Millisecond
+}
+
+// the rectangle is almost always square; if it isn't, go with the smaller side
+func doubleClickDistance() int {
+	xdist, ydist := doubleClickRect()
+	if ydist < xdist {
+		return ydist
+	}
+	return xdist
+}
@@ -2,6 +2,10 @@
 
 package ui
 
+import (
+	"image/color"
+)
+
 // Button is a clickable button that performs some task.
 type Button interface {
 	Control
@@ -10,6 +14,8 @@ type Button interface {
 	OnClicked(func())
 
 	// Text and SetText get and set the Button's label text.
+	// The character after an & is the Button's mnemonic: it is underlined and pressing it with Alt clicks the Button; use && for a literal &.
+	// (Mac OS X has no mnemonics; there the & is simply not shown.)
 	Text() string
 	SetText(text string)
 }
@@ -23,10 +29,12 @@ func NewButton(text string) Button {
 type Checkbox interface {
 	Control
 
-	// OnToggled sets the event handler for when the Checkbox is toggled.
+	// OnToggled sets the event handler for when the user toggles the Checkbox.
+	// It is not triggered by SetChecked.
 	OnToggled(func())
 
 	// Text and SetText get and set the Checkbox's label text.
+	// & marks the mnemonic as with Button.
 	Text() string
 	SetText(text string)
 
@@ -41,6 +49,31 @@ func NewCheckbox(text string) Checkbox {
 	return newCheckbox(text)
 }
 
+// RadioButtons is a Control that shows a set of mutually exclusive choices as a column of radio buttons.
+// Exactly one of the radio buttons is selected at any time.
+type RadioButtons interface {
+	Control
+
+	// Selected and SetSelected get and set the index of the selected radio button.
+	// SetSelected panics if index is out of range.
+	Selected() int
+	SetSelected(index int)
+
+	// OnSelected sets the event handler for when the user selects a different radio button.
+	// It is not triggered by SetSelected.
+	OnSelected(func())
+}
+
+// NewRadioButtons creates a new RadioButtons with one radio button for each of the given labels, in order.
+// The first radio button will be initially selected.
+// NewRadioButtons panics if no labels are given.
+func NewRadioButtons(labels ...string) RadioButtons {
+	if len(labels) == 0 {
+		panic("no labels given to NewRadioButtons()")
+	}
+	return newRadioButtons(labels)
+}
+
 // TextField is a Control in which the user can enter a single line of text.
 type TextField interface {
 	Control
@@ -53,11 +86,32 @@ type TextField interface {
 	// Do not bother trying to figure out how the text was changed; instead, perform your validation and use Invalid to inform the user that the entered text is invalid instead.
 	OnChanged(func())
 
+	// OnEnterPressed sets the event handler for when the user presses Enter in the TextField, for instance to start a search.
+	// Enter still does whatever else it does; a Window shortcut for Enter (see Window.RegisterShortcut) gets Enter first, and then OnEnterPressed is not triggered.
+	OnEnterPressed(func())
+
+	// Select selects the text between start and end, which are byte offsets into Text() as with slicing it; if start == end, it just moves the caret there.
+	// SelectAll selects all of the text.
+	// Select panics if Text()[start:end] would.
+	// Giving a TextField the keyboard focus selects all of its text on some systems, so to select part of the text of a TextField that doesn't have the focus, call SetFocus first.
+	// On Mac OS X, only the focused TextField has a selection, so Select and SelectAll give it the focus themselves.
+	Select(start int, end int)
+	SelectAll()
+
+	// CursorPos returns where the caret is, as a byte offset into Text().
+	// If text is selected, the caret is at one end of the selection; which end depends on the system and on how the text was selected.
+	// On Mac OS X, a TextField that doesn't have the keyboard focus has no caret, and CursorPos returns 0.
+	CursorPos() int
+
 	// Invalid throws a non-modal alert (whose nature is system-defined) on or near the TextField that alerts the user that input is invalid.
 	// The string passed to Invalid will be displayed to the user to inform them of what specifically is wrong with the input.
 	// Pass an empty string to remove the warning.
+	// See also Window.FocusFirstInvalid.
 	Invalid(reason string)
 
+	// InvalidReason returns the reason passed to the last call to Invalid, which is "" if the TextField is not marked invalid.
+	InvalidReason() string
+
 	// ReadOnly and SetReadOnly get and set whether the TextField is read-only.
 	// A read-only TextField cannot be changed by the user, but its text can still be manipulated in other ways (selecting, copying, etc.).
 	ReadOnly() bool
@@ -70,6 +124,7 @@ func NewTextField() TextField {
 }
 
 // NewPasswordField creates a new TextField for entering passwords; that is, it hides the text being entered.
+// The text is also kept away from input methods, so it doesn't show up in composition windows or later suggestions, and the user cannot copy it out.
 func NewPasswordField() TextField {
 	return newPasswordField()
 }
@@ -83,6 +138,22 @@ type Tab interface {
 	// Append adds a new tab to Tab.
 	// The tab is added to the end of the current list of tabs.
 	Append(name string, control Control)
+
+	// Delete removes the tab at the given index from Tab.
+	// The Control in that tab is destroyed and must not be used again.
+	// If the removed tab was selected, the tab that takes its place (or the new last tab, if the removed tab was the last one) is selected.
+	// It panics if index is out of range.
+	Delete(index int)
+
+	// Selected and Select get and set the index of the currently selected tab.
+	// Selected returns -1 if Tab has no tabs.
+	// Select panics if index is out of range.
+	Selected() int
+	Select(index int)
+
+	// OnSelected sets the event handler for when the selected tab changes, whether by the user or by a call to Select or Delete.
+	// Deleting a tab before the selected one also triggers it, since Selected changes even though the same tab is still selected.
+	OnSelected(f func())
 }
 
 // NewTab creates a new Tab with no tabs.
@@ -97,6 +168,7 @@ type Label interface {
 	Control
 
 	// Text and SetText get and set the Label's text.
+	// & marks the mnemonic as with Button; pressing it moves the keyboard focus to the first Control after the Label (in the order they were added to their Stack or Grid) that can take it.
 	Text() string
 	SetText(text string)
 }
@@ -108,7 +180,9 @@ func NewLabel(text string) Label {
 
 // Group is a Control that holds a single Control; if that Control also contains other Controls, then the Controls will appear visually grouped together.
 // The appearance of a Group varies from system to system; for the most part a Group consists of a thin frame.
+// On Windows it is a group box and on Mac OS X an NSBox; on GTK+ it is a GtkFrame, but drawn as a bold label with no frame, as the GNOME Human Interface Guidelines ask.
 // All Groups have a text label indicating what the Group is for.
+// Groups are meant for clustering related settings, as in a preferences window: put a Stack or Grid of the settings in each Group and stack the Groups vertically.
 type Group interface {
 	Control
 
@@ -129,10 +203,9 @@ func NewGroup(text string, control Control) Group {
 
 // Textbox represents a multi-line text entry box.
 // Text in a Textbox is unformatted, and scrollbars are applied automatically.
-// TODO rename to TextBox? merge with TextField (but cannot use Invalid())? enable/disable line wrapping?
-// TODO events
+// A read-only Textbox with Append makes a log viewer; an editable one makes a notes field.
+// TODO rename to TextBox? merge with TextField (but cannot use Invalid())?
 // TODO Tab key - insert horizontal tab or tab stop?
-// TODO ReadOnly
 // TODO line endings
 type Textbox interface {
 	Control
@@ -140,6 +213,25 @@ type Textbox interface {
 	// Text and SetText get and set the Textbox's text.
 	Text() string
 	SetText(text string)
+
+	// Append adds text to the end of the Textbox's text, moves the caret to the end, and scrolls it into view.
+	// This is faster than SetText(Text() + text) on a long Textbox.
+	Append(text string)
+
+	// OnChanged sets the event handler for when the user changes the Textbox's text.
+	// It is not triggered by SetText or Append.
+	OnChanged(func())
+
+	// ReadOnly and SetReadOnly get and set whether the Textbox is read-only.
+	// As with TextField, the user can still select and copy the text of a read-only Textbox.
+	ReadOnly() bool
+	SetReadOnly(readonly bool)
+
+	// WordWrap and SetWordWrap get and set whether lines too long to fit are wrapped at the Textbox's width.
+	// If not, the Textbox scrolls horizontally instead.
+	// Textboxes do not wrap by default.
+	WordWrap() bool
+	SetWordWrap(wrap bool)
 }
 
 // NewTextbox creates a new Textbox.
@@ -150,7 +242,6 @@ func NewTextbox() Textbox {
 // Spinbox is a Control that provides a text entry field that accepts integers and up and down buttons to increment and decrement those values.
 // This control is in its preliminary state.
 // TODO everything:
-// - TODO set increment? (work on windows)
 // - TODO set page step?
 // - TODO wrapping
 // - TODO negative values
@@ -165,6 +256,13 @@ type Spinbox interface {
 	// OnChanged sets the event handler for when the Spinbox's value is changed.
 	// Under what conditions this event is raised when the user types into the Spinbox's edit field is platform-defined.
 	OnChanged(func())
+
+	// Step and SetStep get and set how much the value changes each time the user clicks the up or down button (or presses the up or down arrow key).
+	// The default step is 1; SetStep panics if step is not positive.
+	// Values the user types in are not rounded to a multiple of the step.
+	// On some systems, holding a button down steps by a larger multiple of the step after a few seconds.
+	Step() int
+	SetStep(step int)
 }
 
 // NewSpinbox creates a new Spinbox with the given minimum and maximum.
@@ -178,7 +276,6 @@ func NewSpinbox(min int, max int) Spinbox {
 }
 
 // ProgressBar is a Control that displays a horizontal bar which shows the level of completion of an operation.
-// TODO indetermiante
 type ProgressBar interface {
 	Control
 
@@ -187,6 +284,12 @@ type ProgressBar interface {
 	// TODO rename to Progress/SetProgress?
 	Percent() int
 	SetPercent(percent int)
+
+	// Indeterminate and SetIndeterminate get and set whether the ProgressBar is in indeterminate mode.
+	// An indeterminate ProgressBar shows the system's animation for an operation of unknown length instead of a percentage; the animation runs on its own, so there's no need to keep calling anything.
+	// The percentage is kept while the ProgressBar is indeterminate (SetPercent still changes it) and is shown again when indeterminate mode is turned off.
+	Indeterminate() bool
+	SetIndeterminate(indeterminate bool)
 }
 
 // NewProgressBar creates a new ProgressBar.
@@ -194,3 +297,64 @@ type ProgressBar interface {
 func NewProgressBar() ProgressBar {
 	return newProgressBar()
 }
+
+// Slider is a Control that lets the user choose an integer in a range by dragging a knob along a track.
+type Slider interface {
+	Control
+
+	// Value and SetValue get and set the current value of the Slider, respectively.
+	// For SetValue, if the new value is outside the range of the Slider, it is set to the nearest extremity.
+	Value() int
+	SetValue(value int)
+
+	// OnChanged sets the event handler for when the user changes the Slider's value.
+	// It is triggered repeatedly as the user drags the knob, but not by SetValue.
+	OnChanged(func())
+
+	// TickInterval and SetTickInterval get and set the distance, in values, between the tick marks drawn along the Slider, starting at the minimum.
+	// An interval of 0 means no tick marks, and is the default.
+	// SetTickInterval panics if interval is negative.
+	// The knob is not restricted to the tick marks.
+	// Some systems can only space tick marks evenly from end to end, so choose an interval that divides max - min.
+	TickInterval() int
+	SetTickInterval(interval int)
+}
+
+// NewHorizontalSlider creates a new Slider with the given minimum and maximum whose track runs from left (min) to right (max).
+// The initial value will be the minimum value.
+// NewHorizontalSlider() panics if min > max.
+func NewHorizontalSlider(min int, max int) Slider {
+	if min > max {
+		panic("min > max in NewHorizontalSlider()")
+	}
+	return newSlider(min, max, false)
+}
+
+// NewVerticalSlider is like NewHorizontalSlider, except the track runs from bottom (min) to top (max).
+func NewVerticalSlider(min int, max int) Slider {
+	if min > max {
+		panic("min > max in NewVerticalSlider()")
+	}
+	return newSlider(min, max, true)
+}
+
+// ColorButton is a Control that shows a color and lets the user change it by clicking it, which opens the system's color chooser as with ChooseColor.
+// Colors are always opaque; the alpha of a color given to the ColorButton is ignored.
+type ColorButton interface {
+	Control
+
+	// Color and SetColor get and set the color shown.
+	// Color returns a color.NRGBA.
+	Color() color.Color
+	SetColor(c color.Color)
+
+	// OnChanged sets the event handler for when the user chooses a different color.
+	// On Mac OS X, it is triggered repeatedly as the user moves around the color chooser.
+	// It is not triggered by SetColor.
+	OnChanged(func())
+}
+
+// NewColorButton creates a new ColorButton showing initial.
+func NewColorButton(initial color.Color) ColorButton {
+	return newColorButton(toOpaqueNRGBA(initial))
+}
@@ -11,8 +11,10 @@
 #define toNSBox(x) ((NSBox *) (x))
 #define toNSTextView(x) ((NSTextView *) (x))
 #define toNSProgressIndicator(x) ((NSProgressIndicator *) (x))
+#define toNSMatrix(x) ((NSMatrix *) (x))
+#define toNSSlider(x) ((NSSlider *) (x))
 
-@interface goControlDelegate : NSObject <NSTextFieldDelegate> {
+@interface goControlDelegate : NSObject <NSTextFieldDelegate, NSTextViewDelegate> {
 @public
 	void *gocontrol;
 }
@@ -30,11 +32,35 @@
 	checkboxToggled(self->gocontrol);
 }
 
+- (IBAction)radiobuttonsClicked:(id)sender
+{
+	radiobuttonsClicked(self->gocontrol);
+}
+
+- (IBAction)sliderChanged:(id)sender
+{
+	sliderChanged(self->gocontrol);
+}
+
 - (void)controlTextDidChange:(NSNotification *)note
 {
 	textfieldChanged(self->gocontrol);
 }
 
+// returning NO lets the field editor go on to do what it normally does with Enter
+- (BOOL)control:(NSControl *)control textView:(NSTextView *)tv doCommandBySelector:(SEL)sel
+{
+	if (sel == @selector(insertNewline:))
+		textfieldEntered(self->gocontrol);
+	return NO;
+}
+
+// unlike the above, this is only sent for changes the user makes
+- (void)textDidChange:(NSNotification *)note
+{
+	textboxChanged(self->gocontrol);
+}
+
 @end
 
 id newButton(void)
@@ -107,6 +133,53 @@ void checkboxSetChecked(id c, BOOL checked)
 	[toNSButton(c) setState:state];
 }
 
+// this is what Interface Builder makes for a radio group: an NSMatrix of radio button cells
+id newRadioButtons(intptr_t n)
+{
+	NSButtonCell *prototype;
+	NSMatrix *m;
+
+	prototype = [[NSButtonCell alloc] init];
+	[prototype setButtonType:NSRadioButton];
+	setStandardControlFont((id) prototype);
+	m = [[NSMatrix alloc] initWithFrame:NSZeroRect
+		mode:NSRadioModeMatrix
+		prototype:prototype
+		numberOfRows:(NSInteger) n
+		numberOfColumns:1];
+	[prototype release];
+	[m setAllowsEmptySelection:NO];
+	// this is the spacing Interface Builder uses
+	[m setIntercellSpacing:NSMakeSize(4, 2)];
+	[m selectCellAtRow:0 column:0];
+	return (id) m;
+}
+
+void radiobuttonsSetDelegate(id m, void *r)
+{
+	goControlDelegate *d;
+
+	d = [goControlDelegate new];
+	d->gocontrol = r;
+	[toNSMatrix(m) setTarget:d];
+	[toNSMatrix(m) setAction:@selector(radiobuttonsClicked:)];
+}
+
+void radiobuttonsSetText(id m, intptr_t index, char *text)
+{
+	[[toNSMatrix(m) cellAtRow:(NSInteger) index column:0] setTitle:[NSString stringWithUTF8String:text]];
+}
+
+intptr_t radiobuttonsSelected(id m)
+{
+	return (intptr_t) [toNSMatrix(m) selectedRow];
+}
+
+void radiobuttonsSelect(id m, intptr_t index)
+{
+	[toNSMatrix(m) selectCellAtRow:(NSInteger) index column:0];
+}
+
 // also good for labels
 // not static because area_darwin.m uses it
 id finishNewTextField(id _t, BOOL bordered)
@@ -196,6 +269,38 @@ void textfieldSetEditable(id textfield, BOOL editable)
 	[toNSTextField(textfield) setEditable:editable];
 }
 
+// only the text field being edited has a selection, which is really the field editor's, so start editing first; makeFirstResponder: selects everything
+// an end of -1 means the end of the text
+void textfieldSelect(id textfield, intptr_t start, intptr_t end)
+{
+	NSTextField *t = toNSTextField(textfield);
+	NSText *editor;
+
+	editor = [t currentEditor];
+	if (editor == nil) {
+		if ([t window] == nil || [[t window] makeFirstResponder:t] == NO)
+			return;
+		editor = [t currentEditor];
+		if (editor == nil)
+			return;
+	}
+	if (end == -1)
+		end = (intptr_t) [[editor string] length];
+	[editor setSelectedRange:NSMakeRange((NSUInteger) start, (NSUInteger) (end - start))];
+}
+
+intptr_t textfieldCursorPos(id textfield)
+{
+	NSText *editor;
+	NSRange r;
+
+	editor = [toNSTextField(textfield) currentEditor];
+	if (editor == nil)
+		return 0;
+	r = [editor selectedRange];
+	return (intptr_t) (r.location + r.length);
+}
+
 id newLabel(void)
 {
 	NSTextField *l;
@@ -215,8 +320,7 @@ id newGroup(id container)
 	[group setBorderType:NSLineBorder];
 	[group setBoxType:NSBoxPrimary];
 	[group setTransparent:NO];
-	// can't use setSmallControlFont() here because the selector is different
-	[group setTitleFont:[NSFont systemFontOfSize:[NSFont systemFontSizeForControlSize:NSSmallControlSize]]];
+	setGroupTitleFont((id) group);
 	[group setTitlePosition:NSAtTop];
 	[group setContentView:toNSView(container)];
 	return (id) group;
@@ -272,9 +376,20 @@ id newTextbox(void)
 	[[tv layoutManager] setAllowsNonContiguousLayout:YES];
 	// this will work because it's the same selector
 	setStandardControlFont((id) tv);
+	// NSTextView wraps by default; Textbox doesn't
+	textboxSetWordWrap((id) tv, NO);
 	return (id) tv;
 }
 
+void textboxSetDelegate(id tv, void *t)
+{
+	goControlDelegate *d;
+
+	d = [goControlDelegate new];
+	d->gocontrol = t;
+	[toNSTextView(tv) setDelegate:d];
+}
+
 char *textboxText(id tv)
 {
 	return [[toNSTextView(tv) string] UTF8String];
@@ -285,6 +400,61 @@ void textboxSetText(id tv, char *text)
 	[toNSTextView(tv) setString:[NSString stringWithUTF8String:text]];
 }
 
+void textboxAppend(id tv, char *text)
+{
+	NSTextView *t;
+	NSRange end;
+
+	t = toNSTextView(tv);
+	end = NSMakeRange([[t string] length], 0);
+	// this uses the typing attributes, so the new text gets the same font as the rest
+	[t replaceCharactersInRange:end withString:[NSString stringWithUTF8String:text]];
+	end = NSMakeRange([[t string] length], 0);
+	[t setSelectedRange:end];
+	[t scrollRangeToVisible:end];
+}
+
+BOOL textboxEditable(id tv)
+{
+	return [toNSTextView(tv) isEditable];
+}
+
+void textboxSetEditable(id tv, BOOL editable)
+{
+	[toNSTextView(tv) setEditable:editable];
+}
+
+BOOL textboxWordWrap(id tv)
+{
+	return [[toNSTextView(tv) textContainer] widthTracksTextView];
+}
+
+// see https://developer.apple.com/library/mac/documentation/Cocoa/Conceptual/TextUILayer/Tasks/TextInScrollView.html
+void textboxSetWordWrap(id tv, BOOL wrap)
+{
+	NSTextView *t;
+	NSTextContainer *c;
+	NSScrollView *sv;
+
+	t = toNSTextView(tv);
+	c = [t textContainer];
+	if (wrap) {
+		[t setHorizontallyResizable:NO];
+		[c setWidthTracksTextView:YES];
+		// the text view may be wider than the scroll view from when it wasn't wrapping; bring it back
+		// there's no scroll view yet when called from newTextbox(), but then we don't wrap anyway
+		sv = [t enclosingScrollView];
+		if (sv != nil)
+			[t setFrameSize:NSMakeSize([sv contentSize].width, [t frame].size.height)];
+		[c setContainerSize:NSMakeSize([t frame].size.width, FLT_MAX)];
+		return;
+	}
+	[t setMaxSize:NSMakeSize(FLT_MAX, FLT_MAX)];
+	[t setHorizontallyResizable:YES];
+	[c setWidthTracksTextView:NO];
+	[c setContainerSize:NSMakeSize(FLT_MAX, FLT_MAX)];
+}
+
 id newProgressBar(void)
 {
 	NSProgressIndicator *pi;
@@ -312,3 +482,67 @@ void progressbarSetPercent(id pbar, intmax_t percent)
 {
 	[toNSProgressIndicator(pbar) setDoubleValue:((double) percent)];
 }
+
+BOOL progressbarIndeterminate(id pbar)
+{
+	return [toNSProgressIndicator(pbar) isIndeterminate];
+}
+
+// the animation has to be started and stopped by hand
+void progressbarSetIndeterminate(id pbar, BOOL indeterminate)
+{
+	NSProgressIndicator *pi;
+
+	pi = toNSProgressIndicator(pbar);
+	if (indeterminate == [pi isIndeterminate])
+		return;
+	if (!indeterminate)
+		[pi stopAnimation:nil];
+	[pi setIndeterminate:indeterminate];
+	if (indeterminate)
+		[pi startAnimation:nil];
+}
+
+id newSlider(intmax_t min, intmax_t max, BOOL vertical)
+{
+	NSSlider *s;
+	NSRect r;
+
+	// there's no setVertical: before 10.12; an NSSlider is vertical if it's taller than it is wide
+	r = NSMakeRect(0, 0, 100, 20);
+	if (vertical)
+		r = NSMakeRect(0, 0, 20, 100);
+	s = [[NSSlider alloc] initWithFrame:r];
+	[s setMinValue:(double) min];
+	[s setMaxValue:(double) max];
+	[s setDoubleValue:(double) min];
+	[s setContinuous:YES];		// send the action while dragging, not just at the end
+	[s setNumberOfTickMarks:0];
+	[s setControlSize:NSRegularControlSize];
+	return (id) s;
+}
+
+void sliderSetDelegate(id slider, void *s)
+{
+	goControlDelegate *d;
+
+	d = [goControlDelegate new];
+	d->gocontrol = s;
+	[toNSSlider(slider) setTarget:d];
+	[toNSSlider(slider) setAction:@selector(sliderChanged:)];
+}
+
+intmax_t sliderValue(id slider)
+{
+	return (intmax_t) [toNSSlider(slider) integerValue];
+}
+
+void sliderSetValue(id slider, intmax_t value)
+{
+	[toNSSlider(slider) setIntegerValue:(NSInteger) value];
+}
+
+void sliderSetTickMarks(id slider, intmax_t n)
+{
+	[toNSSlider(slider) setNumberOfTickMarks:(NSInteger) n];
+}
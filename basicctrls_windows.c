@@ -79,8 +79,54 @@ void checkboxSetChecked(HWND hwnd, BOOL c)
 	SendMessage(hwnd, BM_SETCHECK, check, 0);
 }
 
+static LRESULT CALLBACK radiobuttonSubProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM lParam, UINT_PTR id, DWORD_PTR data)
+{
+	switch (uMsg) {
+	case msgCOMMAND:
+		if (HIWORD(wParam) == BN_CLICKED) {
+			// as with Checkbox, we don't use BS_AUTORADIOBUTTON, so the Go side manages the check state; the subclass ID is the index of the button
+			radiobuttonClicked((void *) data, id);
+			return 0;
+		}
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_NCDESTROY:
+		if ((*fv_RemoveWindowSubclass)(hwnd, radiobuttonSubProc, id) == FALSE)
+			xpanic("error removing RadioButtons button subclass (which was for its own event handler)", GetLastError());
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	default:
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	}
+	xmissedmsg("RadioButtons button", "radiobuttonSubProc()", uMsg);
+	return 0;		// unreached
+}
+
+void setRadioButtonSubclass(HWND hwnd, void *data, UINT_PTR index)
+{
+	if ((*fv_SetWindowSubclass)(hwnd, radiobuttonSubProc, index, (DWORD_PTR) data) == FALSE)
+		xpanic("error subclassing RadioButtons button to give it its own event handler", GetLastError());
+}
+
+void radiobuttonSetChecked(HWND hwnd, BOOL checked)
+{
+	LONG_PTR style;
+	WPARAM check;
+
+	check = BST_CHECKED;
+	if (checked == FALSE)
+		check = BST_UNCHECKED;
+	SendMessageW(hwnd, BM_SETCHECK, check, 0);
+	// only the checked button is a tab stop; IsDialogMessage() moves between the others with the arrow keys
+	style = GetWindowLongPtrW(hwnd, GWL_STYLE);
+	style &= ~WS_TABSTOP;
+	if (checked)
+		style |= WS_TABSTOP;
+	SetWindowLongPtrW(hwnd, GWL_STYLE, style);
+}
+
 static LRESULT CALLBACK textfieldSubProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM lParam, UINT_PTR id, DWORD_PTR data)
 {
+	MSG *msg = (MSG *) lParam;
+
 	switch (uMsg) {
 	case msgCOMMAND:
 		if (HIWORD(wParam) == EN_CHANGE) {
@@ -88,6 +134,20 @@ static LRESULT CALLBACK textfieldSubProc(HWND hwnd, UINT uMsg, WPARAM wParam, LP
 			return 0;
 		}
 		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_GETDLGCODE:
+		// otherwise IsDialogMessage() turns Enter into IDOK for the window and we never see it
+		if (msg != NULL && msg->message == WM_KEYDOWN && msg->wParam == VK_RETURN)
+			return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam) | DLGC_WANTALLKEYS;
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_KEYDOWN:
+		if (wParam == VK_RETURN)
+			textfieldEntered((void *) data);
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_CHAR:
+		// a single-line edit control beeps at the WM_CHAR that follows, since it can't take a new line
+		if (wParam == L'\r')
+			return 0;
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
 	case WM_NCDESTROY:
 		if ((*fv_RemoveWindowSubclass)(hwnd, textfieldSubProc, id) == FALSE)
 			xpanic("error removing TextField subclass (which was for its own event handler)", GetLastError());
@@ -128,6 +188,14 @@ void textfieldHideInvalidBalloonTip(HWND hwnd)
 		xpanic("error hiding TextField.Invalid() balloon tip", GetLastError());
 }
 
+// ES_PASSWORD hides the text, but an IME would still show it in its composition window and could remember it for later suggestions
+// so take the IME away from password fields entirely, like OS X does with secure input
+void textfieldDisableIME(HWND hwnd)
+{
+	// don't check for failure; if there's no IME to take away, there's nothing to worry about
+	ImmAssociateContextEx(hwnd, NULL, 0);
+}
+
 // also good for Textbox
 int textfieldReadOnly(HWND hwnd)
 {
@@ -141,6 +209,99 @@ void textfieldSetReadOnly(HWND hwnd, BOOL readonly)
 		xpanic("error setting TextField/Textbox as read-only/not read-only", GetLastError());
 }
 
+// PBS_MARQUEE has to be set for PBM_SETMARQUEE to do anything, and cleared again for the bar to show a position
+void progressbarSetMarquee(HWND hwnd, BOOL marquee)
+{
+	LONG_PTR style;
+
+	style = GetWindowLongPtrW(hwnd, GWL_STYLE);
+	if (marquee) {
+		SetWindowLongPtrW(hwnd, GWL_STYLE, style | PBS_MARQUEE);
+		SendMessageW(hwnd, PBM_SETMARQUEE, (WPARAM) TRUE, 0);		// 0: the default animation speed
+		return;
+	}
+	SendMessageW(hwnd, PBM_SETMARQUEE, (WPARAM) FALSE, 0);
+	SetWindowLongPtrW(hwnd, GWL_STYLE, style & ~PBS_MARQUEE);
+}
+
+static LRESULT CALLBACK textboxSubProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM lParam, UINT_PTR id, DWORD_PTR data)
+{
+	switch (uMsg) {
+	case msgCOMMAND:
+		if (HIWORD(wParam) == EN_CHANGE) {
+			textboxChanged((void *) data);
+			return 0;
+		}
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_NCDESTROY:
+		if ((*fv_RemoveWindowSubclass)(hwnd, textboxSubProc, id) == FALSE)
+			xpanic("error removing Textbox subclass (which was for its own event handler)", GetLastError());
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	default:
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	}
+	xmissedmsg("Textbox", "textboxSubProc()", uMsg);
+	return 0;		// unreached
+}
+
+void setTextboxSubclass(HWND hwnd, void *data)
+{
+	if ((*fv_SetWindowSubclass)(hwnd, textboxSubProc, 0, (DWORD_PTR) data) == FALSE)
+		xpanic("error subclassing Textbox to give it its own event handler", GetLastError());
+}
+
+void textboxAppend(HWND hwnd, LPWSTR text)
+{
+	int n;
+
+	// EM_REPLACESEL replaces the selection, so collapse it to the end first
+	// EM_REPLACESEL also scrolls the caret into view for us
+	n = GetWindowTextLengthW(hwnd);
+	SendMessageW(hwnd, EM_SETSEL, (WPARAM) n, (LPARAM) n);
+	SendMessageW(hwnd, EM_REPLACESEL, (WPARAM) FALSE, (LPARAM) text);		// FALSE: can't undo
+}
+
+// a multi-line edit control only decides whether to wrap when it's created (it wraps if it doesn't have WS_HSCROLL), so the only way to change it is to make a new edit control in the old one's place
+// this makes the new one and destroys the old one; moving the text over and subclassing are the caller's job
+HWND textboxRemake(HWND hwnd, BOOL wordWrap)
+{
+	HWND new;
+	HWND parent;
+	LONG_PTR style;
+	RECT r;
+	POINT p;
+	BOOL focused;
+
+	style = GetWindowLongPtrW(hwnd, GWL_STYLE);
+	style &= ~(WS_HSCROLL | WS_CHILD | WS_VISIBLE);		// newControl() adds the last two
+	if (!wordWrap)
+		style |= WS_HSCROLL;
+	new = newControl(L"EDIT", (DWORD) style, WS_EX_CLIENTEDGE);
+	controlSetControlFont(new);
+	parent = GetParent(hwnd);
+	if (parent == NULL)
+		xpanic("error getting parent of old Textbox to replace it", GetLastError());
+	controlSetParent(new, parent);
+	if (GetWindowRect(hwnd, &r) == 0)
+		xpanic("error getting old Textbox's window rect to replace it", GetLastError());
+	// the above is a window rect; convert to client rect
+	p.x = r.left;
+	p.y = r.top;
+	if (ScreenToClient(parent, &p) == 0)
+		xpanic("error getting client origin of old Textbox to replace it", GetLastError());
+	// put the new one right after the old one in the z-order so the tab order doesn't change
+	if (SetWindowPos(new, hwnd, p.x, p.y, r.right - r.left, r.bottom - r.top, SWP_NOACTIVATE | SWP_NOOWNERZORDER) == 0)
+		xpanic("error moving new Textbox into old Textbox's place", GetLastError());
+	if ((GetWindowLongPtrW(hwnd, GWL_STYLE) & WS_VISIBLE) == 0)
+		ShowWindow(new, SW_HIDE);
+	focused = GetFocus() == hwnd;
+	if (DestroyWindow(hwnd) == 0)
+		xpanic("error destroying old Textbox after replacing it", GetLastError());
+	if (focused)
+		SetFocus(new);
+	return new;
+}
+
 static LRESULT CALLBACK groupSubProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM lParam, UINT_PTR id, DWORD_PTR data)
 {
 	LRESULT lResult;
@@ -252,3 +413,30 @@ void setSpinboxEditSubclass(HWND hwnd, void *data)
 
 // provided for cgo's benefit
 LPWSTR xPROGRESS_CLASS = PROGRESS_CLASS;
+
+static LRESULT CALLBACK sliderSubProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM lParam, UINT_PTR id, DWORD_PTR data)
+{
+	switch (uMsg) {
+	case msgSCROLL:
+		// this is sent for every kind of movement (and for TB_ENDTRACK, which doesn't move anything); slider_windows.go figures out if the value actually changed
+		sliderChanged((void *) data);
+		return 0;
+	case WM_NCDESTROY:
+		if ((*fv_RemoveWindowSubclass)(hwnd, sliderSubProc, id) == FALSE)
+			xpanic("error removing Slider subclass (which was for its own event handler)", GetLastError());
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	default:
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	}
+	xmissedmsg("Slider", "sliderSubProc()", uMsg);
+	return 0;		// unreached
+}
+
+void setSliderSubclass(HWND hwnd, void *data)
+{
+	if ((*fv_SetWindowSubclass)(hwnd, sliderSubProc, 0, (DWORD_PTR) data) == FALSE)
+		xpanic("error subclassing Slider to give it its own event handler", GetLastError());
+}
+
+// provided for cgo's benefit
+LPWSTR xTRACKBAR_CLASS = TRACKBAR_CLASS;
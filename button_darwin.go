@@ -12,16 +12,15 @@ import "C"
 type button struct {
 	*controlSingleObject
 	clicked *event
+	text    string // as given, with the & mnemonic markers that Mac OS X doesn't show
 }
 
 func newButton(text string) *button {
-	ctext := C.CString(text)
-	defer C.free(unsafe.Pointer(ctext))
 	b := &button{
 		controlSingleObject:		newControlSingleObject(C.newButton()),
 		clicked: newEvent(),
 	}
-	C.buttonSetText(b.id, ctext)
+	b.SetText(text)
 	C.buttonSetDelegate(b.id, unsafe.Pointer(b))
 	return b
 }
@@ -31,11 +30,12 @@ func (b *button) OnClicked(e func()) {
 }
 
 func (b *button) Text() string {
-	return C.GoString(C.buttonText(b.id))
+	return b.text
 }
 
 func (b *button) SetText(text string) {
-	ctext := C.CString(text)
+	b.text = text
+	ctext := C.CString(stripMnemonic(text))
 	defer C.free(unsafe.Pointer(ctext))
 	C.buttonSetText(b.id, ctext)
 }
@@ -20,9 +20,9 @@ type button struct {
 
 // shared code for setting up buttons, check boxes, etc.
 func newButton(text string) *button {
-	ctext := togstr(text)
+	ctext := togstr(toUnderlineMnemonic(text))
 	defer freegstr(ctext)
-	widget := C.gtk_button_new_with_label(ctext)
+	widget := C.gtk_button_new_with_mnemonic(ctext)
 	b := &button{
 		controlSingleWidget: newControlSingleWidget(widget),
 		button:  (*C.GtkButton)(unsafe.Pointer(widget)),
@@ -41,11 +41,11 @@ func (b *button) OnClicked(e func()) {
 }
 
 func (b *button) Text() string {
-	return fromgstr(C.gtk_button_get_label(b.button))
+	return fromUnderlineMnemonic(fromgstr(C.gtk_button_get_label(b.button)))
 }
 
 func (b *button) SetText(text string) {
-	ctext := togstr(text)
+	ctext := togstr(toUnderlineMnemonic(text))
 	defer freegstr(ctext)
 	C.gtk_button_set_label(b.button, ctext)
 }
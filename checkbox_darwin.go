@@ -12,16 +12,15 @@ import "C"
 type checkbox struct {
 	*controlSingleObject
 	toggled *event
+	text    string // as given, with the & mnemonic markers that Mac OS X doesn't show
 }
 
 func newCheckbox(text string) *checkbox {
-	ctext := C.CString(text)
-	defer C.free(unsafe.Pointer(ctext))
 	c := &checkbox{
 		controlSingleObject:		newControlSingleObject(C.newCheckbox()),
 		toggled: newEvent(),
 	}
-	C.buttonSetText(c.id, ctext)
+	c.SetText(text)
 	C.checkboxSetDelegate(c.id, unsafe.Pointer(c))
 	return c
 }
@@ -31,11 +30,12 @@ func (c *checkbox) OnToggled(e func()) {
 }
 
 func (c *checkbox) Text() string {
-	return C.GoString(C.buttonText(c.id))
+	return c.text
 }
 
 func (c *checkbox) SetText(text string) {
-	ctext := C.CString(text)
+	c.text = text
+	ctext := C.CString(stripMnemonic(text))
 	defer C.free(unsafe.Pointer(ctext))
 	C.buttonSetText(c.id, ctext)
 }
@@ -18,12 +18,13 @@ type checkbox struct {
 	toggle   *C.GtkToggleButton
 	checkbox *C.GtkCheckButton
 	toggled  *event
+	setting  bool // so SetChecked() doesn't trigger the event
 }
 
 func newCheckbox(text string) *checkbox {
-	ctext := togstr(text)
+	ctext := togstr(toUnderlineMnemonic(text))
 	defer freegstr(ctext)
-	widget := C.gtk_check_button_new_with_label(ctext)
+	widget := C.gtk_check_button_new_with_mnemonic(ctext)
 	c := &checkbox{
 		controlSingleWidget:  newControlSingleWidget(widget),
 		button:   (*C.GtkButton)(unsafe.Pointer(widget)),
@@ -44,11 +45,11 @@ func (c *checkbox) OnToggled(e func()) {
 }
 
 func (c *checkbox) Text() string {
-	return fromgstr(C.gtk_button_get_label(c.button))
+	return fromUnderlineMnemonic(fromgstr(C.gtk_button_get_label(c.button)))
 }
 
 func (c *checkbox) SetText(text string) {
-	ctext := togstr(text)
+	ctext := togstr(toUnderlineMnemonic(text))
 	defer freegstr(ctext)
 	C.gtk_button_set_label(c.button, ctext)
 }
@@ -58,11 +59,16 @@ func (c *checkbox) Checked() bool {
 }
 
 func (c *checkbox) SetChecked(checked bool) {
+	c.setting = true
 	C.gtk_toggle_button_set_active(c.toggle, togbool(checked))
+	c.setting = false
 }
 
 //export checkboxToggled
 func checkboxToggled(bwid *C.GtkToggleButton, data C.gpointer) {
 	c := (*checkbox)(unsafe.Pointer(data))
+	if c.setting {
+		return
+	}
 	c.toggled.fire()
 }
@@ -0,0 +1,138 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"fmt"
+)
+
+// ClipboardFormat names a kind of data on the clipboard by its MIME type.
+// Besides ClipboardText and ClipboardHTML, which every system knows, a program can use any MIME type for data of its own, such as "application/x-myeditor-shapes"; other programs that use the same MIME type can paste it too.
+type ClipboardFormat string
+
+const (
+	// ClipboardText is plain text, in UTF-8.
+	// Whatever the system calls text on the clipboard (and most have several names for it) is pasted as ClipboardText.
+	ClipboardText ClipboardFormat = "text/plain"
+
+	// ClipboardHTML is HTML, in UTF-8, as copied by web browsers and word processors to keep formatting.
+	// Programs that copy HTML should copy the same thing as ClipboardText as well, for programs that can't paste HTML.
+	ClipboardHTML ClipboardFormat = "text/html"
+)
+
+// ClipboardItem is the data for one format given to SetClipboard.
+type ClipboardItem struct {
+	Format ClipboardFormat
+
+	// Data is the data in Format.
+	Data []byte
+
+	// If Render is not nil, Data is ignored; instead, Render is called on the main thread to produce the data when a program actually pastes it in Format.
+	// This is for formats that take time or memory to produce, such as a rich text copy of a large selection, so that it's only done if something wants it.
+	// Render may be called more than once, and at any time until the clipboard is replaced, so it must return the data as it was when SetClipboard was called, not as it is now.
+	// When the program quits, data that was never rendered is lost.
+	// On GTK+, a running clipboard manager asks for everything first, so nothing is lost; without one, everything the program put on the clipboard is lost, Data included.
+	Render func() []byte
+}
+
+var (
+	// the items given to the last SetClipboard, while the clipboard still holds them; the per-platform code renders from these
+	clipboardItems []ClipboardItem
+
+	// counts calls to SetClipboard, so that news of losing the clipboard that comes late (some systems only say so once the next SetClipboard has already begun) doesn't throw away the new items
+	clipboardGen uintptr
+)
+
+// SetClipboard replaces what's on the clipboard with items.
+// Each item should be the same data in a different format, best first; the program pasting picks the first format it can use.
+// It panics if items is empty or has two items with the same Format.
+func SetClipboard(items ...ClipboardItem) {
+	if len(items) == 0 {
+		panic("no items given to SetClipboard()")
+	}
+	seen := make(map[ClipboardFormat]bool)
+	for _, item := range items {
+		if seen[item.Format] {
+			panic(fmt.Errorf("format %q given twice to SetClipboard()", item.Format))
+		}
+		seen[item.Format] = true
+	}
+	clipboardGen++
+	clipboardItems = append([]ClipboardItem(nil), items...)
+	setClipboard(clipboardItems, clipboardGen)
+}
+
+// ClipboardFormats returns the formats of what's on the clipboard, best first.
+// Formats the system has no MIME type for are left out; on Windows, this is every format registered by a program that doesn't use package ui except HTML.
+func ClipboardFormats() []ClipboardFormat {
+	return clipboardFormats()
+}
+
+// Clipboard returns what's on the clipboard in format; ok is false if the clipboard has nothing in that format.
+// If another program holds the clipboard, Clipboard and ClipboardFormats have to ask it and wait for the answer, so don't call them more often than needed (for instance, on every keystroke).
+func Clipboard(format ClipboardFormat) (data []byte, ok bool) {
+	return clipboardData(format)
+}
+
+// clipboardRender returns the data of clipboardItems[i], for the per-platform code to hand over when a program pastes it
+func clipboardRender(i int) []byte {
+	item := clipboardItems[i]
+	if item.Render != nil {
+		return item.Render()
+	}
+	return item.Data
+}
+
+// clipboardIndex returns the index in clipboardItems of format, or -1 if it isn't there
+func clipboardIndex(format ClipboardFormat) int {
+	for i, item := range clipboardItems {
+		if item.Format == format {
+			return i
+		}
+	}
+	return -1
+}
+
+// clipboardLost is called by the per-platform code when something replaces what the SetClipboard numbered gen put on the clipboard, so that the data and the Render functions can be collected
+func clipboardLost(gen uintptr) {
+	if gen == clipboardGen {
+		clipboardItems = nil
+	}
+}
+
+// ClipboardWatcher tells a program when what's on the clipboard changes, for instance to enable and disable a Paste menu item.
+type ClipboardWatcher struct {
+	changed func()
+}
+
+var (
+	clipboardWatchers       = make(map[*ClipboardWatcher]struct{})
+	clipboardMonitorStarted bool
+)
+
+// WatchClipboard creates a ClipboardWatcher that calls changed on the main thread whenever what's on the clipboard changes, including by the program's own SetClipboard.
+// On Mac OS X, which has no notification for this, the clipboard is checked periodically, so changed may come up to a second late.
+func WatchClipboard(changed func()) *ClipboardWatcher {
+	if !clipboardMonitorStarted {
+		startClipboardMonitor()
+		clipboardMonitorStarted = true
+	}
+	w := &ClipboardWatcher{
+		changed: changed,
+	}
+	clipboardWatchers[w] = struct{}{}
+	return w
+}
+
+// Stop stops the ClipboardWatcher; changed will not be called again.
+func (w *ClipboardWatcher) Stop() {
+	delete(clipboardWatchers, w)
+}
+
+// clipboardChanged is called by the per-platform code on the main thread when what's on the clipboard changes.
+// As with NetworkWatcher, the system monitor is left running after the last ClipboardWatcher stops.
+func clipboardChanged() {
+	for w := range clipboardWatchers {
+		w.changed()
+	}
+}
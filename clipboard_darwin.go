@@ -0,0 +1,97 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"time"
+	"unsafe"
+)
+
+// #include "objc_darwin.h"
+import "C"
+
+func setClipboard(items []ClipboardItem, gen uintptr) {
+	C.clipboardBegin(C.uintptr_t(gen))
+	for i, item := range items {
+		cformat := C.CString(string(item.Format))
+		if item.Render != nil {
+			C.clipboardAddDelayed(cformat, C.intptr_t(i))
+		} else {
+			data, size := clipboardBytes(clipboardRender(i))
+			C.clipboardAddData(cformat, data, size)
+		}
+		C.free(unsafe.Pointer(cformat))
+	}
+	C.clipboardEnd()
+}
+
+// a program's own formats can be empty, but NSData still wants a pointer
+func clipboardBytes(data []byte) (unsafe.Pointer, C.intptr_t) {
+	if len(data) == 0 {
+		var empty [1]byte
+		return unsafe.Pointer(&empty[0]), 0
+	}
+	return unsafe.Pointer(&data[0]), C.intptr_t(len(data))
+}
+
+//export clipboardProvide
+func clipboardProvide(gen C.uintptr_t, i C.intptr_t, item C.id, ctype C.id) {
+	if uintptr(gen) != clipboardGen { // shouldn't happen, as the provider is finished once something else is on the pasteboard
+		return
+	}
+	data, size := clipboardBytes(clipboardRender(int(i)))
+	C.clipboardItemSetData(item, ctype, data, size)
+}
+
+//export clipboardFinished
+func clipboardFinished(gen C.uintptr_t) {
+	clipboardLost(uintptr(gen))
+}
+
+func clipboardFormats() []ClipboardFormat {
+	n := int(C.clipboardFormatCount())
+	formats := make([]ClipboardFormat, 0, n)
+	seen := make(map[ClipboardFormat]bool)
+	for i := 0; i < n; i++ {
+		cformat := C.clipboardFormat(C.intptr_t(i))
+		if cformat == nil {
+			continue
+		}
+		f := ClipboardFormat(C.GoString(cformat))
+		C.free(unsafe.Pointer(cformat))
+		// text can be on the pasteboard in several encodings, which all have the same MIME type
+		if !seen[f] {
+			seen[f] = true
+			formats = append(formats, f)
+		}
+	}
+	return formats
+}
+
+func clipboardData(format ClipboardFormat) ([]byte, bool) {
+	var size C.intptr_t
+
+	cformat := C.CString(string(format))
+	defer C.free(unsafe.Pointer(cformat))
+	p := C.clipboardData(cformat, &size)
+	if p == nil {
+		return nil, false
+	}
+	data := C.GoBytes(p, C.int(size))
+	C.free(p)
+	return data, true
+}
+
+// clipboardPollInterval is how often the pasteboard is checked for changes, as Mac OS X has no notification for them
+const clipboardPollInterval = 1 * time.Second
+
+func startClipboardMonitor() {
+	last := C.clipboardChangeCount()
+	ticker := time.NewTicker(clipboardPollInterval)
+	NewForeignEvent(ticker.C, func(interface{}) {
+		if count := C.clipboardChangeCount(); count != last {
+			last = count
+			clipboardChanged()
+		}
+	})
+}
@@ -0,0 +1,169 @@
+// 15 october 2026
+
+#include "objc_darwin.h"
+#include "_cgo_export.h"
+#import <Cocoa/Cocoa.h>
+#import <CoreServices/CoreServices.h>
+
+#define toNSPasteboardItem(x) ((NSPasteboardItem *) (x))
+#define toNSString(x) ((NSString *) (x))
+
+// pasteboard types are UTIs, not MIME types; for MIME types it doesn't know, the system makes up a UTI, which is the same in every program
+static NSString *toPasteboardType(char *format)
+{
+	NSString *mime;
+
+	mime = [NSString stringWithUTF8String:format];
+	if ([mime isEqual:@"text/plain"])
+		return NSPasteboardTypeString;
+	if ([mime isEqual:@"text/html"])
+		return NSPasteboardTypeHTML;
+	return [(NSString *) UTTypeCreatePreferredIdentifierForTag(kUTTagClassMIMEType, (CFStringRef) mime, NULL) autorelease];
+}
+
+// returns nil for types with no MIME type, such as the ones only Apple's programs use
+static NSString *fromPasteboardType(NSString *type)
+{
+	if ([type isEqual:NSPasteboardTypeString])
+		return @"text/plain";
+	if ([type isEqual:NSPasteboardTypeHTML])
+		return @"text/html";
+	return [(NSString *) UTTypeCopyPreferredTagWithClass((CFStringRef) type, kUTTagClassMIMEType) autorelease];
+}
+
+@interface goClipboardProvider : NSObject <NSPasteboardItemDataProvider> {
+@public
+	uintptr_t gen;
+	NSMutableDictionary *indices;		// pasteboard type -> index into clipboardItems
+}
+@end
+
+@implementation goClipboardProvider
+
+- (void)pasteboard:(NSPasteboard *)pasteboard item:(NSPasteboardItem *)item provideDataForType:(NSString *)type
+{
+	NSNumber *i;
+
+	i = [self->indices objectForKey:type];
+	if (i != nil)
+		clipboardProvide(self->gen, (intptr_t) [i integerValue], item, type);
+}
+
+- (void)pasteboardFinishedWithDataProvider:(NSPasteboard *)pasteboard
+{
+	clipboardFinished(self->gen);
+}
+
+- (void)dealloc
+{
+	[self->indices release];
+	[super dealloc];
+}
+
+@end
+
+// SetClipboard builds one NSPasteboardItem with clipboardBegin(), clipboardAddData(), and clipboardAddDelayed(), then clipboardEnd() puts it on the pasteboard
+static NSPasteboardItem *newItem = nil;
+static goClipboardProvider *newProvider = nil;
+static NSMutableArray *newDelayed = nil;
+
+void clipboardBegin(uintptr_t gen)
+{
+	newItem = [NSPasteboardItem new];
+	newProvider = [goClipboardProvider new];
+	newProvider->gen = gen;
+	newProvider->indices = [NSMutableDictionary new];
+	newDelayed = [NSMutableArray new];
+}
+
+void clipboardAddData(char *format, void *data, intptr_t size)
+{
+	[newItem setData:[NSData dataWithBytes:data length:(NSUInteger) size] forType:toPasteboardType(format)];
+}
+
+void clipboardAddDelayed(char *format, intptr_t index)
+{
+	NSString *type;
+
+	type = toPasteboardType(format);
+	[newProvider->indices setObject:[NSNumber numberWithInteger:(NSInteger) index] forKey:type];
+	[newDelayed addObject:type];
+}
+
+void clipboardEnd(void)
+{
+	NSPasteboard *pb;
+
+	if ([newDelayed count] != 0)
+		[newItem setDataProvider:newProvider forTypes:newDelayed];
+	pb = [NSPasteboard generalPasteboard];
+	[pb clearContents];
+	[pb writeObjects:[NSArray arrayWithObject:newItem]];
+	// the item holds on to the provider for as long as it needs it
+	[newDelayed release];
+	[newProvider release];
+	[newItem release];
+	newItem = nil;
+	newProvider = nil;
+	newDelayed = nil;
+}
+
+void clipboardItemSetData(id item, id type, void *data, intptr_t size)
+{
+	[toNSPasteboardItem(item) setData:[NSData dataWithBytes:data length:(NSUInteger) size] forType:toNSString(type)];
+}
+
+intptr_t clipboardFormatCount(void)
+{
+	return (intptr_t) [[[NSPasteboard generalPasteboard] types] count];
+}
+
+// returns NULL if the type has no MIME type (or if the pasteboard changed since clipboardFormatCount() and there is no type i anymore)
+char *clipboardFormat(intptr_t i)
+{
+	NSArray *types;
+	NSString *mime;
+
+	types = [[NSPasteboard generalPasteboard] types];
+	if (i >= (intptr_t) [types count])
+		return NULL;
+	mime = fromPasteboardType((NSString *) [types objectAtIndex:(NSUInteger) i]);
+	if (mime == nil)
+		return NULL;
+	return strdup([mime UTF8String]);
+}
+
+// returns a malloc()'d copy of the data, or NULL if the pasteboard has nothing in format
+void *clipboardData(char *format, intptr_t *size)
+{
+	NSPasteboard *pb;
+	NSString *type;
+	NSString *text;
+	NSData *d;
+	void *data;
+
+	pb = [NSPasteboard generalPasteboard];
+	type = toPasteboardType(format);
+	if ([type isEqual:NSPasteboardTypeString]) {
+		// this also gets text that's on the pasteboard in other forms, such as UTF-16
+		text = [pb stringForType:type];
+		if (text == nil)
+			return NULL;
+		d = [text dataUsingEncoding:NSUTF8StringEncoding];
+	} else
+		d = [pb dataForType:type];
+	if (d == nil)
+		return NULL;
+	*size = (intptr_t) [d length];
+	// malloc(0) can return NULL, which would look like no data
+	data = malloc([d length] + 1);
+	if (data == NULL)
+		[NSException raise:@"memory exhausted copying clipboard data" format:@""];
+	memcpy(data, [d bytes], [d length]);
+	return data;
+}
+
+intptr_t clipboardChangeCount(void)
+{
+	return (intptr_t) [[NSPasteboard generalPasteboard] changeCount];
+}
@@ -0,0 +1,44 @@
+// +build !windows,!darwin
+
+// 15 october 2026
+
+#include "gtk_unix.h"
+#include "_cgo_export.h"
+
+GtkClipboard *getClipboard(void)
+{
+	return gtk_clipboard_get(GDK_SELECTION_CLIPBOARD);
+}
+
+static void clipboardGet(GtkClipboard *clipboard, GtkSelectionData *sel, guint info, gpointer data)
+{
+	clipboardGetData(sel, info, (guintptr) data);
+}
+
+static void clipboardClear(GtkClipboard *clipboard, gpointer data)
+{
+	clipboardCleared((guintptr) data);
+}
+
+// the info of each target is the index of its item; text is offered under all the names GTK+ knows for it
+// nothing is rendered until a program asks for it, so everything goes through clipboardGet(), not just delayed items
+void setClipboard(gchar **formats, gboolean *istext, gint n, guintptr gen)
+{
+	GtkTargetList *list;
+	GtkTargetEntry *targets;
+	gint i, ntargets;
+
+	list = gtk_target_list_new(NULL, 0);
+	for (i = 0; i < n; i++)
+		if (istext[i])
+			gtk_target_list_add_text_targets(list, (guint) i);
+		else
+			gtk_target_list_add(list, gdk_atom_intern(formats[i], FALSE), 0, (guint) i);
+	targets = gtk_target_table_new_from_list(list, &ntargets);
+	// this calls clipboardClear() for what we put there before, if anything; gen tells clipboardCleared() which that was
+	gtk_clipboard_set_with_data(getClipboard(), targets, (guint) ntargets, clipboardGet, clipboardClear, (gpointer) gen);
+	// if there's a clipboard manager, it gets all of it when the program quits, so it can still be pasted afterward
+	gtk_clipboard_set_can_store(getClipboard(), NULL, 0);
+	gtk_target_table_free(targets, ntargets);
+	gtk_target_list_unref(list);
+}
@@ -0,0 +1,141 @@
+// +build !windows,!darwin
+
+// 15 october 2026
+
+package ui
+
+import (
+	"strings"
+	"unicode/utf16"
+	"unsafe"
+)
+
+// #include "gtk_unix.h"
+// extern void clipboardOwnerChange(GtkClipboard *, GdkEvent *, gpointer);
+import "C"
+
+func setClipboard(items []ClipboardItem, gen uintptr) {
+	formats := make([]*C.gchar, len(items))
+	istext := make([]C.gboolean, len(items))
+	for i, item := range items {
+		formats[i] = togstr(string(item.Format))
+		defer freegstr(formats[i])
+		istext[i] = togbool(item.Format == ClipboardText)
+	}
+	C.setClipboard(&formats[0], &istext[0], C.gint(len(items)), C.guintptr(gen))
+}
+
+//export clipboardGetData
+func clipboardGetData(sel *C.GtkSelectionData, info C.guint, gen C.guintptr) {
+	if uintptr(gen) != clipboardGen { // shouldn't happen, as GTK+ stops asking once clipboardCleared() is called
+		return
+	}
+	i := int(info)
+	format := clipboardItems[i].Format
+	data := clipboardRender(i)
+	if format == ClipboardText {
+		// this converts to whichever of GTK+'s names for text was asked for
+		ctext := togstr(string(data))
+		defer freegstr(ctext)
+		C.gtk_selection_data_set_text(sel, ctext, -1)
+		return
+	}
+	var p *C.guchar
+	if len(data) != 0 {
+		p = (*C.guchar)(unsafe.Pointer(&data[0]))
+	}
+	C.gtk_selection_data_set(sel, C.gtk_selection_data_get_target(sel), 8, p, C.gint(len(data)))
+}
+
+//export clipboardCleared
+func clipboardCleared(gen C.guintptr) {
+	clipboardLost(uintptr(gen))
+}
+
+// these run a main loop of their own until the owner of the clipboard answers
+func clipboardFormats() []ClipboardFormat {
+	var atoms *C.GdkAtom
+	var n C.gint
+
+	if C.gtk_clipboard_wait_for_targets(C.getClipboard(), &atoms, &n) == C.FALSE {
+		return nil
+	}
+	defer C.g_free(C.gpointer(unsafe.Pointer(atoms)))
+	targets := (*[1 << 16]C.GdkAtom)(unsafe.Pointer(atoms))[:n:n]
+	formats := make([]ClipboardFormat, 0, len(targets))
+	seen := make(map[ClipboardFormat]bool)
+	for i := range targets {
+		var f ClipboardFormat
+
+		// text goes by many names (UTF8_STRING, text/plain;charset=utf-8, and so on), all of which are ClipboardText
+		if C.gtk_targets_include_text(&targets[i], 1) != C.FALSE {
+			f = ClipboardText
+		} else {
+			name := C.gdk_atom_name(targets[i])
+			f = ClipboardFormat(fromgstr(name))
+			C.g_free(C.gpointer(unsafe.Pointer(name)))
+			// the rest of the targets that aren't MIME types are X11's own, such as TARGETS and TIMESTAMP
+			if !strings.Contains(string(f), "/") {
+				continue
+			}
+		}
+		if !seen[f] {
+			seen[f] = true
+			formats = append(formats, f)
+		}
+	}
+	return formats
+}
+
+func clipboardData(format ClipboardFormat) ([]byte, bool) {
+	if format == ClipboardText {
+		text := C.gtk_clipboard_wait_for_text(C.getClipboard())
+		if text == nil {
+			return nil, false
+		}
+		defer C.g_free(C.gpointer(unsafe.Pointer(text)))
+		return []byte(fromgstr(text)), true
+	}
+	cformat := togstr(string(format))
+	defer freegstr(cformat)
+	sel := C.gtk_clipboard_wait_for_contents(C.getClipboard(), C.gdk_atom_intern(cformat, C.FALSE))
+	if sel == nil {
+		return nil, false
+	}
+	defer C.gtk_selection_data_free(sel)
+	n := C.gtk_selection_data_get_length(sel)
+	if n < 0 {
+		return nil, false
+	}
+	data := C.GoBytes(unsafe.Pointer(C.gtk_selection_data_get_data(sel)), n)
+	if format == ClipboardHTML {
+		data = htmlToUTF8(data)
+	}
+	return data, true
+}
+
+// Firefox, among others, puts HTML on the clipboard in UTF-16 with a byte order mark
+func htmlToUTF8(data []byte) []byte {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xFE {
+		return data
+	}
+	u := make([]uint16, (len(data)-2)/2)
+	for i := range u {
+		u[i] = uint16(data[2+2*i]) | uint16(data[3+2*i])<<8
+	}
+	return []byte(string(utf16.Decode(u)))
+}
+
+// owner-change needs the XFIXES extension on X11; without it, changes are never reported
+func startClipboardMonitor() {
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(C.getClipboard())),
+		"owner-change",
+		C.GCallback(C.clipboardOwnerChange),
+		nil)
+}
+
+//export clipboardOwnerChange
+func clipboardOwnerChange(clipboard *C.GtkClipboard, event *C.GdkEvent, data C.gpointer) {
+	clipboardChanged()
+}
@@ -0,0 +1,117 @@
+// 15 october 2026
+
+#include "winapi_windows.h"
+#include "_cgo_export.h"
+
+// the message-only window owns the clipboard when we put something there, so it gets WM_RENDERFORMAT and WM_DESTROYCLIPBOARD
+// it is also our clipboard viewer; AddClipboardFormatListener() would be simpler, but it needs Vista
+
+// another program can have the clipboard open for a moment, so try a few times before giving up
+BOOL clipboardOpen(void)
+{
+	int i;
+
+	for (i = 0; i < 5; i++) {
+		if (OpenClipboard(msgwin) != 0)
+			return TRUE;
+		Sleep(20);
+	}
+	return FALSE;
+}
+
+void clipboardClose(void)
+{
+	if (CloseClipboard() == 0)
+		xpanic("error closing clipboard", GetLastError());
+}
+
+// this sends WM_DESTROYCLIPBOARD to whoever had the clipboard before, which can be us
+void clipboardEmpty(void)
+{
+	if (EmptyClipboard() == 0)
+		xpanic("error emptying clipboard", GetLastError());
+}
+
+// data is NULL for delayed rendering; WM_RENDERFORMAT comes when a program pastes format
+void clipboardSet(UINT format, void *data, SIZE_T size)
+{
+	HGLOBAL h;
+	void *p;
+
+	if (data == NULL) {
+		// this fails if we don't have the clipboard open, which is fine; that only happens if someone else took the clipboard in the meantime
+		SetClipboardData(format, NULL);
+		return;
+	}
+	// GlobalAlloc() can't make an empty block that can be locked, so an empty item comes out as one zero byte
+	if (size == 0)
+		size = 1;
+	h = GlobalAlloc(GMEM_MOVEABLE | GMEM_ZEROINIT, size);
+	if (h == NULL)
+		xpanic("error allocating clipboard data", GetLastError());
+	p = GlobalLock(h);
+	if (p == NULL)
+		xpanic("error locking clipboard data to fill it", GetLastError());
+	memcpy(p, data, size);
+	GlobalUnlock(h);
+	// the system owns h once this succeeds
+	if (SetClipboardData(format, h) == NULL)
+		GlobalFree(h);
+}
+
+// returns a malloc()'d copy of the data, or NULL if the clipboard doesn't have format
+void *clipboardGet(UINT format, SIZE_T *size)
+{
+	HGLOBAL h;
+	void *p;
+	void *data;
+
+	h = GetClipboardData(format);
+	if (h == NULL)
+		return NULL;
+	*size = GlobalSize(h);
+	p = GlobalLock(h);
+	if (p == NULL)
+		return NULL;
+	// malloc(0) can return NULL, which would look like no data
+	data = malloc(*size + 1);
+	if (data == NULL)
+		xpanic("memory exhausted copying clipboard data", GetLastError());
+	memcpy(data, p, *size);
+	GlobalUnlock(h);
+	return data;
+}
+
+static HWND nextClipboardViewer = NULL;
+
+void startClipboardViewer(void)
+{
+	// NULL is both an error and there being no other viewer; either way there's no one to pass messages on to
+	nextClipboardViewer = SetClipboardViewer(msgwin);
+}
+
+// called by msgwinproc(); returns whether it handled the message
+BOOL clipboardMessage(UINT uMsg, WPARAM wParam, LPARAM lParam)
+{
+	switch (uMsg) {
+	case WM_RENDERFORMAT:
+		clipboardRenderFormat((UINT) wParam);
+		return TRUE;
+	case WM_DESTROYCLIPBOARD:
+		clipboardDestroyed();
+		return TRUE;
+	case WM_DRAWCLIPBOARD:
+		clipboardViewerChanged();
+		if (nextClipboardViewer != NULL)
+			SendMessageW(nextClipboardViewer, uMsg, wParam, lParam);
+		return TRUE;
+	case WM_CHANGECBCHAIN:
+		// keep the chain together when a viewer leaves it
+		if ((HWND) wParam == nextClipboardViewer)
+			nextClipboardViewer = (HWND) lParam;
+		else if (nextClipboardViewer != NULL)
+			SendMessageW(nextClipboardViewer, uMsg, wParam, lParam);
+		return TRUE;
+	}
+	return FALSE;
+}
@@ -0,0 +1,204 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall"
+	"unicode/utf16"
+	"unsafe"
+)
+
+// #include "winapi_windows.h"
+import "C"
+
+// the SetClipboard whose items are on the clipboard, for clipboardDestroyed(); see clipboardGen
+var clipboardOwnedGen uintptr
+
+// if another program keeps the clipboard open, there's nothing we can do but give up
+func setClipboard(items []ClipboardItem, gen uintptr) {
+	if C.clipboardOpen() == C.FALSE {
+		return
+	}
+	defer C.clipboardClose()
+	C.clipboardEmpty() // WM_DESTROYCLIPBOARD for our previous SetClipboard comes now, before clipboardOwnedGen changes
+	clipboardOwnedGen = gen
+	for i, item := range items {
+		if item.Render != nil {
+			C.clipboardSet(clipboardFormatID(item.Format), nil, 0)
+			continue
+		}
+		setClipboardData(item.Format, clipboardRender(i))
+	}
+}
+
+func setClipboardData(format ClipboardFormat, data []byte) {
+	var empty [1]byte
+
+	data = toClipboardNative(format, data)
+	// a program's own formats can be empty, but clipboardSet() takes NULL to mean delayed rendering
+	p := unsafe.Pointer(&empty[0])
+	if len(data) != 0 {
+		p = unsafe.Pointer(&data[0])
+	}
+	C.clipboardSet(clipboardFormatID(format), p, C.SIZE_T(len(data)))
+}
+
+//export clipboardRenderFormat
+func clipboardRenderFormat(id C.UINT) {
+	for i, item := range clipboardItems {
+		if clipboardFormatID(item.Format) == id {
+			setClipboardData(item.Format, clipboardRender(i))
+			return
+		}
+	}
+}
+
+//export clipboardDestroyed
+func clipboardDestroyed() {
+	clipboardLost(clipboardOwnedGen)
+}
+
+// RegisterClipboardFormat() returns the same number for the same name every time, in every program, so our own formats are registered under their MIME types
+func clipboardFormatID(format ClipboardFormat) C.UINT {
+	switch format {
+	case ClipboardText:
+		return C.CF_UNICODETEXT
+	case ClipboardHTML:
+		return C.RegisterClipboardFormatW(toUTF16("HTML Format"))
+	}
+	return C.RegisterClipboardFormatW(toUTF16(string(format)))
+}
+
+func clipboardFormats() []ClipboardFormat {
+	if C.clipboardOpen() == C.FALSE {
+		return nil
+	}
+	defer C.clipboardClose()
+	html := clipboardFormatID(ClipboardHTML)
+	var formats []ClipboardFormat
+	seen := make(map[ClipboardFormat]bool)
+	for id := C.EnumClipboardFormats(0); id != 0; id = C.EnumClipboardFormats(id) {
+		var f ClipboardFormat
+
+		switch {
+		// the system converts between these on its own
+		case id == C.CF_UNICODETEXT || id == C.CF_TEXT || id == C.CF_OEMTEXT:
+			f = ClipboardText
+		case id == html:
+			f = ClipboardHTML
+		case id >= 0xC000: // registered formats; see clipboardFormatID()
+			f = ClipboardFormat(clipboardFormatName(id))
+			if !strings.Contains(string(f), "/") {
+				continue
+			}
+		default: // the rest of the standard formats, such as bitmaps
+			continue
+		}
+		if !seen[f] {
+			seen[f] = true
+			formats = append(formats, f)
+		}
+	}
+	return formats
+}
+
+func clipboardFormatName(id C.UINT) string {
+	buf := make([]uint16, 256)
+	n := C.GetClipboardFormatNameW(id, C.LPWSTR(unsafe.Pointer(&buf[0])), C.int(len(buf)))
+	return syscall.UTF16ToString(buf[:n])
+}
+
+func clipboardData(format ClipboardFormat) ([]byte, bool) {
+	var size C.SIZE_T
+
+	if C.clipboardOpen() == C.FALSE {
+		return nil, false
+	}
+	defer C.clipboardClose()
+	p := C.clipboardGet(clipboardFormatID(format), &size)
+	if p == nil {
+		return nil, false
+	}
+	data := C.GoBytes(p, C.int(size))
+	C.free(p)
+	return fromClipboardNative(format, data), true
+}
+
+// text is UTF-16 on Windows, and HTML comes wrapped in a header that says where the HTML is (see "HTML Clipboard Format" on MSDN)
+const (
+	cfhtmlHeader = "Version:0.9\r\nStartHTML:%010d\r\nEndHTML:%010d\r\nStartFragment:%010d\r\nEndFragment:%010d\r\n"
+	cfhtmlPrefix = "<html><body>\r\n<!--StartFragment-->"
+	cfhtmlSuffix = "<!--EndFragment-->\r\n</body>\r\n</html>"
+)
+
+func toClipboardNative(format ClipboardFormat, data []byte) []byte {
+	switch format {
+	case ClipboardText:
+		u := utf16.Encode([]rune(string(data)))
+		b := make([]byte, 2*len(u)+2) // and the terminating null
+		for i, c := range u {
+			b[2*i] = byte(c)
+			b[2*i+1] = byte(c >> 8)
+		}
+		return b
+	case ClipboardHTML:
+		start := len(fmt.Sprintf(cfhtmlHeader, 0, 0, 0, 0))
+		startFragment := start + len(cfhtmlPrefix)
+		endFragment := startFragment + len(data)
+		end := endFragment + len(cfhtmlSuffix)
+		s := fmt.Sprintf(cfhtmlHeader, start, end, startFragment, endFragment) + cfhtmlPrefix + string(data) + cfhtmlSuffix + "\x00"
+		return []byte(s)
+	}
+	return data
+}
+
+func fromClipboardNative(format ClipboardFormat, data []byte) []byte {
+	switch format {
+	case ClipboardText:
+		u := make([]uint16, len(data)/2)
+		for i := range u {
+			u[i] = uint16(data[2*i]) | uint16(data[2*i+1])<<8
+		}
+		return []byte(syscall.UTF16ToString(u))
+	case ClipboardHTML:
+		// the fragment is what was copied; the rest is there to make it a whole document
+		start := cfhtmlOffset(data, "StartFragment:")
+		end := cfhtmlOffset(data, "EndFragment:")
+		if start < 0 || end < start || end > len(data) {
+			return data
+		}
+		return data[start:end]
+	}
+	return data
+}
+
+// cfhtmlOffset returns the number after field in the header of CF_HTML data, or -1 if it isn't there
+func cfhtmlOffset(data []byte, field string) int {
+	s := string(data)
+	i := strings.Index(s, field)
+	if i == -1 {
+		return -1
+	}
+	s = s[i+len(field):]
+	if j := strings.IndexAny(s, "\r\n"); j != -1 {
+		s = s[:j]
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// SetClipboardViewer() sends WM_DRAWCLIPBOARD right away, but there are no ClipboardWatchers yet to hear it
+func startClipboardMonitor() {
+	C.startClipboardViewer()
+}
+
+//export clipboardViewerChanged
+func clipboardViewerChanged() {
+	clipboardChanged()
+}
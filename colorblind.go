@@ -0,0 +1,136 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"image"
+	"math"
+)
+
+// ColorBlindness is a kind of color blindness for Window.SimulateColorBlindness.
+type ColorBlindness uint
+
+const (
+	// NormalVision turns the simulation off.
+	NormalVision ColorBlindness = iota
+
+	// Protanopia is missing red cones: reds look darker and are confused with greens.
+	Protanopia
+
+	// Deuteranopia is missing green cones, the most common kind: reds and greens are confused.
+	Deuteranopia
+
+	// Tritanopia is missing blue cones, which is rare: blues are confused with greens, and yellows with pinks.
+	Tritanopia
+)
+
+func (c ColorBlindness) String() string {
+	switch c {
+	case NormalVision:
+		return "NormalVision"
+	case Protanopia:
+		return "Protanopia"
+	case Deuteranopia:
+		return "Deuteranopia"
+	case Tritanopia:
+		return "Tritanopia"
+	}
+	return fmt.Sprintf("ColorBlindness(%d)", uint(c))
+}
+
+// these are the full-severity matrices of Machado, Oliveira, and Fernandes, "A Physiologically-based Model for Simulation of Color Vision Deficiency" (2009), which work on linear RGB
+var colorBlindnessMatrices = map[ColorBlindness][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// the sRGB curve both ways, as tables, since simulate() runs on every pixel of every paint; linear values are in 4096ths
+var (
+	srgbToLinear [256]float64
+	linearToSRGB [4096]uint8
+)
+
+func init() {
+	for i := range srgbToLinear {
+		v := float64(i) / 255
+		if v <= 0.04045 {
+			v /= 12.92
+		} else {
+			v = math.Pow((v+0.055)/1.055, 2.4)
+		}
+		srgbToLinear[i] = v
+	}
+	for i := range linearToSRGB {
+		v := float64(i) / float64(len(linearToSRGB)-1)
+		if v <= 0.0031308 {
+			v *= 12.92
+		} else {
+			v = 1.055*math.Pow(v, 1/2.4) - 0.055
+		}
+		linearToSRGB[i] = uint8(math.Floor(v*255 + 0.5))
+	}
+}
+
+// simulate changes the colors of img in place to how someone with c sees them
+// the pixels are premultiplied, which only makes a difference for translucent ones; for a debugging aid that's close enough, so long as no color ends up more than its alpha
+func (c ColorBlindness) simulate(img *image.RGBA) {
+	m, ok := colorBlindnessMatrices[c]
+	if !ok {
+		return
+	}
+	toSRGB := func(v float64, a uint8) uint8 {
+		if v <= 0 {
+			return 0
+		}
+		if v >= 1 {
+			return a
+		}
+		s := linearToSRGB[int(v*float64(len(linearToSRGB)-1)+0.5)]
+		if s > a {
+			return a
+		}
+		return s
+	}
+	r := img.Rect
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		p := img.Pix[img.PixOffset(r.Min.X, y):img.PixOffset(r.Max.X, y)]
+		for i := 0; i < len(p); i += 4 {
+			red := srgbToLinear[p[i]]
+			green := srgbToLinear[p[i+1]]
+			blue := srgbToLinear[p[i+2]]
+			a := p[i+3]
+			p[i] = toSRGB(m[0][0]*red+m[0][1]*green+m[0][2]*blue, a)
+			p[i+1] = toSRGB(m[1][0]*red+m[1][1]*green+m[1][2]*blue, a)
+			p[i+2] = toSRGB(m[2][0]*red+m[2][1]*green+m[2][2]*blue, a)
+		}
+	}
+}
+
+// for Window.SimulateColorBlindness()
+func simulateColorBlindness(c Control, kind ColorBlindness) {
+	if c == nil {
+		return
+	}
+	if a, ok := c.(Area); ok {
+		a.base().colorblindness = kind
+		a.RepaintAll()
+		return
+	}
+	for _, child := range c.childControls() {
+		simulateColorBlindness(child, kind)
+	}
+}
@@ -0,0 +1,54 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"image/color"
+	"unsafe"
+)
+
+// #include "objc_darwin.h"
+import "C"
+
+type colorButton struct {
+	*controlSingleObject
+	changed *event
+}
+
+func newColorButton(initial color.NRGBA) *colorButton {
+	b := &colorButton{
+		controlSingleObject: newControlSingleObject(C.newColorWell()),
+		changed:             newEvent(),
+	}
+	// NSColorWell has no size of its own to fit to
+	b.fpreferredSize = b.xpreferredSize
+	b.SetColor(initial)
+	C.colorWellSetDelegate(b.id, unsafe.Pointer(b))
+	return b
+}
+
+func (b *colorButton) Color() color.Color {
+	var r, g, bl C.uint8_t
+
+	C.colorWellColor(b.id, &r, &g, &bl)
+	return color.NRGBA{uint8(r), uint8(g), uint8(bl), 0xFF}
+}
+
+func (b *colorButton) SetColor(c color.Color) {
+	n := toOpaqueNRGBA(c)
+	C.colorWellSetColor(b.id, C.uint8_t(n.R), C.uint8_t(n.G), C.uint8_t(n.B))
+}
+
+func (b *colorButton) OnChanged(e func()) {
+	b.changed.set(e)
+}
+
+func (b *colorButton) xpreferredSize(d *sizing) (width, height int) {
+	return 44, 23
+}
+
+//export colorButtonChanged
+func colorButtonChanged(xb unsafe.Pointer) {
+	b := (*colorButton)(unsafe.Pointer(xb))
+	b.changed.fire()
+}
@@ -0,0 +1,79 @@
+// 15 october 2026
+
+#import "objc_darwin.h"
+#import "_cgo_export.h"
+#import <Cocoa/Cocoa.h>
+
+#define toNSColorWell(x) ((NSColorWell *) (x))
+
+// the color panel works in whatever color space the user picks, so convert before asking for components
+id toNSColor(uint8_t r, uint8_t g, uint8_t b)
+{
+	return (id) [NSColor colorWithCalibratedRed:((CGFloat) r) / 255
+		green:((CGFloat) g) / 255
+		blue:((CGFloat) b) / 255
+		alpha:1];
+}
+
+void fromNSColor(id color, uint8_t *r, uint8_t *g, uint8_t *b)
+{
+	NSColor *c;
+	CGFloat cr, cg, cb, ca;
+
+	c = [((NSColor *) color) colorUsingColorSpaceName:NSCalibratedRGBColorSpace];
+	if (c == nil) {		// pattern colors have no RGB
+		*r = 0;
+		*g = 0;
+		*b = 0;
+		return;
+	}
+	[c getRed:&cr green:&cg blue:&cb alpha:&ca];
+	*r = (uint8_t) (cr * 255 + 0.5);
+	*g = (uint8_t) (cg * 255 + 0.5);
+	*b = (uint8_t) (cb * 255 + 0.5);
+}
+
+@interface goColorWellDelegate : NSObject {
+@public
+	void *gocolorbutton;
+}
+@end
+
+@implementation goColorWellDelegate
+
+- (IBAction)colorWellChanged:(id)sender
+{
+	colorButtonChanged(self->gocolorbutton);
+}
+
+@end
+
+id newColorWell(void)
+{
+	NSColorWell *w;
+
+	// the size Interface Builder gives a new color well
+	w = [[NSColorWell alloc] initWithFrame:NSMakeRect(0, 0, 44, 23)];
+	[w setBordered:YES];
+	return (id) w;
+}
+
+void colorWellSetDelegate(id well, void *b)
+{
+	goColorWellDelegate *d;
+
+	d = [goColorWellDelegate new];
+	d->gocolorbutton = b;
+	[toNSColorWell(well) setTarget:d];
+	[toNSColorWell(well) setAction:@selector(colorWellChanged:)];
+}
+
+void colorWellColor(id well, uint8_t *r, uint8_t *g, uint8_t *b)
+{
+	fromNSColor((id) [toNSColorWell(well) color], r, g, b);
+}
+
+void colorWellSetColor(id well, uint8_t r, uint8_t g, uint8_t b)
+{
+	[toNSColorWell(well) setColor:toNSColor(r, g, b)];
+}
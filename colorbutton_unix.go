@@ -0,0 +1,60 @@
+// +build !windows,!darwin
+
+// 15 october 2026
+
+package ui
+
+import (
+	"image/color"
+	"unsafe"
+)
+
+// #include "gtk_unix.h"
+// extern void colorButtonColorSet(GtkColorButton *, gpointer);
+import "C"
+
+type colorButton struct {
+	*controlSingleWidget
+	cc      *C.GtkColorChooser
+	changed *event
+}
+
+func newColorButton(initial color.NRGBA) *colorButton {
+	rgba := toGdkRGBA(initial)
+	widget := C.gtk_color_button_new_with_rgba(&rgba)
+	b := &colorButton{
+		controlSingleWidget: newControlSingleWidget(widget),
+		cc:                  (*C.GtkColorChooser)(unsafe.Pointer(widget)),
+		changed:             newEvent(),
+	}
+	C.gtk_color_chooser_set_use_alpha(b.cc, C.FALSE)
+	// color-set is only sent for changes the user makes
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(widget)),
+		"color-set",
+		C.GCallback(C.colorButtonColorSet),
+		C.gpointer(unsafe.Pointer(b)))
+	return b
+}
+
+func (b *colorButton) Color() color.Color {
+	var rgba C.GdkRGBA
+
+	C.gtk_color_chooser_get_rgba(b.cc, &rgba)
+	return fromGdkRGBA(&rgba)
+}
+
+func (b *colorButton) SetColor(c color.Color) {
+	rgba := toGdkRGBA(toOpaqueNRGBA(c))
+	C.gtk_color_chooser_set_rgba(b.cc, &rgba)
+}
+
+func (b *colorButton) OnChanged(e func()) {
+	b.changed.set(e)
+}
+
+//export colorButtonColorSet
+func colorButtonColorSet(cb *C.GtkColorButton, data C.gpointer) {
+	b := (*colorButton)(unsafe.Pointer(data))
+	b.changed.fire()
+}
@@ -0,0 +1,45 @@
+// 15 october 2026
+
+#include "winapi_windows.h"
+#include "_cgo_export.h"
+
+// these match the swatch of the color button in Paint
+#define swatchWidth 32
+#define swatchHeight 14
+
+// there is no color button control, so we give a regular button a swatch of the color as its image
+void colorButtonSetColor(HWND hwnd, COLORREF color)
+{
+	HDC screen, dc;
+	HBITMAP bitmap, prevbitmap, old;
+	HBRUSH brush;
+	RECT r;
+
+	screen = GetDC(NULL);
+	if (screen == NULL)
+		xpanic("error getting screen DC for ColorButton swatch", GetLastError());
+	dc = CreateCompatibleDC(screen);
+	if (dc == NULL)
+		xpanic("error creating DC for ColorButton swatch", GetLastError());
+	bitmap = CreateCompatibleBitmap(screen, swatchWidth, swatchHeight);
+	if (bitmap == NULL)
+		xpanic("error creating ColorButton swatch bitmap", GetLastError());
+	prevbitmap = (HBITMAP) SelectObject(dc, bitmap);
+	r.left = 0;
+	r.top = 0;
+	r.right = swatchWidth;
+	r.bottom = swatchHeight;
+	brush = CreateSolidBrush(color);
+	if (brush == NULL)
+		xpanic("error creating ColorButton swatch brush", GetLastError());
+	FillRect(dc, &r, brush);
+	DeleteObject(brush);
+	// a border so that colors close to the button face still stand out
+	FrameRect(dc, &r, GetSysColorBrush(COLOR_BTNSHADOW));
+	SelectObject(dc, prevbitmap);
+	DeleteDC(dc);
+	ReleaseDC(NULL, screen);
+	old = (HBITMAP) SendMessageW(hwnd, BM_SETIMAGE, IMAGE_BITMAP, (LPARAM) bitmap);
+	if (old != NULL)
+		DeleteObject(old);
+}
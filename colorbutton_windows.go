@@ -0,0 +1,51 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"image/color"
+)
+
+// #include "winapi_windows.h"
+import "C"
+
+type colorButton struct {
+	*button
+	color   color.NRGBA
+	changed *event
+}
+
+func newColorButton(initial color.NRGBA) *colorButton {
+	b := &colorButton{
+		button:  newButton(""),
+		changed: newEvent(),
+	}
+	b.SetColor(initial)
+	b.clicked.set(b.choose)
+	return b
+}
+
+func (b *colorButton) Color() color.Color {
+	return b.color
+}
+
+func (b *colorButton) SetColor(c color.Color) {
+	b.color = toOpaqueNRGBA(c)
+	C.colorButtonSetColor(b.hwnd, toCOLORREF(b.color))
+}
+
+func (b *colorButton) OnChanged(e func()) {
+	b.changed.set(e)
+}
+
+func (b *colorButton) choose() {
+	f := func(c color.Color) {
+		if c == nil || c == color.Color(b.color) {
+			return
+		}
+		b.SetColor(c)
+		b.changed.fire()
+	}
+	// the button doesn't know its Window, but the dialog only needs the top-level window to be modal to
+	C.chooseColor(C.GetAncestor(b.hwnd, C.GA_ROOT), toCOLORREF(b.color), C.uintptr_t(addDialogFunc(f)))
+}
@@ -12,12 +12,17 @@ LRESULT (*WINAPI fv_DefSubclassProc)(HWND, UINT, WPARAM, LPARAM);
 
 // these are listed as WINAPI on MSDN
 BOOL (*WINAPI fv__TrackMouseEvent)(LPTRACKMOUSEEVENT);
+HIMAGELIST (*WINAPI fv_ImageList_Create)(int, int, UINT, int, int);
+int (*WINAPI fv_ImageList_Add)(HIMAGELIST, HBITMAP, HBITMAP);
+BOOL (*WINAPI fv_ImageList_Destroy)(HIMAGELIST);
 
 #define wantedICCClasses ( \
 	ICC_PROGRESS_CLASS |		/* progress bars */		\
 	ICC_TAB_CLASSES |			/* tabs */				\
 	ICC_LISTVIEW_CLASSES |		/* table headers */		\
 	ICC_UPDOWN_CLASS |		/* spinboxes */		\
+	ICC_TREEVIEW_CLASSES |		/* trees */			\
+	ICC_BAR_CLASSES |			/* sliders */			\
 	0)
 
 // note that this is an 8-bit character string we're writing; see the encoding clause
@@ -121,6 +126,12 @@ DWORD initCommonControls(char **errmsg)
 	fv_DefSubclassProc = (LRESULT (*WINAPI)(HWND, UINT, WPARAM, LPARAM)) f;
 	LOAD("_TrackMouseEvent");
 	fv__TrackMouseEvent = (HIMAGELIST (*WINAPI)(int, int, UINT, int, int)) f;
+	LOAD("ImageList_Create");
+	fv_ImageList_Create = (HIMAGELIST (*WINAPI)(int, int, UINT, int, int)) f;
+	LOAD("ImageList_Add");
+	fv_ImageList_Add = (int (*WINAPI)(HIMAGELIST, HBITMAP, HBITMAP)) f;
+	LOAD("ImageList_Destroy");
+	fv_ImageList_Destroy = (BOOL (*WINAPI)(HIMAGELIST)) f;
 
 	if ((*ficc)(&icc) == FALSE) {
 		*errmsg = "error initializing Common Controls (comctl32.dll)";
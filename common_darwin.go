@@ -5,6 +5,8 @@ package ui
 // #include "objc_darwin.h"
 import "C"
 
+const currentPlatform = PlatformMac
+
 func fromBOOL(b C.BOOL) bool {
 	if b != C.NO {
 		return true
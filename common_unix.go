@@ -20,6 +20,8 @@ import (
 // }
 import "C"
 
+const currentPlatform = PlatformGTK
+
 func fromgstr(s *C.gchar) string {
 	return C.GoString((*C.char)(unsafe.Pointer(s)))
 }
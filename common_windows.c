@@ -107,6 +107,17 @@ static LRESULT forwardNotify(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM lParam)
 	return DefWindowProcW(hwnd, uMsg, wParam, lParam);
 }
 
+// trackbars send WM_HSCROLL and WM_VSCROLL with themselves in lParam; lParam is NULL for a window's own scrollbars, which don't go to the parent anyway
+static LRESULT forwardScroll(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM lParam)
+{
+	HWND control = (HWND) lParam;
+
+	// don't generate an event if the control (if there is one) is unparented (a child of the message-only window)
+	if (control != NULL && IsChild(msgwin, control) == 0)
+		return SendMessageW(control, msgSCROLL, wParam, lParam);
+	return DefWindowProcW(hwnd, uMsg, wParam, lParam);
+}
+
 BOOL sharedWndProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM lParam, LRESULT *lResult)
 {
 	switch (uMsg) {
@@ -116,6 +127,10 @@ BOOL sharedWndProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM lParam, LRESULT *
 	case WM_NOTIFY:
 		*lResult = forwardNotify(hwnd, uMsg, wParam, lParam);
 		return TRUE;
+	case WM_HSCROLL:
+	case WM_VSCROLL:
+		*lResult = forwardScroll(hwnd, uMsg, wParam, lParam);
+		return TRUE;
 	case WM_CTLCOLORSTATIC:
 	case WM_CTLCOLORBTN:
 		// read-only TextFields and Textboxes are exempt
@@ -128,6 +143,13 @@ BOOL sharedWndProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM lParam, LRESULT *
 		paintControlBackground((HWND) lParam, (HDC) wParam);
 		*lResult = (LRESULT) hollowBrush;
 		return TRUE;
+	case WM_SETCURSOR:
+		// see setOverrideCursor() in cursor_windows.c
+		if (hOverrideCursor == NULL)
+			return FALSE;
+		SetCursor(hOverrideCursor);
+		*lResult = (LRESULT) TRUE;
+		return TRUE;
 	}
 	return FALSE;
 }
@@ -163,3 +185,16 @@ void paintControlBackground(HWND hwnd, HDC dc)
 	if (SetWindowOrgEx(dc, pOrig.x, pOrig.y, NULL) == 0)
 		xpanic("error resetting window origin in paintControlBackground()", GetLastError());
 }
+
+// this is for the whole session, not just our program
+DWORD idleTime(void)
+{
+	LASTINPUTINFO lii;
+
+	ZeroMemory(&lii, sizeof (LASTINPUTINFO));
+	lii.cbSize = sizeof (LASTINPUTINFO);
+	if (GetLastInputInfo(&lii) == 0)
+		xpanic("error getting time of last user input", GetLastError());
+	// both are in milliseconds since boot and wrap around after 49.7 days; unsigned subtraction handles the wraparound
+	return GetTickCount() - lii.dwTime;
+}
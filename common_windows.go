@@ -12,6 +12,8 @@ import (
 // #include "winapi_windows.h"
 import "C"
 
+const currentPlatform = PlatformWindows
+
 //export xpanic
 func xpanic(msg *C.char, lasterr C.DWORD) {
 	panic(fmt.Errorf("%s: %s", C.GoString(msg), syscall.Errno(lasterr)))
@@ -56,3 +58,10 @@ func wstrToString(wstr *C.WCHAR) string {
 	buf := (*[]uint16)(unsafe.Pointer(xbuf))
 	return syscall.UTF16ToString(*buf)
 }
+
+func toBOOL(b bool) C.BOOL {
+	if b == true {
+		return C.TRUE
+	}
+	return C.FALSE
+}
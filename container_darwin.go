@@ -44,10 +44,10 @@ func containerResized(data unsafe.Pointer) {
 	// TODO make this a parameter
 	b := C.containerBounds(c.id)
 	if c.margined {
-		b.x += C.intptr_t(macXMargin)
-		b.y += C.intptr_t(macYMargin)
-		b.width -= C.intptr_t(macXMargin) * 2
-		b.height -= C.intptr_t(macYMargin) * 2
+		b.x += C.intptr_t(scaled(macXMargin))
+		b.y += C.intptr_t(scaled(macYMargin))
+		b.width -= C.intptr_t(scaled(macXMargin)) * 2
+		b.height -= C.intptr_t(scaled(macYMargin)) * 2
 	}
 	c.resize(int(b.x), int(b.y), int(b.width), int(b.height), d)
 }
@@ -63,8 +63,8 @@ const (
 
 func beginResize() (d *sizing) {
 	d = new(sizing)
-	d.xpadding = macXPadding
-	d.ypadding = macYPadding
+	d.xpadding = scaled(macXPadding)
+	d.ypadding = scaled(macYPadding)
 	return d
 }
 
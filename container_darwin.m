@@ -27,6 +27,13 @@
 	containerResized(self->gocontainer);
 }
 
+// clicks on the container itself, rather than on a control in it, might be in the Window's drag region
+- (void)mouseDown:(NSEvent *)e
+{
+	if (!windowDragRegionMouseDown((id) [self window], (id) e))
+		[super mouseDown:e];
+}
+
 @end
 
 id newContainerView(void *gocontainer)
@@ -64,3 +71,37 @@ struct xrect containerBounds(id view)
 	r.height = (intptr_t) b.size.height;
 	return r;
 }
+
+// containerResized() is called from -[goContainerView setFrameSize:], so setting the same size again lays out a container's Controls
+// do it for every container from the Window's on in, since the size of a container in a Tab or Group can change when the Controls around it are laid out again
+void relayoutContainer(id container)
+{
+	NSMutableArray *containers;
+	NSView *v;
+	NSUInteger i;
+
+	containers = [NSMutableArray new];
+	for (v = toNSView(container); v != nil; v = [v superview])
+		if ([v isKindOfClass:[goContainerView class]])
+			[containers insertObject:v atIndex:0];
+	for (i = 0; i < [containers count]; i++) {
+		v = (NSView *) [containers objectAtIndex:i];
+		[v setFrameSize:[v frame].size];
+	}
+	[containers release];
+}
+
+// for SetScaleFactor(): lays out all the containers in v, outermost first, as relayoutContainer() does for one container's outer ones
+void relayoutAllContainers(id view)
+{
+	NSView *v;
+	NSArray *subviews;
+	NSUInteger i;
+
+	v = toNSView(view);
+	if ([v isKindOfClass:[goContainerView class]])
+		[v setFrameSize:[v frame].size];
+	subviews = [v subviews];
+	for (i = 0; i < [subviews count]; i++)
+		relayoutAllContainers((id) [subviews objectAtIndex:i]);
+}
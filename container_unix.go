@@ -47,10 +47,10 @@ func containerResize(data unsafe.Pointer, aorig *C.GtkAllocation) {
 	// copy aorig
 	a := *aorig
 	if c.margined {
-		a.x += C.int(gtkXMargin)
-		a.y += C.int(gtkYMargin)
-		a.width -= C.int(gtkXMargin) * 2
-		a.height -= C.int(gtkYMargin) * 2
+		a.x += C.int(scaled(gtkXMargin))
+		a.y += C.int(scaled(gtkYMargin))
+		a.width -= C.int(scaled(gtkXMargin)) * 2
+		a.height -= C.int(scaled(gtkYMargin)) * 2
 	}
 	c.resize(int(a.x), int(a.y), int(a.width), int(a.height), d)
 }
@@ -64,7 +64,7 @@ const (
 
 func beginResize() (d *sizing) {
 	d = new(sizing)
-	d.xpadding = gtkXPadding
-	d.ypadding = gtkYPadding
+	d.xpadding = scaled(gtkXPadding)
+	d.ypadding = scaled(gtkYPadding)
 	return d
 }
@@ -10,24 +10,243 @@ type Control interface {
 	nTabStops() int		// used by the Windows backend
 
 	// these are provided for Tab on Windows, where we have to show and hide the individual tab pages manually
-	// if we ever get something like a SidebarStack of some sort, we'll need to implement this everywhere
+	// they are also how Stack, Grid, and SimpleGrid show and hide their children when they themselves are shown and hidden
 	containerShow()	// show if and only if programmer said to show
 	containerHide()	// hide regardless of whether programmer said to hide
+
+	// and likewise for enabling and disabling; these are how a disabled Stack or Group disables its children
+	containerEnable()	// enable if and only if programmer said to enable
+	containerDisable()	// disable regardless of whether programmer said to disable
+
+	// Visible, Show, and Hide get and set whether the Control is shown; Controls are shown by default.
+	// A hidden Control takes up no space in a Stack, Grid, or SimpleGrid; the other Controls are laid out again as if it wasn't there.
+	// Hiding a Stack, Grid, SimpleGrid, Group, or Tab hides everything in it, but Visible still returns what was last set on each Control itself.
+	Visible() bool
+	Show()
+	Hide()
+
+	// Enabled, Enable, and Disable get and set whether the user can interact with the Control; Controls are enabled by default.
+	// A disabled Control is drawn greyed out (or however the system shows disabled controls) but is still laid out and can still be changed by the program.
+	// As with hiding, disabling a Stack, Grid, SimpleGrid, Group, or Tab disables everything in it.
+	// An Area cannot know how to draw itself disabled; check Enabled in Paint if that matters.
+	Enabled() bool
+	Enable()
+	Disable()
+
+	// SetTooltip sets the text shown in a small popup when the mouse rests over the Control, such as to explain what a Button with only an icon does.
+	// Pass "" to remove the tooltip; Controls have no tooltip by default.
+	// Stack, Grid, and SimpleGrid have nothing of their own to hover over, so SetTooltip does nothing on them; set it on their children instead.
+	// For tooltips that change depending on where the mouse is in an Area, see AreaTooltipHandler.
+	SetTooltip(text string)
+
+	// SetFocus gives the Control the keyboard focus, if it can take it.
+	// A Control that contains others, such as a Group or Tab, gives it to the first of those that can take it; Stack, Grid, and SimpleGrid do nothing.
+	// To find out when the focus moves, see Window.OnFocusChanged.
+	SetFocus()
+
+	// ScrollIntoView scrolls whatever scroll views the Control is inside, innermost first, just far enough that the Control can be seen, such as to bring a field in a long form into view after a search.
+	// Package ui has no scrolling container of its own, so this only matters for Controls that end up inside a scroll view of the system's (a GtkScrolledWindow or an NSScrollView); on Windows, where there are none, it does nothing.
+	// It does not switch Tab pages; see Window.FocusFirstInvalid for that. Stack, Grid, and SimpleGrid do nothing; scroll one of their children instead.
+	ScrollIntoView()
+
+	focusHandle() uintptr		// the native object that stands for the Control in Window.SetTabOrder() and Window.OnFocusChanged(); see controlstate.handle
+	childControls() []Control	// the Controls in a Stack, Grid, SimpleGrid, Group, or Tab; nil for everything else
+	destroy()				// destroys the native controls, children included; used when a Control is removed from its container, after which it must not be used again
+}
+
+// controlstate keeps track of whether a Control is shown and enabled.
+// Both the programmer (through Show/Hide and Enable/Disable) and the Control's containers (through containerShow/containerHide and containerEnable/containerDisable) have a say; the Control is shown or enabled only if both agree.
+// fsetShown and fsetEnabled do the actual work.
+type controlstate struct {
+	fsetShown		func(shown bool)
+	fsetEnabled		func(enabled bool)
+	fsetTooltip		func(text string)		// nil if the Control can't have a tooltip
+	fsetFocus		func()				// nil if the Control can't take the keyboard focus
+	fdestroy		func()
+
+	// the native object that is the Control as far as keyboard focus is concerned, converted to a uintptr: the outermost GtkWidget on GTK+, the HWND on Windows, and the NSView that becomes first responder on Mac OS X
+	// 0 for Controls that have no single such object, such as Stack
+	handle			uintptr
+
+	parent			*controlParent		// for relayout(); nil until setParent() is called
+	hidden			bool
+	containerHidden	bool
+	disabled			bool
+	containerDisabled	bool
+	tooltip			string
+}
+
+func (c *controlstate) shown() bool {
+	return !c.hidden && !c.containerHidden
+}
+
+func (c *controlstate) enabled() bool {
+	return !c.disabled && !c.containerDisabled
+}
+
+func (c *controlstate) Visible() bool {
+	return !c.hidden
+}
+
+func (c *controlstate) Show() {
+	if !c.hidden {
+		return
+	}
+	c.hidden = false
+	c.fsetShown(c.shown())
+	c.relayout()
+}
+
+func (c *controlstate) Hide() {
+	if c.hidden {
+		return
+	}
+	c.hidden = true
+	c.fsetShown(false)
+	c.relayout()
+}
+
+func (c *controlstate) containerShow() {
+	c.containerHidden = false
+	c.fsetShown(c.shown())
+}
+
+func (c *controlstate) containerHide() {
+	c.containerHidden = true
+	c.fsetShown(false)
+}
+
+func (c *controlstate) Enabled() bool {
+	return !c.disabled
+}
+
+func (c *controlstate) Enable() {
+	c.disabled = false
+	c.fsetEnabled(c.enabled())
+}
+
+func (c *controlstate) Disable() {
+	c.disabled = true
+	c.fsetEnabled(false)
+}
+
+func (c *controlstate) SetTooltip(text string) {
+	c.tooltip = text
+	if c.fsetTooltip != nil {
+		c.fsetTooltip(text)
+	}
+}
+
+func (c *controlstate) SetFocus() {
+	if c.fsetFocus != nil {
+		c.fsetFocus()
+	}
+}
+
+// scrollIntoView(h) is defined per-platform
+func (c *controlstate) ScrollIntoView() {
+	if c.handle != 0 {
+		scrollIntoView(c.handle)
+	}
+}
+
+func (c *controlstate) focusHandle() uintptr {
+	return c.handle
+}
+
+func (c *controlstate) childControls() []Control {
+	return nil
+}
+
+func (c *controlstate) destroy() {
+	c.fdestroy()
+}
+
+func (c *controlstate) containerEnable() {
+	c.containerDisabled = false
+	c.fsetEnabled(c.enabled())
+}
+
+func (c *controlstate) containerDisable() {
+	c.containerDisabled = true
+	c.fsetEnabled(false)
+}
+
+// relayout has the Window lay out its Controls again, as their sizes have changed; relayout(p) is defined per-platform
+func (c *controlstate) relayout() {
+	if c.parent != nil {		// not in a Window yet; it'll be laid out when it is
+		relayout(c.parent)
+	}
+}
+
+// reapply is called after a Control is given a parent, as some systems show newly parented controls on their own
+func (c *controlstate) reapply() {
+	if !c.shown() {
+		c.fsetShown(false)
+	}
+	if !c.enabled() {
+		c.fsetEnabled(false)
+	}
+}
+
+// adopt is called by Stack and Grid when a Control is added to them: it gives the new Control the container's shown and enabled state and, if the container is already in a Window, parents the Control and lays the Window out again
+func (c *controlstate) adopt(child Control) {
+	if !c.shown() {
+		child.containerHide()
+	}
+	if !c.enabled() {
+		child.containerDisable()
+	}
+	if c.parent != nil {		// otherwise the Control is parented along with the rest when the container is
+		child.setParent(c.parent)
+		c.relayout()
+	}
+}
+
+// orphan is called by Stack and Grid when a Control is removed from them
+func (c *controlstate) orphan(child Control) {
+	child.destroy()
+	c.relayout()
+}
+
+// chainEnabled makes fsetEnabled also enable or disable the Controls returned by children; Tab and Group use it, since not every system does this for us (and those that do don't mind)
+func chainEnabled(fsetEnabled func(enabled bool), children func() []Control) func(enabled bool) {
+	return func(enabled bool) {
+		fsetEnabled(enabled)
+		for _, c := range children() {
+			if enabled {
+				c.containerEnable()
+			} else {
+				c.containerDisable()
+			}
+		}
+	}
+}
+
+// chainDestroy makes fdestroy destroy the Controls returned by children first; Tab and Group use it, since Mac OS X views and the Windows controls with more than one window need more than their container going away
+func chainDestroy(fdestroy func(), children func() []Control) func() {
+	return func() {
+		for _, c := range children() {
+			c.destroy()
+		}
+		fdestroy()
+	}
 }
 
 type controlbase struct {
+	controlstate
 	fsetParent			func(p *controlParent)
 	fpreferredSize		func(d *sizing) (width, height int)
 	fresize			func(x int, y int, width int, height int, d *sizing)
 	fnTabStops		func() int
-	fcontainerShow	func()
-	fcontainerHide		func()
 }
 
 // children should not use the same name as these, otherwise weird things will happen
 
 func (c *controlbase) setParent(p *controlParent) {
+	c.parent = p
 	c.fsetParent(p)
+	c.reapply()
 }
 
 func (c *controlbase) preferredSize(d *sizing) (width, height int) {
@@ -41,11 +260,3 @@ func (c *controlbase) resize(x int, y int, width int, height int, d *sizing) {
 func (c *controlbase) nTabStops() int {
 	return c.fnTabStops()
 }
-
-func (c *controlbase) containerShow() {
-	c.fcontainerShow()
-}
-
-func (c *controlbase) containerHide() {
-	c.fcontainerHide()
-}
@@ -2,6 +2,10 @@
 
 package ui
 
+import (
+	"unsafe"
+)
+
 // #include "objc_darwin.h"
 import "C"
 
@@ -21,7 +25,13 @@ func newControlSingleObject(id C.id) *controlSingleObject {
 		fpreferredSize:		c.xpreferredSize,
 		fresize:			c.xresize,
 	}
+	c.fsetShown = c.xsetShown
+	c.fsetEnabled = c.xsetEnabled
+	c.fsetTooltip = c.xsetTooltip
+	c.fsetFocus = c.xsetFocus
+	c.fdestroy = c.xdestroy
 	c.id = id
+	c.handle = uintptr(unsafe.Pointer(id))
 	return c
 }
 
@@ -39,6 +49,49 @@ func (c *controlSingleObject) xresize(x int, y int, width int, height int, d *si
 	C.moveControl(c.id, C.intptr_t(x), C.intptr_t(y), C.intptr_t(width), C.intptr_t(height))
 }
 
+func (c *controlSingleObject) xdestroy() {
+	C.controlDestroy(c.id)
+}
+
+func (c *controlSingleObject) xsetShown(shown bool) {
+	C.controlSetHidden(c.id, toBOOL(!shown))
+}
+
+func (c *controlSingleObject) xsetEnabled(enabled bool) {
+	C.controlSetEnabled(c.id, toBOOL(enabled))
+}
+
+func (c *controlSingleObject) xsetTooltip(text string) {
+	setTooltip(c.id, text)
+}
+
+func (c *controlSingleObject) xsetFocus() {
+	C.controlSetFocus(c.id)
+}
+
+// whether the view h is c or inside it, for Window.OnFocusChanged()
+func nativeContains(c uintptr, h uintptr) bool {
+	return fromBOOL(C.controlContains(C.id(unsafe.Pointer(c)), C.id(unsafe.Pointer(h))))
+}
+
+func scrollIntoView(h uintptr) {
+	C.controlScrollIntoView(C.id(unsafe.Pointer(h)))
+}
+
+func setTooltip(id C.id, text string) {
+	if text == "" {
+		C.controlSetTooltip(id, nil)
+		return
+	}
+	ctext := C.CString(text)
+	C.controlSetTooltip(id, ctext)
+	C.free(unsafe.Pointer(ctext))
+}
+
+func relayout(p *controlParent) {
+	C.relayoutContainer(p.id)
+}
+
 type scroller struct {
 	*controlSingleObject
 	scroller	*controlSingleObject
@@ -52,5 +105,11 @@ func newScroller(child C.id, bordered bool) *scroller {
 	}
 	s.fsetParent = s.scroller.fsetParent
 	s.fresize = s .scroller.fresize
+	s.fsetShown = s.scroller.fsetShown		// but the user interacts with the child, so leave fsetEnabled alone
+	childdestroy := s.fdestroy
+	s.fdestroy = func() {
+		s.scroller.fdestroy()
+		childdestroy()
+	}
 	return s
 }
@@ -11,21 +11,154 @@ void parent(id control, id parentid)
 	[toNSView(parentid) addSubview:toNSView(control)];
 }
 
+// we still own the reference from creating the view, so give that up too
+void controlDestroy(id control)
+{
+	[toNSView(control) removeFromSuperview];
+	[toNSView(control) release];
+}
+
 void controlSetHidden(id control, BOOL hidden)
 {
 	[toNSView(control) setHidden:hidden];
 }
 
+// plain NSViews (such as Areas, NSBoxes, and NSTabViews) can't be disabled; the Go side handles those
+void controlSetEnabled(id control, BOOL enabled)
+{
+	if ([toNSView(control) respondsToSelector:@selector(setEnabled:)])
+		[toNSControl(control) setEnabled:enabled];
+}
+
+// text is NULL to remove the tooltip
+void controlSetTooltip(id control, char *text)
+{
+	if (text == NULL) {
+		[toNSView(control) setToolTip:nil];
+		return;
+	}
+	[toNSView(control) setToolTip:[NSString stringWithUTF8String:text]];
+}
+
+// views that don't accept first responder, like non-editable labels, make this do nothing
+void controlSetFocus(id control)
+{
+	[[toNSView(control) window] makeFirstResponder:toNSView(control)];
+}
+
+// the first responder isn't necessarily a view (it can be the window itself), so check
+BOOL controlContains(id control, id responder)
+{
+	if (![responder isKindOfClass:[NSView class]])
+		return NO;
+	return [toNSView(responder) isDescendantOf:toNSView(control)];
+}
+
+// -[NSView scrollRectToVisible:] only scrolls the nearest NSClipView, so go up through each NSScrollView in turn
+// Controls that scroll themselves, like Area and Textbox, give us the view inside their own NSScrollView; start from that NSScrollView instead, or all we'd do is scroll the Control's own contents
+void controlScrollIntoView(id control)
+{
+	NSView *v;
+	NSScrollView *sv;
+
+	v = toNSView(control);
+	sv = [v enclosingScrollView];
+	if (sv != nil && [sv documentView] == v)
+		v = sv;
+	for (;;) {
+		[v scrollRectToVisible:[v bounds]];
+		sv = [v enclosingScrollView];
+		if (sv == nil)
+			break;
+		v = sv;
+	}
+}
+
+// see SetScaleFactor()
+static double controlFontScale = 1;
+
+static CGFloat controlFontSize(NSControlSize size, double scale)
+{
+	return [NSFont systemFontSizeForControlSize:size] * (CGFloat) scale;
+}
+
 // also fine for NSCells and NSTexts (NSTextViews)
 void setStandardControlFont(id control)
 {
-	[toNSControl(control) setFont:[NSFont systemFontOfSize:[NSFont systemFontSizeForControlSize:NSRegularControlSize]]];
+	[toNSControl(control) setFont:[NSFont systemFontOfSize:controlFontSize(NSRegularControlSize, controlFontScale)]];
 }
 
 // also fine for NSCells
 void setSmallControlFont(id control)
 {
-	[toNSControl(control) setFont:[NSFont systemFontOfSize:[NSFont systemFontSizeForControlSize:NSSmallControlSize]]];
+	[toNSControl(control) setFont:[NSFont systemFontOfSize:controlFontSize(NSSmallControlSize, controlFontScale)]];
+}
+
+// can't use setSmallControlFont() for NSBox because the selector is different
+void setGroupTitleFont(id box)
+{
+	[(NSBox *) box setTitleFont:[NSFont systemFontOfSize:controlFontSize(NSSmallControlSize, controlFontScale)]];
+}
+
+// o is anything with font and setFont:; its font is replaced only if it's one setStandardControlFont() or setSmallControlFont() gave it, so Controls with fonts of their own keep them
+static void rescaleFont(id o, double prev)
+{
+	NSFont *font;
+
+	font = (NSFont *) [o font];
+	if (font == nil)
+		return;
+	if ([font pointSize] == controlFontSize(NSRegularControlSize, prev))
+		setStandardControlFont(o);
+	else if ([font pointSize] == controlFontSize(NSSmallControlSize, prev))
+		setSmallControlFont(o);
+}
+
+static void rescaleView(NSView *v, double prev)
+{
+	NSArray *subviews;
+	NSUInteger i;
+
+	if ([v respondsToSelector:@selector(font)] && [v respondsToSelector:@selector(setFont:)])
+		rescaleFont(v, prev);
+	if ([v isKindOfClass:[NSBox class]])
+		if ([[(NSBox *) v titleFont] pointSize] == controlFontSize(NSSmallControlSize, prev))
+			setGroupTitleFont(v);
+	if ([v isKindOfClass:[NSTableView class]]) {
+		NSTableView *t;
+		NSArray *columns;
+
+		t = (NSTableView *) v;
+		columns = [t tableColumns];
+		for (i = 0; i < [columns count]; i++) {
+			rescaleFont([[columns objectAtIndex:i] headerCell], prev);
+			rescaleFont([[columns objectAtIndex:i] dataCell], prev);
+		}
+		// the rows don't grow with their font on their own
+		[t setRowHeight:[t rowHeight] * (CGFloat) (controlFontScale / prev)];
+	}
+	subviews = [v subviews];
+	for (i = 0; i < [subviews count]; i++)
+		rescaleView((NSView *) [subviews objectAtIndex:i], prev);
+}
+
+void setScaleFactor(double factor)
+{
+	NSArray *windows;
+	NSView *cv;
+	NSUInteger i;
+	double prev;
+
+	prev = controlFontScale;
+	controlFontScale = factor;
+	windows = [NSApp windows];
+	for (i = 0; i < [windows count]; i++) {
+		cv = [(NSWindow *) [windows objectAtIndex:i] contentView];
+		if (cv == nil)
+			continue;
+		rescaleView(cv, prev);
+		relayoutAllContainers(cv);
+	}
 }
 
 // also good for NSBox and NSProgressIndicator
@@ -0,0 +1,29 @@
+// +build !windows,!darwin
+
+// 15 october 2026
+
+#include "gtk_unix.h"
+
+// gtk_widget_translate_coordinates() counts from the top-left of what is being scrolled, which is what the adjustments count in too; for widgets that can't scroll themselves, that's the child of the GtkViewport
+void controlScrollIntoView(GtkWidget *widget)
+{
+	GtkWidget *parent;
+	GtkWidget *content;
+	GtkAllocation a;
+	gint x, y;
+
+	for (parent = gtk_widget_get_parent(widget); parent != NULL; parent = gtk_widget_get_parent(parent)) {
+		if (!GTK_IS_SCROLLED_WINDOW(parent))
+			continue;
+		content = gtk_bin_get_child(GTK_BIN(parent));
+		if (content != NULL && GTK_IS_VIEWPORT(content))
+			content = gtk_bin_get_child(GTK_BIN(content));
+		if (content == NULL || !gtk_widget_translate_coordinates(widget, content, 0, 0, &x, &y))
+			continue;
+		gtk_widget_get_allocation(widget, &a);
+		gtk_adjustment_clamp_page(gtk_scrolled_window_get_hadjustment(GTK_SCROLLED_WINDOW(parent)), x, x + a.width);
+		gtk_adjustment_clamp_page(gtk_scrolled_window_get_vadjustment(GTK_SCROLLED_WINDOW(parent)), y, y + a.height);
+		// and now make sure the GtkScrolledWindow can be seen in whatever scrolls it
+		widget = parent;
+	}
+}
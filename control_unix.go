@@ -27,7 +27,13 @@ func newControlSingleWidget(widget *C.GtkWidget) *controlSingleWidget {
 		fpreferredSize:		c.xpreferredSize,
 		fresize:			c.xresize,
 	}
+	c.fsetShown = c.xsetShown
+	c.fsetEnabled = c.xsetEnabled
+	c.fsetTooltip = c.xsetTooltip
+	c.fsetFocus = c.xsetFocus
+	c.fdestroy = c.xdestroy
 	c.widget = widget
+	c.handle = uintptr(unsafe.Pointer(widget))
 	return c
 }
 
@@ -66,6 +72,62 @@ func (c *controlSingleWidget) xresize(x int, y int, width int, height int, d *si
 	C.gtk_widget_size_allocate(c.widget, &r)
 }
 
+func (c *controlSingleWidget) xsetShown(shown bool) {
+	if shown {
+		C.gtk_widget_show(c.widget)
+	} else {
+		C.gtk_widget_hide(c.widget)
+	}
+}
+
+func (c *controlSingleWidget) xsetEnabled(enabled bool) {
+	C.gtk_widget_set_sensitive(c.widget, togbool(enabled))
+}
+
+// if a widget doesn't have a tooltip, GTK+ tries its parents, so this works for containers like the GtkBox of RadioButtons too
+func (c *controlSingleWidget) xsetTooltip(text string) {
+	if text == "" {
+		// this also turns off has-tooltip, which Table, Tree, and Area need for their own query-tooltip handlers, so put it back
+		had := C.gtk_widget_get_has_tooltip(c.widget)
+		C.gtk_widget_set_tooltip_text(c.widget, nil)
+		C.gtk_widget_set_has_tooltip(c.widget, had)
+		return
+	}
+	ctext := togstr(text)
+	defer freegstr(ctext)
+	C.gtk_widget_set_tooltip_text(c.widget, ctext)
+}
+
+// some of our widgets, like the GtkBox of RadioButtons, can't take the focus themselves but have children that can
+func (c *controlSingleWidget) xsetFocus() {
+	if C.gtk_widget_get_can_focus(c.widget) != C.FALSE {
+		C.gtk_widget_grab_focus(c.widget)
+		return
+	}
+	C.gtk_widget_child_focus(c.widget, C.GTK_DIR_TAB_FORWARD)
+}
+
+// this also takes the widget out of its container, and destroys any widgets inside it
+func (c *controlSingleWidget) xdestroy() {
+	C.gtk_widget_destroy(c.widget)
+}
+
+// whether the widget h is c or inside it, for Window.OnFocusChanged()
+func nativeContains(c uintptr, h uintptr) bool {
+	cw := (*C.GtkWidget)(unsafe.Pointer(c))
+	hw := (*C.GtkWidget)(unsafe.Pointer(h))
+	return c == h || C.gtk_widget_is_ancestor(hw, cw) != C.FALSE
+}
+
+func scrollIntoView(h uintptr) {
+	C.controlScrollIntoView((*C.GtkWidget)(unsafe.Pointer(h)))
+}
+
+// queueing a resize on the container makes GTK+ allocate it again, which is where containerResize() lays out its Controls; GTK+ also passes the request up to the containers above it
+func relayout(p *controlParent) {
+	C.gtk_widget_queue_resize((*C.GtkWidget)(unsafe.Pointer(p.c)))
+}
+
 type scroller struct {
 	*controlSingleWidget
 
@@ -92,6 +154,10 @@ func newScroller(widget *C.GtkWidget, native bool, bordered bool, overlay bool)
 	s.scroller = newControlSingleWidget(s.scrollwidget)
 	s.fsetParent = s.scroller.fsetParent
 	s.fresize = s.scroller.fresize
+	s.fsetShown = s.scroller.fsetShown
+	s.fsetEnabled = s.scroller.fsetEnabled
+	s.fdestroy = s.scroller.fdestroy
+	s.handle = s.scroller.handle		// but the focus goes to the widget itself
 
 	// in GTK+ 3.4 we still technically need to use the separate gtk_scrolled_window_add_with_viewpoint()/gtk_container_add() spiel for adding the widget to the scrolled window
 	if native {
@@ -114,6 +180,10 @@ func newScroller(widget *C.GtkWidget, native bool, bordered bool, overlay bool)
 		s.overlay = newControlSingleWidget(s.overlaywidget)
 		s.fsetParent = s.overlay.fsetParent
 		s.fresize = s.overlay.fresize
+		s.fsetShown = s.overlay.fsetShown
+		s.fsetEnabled = s.overlay.fsetEnabled
+		s.fdestroy = s.overlay.fdestroy
+		s.handle = s.overlay.handle
 		C.gtk_container_add(s.overlaycontainer, s.scrollwidget)
 	}
 
@@ -29,6 +29,13 @@ void controlSetParent(HWND control, HWND parent)
 		xpanic("error changing control parent", GetLastError());
 }
 
+// child windows, such as the controls of a Group or Tab, go with it
+void controlDestroy(HWND control)
+{
+	if (DestroyWindow(control) == 0)
+		xpanic("error destroying control", GetLastError());
+}
+
 void controlSetControlFont(HWND which)
 {
 	SendMessageW(which, WM_SETFONT, (WPARAM) controlFont, TRUE);
@@ -60,3 +67,41 @@ LONG controlTextLength(HWND hwnd, LPWSTR text)
 		xpanic("error releasing DC of control for text length", GetLastError());
 	return size.cx;
 }
+
+// tooltip is the tooltip control from the last call, or NULL the first time; the return value is the tooltip control to pass next time
+// each control gets its own tooltip control, since we don't know which window it will end up in when this is first called (controls start out as children of msgwin)
+// for the same reason the tooltip control has no owner; TTF_SUBCLASS has it watch the mouse messages of the control itself, so it doesn't need one
+HWND controlSetTooltip(HWND hwnd, HWND tooltip, LPWSTR text)
+{
+	TOOLINFOW ti;
+
+	ZeroMemory(&ti, sizeof (TOOLINFOW));
+	ti.cbSize = sizeof (TOOLINFOW);
+	ti.uFlags = TTF_IDISHWND | TTF_SUBCLASS;
+	ti.hwnd = hwnd;
+	ti.uId = (UINT_PTR) hwnd;
+	ti.lpszText = text;		// copied by the tooltip control
+	if (tooltip != NULL) {
+		SendMessageW(tooltip, TTM_UPDATETIPTEXTW, 0, (LPARAM) (&ti));
+		return tooltip;
+	}
+	tooltip = CreateWindowExW(WS_EX_TOPMOST,
+		TOOLTIPS_CLASSW, L"",
+		WS_POPUP | TTS_NOPREFIX | TTS_ALWAYSTIP,
+		CW_USEDEFAULT, CW_USEDEFAULT,
+		CW_USEDEFAULT, CW_USEDEFAULT,
+		NULL, NULL, hInstance, NULL);
+	if (tooltip == NULL)
+		xpanic("error creating tooltip control", GetLastError());
+	if (SendMessageW(tooltip, TTM_ADDTOOLW, 0, (LPARAM) (&ti)) == FALSE)
+		xpanic("error adding control to its tooltip control", GetLastError());
+	// without a maximum width, tooltips are always a single line, no matter how long
+	SendMessageW(tooltip, TTM_SETMAXTIPWIDTH, 0, 400);
+	return tooltip;
+}
+
+// for Areas, whose tooltip depends on where the mouse is; this makes the tooltip go away so the new text shows up after the next hover instead of replacing the old text in place
+void controlPopTooltip(HWND tooltip)
+{
+	SendMessageW(tooltip, TTM_POP, 0, 0);
+}
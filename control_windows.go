@@ -2,6 +2,10 @@
 
 package ui
 
+import (
+	"unsafe"
+)
+
 // #include "winapi_windows.h"
 import "C"
 
@@ -14,6 +18,7 @@ type controlParent struct {
 type controlSingleHWND struct {
 	*controlbase
 	hwnd	C.HWND
+	tooltipHWND	C.HWND	// nil until the first SetTooltip()
 }
 
 func newControlSingleHWND(hwnd C.HWND) *controlSingleHWND {
@@ -25,14 +30,14 @@ func newControlSingleHWND(hwnd C.HWND) *controlSingleHWND {
 			// most controls count as one tab stop
 			return 1
 		},
-		fcontainerShow:	func() {
-			C.ShowWindow(c.hwnd, C.SW_SHOW)
-		},
-		fcontainerHide:		func() {
-			C.ShowWindow(c.hwnd, C.SW_HIDE)
-		},
 	}
+	c.fsetShown = c.xsetShown
+	c.fsetEnabled = c.xsetEnabled
+	c.fsetTooltip = c.xsetTooltip
+	c.fsetFocus = c.xsetFocus
+	c.fdestroy = c.xdestroy
 	c.hwnd = hwnd
+	c.handle = uintptr(unsafe.Pointer(hwnd))
 	return c
 }
 
@@ -44,6 +49,49 @@ func (c *controlSingleHWND) xresize(x int, y int, width int, height int, d *sizi
 	C.moveWindow(c.hwnd, C.int(x), C.int(y), C.int(width), C.int(height))
 }
 
+func (c *controlSingleHWND) xsetShown(shown bool) {
+	if shown {
+		C.ShowWindow(c.hwnd, C.SW_SHOW)
+	} else {
+		C.ShowWindow(c.hwnd, C.SW_HIDE)
+	}
+}
+
+func (c *controlSingleHWND) xsetEnabled(enabled bool) {
+	C.EnableWindow(c.hwnd, toBOOL(enabled))
+}
+
+func (c *controlSingleHWND) xsetFocus() {
+	C.SetFocus(c.hwnd)
+}
+
+func (c *controlSingleHWND) xdestroy() {
+	// the tooltip control is a popup window, not a child, so it has to go separately
+	if c.tooltipHWND != nil {
+		C.controlDestroy(c.tooltipHWND)
+	}
+	C.controlDestroy(c.hwnd)
+}
+
+// whether the window h is c or inside it, for Window.OnFocusChanged()
+func nativeContains(c uintptr, h uintptr) bool {
+	return c == h || C.IsChild(C.HWND(unsafe.Pointer(c)), C.HWND(unsafe.Pointer(h))) != 0
+}
+
+// there are no scrolling windows on Windows that package ui puts controls in, so there is nothing to scroll
+func scrollIntoView(h uintptr) {
+	// do nothing
+}
+
+func (c *controlSingleHWND) xsetTooltip(text string) {
+	c.tooltipHWND = C.controlSetTooltip(c.hwnd, c.tooltipHWND, toUTF16(text))
+}
+
+// the Controls of a Window (including those in Tabs and Groups) are all laid out from windowResize(), so relayout the whole window by pretending it changed size
+func relayout(p *controlParent) {
+	C.relayoutWindow(p.hwnd)
+}
+
 // these are provided for convenience
 
 type controlSingleHWNDWithText struct {
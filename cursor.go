@@ -0,0 +1,189 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"image"
+	"time"
+)
+
+// Cursor is a shape for the mouse pointer, for use with Area.SetCursor and SetOverrideCursor.
+// One Cursor can be used in any number of places at once.
+// Cursors are never freed, so make the ones you need once rather than each time you show them.
+type Cursor struct {
+	frames   []sysCursor
+	interval time.Duration
+	frame    int
+
+	// for animated Cursors: the places showing the Cursor, each with a function that shows the current frame there again; see use()
+	users map[interface{}]func()
+	tick  chan struct{}
+	stop  chan struct{}
+	fe    *ForeignEvent
+}
+
+// NamedCursor loads the cursor with the given name from the system's cursor theme.
+// The following names, which are the ones CSS uses, are understood on all systems unless noted:
+//
+// 	default       the usual arrow
+// 	text          the I-beam, for selecting text
+// 	pointer       the pointing hand, for links
+// 	crosshair     for precise selection
+// 	not-allowed   for places a drag can't be dropped
+// 	ew-resize     for resizing left and right
+// 	ns-resize     for resizing up and down
+// 	wait          the busy cursor (not on Mac OS X)
+// 	progress      the arrow with the busy cursor beside it, for work going on in the background (not on Mac OS X)
+// 	help          (not on Mac OS X)
+// 	move          (not on Mac OS X)
+// 	nwse-resize   (not on Mac OS X)
+// 	nesw-resize   (not on Mac OS X)
+// 	grab          the open hand, for things that can be dragged around (not on Windows)
+// 	grabbing      the closed hand, while dragging them (not on Windows)
+// 	copy          (not on Windows)
+// 	alias         (not on Windows)
+// 	context-menu  (not on Windows)
+//
+// On GTK+, any other name in the user's cursor theme can also be used.
+// The cursors come from the user's chosen theme (on Windows, the pointer scheme), so they match the rest of the system; those the theme animates, such as the busy cursor on Windows, are animated.
+// NamedCursor returns an error if the system has no cursor by that name; programs that want one of the cursors above that isn't everywhere should fall back to another, or to NewCursor.
+func NamedCursor(name string) (*Cursor, error) {
+	c, ok := namedSysCursor(name)
+	if !ok {
+		return nil, fmt.Errorf("no cursor named %q on this system", name)
+	}
+	return &Cursor{
+		frames: []sysCursor{c},
+	}, nil
+}
+
+// NewCursor makes a Cursor from img.
+// hotspot is the point of img, relative to img.Bounds().Min, that the Cursor points with; NewCursor panics if it is outside img.
+// Systems differ in the largest cursor they can show; 32x32 is safe everywhere.
+func NewCursor(img image.Image, hotspot image.Point) *Cursor {
+	return newCursor([]image.Image{img}, hotspot, 0, "NewCursor()")
+}
+
+// NewAnimatedCursor makes a Cursor that shows each of frames in turn for interval, then starts over.
+// All the frames share the same hotspot, as with NewCursor.
+// NewAnimatedCursor panics if there are no frames, if interval is not positive, or if hotspot is outside any of the frames.
+// The animation only runs while the Cursor is being shown somewhere; like everything else, it stops while an event handler is running.
+func NewAnimatedCursor(frames []image.Image, hotspot image.Point, interval time.Duration) *Cursor {
+	if interval <= 0 {
+		panic(fmt.Errorf("non-positive interval %v given to NewAnimatedCursor()", interval))
+	}
+	return newCursor(frames, hotspot, interval, "NewAnimatedCursor()")
+}
+
+func newCursor(frames []image.Image, hotspot image.Point, interval time.Duration, which string) *Cursor {
+	if len(frames) == 0 {
+		panic(fmt.Errorf("no frames given to %s", which))
+	}
+	c := &Cursor{
+		interval: interval,
+		users:    make(map[interface{}]func()),
+	}
+	for _, f := range frames {
+		img := toRGBA(f)
+		if !hotspot.In(img.Rect.Sub(img.Rect.Min)) {
+			panic(fmt.Errorf("hotspot %v outside %v image given to %s", hotspot, img.Rect.Size(), which))
+		}
+		c.frames = append(c.frames, imageSysCursor(img, hotspot))
+	}
+	return c
+}
+
+// current returns the frame to show now; a nil Cursor means the default
+func (c *Cursor) current() sysCursor {
+	if c == nil {
+		return nil
+	}
+	return c.frames[c.frame]
+}
+
+// use and unuse are called when something starts and stops showing c; for animated Cursors, show is called each time the frame changes, and the animation runs only while something is showing c
+// key identifies the user, so the same thing can use and unuse c without keeping track of whether it already did
+func (c *Cursor) use(key interface{}, show func()) {
+	if c == nil || len(c.frames) == 1 {
+		return
+	}
+	if len(c.users) == 0 {
+		c.tick = make(chan struct{})
+		c.stop = make(chan struct{})
+		c.fe = NewForeignEvent(c.tick, func(interface{}) {
+			c.frame = (c.frame + 1) % len(c.frames)
+			for _, show := range c.users {
+				show()
+			}
+		})
+		go c.animate(c.tick, c.stop)
+	}
+	c.users[key] = show
+}
+
+func (c *Cursor) unuse(key interface{}) {
+	if c == nil || len(c.frames) == 1 {
+		return
+	}
+	if _, ok := c.users[key]; !ok {
+		return
+	}
+	delete(c.users, key)
+	if len(c.users) == 0 {
+		c.fe.Stop()
+		close(c.stop)
+	}
+}
+
+// animate runs on its own goroutine while an animated Cursor is in use
+func (c *Cursor) animate(tick chan<- struct{}, stop <-chan struct{}) {
+	defer close(tick) // lets the ForeignEvent's goroutine go away
+	t := time.NewTicker(c.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+		case <-stop:
+			return
+		}
+		select {
+		case tick <- struct{}{}:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// the key the override cursor uses in Cursor.use()
+var overrideKey = new(struct{})
+
+var overrideCursor *Cursor
+
+// SetOverrideCursor shows c whenever the mouse is over any Window of the program, regardless of what the Controls under it would show, until SetOverrideCursor(nil) is called.
+// It is meant for operations long enough that the user should know the program is working, such as loading a file on another goroutine; NamedCursor("progress") is usually the right Cursor for that, since the program still responds to the user.
+// For work done in an event handler itself, see Busy.
+// On GTK+, Windows and Controls made while an override cursor is set don't show it.
+func SetOverrideCursor(c *Cursor) {
+	overrideCursor.unuse(overrideKey)
+	overrideCursor = c
+	c.use(overrideKey, func() {
+		setSysOverrideCursor(c.current())
+	})
+	setSysOverrideCursor(c.current())
+}
+
+var busyCursor *Cursor
+
+// Busy shows the busy cursor over all the program's Windows, as SetOverrideCursor would, while f runs, and then puts back the override cursor from before, if any.
+// f runs on the main thread, so no events are handled and nothing is redrawn until it returns; Busy is for things that take a second or two, such as sorting a large Table in an event handler.
+// On Mac OS X, which has no busy cursor of its own for programs to show (the system shows one on its own when a program stops responding), Busy just runs f.
+func Busy(f func()) {
+	if busyCursor == nil {
+		busyCursor, _ = NamedCursor("wait")
+	}
+	prev := overrideCursor
+	SetOverrideCursor(busyCursor)
+	defer SetOverrideCursor(prev)
+	f()
+}
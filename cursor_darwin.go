@@ -0,0 +1,30 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"image"
+	"unsafe"
+)
+
+// #include "objc_darwin.h"
+import "C"
+
+type sysCursor C.id
+
+func namedSysCursor(name string) (sysCursor, bool) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	c := C.namedCursor(cname)
+	return sysCursor(c), c != nil
+}
+
+func imageSysCursor(img *image.RGBA, hotspot image.Point) sysCursor {
+	return sysCursor(C.newImageCursor(unsafe.Pointer(pixelData(img)),
+		C.intptr_t(img.Rect.Dx()), C.intptr_t(img.Rect.Dy()), C.intptr_t(img.Stride),
+		C.intptr_t(hotspot.X), C.intptr_t(hotspot.Y)))
+}
+
+func setSysOverrideCursor(c sysCursor) {
+	C.setOverrideCursor(C.id(c))
+}
@@ -0,0 +1,69 @@
+// 14 october 2026
+
+#include "objc_darwin.h"
+#import <Cocoa/Cocoa.h>
+
+#define toNSCursor(x) ((NSCursor *) (x))
+
+// returns nil if there is no such cursor
+id namedCursor(char *name)
+{
+#define is(n) (strcmp(name, (n)) == 0)
+	if (is("default"))
+		return [NSCursor arrowCursor];
+	if (is("text"))
+		return [NSCursor IBeamCursor];
+	if (is("pointer"))
+		return [NSCursor pointingHandCursor];
+	if (is("crosshair"))
+		return [NSCursor crosshairCursor];
+	if (is("not-allowed"))
+		return [NSCursor operationNotAllowedCursor];
+	if (is("ew-resize"))
+		return [NSCursor resizeLeftRightCursor];
+	if (is("ns-resize"))
+		return [NSCursor resizeUpDownCursor];
+	if (is("grab"))
+		return [NSCursor openHandCursor];
+	if (is("grabbing"))
+		return [NSCursor closedHandCursor];
+	if (is("copy"))
+		return [NSCursor dragCopyCursor];
+	if (is("alias"))
+		return [NSCursor dragLinkCursor];
+	if (is("context-menu"))
+		return [NSCursor contextualMenuCursor];
+#undef is
+	return nil;
+}
+
+id newImageCursor(void *pixels, intptr_t width, intptr_t height, intptr_t stride, intptr_t xHotspot, intptr_t yHotspot)
+{
+	NSImage *image;
+
+	image = (NSImage *) toTableImage(pixels, width, height, stride);
+	return [[NSCursor alloc] initWithImage:image hotSpot:NSMakePoint((CGFloat) xHotspot, (CGFloat) yHotspot)];
+}
+
+void setCursor(id c)
+{
+	[toNSCursor(c) set];
+}
+
+// cursor rects (which the Area and the text controls use) would put back their own cursor as soon as the mouse moves, so turn them off while there's an override cursor
+void setOverrideCursor(id c)
+{
+	NSWindow *w;
+
+	for (w in [NSApp windows])
+		if (c != nil)
+			[w disableCursorRects];
+		else {
+			[w enableCursorRects];
+			[w resetCursorRects];
+		}
+	if (c != nil)
+		[toNSCursor(c) set];
+	else
+		[[NSCursor arrowCursor] set];
+}
@@ -0,0 +1,61 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+#include "gtk_unix.h"
+
+// GTK+ has no such thing as an override cursor, so we set the cursor of every GdkWindow of every toplevel instead (GtkEntry and GtkTextView have GdkWindows of their own, with the I-beam cursor, and so does an Area's GtkDrawingArea); the cursor each had before is kept in object data so it can be put back
+#define overriddenKey "gouiOverridden"
+#define prevCursorKey "gouiPrevCursor"
+
+static void overrideWindow(GdkWindow *w, GdkCursor *c)
+{
+	GList *l;
+	GdkCursor *prev;
+
+	if (c != NULL) {
+		if (g_object_get_data(G_OBJECT(w), overriddenKey) == NULL) {
+			prev = gdk_window_get_cursor(w);
+			if (prev != NULL)
+				g_object_set_data_full(G_OBJECT(w), prevCursorKey, g_object_ref(prev), g_object_unref);
+			g_object_set_data(G_OBJECT(w), overriddenKey, GINT_TO_POINTER(TRUE));
+		}
+		gdk_window_set_cursor(w, c);
+	} else if (g_object_get_data(G_OBJECT(w), overriddenKey) != NULL) {
+		// set the cursor first; removing the data drops our reference to it
+		gdk_window_set_cursor(w, (GdkCursor *) g_object_get_data(G_OBJECT(w), prevCursorKey));
+		g_object_set_data(G_OBJECT(w), prevCursorKey, NULL);
+		g_object_set_data(G_OBJECT(w), overriddenKey, NULL);
+	}
+	for (l = gdk_window_peek_children(w); l != NULL; l = l->next)
+		overrideWindow(GDK_WINDOW(l->data), c);
+}
+
+void setOverrideCursor(GdkCursor *c)
+{
+	GList *toplevels, *l;
+	GdkWindow *w;
+
+	toplevels = gtk_window_list_toplevels();
+	for (l = toplevels; l != NULL; l = l->next) {
+		w = gtk_widget_get_window(GTK_WIDGET(l->data));
+		if (w != NULL)		// not realized
+			overrideWindow(w, c);
+	}
+	g_list_free(toplevels);
+	// show it now, in case the main loop doesn't get to run for a while (as in Busy())
+	gdk_display_flush(gdk_display_get_default());
+}
+
+// for the Area; while an override cursor is set, this changes the cursor to put back afterward instead
+void setWindowCursor(GdkWindow *w, GdkCursor *c)
+{
+	if (g_object_get_data(G_OBJECT(w), overriddenKey) == NULL) {
+		gdk_window_set_cursor(w, c);
+		return;
+	}
+	if (c != NULL)
+		g_object_set_data_full(G_OBJECT(w), prevCursorKey, g_object_ref(c), g_object_unref);
+	else
+		g_object_set_data(G_OBJECT(w), prevCursorKey, NULL);
+}
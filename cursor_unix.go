@@ -0,0 +1,34 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+package ui
+
+import (
+	"image"
+	"unsafe"
+)
+
+// #include "gtk_unix.h"
+import "C"
+
+type sysCursor *C.GdkCursor
+
+// gdk_cursor_new_from_name() looks the name up in the cursor theme, so this covers more than the names documented for NamedCursor
+// from GTK+ 3.18 on, it also maps the CSS names to older ones if the theme doesn't have them; most themes have the CSS names anyway
+func namedSysCursor(name string) (sysCursor, bool) {
+	cname := togstr(name)
+	defer freegstr(cname)
+	c := C.gdk_cursor_new_from_name(C.gdk_display_get_default(), cname)
+	return sysCursor(c), c != nil
+}
+
+func imageSysCursor(img *image.RGBA, hotspot image.Point) sysCursor {
+	pixbuf := toGdkPixbuf(img)
+	defer C.g_object_unref(C.gpointer(unsafe.Pointer(pixbuf)))
+	return sysCursor(C.gdk_cursor_new_from_pixbuf(C.gdk_display_get_default(), pixbuf, C.gint(hotspot.X), C.gint(hotspot.Y)))
+}
+
+func setSysOverrideCursor(c sysCursor) {
+	C.setOverrideCursor(c)
+}
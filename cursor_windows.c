@@ -0,0 +1,63 @@
+// 14 october 2026
+
+#include "winapi_windows.h"
+#include "_cgo_export.h"
+
+HCURSOR hOverrideCursor = NULL;
+
+HCURSOR loadSystemCursor(WORD id)
+{
+	HCURSOR c;
+
+	// system cursors are shared; they don't need to be (and must not be) destroyed
+	c = LoadCursorW(NULL, MAKEINTRESOURCEW(id));
+	if (c == NULL)
+		xpanic("error loading system cursor", GetLastError());
+	return c;
+}
+
+HCURSOR newImageCursor(void *i, intptr_t dx, intptr_t dy, DWORD xHotspot, DWORD yHotspot)
+{
+	ICONINFO ii;
+	HCURSOR c;
+
+	ZeroMemory(&ii, sizeof (ICONINFO));
+	ii.fIcon = FALSE;
+	ii.xHotspot = xHotspot;
+	ii.yHotspot = yHotspot;
+	ii.hbmColor = toBitmap(i, dx, dy);
+	// the mask is still required with a 32-bit color bitmap, even though the alpha channel is used instead; an all-zero one does nothing
+	ii.hbmMask = CreateBitmap((int) dx, (int) dy, 1, 1, NULL);
+	if (ii.hbmMask == NULL)
+		xpanic("error creating cursor mask bitmap in newImageCursor()", GetLastError());
+	c = (HCURSOR) CreateIconIndirect(&ii);
+	if (c == NULL)
+		xpanic("error creating cursor in newImageCursor()", GetLastError());
+	// CreateIconIndirect() makes its own copies of the bitmaps
+	freeBitmap((uintptr_t) ii.hbmColor);
+	freeBitmap((uintptr_t) ii.hbmMask);
+	return c;
+}
+
+// sharedWndProc() shows hOverrideCursor on WM_SETCURSOR; controls ask their parent first when they get WM_SETCURSOR, so this covers every control
+// but WM_SETCURSOR is only sent when the mouse moves, so show the new cursor now
+void setOverrideCursor(HCURSOR c)
+{
+	POINT pt;
+	HWND under;
+
+	hOverrideCursor = c;
+	if (GetCursorPos(&pt) == 0)
+		return;			// this can fail if the desktop isn't ours (for instance, if the workstation is locked); there's nothing to show the cursor over anyway
+	// SetCursor() should only be called while the mouse is over one of our windows
+	under = WindowFromPoint(pt);
+	if (under == NULL || GetWindowThreadProcessId(under, NULL) != GetCurrentThreadId())
+		return;
+	if (c != NULL) {
+		SetCursor(c);
+		return;
+	}
+	// otherwise have the window under the mouse choose its cursor again; moving the mouse to where it is sends it WM_SETCURSOR
+	if (SetCursorPos(pt.x, pt.y) == 0)
+		xpanic("error resetting cursor after removing override cursor", GetLastError());
+}
@@ -0,0 +1,48 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"image"
+	"unsafe"
+)
+
+// #include "winapi_windows.h"
+import "C"
+
+type sysCursor C.HCURSOR
+
+// these are the values of the IDC_* constants, which cgo can't use, since they're cast to LPWSTR
+var systemCursors = map[string]C.WORD{
+	"default":     32512, // IDC_ARROW
+	"text":        32513, // IDC_IBEAM
+	"wait":        32514, // IDC_WAIT
+	"crosshair":   32515, // IDC_CROSS
+	"nwse-resize": 32642, // IDC_SIZENWSE
+	"nesw-resize": 32643, // IDC_SIZENESW
+	"ew-resize":   32644, // IDC_SIZEWE
+	"ns-resize":   32645, // IDC_SIZENS
+	"move":        32646, // IDC_SIZEALL
+	"not-allowed": 32648, // IDC_NO
+	"pointer":     32649, // IDC_HAND
+	"progress":    32650, // IDC_APPSTARTING
+	"help":        32651, // IDC_HELP
+}
+
+func namedSysCursor(name string) (sysCursor, bool) {
+	id, ok := systemCursors[name]
+	if !ok {
+		return nil, false
+	}
+	return sysCursor(C.loadSystemCursor(id)), true
+}
+
+func imageSysCursor(img *image.RGBA, hotspot image.Point) sysCursor {
+	return sysCursor(C.newImageCursor(unsafe.Pointer(img),
+		C.intptr_t(img.Rect.Dx()), C.intptr_t(img.Rect.Dy()),
+		C.DWORD(hotspot.X), C.DWORD(hotspot.Y)))
+}
+
+func setSysOverrideCursor(c sysCursor) {
+	C.setOverrideCursor(C.HCURSOR(c))
+}
@@ -0,0 +1,98 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"sync"
+	"time"
+)
+
+// CustomEvent is an event that the program sends to a Window or an Area with Post, for instance to hand it data that arrived on another goroutine.
+type CustomEvent struct {
+	// Data is the value given to Post.
+	Data interface{}
+
+	// Timestamp is the time at which Post was called, on the same clock as MouseEvent.Timestamp.
+	// Since the event is delivered some time after that, other events handled in between may have later Timestamps.
+	Timestamp time.Duration
+}
+
+// AreaCustomHandler can optionally be implemented by an AreaHandler to receive the CustomEvents sent with Area.Post.
+// If the AreaHandler does not implement it, such events are discarded.
+// As with the rest of AreaHandler, Custom is executed on the main goroutine.
+type AreaCustomHandler interface {
+	Custom(e CustomEvent)
+}
+
+type posted struct {
+	f func(e CustomEvent)
+	e CustomEvent
+}
+
+var (
+	postlock  sync.Mutex
+	postqueue []posted
+)
+
+// post queues f to be run with e on the main goroutine
+// the queue is run by a single Do(), which is started by whichever post() finds the queue empty; this keeps the events in order without Post() ever blocking, even on the main goroutine
+func post(f func(e CustomEvent), data interface{}) {
+	postlock.Lock()
+	defer postlock.Unlock()
+
+	postqueue = append(postqueue, posted{
+		f: f,
+		e: CustomEvent{
+			Data:      data,
+			Timestamp: eventTime(),
+		},
+	})
+	if len(postqueue) == 1 {
+		go Do(runPosted)
+	}
+}
+
+func runPosted() {
+	postlock.Lock()
+	q := postqueue
+	postqueue = nil
+	postlock.Unlock()
+	for _, p := range q {
+		p.f(p.e)
+	}
+}
+
+// customHandler is embedded by each Window implementation to provide Post and OnCustomEvent
+type customHandler struct {
+	lock sync.Mutex // Post() can be called from any goroutine
+	f    func(e CustomEvent)
+}
+
+func (c *customHandler) OnCustomEvent(f func(e CustomEvent)) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.f = f
+}
+
+func (c *customHandler) Post(data interface{}) {
+	post(c.fire, data)
+}
+
+func (c *customHandler) fire(e CustomEvent) {
+	c.lock.Lock()
+	f := c.f
+	c.lock.Unlock()
+	if f != nil {
+		f(e)
+	}
+}
+
+func (a *areabase) Post(data interface{}) {
+	post(a.custom, data)
+}
+
+func (a *areabase) custom(e CustomEvent) {
+	if c, ok := a.handler.(AreaCustomHandler); ok {
+		c.Custom(e)
+	}
+}
@@ -2,15 +2,66 @@
 
 package ui
 
+import (
+	"image/color"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
 type windowDialog interface {
 	openFile(f func(filename string))
+	saveFile(ext string, f func(filename string))
+	msgBox(primary string, secondary string, style msgBoxStyle, f func(result DialogResult))
+	chooseColor(initial color.NRGBA, f func(c color.Color))
+	chooseFont(initial FontDescriptor, f func(font *FontDescriptor))
+
+	// for dialog boxes made of our own Controls (see prompt.go)
+	// showModal shows dialog modal to the window; endModal has to be called before dialog is closed
+	showModal(dialog Window)
+	endModal(dialog Window)
+}
+
+// dialogAttachment implements Window.DialogsAttached() and Window.SetDialogsAttached(); each backend's window embeds one, but only Mac OS X looks at it
+type dialogAttachment struct {
+	detached bool // so the zero value is attached
+}
+
+func (d *dialogAttachment) DialogsAttached() bool {
+	return !d.detached
+}
+
+func (d *dialogAttachment) SetDialogsAttached(attached bool) {
+	d.detached = !attached
+}
+
+// the dialog boxes outlive the calls that open them, and C can't hold on to Go pointers, so the function to run when one closes is kept here and C only gets its handle
+// the backends call takeDialogFunc() exactly once per addDialogFunc(), from the main thread, like everything else here
+var (
+	dialogFuncs      = make(map[uintptr]interface{})
+	nextDialogHandle = uintptr(1) // so 0 is never a valid handle
+)
+
+func addDialogFunc(f interface{}) uintptr {
+	h := nextDialogHandle
+	nextDialogHandle++
+	dialogFuncs[h] = f
+	return h
+}
+
+func takeDialogFunc(h uintptr) interface{} {
+	f, ok := dialogFuncs[h]
+	if !ok {
+		panic("invalid or already used dialog handle")
+	}
+	delete(dialogFuncs, h)
+	return f
 }
 
 // OpenFile opens a dialog box that asks the user to choose a file.
 // The dialog box is modal to win, which mut not be nil.
 // Some time after the dialog box is closed, OpenFile runs f on the main thread, passing filename.
 // filename is the selected filename, or an empty string if no file was chosen.
-// OpenFile does not ensure that f remains alive; the programmer is responsible for this.
 // If possible on a given system, OpenFile() will not dereference links; it will return the link file itself.
 // Hidden files will not be hidden by OpenFile().
 func OpenFile(win Window, f func(filename string)) {
@@ -19,3 +70,140 @@ func OpenFile(win Window, f func(filename string)) {
 	}
 	win.openFile(f)
 }
+
+// SaveFile opens a dialog box that asks the user to choose a filename to save to.
+// The dialog box is modal to win, which must not be nil.
+// Some time after the dialog box is closed, SaveFile runs f on the main thread, passing filename.
+// filename is the chosen filename, or an empty string if the dialog was cancelled.
+// If ext is not empty, it is the default extension (without the leading dot) and is appended to filenames the user enters without one.
+// If the chosen file already exists, the user is asked to confirm replacing it before the dialog box closes; filename will not be an existing file the user did not agree to replace.
+// SaveFile does not create or write to the file; see WriteFileAtomic for a safe way to do that.
+func SaveFile(win Window, ext string, f func(filename string)) {
+	if win == nil {
+		panic("Window passed to SaveFile() cannot be nil")
+	}
+	win.saveFile(ext, f)
+}
+
+// ChooseColor opens the system's color chooser to let the user pick a color, starting with initial.
+// The color chooser is modal to win, which must not be nil; on Mac OS X, it is the shared color panel instead, which is not modal.
+// Some time after the color chooser is closed, ChooseColor runs f on the main thread, passing the chosen color as a color.NRGBA, or nil if the user cancelled.
+// The Mac OS X color panel has no Cancel button, so c is never nil there.
+// Colors are always opaque; the alpha of initial is ignored.
+func ChooseColor(win Window, initial color.Color, f func(c color.Color)) {
+	if win == nil {
+		panic("Window passed to ChooseColor() cannot be nil")
+	}
+	if f == nil {
+		panic("function passed to ChooseColor() cannot be nil")
+	}
+	win.chooseColor(toOpaqueNRGBA(initial), f)
+}
+
+// the color choosers of Windows and the Mac OS X color well don't do alpha, so none of them do
+func toOpaqueNRGBA(c color.Color) color.NRGBA {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	n.A = 0xFF
+	return n
+}
+
+// WriteFileAtomic writes data to the named file so that other programs see either the old contents or the new contents, never a partially written file.
+// It does this by writing to a temporary file in the same directory and renaming it over filename once the data has been flushed to disk.
+// If filename does not exist, it is created with permissions perm; otherwise its existing permissions are kept.
+// On error, filename is left unchanged and the temporary file is removed.
+// WriteFileAtomic can be called from any goroutine.
+func WriteFileAtomic(filename string, data []byte, perm os.FileMode) (err error) {
+	dir, base := filepath.Split(filename)
+	if dir == "" {
+		dir = "."
+	}
+	if fi, err := os.Stat(filename); err == nil {
+		perm = fi.Mode().Perm()
+	}
+	f, err := ioutil.TempFile(dir, "."+base+".")
+	if err != nil {
+		return err
+	}
+	tmpname := f.Name()
+	defer func() {
+		if err != nil {
+			f.Close()		// in case we failed before closing; the error is irrelevant
+			os.Remove(tmpname)
+		}
+	}()
+	if _, err = f.Write(data); err != nil {
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmpname, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmpname, filename)
+}
+
+// DialogResult identifies the button the user chose to dismiss a message box.
+type DialogResult uint
+
+const (
+	// DialogOK is returned by the message boxes with a single button.
+	DialogOK DialogResult = iota
+	DialogYes
+	DialogNo
+	// DialogCancel is returned if the user chose Cancel or otherwise dismissed the dialog box without making a choice (for instance, by pressing Escape).
+	DialogCancel
+)
+
+type msgBoxStyle uint
+
+const (
+	msgBoxInfo msgBoxStyle = iota
+	msgBoxError
+	msgBoxYesNoCancel
+)
+
+// MsgBox opens a dialog box that shows a message to the user.
+// primary is the main message; secondary, which may be empty, is additional descriptive text shown beneath it.
+// The dialog box is modal to win, which must not be nil.
+// Some time after the dialog box is closed, MsgBox runs f on the main thread; f may be nil.
+func MsgBox(win Window, primary string, secondary string, f func()) {
+	if win == nil {
+		panic("Window passed to MsgBox() cannot be nil")
+	}
+	win.msgBox(primary, secondary, msgBoxInfo, func(DialogResult) {
+		if f != nil {
+			f()
+		}
+	})
+}
+
+// MsgBoxError is like MsgBox, but signals to the user that an error has occurred.
+func MsgBoxError(win Window, primary string, secondary string, f func()) {
+	if win == nil {
+		panic("Window passed to MsgBoxError() cannot be nil")
+	}
+	win.msgBox(primary, secondary, msgBoxError, func(DialogResult) {
+		if f != nil {
+			f()
+		}
+	})
+}
+
+// Confirm opens a dialog box that asks the user a question with Yes, No, and Cancel as possible answers.
+// primary and secondary are as with MsgBox.
+// The dialog box is modal to win, which must not be nil.
+// Some time after the dialog box is closed, Confirm runs f on the main thread, passing the chosen button.
+// result is one of DialogYes, DialogNo, or DialogCancel.
+func Confirm(win Window, primary string, secondary string, f func(result DialogResult)) {
+	if win == nil {
+		panic("Window passed to Confirm() cannot be nil")
+	}
+	if f == nil {
+		panic("function passed to Confirm() cannot be nil")
+	}
+	win.msgBox(primary, secondary, msgBoxYesNoCancel, f)
+}
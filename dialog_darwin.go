@@ -3,6 +3,7 @@
 package ui
 
 import (
+	"image/color"
 	"unsafe"
 )
 
@@ -10,16 +11,99 @@ import (
 import "C"
 
 func (w *window) openFile(f func(filename string)) {
-	C.openFile(w.id, unsafe.Pointer(&f))
+	C.openFile(w.id, toBOOL(!w.detached), C.uintptr_t(addDialogFunc(f)))
+}
+
+func (w *window) saveFile(ext string, f func(filename string)) {
+	cext := (*C.char)(nil)
+	if ext != "" {
+		cext = C.CString(ext)
+		defer C.free(unsafe.Pointer(cext))
+	}
+	// the result comes back through finishOpenFile() as well
+	C.saveFile(w.id, cext, toBOOL(!w.detached), C.uintptr_t(addDialogFunc(f)))
 }
 
 //export finishOpenFile
-func finishOpenFile(fname *C.char, data unsafe.Pointer) {
-	f := (*func(string))(data)
+func finishOpenFile(fname *C.char, h C.uintptr_t) {
+	f := takeDialogFunc(uintptr(h)).(func(string))
 	if fname == nil {
-		(*f)("")
+		f("")
 		return
 	}
 	defer C.free(unsafe.Pointer(fname))
-	(*f)(C.GoString(fname))
+	f(C.GoString(fname))
+}
+
+func (w *window) msgBox(primary string, secondary string, style msgBoxStyle, f func(result DialogResult)) {
+	cprimary := C.CString(primary)
+	defer C.free(unsafe.Pointer(cprimary))
+	csecondary := (*C.char)(nil)
+	if secondary != "" {
+		csecondary = C.CString(secondary)
+		defer C.free(unsafe.Pointer(csecondary))
+	}
+	C.msgBox(w.id, cprimary, csecondary,
+		toBOOL(style == msgBoxError),
+		toBOOL(style == msgBoxYesNoCancel),
+		toBOOL(!w.detached),
+		C.uintptr_t(addDialogFunc(f)))
+}
+
+//export finishMsgBox
+func finishMsgBox(ret C.int, h C.uintptr_t) {
+	f := takeDialogFunc(uintptr(h)).(func(DialogResult))
+	switch ret {
+	case C.msgBoxOK:
+		f(DialogOK)
+	case C.msgBoxYes:
+		f(DialogYes)
+	case C.msgBoxNo:
+		f(DialogNo)
+	default:
+		f(DialogCancel)
+	}
+}
+
+// sheets are how Mac OS X does dialog boxes modal to a single window; see also Window.SetDialogsAttached()
+// the dialog box has to end the way it started, so remember how that was
+func (w *window) showModal(dialog Window) {
+	d := dialog.(*window)
+	d.modalattached = !w.detached
+	C.windowBeginModal(w.id, d.id, toBOOL(d.modalattached))
+}
+
+func (w *window) endModal(dialog Window) {
+	d := dialog.(*window)
+	C.windowEndModal(w.id, d.id, toBOOL(d.modalattached))
+}
+
+func (w *window) chooseColor(initial color.NRGBA, f func(c color.Color)) {
+	C.chooseColor(w.id, C.uint8_t(initial.R), C.uint8_t(initial.G), C.uint8_t(initial.B), C.uintptr_t(addDialogFunc(f)))
+}
+
+//export finishChooseColor
+func finishChooseColor(r C.uint8_t, g C.uint8_t, b C.uint8_t, h C.uintptr_t) {
+	f := takeDialogFunc(uintptr(h)).(func(color.Color))
+	f(color.NRGBA{uint8(r), uint8(g), uint8(b), 0xFF})
+}
+
+func (w *window) chooseFont(initial FontDescriptor, f func(font *FontDescriptor)) {
+	cfamily := (*C.char)(nil)
+	if initial.Family != "" {
+		cfamily = C.CString(initial.Family)
+		defer C.free(unsafe.Pointer(cfamily))
+	}
+	C.chooseFont(w.id, cfamily, C.double(initial.Size), C.intptr_t(initial.weight()), toBOOL(initial.Italic), C.uintptr_t(addDialogFunc(f)))
+}
+
+//export finishChooseFont
+func finishChooseFont(family *C.char, size C.double, weight C.intptr_t, italic C.BOOL, h C.uintptr_t) {
+	f := takeDialogFunc(uintptr(h)).(func(*FontDescriptor))
+	f(&FontDescriptor{
+		Family: C.GoString(family),
+		Size:   float64(size),
+		Weight: FontWeight(weight),
+		Italic: italic != C.NO,
+	})
 }
@@ -1,11 +1,46 @@
 // 19 august 2014
 
 #import "objc_darwin.h"
+#import "_cgo_export.h"
 #import <Cocoa/Cocoa.h>
 
 #define toNSWindow(x) ((NSWindow *) (x))
 
-void openFile(id parent, void *data)
+// detached dialog boxes (see Window.SetDialogsAttached()) are run with -runModal and friends, which don't return until the dialog box is closed
+// so that the event handler that opened one isn't held up (as it isn't with a sheet), start them from a timer instead
+// a timer rather than dispatch_async(), since Do() uses the main queue, which doesn't run while one of its blocks is still going
+@interface goRunLater : NSObject {
+@public
+	void (^block)(void);
+}
+- (void)run;
+@end
+
+@implementation goRunLater
+
+- (void)run
+{
+	self->block();
+	[self->block release];
+	[self release];
+}
+
+@end
+
+static void runLater(void (^block)(void))
+{
+	goRunLater *r;
+
+	r = [goRunLater new];		// released in run
+	r->block = [block copy];
+	// common modes so that this also works from within another modal loop
+	[r performSelector:@selector(run)
+		withObject:nil
+		afterDelay:0
+		inModes:[NSArray arrayWithObject:NSRunLoopCommonModes]];
+}
+
+void openFile(id parent, BOOL attached, uintptr_t data)
 {
 	NSOpenPanel *op;
 
@@ -19,12 +54,311 @@ void openFile(id parent, void *data)
 	[op setExtensionHidden:NO];
 	[op setAllowsOtherFileTypes:YES];
 	[op setTreatsFilePackagesAsDirectories:YES];
-	[op beginSheetModalForWindow:toNSWindow(parent) completionHandler:^(NSInteger ret){
+	void (^done)(NSInteger) = ^(NSInteger ret){
 		if (ret != NSFileHandlingPanelOKButton) {
 			finishOpenFile(NULL, data);
 			return;
 		}
 		// string freed on the Go side
 		finishOpenFile(strdup([[[op URL] path] UTF8String]), data);
-	}];
+	};
+	if (!attached) {
+		runLater(^{
+			done([op runModal]);
+		});
+		return;
+	}
+	[op beginSheetModalForWindow:toNSWindow(parent) completionHandler:done];
+}
+
+void saveFile(id parent, char *ext, BOOL attached, uintptr_t data)
+{
+	NSSavePanel *sp;
+
+	sp = [NSSavePanel savePanel];
+	[sp setCanCreateDirectories:YES];
+	[sp setShowsHiddenFiles:YES];
+	[sp setCanSelectHiddenExtension:NO];
+	[sp setExtensionHidden:NO];
+	[sp setTreatsFilePackagesAsDirectories:YES];
+	if (ext != NULL) {
+		// this appends the extension if the user leaves it off; NSSavePanel does this before it asks about replacing an existing file
+		[sp setAllowedFileTypes:[NSArray arrayWithObject:[NSString stringWithUTF8String:ext]]];
+		[sp setAllowsOtherFileTypes:YES];
+	}
+	// NSSavePanel asks about replacing existing files on its own
+	void (^done)(NSInteger) = ^(NSInteger ret){
+		if (ret != NSFileHandlingPanelOKButton) {
+			finishOpenFile(NULL, data);
+			return;
+		}
+		// string freed on the Go side
+		finishOpenFile(strdup([[[sp URL] path] UTF8String]), data);
+	};
+	if (!attached) {
+		runLater(^{
+			done([sp runModal]);
+		});
+		return;
+	}
+	[sp beginSheetModalForWindow:toNSWindow(parent) completionHandler:done];
+}
+
+// -[NSAlert beginSheetModalForWindow:completionHandler:] is 10.9+, so use the modal delegate form
+@interface goMsgBoxDelegate : NSObject {
+@public
+	uintptr_t data;
+	BOOL hasNo;
+}
+@end
+
+@implementation goMsgBoxDelegate
+
+- (void)alertDidEnd:(NSAlert *)alert returnCode:(NSInteger)ret contextInfo:(void *)ctx
+{
+	int result = msgBoxOK;
+
+	switch (ret) {
+	case NSAlertFirstButtonReturn:
+		if (self->hasNo)
+			result = msgBoxYes;
+		break;
+	case NSAlertSecondButtonReturn:
+		result = msgBoxCancel;
+		break;
+	case NSAlertThirdButtonReturn:
+		result = msgBoxNo;
+		break;
+	}
+	finishMsgBox(result, self->data);
+	[self release];
+}
+
+@end
+
+void msgBox(id parent, char *primary, char *secondary, BOOL isError, BOOL yesNoCancel, BOOL attached, uintptr_t data)
+{
+	NSAlert *alert;
+	goMsgBoxDelegate *delegate;
+
+	alert = [NSAlert new];
+	[alert setMessageText:[NSString stringWithUTF8String:primary]];
+	if (secondary != NULL)
+		[alert setInformativeText:[NSString stringWithUTF8String:secondary]];
+	[alert setAlertStyle:NSInformationalAlertStyle];
+	if (isError)
+		[alert setAlertStyle:NSCriticalAlertStyle];
+	delegate = [goMsgBoxDelegate new];		// released in alertDidEnd:
+	delegate->data = data;
+	delegate->hasNo = yesNoCancel;
+	if (yesNoCancel) {
+		// rightmost is the default button; the Human Interface Guidelines puts Cancel next to it and the destructive choice farthest away
+		[alert addButtonWithTitle:@"Yes"];
+		[alert addButtonWithTitle:@"Cancel"];
+		[alert addButtonWithTitle:@"No"];
+	}
+	// otherwise NSAlert gives us a single OK button
+	if (!attached) {
+		runLater(^{
+			[delegate alertDidEnd:alert returnCode:[alert runModal] contextInfo:NULL];
+		});
+		return;
+	}
+	[alert beginSheetModalForWindow:toNSWindow(parent)
+		modalDelegate:delegate
+		didEndSelector:@selector(alertDidEnd:returnCode:contextInfo:)
+		contextInfo:NULL];
+}
+
+// NSColorPanel is a shared, modeless panel with no OK or Cancel; we take whatever color it has when the user closes it
+@interface goColorPanelObserver : NSObject {
+@public
+	uintptr_t data;
+}
+- (void)finish;
+@end
+
+static goColorPanelObserver *colorPanelObserver = nil;
+
+@implementation goColorPanelObserver
+
+- (void)panelWillClose:(NSNotification *)note
+{
+	[self finish];
+}
+
+- (void)finish
+{
+	NSColorPanel *cp;
+	uint8_t r, g, b;
+
+	cp = [NSColorPanel sharedColorPanel];
+	[[NSNotificationCenter defaultCenter] removeObserver:self];
+	colorPanelObserver = nil;
+	fromNSColor([cp color], &r, &g, &b);
+	finishChooseColor(r, g, b, self->data);
+	[self release];
+}
+
+@end
+
+void chooseColor(id parent, uint8_t r, uint8_t g, uint8_t b, uintptr_t data)
+{
+	NSColorPanel *cp;
+	goColorPanelObserver *o;
+
+	// only one ChooseColor() can have the panel at a time; the one before gets what it has now
+	if (colorPanelObserver != nil)
+		[colorPanelObserver finish];
+	cp = [NSColorPanel sharedColorPanel];
+	[cp setShowsAlpha:NO];
+	[cp setColor:toNSColor(r, g, b)];
+	o = [goColorPanelObserver new];		// released in finish
+	o->data = data;
+	colorPanelObserver = o;
+	[[NSNotificationCenter defaultCenter] addObserver:o
+		selector:@selector(panelWillClose:)
+		name:NSWindowWillCloseNotification
+		object:cp];
+	[cp makeKeyAndOrderFront:toNSWindow(parent)];
+}
+
+// NSFontPanel is like NSColorPanel, except that it tells us about changes through -changeFont: on the font manager's target
+@interface goFontPanelObserver : NSObject {
+@public
+	uintptr_t data;
+	NSFont *font;
+}
+- (void)finish;
+@end
+
+static goFontPanelObserver *fontPanelObserver = nil;
+
+// NSFontManager weights go from 0 to 15, with 5 normal and 9 bold; FontWeight goes from 100 to 900
+static const NSInteger toNSWeights[] = { 2, 3, 4, 5, 6, 8, 9, 10, 12 };
+
+static NSInteger toNSWeight(intptr_t weight)
+{
+	return toNSWeights[(weight + 50) / 100 - 1];
+}
+
+static intptr_t fromNSWeight(NSInteger weight)
+{
+	int i;
+
+	for (i = 0; i < 8; i++)
+		if (weight <= (toNSWeights[i] + toNSWeights[i + 1]) / 2)
+			break;
+	return (i + 1) * 100;
+}
+
+@implementation goFontPanelObserver
+
+- (void)changeFont:(id)sender
+{
+	NSFont *newfont;
+
+	newfont = [sender convertFont:self->font];
+	[newfont retain];
+	[self->font release];
+	self->font = newfont;
+}
+
+- (void)panelWillClose:(NSNotification *)note
+{
+	[self finish];
+}
+
+- (void)finish
+{
+	NSFontManager *fm;
+
+	fm = [NSFontManager sharedFontManager];
+	[[NSNotificationCenter defaultCenter] removeObserver:self];
+	if ([fm target] == self)
+		[fm setTarget:nil];
+	fontPanelObserver = nil;
+	finishChooseFont((char *) [[self->font familyName] UTF8String],
+		(double) [self->font pointSize],
+		fromNSWeight([fm weightOfFont:self->font]),
+		([fm traitsOfFont:self->font] & NSItalicFontMask) != 0,
+		self->data);
+	[self->font release];
+	[self release];
+}
+
+@end
+
+// also used by TextLayout; see text_darwin.m
+id newFont(char *family, double size, intptr_t weight, BOOL italic)
+{
+	NSFont *font = nil;
+
+	if (family != NULL)
+		font = [[NSFontManager sharedFontManager] fontWithFamily:[NSString stringWithUTF8String:family]
+			traits:(italic ? NSItalicFontMask : 0)
+			weight:toNSWeight(weight)
+			size:(CGFloat) size];
+	if (font == nil)			// no family given, or it isn't installed
+		font = [NSFont systemFontOfSize:(CGFloat) size];
+	return font;
+}
+
+void chooseFont(id parent, char *family, double size, intptr_t weight, BOOL italic, uintptr_t data)
+{
+	NSFontManager *fm;
+	NSFont *font;
+	goFontPanelObserver *o;
+
+	if (fontPanelObserver != nil)
+		[fontPanelObserver finish];
+	fm = [NSFontManager sharedFontManager];
+	font = (NSFont *) newFont(family, size, weight, italic);
+	o = [goFontPanelObserver new];		// released in finish
+	o->data = data;
+	o->font = [font retain];
+	fontPanelObserver = o;
+	[fm setTarget:o];
+	[fm setSelectedFont:font isMultiple:NO];
+	[[NSNotificationCenter defaultCenter] addObserver:o
+		selector:@selector(panelWillClose:)
+		name:NSWindowWillCloseNotification
+		object:[fm fontPanel:YES]];
+	[fm orderFrontFontPanel:toNSWindow(parent)];
+}
+
+// no didEndSelector; prompt.go closes the sheet itself
+void windowBeginModal(id parent, id dialog, BOOL attached)
+{
+	NSRect pr, dr;
+
+	if (!attached) {
+		// in the middle of the parent, where sheets are
+		pr = [toNSWindow(parent) frame];
+		dr = [toNSWindow(dialog) frame];
+		[toNSWindow(dialog) setFrameOrigin:NSMakePoint(pr.origin.x + (pr.size.width - dr.size.width) / 2,
+			pr.origin.y + (pr.size.height - dr.size.height) / 2)];
+		// show it now, so that it isn't run if it is closed before the timer fires
+		[toNSWindow(dialog) makeKeyAndOrderFront:toNSWindow(dialog)];
+		runLater(^{
+			if ([toNSWindow(dialog) isVisible])
+				[NSApp runModalForWindow:toNSWindow(dialog)];
+		});
+		return;
+	}
+	[NSApp beginSheet:toNSWindow(dialog)
+		modalForWindow:toNSWindow(parent)
+		modalDelegate:nil
+		didEndSelector:NULL
+		contextInfo:NULL];
+}
+
+void windowEndModal(id parent, id dialog, BOOL attached)
+{
+	if (!attached) {
+		[NSApp stopModal];
+		return;
+	}
+	[NSApp endSheet:toNSWindow(dialog)];
+	[toNSWindow(dialog) orderOut:toNSWindow(parent)];
 }
@@ -5,11 +5,27 @@
 package ui
 
 import (
+	"image/color"
+	"os"
+	"path/filepath"
 	"unsafe"
 )
 
 // #include "gtk_unix.h"
 // extern void our_openfile_response_callback(GtkDialog *, gint, gpointer);
+// extern void our_msgbox_response_callback(GtkDialog *, gint, gpointer);
+// extern void our_savefile_response_callback(GtkDialog *, gint, gpointer);
+// extern void our_choosecolor_response_callback(GtkDialog *, gint, gpointer);
+// extern void our_choosefont_response_callback(GtkDialog *, gint, gpointer);
+// /* the handles from addDialogFunc() go through signal user data; doing this in C keeps go vet from seeing integers turned into pointers */
+// static inline gpointer toDialogHandle(gsize h)
+// {
+// 	return GSIZE_TO_POINTER(h);
+// }
+// static inline gsize fromDialogHandle(gpointer data)
+// {
+// 	return GPOINTER_TO_SIZE(data);
+// }
 // /* because cgo doesn't like ... */
 // static inline GtkWidget *newOpenFileDialog(GtkWindow *parent)
 // {
@@ -20,6 +36,26 @@ import (
 // 		GTK_STOCK_OPEN, GTK_RESPONSE_ACCEPT,
 // 		NULL);
 // }
+// static inline GtkWidget *newSaveFileDialog(GtkWindow *parent)
+// {
+// 	return gtk_file_chooser_dialog_new(NULL,	/* default title */
+// 		parent,
+// 		GTK_FILE_CHOOSER_ACTION_SAVE,
+// 		GTK_STOCK_CANCEL, GTK_RESPONSE_CANCEL,
+// 		GTK_STOCK_SAVE, GTK_RESPONSE_ACCEPT,
+// 		NULL);
+// }
+// static inline GtkWidget *newMsgBox(GtkWindow *parent, GtkMessageType type, GtkButtonsType buttons, gchar *primary, gchar *secondary)
+// {
+// 	GtkWidget *dialog;
+//
+// 	dialog = gtk_message_dialog_new(parent,
+// 		GTK_DIALOG_MODAL | GTK_DIALOG_DESTROY_WITH_PARENT,
+// 		type, buttons, "%s", (char *) primary);
+// 	if (secondary != NULL)
+// 		gtk_message_dialog_format_secondary_text(GTK_MESSAGE_DIALOG(dialog), "%s", (char *) secondary);
+// 	return dialog;
+// }
 import "C"
 
 func (w *window) openFile(f func(filename string)) {
@@ -36,15 +72,15 @@ func (w *window) openFile(f func(filename string)) {
 		C.gpointer(unsafe.Pointer(dialog)),
 		"response",
 		C.GCallback(C.our_openfile_response_callback),
-		C.gpointer(unsafe.Pointer(&f)))
+		C.toDialogHandle(C.gsize(addDialogFunc(f))))
 	C.gtk_widget_show_all(widget)
 }
 
 //export our_openfile_response_callback
 func our_openfile_response_callback(dialog *C.GtkDialog, response C.gint, data C.gpointer) {
-	f := (*func(string))(unsafe.Pointer(data))
+	f := takeDialogFunc(uintptr(C.fromDialogHandle(data))).(func(string))
 	if response != C.GTK_RESPONSE_ACCEPT {
-		(*f)("")
+		f("")
 		C.gtk_widget_destroy((*C.GtkWidget)(unsafe.Pointer(dialog)))
 		return
 	}
@@ -55,5 +91,271 @@ func our_openfile_response_callback(dialog *C.GtkDialog, response C.gint, data C
 	realfilename := fromgstr(filename)
 	C.g_free(C.gpointer(unsafe.Pointer(filename)))
 	C.gtk_widget_destroy((*C.GtkWidget)(unsafe.Pointer(dialog)))
-	(*f)(realfilename)
+	f(realfilename)
+}
+
+type saveFileData struct {
+	ext string
+	f   func(filename string)
+}
+
+func (w *window) saveFile(ext string, f func(filename string)) {
+	widget := C.newSaveFileDialog(w.window)
+	window := (*C.GtkWindow)(unsafe.Pointer(widget))
+	dialog := (*C.GtkDialog)(unsafe.Pointer(widget))
+	fc := (*C.GtkFileChooser)(unsafe.Pointer(widget))
+	C.gtk_file_chooser_set_local_only(fc, C.TRUE)
+	C.gtk_file_chooser_set_select_multiple(fc, C.FALSE)
+	C.gtk_file_chooser_set_show_hidden(fc, C.TRUE)
+	// with a default extension, the filename to confirm isn't known until the response callback adds it
+	C.gtk_file_chooser_set_do_overwrite_confirmation(fc, togbool(ext == ""))
+	C.gtk_window_set_modal(window, C.TRUE)
+	data := &saveFileData{
+		ext: ext,
+		f:   f,
+	}
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(dialog)),
+		"response",
+		C.GCallback(C.our_savefile_response_callback),
+		C.toDialogHandle(C.gsize(addDialogFunc(data))))
+	C.gtk_widget_show_all(widget)
+}
+
+//export our_savefile_response_callback
+func our_savefile_response_callback(dialog *C.GtkDialog, response C.gint, data C.gpointer) {
+	h := uintptr(C.fromDialogHandle(data))
+	widget := (*C.GtkWidget)(unsafe.Pointer(dialog))
+	if response != C.GTK_RESPONSE_ACCEPT {
+		s := takeDialogFunc(h).(*saveFileData)
+		C.gtk_widget_destroy(widget)
+		s.f("")
+		return
+	}
+	// not taken yet; the dialog stays open if the user doesn't want to replace an existing file
+	s := dialogFuncs[h].(*saveFileData)
+	filename := C.gtk_file_chooser_get_filename((*C.GtkFileChooser)(unsafe.Pointer(dialog)))
+	if filename == nil {
+		panic("chosen filename NULL in SaveFile()")
+	}
+	realfilename := fromgstr(filename)
+	C.g_free(C.gpointer(unsafe.Pointer(filename)))
+	finish := func() {
+		takeDialogFunc(h)
+		C.gtk_widget_destroy(widget)
+		s.f(realfilename)
+	}
+	if s.ext == "" {		// GtkFileChooser already asked about replacing it
+		finish()
+		return
+	}
+	// GtkFileChooser does not add extensions for us
+	switch filepath.Ext(realfilename) {
+	case "":
+		realfilename += "." + s.ext
+	case ".":		// "name." has no extension either
+		realfilename += s.ext
+	}
+	if _, err := os.Stat(realfilename); err != nil {
+		finish()
+		return
+	}
+	// GtkFileChooser's own confirmation would only have seen what the user typed, so it's off (see saveFile() above); ask over the file chooser instead, so that it is still open if the answer is no
+	msgBoxFor((*C.GtkWindow)(unsafe.Pointer(dialog)),
+		"A file named \""+filepath.Base(realfilename)+"\" already exists. Do you want to replace it?",
+		"The file already exists in \""+filepath.Dir(realfilename)+"\". Replacing it will overwrite its contents.",
+		msgBoxYesNoCancel, func(result DialogResult) {
+			// the file chooser may have been closed from the window manager in the meantime
+			if _, open := dialogFuncs[h]; open && result == DialogYes {
+				finish()
+			}
+		})
+}
+
+func (w *window) msgBox(primary string, secondary string, style msgBoxStyle, f func(result DialogResult)) {
+	msgBoxFor(w.window, primary, secondary, style, f)
+}
+
+// this is separate so the save dialog can show a message box modal to itself
+func msgBoxFor(parent *C.GtkWindow, primary string, secondary string, style msgBoxStyle, f func(result DialogResult)) {
+	ctype := C.GtkMessageType(C.GTK_MESSAGE_INFO)
+	cbuttons := C.GtkButtonsType(C.GTK_BUTTONS_OK)
+	switch style {
+	case msgBoxError:
+		ctype = C.GTK_MESSAGE_ERROR
+	case msgBoxYesNoCancel:
+		ctype = C.GTK_MESSAGE_QUESTION
+		cbuttons = C.GTK_BUTTONS_NONE
+	}
+	cprimary := togstr(primary)
+	defer freegstr(cprimary)
+	csecondary := (*C.gchar)(nil)
+	if secondary != "" {
+		csecondary = togstr(secondary)
+		defer freegstr(csecondary)
+	}
+	widget := C.newMsgBox(parent, ctype, cbuttons, cprimary, csecondary)
+	dialog := (*C.GtkDialog)(unsafe.Pointer(widget))
+	if style == msgBoxYesNoCancel {
+		// GTK_BUTTONS_YES_NO has no Cancel, so add the buttons ourselves in the order the HIG wants
+		cancel := togstr("_Cancel")
+		no := togstr("_No")
+		yes := togstr("_Yes")
+		C.gtk_dialog_add_button(dialog, cancel, C.GTK_RESPONSE_CANCEL)
+		C.gtk_dialog_add_button(dialog, no, C.GTK_RESPONSE_NO)
+		C.gtk_dialog_add_button(dialog, yes, C.GTK_RESPONSE_YES)
+		freegstr(cancel)
+		freegstr(no)
+		freegstr(yes)
+		C.gtk_dialog_set_default_response(dialog, C.GTK_RESPONSE_YES)
+	}
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(dialog)),
+		"response",
+		C.GCallback(C.our_msgbox_response_callback),
+		C.toDialogHandle(C.gsize(addDialogFunc(f))))
+	C.gtk_widget_show_all(widget)
+}
+
+//export our_msgbox_response_callback
+func our_msgbox_response_callback(dialog *C.GtkDialog, response C.gint, data C.gpointer) {
+	f := takeDialogFunc(uintptr(C.fromDialogHandle(data))).(func(DialogResult))
+	C.gtk_widget_destroy((*C.GtkWidget)(unsafe.Pointer(dialog)))
+	switch response {
+	case C.GTK_RESPONSE_OK:
+		f(DialogOK)
+	case C.GTK_RESPONSE_YES:
+		f(DialogYes)
+	case C.GTK_RESPONSE_NO:
+		f(DialogNo)
+	default:		// GTK_RESPONSE_CANCEL, GTK_RESPONSE_DELETE_EVENT, etc.
+		f(DialogCancel)
+	}
+}
+
+func (w *window) chooseColor(initial color.NRGBA, f func(c color.Color)) {
+	widget := C.gtk_color_chooser_dialog_new(nil, w.window)
+	dialog := (*C.GtkDialog)(unsafe.Pointer(widget))
+	cc := (*C.GtkColorChooser)(unsafe.Pointer(widget))
+	C.gtk_color_chooser_set_use_alpha(cc, C.FALSE)
+	rgba := toGdkRGBA(initial)
+	C.gtk_color_chooser_set_rgba(cc, &rgba)
+	C.gtk_window_set_modal((*C.GtkWindow)(unsafe.Pointer(widget)), C.TRUE)
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(dialog)),
+		"response",
+		C.GCallback(C.our_choosecolor_response_callback),
+		C.toDialogHandle(C.gsize(addDialogFunc(f))))
+	C.gtk_widget_show_all(widget)
+}
+
+//export our_choosecolor_response_callback
+func our_choosecolor_response_callback(dialog *C.GtkDialog, response C.gint, data C.gpointer) {
+	f := takeDialogFunc(uintptr(C.fromDialogHandle(data))).(func(color.Color))
+	var rgba C.GdkRGBA
+
+	C.gtk_color_chooser_get_rgba((*C.GtkColorChooser)(unsafe.Pointer(dialog)), &rgba)
+	C.gtk_widget_destroy((*C.GtkWidget)(unsafe.Pointer(dialog)))
+	if response != C.GTK_RESPONSE_OK {
+		f(nil)
+		return
+	}
+	f(fromGdkRGBA(&rgba))
+}
+
+func toGdkRGBA(c color.NRGBA) C.GdkRGBA {
+	return C.GdkRGBA{
+		red:   C.gdouble(c.R) / 255,
+		green: C.gdouble(c.G) / 255,
+		blue:  C.gdouble(c.B) / 255,
+		alpha: 1,
+	}
+}
+
+func fromGdkRGBA(rgba *C.GdkRGBA) color.NRGBA {
+	return color.NRGBA{
+		R: uint8(rgba.red*255 + 0.5),
+		G: uint8(rgba.green*255 + 0.5),
+		B: uint8(rgba.blue*255 + 0.5),
+		A: 0xFF,
+	}
+}
+
+func (w *window) chooseFont(initial FontDescriptor, f func(font *FontDescriptor)) {
+	widget := C.gtk_font_chooser_dialog_new(nil, w.window)
+	dialog := (*C.GtkDialog)(unsafe.Pointer(widget))
+	if initial.Family != "" {
+		desc := toPangoFontDescription(initial)
+		C.gtk_font_chooser_set_font_desc((*C.GtkFontChooser)(unsafe.Pointer(widget)), desc)
+		C.pango_font_description_free(desc)
+	}
+	C.gtk_window_set_modal((*C.GtkWindow)(unsafe.Pointer(widget)), C.TRUE)
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(dialog)),
+		"response",
+		C.GCallback(C.our_choosefont_response_callback),
+		C.toDialogHandle(C.gsize(addDialogFunc(f))))
+	C.gtk_widget_show_all(widget)
+}
+
+//export our_choosefont_response_callback
+func our_choosefont_response_callback(dialog *C.GtkDialog, response C.gint, data C.gpointer) {
+	f := takeDialogFunc(uintptr(C.fromDialogHandle(data))).(func(*FontDescriptor))
+	if response != C.GTK_RESPONSE_OK {
+		C.gtk_widget_destroy((*C.GtkWidget)(unsafe.Pointer(dialog)))
+		f(nil)
+		return
+	}
+	desc := C.gtk_font_chooser_get_font_desc((*C.GtkFontChooser)(unsafe.Pointer(dialog)))
+	C.gtk_widget_destroy((*C.GtkWidget)(unsafe.Pointer(dialog)))
+	if desc == nil {		// no font selected
+		f(nil)
+		return
+	}
+	font := fromPangoFontDescription(desc)
+	C.pango_font_description_free(desc)
+	f(&font)
+}
+
+// PangoWeight uses the same scale as FontWeight
+func toPangoFontDescription(font FontDescriptor) *C.PangoFontDescription {
+	desc := C.pango_font_description_new()
+	cfamily := togstr(font.Family)
+	defer freegstr(cfamily)
+	C.pango_font_description_set_family(desc, (*C.char)(unsafe.Pointer(cfamily)))
+	C.pango_font_description_set_size(desc, C.gint(font.Size*C.PANGO_SCALE+0.5))
+	C.pango_font_description_set_weight(desc, C.PangoWeight(font.weight()))
+	C.pango_font_description_set_style(desc, C.PANGO_STYLE_NORMAL)
+	if font.Italic {
+		C.pango_font_description_set_style(desc, C.PANGO_STYLE_ITALIC)
+	}
+	return desc
+}
+
+func fromPangoFontDescription(desc *C.PangoFontDescription) FontDescriptor {
+	font := FontDescriptor{
+		Family: C.GoString(C.pango_font_description_get_family(desc)),
+		Size:   float64(C.pango_font_description_get_size(desc)) / C.PANGO_SCALE,
+		Weight: FontWeight(C.pango_font_description_get_weight(desc)),
+		Italic: C.pango_font_description_get_style(desc) != C.PANGO_STYLE_NORMAL,
+	}
+	// GtkFontChooser only gives sizes in points, but just in case
+	if C.pango_font_description_get_size_is_absolute(desc) != C.FALSE {
+		font.Size = font.Size * 72 / 96
+	}
+	return font
+}
+
+func (w *window) showModal(dialog Window) {
+	d := dialog.(*window)
+	C.gtk_window_set_transient_for(d.window, w.window)
+	C.gtk_window_set_destroy_with_parent(d.window, C.TRUE)
+	// modality only applies within a window group; see newWindow()
+	C.gtk_window_group_add_window(w.group, d.window)
+	C.gtk_window_set_modal(d.window, C.TRUE)
+	d.Show()
+}
+
+func (w *window) endModal(dialog Window) {
+	// GTK+ drops the grab when the dialog is destroyed
 }
@@ -8,8 +8,10 @@
 
 struct openFileData {
 	HWND parent;
-	void *f;
+	uintptr_t f;
 	WCHAR *filenameBuffer;
+	BOOL save;
+	WCHAR *ext;		// default extension for save dialogs; NULL for none
 };
 
 static DWORD WINAPI doOpenFile(LPVOID data)
@@ -31,7 +33,19 @@ static DWORD WINAPI doOpenFile(LPVOID data)
 	// TODO OFN_SHAREAWARE?
 	// better question: TODO keep networking?
 	ofn.Flags = OFN_EXPLORER | OFN_FILEMUSTEXIST | OFN_FORCESHOWHIDDEN | OFN_HIDEREADONLY | OFN_LONGNAMES | OFN_NOCHANGEDIR | OFN_NODEREFERENCELINKS | OFN_NOTESTFILECREATE | OFN_PATHMUSTEXIST;
-	if (GetOpenFileNameW(&ofn) == FALSE) {
+	if (o->save) {
+		// lpstrDefExt is added before OFN_OVERWRITEPROMPT checks the filename, so the prompt sees the real filename
+		ofn.lpstrDefExt = o->ext;
+		ofn.Flags &= ~OFN_FILEMUSTEXIST;
+		ofn.Flags |= OFN_OVERWRITEPROMPT;
+		if (GetSaveFileNameW(&ofn) == FALSE) {
+			err = CommDlgExtendedError();
+			if (err != 0)				// user cancelled
+				xpaniccomdlg("error running save file dialog", err);
+			free(o->filenameBuffer);
+			o->filenameBuffer = NULL;
+		}
+	} else if (GetOpenFileNameW(&ofn) == FALSE) {
 		err = CommDlgExtendedError();
 		if (err != 0)				// user cancelled
 			xpaniccomdlg("error running open file dialog", err);
@@ -40,11 +54,13 @@ static DWORD WINAPI doOpenFile(LPVOID data)
 	}
 	if (PostMessageW(msgwin, msgOpenFileDone, (WPARAM) (o->filenameBuffer), (LPARAM) (o->f)) == 0)
 		xpanic("error posting OpenFile() finished message to message-only window", GetLastError());
-	free(o);		// won't free o->f or o->filenameBuffer in above invocation
+	if (o->ext != NULL)
+		free(o->ext);
+	free(o);		// won't free o->filenameBuffer in above invocation
 	return 0;
 }
 
-void openFile(HWND hwnd, void *f)
+static void runFileDialog(HWND hwnd, uintptr_t f, BOOL save, LPWSTR ext)
 {
 	struct openFileData *o;
 
@@ -54,6 +70,14 @@ void openFile(HWND hwnd, void *f)
 		xpanic("memory exhausted allocating data structure in OpenFile()", GetLastError());
 	o->parent = hwnd;
 	o->f = f;
+	o->save = save;
+	o->ext = NULL;
+	if (ext != NULL) {
+		// ext is owned by Go, so the thread needs its own copy
+		o->ext = _wcsdup(ext);
+		if (o->ext == NULL)
+			xpanic("memory exhausted copying default extension in SaveFile()", GetLastError());
+	}
 	// freed on the Go side
 	o->filenameBuffer = (WCHAR *) malloc((NFILENAME + 1) * sizeof (WCHAR));
 	if (o->filenameBuffer == NULL)
@@ -61,3 +85,155 @@ void openFile(HWND hwnd, void *f)
 	if (CreateThread(NULL, 0, doOpenFile, (LPVOID) o, 0, NULL) == NULL)
 		xpanic("error creating thread for running OpenFIle()", GetLastError());
 }
+
+void openFile(HWND hwnd, uintptr_t f)
+{
+	runFileDialog(hwnd, f, FALSE, NULL);
+}
+
+void saveFile(HWND hwnd, LPWSTR ext, uintptr_t f)
+{
+	runFileDialog(hwnd, f, TRUE, ext);
+}
+
+struct msgBoxData {
+	HWND parent;
+	WCHAR *title;
+	WCHAR *text;
+	UINT type;
+	uintptr_t f;
+};
+
+static DWORD WINAPI doMsgBox(LPVOID data)
+{
+	struct msgBoxData *m = (struct msgBoxData *) data;
+	int ret;
+
+	ret = MessageBoxW(m->parent, m->text, m->title, m->type | MB_APPLMODAL | MB_SETFOREGROUND);
+	if (ret == 0)
+		xpanic("error running message box", GetLastError());
+	if (PostMessageW(msgwin, msgMsgBoxDone, (WPARAM) ret, (LPARAM) (m->f)) == 0)
+		xpanic("error posting MsgBox() finished message to message-only window", GetLastError());
+	free(m->title);
+	free(m->text);
+	free(m);
+	return 0;
+}
+
+// like with openFile(), MessageBoxW() runs its own modal loop, so run it on another thread to keep ours going
+void msgBox(HWND hwnd, LPWSTR title, LPWSTR text, UINT type, uintptr_t f)
+{
+	struct msgBoxData *m;
+
+	// freed by the thread
+	m = (struct msgBoxData *) malloc(sizeof (struct msgBoxData));
+	if (m == NULL)
+		xpanic("memory exhausted allocating data structure in MsgBox()", GetLastError());
+	m->parent = hwnd;
+	m->type = type;
+	m->f = f;
+	// the strings passed in are owned by Go, so make copies the thread can keep
+	m->title = _wcsdup(title);
+	m->text = _wcsdup(text);
+	if (m->title == NULL || m->text == NULL)
+		xpanic("memory exhausted copying strings in MsgBox()", GetLastError());
+	if (CreateThread(NULL, 0, doMsgBox, (LPVOID) m, 0, NULL) == NULL)
+		xpanic("error creating thread for running MsgBox()", GetLastError());
+}
+
+struct chooseColorData {
+	HWND parent;
+	COLORREF color;
+	uintptr_t f;
+};
+
+// the custom colors the user sets up in the dialog are kept for the next one, as other programs do
+static COLORREF customColors[16];
+
+static DWORD WINAPI doChooseColor(LPVOID data)
+{
+	struct chooseColorData *c = (struct chooseColorData *) data;
+	CHOOSECOLORW cc;
+	DWORD err;
+	WPARAM result;
+
+	ZeroMemory(&cc, sizeof (CHOOSECOLORW));
+	cc.lStructSize = sizeof (CHOOSECOLORW);
+	cc.hwndOwner = c->parent;
+	cc.rgbResult = c->color;
+	cc.lpCustColors = customColors;
+	cc.Flags = CC_RGBINIT | CC_FULLOPEN;
+	// COLORREFs only use the low 24 bits, so the top byte says whether a color was chosen
+	result = (WPARAM) (cc.rgbResult & 0xFFFFFF);
+	if (ChooseColorW(&cc) == FALSE) {
+		err = CommDlgExtendedError();
+		if (err != 0)				// user cancelled
+			xpaniccomdlg("error running color dialog", err);
+	} else
+		result = ((WPARAM) TRUE << 24) | (WPARAM) (cc.rgbResult & 0xFFFFFF);
+	if (PostMessageW(msgwin, msgChooseColorDone, result, (LPARAM) (c->f)) == 0)
+		xpanic("error posting ChooseColor() finished message to message-only window", GetLastError());
+	free(c);
+	return 0;
+}
+
+// like the others, ChooseColorW() runs its own modal loop
+void chooseColor(HWND hwnd, COLORREF initial, uintptr_t f)
+{
+	struct chooseColorData *c;
+
+	// freed by the thread
+	c = (struct chooseColorData *) malloc(sizeof (struct chooseColorData));
+	if (c == NULL)
+		xpanic("memory exhausted allocating data structure in ChooseColor()", GetLastError());
+	c->parent = hwnd;
+	c->color = initial;
+	c->f = f;
+	if (CreateThread(NULL, 0, doChooseColor, (LPVOID) c, 0, NULL) == NULL)
+		xpanic("error creating thread for running ChooseColor()", GetLastError());
+}
+
+static DWORD WINAPI doChooseFont(LPVOID data)
+{
+	struct chooseFontData *c = (struct chooseFontData *) data;
+	CHOOSEFONTW cf;
+	DWORD err;
+	HDC dc;
+
+	// LOGFONTW heights are in pixels, negative for the character height rather than the cell height that point sizes go by
+	if (c->pointSize != 0) {
+		dc = GetDC(NULL);
+		if (dc == NULL)
+			xpanic("error getting screen DC for ChooseFont()", GetLastError());
+		c->font.lfHeight = -MulDiv(c->pointSize, GetDeviceCaps(dc, LOGPIXELSY), 720);
+		ReleaseDC(NULL, dc);
+	}
+	ZeroMemory(&cf, sizeof (CHOOSEFONTW));
+	cf.lStructSize = sizeof (CHOOSEFONTW);
+	cf.hwndOwner = c->parent;
+	cf.lpLogFont = &(c->font);
+	// no CF_EFFECTS; FontDescriptor has no underline, strikeout, or color
+	cf.Flags = CF_SCREENFONTS | CF_NOVERTFONTS;
+	if (c->font.lfFaceName[0] != L'\0')
+		cf.Flags |= CF_INITTOLOGFONTSTRUCT;
+	c->chosen = ChooseFontW(&cf);
+	if (c->chosen == FALSE) {
+		err = CommDlgExtendedError();
+		if (err != 0)				// user cancelled
+			xpaniccomdlg("error running font dialog", err);
+	}
+	c->pointSize = cf.iPointSize;
+	// c is freed on the Go side, after it has read the font out of it
+	if (PostMessageW(msgwin, msgChooseFontDone, 0, (LPARAM) c) == 0)
+		xpanic("error posting ChooseFont() finished message to message-only window", GetLastError());
+	return 0;
+}
+
+// like the others, ChooseFontW() runs its own modal loop
+// c is allocated by the caller with malloc(); see chooseFont() in dialog_windows.go
+void chooseFont(HWND hwnd, struct chooseFontData *c)
+{
+	c->parent = hwnd;
+	if (CreateThread(NULL, 0, doChooseFont, (LPVOID) c, 0, NULL) == NULL)
+		xpanic("error creating thread for running ChooseFont()", GetLastError());
+}
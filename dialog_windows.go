@@ -3,6 +3,8 @@
 package ui
 
 import (
+	"image/color"
+	"syscall"
 	"unsafe"
 )
 
@@ -10,16 +12,146 @@ import (
 import "C"
 
 func (w *window) openFile(f func(filename string)) {
-	C.openFile(w.hwnd, unsafe.Pointer(&f))
+	C.openFile(w.hwnd, C.uintptr_t(addDialogFunc(f)))
+}
+
+func (w *window) saveFile(ext string, f func(filename string)) {
+	cext := C.LPWSTR(nil)
+	if ext != "" {
+		cext = toUTF16(ext)
+	}
+	// the result comes back through finishOpenFile() as well
+	C.saveFile(w.hwnd, cext, C.uintptr_t(addDialogFunc(f)))
 }
 
 //export finishOpenFile
-func finishOpenFile(name *C.WCHAR, fp unsafe.Pointer) {
-	f := (*func(string))(fp)
+func finishOpenFile(name *C.WCHAR, h C.uintptr_t) {
+	f := takeDialogFunc(uintptr(h)).(func(string))
 	if name == nil {
-		(*f)("")
+		f("")
 		return
 	}
 	defer C.free(unsafe.Pointer(name))
-	(*f)(wstrToString(name))
+	f(wstrToString(name))
+}
+
+func (w *window) msgBox(primary string, secondary string, style msgBoxStyle, f func(result DialogResult)) {
+	text := primary
+	if secondary != "" {
+		text += "\n\n" + secondary
+	}
+	var flags C.UINT
+	switch style {
+	case msgBoxInfo:
+		flags = C.MB_OK | C.MB_ICONINFORMATION
+	case msgBoxError:
+		flags = C.MB_OK | C.MB_ICONERROR
+	case msgBoxYesNoCancel:
+		flags = C.MB_YESNOCANCEL | C.MB_ICONQUESTION
+	}
+	C.msgBox(w.hwnd, toUTF16(w.Title()), toUTF16(text), flags, C.uintptr_t(addDialogFunc(f)))
+}
+
+//export finishMsgBox
+func finishMsgBox(ret C.int, h C.uintptr_t) {
+	f := takeDialogFunc(uintptr(h)).(func(DialogResult))
+	switch ret {
+	case C.IDOK:
+		f(DialogOK)
+	case C.IDYES:
+		f(DialogYes)
+	case C.IDNO:
+		f(DialogNo)
+	default:		// IDCANCEL
+		f(DialogCancel)
+	}
+}
+
+// this is what DialogBox() does for modal dialogs
+func (w *window) showModal(dialog Window) {
+	d := dialog.(*window)
+	C.windowSetOwner(d.hwnd, w.hwnd)
+	C.EnableWindow(w.hwnd, C.FALSE)
+	d.Show()
+}
+
+func (w *window) endModal(dialog Window) {
+	C.EnableWindow(w.hwnd, C.TRUE)
+}
+
+func (w *window) chooseColor(initial color.NRGBA, f func(c color.Color)) {
+	C.chooseColor(w.hwnd, toCOLORREF(initial), C.uintptr_t(addDialogFunc(f)))
+}
+
+//export finishChooseColor
+func finishChooseColor(ok C.BOOL, c C.COLORREF, h C.uintptr_t) {
+	f := takeDialogFunc(uintptr(h)).(func(color.Color))
+	if ok == C.FALSE {
+		f(nil)
+		return
+	}
+	f(fromCOLORREF(c))
+}
+
+// COLORREFs are 0x00BBGGRR
+func toCOLORREF(c color.NRGBA) C.COLORREF {
+	return C.COLORREF(c.R) | C.COLORREF(c.G)<<8 | C.COLORREF(c.B)<<16
+}
+
+func fromCOLORREF(c C.COLORREF) color.NRGBA {
+	return color.NRGBA{
+		R: uint8(c),
+		G: uint8(c >> 8),
+		B: uint8(c >> 16),
+		A: 0xFF,
+	}
+}
+
+func (w *window) chooseFont(initial FontDescriptor, f func(font *FontDescriptor)) {
+	// freed by finishChooseFont()
+	c := (*C.struct_chooseFontData)(C.calloc(1, C.sizeof_struct_chooseFontData))
+	if c == nil {
+		panic("memory exhausted allocating data structure in ChooseFont()")
+	}
+	if initial.Family != "" {
+		toLOGFONTW(initial, &c.font)
+		c.pointSize = C.INT(initial.Size*10 + 0.5)
+	}
+	c.f = C.uintptr_t(addDialogFunc(f))
+	C.chooseFont(w.hwnd, c)
+}
+
+// this fills in everything but lfHeight, which depends on the DC; see doChooseFont() in dialog_windows.c
+// lf must be zeroed beforehand
+func toLOGFONTW(font FontDescriptor, lf *C.LOGFONTW) {
+	name := syscall.StringToUTF16(font.Family)
+	if len(name) > C.LF_FACESIZE {
+		name = append(name[:C.LF_FACESIZE-1], 0)
+	}
+	for i, r := range name {
+		lf.lfFaceName[i] = C.WCHAR(r)
+	}
+	// LOGFONTW weights use the same scale as FontWeight
+	lf.lfWeight = C.LONG(font.weight())
+	if font.Italic {
+		lf.lfItalic = C.TRUE
+	}
+	lf.lfCharSet = C.DEFAULT_CHARSET
+}
+
+//export finishChooseFont
+func finishChooseFont(c *C.struct_chooseFontData) {
+	defer C.free(unsafe.Pointer(c))
+	f := takeDialogFunc(uintptr(c.f)).(func(*FontDescriptor))
+	if c.chosen == C.FALSE {
+		f(nil)
+		return
+	}
+	name := (*[C.LF_FACESIZE]uint16)(unsafe.Pointer(&c.font.lfFaceName[0]))
+	f(&FontDescriptor{
+		Family: syscall.UTF16ToString(name[:]),
+		Size:   float64(c.pointSize) / 10,
+		Weight: FontWeight(c.font.lfWeight),
+		Italic: c.font.lfItalic != 0,
+	})
 }
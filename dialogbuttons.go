@@ -0,0 +1,37 @@
+// 15 october 2026
+
+package ui
+
+// NewDialogButtons returns a horizontal Stack holding the row of buttons at the bottom of a dialog box, in the order and place each system puts them in its own dialog boxes, so that custom dialog boxes look native everywhere.
+// affirmative is the button that carries out the dialog box (OK, Save, Delete); negative is the one that dismisses it without doing anything (Cancel).
+// Either may be nil if the dialog box doesn't have one.
+// others are any further buttons, such as Help or Don't Save.
+// On Windows, the buttons are at the right, in the order affirmative, negative, then others.
+// On GTK+ and Mac OS X, others are at the left and negative and affirmative are at the right, with affirmative last.
+// The buttons are padded, and the space between the two groups stretches to fill the width of the Stack.
+func NewDialogButtons(affirmative Button, negative Button, others ...Button) Stack {
+	var left, right []Control
+
+	add := func(list []Control, buttons ...Button) []Control {
+		for _, b := range buttons {
+			if b != nil {
+				list = append(list, b)
+			}
+		}
+		return list
+	}
+	switch currentPlatform {
+	case PlatformWindows:
+		right = add(right, affirmative, negative)
+		right = add(right, others...)
+	default:
+		left = add(left, others...)
+		right = add(right, negative, affirmative)
+	}
+	controls := append(left, Space())
+	controls = append(controls, right...)
+	s := NewHorizontalStack(controls...)
+	s.SetStretchy(len(left))
+	s.SetPadded(true)
+	return s
+}
@@ -8,5 +8,7 @@ notes:
 - default behavior of event handlers that return bool is to do nothing but return false
 - passing nil to an event handler set function restores default behavior
 - only functions safe for calling outside Do() are Go(), Do(), and Stop()
+- events from all controls and Windows are handled one at a time on the main goroutine, in the order the system delivers them; an event handler runs to completion before the next one starts (unless it opens a modal dialog, which runs the event loop itself)
+- the Timestamp fields of MouseEvent, KeyEvent, and DropEvent share one monotonic clock and never decrease, so they can be used to correlate events across controls
 */
 package ui
@@ -0,0 +1,209 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Document handles the plumbing common to Windows that edit a single file: remembering the filename, tracking whether there are unsaved changes, showing both in the Window's title, and asking the user what to do with unsaved changes when the Window is closed.
+// How the title reflects this is platform-dependent; on Mac OS X, for instance, the Window's proxy icon and close button are used instead of changing the title text.
+// Document methods must be called from the main thread, like the methods of the Window itself.
+type Document interface {
+	// Filename and SetFilename get and set the file the Document is associated with.
+	// An empty filename means the Document has not been saved yet.
+	Filename() string
+	SetFilename(filename string)
+
+	// Dirty and SetDirty get and set whether the Document has unsaved changes.
+	// Call SetDirty(true) whenever the user changes the document.
+	Dirty() bool
+	SetDirty(dirty bool)
+
+	// OnSave sets the function that writes the document to the named file.
+	// If it returns an error, the error is shown to the user and the Document remains dirty.
+	// See WriteFileAtomic for a safe way to write the file.
+	OnSave(f func(filename string) error)
+
+	// Save saves the Document to its current filename, asking the user for a filename with SaveFile if there is none.
+	// SaveAs always asks for a new filename.
+	// Some time after saving finishes, f is run on the main thread; saved is false if the user cancelled or OnSave failed.
+	// f may be nil.
+	Save(f func(saved bool))
+	SaveAs(f func(saved bool))
+
+	// Close closes the Window as if the user had clicked its close button.
+	// If the Document is dirty, the user is first asked whether to save their changes, discard them, or cancel closing.
+	Close()
+
+	// OnClosed registers an event handler that is run just before the Document closes its Window.
+	OnClosed(f func())
+}
+
+type windowDocument interface {
+	// name is the display name of the document; filename is the full path, or empty if the document has not been saved
+	setDocument(name string, appname string, filename string, edited bool)
+}
+
+type document struct {
+	w        Window
+	appname  string
+	ext      string
+	filename string
+	dirty    bool
+	asking   bool
+	save     func(filename string) error
+	closed   *event
+}
+
+// NewDocument creates a new Document for the given Window.
+// appname is the name of the application, which is included in the Window's title where that is customary; it may be empty.
+// ext is the default extension passed to SaveFile.
+// NewDocument takes over the Window's OnClosing handler; use the Document's OnClosed instead.
+// It panics if w is nil.
+func NewDocument(w Window, appname string, ext string) Document {
+	if w == nil {
+		panic("Window passed to NewDocument() cannot be nil")
+	}
+	d := &document{
+		w:       w,
+		appname: appname,
+		ext:     ext,
+		closed:  newEvent(),
+	}
+	w.OnClosing(d.closing)
+	d.update()
+	return d
+}
+
+func (d *document) name() string {
+	if d.filename == "" {
+		return "Untitled"
+	}
+	return filepath.Base(d.filename)
+}
+
+func (d *document) update() {
+	d.w.setDocument(d.name(), d.appname, d.filename, d.dirty)
+}
+
+// documentTitle builds a title in the form most window managers expect, for use by the implementations of setDocument() that do not have native support.
+func documentTitle(name string, appname string, edited bool) string {
+	if edited {
+		name = "*" + name
+	}
+	if appname == "" {
+		return name
+	}
+	return name + " - " + appname
+}
+
+func (d *document) Filename() string {
+	return d.filename
+}
+
+func (d *document) SetFilename(filename string) {
+	d.filename = filename
+	d.update()
+}
+
+func (d *document) Dirty() bool {
+	return d.dirty
+}
+
+func (d *document) SetDirty(dirty bool) {
+	if d.dirty == dirty {
+		return
+	}
+	d.dirty = dirty
+	d.update()
+}
+
+func (d *document) OnSave(f func(filename string) error) {
+	d.save = f
+}
+
+func (d *document) Save(f func(saved bool)) {
+	if d.filename == "" {
+		d.SaveAs(f)
+		return
+	}
+	d.saveTo(d.filename, f)
+}
+
+func (d *document) SaveAs(f func(saved bool)) {
+	SaveFile(d.w, d.ext, func(filename string) {
+		if filename == "" {
+			if f != nil {
+				f(false)
+			}
+			return
+		}
+		d.saveTo(filename, f)
+	})
+}
+
+func (d *document) saveTo(filename string, f func(saved bool)) {
+	if d.save == nil {
+		panic("Document saved without an OnSave handler")
+	}
+	err := d.save(filename)
+	if err != nil {
+		MsgBoxError(d.w, fmt.Sprintf("The document could not be saved as %q.", filepath.Base(filename)), err.Error(), func() {
+			if f != nil {
+				f(false)
+			}
+		})
+		return
+	}
+	d.filename = filename
+	d.dirty = false
+	d.update()
+	if f != nil {
+		f(true)
+	}
+}
+
+func (d *document) Close() {
+	if !d.dirty {
+		d.closed.fire()
+		d.w.Close()
+		return
+	}
+	if d.asking {		// don't stack up confirmations if the user clicks the close button repeatedly
+		return
+	}
+	d.asking = true
+	Confirm(d.w, fmt.Sprintf("Do you want to save the changes made to %q?", d.name()),
+		"Your changes will be lost if you don't save them.",
+		func(result DialogResult) {
+			d.asking = false
+			switch result {
+			case DialogYes:
+				d.Save(func(saved bool) {
+					if saved {
+						d.closed.fire()
+						d.w.Close()
+					}
+				})
+			case DialogNo:
+				d.closed.fire()
+				d.w.Close()
+			}
+		})
+}
+
+func (d *document) OnClosed(f func()) {
+	d.closed.set(f)
+}
+
+// the confirmation is asynchronous, so we always keep the Window open here and let Close() close it later if needed
+func (d *document) closing() bool {
+	if !d.dirty {
+		d.closed.fire()
+		return true
+	}
+	d.Close()
+	return false
+}
@@ -0,0 +1,198 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"image"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// DropFormats is a bit mask of the kinds of data a drag-and-drop operation carries.
+type DropFormats uint
+
+const (
+	DropFiles DropFormats = 1 << iota // one or more files from the file manager
+	DropText                          // plain text
+	DropImage                         // a bitmap image
+	DropLocal                         // a Go value from a drag started in this program; see DragData.Local
+)
+
+// DropEvent contains the information for a drag-and-drop event sent to a DropHandler.
+type DropEvent struct {
+	// Pos is the position of the mouse in the Area at the time of the event.
+	// As with MouseEvent, it takes the Area's scroll position into account.
+	Pos image.Point
+
+	// Formats contains the kinds of data the dragged object can provide.
+	// Data the program does not know how to interpret (for instance, a web browser's internal representation of a link) is not reported.
+	Formats DropFormats
+
+	// Files, Text, and Image contain the data being dropped.
+	// They are only filled in for DropHandler.Drop, and then only for the first format in Formats, in the order Files, Image, Text; the others are left empty.
+	// Files contains full paths; items that are not local files are omitted.
+	// Image is nil if the image could not be converted.
+	Files []string
+	Text  string
+	Image *image.RGBA
+
+	// If the drag was started by this program (from any of its Windows) and its DragData has a Local, Formats includes DropLocal and Local is that value, as is, in every event of the drag, not just in Drop.
+	// Otherwise, Local is nil.
+	Local interface{}
+
+	// Operation is what dropping the data would do, chosen by the system from what the source of the drag allows and the modifier keys the user is holding.
+	// On Windows and GTK+, Control copies, Shift moves, and both together link; on Mac OS X, Option copies and Command moves.
+	// With no modifier keys held, it is DragCopy if the source allows it, then DragMove, then DragLink.
+	// It is 0 if the source doesn't allow what the modifier keys ask for; the drop is refused then, whatever DragEnter or DragOver return.
+	// In Drop, it is what the drop does; for DragMove, the source removes the original once Drop returns true.
+	Operation DragOperation
+
+	// Timestamp is the time at which package ui received the event; see MouseEvent.Timestamp for details.
+	Timestamp time.Duration
+}
+
+// DropHandler can optionally be implemented by an AreaHandler to allow the Area to accept data dragged from other programs (or from elsewhere in the same program).
+// Package ui checks for this interface in NewArea, so nothing else needs to be registered.
+//
+// DragEnter is called when a drag enters the Area.
+// DragOver is called each time the mouse moves while the drag is over the Area.
+// Both return whether the Area would accept the data if it were dropped at the given position; the system shows feedback accordingly.
+// DragLeave is called when the drag leaves the Area, is cancelled, or is about to be dropped; in the last case, Drop follows immediately.
+// While the Area accepts the drag, the system shows the user the operation in DropEvent.Operation, and changes it as they press and release modifier keys.
+// Drop is called when the user drops the data on the Area; it only happens if the last call to DragEnter or DragOver returned true.
+// Drop returns whether the data was actually used.
+//
+// As with AreaHandler, these are all executed on the main goroutine.
+// Drag and drop onto controls other than Area, or onto a Window, is not supported yet.
+type DropHandler interface {
+	DragEnter(e DropEvent) (accept bool)
+	DragOver(e DropEvent) (accept bool)
+	DragLeave()
+	Drop(e DropEvent) (accepted bool)
+}
+
+// DragOperation is a bit mask of what a drop target does with dragged data.
+type DragOperation uint
+
+const (
+	DragCopy DragOperation = 1 << iota
+	DragMove
+	DragLink
+)
+
+// DragData is the data provided by a drag started with Area.StartDrag.
+// Any combination of the fields may be set; the drop target picks whichever it understands best.
+type DragData struct {
+	// Files contains full paths to local files.
+	// Image can be any image.Image; it is converted as needed when the drag starts.
+	Files []string
+	Text  string
+	Image image.Image
+
+	// DragImage, if not nil, is shown under the mouse pointer during the drag instead of what the system shows by default (usually a picture of the data, such as file icons).
+	// Hotspot is the point in DragImage, relative to the top-left corner of its bounds, that stays under the mouse pointer; the zero value puts the pointer at the top-left corner.
+	// Like Image, DragImage can be any image.Image; keep it small, as the system may shrink large ones or show them partly transparent.
+	DragImage image.Image
+	Hotspot   image.Point
+
+	// FilePromises are files that don't exist yet, which are only written if the user drops them somewhere that takes files, such as the desktop or a file manager window; see FilePromise.
+	// Drop targets that want files but can't take promises (including an Area) see only Files, so give Files too if the files already exist.
+	// On GTK+, promises are made through the XDS protocol, which only takes one file; only the first FilePromise is offered there, and only on X11.
+	// On Mac OS X, Area.StartDrag only offers FilePromises on 10.12 and newer.
+	FilePromises []FilePromise
+
+	// Local is any Go value, for drops within the program, such as from a palette Window onto a canvas Area in another Window; see DropEvent.Local.
+	// It is handed over as is, without being converted to anything, so a drag that only ever goes to the program's own Areas can leave out the other fields, and the work of making them.
+	// Other programs never see Local.
+	Local interface{}
+}
+
+func (d DragData) empty() bool {
+	return len(d.Files) == 0 && d.Text == "" && d.Image == nil && len(d.FilePromises) == 0 && d.Local == nil
+}
+
+// FilePromise is a file that a drag offers to create wherever it is dropped, for exporting data (for instance, an image from a drawing program) by dragging it out of the program.
+type FilePromise struct {
+	// Name is the name of the file to create, without a directory, such as "Drawing.png".
+	// The system or the drop target may change it, for instance if there is a file by that name already.
+	Name string
+
+	// Write is called on the main thread once the user drops the file; it writes the contents of the file to w.
+	// This can happen after the function given to Area.StartDrag or Table.OnDragRow is called, so Write must not depend on anything that function changes.
+	// If Write returns an error, the file is removed and the drop target is told that the file couldn't be made.
+	Write func(w io.Writer) error
+}
+
+// writeFile creates the file at path and has p write it, removing the file if that fails
+func (p FilePromise) writeFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = p.Write(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return err
+}
+
+// the FilePromises of the last drag, for the systems whose drop targets ask for them through something other than the drag source; there is only one mouse, so there is only one drag at a time
+// this isn't cleared when the drag ends, since some drop targets don't ask for the files until after that
+var dragPromises []FilePromise
+
+// the Local of the last drag, which is the one in progress whenever the per-platform code sees a drag from this program with DropLocal
+var dragLocal interface{}
+
+// dropLocal returns DropEvent.Local for formats
+func dropLocal(formats DropFormats) interface{} {
+	if (formats & DropLocal) != 0 {
+		return dragLocal
+	}
+	return nil
+}
+
+// what names the function the drag came from, for the panic messages
+func checkStartDrag(data DragData, allowed DragOperation, what string) {
+	if data.empty() {
+		panic("no data given to " + what)
+	}
+	for _, p := range data.FilePromises {
+		if p.Name == "" || p.Write == nil {
+			panic("FilePromise without a Name or a Write given to " + what)
+		}
+		if strings.ContainsAny(p.Name, `/\`) {
+			panic("FilePromise Name with a directory given to " + what)
+		}
+	}
+	if (allowed & (DragCopy | DragMove | DragLink)) == 0 {
+		panic("no operations allowed in " + what)
+	}
+}
+
+// dropHandler returns the Area's DropHandler, or nil if its AreaHandler does not implement one.
+func (a *areabase) dropHandler() DropHandler {
+	if d, ok := a.handler.(DropHandler); ok {
+		return d
+	}
+	return nil
+}
+
+// bestDropFormat returns the single format whose data is filled in for Drop.
+func bestDropFormat(f DropFormats) DropFormats {
+	switch {
+	case (f & DropFiles) != 0:
+		return DropFiles
+	case (f & DropImage) != 0:
+		return DropImage
+	case (f & DropText) != 0:
+		return DropText
+	case (f & DropLocal) != 0: // nothing to get, but the drop still has to go through
+		return DropLocal
+	}
+	return 0
+}
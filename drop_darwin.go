@@ -0,0 +1,243 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+	"unsafe"
+)
+
+// #include "objc_darwin.h"
+import "C"
+
+// the dropFormat* constants in objc_darwin.h have the same values as the DropFormats constants, so we can convert directly
+
+func (a *area) setDropTarget() {
+	if a.dropHandler() == nil {
+		return
+	}
+	C.areaSetDropTarget(a.id)
+}
+
+// op is the single NSDragOperation the drop would have; see dropOperation: in area_darwin.m
+
+//export areaView_dragEnter
+func areaView_dragEnter(data unsafe.Pointer, formats C.uintptr_t, p C.struct_xpoint, op C.uintptr_t) C.BOOL {
+	a := (*area)(data)
+	e := DropEvent{
+		Pos:       image.Pt(int(p.x), int(p.y)),
+		Formats:   DropFormats(formats),
+		Local:     dropLocal(DropFormats(formats)),
+		Operation: dragOperation(op),
+		Timestamp: eventTime(),
+	}
+	return toBOOL(a.dropHandler().DragEnter(e))
+}
+
+//export areaView_dragOver
+func areaView_dragOver(data unsafe.Pointer, formats C.uintptr_t, p C.struct_xpoint, op C.uintptr_t) C.BOOL {
+	a := (*area)(data)
+	e := DropEvent{
+		Pos:       image.Pt(int(p.x), int(p.y)),
+		Formats:   DropFormats(formats),
+		Local:     dropLocal(DropFormats(formats)),
+		Operation: dragOperation(op),
+		Timestamp: eventTime(),
+	}
+	return toBOOL(a.dropHandler().DragOver(e))
+}
+
+//export areaView_dragLeave
+func areaView_dragLeave(data unsafe.Pointer) {
+	a := (*area)(data)
+	a.dropHandler().DragLeave()
+}
+
+//export areaBestDropFormat
+func areaBestDropFormat(formats C.uintptr_t) C.uintptr_t {
+	return C.uintptr_t(bestDropFormat(DropFormats(formats)))
+}
+
+// performDragOperation: calls zero or more of these to fill in a.drop, then areaView_drop()
+
+//export areaView_dropAddFile
+func areaView_dropAddFile(data unsafe.Pointer, name *C.char) {
+	a := (*area)(data)
+	a.drop.Files = append(a.drop.Files, C.GoString(name))
+}
+
+//export areaView_dropSetText
+func areaView_dropSetText(data unsafe.Pointer, text *C.char) {
+	a := (*area)(data)
+	if text != nil {
+		a.drop.Text = C.GoString(text)
+	}
+}
+
+//export areaView_dropSetImage
+func areaView_dropSetImage(data unsafe.Pointer, pixels unsafe.Pointer, width C.intptr_t, height C.intptr_t, stride C.intptr_t) {
+	a := (*area)(data)
+	img := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	pix := C.GoBytes(pixels, C.int(stride*height))
+	for y := 0; y < int(height); y++ {
+		copy(img.Pix[y*img.Stride:(y+1)*img.Stride], pix[y*int(stride):])
+	}
+	a.drop.Image = img
+}
+
+//export areaView_drop
+func areaView_drop(data unsafe.Pointer, formats C.uintptr_t, p C.struct_xpoint, op C.uintptr_t) C.BOOL {
+	a := (*area)(data)
+	e := a.drop
+	a.drop = DropEvent{} // for next time
+	e.Pos = image.Pt(int(p.x), int(p.y))
+	e.Formats = DropFormats(formats)
+	e.Local = dropLocal(e.Formats)
+	e.Operation = dragOperation(op)
+	e.Timestamp = eventTime()
+	return toBOOL(a.dropHandler().Drop(e))
+}
+
+func (a *area) StartDrag(data DragData, allowed DragOperation, f func(op DragOperation)) {
+	checkStartDrag(data, allowed, "Area.StartDrag()")
+	if a.mouseevent == nil {
+		panic("Area.StartDrag() called outside of a mouse event with a button held")
+	}
+	s := newDragStrings(data)
+	defer s.free()
+	a.dragdone = f
+	dragPromises = data.FilePromises
+	dragLocal = data.Local
+	// this returns right away; areaView_dragEnded() is called when the drag is over
+	C.areaStartDrag(a.id, a.mouseevent, s.cfiles(), C.intptr_t(len(s.files)), s.text,
+		toDragImage(data.Image), toDragImage(data.DragImage), C.intptr_t(data.Hotspot.X), C.intptr_t(data.Hotspot.Y),
+		s.cpromises(), C.intptr_t(len(s.promises)), toBOOL(data.Local != nil),
+		nsDragOperations(allowed))
+}
+
+// for NSFilePromiseProvider, which says exactly where the file goes; this returns an error message for the caller to free, or nil
+//export dragWritePromise
+func dragWritePromise(i C.intptr_t, path *C.char) *C.char {
+	if err := dragPromises[i].writeFile(C.GoString(path)); err != nil {
+		return C.CString(err.Error())
+	}
+	return nil
+}
+
+// for NSFilesPromisePboardType, which only says what folder the file goes in; this returns the name the file was given, for the caller to free, or nil if it couldn't be written
+//export dragWritePromiseIn
+func dragWritePromiseIn(i C.intptr_t, dir *C.char) *C.char {
+	d := C.GoString(dir)
+	p := dragPromises[i]
+	name := p.Name
+	ext := filepath.Ext(name)
+	for n := 2; ; n++ {
+		if _, err := os.Lstat(filepath.Join(d, name)); os.IsNotExist(err) {
+			break
+		}
+		name = fmt.Sprintf("%s %d%s", strings.TrimSuffix(p.Name, ext), n, ext)
+	}
+	if err := p.writeFile(filepath.Join(d, name)); err != nil {
+		return nil
+	}
+	return C.CString(name)
+}
+
+//export areaView_dragEnded
+func areaView_dragEnded(data unsafe.Pointer, op C.uintptr_t) {
+	a := (*area)(data)
+	f := a.dragdone
+	a.dragdone = nil
+	if f != nil {
+		f(dragOperation(op))
+	}
+}
+
+// dragStrings holds the Files, Text, and FilePromise names of a DragData as C strings for areaStartDrag() and tableSetDragData()
+type dragStrings struct {
+	files    []*C.char
+	text     *C.char // nil for no text
+	promises []*C.char
+}
+
+func newDragStrings(data DragData) *dragStrings {
+	s := new(dragStrings)
+	s.files = make([]*C.char, len(data.Files))
+	for i, f := range data.Files {
+		s.files[i] = C.CString(f)
+	}
+	if data.Text != "" {
+		s.text = C.CString(data.Text)
+	}
+	s.promises = make([]*C.char, len(data.FilePromises))
+	for i, p := range data.FilePromises {
+		s.promises[i] = C.CString(p.Name)
+	}
+	return s
+}
+
+func (s *dragStrings) cfiles() **C.char {
+	if len(s.files) == 0 {
+		return nil
+	}
+	return &s.files[0]
+}
+
+func (s *dragStrings) cpromises() **C.char {
+	if len(s.promises) == 0 {
+		return nil
+	}
+	return &s.promises[0]
+}
+
+func (s *dragStrings) free() {
+	for _, f := range s.files {
+		C.free(unsafe.Pointer(f))
+	}
+	for _, p := range s.promises {
+		C.free(unsafe.Pointer(p))
+	}
+	if s.text != nil {
+		C.free(unsafe.Pointer(s.text))
+	}
+}
+
+// returns a +1 NSImage that areaStartDrag() or tableSetDragData() releases, or nil for no image
+func toDragImage(i image.Image) C.id {
+	img := toRGBA(i)
+	if img == nil || img.Rect.Empty() {
+		return nil
+	}
+	return C.toTableImage(unsafe.Pointer(pixelData(img)), C.intptr_t(img.Rect.Dx()), C.intptr_t(img.Rect.Dy()), C.intptr_t(img.Stride))
+}
+
+// Command asks for NSDragOperationGeneric, so a source that allows moves allows that too; see dropOperation: in area_darwin.m
+func nsDragOperations(ops DragOperation) (nsops C.uintptr_t) {
+	if (ops & DragCopy) != 0 {
+		nsops |= C.cNSDragOperationCopy
+	}
+	if (ops & DragMove) != 0 {
+		nsops |= C.cNSDragOperationMove | C.cNSDragOperationGeneric
+	}
+	if (ops & DragLink) != 0 {
+		nsops |= C.cNSDragOperationLink
+	}
+	return nsops
+}
+
+// a drag source can get back more than one operation; a move matters most, since the source has to remove the original
+func dragOperation(op C.uintptr_t) DragOperation {
+	switch {
+	case (op & (C.cNSDragOperationMove | C.cNSDragOperationGeneric)) != 0:
+		return DragMove
+	case (op & C.cNSDragOperationCopy) != 0:
+		return DragCopy
+	case (op & C.cNSDragOperationLink) != 0:
+		return DragLink
+	}
+	return 0
+}
@@ -0,0 +1,157 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+#include "gtk_unix.h"
+
+// DragData.Local is carried by a target only our own program can see; the data itself stays on the Go side
+#define localTarget "application/x-go-ui-local"
+
+void areaSetDropTarget(GtkWidget *widget)
+{
+	GtkTargetList *targets;
+
+	// we report status in drag-motion and request the data in drag-drop ourselves, so don't let GTK+ do either (hence no flags)
+	// the info values are the formats in the DropFormats sense; see dropFormats() below
+	gtk_drag_dest_set(widget, 0, NULL, 0, GDK_ACTION_COPY | GDK_ACTION_MOVE | GDK_ACTION_LINK);
+	targets = gtk_target_list_new(NULL, 0);
+	gtk_target_list_add_uri_targets(targets, dropFormatFiles);
+	gtk_target_list_add_image_targets(targets, dropFormatImage, FALSE);
+	gtk_target_list_add_text_targets(targets, dropFormatText);
+	gtk_target_list_add(targets, gdk_atom_intern_static_string(localTarget), GTK_TARGET_SAME_APP, dropFormatLocal);
+	gtk_drag_dest_set_target_list(widget, targets);
+	gtk_target_list_unref(targets);
+}
+
+guint dropFormats(GtkWidget *widget, GdkDragContext *context)
+{
+	GtkTargetList *targets;
+	GList *l;
+	guint info;
+	guint formats = 0;
+
+	targets = gtk_drag_dest_get_target_list(widget);
+	for (l = gdk_drag_context_list_targets(context); l != NULL; l = l->next)
+		if (gtk_target_list_find(targets, GDK_POINTER_TO_ATOM(l->data), &info))
+			formats |= info;
+	// GTK_TARGET_SAME_APP only stops GTK+ from giving us the data; make sure nobody else is using our target name
+	if (gtk_drag_get_source_widget(context) == NULL)
+		formats &= ~dropFormatLocal;
+	return formats;
+}
+
+GdkAtom dropTarget(GtkWidget *widget, GdkDragContext *context, guint format)
+{
+	GtkTargetList *targets;
+	GList *l;
+	guint info;
+
+	targets = gtk_drag_dest_get_target_list(widget);
+	for (l = gdk_drag_context_list_targets(context); l != NULL; l = l->next)
+		if (gtk_target_list_find(targets, GDK_POINTER_TO_ATOM(l->data), &info))
+			if (info == format)
+				return GDK_POINTER_TO_ATOM(l->data);
+	return GDK_NONE;
+}
+
+void dragSetFilenames(GtkSelectionData *data, gchar **names, guint n)
+{
+	gchar **uris;
+	guint i, j;
+
+	uris = g_new0(gchar *, n + 1);
+	j = 0;
+	for (i = 0; i < n; i++) {
+		// this returns NULL for relative paths; skip those
+		uris[j] = g_filename_to_uri(names[i], NULL, NULL);
+		if (uris[j] != NULL)
+			j++;
+	}
+	gtk_selection_data_set_uris(data, uris);
+	g_strfreev(uris);
+}
+
+void dragAddLocalTarget(GtkTargetList *targets)
+{
+	gtk_target_list_add(targets, gdk_atom_intern_static_string(localTarget), GTK_TARGET_SAME_APP, dropFormatLocal);
+}
+
+// the drop still has to ask for the data to finish, so give it something
+void dragSetLocal(GtkSelectionData *data)
+{
+	gtk_selection_data_set(data, gtk_selection_data_get_target(data), 8, (const guchar *) "", 1);
+}
+
+// FilePromises use XDS (see https://www.freedesktop.org/wiki/Specifications/XDS/): we put the name of the file in a property on our window, the drop target replaces it with the URI of where it wants the file, and then it asks us for the XdndDirectSave0 target, which says whether we made the file
+// the protocol only has room for one file
+#define directSave "XdndDirectSave0"
+
+void dragAddDirectSaveTarget(GtkTargetList *targets)
+{
+	gtk_target_list_add(targets, gdk_atom_intern_static_string(directSave), 0, dragFormatPromise);
+}
+
+void dragSetDirectSave(GdkDragContext *context, gchar *name)
+{
+	gdk_property_change(gdk_drag_context_get_source_window(context),
+		gdk_atom_intern_static_string(directSave),
+		gdk_atom_intern_static_string("text/plain"),
+		8, GDK_PROP_MODE_REPLACE,
+		(const guchar *) name, (gint) strlen(name));
+}
+
+// returns NULL if the drop target didn't say where to put the file or if it isn't a local file; free the result with g_free()
+gchar *dragDirectSavePath(GdkDragContext *context)
+{
+	guchar *data;
+	gint len;
+	gchar *uri, *name;
+
+	if (!gdk_property_get(gdk_drag_context_get_source_window(context),
+		gdk_atom_intern_static_string(directSave),
+		gdk_atom_intern_static_string("text/plain"),
+		0, 4096, FALSE,
+		NULL, NULL, &len, &data))
+		return NULL;
+	// the property isn't null-terminated
+	uri = g_strndup((gchar *) data, (gsize) len);
+	g_free(data);
+	// the URI may name a host, but we can only write to this one
+	name = g_filename_from_uri(uri, NULL, NULL);
+	g_free(uri);
+	return name;
+}
+
+// result is 'S' for success, 'E' for an error, or 'F' to have the drop target fall back to another target
+void dragSetDirectSaveResult(GtkSelectionData *data, gchar result)
+{
+	gtk_selection_data_set(data, gtk_selection_data_get_target(data), 8, (const guchar *) (&result), 1);
+}
+
+void dragEndDirectSave(GdkDragContext *context)
+{
+	gdk_property_delete(gdk_drag_context_get_source_window(context), gdk_atom_intern_static_string(directSave));
+}
+
+// the returned vector is NULL-terminated; free it with g_strfreev()
+gchar **dropFilenames(GtkSelectionData *data)
+{
+	gchar **uris;
+	GPtrArray *names;
+	gchar *name;
+	guint i;
+
+	names = g_ptr_array_new();
+	uris = gtk_selection_data_get_uris(data);
+	if (uris != NULL) {
+		for (i = 0; uris[i] != NULL; i++) {
+			// this returns NULL for URIs that aren't local files; skip those
+			name = g_filename_from_uri(uris[i], NULL, NULL);
+			if (name != NULL)
+				g_ptr_array_add(names, name);
+		}
+		g_strfreev(uris);
+	}
+	g_ptr_array_add(names, NULL);
+	return (gchar **) g_ptr_array_free(names, FALSE);
+}
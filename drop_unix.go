@@ -0,0 +1,338 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+package ui
+
+import (
+	"image"
+	"unsafe"
+)
+
+// #include "gtk_unix.h"
+// extern gboolean our_area_drag_motion_callback(GtkWidget *, GdkDragContext *, gint, gint, guint, gpointer);
+// extern void our_area_drag_leave_callback(GtkWidget *, GdkDragContext *, guint, gpointer);
+// extern gboolean our_area_drag_drop_callback(GtkWidget *, GdkDragContext *, gint, gint, guint, gpointer);
+// extern void our_area_drag_data_received_callback(GtkWidget *, GdkDragContext *, gint, gint, GtkSelectionData *, guint, guint, gpointer);
+// extern void our_drag_data_get_callback(GtkWidget *, GdkDragContext *, GtkSelectionData *, guint, guint, gpointer);
+// extern gboolean our_drag_failed_callback(GtkWidget *, GdkDragContext *, GtkDragResult, gpointer);
+// extern void our_drag_end_callback(GtkWidget *, GdkDragContext *, gpointer);
+import "C"
+
+// the dropFormat* constants in gtk_unix.h have the same values as the DropFormats constants, so we can convert directly
+
+func (a *area) setDropTarget() {
+	if a.dropHandler() == nil {
+		return
+	}
+	C.areaSetDropTarget(a.widget)
+	for _, c := range areaDropCallbacks {
+		g_signal_connect(
+			C.gpointer(unsafe.Pointer(a.drawingarea)),
+			c.name,
+			c.callback,
+			C.gpointer(unsafe.Pointer(a)))
+	}
+}
+
+var areaDropCallbacks = []struct {
+	name     string
+	callback C.GCallback
+}{
+	{"drag-motion", area_drag_motion_callback},
+	{"drag-leave", area_drag_leave_callback},
+	{"drag-drop", area_drag_drop_callback},
+	{"drag-data-received", area_drag_data_received_callback},
+}
+
+// GTK+ has no equivalent of drag-enter; the first drag-motion after a drag-leave (or ever) is the enter
+
+//export our_area_drag_motion_callback
+func our_area_drag_motion_callback(widget *C.GtkWidget, context *C.GdkDragContext, x C.gint, y C.gint, time C.guint, data C.gpointer) C.gboolean {
+	var accept bool
+
+	a := (*area)(unsafe.Pointer(data))
+	formats := DropFormats(C.dropFormats(widget, context))
+	e := DropEvent{
+		Pos:       image.Pt(int(x), int(y)),
+		Formats:   formats,
+		Local:     dropLocal(formats),
+		Operation: dropOperation(context),
+		Timestamp: eventTime(),
+	}
+	if !a.dragging {
+		a.dragging = true
+		accept = a.dropHandler().DragEnter(e)
+	} else {
+		accept = a.dropHandler().DragOver(e)
+	}
+	// we always say we're a drop site (by returning TRUE) so that we continue to get drag-leave; refuse the drop with the status instead
+	if accept {
+		C.gdk_drag_status(context, gdkDragActions(e.Operation), time)
+	} else {
+		C.gdk_drag_status(context, 0, time)
+	}
+	return C.TRUE
+}
+
+// GTK+ drag sources already pick the suggested action from the modifier keys the way DropEvent.Operation says, narrowing the allowed actions to match
+// some other sources suggest GDK_ACTION_ASK or nothing at all, so fall back to our own order
+func dropOperation(context *C.GdkDragContext) DragOperation {
+	if op := dragOperation(C.gdk_drag_context_get_suggested_action(context)); op != 0 {
+		return op
+	}
+	actions := C.gdk_drag_context_get_actions(context)
+	switch {
+	case (actions & C.GDK_ACTION_COPY) != 0:
+		return DragCopy
+	case (actions & C.GDK_ACTION_MOVE) != 0:
+		return DragMove
+	case (actions & C.GDK_ACTION_LINK) != 0:
+		return DragLink
+	}
+	return 0
+}
+
+func dragOperation(action C.GdkDragAction) DragOperation {
+	switch action {
+	case C.GDK_ACTION_COPY:
+		return DragCopy
+	case C.GDK_ACTION_MOVE:
+		return DragMove
+	case C.GDK_ACTION_LINK:
+		return DragLink
+	}
+	return 0
+}
+
+func gdkDragActions(ops DragOperation) (actions C.GdkDragAction) {
+	if (ops & DragCopy) != 0 {
+		actions |= C.GDK_ACTION_COPY
+	}
+	if (ops & DragMove) != 0 {
+		actions |= C.GDK_ACTION_MOVE
+	}
+	if (ops & DragLink) != 0 {
+		actions |= C.GDK_ACTION_LINK
+	}
+	return actions
+}
+
+var area_drag_motion_callback = C.GCallback(C.our_area_drag_motion_callback)
+
+// this is also sent just before drag-drop, which is why DropHandler documents that DragLeave comes before Drop
+
+//export our_area_drag_leave_callback
+func our_area_drag_leave_callback(widget *C.GtkWidget, context *C.GdkDragContext, time C.guint, data C.gpointer) {
+	a := (*area)(unsafe.Pointer(data))
+	if a.dragging {
+		a.dragging = false
+		a.dropHandler().DragLeave()
+	}
+}
+
+var area_drag_leave_callback = C.GCallback(C.our_area_drag_leave_callback)
+
+//export our_area_drag_drop_callback
+func our_area_drag_drop_callback(widget *C.GtkWidget, context *C.GdkDragContext, x C.gint, y C.gint, time C.guint, data C.gpointer) C.gboolean {
+	a := (*area)(unsafe.Pointer(data))
+	a.droppos = image.Pt(int(x), int(y))
+	a.dropformats = DropFormats(C.dropFormats(widget, context))
+	target := C.dropTarget(widget, context, C.guint(bestDropFormat(a.dropformats)))
+	if target == nil { // GDK_NONE
+		C.gtk_drag_finish(context, C.FALSE, C.FALSE, time)
+		return C.TRUE
+	}
+	// the rest happens in drag-data-received
+	C.gtk_drag_get_data(widget, context, target, time)
+	return C.TRUE
+}
+
+var area_drag_drop_callback = C.GCallback(C.our_area_drag_drop_callback)
+
+//export our_area_drag_data_received_callback
+func our_area_drag_data_received_callback(widget *C.GtkWidget, context *C.GdkDragContext, x C.gint, y C.gint, sel *C.GtkSelectionData, info C.guint, time C.guint, data C.gpointer) {
+	a := (*area)(unsafe.Pointer(data))
+	// this is the action we gave gdk_drag_status() last
+	e := DropEvent{
+		Pos:       a.droppos,
+		Formats:   a.dropformats,
+		Local:     dropLocal(a.dropformats),
+		Operation: dragOperation(C.gdk_drag_context_get_selected_action(context)),
+		Timestamp: eventTime(),
+	}
+	switch DropFormats(info) {
+	case DropFiles:
+		names := C.dropFilenames(sel)
+		for p := names; *p != nil; p = (**C.gchar)(unsafe.Pointer(uintptr(unsafe.Pointer(p)) + unsafe.Sizeof(*p))) {
+			e.Files = append(e.Files, fromgstr(*p))
+		}
+		C.g_strfreev(names)
+	case DropText:
+		text := C.gtk_selection_data_get_text(sel)
+		if text != nil {
+			e.Text = fromgstr((*C.gchar)(unsafe.Pointer(text)))
+			C.g_free(C.gpointer(unsafe.Pointer(text)))
+		}
+	case DropImage:
+		pixbuf := C.gtk_selection_data_get_pixbuf(sel)
+		if pixbuf != nil {
+			e.Image = fromGdkPixbuf(pixbuf)
+			C.g_object_unref(C.gpointer(unsafe.Pointer(pixbuf)))
+		}
+	}
+	accepted := a.dropHandler().Drop(e)
+	// for a move, this has the source delete the original
+	del := accepted && e.Operation == DragMove
+	C.gtk_drag_finish(context, togbool(accepted), togbool(del), time)
+}
+
+var area_drag_data_received_callback = C.GCallback(C.our_area_drag_data_received_callback)
+
+// the source side doesn't need anything set up beforehand (we don't use gtk_drag_source_set() because the drag starts when the program says so), so these are always connected
+
+// dragSource is the source side of drags from one widget, shared by Area.StartDrag() and Table.OnDragRow()
+type dragSource struct {
+	data   DragData
+	done   func(op DragOperation)
+	failed bool
+}
+
+func newDragSource(widget *C.GtkWidget) *dragSource {
+	s := new(dragSource)
+	for _, c := range dragSourceCallbacks {
+		g_signal_connect(
+			C.gpointer(unsafe.Pointer(widget)),
+			c.name,
+			c.callback,
+			C.gpointer(unsafe.Pointer(s)))
+	}
+	return s
+}
+
+var dragSourceCallbacks = []struct {
+	name     string
+	callback C.GCallback
+}{
+	{"drag-data-get", drag_data_get_callback},
+	{"drag-failed", drag_failed_callback},
+	{"drag-end", drag_end_callback},
+}
+
+// button and event are the mouse button that is held and the event that started the drag
+// if data has no DragImage, GTK+ shows a default icon; the caller can set another on the returned context, which is nil if the drag couldn't start
+func (s *dragSource) begin(widget *C.GtkWidget, data DragData, allowed DragOperation, done func(op DragOperation), button uint, event *C.GdkEvent) *C.GdkDragContext {
+	s.data = data
+	s.done = done
+	s.failed = false
+	targets := C.gtk_target_list_new(nil, 0)
+	if len(data.Files) != 0 {
+		C.gtk_target_list_add_uri_targets(targets, C.dropFormatFiles)
+	}
+	if data.Image != nil {
+		C.gtk_target_list_add_image_targets(targets, C.dropFormatImage, C.TRUE) // TRUE: only formats GdkPixbuf can write
+	}
+	if data.Text != "" {
+		C.gtk_target_list_add_text_targets(targets, C.dropFormatText)
+	}
+	if len(data.FilePromises) != 0 {
+		C.dragAddDirectSaveTarget(targets)
+	}
+	if data.Local != nil {
+		C.dragAddLocalTarget(targets)
+	}
+	dragLocal = data.Local
+	context := C.gtk_drag_begin(widget, targets, gdkDragActions(allowed), C.gint(button), event)
+	C.gtk_target_list_unref(targets)
+	if context != nil && len(data.FilePromises) != 0 {
+		cname := togstr(data.FilePromises[0].Name)
+		C.dragSetDirectSave(context, cname)
+		freegstr(cname)
+	}
+	if context != nil && data.DragImage != nil {
+		// drag-begin has already run, so this replaces whatever icon it set
+		pixbuf := toGdkPixbuf(toRGBA(data.DragImage))
+		C.gtk_drag_set_icon_pixbuf(context, pixbuf, C.gint(data.Hotspot.X), C.gint(data.Hotspot.Y))
+		C.g_object_unref(C.gpointer(unsafe.Pointer(pixbuf)))
+	}
+	return context
+}
+
+func (a *area) StartDrag(data DragData, allowed DragOperation, f func(op DragOperation)) {
+	checkStartDrag(data, allowed, "Area.StartDrag()")
+	if a.mouseevent == nil || a.mousebutton == 0 {
+		panic("Area.StartDrag() called outside of a mouse event with a button held")
+	}
+	a.drag.begin(a.widget, data, allowed, f, a.mousebutton, a.mouseevent)
+}
+
+//export our_drag_data_get_callback
+func our_drag_data_get_callback(widget *C.GtkWidget, context *C.GdkDragContext, sel *C.GtkSelectionData, info C.guint, time C.guint, data C.gpointer) {
+	s := (*dragSource)(unsafe.Pointer(data))
+	switch DropFormats(info) {
+	case DropFiles:
+		names := make([]*C.gchar, len(s.data.Files))
+		for i, f := range s.data.Files {
+			names[i] = togstr(f)
+		}
+		C.dragSetFilenames(sel, &names[0], C.guint(len(names)))
+		for _, n := range names {
+			freegstr(n)
+		}
+	case DropText:
+		ctext := togstr(s.data.Text)
+		defer freegstr(ctext)
+		C.gtk_selection_data_set_text(sel, ctext, -1)
+	case DropImage:
+		pixbuf := toGdkPixbuf(toRGBA(s.data.Image))
+		C.gtk_selection_data_set_pixbuf(sel, pixbuf)
+		C.g_object_unref(C.gpointer(unsafe.Pointer(pixbuf)))
+	case DropLocal:
+		C.dragSetLocal(sel)
+	case C.dragFormatPromise:
+		path := C.dragDirectSavePath(context)
+		if path == nil {
+			C.dragSetDirectSaveResult(sel, 'F')
+			return
+		}
+		err := s.data.FilePromises[0].writeFile(fromgstr(path))
+		C.g_free(C.gpointer(unsafe.Pointer(path)))
+		if err != nil {
+			C.dragSetDirectSaveResult(sel, 'E')
+			return
+		}
+		C.dragSetDirectSaveResult(sel, 'S')
+	}
+}
+
+var drag_data_get_callback = C.GCallback(C.our_drag_data_get_callback)
+
+//export our_drag_failed_callback
+func our_drag_failed_callback(widget *C.GtkWidget, context *C.GdkDragContext, result C.GtkDragResult, data C.gpointer) C.gboolean {
+	s := (*dragSource)(unsafe.Pointer(data))
+	s.failed = true
+	return C.FALSE // let GTK+ animate the failure
+}
+
+var drag_failed_callback = C.GCallback(C.our_drag_failed_callback)
+
+//export our_drag_end_callback
+func our_drag_end_callback(widget *C.GtkWidget, context *C.GdkDragContext, data C.gpointer) {
+	var op DragOperation
+
+	s := (*dragSource)(unsafe.Pointer(data))
+	if !s.failed {
+		op = dragOperation(C.gdk_drag_context_get_selected_action(context))
+	}
+	if len(s.data.FilePromises) != 0 {
+		C.dragEndDirectSave(context)
+	}
+	f := s.done
+	s.data = DragData{}
+	s.done = nil
+	if f != nil {
+		f(op)
+	}
+}
+
+var drag_end_callback = C.GCallback(C.our_drag_end_callback)
@@ -0,0 +1,754 @@
+// 14 october 2026
+
+#include "winapi_windows.h"
+#include "_cgo_export.h"
+
+// this is an IDropTarget for an Area; see drop_windows.go
+// RegisterDragDrop() holds a reference for as long as the Area is registered and RevokeDragDrop() releases it
+struct areaDropTarget {
+	IDropTarget dt;		// must be first
+	LONG refcount;
+	HWND hwnd;
+	void *data;
+	DWORD formats;		// as of DragEnter(), for Drop()
+	IDropTargetHelper *helper;		// shows the drag image of the source, if any, over the Area; NULL if the shell can't
+};
+
+static HRESULT STDMETHODCALLTYPE adtQueryInterface(IDropTarget *this, REFIID riid, void **ppvObject)
+{
+	if (ppvObject == NULL)
+		return E_POINTER;
+	if (IsEqualIID(riid, &IID_IUnknown) || IsEqualIID(riid, &IID_IDropTarget)) {
+		IDropTarget_AddRef(this);
+		*ppvObject = (void *) this;
+		return S_OK;
+	}
+	*ppvObject = NULL;
+	return E_NOINTERFACE;
+}
+
+static ULONG STDMETHODCALLTYPE adtAddRef(IDropTarget *this)
+{
+	struct areaDropTarget *t = (struct areaDropTarget *) this;
+
+	return (ULONG) InterlockedIncrement(&(t->refcount));
+}
+
+static ULONG STDMETHODCALLTYPE adtRelease(IDropTarget *this)
+{
+	struct areaDropTarget *t = (struct areaDropTarget *) this;
+	LONG n;
+
+	n = InterlockedDecrement(&(t->refcount));
+	if (n == 0) {
+		if (t->helper != NULL)
+			IDropTargetHelper_Release(t->helper);
+		free(t);
+	}
+	return (ULONG) n;
+}
+
+static BOOL hasFormat(IDataObject *obj, CLIPFORMAT cf)
+{
+	FORMATETC fe;
+
+	ZeroMemory(&fe, sizeof (FORMATETC));
+	fe.cfFormat = cf;
+	fe.ptd = NULL;
+	fe.dwAspect = DVASPECT_CONTENT;
+	fe.lindex = -1;
+	fe.tymed = TYMED_HGLOBAL;
+	return IDataObject_QueryGetData(obj, &fe) == S_OK;
+}
+
+static BOOL isLocalDragData(IDataObject *);
+
+static DWORD getFormats(IDataObject *obj)
+{
+	DWORD formats = 0;
+
+	if (hasFormat(obj, CF_HDROP))
+		formats |= dropFormatFiles;
+	if (hasFormat(obj, CF_UNICODETEXT))
+		formats |= dropFormatText;
+	if (hasFormat(obj, CF_DIB))
+		formats |= dropFormatImage;
+	if (isLocalDragData(obj))
+		formats |= dropFormatLocal;
+	return formats;
+}
+
+// pt is in screen coordinates; make it relative to the Area, as in areaMouseEvent()
+static void toAreaPoint(struct areaDropTarget *t, POINTL pt, int *x, int *y)
+{
+	POINT p;
+	int xpos, ypos;
+
+	p.x = pt.x;
+	p.y = pt.y;
+	if (ScreenToClient(t->hwnd, &p) == 0)
+		xpanic("error converting drag and drop position to Area coordinates", GetLastError());
+	SendMessageW(t->hwnd, msgAreaGetScroll, (WPARAM) (&xpos), (LPARAM) (&ypos));
+	*x = xpos + p.x;
+	*y = ypos + p.y;
+}
+
+// the effect a drop would have given the modifier keys held and the effects the source allows; see DropEvent.Operation
+static DWORD dropEffect(DWORD keys, DWORD allowed)
+{
+	if ((keys & (MK_CONTROL | MK_SHIFT)) == (MK_CONTROL | MK_SHIFT))
+		return allowed & DROPEFFECT_LINK;
+	if ((keys & MK_CONTROL) != 0)
+		return allowed & DROPEFFECT_COPY;
+	if ((keys & MK_SHIFT) != 0)
+		return allowed & DROPEFFECT_MOVE;
+	if ((allowed & DROPEFFECT_COPY) != 0)
+		return DROPEFFECT_COPY;
+	if ((allowed & DROPEFFECT_MOVE) != 0)
+		return DROPEFFECT_MOVE;
+	return allowed & DROPEFFECT_LINK;
+}
+
+static HRESULT STDMETHODCALLTYPE adtDragEnter(IDropTarget *this, IDataObject *obj, DWORD grfKeyState, POINTL pt, DWORD *pdwEffect)
+{
+	struct areaDropTarget *t = (struct areaDropTarget *) this;
+	POINT p;
+	int x, y;
+	DWORD effect;
+
+	t->formats = getFormats(obj);
+	toAreaPoint(t, pt, &x, &y);
+	effect = dropEffect(grfKeyState, *pdwEffect);
+	if (!areaDragEnter(t->data, t->formats, x, y, effect))
+		effect = DROPEFFECT_NONE;
+	*pdwEffect = effect;
+	if (t->helper != NULL) {
+		p.x = pt.x;
+		p.y = pt.y;
+		IDropTargetHelper_DragEnter(t->helper, t->hwnd, obj, &p, effect);
+	}
+	return S_OK;
+}
+
+static HRESULT STDMETHODCALLTYPE adtDragOver(IDropTarget *this, DWORD grfKeyState, POINTL pt, DWORD *pdwEffect)
+{
+	struct areaDropTarget *t = (struct areaDropTarget *) this;
+	POINT p;
+	int x, y;
+	DWORD effect;
+
+	toAreaPoint(t, pt, &x, &y);
+	effect = dropEffect(grfKeyState, *pdwEffect);
+	if (!areaDragOver(t->data, t->formats, x, y, effect))
+		effect = DROPEFFECT_NONE;
+	*pdwEffect = effect;
+	if (t->helper != NULL) {
+		p.x = pt.x;
+		p.y = pt.y;
+		IDropTargetHelper_DragOver(t->helper, &p, effect);
+	}
+	return S_OK;
+}
+
+static HRESULT STDMETHODCALLTYPE adtDragLeave(IDropTarget *this)
+{
+	struct areaDropTarget *t = (struct areaDropTarget *) this;
+
+	if (t->helper != NULL)
+		IDropTargetHelper_DragLeave(t->helper);
+	areaDragLeave(t->data);
+	return S_OK;
+}
+
+static HRESULT STDMETHODCALLTYPE adtDrop(IDropTarget *this, IDataObject *obj, DWORD grfKeyState, POINTL pt, DWORD *pdwEffect)
+{
+	struct areaDropTarget *t = (struct areaDropTarget *) this;
+	FORMATETC fe;
+	STGMEDIUM sm;
+	void *p;
+	POINT hp;
+	int x, y;
+	DWORD format;
+	DWORD effect;
+	BOOL accepted;
+
+	effect = dropEffect(grfKeyState, *pdwEffect);
+	if (t->helper != NULL) {
+		hp.x = pt.x;
+		hp.y = pt.y;
+		IDropTargetHelper_Drop(t->helper, obj, &hp, effect);
+	}
+	// OLE doesn't send DragLeave() for a drop; we promise one, though
+	areaDragLeave(t->data);
+	toAreaPoint(t, pt, &x, &y);
+	format = areaBestDropFormat(t->formats);
+	if (format == dropFormatLocal) {
+		// the Go side already has the data; see DragData.Local
+		if (!areaDrop(t->data, t->formats, x, y, effect, format, NULL, 0))
+			effect = DROPEFFECT_NONE;
+		*pdwEffect = effect;
+		return S_OK;
+	}
+	ZeroMemory(&fe, sizeof (FORMATETC));
+	switch (format) {
+	case dropFormatFiles:
+		fe.cfFormat = CF_HDROP;
+		break;
+	case dropFormatText:
+		fe.cfFormat = CF_UNICODETEXT;
+		break;
+	case dropFormatImage:
+		fe.cfFormat = CF_DIB;
+		break;
+	default:
+		*pdwEffect = DROPEFFECT_NONE;
+		return S_OK;
+	}
+	fe.ptd = NULL;
+	fe.dwAspect = DVASPECT_CONTENT;
+	fe.lindex = -1;
+	fe.tymed = TYMED_HGLOBAL;
+	ZeroMemory(&sm, sizeof (STGMEDIUM));
+	if (IDataObject_GetData(obj, &fe, &sm) != S_OK) {
+		// the data went away between DragEnter() and now; nothing we can do
+		*pdwEffect = DROPEFFECT_NONE;
+		return S_OK;
+	}
+	// DragQueryFileW() takes the HDROP itself; the others want the memory
+	if (format == dropFormatFiles)
+		p = (void *) (sm.hGlobal);
+	else {
+		p = GlobalLock(sm.hGlobal);
+		if (p == NULL)
+			xpanic("error locking dropped data", GetLastError());
+	}
+	accepted = areaDrop(t->data, t->formats, x, y, effect, format, p, (uintptr_t) GlobalSize(sm.hGlobal));
+	if (format != dropFormatFiles)
+		GlobalUnlock(sm.hGlobal);
+	ReleaseStgMedium(&sm);
+	if (!accepted)
+		effect = DROPEFFECT_NONE;
+	*pdwEffect = effect;
+	return S_OK;
+}
+
+static IDropTargetVtbl areaDropTargetVtbl = {
+	adtQueryInterface,
+	adtAddRef,
+	adtRelease,
+	adtDragEnter,
+	adtDragOver,
+	adtDragLeave,
+	adtDrop,
+};
+
+void areaSetDropTarget(HWND hwnd, void *data)
+{
+	struct areaDropTarget *t;
+	HRESULT hr;
+
+	t = (struct areaDropTarget *) malloc(sizeof (struct areaDropTarget));
+	if (t == NULL)
+		xpanic("memory exhausted allocating Area drop target", GetLastError());
+	ZeroMemory(t, sizeof (struct areaDropTarget));
+	t->dt.lpVtbl = &areaDropTargetVtbl;
+	t->refcount = 1;
+	t->hwnd = hwnd;
+	t->data = data;
+	// this is only cosmetic, so don't complain if it fails
+	if (CoCreateInstance(&CLSID_DragDropHelper, NULL, CLSCTX_INPROC_SERVER, &IID_IDropTargetHelper, (LPVOID *) (&(t->helper))) != S_OK)
+		t->helper = NULL;
+	hr = RegisterDragDrop(hwnd, (IDropTarget *) t);
+	if (hr != S_OK)
+		xpanichresult("error registering Area as drop target", hr);
+	// RegisterDragDrop() took its own reference
+	IDropTarget_Release((IDropTarget *) t);
+}
+
+// and this is the IDataObject and IDropSource for Area.StartDrag() and Table.OnDragRow()
+// the data is stored as HGLOBALs we make up front; GetData() hands out copies
+// SetData() is only there for IDragSourceHelper, which stores the drag image and some bookkeeping of its own in the data object
+
+// our formats, and room for the shell's
+#define maxDragFormats 16
+
+struct areaDataObject {
+	IDataObject obj;		// must be first
+	LONG refcount;
+	ULONG n;
+	FORMATETC fe[maxDragFormats];
+	HGLOBAL data[maxDragFormats];		// NULL for CFSTR_FILECONTENTS, which is made when asked for; see promiseContents()
+	ULONG npromises;
+	BOOL local;		// see isLocalDragData()
+};
+
+// FilePromises are a CFSTR_FILEDESCRIPTORW naming the files and a CFSTR_FILECONTENTS for each, told apart by lindex
+static CLIPFORMAT cfFileDescriptor = 0;
+static CLIPFORMAT cfFileContents = 0;
+
+static HRESULT STDMETHODCALLTYPE adoQueryInterface(IDataObject *this, REFIID riid, void **ppvObject)
+{
+	if (ppvObject == NULL)
+		return E_POINTER;
+	if (IsEqualIID(riid, &IID_IUnknown) || IsEqualIID(riid, &IID_IDataObject)) {
+		IDataObject_AddRef(this);
+		*ppvObject = (void *) this;
+		return S_OK;
+	}
+	*ppvObject = NULL;
+	return E_NOINTERFACE;
+}
+
+static ULONG STDMETHODCALLTYPE adoAddRef(IDataObject *this)
+{
+	struct areaDataObject *o = (struct areaDataObject *) this;
+
+	return (ULONG) InterlockedIncrement(&(o->refcount));
+}
+
+static ULONG STDMETHODCALLTYPE adoRelease(IDataObject *this)
+{
+	struct areaDataObject *o = (struct areaDataObject *) this;
+	LONG n;
+	ULONG i;
+
+	n = InterlockedDecrement(&(o->refcount));
+	if (n == 0) {
+		for (i = 0; i < o->n; i++)
+			if (o->data[i] != NULL)
+				GlobalFree(o->data[i]);
+		free(o);
+	}
+	return (ULONG) n;
+}
+
+static int findFormat(struct areaDataObject *o, FORMATETC *fe)
+{
+	ULONG i;
+
+	for (i = 0; i < o->n; i++)
+		if (fe->cfFormat == o->fe[i].cfFormat &&
+			(fe->tymed & TYMED_HGLOBAL) != 0 &&
+			fe->dwAspect == DVASPECT_CONTENT)
+			return (int) i;
+	return -1;
+}
+
+static HRESULT queryPromise(struct areaDataObject *o, FORMATETC *fe)
+{
+	if (fe->lindex < 0 || (ULONG) (fe->lindex) >= o->npromises)
+		return DV_E_LINDEX;
+	if ((fe->tymed & (TYMED_ISTREAM | TYMED_HGLOBAL)) == 0 || fe->dwAspect != DVASPECT_CONTENT)
+		return DV_E_FORMATETC;
+	return S_OK;
+}
+
+// the Go side writes the file into memory we give back as a stream if the drop target takes one, so the file comes out the right size (GlobalSize() can round up)
+static HRESULT promiseContents(struct areaDataObject *o, FORMATETC *fe, STGMEDIUM *sm)
+{
+	HGLOBAL data;
+	uintptr_t size;
+	IStream *stream;
+	LARGE_INTEGER zero;
+	HRESULT hr;
+
+	hr = queryPromise(o, fe);
+	if (hr != S_OK)
+		return hr;
+	data = dragWritePromise((intptr_t) (fe->lindex), &size);
+	if (data == NULL)
+		return E_FAIL;
+	ZeroMemory(sm, sizeof (STGMEDIUM));
+	sm->pUnkForRelease = NULL;		// the receiver frees it
+	if ((fe->tymed & TYMED_ISTREAM) == 0) {
+		sm->tymed = TYMED_HGLOBAL;
+		sm->hGlobal = data;
+		return S_OK;
+	}
+	hr = CreateStreamOnHGlobal(NULL, TRUE, &stream);
+	if (hr != S_OK) {
+		GlobalFree(data);
+		return hr;
+	}
+	hr = IStream_Write(stream, GlobalLock(data), (ULONG) size, NULL);
+	GlobalUnlock(data);
+	GlobalFree(data);
+	if (hr != S_OK) {
+		IStream_Release(stream);
+		return hr;
+	}
+	ZeroMemory(&zero, sizeof (LARGE_INTEGER));
+	IStream_Seek(stream, zero, STREAM_SEEK_SET, NULL);
+	sm->tymed = TYMED_ISTREAM;
+	sm->pstm = stream;
+	return S_OK;
+}
+
+static HRESULT STDMETHODCALLTYPE adoGetData(IDataObject *this, FORMATETC *fe, STGMEDIUM *sm)
+{
+	struct areaDataObject *o = (struct areaDataObject *) this;
+	int i;
+	SIZE_T size;
+	void *from, *to;
+
+	if (fe == NULL || sm == NULL)
+		return E_INVALIDARG;
+	if (o->npromises != 0 && fe->cfFormat == cfFileContents)
+		return promiseContents(o, fe, sm);
+	i = findFormat(o, fe);
+	if (i == -1)
+		return DV_E_FORMATETC;
+	size = GlobalSize(o->data[i]);
+	ZeroMemory(sm, sizeof (STGMEDIUM));
+	sm->tymed = TYMED_HGLOBAL;
+	sm->hGlobal = GlobalAlloc(GMEM_MOVEABLE, size);
+	if (sm->hGlobal == NULL)
+		return E_OUTOFMEMORY;
+	from = GlobalLock(o->data[i]);
+	to = GlobalLock(sm->hGlobal);
+	memcpy(to, from, size);
+	GlobalUnlock(sm->hGlobal);
+	GlobalUnlock(o->data[i]);
+	sm->pUnkForRelease = NULL;		// the receiver frees it
+	return S_OK;
+}
+
+static HRESULT STDMETHODCALLTYPE adoGetDataHere(IDataObject *this, FORMATETC *fe, STGMEDIUM *sm)
+{
+	return E_NOTIMPL;
+}
+
+static HRESULT STDMETHODCALLTYPE adoQueryGetData(IDataObject *this, FORMATETC *fe)
+{
+	struct areaDataObject *o = (struct areaDataObject *) this;
+
+	if (fe == NULL)
+		return E_INVALIDARG;
+	if (o->npromises != 0 && fe->cfFormat == cfFileContents)
+		return queryPromise(o, fe);
+	if (findFormat(o, fe) == -1)
+		return DV_E_FORMATETC;
+	return S_OK;
+}
+
+static HRESULT STDMETHODCALLTYPE adoGetCanonicalFormatEtc(IDataObject *this, FORMATETC *in, FORMATETC *out)
+{
+	if (out != NULL)
+		out->ptd = NULL;
+	return E_NOTIMPL;
+}
+
+static HRESULT STDMETHODCALLTYPE adoSetData(IDataObject *this, FORMATETC *fe, STGMEDIUM *sm, BOOL fRelease)
+{
+	struct areaDataObject *o = (struct areaDataObject *) this;
+	int i;
+	SIZE_T size;
+	HGLOBAL data;
+	void *from, *to;
+
+	if (fe == NULL || sm == NULL)
+		return E_INVALIDARG;
+	if (fe->tymed != TYMED_HGLOBAL || sm->tymed != TYMED_HGLOBAL || fe->dwAspect != DVASPECT_CONTENT)
+		return DV_E_FORMATETC;
+	if (o->npromises != 0 && fe->cfFormat == cfFileContents)
+		return DV_E_FORMATETC;
+	i = findFormat(o, fe);
+	if (i == -1 && o->n >= maxDragFormats)
+		return E_OUTOFMEMORY;
+	// always keep a copy; the medium might have its own way of being released
+	size = GlobalSize(sm->hGlobal);
+	data = GlobalAlloc(GMEM_MOVEABLE, size);
+	if (data == NULL)
+		return E_OUTOFMEMORY;
+	from = GlobalLock(sm->hGlobal);
+	to = GlobalLock(data);
+	memcpy(to, from, size);
+	GlobalUnlock(data);
+	GlobalUnlock(sm->hGlobal);
+	if (i == -1) {
+		i = (int) (o->n);
+		o->fe[i] = *fe;
+		o->fe[i].ptd = NULL;
+		o->n++;
+	} else
+		GlobalFree(o->data[i]);
+	o->data[i] = data;
+	if (fRelease)
+		ReleaseStgMedium(sm);
+	return S_OK;
+}
+
+static HRESULT STDMETHODCALLTYPE adoEnumFormatEtc(IDataObject *this, DWORD dwDirection, IEnumFORMATETC **ppenum)
+{
+	struct areaDataObject *o = (struct areaDataObject *) this;
+
+	if (dwDirection != DATADIR_GET)
+		return E_NOTIMPL;
+	return SHCreateStdEnumFmtEtc((UINT) (o->n), o->fe, ppenum);
+}
+
+static HRESULT STDMETHODCALLTYPE adoDAdvise(IDataObject *this, FORMATETC *fe, DWORD advf, IAdviseSink *sink, DWORD *conn)
+{
+	return OLE_E_ADVISENOTSUPPORTED;
+}
+
+static HRESULT STDMETHODCALLTYPE adoDUnadvise(IDataObject *this, DWORD conn)
+{
+	return OLE_E_ADVISENOTSUPPORTED;
+}
+
+static HRESULT STDMETHODCALLTYPE adoEnumDAdvise(IDataObject *this, IEnumSTATDATA **ppenum)
+{
+	return OLE_E_ADVISENOTSUPPORTED;
+}
+
+static IDataObjectVtbl areaDataObjectVtbl = {
+	adoQueryInterface,
+	adoAddRef,
+	adoRelease,
+	adoGetData,
+	adoGetDataHere,
+	adoQueryGetData,
+	adoGetCanonicalFormatEtc,
+	adoSetData,
+	adoEnumFormatEtc,
+	adoDAdvise,
+	adoDUnadvise,
+	adoEnumDAdvise,
+};
+
+// OLE hands drop targets in our own program the very IDataObject we gave DoDragDrop(), so a drag started by us is one with our vtable
+// DragData.Local itself is kept on the Go side, as there is only one drag at a time
+static BOOL isLocalDragData(IDataObject *obj)
+{
+	return obj->lpVtbl == &areaDataObjectVtbl && ((struct areaDataObject *) obj)->local;
+}
+
+void dragDataSetLocal(void *data)
+{
+	struct areaDataObject *o = (struct areaDataObject *) data;
+
+	o->local = TRUE;
+}
+
+void *newDragData(void)
+{
+	struct areaDataObject *o;
+
+	o = (struct areaDataObject *) malloc(sizeof (struct areaDataObject));
+	if (o == NULL)
+		xpanic("memory exhausted allocating Area drag data", GetLastError());
+	ZeroMemory(o, sizeof (struct areaDataObject));
+	o->obj.lpVtbl = &areaDataObjectVtbl;
+	o->refcount = 1;
+	return (void *) o;
+}
+
+static void *addFormat(struct areaDataObject *o, CLIPFORMAT cf, SIZE_T size)
+{
+	ULONG i;
+
+	if (o->n >= maxDragFormats)
+		xpanic("too many formats in Area drag data (bug in package ui)", 0);
+	i = o->n;
+	o->fe[i].cfFormat = cf;
+	o->fe[i].ptd = NULL;
+	o->fe[i].dwAspect = DVASPECT_CONTENT;
+	o->fe[i].lindex = -1;
+	o->fe[i].tymed = TYMED_HGLOBAL;
+	o->data[i] = GlobalAlloc(GMEM_MOVEABLE | GMEM_ZEROINIT, size);
+	if (o->data[i] == NULL)
+		xpanic("error allocating memory for Area drag data", GetLastError());
+	o->n++;
+	return GlobalLock(o->data[i]);
+}
+
+// p is either CF_UNICODETEXT text or a CF_DIB
+void dragDataAdd(void *data, UINT cf, void *p, uintptr_t size)
+{
+	struct areaDataObject *o = (struct areaDataObject *) data;
+	void *to;
+
+	to = addFormat(o, (CLIPFORMAT) cf, (SIZE_T) size);
+	memcpy(to, p, (size_t) size);
+	GlobalUnlock(o->data[o->n - 1]);
+}
+
+// files is a list of L'\0'-terminated filenames followed by another L'\0'; size is in bytes
+void dragDataAddFiles(void *data, WCHAR *files, uintptr_t size)
+{
+	struct areaDataObject *o = (struct areaDataObject *) data;
+	DROPFILES *df;
+
+	df = (DROPFILES *) addFormat(o, CF_HDROP, sizeof (DROPFILES) + (SIZE_T) size);
+	df->pFiles = sizeof (DROPFILES);
+	df->fWide = TRUE;
+	memcpy(((BYTE *) df) + sizeof (DROPFILES), files, (size_t) size);
+	GlobalUnlock(o->data[o->n - 1]);
+}
+
+// names is a list of L'\0'-terminated filenames followed by another L'\0', as with dragDataAddFiles()
+// the CFSTR_FILECONTENTS entry is only there for EnumFormatEtc(); GetData() makes the contents as needed
+void dragDataAddPromises(void *data, WCHAR *names, ULONG n)
+{
+	struct areaDataObject *o = (struct areaDataObject *) data;
+	FILEGROUPDESCRIPTORW *fgd;
+	ULONG i;
+
+	if (cfFileDescriptor == 0) {
+		cfFileDescriptor = (CLIPFORMAT) RegisterClipboardFormatW(CFSTR_FILEDESCRIPTORW);
+		if (cfFileDescriptor == 0)
+			xpanic("error registering CFSTR_FILEDESCRIPTORW", GetLastError());
+		cfFileContents = (CLIPFORMAT) RegisterClipboardFormatW(CFSTR_FILECONTENTS);
+		if (cfFileContents == 0)
+			xpanic("error registering CFSTR_FILECONTENTS", GetLastError());
+	}
+	// FILEGROUPDESCRIPTORW already has room for one FILEDESCRIPTORW
+	fgd = (FILEGROUPDESCRIPTORW *) addFormat(o, cfFileDescriptor, sizeof (FILEGROUPDESCRIPTORW) + (n - 1) * sizeof (FILEDESCRIPTORW));
+	fgd->cItems = n;
+	for (i = 0; i < n; i++) {
+		fgd->fgd[i].dwFlags = FD_PROGRESSUI;
+		lstrcpynW(fgd->fgd[i].cFileName, names, MAX_PATH);
+		names += lstrlenW(names) + 1;
+	}
+	GlobalUnlock(o->data[o->n - 1]);
+	if (o->n >= maxDragFormats)
+		xpanic("too many formats in Area drag data (bug in package ui)", 0);
+	i = o->n;
+	o->fe[i].cfFormat = cfFileContents;
+	o->fe[i].ptd = NULL;
+	o->fe[i].dwAspect = DVASPECT_CONTENT;
+	o->fe[i].lindex = -1;
+	o->fe[i].tymed = TYMED_ISTREAM | TYMED_HGLOBAL;
+	o->data[i] = NULL;
+	o->n++;
+	o->npromises = n;
+}
+
+// this is for dragWritePromise() to hand the contents of a FilePromise to promiseContents()
+HGLOBAL dragPromiseGlobal(void *p, uintptr_t size)
+{
+	HGLOBAL data;
+	void *to;
+
+	// GlobalAlloc() can't make an empty block
+	data = GlobalAlloc(GMEM_MOVEABLE, (size == 0) ? 1 : (SIZE_T) size);
+	if (data == NULL)
+		xpanic("error allocating memory for FilePromise contents", GetLastError());
+	to = GlobalLock(data);
+	if (size != 0)
+		memcpy(to, p, (size_t) size);
+	GlobalUnlock(data);
+	return data;
+}
+
+// bitmap is a 32-bit top-down DIB section with premultiplied alpha; the shell owns it from here on
+// without a drag image helper (or if it fails), the drag goes on without an image
+void dragDataSetImage(void *data, HBITMAP bitmap, LONG width, LONG height, LONG hotx, LONG hoty)
+{
+	IDragSourceHelper *helper;
+	SHDRAGIMAGE di;
+
+	if (CoCreateInstance(&CLSID_DragDropHelper, NULL, CLSCTX_INPROC_SERVER, &IID_IDragSourceHelper, (LPVOID *) (&helper)) != S_OK) {
+		DeleteObject(bitmap);
+		return;
+	}
+	ZeroMemory(&di, sizeof (SHDRAGIMAGE));
+	di.sizeDragImage.cx = width;
+	di.sizeDragImage.cy = height;
+	di.ptOffset.x = hotx;
+	di.ptOffset.y = hoty;
+	di.hbmpDragImage = bitmap;
+	di.crColorKey = CLR_NONE;
+	if (IDragSourceHelper_InitializeFromBitmap(helper, &di, (IDataObject *) data) != S_OK)
+		DeleteObject(bitmap);
+	IDragSourceHelper_Release(helper);
+}
+
+struct areaDropSource {
+	IDropSource ds;		// must be first
+	LONG refcount;
+	DWORD button;		// MK_xxx of the button that started the drag
+};
+
+static HRESULT STDMETHODCALLTYPE adsQueryInterface(IDropSource *this, REFIID riid, void **ppvObject)
+{
+	if (ppvObject == NULL)
+		return E_POINTER;
+	if (IsEqualIID(riid, &IID_IUnknown) || IsEqualIID(riid, &IID_IDropSource)) {
+		IDropSource_AddRef(this);
+		*ppvObject = (void *) this;
+		return S_OK;
+	}
+	*ppvObject = NULL;
+	return E_NOINTERFACE;
+}
+
+static ULONG STDMETHODCALLTYPE adsAddRef(IDropSource *this)
+{
+	struct areaDropSource *s = (struct areaDropSource *) this;
+
+	return (ULONG) InterlockedIncrement(&(s->refcount));
+}
+
+static ULONG STDMETHODCALLTYPE adsRelease(IDropSource *this)
+{
+	struct areaDropSource *s = (struct areaDropSource *) this;
+	LONG n;
+
+	n = InterlockedDecrement(&(s->refcount));
+	if (n == 0)
+		free(s);
+	return (ULONG) n;
+}
+
+static HRESULT STDMETHODCALLTYPE adsQueryContinueDrag(IDropSource *this, BOOL fEscapePressed, DWORD grfKeyState)
+{
+	struct areaDropSource *s = (struct areaDropSource *) this;
+
+	if (fEscapePressed)
+		return DRAGDROP_S_CANCEL;
+	if ((grfKeyState & s->button) == 0)
+		return DRAGDROP_S_DROP;
+	return S_OK;
+}
+
+static HRESULT STDMETHODCALLTYPE adsGiveFeedback(IDropSource *this, DWORD dwEffect)
+{
+	return DRAGDROP_S_USEDEFAULTCURSORS;
+}
+
+static IDropSourceVtbl areaDropSourceVtbl = {
+	adsQueryInterface,
+	adsAddRef,
+	adsRelease,
+	adsQueryContinueDrag,
+	adsGiveFeedback,
+};
+
+// this runs a modal loop of its own and returns when the drag is over
+// it also releases the drag data
+DWORD doDrag(void *data, DWORD button, DWORD allowed)
+{
+	struct areaDataObject *o = (struct areaDataObject *) data;
+	struct areaDropSource *s;
+	DWORD effect;
+	HRESULT hr;
+
+	s = (struct areaDropSource *) malloc(sizeof (struct areaDropSource));
+	if (s == NULL)
+		xpanic("memory exhausted allocating Area drop source", GetLastError());
+	ZeroMemory(s, sizeof (struct areaDropSource));
+	s->ds.lpVtbl = &areaDropSourceVtbl;
+	s->refcount = 1;
+	s->button = button;
+	effect = DROPEFFECT_NONE;
+	hr = DoDragDrop((IDataObject *) o, (IDropSource *) s, allowed, &effect);
+	IDropSource_Release((IDropSource *) s);
+	IDataObject_Release((IDataObject *) o);
+	if (hr == DRAGDROP_S_DROP)
+		return effect;
+	if (hr != DRAGDROP_S_CANCEL)
+		xpanichresult("error running Area drag and drop", hr);
+	return DROPEFFECT_NONE;
+}
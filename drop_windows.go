@@ -0,0 +1,282 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"syscall"
+	"unsafe"
+)
+
+// #include "winapi_windows.h"
+import "C"
+
+// the dropFormat* constants in winapi_windows.h have the same values as the DropFormats constants, so we can convert directly
+
+func (a *area) setDropTarget() {
+	if a.dropHandler() == nil {
+		return
+	}
+	C.areaSetDropTarget(a.hwnd, unsafe.Pointer(a))
+}
+
+// effect is the single DROPEFFECT_xxx the drop would have, or DROPEFFECT_NONE; see dropEffect() in drop_windows.c
+
+//export areaDragEnter
+func areaDragEnter(data unsafe.Pointer, formats C.DWORD, x C.int, y C.int, effect C.DWORD) C.BOOL {
+	a := (*area)(data)
+	e := DropEvent{
+		Pos:       image.Pt(int(x), int(y)),
+		Formats:   DropFormats(formats),
+		Local:     dropLocal(DropFormats(formats)),
+		Operation: dragOperation(effect),
+		Timestamp: eventTime(),
+	}
+	return toBOOL(a.dropHandler().DragEnter(e))
+}
+
+//export areaDragOver
+func areaDragOver(data unsafe.Pointer, formats C.DWORD, x C.int, y C.int, effect C.DWORD) C.BOOL {
+	a := (*area)(data)
+	e := DropEvent{
+		Pos:       image.Pt(int(x), int(y)),
+		Formats:   DropFormats(formats),
+		Local:     dropLocal(DropFormats(formats)),
+		Operation: dragOperation(effect),
+		Timestamp: eventTime(),
+	}
+	return toBOOL(a.dropHandler().DragOver(e))
+}
+
+//export areaDragLeave
+func areaDragLeave(data unsafe.Pointer) {
+	a := (*area)(data)
+	a.dropHandler().DragLeave()
+}
+
+//export areaBestDropFormat
+func areaBestDropFormat(formats C.DWORD) C.DWORD {
+	return C.DWORD(bestDropFormat(DropFormats(formats)))
+}
+
+// for DropFiles, p is the HDROP; otherwise it is the locked memory of the given size
+//export areaDrop
+func areaDrop(data unsafe.Pointer, formats C.DWORD, x C.int, y C.int, effect C.DWORD, format C.DWORD, p unsafe.Pointer, size C.uintptr_t) C.BOOL {
+	a := (*area)(data)
+	e := DropEvent{
+		Pos:       image.Pt(int(x), int(y)),
+		Formats:   DropFormats(formats),
+		Local:     dropLocal(DropFormats(formats)),
+		Operation: dragOperation(effect),
+		Timestamp: eventTime(),
+	}
+	switch DropFormats(format) {
+	case DropFiles:
+		hdrop := C.HDROP(p)
+		n := C.DragQueryFileW(hdrop, 0xFFFFFFFF, nil, 0)
+		for i := C.UINT(0); i < n; i++ {
+			// the length does not include the terminating L'\0'
+			length := C.DragQueryFileW(hdrop, i, nil, 0)
+			buf := make([]uint16, length+1)
+			C.DragQueryFileW(hdrop, i, (*C.WCHAR)(unsafe.Pointer(&buf[0])), length+1)
+			e.Files = append(e.Files, syscall.UTF16ToString(buf))
+		}
+	case DropText:
+		e.Text = wstrToString((*C.WCHAR)(p))
+	case DropImage:
+		e.Image = fromDIB(p, uintptr(size))
+	}
+	return toBOOL(a.dropHandler().Drop(e))
+}
+
+var mkButtons = map[uint]C.DWORD{
+	1: C.MK_LBUTTON,
+	2: C.MK_MBUTTON,
+	3: C.MK_RBUTTON,
+	4: C.MK_XBUTTON1,
+	5: C.MK_XBUTTON2,
+}
+
+func (a *area) StartDrag(data DragData, allowed DragOperation, f func(op DragOperation)) {
+	checkStartDrag(data, allowed, "Area.StartDrag()")
+	button, ok := mkButtons[a.mousebutton]
+	if !ok {
+		panic("Area.StartDrag() called outside of a mouse event with a button held")
+	}
+	op := startDrag(data, allowed, button)
+	if f != nil {
+		f(op)
+	}
+}
+
+// startDrag is shared by Area.StartDrag() and Table.OnDragRow()
+// button is the MK_xxx of the mouse button that is held; the drag ends when it is released
+// this doesn't return until the drag is over, and it eats the button release
+func startDrag(data DragData, allowed DragOperation, button C.DWORD) DragOperation {
+	o := C.newDragData()
+	if len(data.Files) != 0 {
+		var list []uint16
+
+		for _, f := range data.Files {
+			list = append(list, syscall.StringToUTF16(f)...) // includes the terminating L'\0'
+		}
+		list = append(list, 0)
+		C.dragDataAddFiles(o, (*C.WCHAR)(unsafe.Pointer(&list[0])), C.uintptr_t(len(list)*2))
+	}
+	if data.Image != nil {
+		dib := toDIB(toRGBA(data.Image))
+		C.dragDataAdd(o, C.CF_DIB, unsafe.Pointer(&dib[0]), C.uintptr_t(len(dib)))
+	}
+	if data.Text != "" {
+		text := syscall.StringToUTF16(data.Text)
+		C.dragDataAdd(o, C.CF_UNICODETEXT, unsafe.Pointer(&text[0]), C.uintptr_t(len(text)*2))
+	}
+	if len(data.FilePromises) != 0 {
+		var list []uint16
+
+		for _, p := range data.FilePromises {
+			list = append(list, syscall.StringToUTF16(p.Name)...)
+		}
+		list = append(list, 0)
+		C.dragDataAddPromises(o, (*C.WCHAR)(unsafe.Pointer(&list[0])), C.ULONG(len(data.FilePromises)))
+	}
+	dragPromises = data.FilePromises
+	if data.Local != nil {
+		C.dragDataSetLocal(o)
+	}
+	dragLocal = data.Local
+	if img := toRGBA(data.DragImage); img != nil && !img.Rect.Empty() {
+		// the shell frees the bitmap
+		bitmap := C.toAlphaBitmap(unsafe.Pointer(img), C.intptr_t(img.Rect.Dx()), C.intptr_t(img.Rect.Dy()))
+		C.dragDataSetImage(o, bitmap, C.LONG(img.Rect.Dx()), C.LONG(img.Rect.Dy()), C.LONG(data.Hotspot.X), C.LONG(data.Hotspot.Y))
+	}
+	return dragOperation(C.doDrag(o, button, dropEffects(allowed)))
+}
+
+// the drop target asks for the contents of the FilePromises during DoDragDrop(), so this runs on the main thread
+// it returns NULL if Write fails
+//export dragWritePromise
+func dragWritePromise(i C.intptr_t, size *C.uintptr_t) C.HGLOBAL {
+	var buf bytes.Buffer
+
+	if err := dragPromises[i].Write(&buf); err != nil {
+		return nil
+	}
+	*size = C.uintptr_t(buf.Len())
+	var p unsafe.Pointer
+	if buf.Len() != 0 {
+		p = unsafe.Pointer(&buf.Bytes()[0])
+	}
+	return C.dragPromiseGlobal(p, C.uintptr_t(buf.Len()))
+}
+
+// a drag source can get back more than one effect; a move matters most, since the source has to remove the original
+func dragOperation(effect C.DWORD) DragOperation {
+	switch {
+	case (effect & C.DROPEFFECT_MOVE) != 0:
+		return DragMove
+	case (effect & C.DROPEFFECT_COPY) != 0:
+		return DragCopy
+	case (effect & C.DROPEFFECT_LINK) != 0:
+		return DragLink
+	}
+	return 0
+}
+
+func dropEffects(ops DragOperation) (effects C.DWORD) {
+	if (ops & DragCopy) != 0 {
+		effects |= C.DROPEFFECT_COPY
+	}
+	if (ops & DragMove) != 0 {
+		effects |= C.DROPEFFECT_MOVE
+	}
+	if (ops & DragLink) != 0 {
+		effects |= C.DROPEFFECT_LINK
+	}
+	return effects
+}
+
+// toDIB produces a bottom-up 32-bit CF_DIB with non-premultiplied alpha, which is what fromDIB() below expects and what most programs accept
+func toDIB(img *image.RGBA) []byte {
+	var bi C.BITMAPINFOHEADER
+
+	width := img.Rect.Dx()
+	height := img.Rect.Dy()
+	stride := width * 4
+	bi.biSize = C.DWORD(unsafe.Sizeof(bi))
+	bi.biWidth = C.LONG(width)
+	bi.biHeight = C.LONG(height) // positive: bottom-up
+	bi.biPlanes = 1
+	bi.biBitCount = 32
+	bi.biCompression = C.BI_RGB
+	bi.biSizeImage = C.DWORD(stride * height)
+	hdr := C.GoBytes(unsafe.Pointer(&bi), C.int(unsafe.Sizeof(bi)))
+	pix := make([]byte, stride*height)
+	if len(pix) != 0 {
+		toARGB(img, uintptr(unsafe.Pointer(&pix[0])), stride, true) // NRGBA
+	}
+	dib := make([]byte, 0, len(hdr)+len(pix))
+	dib = append(dib, hdr...)
+	for y := height - 1; y >= 0; y-- {
+		dib = append(dib, pix[y*stride:(y+1)*stride]...)
+	}
+	return dib
+}
+
+// this only handles uncompressed 24- and 32-bit DIBs, which is what most programs put on the clipboard; anything else comes out as nil
+// the 32-bit case assumes the fourth byte is alpha, as is the de facto convention; a zero alpha everywhere gets treated as opaque
+func fromDIB(p unsafe.Pointer, size uintptr) *image.RGBA {
+	bi := (*C.BITMAPINFOHEADER)(p)
+	if uintptr(bi.biSize) > size || bi.biBitCount != 24 && bi.biBitCount != 32 {
+		return nil
+	}
+	offset := uintptr(bi.biSize)
+	switch bi.biCompression {
+	case C.BI_RGB:
+	case C.BI_BITFIELDS:
+		// the three color masks follow the header; we assume they're the usual ones
+		offset += 3 * 4
+	default:
+		return nil
+	}
+	width := int(bi.biWidth)
+	height := int(bi.biHeight)
+	bottomup := height > 0
+	if !bottomup {
+		height = -height
+	}
+	bpp := int(bi.biBitCount) / 8
+	// rows are padded to DWORDs
+	stride := ((width*bpp + 3) / 4) * 4
+	if offset+uintptr(stride*height) > size {
+		return nil
+	}
+	pix := C.GoBytes(unsafe.Pointer(uintptr(p)+offset), C.int(stride*height))
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	anyalpha := false
+	for y := 0; y < height; y++ {
+		row := y
+		if bottomup {
+			row = height - 1 - y
+		}
+		for x := 0; x < width; x++ {
+			q := row*stride + x*bpp
+			c := color.NRGBA{R: pix[q+2], G: pix[q+1], B: pix[q+0], A: 0xFF}
+			if bpp == 4 {
+				c.A = pix[q+3]
+				if c.A != 0 {
+					anyalpha = true
+				}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	if bpp == 4 && !anyalpha {
+		for i := 3; i < len(img.Pix); i += 4 {
+			img.Pix[i] = 0xFF
+		}
+	}
+	return toRGBA(img)
+}
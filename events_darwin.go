@@ -103,6 +103,14 @@ var keycodeExtKeys = map[uintptr]ExtKey{
 	0x7C: Right,
 	0x7D: Down,
 	0x7E: Up,
+	// Apple keyboards have no Print Screen or Pause; PC keyboards send F13 and F15 for them, which are in the same places on Apple's extended keyboards
+	0x69: PrintScreen, // F13
+	0x71: Pause,       // F15
+	0x6E: Menu,        // kVK_ContextualMenu; not in older versions of Events.h
+	// the volume keys are usually taken by the system before they get here; the other media keys are never sent as key events at all
+	0x4A: VolumeMute,
+	0x49: VolumeDown,
+	0x48: VolumeUp,
 }
 
 var keycodeModifiers = map[uintptr]Modifiers{
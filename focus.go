@@ -0,0 +1,98 @@
+// 15 october 2026
+
+package ui
+
+// focuser implements Window.OnFocusChanged(); each backend's window embeds one and calls focusMoved() whenever the keyboard focus moves within the window
+// it is only touched on the main thread
+type focuser struct {
+	focuschanged func(c Control)
+	focused      Control
+}
+
+func (f *focuser) OnFocusChanged(fn func(c Control)) {
+	f.focuschanged = fn
+}
+
+// h is the native handle of whatever has the focus now, in the same form as controlstate.handle, or 0 for nothing
+func (f *focuser) focusMoved(root Control, h uintptr) {
+	var c Control
+
+	if h != 0 {
+		c = findFocused(root, h)
+	}
+	if c == f.focused {
+		return
+	}
+	f.focused = c
+	if f.focuschanged != nil {
+		f.focuschanged(c)
+	}
+}
+
+// the focused native object can be inside a Control (such as the entry of a combobox or the field editor of a Mac OS X text field), and Groups and Tabs contain other Controls, so look at children first
+func findFocused(c Control, h uintptr) Control {
+	for _, child := range c.childControls() {
+		if found := findFocused(child, h); found != nil {
+			return found
+		}
+	}
+	if ch := c.focusHandle(); ch != 0 && nativeContains(ch, h) {
+		return c
+	}
+	return nil
+}
+
+// tabOrderHandles returns the native handles of controls for Window.SetTabOrder(), skipping Controls that have none
+func tabOrderHandles(controls []Control) []uintptr {
+	handles := make([]uintptr, 0, len(controls))
+	for _, c := range controls {
+		if h := c.focusHandle(); h != 0 {
+			handles = append(handles, h)
+		}
+	}
+	return handles
+}
+
+// tabPage is a page of a Tab that has to be selected for a Control on it to be shown
+type tabPage struct {
+	tab  Tab
+	page int
+}
+
+// for Window.FocusFirstInvalid()
+// the Tab pages are selected outermost first, and before the focus moves, as a Control on a page that isn't showing can't take the focus
+func focusFirstInvalid(root Control) bool {
+	t, pages := findFirstInvalid(root, nil)
+	if t == nil {
+		return false
+	}
+	for _, p := range pages {
+		p.tab.Select(p.page)
+	}
+	t.SetFocus()
+	t.ScrollIntoView()
+	return true
+}
+
+func findFirstInvalid(c Control, pages []tabPage) (TextField, []tabPage) {
+	if c == nil {
+		return nil, nil
+	}
+	if t, ok := c.(TextField); ok {
+		if t.InvalidReason() != "" && t.Enabled() {
+			return t, pages
+		}
+		return nil, nil
+	}
+	tab, isTab := c.(Tab)
+	for i, child := range c.childControls() {
+		childpages := pages
+		if isTab {
+			childpages = append(pages[:len(pages):len(pages)], tabPage{tab, i})
+		}
+		if t, p := findFirstInvalid(child, childpages); t != nil {
+			return t, p
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,102 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"fmt"
+)
+
+// FontDescriptor describes a font by the properties every system understands, so that it can be saved, compared, and passed to ChooseFont.
+// The system picks the closest font it has to a FontDescriptor; a Family that isn't installed falls back to the system's default font.
+type FontDescriptor struct {
+	// Family is the name of the font family, such as "Helvetica" or "DejaVu Sans".
+	Family string
+
+	// Size is the size of the font in points.
+	Size float64
+
+	// Weight is how heavy the strokes of the font are.
+	// The zero value is taken as FontWeightNormal.
+	Weight FontWeight
+
+	// Italic is whether the font is slanted; oblique fonts count as italic.
+	Italic bool
+}
+
+// FontWeight is the weight of a font, on the same 100 to 900 scale as CSS and OpenType.
+// Fonts rarely come in all nine weights; the system uses the nearest one the font has.
+type FontWeight int
+
+const (
+	FontWeightThin       FontWeight = 100
+	FontWeightExtraLight FontWeight = 200
+	FontWeightLight      FontWeight = 300
+	FontWeightNormal     FontWeight = 400
+	FontWeightMedium     FontWeight = 500
+	FontWeightSemiBold   FontWeight = 600
+	FontWeightBold       FontWeight = 700
+	FontWeightExtraBold  FontWeight = 800
+	FontWeightBlack      FontWeight = 900
+)
+
+// String returns a description of the font such as "Helvetica 12 Bold Italic", for showing to the user.
+func (f FontDescriptor) String() string {
+	s := fmt.Sprintf("%s %g", f.Family, f.Size)
+	switch f.weight() {
+	case FontWeightThin:
+		s += " Thin"
+	case FontWeightExtraLight:
+		s += " Extra Light"
+	case FontWeightLight:
+		s += " Light"
+	case FontWeightNormal:
+		// nothing
+	case FontWeightMedium:
+		s += " Medium"
+	case FontWeightSemiBold:
+		s += " Semi-Bold"
+	case FontWeightBold:
+		s += " Bold"
+	case FontWeightExtraBold:
+		s += " Extra Bold"
+	case FontWeightBlack:
+		s += " Black"
+	default:
+		s += fmt.Sprintf(" Weight %d", f.Weight)
+	}
+	if f.Italic {
+		s += " Italic"
+	}
+	return s
+}
+
+// weight returns Weight with the zero value filled in and out-of-range values clamped, for the backends
+func (f FontDescriptor) weight() FontWeight {
+	switch {
+	case f.Weight == 0:
+		return FontWeightNormal
+	case f.Weight < FontWeightThin:
+		return FontWeightThin
+	case f.Weight > FontWeightBlack:
+		return FontWeightBlack
+	}
+	return f.Weight
+}
+
+// ChooseFont opens the system's font chooser to let the user pick a font, starting with initial.
+// The font chooser is modal to win, which must not be nil; on Mac OS X, it is the shared font panel instead, which is not modal.
+// Some time after the font chooser is closed, ChooseFont runs f on the main thread, passing the chosen font, or nil if the user cancelled.
+// The Mac OS X font panel has no Cancel button, so font is never nil there.
+// If initial.Family is empty, the font chooser starts with the system's default font.
+func ChooseFont(win Window, initial FontDescriptor, f func(font *FontDescriptor)) {
+	if win == nil {
+		panic("Window passed to ChooseFont() cannot be nil")
+	}
+	if f == nil {
+		panic("function passed to ChooseFont() cannot be nil")
+	}
+	if initial.Size <= 0 {
+		initial.Size = 12
+	}
+	win.chooseFont(initial, f)
+}
@@ -0,0 +1,647 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"time"
+)
+
+// GanttTask is a task in a Gantt chart.
+type GanttTask struct {
+	// Start and End give when the task is scheduled; End must be after Start.
+	Start time.Time
+	End   time.Time
+
+	// Progress is the fraction of the task that is done, from 0 to 1; it is drawn as a darker part of the task's bar.
+	Progress float64
+
+	// Label, if not nil, is drawn to the right of the task's bar.
+	// As with GraphNode, render the task's name into an image yourself, for instance with a TextLayout.
+	Label *image.RGBA
+}
+
+// GanttDependency says that the task To cannot start before the task From ends.
+// Dependencies are only drawn, not enforced; a dependency whose tasks are scheduled in the wrong order is drawn in red.
+type GanttDependency struct {
+	From int
+	To   int
+}
+
+// Gantt is an Area that shows a schedule of tasks as a Gantt chart: one row per task, each with a bar spanning the task's scheduled time, arrows for the dependencies between tasks, and a date ruler along the top.
+//
+// The user can click a task's bar to select it, drag the bar to reschedule the task, and drag either end of the bar to change when the task starts or ends.
+// Dragging with the middle mouse button scrolls in time; Ctrl with + (or =), -, and 0 zooms in, zooms out, and resets the zoom.
+// Hold Alt while dragging to stop the task from snapping to whole hours or days (whichever the ruler shows).
+//
+// Gantt resizes its Area to fit all the rows; the width is left alone.
+// The ruler has ticks for hours, days, or weeks depending on the zoom, in the local time zone, but no labels; Gantt draws no text of its own.
+type Gantt interface {
+	Area
+
+	// AddTask adds a task in a new row at the bottom and returns its ID; IDs are never reused.
+	// Task returns a copy of a task, SetTask replaces one, and RemoveTask removes one along with its dependencies.
+	// These panic if there is no such task or if End is not after Start.
+	AddTask(t GanttTask) (id int)
+	Task(id int) GanttTask
+	SetTask(id int, t GanttTask)
+	RemoveTask(id int)
+
+	// Tasks returns the IDs of all the tasks, from the top row down.
+	Tasks() []int
+
+	// AddDependency and RemoveDependency add and remove a dependency.
+	// AddDependency panics if either task does not exist or if they are the same task; it does nothing if the dependency already exists.
+	AddDependency(d GanttDependency)
+	RemoveDependency(d GanttDependency)
+	Dependencies() []GanttDependency
+
+	// OnRescheduled registers a function that is called with the ID of a task after the user changes its Start or End.
+	// It is not called for changes made by the program.
+	OnRescheduled(f func(id int))
+
+	// Selected returns the selected task, if any; Select selects a task, or deselects all tasks if id is negative.
+	Selected() (id int, ok bool)
+	Select(id int)
+
+	// OnSelected is an event that gets triggered after the selection changes in whatever way.
+	OnSelected(f func())
+
+	// Scale and SetScale get and set the zoom, as the amount of time covered by one pixel; the default is 1 hour.
+	// SetScale clamps the scale to the range [1min,1week].
+	Scale() time.Duration
+	SetScale(scale time.Duration)
+
+	// Origin and SetOrigin get and set the time at the left edge of the Area.
+	// The Origin of a new Gantt is the start of the current day.
+	Origin() time.Time
+	SetOrigin(t time.Time)
+
+	// Export draws the entire chart, from the earliest start to the latest end at the current scale, into a new image, regardless of what part of it is shown.
+	// It returns nil if there are no tasks.
+	Export() *image.RGBA
+}
+
+type ganttDrag uint
+
+const (
+	ganttDragNone ganttDrag = iota
+	ganttDragMove
+	ganttDragStart
+	ganttDragEnd
+	ganttDragPan
+)
+
+const (
+	ganttRulerHeight  = 24
+	ganttRowHeight    = 28
+	ganttBarInset     = 6 // between the top and bottom of a row and its bar
+	ganttEdgeSlop     = 5
+	ganttMinTickGap   = 20
+	ganttExportMargin = 50
+	ganttDefaultScale = time.Hour
+)
+
+type gantt struct {
+	Area
+	width     int
+	minHeight int
+
+	tasks    map[int]*GanttTask
+	rows     []int
+	deps     []GanttDependency
+	next     int
+	selected int // -1 if none
+
+	scale  time.Duration
+	origin time.Time
+
+	rescheduled func(id int)
+	onselected  *event
+
+	drag       ganttDrag
+	dragStart  image.Point
+	dragNow    image.Point
+	dragTask   int
+	dragOrig   GanttTask
+	dragOrigin time.Time // for ganttDragPan
+}
+
+// NewGantt creates a new Gantt with no tasks.
+// The Area starts out with the given size and grows taller as tasks are added.
+func NewGantt(width int, height int) Gantt {
+	now := time.Now()
+	y, m, d := now.Date()
+	g := &gantt{
+		width:      width,
+		minHeight:  height,
+		tasks:      make(map[int]*GanttTask),
+		selected:   -1,
+		scale:      ganttDefaultScale,
+		origin:     time.Date(y, m, d, 0, 0, 0, 0, now.Location()),
+		onselected: newEvent(),
+	}
+	g.Area = NewArea(width, height, g)
+	return g
+}
+
+func (g *gantt) task(id int, what string) *GanttTask {
+	t, ok := g.tasks[id]
+	if !ok {
+		panic(fmt.Errorf("no task with ID %d in Gantt.%s()", id, what))
+	}
+	return t
+}
+
+func checkGanttTask(t GanttTask, what string) {
+	if !t.End.After(t.Start) {
+		panic(fmt.Errorf("task end %v is not after start %v in Gantt.%s()", t.End, t.Start, what))
+	}
+}
+
+// fitRows keeps the Area tall enough for all the rows
+func (g *gantt) fitRows() {
+	h := ganttRulerHeight + len(g.rows)*ganttRowHeight
+	if h < g.minHeight {
+		h = g.minHeight
+	}
+	g.Area.SetSize(g.width, h)
+}
+
+// SetSize is overridden so fitRows() knows the size the program wants.
+func (g *gantt) SetSize(width int, height int) {
+	g.width = width
+	g.minHeight = height
+	g.fitRows()
+}
+
+func (g *gantt) AddTask(t GanttTask) (id int) {
+	checkGanttTask(t, "AddTask")
+	id = g.next
+	g.next++
+	tt := t
+	g.tasks[id] = &tt
+	g.rows = append(g.rows, id)
+	g.fitRows()
+	g.RepaintAll()
+	return id
+}
+
+func (g *gantt) Task(id int) GanttTask {
+	return *g.task(id, "Task")
+}
+
+func (g *gantt) SetTask(id int, t GanttTask) {
+	checkGanttTask(t, "SetTask")
+	*g.task(id, "SetTask") = t
+	g.RepaintAll()
+}
+
+func (g *gantt) RemoveTask(id int) {
+	g.task(id, "RemoveTask")
+	for i := 0; i < len(g.deps); {
+		if d := g.deps[i]; d.From == id || d.To == id {
+			g.deps = append(g.deps[:i], g.deps[i+1:]...)
+			continue
+		}
+		i++
+	}
+	delete(g.tasks, id)
+	for i, r := range g.rows {
+		if r == id {
+			g.rows = append(g.rows[:i], g.rows[i+1:]...)
+			break
+		}
+	}
+	if g.drag != ganttDragNone && g.dragTask == id {
+		g.drag = ganttDragNone
+	}
+	if g.selected == id {
+		g.selected = -1
+		g.onselected.fire()
+	}
+	g.fitRows()
+	g.RepaintAll()
+}
+
+func (g *gantt) Tasks() []int {
+	return append([]int(nil), g.rows...)
+}
+
+func (g *gantt) AddDependency(d GanttDependency) {
+	g.task(d.From, "AddDependency")
+	g.task(d.To, "AddDependency")
+	if d.From == d.To {
+		panic(fmt.Errorf("task %d cannot depend on itself in Gantt.AddDependency()", d.From))
+	}
+	for _, old := range g.deps {
+		if old == d {
+			return
+		}
+	}
+	g.deps = append(g.deps, d)
+	g.RepaintAll()
+}
+
+func (g *gantt) RemoveDependency(d GanttDependency) {
+	for i, old := range g.deps {
+		if old == d {
+			g.deps = append(g.deps[:i], g.deps[i+1:]...)
+			g.RepaintAll()
+			return
+		}
+	}
+}
+
+func (g *gantt) Dependencies() []GanttDependency {
+	return append([]GanttDependency(nil), g.deps...)
+}
+
+func (g *gantt) OnRescheduled(f func(id int)) {
+	g.rescheduled = f
+}
+
+func (g *gantt) Selected() (id int, ok bool) {
+	return g.selected, g.selected >= 0
+}
+
+func (g *gantt) Select(id int) {
+	if id >= 0 {
+		g.task(id, "Select")
+	} else {
+		id = -1
+	}
+	g.selected = id
+	g.onselected.fire()
+	g.RepaintAll()
+}
+
+func (g *gantt) OnSelected(f func()) {
+	g.onselected.set(f)
+}
+
+func (g *gantt) Scale() time.Duration {
+	return g.scale
+}
+
+func (g *gantt) SetScale(scale time.Duration) {
+	g.scale = clampDuration(scale, time.Minute, 7*24*time.Hour)
+	g.RepaintAll()
+}
+
+func (g *gantt) Origin() time.Time {
+	return g.origin
+}
+
+func (g *gantt) SetOrigin(t time.Time) {
+	g.origin = t
+	g.RepaintAll()
+}
+
+// coordinate conversion; all of these take the origin so Export() can use them too
+
+func (g *gantt) toX(origin time.Time, t time.Time) int {
+	return int(t.Sub(origin) / g.scale)
+}
+
+func (g *gantt) toTime(origin time.Time, x int) time.Time {
+	return origin.Add(time.Duration(x) * g.scale)
+}
+
+func (g *gantt) barRect(origin time.Time, row int, t *GanttTask) image.Rectangle {
+	r := image.Rect(g.toX(origin, t.Start), ganttRulerHeight+row*ganttRowHeight+ganttBarInset,
+		g.toX(origin, t.End), ganttRulerHeight+(row+1)*ganttRowHeight-ganttBarInset)
+	if r.Dx() < 2 {
+		r.Max.X = r.Min.X + 2
+	}
+	return r
+}
+
+func (g *gantt) rowOf(id int) int {
+	for i, r := range g.rows {
+		if r == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// the ruler and snapping both use this: hours if they are far enough apart, otherwise days, otherwise weeks
+func (g *gantt) tickUnit() time.Duration {
+	switch {
+	case time.Hour/g.scale >= ganttMinTickGap:
+		return time.Hour
+	case 24*time.Hour/g.scale >= ganttMinTickGap:
+		return 24 * time.Hour
+	}
+	return 7 * 24 * time.Hour
+}
+
+// ticks calls f for each tick in [from,to); days and weeks start at local midnight, and weeks start on Monday
+func (g *gantt) ticks(from time.Time, to time.Time, f func(t time.Time, major bool)) {
+	y, m, d := from.Date()
+	t := time.Date(y, m, d, 0, 0, 0, 0, from.Location())
+	switch g.tickUnit() {
+	case time.Hour:
+		for ; t.Before(to); t = t.Add(time.Hour) {
+			f(t, t.Hour() == 0)
+		}
+	case 24 * time.Hour:
+		for ; t.Before(to); t = t.AddDate(0, 0, 1) {
+			f(t, t.Weekday() == time.Monday)
+		}
+	default:
+		for t.Weekday() != time.Monday {
+			t = t.AddDate(0, 0, -1)
+		}
+		for ; t.Before(to); t = t.AddDate(0, 0, 7) {
+			f(t, t.Day() <= 7) // the first week of each month
+		}
+	}
+}
+
+// snapTime rounds t to the nearest tick
+func (g *gantt) snapTime(t time.Time) time.Time {
+	unit := g.tickUnit()
+	best := t
+	bestDist := time.Duration(-1)
+	g.ticks(t.Add(-unit), t.Add(unit+time.Hour), func(tick time.Time, major bool) {
+		dist := tick.Sub(t)
+		if dist < 0 {
+			dist = -dist
+		}
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = tick, dist
+		}
+	})
+	return best
+}
+
+func (g *gantt) taskAt(p image.Point) (id int, part ganttDrag, ok bool) {
+	if p.Y < ganttRulerHeight {
+		return 0, ganttDragNone, false
+	}
+	row := (p.Y - ganttRulerHeight) / ganttRowHeight
+	if row >= len(g.rows) {
+		return 0, ganttDragNone, false
+	}
+	id = g.rows[row]
+	r := g.barRect(g.origin, row, g.tasks[id])
+	if !p.In(r) {
+		return 0, ganttDragNone, false
+	}
+	slop := ganttEdgeSlop
+	if r.Dx()/3 < slop {
+		slop = r.Dx() / 3
+	}
+	switch {
+	case p.X < r.Min.X+slop:
+		return id, ganttDragStart, true
+	case p.X >= r.Max.X-slop:
+		return id, ganttDragEnd, true
+	}
+	return id, ganttDragMove, true
+}
+
+func (g *gantt) Mouse(me MouseEvent) {
+	switch {
+	case me.Down == 1:
+		g.mouseDown(me)
+	case me.Down == 2:
+		g.drag = ganttDragPan
+		g.dragStart = me.Pos
+		g.dragOrigin = g.origin
+	case me.Up != 0:
+		g.mouseUp(me)
+	case me.Down == 0 && len(me.Held) != 0:
+		g.mouseDrag(me)
+	}
+}
+
+func (g *gantt) mouseDown(me MouseEvent) {
+	g.dragStart = me.Pos
+	g.dragNow = me.Pos
+	id, part, ok := g.taskAt(me.Pos)
+	if !ok {
+		if g.selected >= 0 {
+			g.Select(-1)
+		}
+		return
+	}
+	if g.selected != id {
+		g.Select(id)
+	}
+	g.drag = part
+	g.dragTask = id
+	g.dragOrig = *g.tasks[id]
+}
+
+func (g *gantt) mouseDrag(me MouseEvent) {
+	g.dragNow = me.Pos
+	switch g.drag {
+	case ganttDragNone:
+		return
+	case ganttDragPan:
+		g.SetOrigin(g.dragOrigin.Add(-time.Duration(me.Pos.X-g.dragStart.X) * g.scale))
+		return
+	}
+
+	snap := func(t time.Time) time.Time {
+		if (me.Modifiers & Alt) != 0 {
+			return t
+		}
+		return g.snapTime(t)
+	}
+	delta := time.Duration(me.Pos.X-g.dragStart.X) * g.scale
+	minLength := 4 * g.scale
+	t := g.dragOrig
+	switch g.drag {
+	case ganttDragMove:
+		length := t.End.Sub(t.Start)
+		t.Start = snap(t.Start.Add(delta))
+		t.End = t.Start.Add(length)
+	case ganttDragStart:
+		t.Start = snap(t.Start.Add(delta))
+		if t.End.Sub(t.Start) < minLength {
+			t.Start = t.End.Add(-minLength)
+		}
+	case ganttDragEnd:
+		t.End = snap(t.End.Add(delta))
+		if t.End.Sub(t.Start) < minLength {
+			t.End = t.Start.Add(minLength)
+		}
+	}
+	*g.tasks[g.dragTask] = t
+	g.RepaintAll()
+}
+
+func (g *gantt) mouseUp(me MouseEvent) {
+	drag := g.drag
+	g.drag = ganttDragNone
+	if drag != ganttDragMove && drag != ganttDragStart && drag != ganttDragEnd {
+		return
+	}
+	t, ok := g.tasks[g.dragTask]
+	if !ok || (t.Start.Equal(g.dragOrig.Start) && t.End.Equal(g.dragOrig.End)) {
+		return
+	}
+	if g.rescheduled != nil {
+		g.rescheduled(g.dragTask)
+	}
+}
+
+// zoomAt changes the scale, keeping the time at x where it is
+func (g *gantt) zoomAt(x int, scale time.Duration) {
+	at := g.toTime(g.origin, x)
+	g.scale = clampDuration(scale, time.Minute, 7*24*time.Hour)
+	g.SetOrigin(at.Add(-time.Duration(x) * g.scale))
+}
+
+func (g *gantt) Key(ke KeyEvent) bool {
+	if ke.Up || (ke.Modifiers&Ctrl) == 0 {
+		return false
+	}
+	// as with GraphEditor, zoom around the last place the mouse was used
+	x := g.dragNow.X
+	switch ke.Key {
+	case '=':
+		g.zoomAt(x, g.scale*4/5)
+		return true
+	case '-':
+		g.zoomAt(x, g.scale*5/4+1)
+		return true
+	case '0':
+		g.zoomAt(x, ganttDefaultScale)
+		return true
+	}
+	return false
+}
+
+var (
+	ganttBackground  = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	ganttRowAlt      = color.RGBA{0xF4, 0xF4, 0xF4, 0xFF}
+	ganttRuler       = color.RGBA{0xD0, 0xD0, 0xD0, 0xFF}
+	ganttTick        = color.RGBA{0x60, 0x60, 0x60, 0xFF}
+	ganttGridLine    = color.RGBA{0xE0, 0xE0, 0xE0, 0xFF}
+	ganttBarFill     = color.RGBA{0x80, 0xA8, 0xE0, 0xFF}
+	ganttBarProgress = color.RGBA{0x40, 0x70, 0xB8, 0xFF}
+	ganttBarBorder   = color.RGBA{0x40, 0x40, 0x40, 0xFF}
+	ganttSelected    = color.RGBA{0x30, 0x70, 0xD0, 0xFF}
+	ganttDependency  = color.RGBA{0x50, 0x50, 0x50, 0xFF}
+	ganttViolation   = color.RGBA{0xD0, 0x20, 0x20, 0xFF}
+)
+
+func (g *gantt) Paint(cliprect image.Rectangle) image.Image {
+	img := image.NewRGBA(cliprect)
+	g.paint(img, g.origin, true)
+	return img
+}
+
+func (g *gantt) Export() *image.RGBA {
+	if len(g.rows) == 0 {
+		return nil
+	}
+	first := g.tasks[g.rows[0]].Start
+	last := g.tasks[g.rows[0]].End
+	for _, t := range g.tasks {
+		if t.Start.Before(first) {
+			first = t.Start
+		}
+		if t.End.After(last) {
+			last = t.End
+		}
+	}
+	origin := first.Add(-ganttExportMargin * g.scale)
+	width := g.toX(origin, last) + ganttExportMargin
+	height := ganttRulerHeight + len(g.rows)*ganttRowHeight
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	g.paint(img, origin, false)
+	return img
+}
+
+// paint draws the part of the chart covered by img.Rect with origin at x == 0; Export() doesn't show the selection
+func (g *gantt) paint(img *image.RGBA, origin time.Time, showSelection bool) {
+	r := img.Rect
+	draw.Draw(img, r, &image.Uniform{ganttBackground}, image.ZP, draw.Src)
+	for i := 1; i < len(g.rows); i += 2 {
+		row := image.Rect(r.Min.X, ganttRulerHeight+i*ganttRowHeight, r.Max.X, ganttRulerHeight+(i+1)*ganttRowHeight)
+		draw.Draw(img, row, &image.Uniform{ganttRowAlt}, image.ZP, draw.Src)
+	}
+
+	// ruler, with major ticks continued down as grid lines
+	draw.Draw(img, image.Rect(r.Min.X, 0, r.Max.X, ganttRulerHeight), &image.Uniform{ganttRuler}, image.ZP, draw.Src)
+	g.ticks(g.toTime(origin, r.Min.X-1), g.toTime(origin, r.Max.X+1), func(t time.Time, major bool) {
+		x := g.toX(origin, t)
+		h := ganttRulerHeight / 3
+		if major {
+			h = ganttRulerHeight * 2 / 3
+			draw.Draw(img, image.Rect(x, ganttRulerHeight, x+1, r.Max.Y), &image.Uniform{ganttGridLine}, image.ZP, draw.Src)
+		}
+		draw.Draw(img, image.Rect(x, ganttRulerHeight-h, x+1, ganttRulerHeight), &image.Uniform{ganttTick}, image.ZP, draw.Src)
+	})
+	draw.Draw(img, image.Rect(r.Min.X, ganttRulerHeight-1, r.Max.X, ganttRulerHeight), &image.Uniform{ganttTick}, image.ZP, draw.Src)
+
+	for _, d := range g.deps {
+		g.drawDependency(img, origin, d)
+	}
+	for row, id := range g.rows {
+		g.drawTask(img, origin, row, g.tasks[id], showSelection && id == g.selected)
+	}
+}
+
+func (g *gantt) drawTask(img *image.RGBA, origin time.Time, row int, t *GanttTask, selected bool) {
+	r := g.barRect(origin, row, t)
+	if t.Label != nil {
+		lr := t.Label.Rect.Sub(t.Label.Rect.Min).Add(image.Pt(r.Max.X+4, r.Min.Y))
+		draw.Draw(img, lr, t.Label, t.Label.Rect.Min, draw.Over)
+	}
+	if !r.Overlaps(img.Rect) {
+		return
+	}
+	draw.Draw(img, r, &image.Uniform{ganttBarFill}, image.ZP, draw.Src)
+	if t.Progress > 0 {
+		p := r
+		p.Max.X = r.Min.X + int(float64(r.Dx())*clampFloat(t.Progress, 0, 1))
+		draw.Draw(img, p, &image.Uniform{ganttBarProgress}, image.ZP, draw.Src)
+	}
+	if selected {
+		strokeRect(img, r, 2, ganttSelected)
+	} else {
+		strokeRect(img, r, 1, ganttBarBorder)
+	}
+}
+
+// dependencies go right from the end of From, down or up to To's row, and right into the start of To
+func (g *gantt) drawDependency(img *image.RGBA, origin time.Time, d GanttDependency) {
+	from, to := g.tasks[d.From], g.tasks[d.To]
+	fr := g.barRect(origin, g.rowOf(d.From), from)
+	tr := g.barRect(origin, g.rowOf(d.To), to)
+	c := ganttDependency
+	if to.Start.Before(from.End) {
+		c = ganttViolation
+	}
+	p0 := image.Pt(fr.Max.X, (fr.Min.Y+fr.Max.Y)/2)
+	p1 := image.Pt(p0.X+ganttBarInset, p0.Y)
+	p3 := image.Pt(tr.Min.X, (tr.Min.Y+tr.Max.Y)/2)
+	p2 := image.Pt(p1.X, p3.Y)
+	drawLine(img, p0, p1, c)
+	drawLine(img, p1, p2, c)
+	drawLine(img, p2, p3, c)
+	// arrowhead
+	for i := 0; i < 4; i++ {
+		draw.Draw(img, image.Rect(p3.X-4+i, p3.Y-3+i, p3.X-3+i, p3.Y+5-i), &image.Uniform{c}, image.ZP, draw.Src)
+	}
+}
+
+func clampFloat(f float64, min float64, max float64) float64 {
+	if f < min {
+		return min
+	}
+	if f > max {
+		return max
+	}
+	return f
+}
@@ -0,0 +1,75 @@
+// 15 october 2026
+
+package ui
+
+// GLArea is a Control that shows a drawing made with OpenGL, for visualizations and games that need more than drawing images into an Area can give.
+// Like an Area, a GLArea has an explicit size in pixels that it prefers to be in layouts, and is otherwise blank; unlike an Area, it has no scrollbars and takes no keyboard or mouse events.
+//
+// package ui only makes the OpenGL context and shows the result; call OpenGL with a binding package of your choice (such as github.com/go-gl/gl), and load its functions from a GLAreaHandler method, where the context is current.
+// The context is the most recent version of OpenGL the system gives without asking for a particular one: a core profile of version 3.2 or newer on GTK+ and Mac OS X, and a compatibility profile on Windows.
+// The default framebuffer is double-buffered, with 8 bits per color channel, a 24-bit depth buffer, and an 8-bit stencil buffer.
+//
+// On GTK+, GLArea needs GTK+ 3.16 or newer, and is a GtkGLArea; on Windows, it is a window with a WGL context; on Mac OS X, it is an NSOpenGLView.
+type GLArea interface {
+	Control
+
+	// SetSize sets the size the GLArea asks to be in layouts, as with Area.SetSize.
+	// It panics if width or height is zero or negative.
+	SetSize(width int, height int)
+
+	// Repaint has the GLArea call Render again soon.
+	// Call it whenever what the GLArea shows changes; for animation, call it from Render itself, and the GLArea will render as often as the system draws (usually at the display's refresh rate).
+	Repaint()
+}
+
+// GLAreaHandler draws the contents of a GLArea.
+// Both methods are called on the main goroutine with the GLArea's OpenGL context current.
+type GLAreaHandler interface {
+	// Resized is called before the first Render and whenever the size of the GLArea changes after that, with the new size of the default framebuffer, which is in device pixels; pass them to glViewport.
+	// On HiDPI displays, this is larger than the GLArea's size in layouts by Area.PixelRatio.
+	Resized(width int, height int)
+
+	// Render draws the GLArea into the default framebuffer, or into a framebuffer object it is then copied from on GTK+.
+	// The buffers are swapped after Render returns; do not swap them yourself.
+	Render()
+}
+
+// NewGLArea creates a new GLArea with the given size and handler.
+// It panics if handler is nil or if width or height is zero or negative.
+func NewGLArea(width int, height int, handler GLAreaHandler) GLArea {
+	checkAreaSize(width, height, "NewGLArea()")
+	if handler == nil {
+		panic("handler passed to NewGLArea() must not be nil")
+	}
+	return newGLArea(&glareabase{
+		width:   width,
+		height:  height,
+		handler: handler,
+	})
+}
+
+type glareabase struct {
+	width   int
+	height  int
+	handler GLAreaHandler
+
+	// the framebuffer size last given to Resized(); see render()
+	fbwidth  int
+	fbheight int
+}
+
+// internal function, but shared by all system implementations: the backends call this to draw, with the framebuffer size and the context current, and swap buffers after
+// not every system says when the framebuffer changes size before the first draw, so check here instead
+func (g *glareabase) render(width int, height int) {
+	if width != g.fbwidth || height != g.fbheight {
+		g.fbwidth = width
+		g.fbheight = height
+		g.handler.Resized(width, height)
+	}
+	g.handler.Render()
+}
+
+func (g *glareabase) xpreferredSize(d *sizing) (width, height int) {
+	// as with Area, the preferred size is the size
+	return g.width, g.height
+}
@@ -0,0 +1,44 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"unsafe"
+)
+
+// #include "objc_darwin.h"
+import "C"
+
+type glarea struct {
+	*glareabase
+	*controlSingleObject
+}
+
+func newGLArea(gb *glareabase) GLArea {
+	g := &glarea{
+		glareabase: gb,
+	}
+	id := C.newGLArea(unsafe.Pointer(g))
+	if id == nil {
+		panic("no OpenGL pixel format for GLArea (is OpenGL 3.2 supported?)")
+	}
+	g.controlSingleObject = newControlSingleObject(id)
+	g.fpreferredSize = g.glareabase.xpreferredSize // controlSingleObject has one too, so say which
+	return g
+}
+
+func (g *glarea) SetSize(width int, height int) {
+	checkAreaSize(width, height, "GLArea.SetSize()")
+	g.width = width
+	g.height = height
+}
+
+func (g *glarea) Repaint() {
+	C.glAreaRepaint(g.id)
+}
+
+//export glAreaRender
+func glAreaRender(data unsafe.Pointer, width C.intptr_t, height C.intptr_t) {
+	g := (*glarea)(data)
+	g.render(int(width), int(height))
+}
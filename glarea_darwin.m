@@ -0,0 +1,70 @@
+// 15 october 2026
+
+#import "objc_darwin.h"
+#import "_cgo_export.h"
+#import <Cocoa/Cocoa.h>
+
+#define toNSView(x) ((NSView *) (x))
+
+@interface goGLView : NSOpenGLView {
+@public
+	void *goglarea;
+}
+@end
+
+@implementation goGLView
+
+// NSOpenGLView makes the context current and updates it for the new size before this; we only have to draw and flush
+- (void)drawRect:(NSRect)r
+{
+	NSSize size;
+
+	[[self openGLContext] makeCurrentContext];
+	size = [self convertRectToBacking:[self bounds]].size;
+	glAreaRender(self->goglarea, (intptr_t) size.width, (intptr_t) size.height);
+	// flushing a double-buffered context swaps the buffers
+	[[self openGLContext] flushBuffer];
+}
+
+- (BOOL)isOpaque
+{
+	return YES;
+}
+
+@end
+
+id newGLArea(void *glarea)
+{
+	NSOpenGLPixelFormatAttribute attrs[] = {
+		NSOpenGLPFADoubleBuffer,
+		NSOpenGLPFAColorSize, 24,
+		NSOpenGLPFAAlphaSize, 8,
+		NSOpenGLPFADepthSize, 24,
+		NSOpenGLPFAStencilSize, 8,
+		NSOpenGLPFAOpenGLProfile, NSOpenGLProfileVersion3_2Core,
+		0,
+	};
+	NSOpenGLPixelFormat *format;
+	goGLView *v;
+
+	format = [[NSOpenGLPixelFormat alloc] initWithAttributes:attrs];
+	if (format == nil)
+		return nil;
+	v = [[goGLView alloc] initWithFrame:NSZeroRect pixelFormat:format];
+	[format release];
+	v->goglarea = glarea;
+	// without this, the framebuffer is in points, which is blurry on Retina displays
+	[v setWantsBestResolutionOpenGLSurface:YES];
+	return (id) v;
+}
+
+// AppKit can drop a -setNeedsDisplay: made during -drawRect:, which is where animations call Repaint() from, so wait until drawing is over
+// the block retains the view, so it is still there when the block runs
+void glAreaRepaint(id glarea)
+{
+	NSView *v = toNSView(glarea);
+
+	dispatch_async(dispatch_get_main_queue(), ^{
+		[v setNeedsDisplay:YES];
+	});
+}
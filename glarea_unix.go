@@ -0,0 +1,65 @@
+// +build !windows,!darwin
+
+// 15 october 2026
+
+package ui
+
+import (
+	"unsafe"
+)
+
+// #include "gtk_unix.h"
+// extern gboolean glAreaRender(GtkGLArea *, GdkGLContext *, gpointer);
+import "C"
+
+type glarea struct {
+	*glareabase
+	*controlSingleWidget
+	glarea *C.GtkGLArea
+}
+
+func newGLArea(gb *glareabase) GLArea {
+	widget := C.gtk_gl_area_new()
+	g := &glarea{
+		glareabase:          gb,
+		controlSingleWidget: newControlSingleWidget(widget),
+		glarea:              (*C.GtkGLArea)(unsafe.Pointer(widget)),
+	}
+	g.fpreferredSize = g.glareabase.xpreferredSize // controlSingleWidget has one too, so say which
+	C.gtk_gl_area_set_has_depth_buffer(g.glarea, C.TRUE)
+	C.gtk_gl_area_set_has_stencil_buffer(g.glarea, C.TRUE)
+	// GtkGLArea makes its context current before render
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(widget)),
+		"render",
+		C.GCallback(C.glAreaRender),
+		C.gpointer(unsafe.Pointer(g)))
+	g.SetSize(g.width, g.height)
+	return g
+}
+
+func (g *glarea) SetSize(width int, height int) {
+	checkAreaSize(width, height, "GLArea.SetSize()")
+	g.width = width
+	g.height = height
+	C.gtk_widget_set_size_request(g.widget, C.gint(g.width), C.gint(g.height))
+}
+
+func (g *glarea) Repaint() {
+	C.gtk_gl_area_queue_render(g.glarea)
+}
+
+//export glAreaRender
+func glAreaRender(area *C.GtkGLArea, context *C.GdkGLContext, data C.gpointer) C.gboolean {
+	g := (*glarea)(unsafe.Pointer(data))
+	if C.gtk_gl_area_get_error(area) != nil {
+		// GtkGLArea shows the error itself
+		return C.FALSE
+	}
+	// the framebuffer GtkGLArea draws into is in device pixels
+	scale := C.gtk_widget_get_scale_factor(g.widget)
+	g.render(
+		int(C.gtk_widget_get_allocated_width(g.widget)*scale),
+		int(C.gtk_widget_get_allocated_height(g.widget)*scale))
+	return C.TRUE // don't let the default handler draw too; GtkGLArea shows the result itself
+}
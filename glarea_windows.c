@@ -0,0 +1,120 @@
+// 15 october 2026
+
+#include "winapi_windows.h"
+#include "_cgo_export.h"
+
+// the HGLRC is kept in the window's extra bytes; CS_OWNDC gives the window one DC for its whole life, which the pixel format is set on, so GetDC() always returns it
+#define glAreaContext(hwnd) ((HGLRC) GetWindowLongPtrW((hwnd), 0))
+
+static void renderGLArea(HWND hwnd, void *data)
+{
+	PAINTSTRUCT ps;
+	HDC dc;
+	RECT r;
+
+	dc = BeginPaint(hwnd, &ps);
+	if (dc == NULL)
+		xpanic("error beginning GLArea repaint", GetLastError());
+	if (wglMakeCurrent(dc, glAreaContext(hwnd)) == FALSE)
+		xpanic("error making GLArea OpenGL context current", GetLastError());
+	if (GetClientRect(hwnd, &r) == 0)
+		xpanic("error getting GLArea client rect", GetLastError());
+	glAreaRender(data, (intptr_t) (r.right - r.left), (intptr_t) (r.bottom - r.top));
+	if (SwapBuffers(dc) == FALSE)
+		xpanic("error swapping GLArea buffers", GetLastError());
+	EndPaint(hwnd, &ps);
+}
+
+static LRESULT CALLBACK glAreaWndProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM lParam)
+{
+	void *data;
+	LRESULT lResult;
+	HGLRC context;
+
+	data = getWindowData(hwnd, uMsg, wParam, lParam, &lResult);
+	if (data == NULL)
+		return lResult;
+	switch (uMsg) {
+	case WM_PAINT:
+		renderGLArea(hwnd, data);
+		return 0;
+	case WM_ERASEBKGND:
+		// OpenGL draws the whole window; erasing would only flicker
+		return 1;
+	case WM_DESTROY:
+		context = glAreaContext(hwnd);
+		if (wglGetCurrentContext() == context)
+			wglMakeCurrent(NULL, NULL);
+		if (wglDeleteContext(context) == FALSE)
+			xpanic("error deleting GLArea OpenGL context", GetLastError());
+		return 0;
+	default:
+		return DefWindowProcW(hwnd, uMsg, wParam, lParam);
+	}
+	xmissedmsg("GLArea", "glAreaWndProc()", uMsg);
+	return 0;			// unreached
+}
+
+DWORD makeGLAreaWindowClass(char **errmsg)
+{
+	WNDCLASSW wc;
+
+	ZeroMemory(&wc, sizeof (WNDCLASSW));
+	wc.style = CS_OWNDC | CS_HREDRAW | CS_VREDRAW;
+	wc.lpszClassName = glAreaWindowClass;
+	wc.lpfnWndProc = glAreaWndProc;
+	wc.hInstance = hInstance;
+	wc.hIcon = hDefaultIcon;
+	wc.hCursor = hArrowCursor;
+	wc.hbrBackground = NULL;				// no brush; we handle WM_ERASEBKGND
+	wc.cbWndExtra = sizeof (LONG_PTR);		// OpenGL context
+	if (RegisterClassW(&wc) == 0) {
+		*errmsg = "error registering GLArea window class";
+		return GetLastError();
+	}
+	return 0;
+}
+
+HWND newGLArea(void *data)
+{
+	HWND hwnd;
+	HDC dc;
+	PIXELFORMATDESCRIPTOR pfd;
+	int format;
+	HGLRC context;
+
+	hwnd = CreateWindowExW(
+		0,
+		glAreaWindowClass, L"",
+		// OpenGL needs these so that sibling and child windows aren't drawn over
+		WS_CHILD | WS_VISIBLE | WS_CLIPCHILDREN | WS_CLIPSIBLINGS,
+		CW_USEDEFAULT, CW_USEDEFAULT,
+		100, 100,
+		msgwin, NULL, hInstance, data);
+	if (hwnd == NULL)
+		xpanic("error creating GLArea", GetLastError());
+	dc = GetDC(hwnd);
+	if (dc == NULL)
+		xpanic("error getting GLArea DC", GetLastError());
+	ZeroMemory(&pfd, sizeof (PIXELFORMATDESCRIPTOR));
+	pfd.nSize = sizeof (PIXELFORMATDESCRIPTOR);
+	pfd.nVersion = 1;
+	pfd.dwFlags = PFD_DRAW_TO_WINDOW | PFD_SUPPORT_OPENGL | PFD_DOUBLEBUFFER;
+	pfd.iPixelType = PFD_TYPE_RGBA;
+	pfd.cColorBits = 32;
+	pfd.cAlphaBits = 8;
+	pfd.cDepthBits = 24;
+	pfd.cStencilBits = 8;
+	pfd.iLayerType = PFD_MAIN_PLANE;
+	format = ChoosePixelFormat(dc, &pfd);
+	if (format == 0)
+		xpanic("error choosing GLArea pixel format", GetLastError());
+	if (SetPixelFormat(dc, format, &pfd) == FALSE)
+		xpanic("error setting GLArea pixel format", GetLastError());
+	context = wglCreateContext(dc);
+	if (context == NULL)
+		xpanic("error creating GLArea OpenGL context", GetLastError());
+	SetWindowLongPtrW(hwnd, 0, (LONG_PTR) context);
+	ReleaseDC(hwnd, dc);			// does nothing for CS_OWNDC, but is harmless
+	return hwnd;
+}
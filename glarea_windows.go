@@ -0,0 +1,57 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// #include "winapi_windows.h"
+import "C"
+
+type glarea struct {
+	*glareabase
+	*controlSingleHWND
+}
+
+func makeGLAreaWindowClass() error {
+	var errmsg *C.char
+
+	err := C.makeGLAreaWindowClass(&errmsg)
+	if err != 0 || errmsg != nil {
+		return fmt.Errorf("%s: %v", C.GoString(errmsg), syscall.Errno(err))
+	}
+	return nil
+}
+
+func newGLArea(gb *glareabase) GLArea {
+	g := &glarea{
+		glareabase: gb,
+	}
+	g.controlSingleHWND = newControlSingleHWND(C.newGLArea(unsafe.Pointer(g)))
+	g.fpreferredSize = g.glareabase.xpreferredSize
+	// it takes no keyboard input, so keep Tab from stopping on it
+	g.fnTabStops = func() int {
+		return 0
+	}
+	g.fsetFocus = nil
+	return g
+}
+
+func (g *glarea) SetSize(width int, height int) {
+	checkAreaSize(width, height, "GLArea.SetSize()")
+	g.width = width
+	g.height = height
+}
+
+func (g *glarea) Repaint() {
+	C.InvalidateRect(g.hwnd, nil, C.FALSE)
+}
+
+//export glAreaRender
+func glAreaRender(data unsafe.Pointer, width C.intptr_t, height C.intptr_t) {
+	g := (*glarea)(data)
+	g.render(int(width), int(height))
+}
@@ -0,0 +1,699 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// GraphNode is a node of a Graph: a box with input ports on its left edge and output ports on its right edge.
+type GraphNode struct {
+	// Pos is the top-left corner of the node in graph coordinates.
+	Pos image.Point
+
+	// Size is the size of the node in graph coordinates.
+	// If either dimension is zero, a default based on the number of ports is used.
+	Size image.Point
+
+	Inputs  int
+	Outputs int
+
+	// Label, if not nil, is drawn in the top-left corner of the node.
+	// Graph draws no text of its own, so render the node's title into an image yourself, for instance with a TextLayout.
+	Label *image.RGBA
+}
+
+// GraphPort identifies an input or output port of a node by the node's ID and the index of the port.
+type GraphPort struct {
+	Node  int
+	Index int
+}
+
+// GraphConnection connects an output port of one node to an input port of another.
+// An input can only have one connection; an output can have any number.
+type GraphConnection struct {
+	From GraphPort // an output
+	To   GraphPort // an input
+}
+
+// GraphChangeKind says what changed in a GraphChange.
+type GraphChangeKind uint
+
+const (
+	GraphNodeAdded GraphChangeKind = iota
+	GraphNodeRemoved
+	GraphNodeMoved
+	GraphConnected
+	GraphDisconnected
+)
+
+// GraphChange describes a change to a Graph.
+// Node is set for the node changes; Connection is set for GraphConnected and GraphDisconnected.
+type GraphChange struct {
+	Kind       GraphChangeKind
+	Node       int
+	Connection GraphConnection
+}
+
+// Graph is the model of a GraphEditor: a set of nodes, identified by integer IDs, and the connections between them.
+// As with Area, a Graph shown in a GraphEditor must only be changed on the main goroutine; use Do() to change it from elsewhere.
+type Graph struct {
+	nodes map[int]*GraphNode
+	order []int // drawing order; last is on top
+	conns []GraphConnection
+	next  int
+
+	changed  func(c GraphChange)
+	watchers []func(c GraphChange) // GraphEditors showing this Graph
+}
+
+// NewGraph returns a new, empty Graph.
+func NewGraph() *Graph {
+	return &Graph{
+		nodes: make(map[int]*GraphNode),
+	}
+}
+
+// OnChanged registers a function that is called after each change to the Graph, whether made by the program or by the user in a GraphEditor.
+func (g *Graph) OnChanged(f func(c GraphChange)) {
+	g.changed = f
+}
+
+func (g *Graph) notify(c GraphChange) {
+	for _, w := range g.watchers {
+		w(c)
+	}
+	if g.changed != nil {
+		g.changed(c)
+	}
+}
+
+func (g *Graph) node(id int, what string) *GraphNode {
+	n, ok := g.nodes[id]
+	if !ok {
+		panic(fmt.Errorf("no node with ID %d in Graph.%s()", id, what))
+	}
+	return n
+}
+
+// AddNode adds a node on top of all the others and returns its ID.
+// IDs are never reused.
+func (g *Graph) AddNode(n GraphNode) (id int) {
+	id = g.next
+	g.next++
+	nn := n
+	g.nodes[id] = &nn
+	g.order = append(g.order, id)
+	g.notify(GraphChange{Kind: GraphNodeAdded, Node: id})
+	return id
+}
+
+// RemoveNode removes a node and all its connections.
+// It panics if there is no such node.
+func (g *Graph) RemoveNode(id int) {
+	g.node(id, "RemoveNode")
+	for i := 0; i < len(g.conns); {
+		if c := g.conns[i]; c.From.Node == id || c.To.Node == id {
+			g.conns = append(g.conns[:i], g.conns[i+1:]...)
+			g.notify(GraphChange{Kind: GraphDisconnected, Connection: c})
+			continue
+		}
+		i++
+	}
+	delete(g.nodes, id)
+	for i, o := range g.order {
+		if o == id {
+			g.order = append(g.order[:i], g.order[i+1:]...)
+			break
+		}
+	}
+	g.notify(GraphChange{Kind: GraphNodeRemoved, Node: id})
+}
+
+// Node returns a copy of the given node.
+// It panics if there is no such node.
+func (g *Graph) Node(id int) GraphNode {
+	return *g.node(id, "Node")
+}
+
+// Nodes returns the IDs of all the nodes, bottommost first.
+func (g *Graph) Nodes() []int {
+	return append([]int(nil), g.order...)
+}
+
+// MoveNode moves a node so its top-left corner is at pos.
+// It panics if there is no such node.
+func (g *Graph) MoveNode(id int, pos image.Point) {
+	n := g.node(id, "MoveNode")
+	if n.Pos == pos {
+		return
+	}
+	n.Pos = pos
+	g.notify(GraphChange{Kind: GraphNodeMoved, Node: id})
+}
+
+// Connect adds a connection, replacing any connection already going into the same input.
+// It does nothing if the connection already exists.
+// It panics if either port does not exist.
+func (g *Graph) Connect(c GraphConnection) {
+	from := g.node(c.From.Node, "Connect")
+	to := g.node(c.To.Node, "Connect")
+	if c.From.Index < 0 || c.From.Index >= from.Outputs {
+		panic(fmt.Errorf("output %d out of range in Graph.Connect()", c.From.Index))
+	}
+	if c.To.Index < 0 || c.To.Index >= to.Inputs {
+		panic(fmt.Errorf("input %d out of range in Graph.Connect()", c.To.Index))
+	}
+	for i, old := range g.conns {
+		if old == c {
+			return
+		}
+		if old.To == c.To {
+			g.conns = append(g.conns[:i], g.conns[i+1:]...)
+			g.notify(GraphChange{Kind: GraphDisconnected, Connection: old})
+			break
+		}
+	}
+	g.conns = append(g.conns, c)
+	g.notify(GraphChange{Kind: GraphConnected, Connection: c})
+}
+
+// Disconnect removes a connection.
+// It does nothing if there is no such connection.
+func (g *Graph) Disconnect(c GraphConnection) {
+	for i, old := range g.conns {
+		if old == c {
+			g.conns = append(g.conns[:i], g.conns[i+1:]...)
+			g.notify(GraphChange{Kind: GraphDisconnected, Connection: c})
+			return
+		}
+	}
+}
+
+// Connections returns all the connections.
+func (g *Graph) Connections() []GraphConnection {
+	return append([]GraphConnection(nil), g.conns...)
+}
+
+// raise moves a node to the top of the drawing order; this isn't a change to the model, so it isn't reported
+func (g *Graph) raise(id int) {
+	for i, o := range g.order {
+		if o == id {
+			g.order = append(append(g.order[:i], g.order[i+1:]...), id)
+			return
+		}
+	}
+}
+
+const (
+	graphPortSpacing = 20
+	graphPortRadius  = 5
+	graphMinWidth    = 120
+	graphMinHeight   = 60
+)
+
+func (n *GraphNode) size() image.Point {
+	s := n.Size
+	if s.X == 0 {
+		s.X = graphMinWidth
+	}
+	if s.Y == 0 {
+		ports := n.Inputs
+		if n.Outputs > ports {
+			ports = n.Outputs
+		}
+		s.Y = (ports + 1) * graphPortSpacing
+		if s.Y < graphMinHeight {
+			s.Y = graphMinHeight
+		}
+	}
+	return s
+}
+
+// portPos returns the graph coordinates of the center of a port
+func (n *GraphNode) portPos(index int, output bool, count int) image.Point {
+	s := n.size()
+	p := image.Pt(n.Pos.X, n.Pos.Y+(index+1)*s.Y/(count+1))
+	if output {
+		p.X += s.X
+	}
+	return p
+}
+
+// GraphEditor is an Area that shows a Graph and lets the user edit it.
+//
+// The user can drag nodes around (Shift-click adds to or removes from the selection), drag from an output port to an input port to connect them, drag from an input port to move or remove its connection, drag a rectangle around nodes to select them, and press Delete to remove the selected nodes.
+// Dragging with the middle mouse button pans the view; Ctrl with + (or =), -, and 0 zooms in, zooms out, and resets the zoom.
+//
+// Do not call SetSize expecting to resize the graph; it only resizes the view, which has no bounds in graph coordinates.
+type GraphEditor interface {
+	Area
+
+	// Graph returns the Graph passed to NewGraphEditor.
+	Graph() *Graph
+
+	// Selected returns the IDs of the selected nodes; Select replaces the selection.
+	Selected() []int
+	Select(ids []int)
+
+	// OnSelected is an event that gets triggered after the selection changes in whatever way.
+	OnSelected(f func())
+
+	// Zoom and SetZoom get and set the scale factor from graph coordinates to Area coordinates.
+	// SetZoom clamps the zoom to the range [0.25,4].
+	Zoom() float64
+	SetZoom(zoom float64)
+
+	// Pan and SetPan get and set the position in the Area of graph coordinate (0,0).
+	Pan() image.Point
+	SetPan(p image.Point)
+}
+
+type graphDrag uint
+
+const (
+	graphDragNone graphDrag = iota
+	graphDragNodes
+	graphDragConnection
+	graphDragMarquee
+	graphDragPan
+)
+
+type graphEditor struct {
+	Area
+	g *Graph
+
+	selected   map[int]bool
+	onselected *event
+	zoom       float64
+	pan        image.Point
+
+	drag      graphDrag
+	dragStart image.Point // Area coordinates
+	dragNow   image.Point
+	dragPan   image.Point         // for graphDragPan
+	dragOrig  map[int]image.Point // for graphDragNodes
+	dragFrom  GraphPort           // for graphDragConnection
+}
+
+// NewGraphEditor creates a new GraphEditor of the given size showing the given Graph.
+func NewGraphEditor(width int, height int, g *Graph) GraphEditor {
+	if g == nil {
+		panic("Graph passed to NewGraphEditor() cannot be nil")
+	}
+	e := &graphEditor{
+		g:          g,
+		selected:   make(map[int]bool),
+		onselected: newEvent(),
+		zoom:       1,
+	}
+	e.Area = NewArea(width, height, e)
+	g.watchers = append(g.watchers, e.graphChanged)
+	return e
+}
+
+func (e *graphEditor) graphChanged(c GraphChange) {
+	if c.Kind == GraphNodeRemoved && e.selected[c.Node] {
+		delete(e.selected, c.Node)
+		e.onselected.fire()
+	}
+	e.RepaintAll()
+}
+
+func (e *graphEditor) Graph() *Graph {
+	return e.g
+}
+
+func (e *graphEditor) Selected() []int {
+	var ids []int
+
+	for _, id := range e.g.order {
+		if e.selected[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (e *graphEditor) Select(ids []int) {
+	e.selected = make(map[int]bool)
+	for _, id := range ids {
+		e.g.node(id, "Select")
+		e.selected[id] = true
+	}
+	e.onselected.fire()
+	e.RepaintAll()
+}
+
+func (e *graphEditor) OnSelected(f func()) {
+	e.onselected.set(f)
+}
+
+func (e *graphEditor) Zoom() float64 {
+	return e.zoom
+}
+
+func (e *graphEditor) SetZoom(zoom float64) {
+	e.zoom = math.Max(0.25, math.Min(4, zoom))
+	e.RepaintAll()
+}
+
+func (e *graphEditor) Pan() image.Point {
+	return e.pan
+}
+
+func (e *graphEditor) SetPan(p image.Point) {
+	e.pan = p
+	e.RepaintAll()
+}
+
+func (e *graphEditor) toArea(p image.Point) image.Point {
+	return image.Pt(
+		int(math.Floor(float64(p.X)*e.zoom))+e.pan.X,
+		int(math.Floor(float64(p.Y)*e.zoom))+e.pan.Y)
+}
+
+func (e *graphEditor) toGraph(p image.Point) image.Point {
+	return image.Pt(
+		int(math.Floor(float64(p.X-e.pan.X)/e.zoom)),
+		int(math.Floor(float64(p.Y-e.pan.Y)/e.zoom)))
+}
+
+func (e *graphEditor) nodeRect(n *GraphNode) image.Rectangle {
+	return image.Rectangle{e.toArea(n.Pos), e.toArea(n.Pos.Add(n.size()))}
+}
+
+// zoomAt changes the zoom, keeping the graph point under p (in Area coordinates) where it is
+func (e *graphEditor) zoomAt(p image.Point, zoom float64) {
+	gx := float64(p.X-e.pan.X) / e.zoom
+	gy := float64(p.Y-e.pan.Y) / e.zoom
+	e.zoom = math.Max(0.25, math.Min(4, zoom))
+	e.pan = image.Pt(p.X-int(gx*e.zoom), p.Y-int(gy*e.zoom))
+	e.RepaintAll()
+}
+
+// hit testing; all take Area coordinates and go from the top node down
+
+func (e *graphEditor) nodeAt(p image.Point) (id int, ok bool) {
+	for i := len(e.g.order) - 1; i >= 0; i-- {
+		id := e.g.order[i]
+		if p.In(e.nodeRect(e.g.nodes[id])) {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func (e *graphEditor) portAt(p image.Point) (port GraphPort, output bool, ok bool) {
+	r := int(math.Ceil(graphPortRadius*e.zoom)) + 2 // a bit of slack
+	near := func(q image.Point) bool {
+		d := p.Sub(q)
+		return d.X*d.X+d.Y*d.Y <= r*r
+	}
+	for i := len(e.g.order) - 1; i >= 0; i-- {
+		id := e.g.order[i]
+		n := e.g.nodes[id]
+		for j := 0; j < n.Outputs; j++ {
+			if near(e.toArea(n.portPos(j, true, n.Outputs))) {
+				return GraphPort{id, j}, true, true
+			}
+		}
+		for j := 0; j < n.Inputs; j++ {
+			if near(e.toArea(n.portPos(j, false, n.Inputs))) {
+				return GraphPort{id, j}, false, true
+			}
+		}
+	}
+	return GraphPort{}, false, false
+}
+
+func (e *graphEditor) marquee() image.Rectangle {
+	return image.Rectangle{e.dragStart, e.dragNow}.Canon()
+}
+
+func (e *graphEditor) Mouse(me MouseEvent) {
+	switch {
+	case me.Down == 1:
+		e.mouseDown(me)
+	case me.Down == 2:
+		e.drag = graphDragPan
+		e.dragStart = me.Pos
+		e.dragPan = e.pan
+	case me.Up != 0:
+		e.mouseUp(me)
+	case me.Down == 0 && len(me.Held) != 0:
+		e.mouseDrag(me)
+	}
+}
+
+func (e *graphEditor) mouseDown(me MouseEvent) {
+	e.dragStart = me.Pos
+	e.dragNow = me.Pos
+	if port, output, ok := e.portAt(me.Pos); ok {
+		if output {
+			e.drag = graphDragConnection
+			e.dragFrom = port
+			return
+		}
+		// dragging from a connected input picks up its connection
+		for _, c := range e.g.conns {
+			if c.To == port {
+				e.g.Disconnect(c)
+				e.drag = graphDragConnection
+				e.dragFrom = c.From
+				return
+			}
+		}
+	}
+	id, ok := e.nodeAt(me.Pos)
+	if !ok {
+		if (me.Modifiers & Shift) == 0 {
+			e.selected = make(map[int]bool)
+			e.onselected.fire()
+		}
+		e.drag = graphDragMarquee
+		e.RepaintAll()
+		return
+	}
+	switch {
+	case (me.Modifiers & Shift) != 0:
+		e.selected[id] = !e.selected[id]
+		if !e.selected[id] {
+			delete(e.selected, id)
+		}
+		e.onselected.fire()
+	case !e.selected[id]:
+		e.selected = map[int]bool{id: true}
+		e.onselected.fire()
+	}
+	e.g.raise(id)
+	e.drag = graphDragNodes
+	e.dragOrig = make(map[int]image.Point)
+	for sid := range e.selected {
+		e.dragOrig[sid] = e.g.nodes[sid].Pos
+	}
+	e.RepaintAll()
+}
+
+func (e *graphEditor) mouseDrag(me MouseEvent) {
+	e.dragNow = me.Pos
+	switch e.drag {
+	case graphDragNodes:
+		d := me.Pos.Sub(e.dragStart)
+		gd := image.Pt(int(float64(d.X)/e.zoom), int(float64(d.Y)/e.zoom))
+		for id, orig := range e.dragOrig {
+			if _, ok := e.g.nodes[id]; ok {
+				e.g.MoveNode(id, orig.Add(gd))
+			}
+		}
+	case graphDragPan:
+		e.SetPan(e.dragPan.Add(me.Pos.Sub(e.dragStart)))
+	case graphDragConnection, graphDragMarquee:
+		e.RepaintAll()
+	}
+}
+
+func (e *graphEditor) mouseUp(me MouseEvent) {
+	e.dragNow = me.Pos
+	switch e.drag {
+	case graphDragConnection:
+		if port, output, ok := e.portAt(me.Pos); ok && !output && port.Node != e.dragFrom.Node {
+			if _, ok := e.g.nodes[e.dragFrom.Node]; ok {
+				e.g.Connect(GraphConnection{From: e.dragFrom, To: port})
+			}
+		}
+	case graphDragMarquee:
+		r := e.marquee()
+		for id, n := range e.g.nodes {
+			if e.nodeRect(n).Overlaps(r) {
+				e.selected[id] = true
+			}
+		}
+		e.onselected.fire()
+	}
+	e.drag = graphDragNone
+	e.dragOrig = nil
+	e.RepaintAll()
+}
+
+func (e *graphEditor) Key(ke KeyEvent) bool {
+	if ke.Up {
+		return false
+	}
+	if ke.ExtKey == Delete || ke.Key == '\b' {
+		if len(e.selected) == 0 {
+			return false
+		}
+		for _, id := range e.Selected() {
+			e.g.RemoveNode(id)
+		}
+		return true
+	}
+	if (ke.Modifiers & Ctrl) != 0 {
+		// we don't know what part of the Area is visible, so zoom around the last place the mouse was used
+		center := e.dragNow
+		switch ke.Key {
+		case '=':
+			e.zoomAt(center, e.zoom*1.25)
+			return true
+		case '-':
+			e.zoomAt(center, e.zoom/1.25)
+			return true
+		case '0':
+			e.zoomAt(center, 1)
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	graphBackground   = color.RGBA{0xF0, 0xF0, 0xF0, 0xFF}
+	graphNodeFill     = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	graphNodeBorder   = color.RGBA{0x60, 0x60, 0x60, 0xFF}
+	graphSelected     = color.RGBA{0x30, 0x70, 0xD0, 0xFF}
+	graphMarqueeFill  = color.RGBA{0x0C, 0x1C, 0x34, 0x40} // graphSelected at 25%, alpha-premultiplied
+	graphConnectColor = color.RGBA{0x40, 0x40, 0x40, 0xFF}
+)
+
+func (e *graphEditor) Paint(cliprect image.Rectangle) image.Image {
+	img := image.NewRGBA(cliprect)
+	draw.Draw(img, cliprect, &image.Uniform{graphBackground}, image.ZP, draw.Src)
+	for _, c := range e.g.conns {
+		from := e.g.nodes[c.From.Node]
+		to := e.g.nodes[c.To.Node]
+		e.drawConnection(img,
+			e.toArea(from.portPos(c.From.Index, true, from.Outputs)),
+			e.toArea(to.portPos(c.To.Index, false, to.Inputs)),
+			graphConnectColor)
+	}
+	for _, id := range e.g.order {
+		e.drawNode(img, e.g.nodes[id], e.selected[id])
+	}
+	switch e.drag {
+	case graphDragConnection:
+		if from, ok := e.g.nodes[e.dragFrom.Node]; ok {
+			e.drawConnection(img, e.toArea(from.portPos(e.dragFrom.Index, true, from.Outputs)), e.dragNow, graphSelected)
+		}
+	case graphDragMarquee:
+		r := e.marquee()
+		draw.Draw(img, r, &image.Uniform{graphMarqueeFill}, image.ZP, draw.Over)
+		strokeRect(img, r, 1, graphSelected)
+	}
+	return img
+}
+
+func (e *graphEditor) drawNode(img *image.RGBA, n *GraphNode, selected bool) {
+	r := e.nodeRect(n)
+	if !r.Overlaps(img.Rect.Inset(-graphPortRadius * 4)) {
+		return
+	}
+	draw.Draw(img, r, &image.Uniform{graphNodeFill}, image.ZP, draw.Src)
+	if n.Label != nil {
+		lr := n.Label.Rect.Sub(n.Label.Rect.Min).Add(r.Min.Add(image.Pt(4, 4))).Intersect(r.Inset(1))
+		draw.Draw(img, lr, n.Label, n.Label.Rect.Min, draw.Over)
+	}
+	if selected {
+		strokeRect(img, r, 2, graphSelected)
+	} else {
+		strokeRect(img, r, 1, graphNodeBorder)
+	}
+	radius := int(math.Max(2, graphPortRadius*e.zoom))
+	for i := 0; i < n.Inputs; i++ {
+		fillCircle(img, e.toArea(n.portPos(i, false, n.Inputs)), radius, graphNodeBorder)
+	}
+	for i := 0; i < n.Outputs; i++ {
+		fillCircle(img, e.toArea(n.portPos(i, true, n.Outputs)), radius, graphNodeBorder)
+	}
+}
+
+// connections are cubic Bézier curves that leave outputs going right and enter inputs going right
+func (e *graphEditor) drawConnection(img *image.RGBA, from image.Point, to image.Point, c color.RGBA) {
+	const segments = 32
+
+	d := math.Max(math.Abs(float64(to.X-from.X))/2, 40*e.zoom)
+	x0, y0 := float64(from.X), float64(from.Y)
+	x1, y1 := x0+d, y0
+	x3, y3 := float64(to.X), float64(to.Y)
+	x2, y2 := x3-d, y3
+	prev := from
+	for i := 1; i <= segments; i++ {
+		t := float64(i) / segments
+		u := 1 - t
+		x := u*u*u*x0 + 3*u*u*t*x1 + 3*u*t*t*x2 + t*t*t*x3
+		y := u*u*u*y0 + 3*u*u*t*y1 + 3*u*t*t*y2 + t*t*t*y3
+		p := image.Pt(int(x), int(y))
+		drawLine(img, prev, p, c)
+		prev = p
+	}
+}
+
+// these are deliberately simple; all of them rely on image.RGBA ignoring points outside its bounds
+
+func drawLine(img *image.RGBA, from image.Point, to image.Point, c color.RGBA) {
+	d := to.Sub(from)
+	steps := d.X
+	if steps < 0 {
+		steps = -steps
+	}
+	if d.Y > steps || -d.Y > steps {
+		steps = d.Y
+		if steps < 0 {
+			steps = -steps
+		}
+	}
+	if steps == 0 {
+		steps = 1
+	}
+	for i := 0; i <= steps; i++ {
+		x := from.X + d.X*i/steps
+		y := from.Y + d.Y*i/steps
+		// 2 pixels thick
+		img.SetRGBA(x, y, c)
+		img.SetRGBA(x+1, y, c)
+		img.SetRGBA(x, y+1, c)
+		img.SetRGBA(x+1, y+1, c)
+	}
+}
+
+func strokeRect(img *image.RGBA, r image.Rectangle, width int, c color.RGBA) {
+	u := &image.Uniform{c}
+	draw.Draw(img, image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+width), u, image.ZP, draw.Src)
+	draw.Draw(img, image.Rect(r.Min.X, r.Max.Y-width, r.Max.X, r.Max.Y), u, image.ZP, draw.Src)
+	draw.Draw(img, image.Rect(r.Min.X, r.Min.Y, r.Min.X+width, r.Max.Y), u, image.ZP, draw.Src)
+	draw.Draw(img, image.Rect(r.Max.X-width, r.Min.Y, r.Max.X, r.Max.Y), u, image.ZP, draw.Src)
+}
+
+func fillCircle(img *image.RGBA, center image.Point, radius int, c color.RGBA) {
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if x*x+y*y <= radius*radius {
+				img.SetRGBA(center.X+x, center.Y+y, c)
+			}
+		}
+	}
+}
@@ -28,6 +28,23 @@ type Grid interface {
 	// Add panics if either xspan or yspan are zero or negative.
 	Add(control Control, nextTo Control, side Side, xexpand bool, xalign Align, yexpand bool, yalign Align, xspan int, yspan int)
 
+	// Attach adds a Control to the Grid at the given column and row, counting from the top-left cell, which is (0, 0).
+	// This is easier than Add for laying out forms, where each row is a label and a field:
+	// 	g.Attach(NewLabel("Name"), 0, 0, false, RightBottom, false, Center, 1, 1)
+	// 	g.Attach(nameField, 1, 0, true, Fill, false, Fill, 1, 1)
+	// 	g.Attach(NewLabel("Address"), 0, 1, false, RightBottom, false, Center, 1, 1)
+	// 	g.Attach(addressField, 1, 1, true, Fill, false, Fill, 1, 1)
+	// Because Add can add Controls above or to the left of the top-left cell, the coordinates of a Control already in the Grid can change after Add; they never change after Attach.
+	// The same undefined behaviors as Add apply.
+	// Attach panics if x or y are negative or if either xspan or yspan are zero or negative.
+	Attach(control Control, x int, y int, xexpand bool, xalign Align, yexpand bool, yalign Align, xspan int, yspan int)
+
+	// Delete removes a Control from the Grid; the cells it occupied become empty.
+	// As with Add and Attach, Controls can be added and removed at any time, including after the Grid is in a Window that has been shown; the Window is laid out again.
+	// As with Tab.Delete, the removed Control is destroyed and must not be used again.
+	// Delete panics if control is not in the Grid.
+	Delete(control Control)
+
 	// Padded and SetPadded get and set whether the controls of the Grid have padding between them.
 	// The size of the padding is platform-dependent.
 	Padded() bool
@@ -56,10 +73,10 @@ const (
 )
 
 type grid struct {
+	controlstate
 	controls []gridCell
 	indexof  map[Control]int
 	prev     int
-	parent	*controlParent
 	padded	bool
 
 	xmax int
@@ -88,9 +105,13 @@ type gridCell struct {
 
 // NewGrid creates a new Grid with no Controls.
 func NewGrid() Grid {
-	return &grid{
+	g := &grid{
 		indexof: map[Control]int{},
 	}
+	g.fsetShown = g.setShown
+	g.fsetEnabled = g.setEnabled
+	g.fdestroy = chainDestroy(func() {}, g.childControls)		// nothing native of its own
+	return g
 }
 
 // ensures that all (x, y) pairs are 0-based
@@ -135,9 +156,6 @@ func (g *grid) Add(control Control, nextTo Control, side Side, xexpand bool, xal
 		xspan:   xspan,
 		yspan:   yspan,
 	}
-	if g.parent != nil {
-		control.setParent(g.parent)
-	}
 	// if this is the first control, just add it in directly
 	if len(g.controls) != 0 {
 		next := g.prev
@@ -165,6 +183,54 @@ func (g *grid) Add(control Control, nextTo Control, side Side, xexpand bool, xal
 	g.prev = len(g.controls) - 1
 	g.indexof[control] = g.prev
 	g.reorigin()
+	g.adopt(control)
+}
+
+func (g *grid) Attach(control Control, x int, y int, xexpand bool, xalign Align, yexpand bool, yalign Align, xspan int, yspan int) {
+	if x < 0 || y < 0 {
+		panic(fmt.Errorf("invalid cell (%d, %d) given to Grid.Attach()", x, y))
+	}
+	if xspan <= 0 || yspan <= 0 {
+		panic(fmt.Errorf("invalid span %dx%d given to Grid.Attach()", xspan, yspan))
+	}
+	g.controls = append(g.controls, gridCell{
+		control: control,
+		xexpand: xexpand,
+		xalign:  xalign,
+		yexpand: yexpand,
+		yalign:  yalign,
+		xspan:   xspan,
+		yspan:   yspan,
+		x:       x,
+		y:       y,
+	})
+	// so that a later Add with a nil nextTo goes next to this one
+	g.prev = len(g.controls) - 1
+	g.indexof[control] = g.prev
+	// nothing is negative, so this only updates xmax and ymax
+	g.reorigin()
+	g.adopt(control)
+}
+
+func (g *grid) Delete(control Control) {
+	index, ok := g.indexof[control]
+	if !ok {
+		panic("Control not in Grid given to Grid.Delete()")
+	}
+	g.controls = append(g.controls[:index], g.controls[index+1:]...)
+	delete(g.indexof, control)
+	for i := index; i < len(g.controls); i++ {
+		g.indexof[g.controls[i].control] = i
+	}
+	switch {
+	case g.prev == index:		// a later Add with a nil nextTo goes next to the last Control instead
+		g.prev = len(g.controls) - 1
+	case g.prev > index:
+		g.prev--
+	}
+	// the Grid may have gotten smaller; this also makes sure it still starts at (0, 0)
+	g.reorigin()
+	g.orphan(control)
 }
 
 func (g *grid) Padded() bool {
@@ -180,21 +246,39 @@ func (g *grid) setParent(p *controlParent) {
 	for _, c := range g.controls {
 		c.control.setParent(g.parent)
 	}
+	g.reapply()
+}
+
+func (g *grid) childControls() []Control {
+	controls := make([]Control, len(g.controls))
+	for i, c := range g.controls {
+		controls[i] = c.control
+	}
+	return controls
 }
 
-func (g *grid) containerShow() {
+func (g *grid) setShown(shown bool) {
 	for _, c := range g.controls {
-		c.control.containerShow()
+		if shown {
+			c.control.containerShow()
+		} else {
+			c.control.containerHide()
+		}
 	}
 }
 
-func (g *grid) containerHide() {
+func (g *grid) setEnabled(enabled bool) {
 	for _, c := range g.controls {
-		c.control.containerHide()
+		if enabled {
+			c.control.containerEnable()
+		} else {
+			c.control.containerDisable()
+		}
 	}
 }
 
 // builds the topological cell grid; also makes colwidths and rowheights
+// hidden controls are left out, so their cells are treated as empty
 func (g *grid) mkgrid() (gg [][]int, colwidths []int, rowheights []int) {
 	gg = make([][]int, g.ymax)
 	for y := 0; y < g.ymax; y++ {
@@ -204,6 +288,9 @@ func (g *grid) mkgrid() (gg [][]int, colwidths []int, rowheights []int) {
 		}
 	}
 	for i := range g.controls {
+		if !g.controls[i].control.Visible() {
+			continue
+		}
 		for y := g.controls[i].y; y < g.controls[i].y+g.controls[i].yspan; y++ {
 			for x := g.controls[i].x; x < g.controls[i].x+g.controls[i].xspan; x++ {
 				gg[y][x] = i
@@ -264,6 +351,9 @@ func (g *grid) resize(x int, y int, width int, height int, d *sizing) {
 	// 2) figure out which rows/columns expand but not span
 	// we need to know which expanding rows/columns don't span before we can handle the ones that do
 	for i := range g.controls {
+		if !g.controls[i].control.Visible() {
+			continue
+		}
 		if g.controls[i].xexpand && g.controls[i].xspan == 1 {
 			xexpand[g.controls[i].x] = true
 		}
@@ -275,6 +365,9 @@ func (g *grid) resize(x int, y int, width int, height int, d *sizing) {
 	// 2) figure out which rows/columns expand that do span
 	// the way we handle this is simple: if none of the spanned rows/columns expand, make all rows/columns expand
 	for i := range g.controls {
+		if !g.controls[i].control.Visible() {
+			continue
+		}
 		if g.controls[i].xexpand && g.controls[i].xspan != 1 {
 			do := true
 			for x := g.controls[i].x; x < g.controls[i].x+g.controls[i].xspan; x++ {
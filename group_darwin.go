@@ -23,6 +23,14 @@ func newGroup(text string, control Control) Group {
 	g.child.setParent(g.container.parent())
 	g.controlSingleObject = newControlSingleObject(C.newGroup(g.container.id))
 	g.SetText(text)
+	g.fsetEnabled = chainEnabled(g.fsetEnabled, func() []Control {
+		return []Control{g.child}
+	})
+	g.fdestroy = chainDestroy(g.fdestroy, g.childControls)
+	// the frame itself can't take the focus
+	g.fsetFocus = func() {
+		g.child.SetFocus()
+	}
 	return g
 }
 
@@ -44,4 +52,8 @@ func (g *group) SetMargined(margined bool) {
 	g.container.margined = margined
 }
 
+func (g *group) childControls() []Control {
+	return []Control{g.child}
+}
+
 // no need to override resize; the child container handles that for us
@@ -54,6 +54,14 @@ func newGroup(text string, control Control) Group {
 	g.child.setParent(g.container.parent())
 	g.container.resize = g.child.resize
 	C.gtk_container_add(g.gcontainer, g.container.widget)
+	g.fsetEnabled = chainEnabled(g.fsetEnabled, func() []Control {
+		return []Control{g.child}
+	})
+	g.fdestroy = chainDestroy(g.fdestroy, g.childControls)
+	// the frame itself can't take the focus
+	g.fsetFocus = func() {
+		g.child.SetFocus()
+	}
 
 	return g
 }
@@ -76,4 +84,8 @@ func (g *group) SetMargined(margined bool) {
 	g.container.margined = margined
 }
 
+func (g *group) childControls() []Control {
+	return []Control{g.child}
+}
+
 // no need to override resize; the child container handles that for us
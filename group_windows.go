@@ -29,6 +29,14 @@ func newGroup(text string, control Control) Group {
 	C.controlSetControlFont(g.hwnd)
 	C.setGroupSubclass(g.hwnd, unsafe.Pointer(g))
 	control.setParent(&controlParent{g.hwnd})
+	g.fsetEnabled = chainEnabled(g.fsetEnabled, func() []Control {
+		return []Control{g.child}
+	})
+	g.fdestroy = chainDestroy(g.fdestroy, g.childControls)
+	// the frame itself can't take the focus
+	g.fsetFocus = func() {
+		g.child.SetFocus()
+	}
 	return g
 }
 
@@ -48,6 +56,10 @@ func (g *group) SetMargined(margined bool) {
 	g.margined = margined
 }
 
+func (g *group) childControls() []Control {
+	return []Control{g.child}
+}
+
 const (
 	groupXMargin       = 6
 	groupYMarginTop    = 11 // note this value /includes the groupbox label/
@@ -24,6 +24,7 @@ Thanks to desrt in irc.gimp.net/#gtk+
 
 // table_unix.c
 extern void tableAppendColumn(GtkTreeView *, gint, gchar *, GtkCellRenderer *, gchar *);
+extern void tableSetRendererEditable(GtkCellRenderer *);
 typedef struct goTableModel goTableModel;
 typedef struct goTableModelClass goTableModelClass;
 struct goTableModel {
@@ -35,8 +36,79 @@ struct goTableModelClass {
 };
 extern goTableModel *newTableModel(void *);
 extern void tableUpdate(goTableModel *, gint, gint);
+extern gint tableRowAt(GtkTreeView *, gint, gint);
+extern void tableSetRowDragIcon(GtkTreeView *, GdkDragContext *, gint, gint);
+
+// tree_unix.c
+extern GtkTreeStore *newTreeStore(void);
+extern void treeStoreAppend(GtkTreeStore *, GtkTreeIter *, GtkTreeIter *, gchar *, GdkPixbuf *);
+extern void treeStoreAppendPlaceholder(GtkTreeStore *, GtkTreeIter *, gchar *);
+extern void treeAppendColumn(GtkTreeView *);
+
+// drop_unix.c
+enum {
+	dropFormatFiles = 1 << 0,
+	dropFormatText = 1 << 1,
+	dropFormatImage = 1 << 2,
+	dropFormatLocal = 1 << 3,
+	dragFormatPromise = 1 << 4,		// not a DropFormats; drag sources only
+};
+extern void areaSetDropTarget(GtkWidget *);
+extern guint dropFormats(GtkWidget *, GdkDragContext *);
+extern GdkAtom dropTarget(GtkWidget *, GdkDragContext *, guint);
+extern gchar **dropFilenames(GtkSelectionData *);
+extern void dragSetFilenames(GtkSelectionData *, gchar **, guint);
+extern void dragAddLocalTarget(GtkTargetList *);
+extern void dragSetLocal(GtkSelectionData *);
+extern void dragAddDirectSaveTarget(GtkTargetList *);
+extern void dragSetDirectSave(GdkDragContext *, gchar *);
+extern gchar *dragDirectSavePath(GdkDragContext *);
+extern void dragSetDirectSaveResult(GtkSelectionData *, gchar);
+extern void dragEndDirectSave(GdkDragContext *);
+
+// control_unix.c
+extern void controlScrollIntoView(GtkWidget *);
 
 // container_unix.c
 extern GtkWidget *newContainer(void *);
 
+// label_unix.c
+extern void labelSetMnemonicHandler(GtkWidget *);
+
+// popupmenu_unix.c
+extern void popupMenuAppend(GtkWidget *, gchar *, gboolean, gboolean, gboolean, gboolean, gboolean, gint, void *);
+extern GtkWidget *popupMenuAppendSubmenu(GtkWidget *, gchar *, gboolean);
+extern void popupMenuShow(GtkWidget *, gboolean, gint, gint);
+
+// idle_unix.c
+extern guint32 inhibitIdle(gchar *, gchar *);
+extern void uninhibitIdle(guint32);
+extern gboolean idleTime(guint64 *);
+
+// power_unix.c
+extern gboolean getPowerStatus(gboolean *, gint *, gboolean *);
+
+// notify_unix.c
+extern gboolean postNotification(gchar *, gchar *, gchar *, GdkPixbuf *);
+
+// cursor_unix.c
+extern void setOverrideCursor(GdkCursor *);
+extern void setWindowCursor(GdkWindow *, GdkCursor *);
+
+// window_unix.c
+extern void windowSetTabOrder(GtkWidget **, gintptr);
+extern GdkPixbuf *windowSnapshot(GtkWindow *);
+
+// clipboard_unix.c
+extern GtkClipboard *getClipboard(void);
+extern void setClipboard(gchar **, gboolean *, gint, guintptr);
+
+// locale_unix.c
+extern gchar *formatNumber(gint64);
+extern gchar *formatDecimal(gdouble, gint);
+extern gchar *formatTime(gint, gint, gint, gint, gint, gint, gboolean, gboolean);
+
+// scale_unix.c
+extern void setScaleFactor(gdouble);
+
 #endif
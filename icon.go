@@ -0,0 +1,20 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"image"
+)
+
+// SetAppIcon sets the icon the system shows for the program: in the taskbar and Alt+Tab on Windows, in the Dock and the application switcher on Mac OS X, and wherever the desktop shows one on other systems.
+// Windows with no icon of their own (see Window.SetIcon) show it in their title bar too, where the system puts one there; this includes Windows that are already open.
+// Pass nil to go back to the default icon.
+// The icon should be square; the system scales it to the sizes it needs, so give the largest one you have (256x256 is enough everywhere).
+// On Mac OS X, a program in an application bundle already has the bundle's icon; SetAppIcon replaces it only while the program runs, and not in the Finder.
+func SetAppIcon(icon image.Image) {
+	if icon == nil {
+		setAppIcon(nil)
+		return
+	}
+	setAppIcon(toRGBA(icon))
+}
@@ -0,0 +1,82 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"time"
+)
+
+// IdleInhibitor keeps the system from going idle: no screensaver, no display sleep, and no system sleep.
+type IdleInhibitor interface {
+	// Release lets the system go idle again, unless other IdleInhibitors are still held.
+	// Calling Release more than once does nothing.
+	Release()
+}
+
+// InhibitIdle stops the system from going idle until the returned IdleInhibitor is released.
+// Use it for things like video playback or long renders, where the user isn't touching the mouse or keyboard but is still there or is waiting on a result.
+// reason is a short explanation for the user; some systems show it in their power settings or sleep menu, and others ignore it.
+// Inhibiting idle is best-effort; if the system has no way to do it (for instance, there is no session bus on a Unix system), InhibitIdle returns an IdleInhibitor that does nothing.
+// Like other functions in package ui, InhibitIdle and Release must be called on the main thread; use Do() if you need to call them elsewhere.
+func InhibitIdle(reason string) IdleInhibitor {
+	return inhibitIdle(reason)
+}
+
+// IdleTime returns how long it has been since the user last used the keyboard, mouse, or other input device anywhere on the system, not just in this program.
+// It returns an error if the system can't tell; on Unix systems this happens if the desktop provides neither of the D-Bus interfaces package ui asks (GNOME's Mutter idle monitor and org.freedesktop.ScreenSaver).
+func IdleTime() (time.Duration, error) {
+	return idleTime()
+}
+
+// idleWatchInterval is how often an IdleWatcher checks IdleTime(), and thus how late its handlers can be.
+const idleWatchInterval = 1 * time.Second
+
+// IdleWatcher tells a program when the user goes idle and when they come back, for things like automatically setting an away status.
+type IdleWatcher struct {
+	ticker *time.Ticker
+	fe     *ForeignEvent
+	idle   bool
+	last   time.Duration
+}
+
+// WatchIdle creates an IdleWatcher that calls away on the main thread once the user has been idle for threshold, and back on the main thread the next time the user uses an input device.
+// Either function can be nil.
+// The IdleWatcher checks IdleTime() periodically, so the handlers may come a second or so late; if IdleTime() returns an error, neither handler is ever called.
+func WatchIdle(threshold time.Duration, away func(), back func()) *IdleWatcher {
+	w := &IdleWatcher{
+		ticker: time.NewTicker(idleWatchInterval),
+	}
+	w.fe = NewForeignEvent(w.ticker.C, func(interface{}) {
+		d, err := IdleTime()
+		if err != nil {
+			return
+		}
+		last := w.last
+		w.last = d
+		switch {
+		case !w.idle && d >= threshold:
+			w.idle = true
+			if away != nil {
+				away()
+			}
+		// the idle time only goes down when there's input, so this catches the user returning even if they only pressed one key
+		case w.idle && d < last:
+			w.idle = false
+			if back != nil {
+				back()
+			}
+		}
+	})
+	return w
+}
+
+// Idle returns whether the IdleWatcher currently considers the user idle; that is, whether away has been called more recently than back.
+func (w *IdleWatcher) Idle() bool {
+	return w.idle
+}
+
+// Stop stops the IdleWatcher; neither handler will be called again.
+func (w *IdleWatcher) Stop() {
+	w.ticker.Stop()
+	w.fe.Stop()
+}
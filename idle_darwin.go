@@ -0,0 +1,39 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"time"
+	"unsafe"
+)
+
+// #include "objc_darwin.h"
+import "C"
+
+// each IOKit power assertion is its own object, so unlike on Windows there's nothing to count here
+type idleInhibitor struct {
+	assertion C.uint32_t
+	released  bool
+}
+
+func inhibitIdle(reason string) IdleInhibitor {
+	creason := C.CString(reason)
+	defer C.free(unsafe.Pointer(creason))
+	return &idleInhibitor{
+		assertion: C.inhibitIdle(creason),
+	}
+}
+
+func (i *idleInhibitor) Release() {
+	if i.released {
+		return
+	}
+	i.released = true
+	if i.assertion != 0 {
+		C.uninhibitIdle(i.assertion)
+	}
+}
+
+func idleTime() (time.Duration, error) {
+	return time.Duration(float64(C.idleTime()) * float64(time.Second)), nil
+}
@@ -0,0 +1,30 @@
+// 14 october 2026
+
+#include "objc_darwin.h"
+#import <Foundation/Foundation.h>
+#import <IOKit/pwr_mgt/IOPMLib.h>
+#import <ApplicationServices/ApplicationServices.h>
+
+// kIOPMAssertionTypeNoDisplaySleep also keeps the system awake
+// returns kIOPMNullAssertionID (0) on failure
+uint32_t inhibitIdle(char *reason)
+{
+	NSString *str;
+	IOPMAssertionID assertion;
+
+	str = [NSString stringWithUTF8String:reason];
+	if (IOPMAssertionCreateWithName(kIOPMAssertionTypeNoDisplaySleep, kIOPMAssertionLevelOn, (CFStringRef) str, &assertion) != kIOReturnSuccess)
+		return kIOPMNullAssertionID;
+	return (uint32_t) assertion;
+}
+
+void uninhibitIdle(uint32_t assertion)
+{
+	IOPMAssertionRelease((IOPMAssertionID) assertion);
+}
+
+// the HID system state is input from the hardware for the whole session, not just our program
+double idleTime(void)
+{
+	return (double) CGEventSourceSecondsSinceLastEventType(kCGEventSourceStateHIDSystemState, kCGAnyInputEventType);
+}
@@ -0,0 +1,76 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+#include "gtk_unix.h"
+
+// The screensaver drops an inhibit when the connection that made it closes, so we hold on to the session bus for the life of the program instead of letting g_bus_get_sync()'s shared connection be freed.
+static GDBusConnection *idleBus = NULL;
+
+static gboolean getIdleBus(void)
+{
+	if (idleBus == NULL)
+		idleBus = g_bus_get_sync(G_BUS_TYPE_SESSION, NULL, NULL);
+	return idleBus != NULL;
+}
+
+#define screenSaverName "org.freedesktop.ScreenSaver"
+#define screenSaverPath "/org/freedesktop/ScreenSaver"
+
+// returns 0 (which is never a valid cookie) if there's no session bus or no screensaver on it
+guint32 inhibitIdle(gchar *app, gchar *reason)
+{
+	GVariant *ret;
+	guint32 cookie;
+
+	if (!getIdleBus())
+		return 0;
+	ret = g_dbus_connection_call_sync(idleBus, screenSaverName, screenSaverPath, screenSaverName,
+		"Inhibit", g_variant_new("(ss)", app, reason), G_VARIANT_TYPE("(u)"),
+		G_DBUS_CALL_FLAGS_NONE, -1, NULL, NULL);
+	if (ret == NULL)
+		return 0;
+	g_variant_get(ret, "(u)", &cookie);
+	g_variant_unref(ret);
+	return cookie;
+}
+
+void uninhibitIdle(guint32 cookie)
+{
+	GVariant *ret;
+
+	ret = g_dbus_connection_call_sync(idleBus, screenSaverName, screenSaverPath, screenSaverName,
+		"UnInhibit", g_variant_new("(u)", cookie), NULL,
+		G_DBUS_CALL_FLAGS_NONE, -1, NULL, NULL);
+	// if this fails the screensaver went away, and our inhibit with it
+	if (ret != NULL)
+		g_variant_unref(ret);
+}
+
+// Mutter's idle monitor is what GNOME provides; the screensaver interface covers KDE and most others
+// returns FALSE if neither works
+gboolean idleTime(guint64 *ms)
+{
+	GVariant *ret;
+	guint32 sec;
+
+	if (!getIdleBus())
+		return FALSE;
+	ret = g_dbus_connection_call_sync(idleBus, "org.gnome.Mutter.IdleMonitor", "/org/gnome/Mutter/IdleMonitor/Core", "org.gnome.Mutter.IdleMonitor",
+		"GetIdletime", NULL, G_VARIANT_TYPE("(t)"),
+		G_DBUS_CALL_FLAGS_NONE, -1, NULL, NULL);
+	if (ret != NULL) {
+		g_variant_get(ret, "(t)", ms);
+		g_variant_unref(ret);
+		return TRUE;
+	}
+	ret = g_dbus_connection_call_sync(idleBus, screenSaverName, screenSaverPath, screenSaverName,
+		"GetSessionIdleTime", NULL, G_VARIANT_TYPE("(u)"),
+		G_DBUS_CALL_FLAGS_NONE, -1, NULL, NULL);
+	if (ret == NULL)
+		return FALSE;
+	g_variant_get(ret, "(u)", &sec);		// in seconds according to the spec
+	g_variant_unref(ret);
+	*ms = ((guint64) sec) * 1000;
+	return TRUE;
+}
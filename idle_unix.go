@@ -0,0 +1,50 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// #include "gtk_unix.h"
+import "C"
+
+// each org.freedesktop.ScreenSaver inhibit has its own cookie, so unlike on Windows there's nothing to count here
+type idleInhibitor struct {
+	cookie   C.guint32
+	released bool
+}
+
+func inhibitIdle(reason string) IdleInhibitor {
+	capp := togstr(filepath.Base(os.Args[0]))
+	defer freegstr(capp)
+	creason := togstr(reason)
+	defer freegstr(creason)
+	return &idleInhibitor{
+		cookie: C.inhibitIdle(capp, creason),
+	}
+}
+
+func (i *idleInhibitor) Release() {
+	if i.released {
+		return
+	}
+	i.released = true
+	if i.cookie != 0 {
+		C.uninhibitIdle(i.cookie)
+	}
+}
+
+func idleTime() (time.Duration, error) {
+	var ms C.guint64
+
+	if C.idleTime(&ms) == C.FALSE {
+		return 0, fmt.Errorf("neither Mutter nor the screensaver on the session bus can report the idle time")
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
@@ -0,0 +1,43 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"time"
+)
+
+// #include "winapi_windows.h"
+import "C"
+
+// SetThreadExecutionState() is a single per-thread state, not a set of requests, so we count the inhibitors ourselves and only clear the state when the last one is released.
+// It is also tied to the calling thread, which is why InhibitIdle() has to be called on the main thread.
+// PowerCreateRequest() would let us pass the reason on, but it's Windows 7 and newer; we still support XP.
+var idleInhibitors int
+
+type idleInhibitor struct {
+	released bool
+}
+
+func inhibitIdle(reason string) IdleInhibitor {
+	if idleInhibitors == 0 {
+		// this can only fail if we pass bad flags; don't bother checking
+		C.SetThreadExecutionState(C.ES_CONTINUOUS | C.ES_DISPLAY_REQUIRED | C.ES_SYSTEM_REQUIRED)
+	}
+	idleInhibitors++
+	return new(idleInhibitor)
+}
+
+func (i *idleInhibitor) Release() {
+	if i.released {
+		return
+	}
+	i.released = true
+	idleInhibitors--
+	if idleInhibitors == 0 {
+		C.SetThreadExecutionState(C.ES_CONTINUOUS)
+	}
+}
+
+func idleTime() (time.Duration, error) {
+	return time.Duration(C.idleTime()) * time.Millisecond, nil
+}
@@ -17,31 +17,36 @@ import "C"
 // technically it uses max(width from that, height from that) if the call below fails and 16x16 otherwise, but we won't worry about that here (yet?)
 const scaleTo = C.GTK_ICON_SIZE_MENU
 
-func toIconSizedGdkPixbuf(img *image.RGBA) *C.GdkPixbuf {
-	var width, height C.gint
-
+func toGdkPixbuf(img *image.RGBA) *C.GdkPixbuf {
 	surface := C.cairo_image_surface_create(C.CAIRO_FORMAT_ARGB32,
 		C.int(img.Rect.Dx()),
 		C.int(img.Rect.Dy()))
 	if status := C.cairo_surface_status(surface); status != C.CAIRO_STATUS_SUCCESS {
-		panic(fmt.Errorf("cairo_create_image_surface() failed in toIconSizedGdkPixbuf(): %s\n",
+		panic(fmt.Errorf("cairo_create_image_surface() failed in toGdkPixbuf(): %s\n",
 			C.GoString(C.cairo_status_to_string(status))))
 	}
 	C.cairo_surface_flush(surface)
 	toARGB(img, uintptr(unsafe.Pointer(C.cairo_image_surface_get_data(surface))),
 		int(C.cairo_image_surface_get_stride(surface)), false) // not NRGBA
 	C.cairo_surface_mark_dirty(surface)
-	basepixbuf := C.gdk_pixbuf_get_from_surface(surface, 0, 0, C.gint(img.Rect.Dx()), C.gint(img.Rect.Dy()))
-	if basepixbuf == nil {
-		panic(fmt.Errorf("gdk_pixbuf_get_from_surface() failed in toIconSizedGdkPixbuf() (no reason available)"))
+	pixbuf := C.gdk_pixbuf_get_from_surface(surface, 0, 0, C.gint(img.Rect.Dx()), C.gint(img.Rect.Dy()))
+	if pixbuf == nil {
+		panic(fmt.Errorf("gdk_pixbuf_get_from_surface() failed in toGdkPixbuf() (no reason available)"))
 	}
+	// the pixbuf has its own copy of the pixels
+	C.cairo_surface_destroy(surface)
+	return pixbuf
+}
 
+func toIconSizedGdkPixbuf(img *image.RGBA) *C.GdkPixbuf {
+	var width, height C.gint
+
+	basepixbuf := toGdkPixbuf(img)
 	if C.gtk_icon_size_lookup(scaleTo, &width, &height) == C.FALSE {
 		panic(fmt.Errorf("gtk_icon_size_lookup() failed in toIconSizedGdkPixbuf() (no reason available)"))
 	}
 	if int(width) == img.Rect.Dx() && int(height) == img.Rect.Dy() {
 		// just return the base pixbuf; we're good
-		C.cairo_surface_destroy(surface)
 		return basepixbuf
 	}
 	// else scale
@@ -51,6 +56,36 @@ func toIconSizedGdkPixbuf(img *image.RGBA) *C.GdkPixbuf {
 	}
 
 	C.g_object_unref(C.gpointer(unsafe.Pointer(basepixbuf)))
-	C.cairo_surface_destroy(surface)
 	return pixbuf
 }
+
+// this assumes 8 bits per sample, which is the only thing GdkPixbuf supports
+func fromGdkPixbuf(pixbuf *C.GdkPixbuf) *image.RGBA {
+	width := int(C.gdk_pixbuf_get_width(pixbuf))
+	height := int(C.gdk_pixbuf_get_height(pixbuf))
+	stride := int(C.gdk_pixbuf_get_rowstride(pixbuf))
+	nchan := int(C.gdk_pixbuf_get_n_channels(pixbuf))
+	alpha := C.gdk_pixbuf_get_has_alpha(pixbuf) != C.FALSE
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	if width == 0 || height == 0 {
+		return toRGBA(img)
+	}
+	// the last row need not be padded to the full stride
+	n := (height-1)*stride + width*nchan
+	pix := C.GoBytes(unsafe.Pointer(C.gdk_pixbuf_get_pixels(pixbuf)), C.int(n))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			p := y*stride + x*nchan
+			q := img.PixOffset(x, y)
+			img.Pix[q+0] = pix[p+0]
+			img.Pix[q+1] = pix[p+1]
+			img.Pix[q+2] = pix[p+2]
+			img.Pix[q+3] = 0xFF
+			if alpha {
+				img.Pix[q+3] = pix[p+3]
+			}
+		}
+	}
+	// GdkPixbuf is not alpha-premultiplied; image.RGBA is
+	return toRGBA(img)
+}
@@ -28,6 +28,28 @@ HBITMAP toBitmap(void *i, intptr_t dx, intptr_t dy)
 	return bitmap;
 }
 
+// the same, but with premultiplied alpha, which is what the shell wants for drag images
+HBITMAP toAlphaBitmap(void *i, intptr_t dx, intptr_t dy)
+{
+	BITMAPINFO bi;
+	VOID *ppvBits;
+	HBITMAP bitmap;
+
+	ZeroMemory(&bi, sizeof (BITMAPINFO));
+	bi.bmiHeader.biSize = sizeof (BITMAPINFOHEADER);
+	bi.bmiHeader.biWidth = (LONG) dx;
+	bi.bmiHeader.biHeight = -((LONG) dy);			// top-down
+	bi.bmiHeader.biPlanes = 1;
+	bi.bmiHeader.biBitCount = 32;
+	bi.bmiHeader.biCompression = BI_RGB;
+	bi.bmiHeader.biSizeImage = (DWORD) (dx * dy * 4);
+	bitmap = CreateDIBSection(NULL, &bi, DIB_RGB_COLORS, &ppvBits, 0, 0);
+	if (bitmap == NULL)
+		xpanic("error creating HBITMAP in toAlphaBitmap()", GetLastError());
+	dotoARGB(i, (void *) ppvBits, FALSE);
+	return bitmap;
+}
+
 void freeBitmap(uintptr_t bitmap)
 {
 	if (DeleteObject((HBITMAP) bitmap) == 0)
@@ -20,6 +20,7 @@ DWORD initWindows(char **errmsg)
 {
 	STARTUPINFOW si;
 	NONCLIENTMETRICSW ncm;
+	HRESULT hr;
 
 	// WinMain() parameters
 	hInstance = GetModuleHandleW(NULL);
@@ -69,5 +70,13 @@ DWORD initWindows(char **errmsg)
 		return GetLastError();
 	}
 
+	// OLE, for drag and drop; this has to be OleInitialize() and not just CoInitialize() (see RegisterDragDrop() on MSDN)
+	// S_FALSE means OLE was already initialized on this thread, which is fine
+	hr = OleInitialize(NULL);
+	if (hr != S_OK && hr != S_FALSE) {
+		*errmsg = "error initializing OLE";
+		return (DWORD) hr;
+	}
+
 	return 0;
 }
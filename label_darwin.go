@@ -11,6 +11,7 @@ import "C"
 
 type label struct {
 	*controlSingleObject
+	text string // as given, with the & mnemonic markers that Mac OS X doesn't show
 }
 
 func newLabel(text string) Label {
@@ -22,11 +23,12 @@ func newLabel(text string) Label {
 }
 
 func (l *label) Text() string {
-	return C.GoString(C.textfieldText(l.id))
+	return l.text
 }
 
 func (l *label) SetText(text string) {
-	ctext := C.CString(text)
+	l.text = text
+	ctext := C.CString(stripMnemonic(text))
 	defer C.free(unsafe.Pointer(ctext))
 	C.textfieldSetText(l.id, ctext)
 }
@@ -0,0 +1,36 @@
+// +build !windows,!darwin
+
+// 15 october 2026
+
+#include "gtk_unix.h"
+#include "_cgo_export.h"
+
+// we don't know which control a Label is labelling, so do what Windows does: focus the next control after it that takes focus
+// child_focus() rather than mnemonic_activate() so that Buttons aren't clicked and so that the focus can go inside things like scrolled windows and nested containers
+static gboolean labelMnemonicActivate(GtkWidget *label, gboolean groupCycling, gpointer data)
+{
+	GtkWidget *parent;
+	GList *children, *l;
+	gboolean handled = FALSE;
+
+	parent = gtk_widget_get_parent(label);
+	if (parent == NULL || !GTK_IS_CONTAINER(parent))
+		return FALSE;
+	// this is in the order the children were added, which for our containers is the order they are laid out
+	children = gtk_container_get_children(GTK_CONTAINER(parent));
+	l = g_list_find(children, label);
+	if (l != NULL)
+		for (l = l->next; l != NULL; l = l->next)
+			if (gtk_widget_child_focus(GTK_WIDGET(l->data), GTK_DIR_TAB_FORWARD)) {
+				handled = TRUE;
+				break;
+			}
+	g_list_free(children);
+	// if there was nothing, let GtkLabel try its parents
+	return handled;
+}
+
+void labelSetMnemonicHandler(GtkWidget *label)
+{
+	g_signal_connect(label, "mnemonic-activate", G_CALLBACK(labelMnemonicActivate), NULL);
+}
@@ -18,9 +18,10 @@ type label struct {
 }
 
 func newLabel(text string) Label {
-	ctext := togstr(text)
+	ctext := togstr(toUnderlineMnemonic(text))
 	defer freegstr(ctext)
-	widget := C.gtk_label_new(ctext)
+	widget := C.gtk_label_new_with_mnemonic(ctext)
+	C.labelSetMnemonicHandler(widget)
 	l := &label{
 		controlSingleWidget:    newControlSingleWidget(widget),
 		misc:       (*C.GtkMisc)(unsafe.Pointer(widget)),
@@ -39,13 +40,14 @@ func newStandaloneLabel(text string) Label {
 */
 
 func (l *label) Text() string {
-	return fromgstr(C.gtk_label_get_text(l.label))
+	// gtk_label_get_text() would lose the mnemonic
+	return fromUnderlineMnemonic(fromgstr(C.gtk_label_get_label(l.label)))
 }
 
 func (l *label) SetText(text string) {
-	ctext := togstr(text)
+	ctext := togstr(toUnderlineMnemonic(text))
 	defer freegstr(ctext)
-	C.gtk_label_set_text(l.label, ctext)
+	C.gtk_label_set_text_with_mnemonic(l.label, ctext)
 }
 
 /*TODO
@@ -13,9 +13,9 @@ var labelclass = toUTF16("STATIC")
 
 func newLabel(text string) Label {
 	hwnd := C.newControl(labelclass,
-		// SS_NOPREFIX avoids accelerator translation; SS_LEFTNOWORDWRAP clips text past the end
+		// no SS_NOPREFIX: & marks the mnemonic, and IsDialogMessage() moves the focus to the next tab stop when it's pressed; SS_LEFTNOWORDWRAP clips text past the end
 		// controls are vertically aligned to the top by default (thanks Xeek in irc.freenode.net/#winapi)
-		C.SS_NOPREFIX|C.SS_LEFTNOWORDWRAP,
+		C.SS_LEFTNOWORDWRAP,
 		C.WS_EX_TRANSPARENT)
 	l := &label{
 		controlSingleHWNDWithText:		newControlSingleHWNDWithText(hwnd),
@@ -25,6 +25,7 @@ func newLabel(text string) Label {
 		// labels are not tab stops
 		return 0
 	}
+	l.fsetFocus = nil		// nor can they take the focus
 	l.SetText(text)
 	C.controlSetControlFont(l.hwnd)
 	return l
@@ -0,0 +1,229 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"sync"
+	"time"
+)
+
+// LiveFrame is one frame sent to a LiveView.
+type LiveFrame struct {
+	// Image contains the pixels; any image.Image will do, though *image.RGBA, *image.NRGBA, *image.Gray, and *image.YCbCr are the fastest to draw.
+	Image image.Image
+
+	// ROI (region of interest) is where in the LiveView the Image goes.
+	// A camera or detector that reads out only part of its sensor, or sends its sensor in tiles, sets ROI to that part; the rest of the LiveView keeps showing what it showed before.
+	// If ROI is empty, the Image goes at (0,0) with its own size.
+	// The Image is not scaled; it is drawn at ROI.Min, and whatever falls outside ROI or outside the LiveView is cut off.
+	ROI image.Rectangle
+}
+
+// LiveView is an Area that shows frames from a live source, such as a microscope camera or a software-defined radio's waterfall, as they arrive.
+//
+// Frames are read from a channel by a goroutine of the LiveView's own, so the source never waits for the screen.
+// If frames come faster than the LiveView can show them (or faster than MaxRate), the stale ones are dropped: a frame is dropped when a newer frame whose ROI covers all of its ROI arrives before it is shown.
+// Frames with different ROIs are all shown, in the order they arrived, so a source that sends tiles doesn't lose any.
+type LiveView interface {
+	Area
+
+	// MaxRate and SetMaxRate get and set the most times per second the LiveView redraws for new frames.
+	// The default is 60; SetMaxRate panics if rate is not positive.
+	MaxRate() int
+	SetMaxRate(rate int)
+
+	// Shown and Dropped return how many frames have been shown and dropped so far, for showing in a status bar or to tell whether the source is too fast.
+	Shown() int
+	Dropped() int
+
+	// OnFrame registers a function that is called on the main thread after each frame is drawn, for updating a readout of, say, the exposure time.
+	OnFrame(f func(frame LiveFrame))
+
+	// Stop stops reading frames; the LiveView keeps showing the last ones.
+	// Frames still in the channel are not read, so the source should stop sending (or not block on sending) afterward.
+	// Call Stop when done with the LiveView even if the channel was closed, so the goroutines behind it go away.
+	Stop()
+}
+
+type liveView struct {
+	Area
+	img *image.RGBA
+
+	lock    sync.Mutex // for the fields below, which the reading goroutine uses too
+	pending []LiveFrame
+	rate    int
+	shown   int
+	dropped int
+
+	arrived chan struct{} // buffered; there are new frames in pending
+	notify  chan struct{} // to the main thread
+	done    chan struct{}
+	stopped sync.Once
+	fe      *ForeignEvent
+	onframe func(frame LiveFrame)
+}
+
+// NewLiveView creates a new LiveView of the given size that shows the frames sent on frames.
+// The LiveView is black until the first frame arrives.
+func NewLiveView(width int, height int, frames <-chan LiveFrame) LiveView {
+	l := &liveView{
+		img:     image.NewRGBA(image.Rect(0, 0, width, height)),
+		rate:    60,
+		arrived: make(chan struct{}, 1),
+		notify:  make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	draw.Draw(l.img, l.img.Rect, image.Black, image.ZP, draw.Src)
+	l.Area = NewArea(width, height, l)
+	l.fe = NewForeignEvent(l.notify, func(interface{}) {
+		l.show()
+	})
+	go l.read(frames)
+	go l.pace()
+	return l
+}
+
+// read runs on its own goroutine and keeps only the frames that are still worth showing
+func (l *liveView) read(frames <-chan LiveFrame) {
+	for {
+		var f LiveFrame
+		var ok bool
+
+		select {
+		case f, ok = <-frames:
+		case <-l.done:
+			return
+		}
+		if !ok {
+			return
+		}
+		if f.ROI.Empty() {
+			f.ROI = f.Image.Bounds().Sub(f.Image.Bounds().Min)
+		}
+		l.lock.Lock()
+		n := 0
+		for _, p := range l.pending {
+			if p.ROI.In(f.ROI) {
+				l.dropped++
+				continue
+			}
+			l.pending[n] = p
+			n++
+		}
+		l.pending = append(l.pending[:n], f)
+		l.lock.Unlock()
+		select {
+		case l.arrived <- struct{}{}:
+		default: // already told
+		}
+	}
+}
+
+// pace runs on its own goroutine and tells the main thread to show new frames, at most rate times a second
+// sending on notify waits for the previous show() to be done, so a busy main thread makes the frames pile up in pending (where read() drops the stale ones) rather than in the channel
+func (l *liveView) pace() {
+	defer close(l.notify) // lets the ForeignEvent's goroutine go away
+	for {
+		select {
+		case <-l.arrived:
+		case <-l.done:
+			return
+		}
+		select {
+		case l.notify <- struct{}{}:
+		case <-l.done:
+			return
+		}
+		l.lock.Lock()
+		interval := time.Second / time.Duration(l.rate)
+		l.lock.Unlock()
+		select {
+		case <-time.After(interval):
+		case <-l.done:
+			return
+		}
+	}
+}
+
+func (l *liveView) show() {
+	l.lock.Lock()
+	frames := l.pending
+	l.pending = nil
+	l.shown += len(frames)
+	l.lock.Unlock()
+	for _, f := range frames {
+		r := f.ROI.Intersect(l.img.Rect)
+		if r.Empty() {
+			continue
+		}
+		draw.Draw(l.img, r, f.Image, f.Image.Bounds().Min.Add(r.Min.Sub(f.ROI.Min)), draw.Src)
+		l.Repaint(r)
+	}
+	if l.onframe != nil {
+		for _, f := range frames {
+			l.onframe(f)
+		}
+	}
+}
+
+func (l *liveView) MaxRate() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.rate
+}
+
+func (l *liveView) SetMaxRate(rate int) {
+	if rate <= 0 {
+		panic(fmt.Errorf("non-positive rate %d given to LiveView.SetMaxRate()", rate))
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.rate = rate
+}
+
+func (l *liveView) Shown() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.shown
+}
+
+func (l *liveView) Dropped() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.dropped
+}
+
+func (l *liveView) OnFrame(f func(frame LiveFrame)) {
+	l.onframe = f
+}
+
+func (l *liveView) Stop() {
+	l.stopped.Do(func() {
+		l.fe.Stop()
+		close(l.done)
+	})
+}
+
+// SetSize is overridden to resize the image the frames are drawn into; what fits of the old one is kept.
+func (l *liveView) SetSize(width int, height int) {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Rect, image.Black, image.ZP, draw.Src)
+	draw.Draw(img, img.Rect, l.img, image.ZP, draw.Src)
+	l.img = img
+	l.Area.SetSize(width, height)
+}
+
+func (l *liveView) Paint(cliprect image.Rectangle) image.Image {
+	return l.img.SubImage(cliprect)
+}
+
+func (l *liveView) Mouse(e MouseEvent) {
+	// do nothing
+}
+
+func (l *liveView) Key(e KeyEvent) (handled bool) {
+	return false
+}
@@ -0,0 +1,82 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// The Format functions format values for display the way the user has asked their system to, using the system's own formatting where it has one.
+// This is not necessarily what Go's LANG-based conventions (or package fmt) would give: on Windows and OS X, the regional settings in Control Panel and System Preferences apply, whatever LANG says.
+// Use them for text shown to the user, not for text a program will read back.
+// Table uses them to show time.Time, time.Duration, and FileSize values.
+
+// FormatNumber formats n with the user's digit grouping; for instance, 1234567 is "1,234,567" in the United States and "1.234.567" in Germany.
+func FormatNumber(n int64) string {
+	return formatNumber(n)
+}
+
+// FormatDecimal formats f with the user's digit grouping and decimal separator, rounded to the given number of digits after the decimal separator.
+// FormatDecimal panics if digits is negative.
+func FormatDecimal(f float64, digits int) string {
+	if digits < 0 {
+		panic(fmt.Errorf("negative digit count %d given to FormatDecimal()", digits))
+	}
+	return formatDecimal(f, digits)
+}
+
+// FormatDate formats the date part of t in the user's short date format, such as "10/14/2026" or "14.10.26".
+// FormatDate, FormatTime, and FormatDateTime use t's wall clock, not the system's time zone; pass t.Local() to show a time in the user's time zone.
+func FormatDate(t time.Time) string {
+	return formatTime(t, true, false)
+}
+
+// FormatTime formats the time-of-day part of t in the user's time format, including seconds, such as "3:04:05 PM" or "15:04:05".
+func FormatTime(t time.Time) string {
+	return formatTime(t, false, true)
+}
+
+// FormatDateTime formats t as both a date and a time, as FormatDate and FormatTime do.
+func FormatDateTime(t time.Time) string {
+	return formatTime(t, true, true)
+}
+
+// FormatDuration formats d like a stopwatch: "1:02:03" for an hour, two minutes, and three seconds, or "2:03" for durations under an hour.
+// Fractions of a second are dropped.
+// The systems package ui supports don't have duration formatting in the versions it supports, so this is the same everywhere.
+func FormatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	s := int64(d / time.Second)
+	if s >= 3600 {
+		return fmt.Sprintf("%s%d:%02d:%02d", sign, s/3600, (s/60)%60, s%60)
+	}
+	return fmt.Sprintf("%s%d:%02d", sign, s/60, s%60)
+}
+
+// FormatFileSize formats a size in bytes the way the system's file manager does, such as "1.2 MB".
+// Systems disagree on whether a kilobyte is 1000 or 1024 bytes; this follows the system.
+// FormatFileSize panics if size is negative.
+func FormatFileSize(size int64) string {
+	if size < 0 {
+		panic(fmt.Errorf("negative size %d given to FormatFileSize()", size))
+	}
+	return formatFileSize(size)
+}
+
+// FileSize is a size in bytes that formats itself with FormatFileSize.
+// Use it as the type of a Table field or TableModel cell value to show file sizes.
+type FileSize int64
+
+// String implements fmt.Stringer.
+// Unlike FormatFileSize, it accepts negative sizes, which it formats as the negated size with a leading minus sign, so that differences in size can be shown.
+func (s FileSize) String() string {
+	if s < 0 {
+		return "-" + FormatFileSize(int64(-s))
+	}
+	return FormatFileSize(int64(s))
+}
@@ -0,0 +1,43 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// #include "objc_darwin.h"
+import "C"
+
+func formatNumber(n int64) string {
+	return C.GoString(C.formatNumber(C.int64_t(n)))
+}
+
+func formatDecimal(f float64, digits int) string {
+	return C.GoString(C.formatDecimal(C.double(f), C.intptr_t(digits)))
+}
+
+func formatTime(t time.Time, date bool, clock bool) string {
+	_, offset := t.Zone()
+	return C.GoString(C.formatTime(C.int64_t(t.Unix()+int64(offset)), toBOOL(date), toBOOL(clock)))
+}
+
+// the Finder has used 1000-byte kilobytes since 10.6, so do the same on 10.7, which doesn't have NSByteCountFormatter
+// the unit names are left in English there; there's nowhere to get translations from
+func formatFileSize(size int64) string {
+	if s := C.formatFileSize(C.int64_t(size)); s != nil {
+		return C.GoString(s)
+	}
+	if size < 1000 {
+		return fmt.Sprintf("%s bytes", FormatNumber(size))
+	}
+	units := []string{"KB", "MB", "GB", "TB"}
+	f := float64(size) / 1000
+	i := 0
+	for f >= 1000 && i < len(units)-1 {
+		f /= 1000
+		i++
+	}
+	return fmt.Sprintf("%s %s", FormatDecimal(f, 1), units[i])
+}
@@ -0,0 +1,60 @@
+// 14 october 2026
+
+#include "objc_darwin.h"
+#import <Foundation/Foundation.h>
+
+// these use the current locale, which comes from System Preferences, not from the environment
+
+static NSNumberFormatter *newNumberFormatter(intptr_t digits)
+{
+	NSNumberFormatter *f;
+
+	f = [[NSNumberFormatter new] autorelease];
+	[f setFormatterBehavior:NSNumberFormatterBehavior10_4];
+	[f setNumberStyle:NSNumberFormatterDecimalStyle];
+	[f setMinimumFractionDigits:((NSUInteger) digits)];
+	[f setMaximumFractionDigits:((NSUInteger) digits)];
+	return f;
+}
+
+const char *formatNumber(int64_t n)
+{
+	return [[newNumberFormatter(0) stringFromNumber:[NSNumber numberWithLongLong:n]] UTF8String];
+}
+
+const char *formatDecimal(double f, intptr_t digits)
+{
+	return [[newNumberFormatter(digits) stringFromNumber:[NSNumber numberWithDouble:f]] UTF8String];
+}
+
+// sec is the wall clock time in seconds since the epoch as if it were UTC; formatting in UTC then gives back exactly that wall clock
+const char *formatTime(int64_t sec, BOOL date, BOOL time)
+{
+	NSDateFormatter *f;
+
+	f = [[NSDateFormatter new] autorelease];
+	[f setFormatterBehavior:NSDateFormatterBehavior10_4];
+	[f setTimeZone:[NSTimeZone timeZoneForSecondsFromGMT:0]];
+	[f setDateStyle:NSDateFormatterNoStyle];
+	if (date)
+		[f setDateStyle:NSDateFormatterShortStyle];
+	[f setTimeStyle:NSDateFormatterNoStyle];
+	if (time)
+		[f setTimeStyle:NSDateFormatterMediumStyle];		// medium includes seconds; short doesn't
+	return [[f stringFromDate:[NSDate dateWithTimeIntervalSince1970:((NSTimeInterval) sec)]] UTF8String];
+}
+
+// NSByteCountFormatter is 10.8 and newer, and we build against the 10.7 SDK, so we have to look for it at runtime
+// returns NULL if it isn't there
+const char *formatFileSize(int64_t size)
+{
+	Class c;
+	NSString *s;
+
+	c = NSClassFromString(@"NSByteCountFormatter");
+	if (c == nil)
+		return NULL;
+	// 0 is NSByteCountFormatterCountStyleFile: the Finder's style
+	s = ((id (*)(id, SEL, long long, NSInteger)) objc_msgSend)(c, @selector(stringFromByteCount:countStyle:), (long long) size, 0);
+	return [s UTF8String];
+}
@@ -0,0 +1,37 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+#include "gtk_unix.h"
+
+// gtk_init() calls setlocale(LC_ALL, ""), so all of these follow the user's locale environment variables, which is as close to a system setting as there is here
+// each returns a string to be freed with g_free()
+
+// the ' flag is digit grouping; it's POSIX, not C99, but glibc and the BSDs have it
+gchar *formatNumber(gint64 n)
+{
+	return g_strdup_printf("%'" G_GINT64_FORMAT, n);
+}
+
+gchar *formatDecimal(gdouble f, gint digits)
+{
+	return g_strdup_printf("%'.*f", digits, f);
+}
+
+// the GDateTime is in UTC so that its wall clock is exactly what we give it; %x and %X don't show the time zone
+gchar *formatTime(gint year, gint month, gint day, gint hour, gint minute, gint second, gboolean date, gboolean time)
+{
+	GDateTime *dt;
+	gchar *out;
+	const gchar *format;
+
+	format = "%x %X";
+	if (!time)
+		format = "%x";
+	else if (!date)
+		format = "%X";
+	dt = g_date_time_new_utc(year, month, day, hour, minute, (gdouble) second);
+	out = g_date_time_format(dt, format);
+	g_date_time_unref(dt);
+	return out;
+}
@@ -0,0 +1,37 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+package ui
+
+import (
+	"time"
+	"unsafe"
+)
+
+// #include "gtk_unix.h"
+import "C"
+
+func fromFormatted(s *C.gchar) string {
+	defer C.g_free(C.gpointer(unsafe.Pointer(s)))
+	return fromgstr(s)
+}
+
+func formatNumber(n int64) string {
+	return fromFormatted(C.formatNumber(C.gint64(n)))
+}
+
+func formatDecimal(f float64, digits int) string {
+	return fromFormatted(C.formatDecimal(C.gdouble(f), C.gint(digits)))
+}
+
+func formatTime(t time.Time, date bool, clock bool) string {
+	return fromFormatted(C.formatTime(C.gint(t.Year()), C.gint(t.Month()), C.gint(t.Day()),
+		C.gint(t.Hour()), C.gint(t.Minute()), C.gint(t.Second()),
+		togbool(date), togbool(clock)))
+}
+
+// this is what Nautilus uses; it uses 1000-byte kilobytes
+func formatFileSize(size int64) string {
+	return fromFormatted(C.g_format_size(C.guint64(size)))
+}
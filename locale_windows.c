@@ -0,0 +1,121 @@
+// 14 october 2026
+
+#include "winapi_windows.h"
+#include "_cgo_export.h"
+
+// all of these return a malloc()'d string for the caller to free()
+
+static UINT localeNumber(LCTYPE type)
+{
+	UINT n;
+
+	if (GetLocaleInfoW(LOCALE_USER_DEFAULT, type | LOCALE_RETURN_NUMBER, (LPWSTR) (&n), sizeof (UINT) / sizeof (WCHAR)) == 0)
+		xpanic("error getting number format setting of user locale", GetLastError());
+	return n;
+}
+
+static void localeString(LCTYPE type, WCHAR *buf, int n)
+{
+	if (GetLocaleInfoW(LOCALE_USER_DEFAULT, type, buf, n) == 0)
+		xpanic("error getting number format setting of user locale", GetLastError());
+}
+
+// LOCALE_SGROUPING is "3;0" for groups of three all the way, "3" for only one group of three, or "3;2;0" for three and then twos, as in India
+// NUMBERFMT.Grouping wants 3, 30, and 32 for those, respectively
+static UINT localeGrouping(void)
+{
+	WCHAR s[16];
+	UINT g;
+	int i;
+	BOOL repeat;
+
+	localeString(LOCALE_SGROUPING, s, 16);
+	g = 0;
+	repeat = FALSE;
+	for (i = 0; s[i] != L'\0'; i++) {
+		if (s[i] == L';')
+			continue;
+		if (i != 0 && s[i] == L'0' && s[i + 1] == L'\0') {
+			repeat = TRUE;
+			break;
+		}
+		g = g * 10 + (UINT) (s[i] - L'0');
+	}
+	if (!repeat)
+		g *= 10;
+	return g;
+}
+
+// digits is in the C locale, as from strconv.FormatFloat()
+// GetNumberFormatW() uses the locale's number of decimal places unless given a NUMBERFMT, and a NUMBERFMT has to be filled in all the way, so fill it in from the user's locale and change only the decimal places
+// (GetNumberFormatEx() is Vista and newer, and has the same problem)
+WCHAR *formatNumber(LPWSTR digits, UINT decimals)
+{
+	NUMBERFMTW f;
+	WCHAR decimal[8], thousand[8];
+	WCHAR *out;
+	int n;
+
+	f.NumDigits = decimals;
+	f.LeadingZero = localeNumber(LOCALE_ILZERO);
+	f.Grouping = localeGrouping();
+	localeString(LOCALE_SDECIMAL, decimal, 8);
+	f.lpDecimalSep = decimal;
+	localeString(LOCALE_STHOUSAND, thousand, 8);
+	f.lpThousandSep = thousand;
+	f.NegativeOrder = localeNumber(LOCALE_INEGNUMBER);
+	n = GetNumberFormatW(LOCALE_USER_DEFAULT, 0, digits, &f, NULL, 0);
+	if (n == 0)
+		xpanic("error getting length of formatted number", GetLastError());
+	out = (WCHAR *) malloc(n * sizeof (WCHAR));
+	if (out == NULL)
+		xpanic("memory exhausted allocating formatted number", GetLastError());
+	if (GetNumberFormatW(LOCALE_USER_DEFAULT, 0, digits, &f, out, n) == 0)
+		xpanic("error formatting number", GetLastError());
+	return out;
+}
+
+// the date and time end up separated by a space; Windows has no setting for how to join them
+WCHAR *formatTime(SYSTEMTIME *t, BOOL date, BOOL time)
+{
+	int ndate = 0, ntime = 0;
+	WCHAR *out;
+
+	if (date) {
+		ndate = GetDateFormatW(LOCALE_USER_DEFAULT, DATE_SHORTDATE, t, NULL, NULL, 0);
+		if (ndate == 0)
+			xpanic("error getting length of formatted date", GetLastError());
+	}
+	if (time) {
+		ntime = GetTimeFormatW(LOCALE_USER_DEFAULT, 0, t, NULL, NULL, 0);
+		if (ntime == 0)
+			xpanic("error getting length of formatted time", GetLastError());
+	}
+	// both counts include the null terminator; with both, one of those becomes the space
+	out = (WCHAR *) malloc((ndate + ntime) * sizeof (WCHAR));
+	if (out == NULL)
+		xpanic("memory exhausted allocating formatted date/time", GetLastError());
+	if (date)
+		if (GetDateFormatW(LOCALE_USER_DEFAULT, DATE_SHORTDATE, t, NULL, out, ndate) == 0)
+			xpanic("error formatting date", GetLastError());
+	if (date && time)
+		out[ndate - 1] = L' ';
+	if (time)
+		if (GetTimeFormatW(LOCALE_USER_DEFAULT, 0, t, NULL, out + ndate, ntime) == 0)
+			xpanic("error formatting time", GetLastError());
+	return out;
+}
+
+// this is what Explorer uses; note that it uses 1024-byte kilobytes
+WCHAR *formatFileSize(LONGLONG size)
+{
+	WCHAR *out;
+
+	// 64 characters is far more than a number and a unit need
+	out = (WCHAR *) malloc(64 * sizeof (WCHAR));
+	if (out == NULL)
+		xpanic("memory exhausted allocating formatted file size", GetLastError());
+	if (StrFormatByteSizeW(size, out, 64) == NULL)
+		xpanic("error formatting file size", GetLastError());
+	return out;
+}
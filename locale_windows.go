@@ -0,0 +1,44 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"strconv"
+	"time"
+	"unsafe"
+)
+
+// #include "winapi_windows.h"
+import "C"
+
+func fromFormatted(s *C.WCHAR) string {
+	defer C.free(unsafe.Pointer(s))
+	return wstrToString(s)
+}
+
+func formatNumber(n int64) string {
+	return fromFormatted(C.formatNumber(toUTF16(strconv.FormatInt(n, 10)), 0))
+}
+
+func formatDecimal(f float64, digits int) string {
+	// GetNumberFormatW() wants plain digits with a . for the decimal point, which is what 'f' gives
+	return fromFormatted(C.formatNumber(toUTF16(strconv.FormatFloat(f, 'f', digits, 64)), C.UINT(digits)))
+}
+
+func formatTime(t time.Time, date bool, clock bool) string {
+	var st C.SYSTEMTIME
+
+	st.wYear = C.WORD(t.Year())
+	st.wMonth = C.WORD(t.Month())
+	st.wDayOfWeek = C.WORD(t.Weekday())
+	st.wDay = C.WORD(t.Day())
+	st.wHour = C.WORD(t.Hour())
+	st.wMinute = C.WORD(t.Minute())
+	st.wSecond = C.WORD(t.Second())
+	st.wMilliseconds = C.WORD(t.Nanosecond() / int(time.Millisecond))
+	return fromFormatted(C.formatTime(&st, toBOOL(date), toBOOL(clock)))
+}
+
+func formatFileSize(size int64) string {
+	return fromFormatted(C.formatFileSize(C.LONGLONG(size)))
+}
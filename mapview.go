@@ -0,0 +1,533 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg"
+	_ "image/png"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LatLng is a geographic position in degrees.
+type LatLng struct {
+	Lat float64
+	Lng float64
+}
+
+// TileSource describes where a MapView gets its tiles: 256x256 images in the Web Mercator projection, numbered the XYZ ("slippy map") way, as served by OpenStreetMap and most others.
+// Fetch can get tiles from anywhere; for tiles from a web server, use XYZFetch.
+// Package ui does no network I/O of its own unless a program uses XYZFetch.
+// For example:
+//
+// 	source := ui.TileSource{
+// 		// OpenStreetMap requires a User-Agent that identifies your program
+// 		Fetch: ui.XYZFetch("https://tile.openstreetmap.org/{z}/{x}/{y}.png", "myprogram/1.0", cacheDir),
+// 	}
+type TileSource struct {
+	// Fetch returns the tile at zoom level z, column x, and row y.
+	// It is called on a goroutine of its own, not the main goroutine, and up to four calls can run at once.
+	// A tile Fetch returns an error for is left blank and not asked for again until the zoom level changes.
+	Fetch func(z int, x int, y int) (image.Image, error)
+
+	// MaxZoom is the highest zoom level there are tiles for; if zero, 19 is used.
+	MaxZoom int
+}
+
+// so that a tile server that stops answering doesn't hold up the others forever
+var xyzClient = &http.Client{
+	Timeout: 30 * time.Second,
+}
+
+// XYZFetch returns a function for TileSource.Fetch that downloads tiles over HTTP.
+// urlTemplate is the URL of each tile, with {z}, {x}, and {y} replaced by the zoom level, column, and row.
+// If userAgent is not empty, it is sent as the User-Agent header; many tile servers require one that identifies the program.
+// Tiles can be in any format package image can decode; PNG and JPEG are always available.
+// If cacheDir is not empty, tiles are kept there once downloaded and loaded from there instead of the server from then on, however old they are; delete the directory to get fresh tiles.
+// Failing to write to the cache is not an error; the tile is still returned.
+func XYZFetch(urlTemplate string, userAgent string, cacheDir string) func(z int, x int, y int) (image.Image, error) {
+	return func(z int, x int, y int) (image.Image, error) {
+		cachefile := ""
+		if cacheDir != "" {
+			cachefile = filepath.Join(cacheDir, strconv.Itoa(z), strconv.Itoa(x), strconv.Itoa(y))
+			if data, err := ioutil.ReadFile(cachefile); err == nil {
+				if img, _, err := image.Decode(bytes.NewReader(data)); err == nil {
+					return img, nil
+				}
+				// a damaged file in the cache is downloaded again
+			}
+		}
+		url := strings.NewReplacer(
+			"{z}", strconv.Itoa(z),
+			"{x}", strconv.Itoa(x),
+			"{y}", strconv.Itoa(y)).Replace(urlTemplate)
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		if userAgent != "" {
+			req.Header.Set("User-Agent", userAgent)
+		}
+		resp, err := xyzClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("tile server returned %s for %s", resp.Status, url)
+		}
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("error decoding tile %s: %v", url, err)
+		}
+		if cachefile != "" {
+			if os.MkdirAll(filepath.Dir(cachefile), 0755) == nil {
+				WriteFileAtomic(cachefile, data, 0644)
+			}
+		}
+		return img, nil
+	}
+}
+
+// MapMarker is a point shown on a MapView.
+type MapMarker struct {
+	Pos LatLng
+
+	// Image is drawn with the middle of its bottom edge at Pos; if nil, a dot is drawn instead.
+	Image *image.RGBA
+}
+
+// MapPolyline is a line through a series of points shown on a MapView.
+// Lines are drawn straight in the projection, not along great circles.
+type MapPolyline struct {
+	Points []LatLng
+	Color  color.RGBA // the zero value chooses a default
+}
+
+// MapView is an Area that shows a map made of tiles from a TileSource, with markers and lines drawn over it.
+//
+// The user can drag the map to pan it and double-click to zoom in (Shift+double-click to zoom out); Ctrl with + (or =) and - zooms in and out around the center.
+// Tiles are fetched in the background; until a tile arrives its place is left blank.
+//
+// Most tile servers require that their attribution be shown with the map; MapView doesn't show it, so show it yourself, for instance with a Label below the MapView.
+type MapView interface {
+	Area
+
+	// Center and SetCenter get and set the position shown in the middle of the Area.
+	Center() LatLng
+	SetCenter(pos LatLng)
+
+	// Zoom and SetZoom get and set the zoom level; at zoom level z the whole world is 256 * 2^z pixels wide.
+	// SetZoom clamps the zoom level to the range [0,MaxZoom].
+	Zoom() int
+	SetZoom(zoom int)
+
+	// AddMarker and AddPolyline add an overlay and return its ID; IDs are never reused.
+	// RemoveMarker and RemovePolyline remove one; they do nothing if there is no such overlay.
+	// Markers are drawn on top of lines.
+	AddMarker(m MapMarker) (id int)
+	RemoveMarker(id int)
+	AddPolyline(p MapPolyline) (id int)
+	RemovePolyline(id int)
+
+	// OnClicked registers a function that is called with the position clicked when the user clicks the map somewhere other than a marker without dragging.
+	OnClicked(f func(pos LatLng))
+
+	// OnMarkerClicked registers a function that is called with the ID of a marker when the user clicks it.
+	OnMarkerClicked(f func(id int))
+}
+
+const (
+	mapTileSize     = 256
+	mapDefaultZoom  = 19
+	mapMaxTiles     = 256 // tiles kept in memory
+	mapFetchers     = 4   // concurrent calls to Fetch
+	mapMarkerRadius = 6
+	mapClickSlop    = 3 // how far the mouse can move and still count as a click
+)
+
+type mapTile struct {
+	z, x, y int
+}
+
+type mapView struct {
+	Area
+	width  int
+	height int
+
+	source  TileSource
+	maxZoom int
+	zoom    int
+	center  [2]float64 // world pixel coordinates at zoom
+
+	tiles   map[mapTile]*image.RGBA
+	pending map[mapTile]bool // being fetched
+	failed  map[mapTile]bool
+	fetch   chan struct{} // semaphore for the fetchers
+
+	markers   map[int]*MapMarker
+	polylines map[int]*MapPolyline
+	next      int
+
+	clicked       func(pos LatLng)
+	markerClicked func(id int)
+
+	dragging   bool
+	dragStart  image.Point
+	dragCenter [2]float64
+	dragMoved  bool
+}
+
+// NewMapView creates a new MapView of the given size that gets its tiles from source, showing the whole world.
+// It panics if source.Fetch is nil.
+func NewMapView(width int, height int, source TileSource) MapView {
+	if source.Fetch == nil {
+		panic("TileSource passed to NewMapView() must have a Fetch function")
+	}
+	m := &mapView{
+		width:     width,
+		height:    height,
+		source:    source,
+		maxZoom:   source.MaxZoom,
+		center:    [2]float64{mapTileSize / 2, mapTileSize / 2},
+		tiles:     make(map[mapTile]*image.RGBA),
+		pending:   make(map[mapTile]bool),
+		failed:    make(map[mapTile]bool),
+		fetch:     make(chan struct{}, mapFetchers),
+		markers:   make(map[int]*MapMarker),
+		polylines: make(map[int]*MapPolyline),
+	}
+	if m.maxZoom == 0 {
+		m.maxZoom = mapDefaultZoom
+	}
+	m.Area = NewArea(width, height, m)
+	return m
+}
+
+// SetSize is overridden because the MapView needs to know where the middle of the Area is.
+func (m *mapView) SetSize(width int, height int) {
+	m.width = width
+	m.height = height
+	m.Area.SetSize(width, height)
+}
+
+// Web Mercator; see https://wiki.openstreetmap.org/wiki/Slippy_map_tilenames
+
+func (m *mapView) worldSize() float64 {
+	return float64(mapTileSize) * math.Exp2(float64(m.zoom))
+}
+
+func (m *mapView) toWorld(pos LatLng) (x float64, y float64) {
+	lat := math.Max(-85.0511, math.Min(85.0511, pos.Lat)) * math.Pi / 180
+	size := m.worldSize()
+	x = (pos.Lng + 180) / 360 * size
+	y = (1 - math.Log(math.Tan(lat)+1/math.Cos(lat))/math.Pi) / 2 * size
+	return x, y
+}
+
+func (m *mapView) fromWorld(x float64, y float64) LatLng {
+	size := m.worldSize()
+	n := math.Pi - 2*math.Pi*y/size
+	return LatLng{
+		Lat: 180 / math.Pi * math.Atan(math.Sinh(n)),
+		Lng: x/size*360 - 180,
+	}
+}
+
+// Area coordinates <-> world pixel coordinates
+func (m *mapView) origin() (x float64, y float64) {
+	return m.center[0] - float64(m.width)/2, m.center[1] - float64(m.height)/2
+}
+
+func (m *mapView) toArea(pos LatLng) image.Point {
+	x, y := m.toWorld(pos)
+	ox, oy := m.origin()
+	return image.Pt(int(math.Floor(x-ox)), int(math.Floor(y-oy)))
+}
+
+func (m *mapView) fromArea(p image.Point) LatLng {
+	ox, oy := m.origin()
+	return m.fromWorld(ox+float64(p.X), oy+float64(p.Y))
+}
+
+func (m *mapView) Center() LatLng {
+	return m.fromWorld(m.center[0], m.center[1])
+}
+
+func (m *mapView) SetCenter(pos LatLng) {
+	x, y := m.toWorld(pos)
+	m.center = [2]float64{x, y}
+	m.RepaintAll()
+}
+
+func (m *mapView) Zoom() int {
+	return m.zoom
+}
+
+func (m *mapView) SetZoom(zoom int) {
+	m.zoomAt(image.Pt(m.width/2, m.height/2), zoom)
+}
+
+// zoomAt changes the zoom level, keeping the position under p where it is
+func (m *mapView) zoomAt(p image.Point, zoom int) {
+	if zoom < 0 {
+		zoom = 0
+	}
+	if zoom > m.maxZoom {
+		zoom = m.maxZoom
+	}
+	if zoom == m.zoom {
+		return
+	}
+	ox, oy := m.origin()
+	scale := math.Exp2(float64(zoom - m.zoom))
+	px, py := ox+float64(p.X), oy+float64(p.Y)
+	m.zoom = zoom
+	m.center[0] = px*scale - float64(p.X) + float64(m.width)/2
+	m.center[1] = py*scale - float64(p.Y) + float64(m.height)/2
+	// give failed tiles another chance
+	m.failed = make(map[mapTile]bool)
+	m.RepaintAll()
+}
+
+func (m *mapView) AddMarker(mk MapMarker) (id int) {
+	id = m.next
+	m.next++
+	mm := mk
+	m.markers[id] = &mm
+	m.RepaintAll()
+	return id
+}
+
+func (m *mapView) RemoveMarker(id int) {
+	delete(m.markers, id)
+	m.RepaintAll()
+}
+
+func (m *mapView) AddPolyline(p MapPolyline) (id int) {
+	id = m.next
+	m.next++
+	pp := p
+	pp.Points = append([]LatLng(nil), p.Points...)
+	m.polylines[id] = &pp
+	m.RepaintAll()
+	return id
+}
+
+func (m *mapView) RemovePolyline(id int) {
+	delete(m.polylines, id)
+	m.RepaintAll()
+}
+
+func (m *mapView) OnClicked(f func(pos LatLng)) {
+	m.clicked = f
+}
+
+func (m *mapView) OnMarkerClicked(f func(id int)) {
+	m.markerClicked = f
+}
+
+func (mk *MapMarker) rect(p image.Point) image.Rectangle {
+	if mk.Image == nil {
+		return image.Rect(p.X-mapMarkerRadius, p.Y-mapMarkerRadius, p.X+mapMarkerRadius+1, p.Y+mapMarkerRadius+1)
+	}
+	s := mk.Image.Rect.Size()
+	return image.Rect(p.X-s.X/2, p.Y-s.Y, p.X-s.X/2+s.X, p.Y)
+}
+
+// markerAt returns the marker under p; markers with higher IDs are drawn on top, so they win
+func (m *mapView) markerAt(p image.Point) (id int, ok bool) {
+	id = -1
+	for mid, mk := range m.markers {
+		if mid > id && p.In(mk.rect(m.toArea(mk.Pos))) {
+			id = mid
+		}
+	}
+	return id, id >= 0
+}
+
+func (m *mapView) Mouse(me MouseEvent) {
+	switch {
+	case me.Down == 1 && me.Count >= 2:
+		zoom := m.zoom + 1
+		if (me.Modifiers & Shift) != 0 {
+			zoom = m.zoom - 1
+		}
+		m.zoomAt(me.Pos, zoom)
+		m.dragging = false
+	case me.Down == 1:
+		m.dragging = true
+		m.dragStart = me.Pos
+		m.dragCenter = m.center
+		m.dragMoved = false
+	case me.Up == 1 && m.dragging:
+		m.dragging = false
+		if m.dragMoved {
+			return
+		}
+		if id, ok := m.markerAt(me.Pos); ok {
+			if m.markerClicked != nil {
+				m.markerClicked(id)
+			}
+		} else if m.clicked != nil {
+			m.clicked(m.fromArea(me.Pos))
+		}
+	case me.Down == 0 && me.Up == 0 && m.dragging:
+		d := me.Pos.Sub(m.dragStart)
+		if d.X*d.X+d.Y*d.Y > mapClickSlop*mapClickSlop {
+			m.dragMoved = true
+		}
+		if m.dragMoved {
+			m.center = [2]float64{m.dragCenter[0] - float64(d.X), m.dragCenter[1] - float64(d.Y)}
+			m.RepaintAll()
+		}
+	}
+}
+
+func (m *mapView) Key(ke KeyEvent) bool {
+	if ke.Up || (ke.Modifiers&Ctrl) == 0 {
+		return false
+	}
+	switch ke.Key {
+	case '=':
+		m.SetZoom(m.zoom + 1)
+		return true
+	case '-':
+		m.SetZoom(m.zoom - 1)
+		return true
+	}
+	return false
+}
+
+var (
+	mapBackground    = color.RGBA{0xE0, 0xE0, 0xE0, 0xFF}
+	mapMarkerFill    = color.RGBA{0xD0, 0x20, 0x20, 0xFF}
+	mapMarkerBorder  = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	mapPolylineColor = color.RGBA{0x30, 0x70, 0xD0, 0xFF}
+)
+
+func (m *mapView) Paint(cliprect image.Rectangle) image.Image {
+	img := image.NewRGBA(cliprect)
+	draw.Draw(img, cliprect, &image.Uniform{mapBackground}, image.ZP, draw.Src)
+
+	ox, oy := m.origin()
+	// the world wraps around horizontally but not vertically
+	ntiles := 1 << uint(m.zoom)
+	tx0 := int(math.Floor((ox + float64(cliprect.Min.X)) / mapTileSize))
+	ty0 := int(math.Floor((oy + float64(cliprect.Min.Y)) / mapTileSize))
+	tx1 := int(math.Floor((ox + float64(cliprect.Max.X-1)) / mapTileSize))
+	ty1 := int(math.Floor((oy + float64(cliprect.Max.Y-1)) / mapTileSize))
+	for ty := ty0; ty <= ty1; ty++ {
+		if ty < 0 || ty >= ntiles {
+			continue
+		}
+		for tx := tx0; tx <= tx1; tx++ {
+			t := mapTile{m.zoom, ((tx % ntiles) + ntiles) % ntiles, ty}
+			timg, ok := m.tiles[t]
+			if !ok {
+				m.request(t)
+				continue
+			}
+			p := image.Pt(int(math.Floor(float64(tx*mapTileSize)-ox)), int(math.Floor(float64(ty*mapTileSize)-oy)))
+			r := image.Rectangle{p, p.Add(image.Pt(mapTileSize, mapTileSize))}
+			draw.Draw(img, r, timg, timg.Rect.Min, draw.Src)
+		}
+	}
+
+	for _, pl := range m.polylines {
+		c := pl.Color
+		if c == (color.RGBA{}) {
+			c = mapPolylineColor
+		}
+		for i := 1; i < len(pl.Points); i++ {
+			drawLine(img, m.toArea(pl.Points[i-1]), m.toArea(pl.Points[i]), c)
+		}
+	}
+	// in ID order so markerAt() agrees with what's on top
+	for id := 0; id < m.next; id++ {
+		mk, ok := m.markers[id]
+		if !ok {
+			continue
+		}
+		p := m.toArea(mk.Pos)
+		if !mk.rect(p).Overlaps(cliprect) {
+			continue
+		}
+		if mk.Image == nil {
+			fillCircle(img, p, mapMarkerRadius, mapMarkerBorder)
+			fillCircle(img, p, mapMarkerRadius-2, mapMarkerFill)
+			continue
+		}
+		draw.Draw(img, mk.rect(p), mk.Image, mk.Image.Rect.Min, draw.Over)
+	}
+	return img
+}
+
+// tile loading: request() starts a goroutine that calls Fetch and hands the tile back on the main goroutine
+
+func (m *mapView) request(t mapTile) {
+	if m.pending[t] || m.failed[t] {
+		return
+	}
+	m.pending[t] = true
+	go func() {
+		m.fetch <- struct{}{}
+		img, err := m.source.Fetch(t.z, t.x, t.y)
+		<-m.fetch
+		if err == nil && img == nil {
+			err = fmt.Errorf("TileSource.Fetch() returned no tile and no error for zoom %d tile (%d,%d)", t.z, t.x, t.y)
+		}
+		Do(func() {
+			delete(m.pending, t)
+			if err != nil {
+				// remember it so we don't keep trying
+				m.failed[t] = true
+				return
+			}
+			m.tiles[t] = toRGBA(img)
+			m.evict()
+			if t.z == m.zoom {
+				m.RepaintAll()
+			}
+		})
+	}()
+}
+
+// evict keeps the number of tiles in memory down, dropping tiles from other zoom levels first and then the ones farthest from the center
+func (m *mapView) evict() {
+	if len(m.tiles) <= mapMaxTiles {
+		return
+	}
+	for t := range m.tiles {
+		if t.z != m.zoom {
+			delete(m.tiles, t)
+		}
+	}
+	cx, cy := m.center[0]/mapTileSize, m.center[1]/mapTileSize
+	for len(m.tiles) > mapMaxTiles {
+		var far mapTile
+		fard := -1.0
+		for t := range m.tiles {
+			dx, dy := float64(t.x)-cx, float64(t.y)-cy
+			if d := dx*dx + dy*dy; d > fard {
+				far, fard = t, d
+			}
+		}
+		delete(m.tiles, far)
+	}
+}
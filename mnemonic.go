@@ -0,0 +1,56 @@
+// 15 october 2026
+
+package ui
+
+// The text of Buttons, Checkboxes, Labels, and PopupMenu items marks its mnemonic the Windows way, with an & before the mnemonic character and && for a literal &.
+// GTK+ uses _ instead, and Mac OS X has no mnemonics at all, so these convert.
+
+// stripMnemonic returns text as it should be shown on systems without mnemonics.
+func stripMnemonic(text string) string {
+	b := make([]byte, 0, len(text))
+	for i := 0; i < len(text); i++ {
+		if text[i] == '&' && i+1 < len(text) {
+			i++
+		}
+		b = append(b, text[i])
+	}
+	return string(b)
+}
+
+// toUnderlineMnemonic converts text to GTK+'s form: "&x" becomes "_x", "&&" becomes "&", and a literal _ is doubled.
+func toUnderlineMnemonic(text string) string {
+	b := make([]byte, 0, len(text)+1)
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '&' && i+1 < len(text) && text[i+1] == '&':
+			b = append(b, '&')
+			i++
+		case text[i] == '&' && i+1 < len(text):
+			b = append(b, '_')
+		case text[i] == '_':
+			b = append(b, '_', '_')
+		default:
+			b = append(b, text[i])
+		}
+	}
+	return string(b)
+}
+
+// fromUnderlineMnemonic undoes toUnderlineMnemonic().
+func fromUnderlineMnemonic(text string) string {
+	b := make([]byte, 0, len(text)+1)
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '_' && i+1 < len(text) && text[i+1] == '_':
+			b = append(b, '_')
+			i++
+		case text[i] == '_' && i+1 < len(text):
+			b = append(b, '&')
+		case text[i] == '&':
+			b = append(b, '&', '&')
+		default:
+			b = append(b, text[i])
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,49 @@
+// 14 october 2026
+
+package ui
+
+// Online returns whether the computer is connected to a network that leads anywhere beyond itself; specifically, whether it has a default route.
+// This doesn't guarantee that any particular server can be reached (the route might lead to a captive portal, for instance), so programs should still handle network errors; use Online to decide what to offer the user, not whether to try.
+func Online() bool {
+	return online()
+}
+
+// NetworkWatcher tells a program when the computer's network connection changes.
+type NetworkWatcher struct {
+	changed func(online bool)
+}
+
+var (
+	networkWatchers       = make(map[*NetworkWatcher]struct{})
+	networkMonitorStarted bool
+)
+
+// WatchNetwork creates a NetworkWatcher that calls changed on the main thread whenever the network configuration changes, with the new value of Online().
+// Not every change changes Online(); switching from one wireless network to another is still a change, since connections made on the old network may no longer work.
+// Some systems report one change as several, so changed may be called more than once in a row with the same value.
+// Unlike IdleWatcher and PowerWatcher, this uses the system's own notifications, so there is no delay.
+func WatchNetwork(changed func(online bool)) *NetworkWatcher {
+	if !networkMonitorStarted {
+		startNetworkMonitor()
+		networkMonitorStarted = true
+	}
+	w := &NetworkWatcher{
+		changed: changed,
+	}
+	networkWatchers[w] = struct{}{}
+	return w
+}
+
+// Stop stops the NetworkWatcher; changed will not be called again.
+func (w *NetworkWatcher) Stop() {
+	delete(networkWatchers, w)
+}
+
+// networkChanged is called by the per-platform code on the main thread when the network configuration changes.
+// The system monitor is left running after the last NetworkWatcher stops; it costs next to nothing and saves starting it again.
+func networkChanged() {
+	o := online()
+	for w := range networkWatchers {
+		w.changed(o)
+	}
+}
@@ -0,0 +1,19 @@
+// 14 october 2026
+
+package ui
+
+// #include "objc_darwin.h"
+import "C"
+
+func online() bool {
+	return fromBOOL(C.networkOnline())
+}
+
+func startNetworkMonitor() {
+	C.startNetworkMonitor()
+}
+
+//export networkMonitorChanged
+func networkMonitorChanged() {
+	networkChanged()
+}
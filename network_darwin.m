@@ -0,0 +1,52 @@
+// 14 october 2026
+
+#include "objc_darwin.h"
+#include "_cgo_export.h"
+#import <SystemConfiguration/SystemConfiguration.h>
+#include <string.h>
+#include <sys/socket.h>
+#include <netinet/in.h>
+
+// reachability of 0.0.0.0 is Apple's way of asking "is there a default route"
+static SCNetworkReachabilityRef reachability = NULL;
+
+static BOOL makeReachability(void)
+{
+	struct sockaddr_in zero;
+
+	if (reachability != NULL)
+		return YES;
+	memset(&zero, 0, sizeof (struct sockaddr_in));
+	zero.sin_len = sizeof (struct sockaddr_in);
+	zero.sin_family = AF_INET;
+	reachability = SCNetworkReachabilityCreateWithAddress(NULL, (const struct sockaddr *) (&zero));
+	return reachability != NULL;
+}
+
+BOOL networkOnline(void)
+{
+	SCNetworkReachabilityFlags flags;
+
+	if (!makeReachability())
+		return NO;
+	if (!SCNetworkReachabilityGetFlags(reachability, &flags))
+		return NO;
+	// ConnectionRequired means something like a dial-up connection has to be made first
+	return (flags & kSCNetworkReachabilityFlagsReachable) != 0 &&
+		(flags & kSCNetworkReachabilityFlagsConnectionRequired) == 0;
+}
+
+static void reachabilityChanged(SCNetworkReachabilityRef r, SCNetworkReachabilityFlags flags, void *info)
+{
+	networkMonitorChanged();
+}
+
+void startNetworkMonitor(void)
+{
+	// if we can't make one, we just never report changes
+	if (!makeReachability())
+		return;
+	SCNetworkReachabilitySetCallback(reachability, reachabilityChanged, NULL);
+	// the main run loop means we're called on the main thread
+	SCNetworkReachabilityScheduleWithRunLoop(reachability, CFRunLoopGetMain(), kCFRunLoopCommonModes);
+}
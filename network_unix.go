@@ -0,0 +1,32 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+package ui
+
+import (
+	"unsafe"
+)
+
+// #include "gtk_unix.h"
+// extern void networkMonitorChanged(GNetworkMonitor *, gboolean, gpointer);
+import "C"
+
+// GNetworkMonitor's default implementation also decides by looking for a default route, so this matches the other platforms
+func online() bool {
+	return fromgbool(C.g_network_monitor_get_network_available(C.g_network_monitor_get_default()))
+}
+
+func startNetworkMonitor() {
+	// the default monitor is created on first use and emits its signals on the main context, so we're called on the main thread
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(C.g_network_monitor_get_default())),
+		"network-changed",
+		C.GCallback(C.networkMonitorChanged),
+		nil)
+}
+
+//export networkMonitorChanged
+func networkMonitorChanged(monitor *C.GNetworkMonitor, available C.gboolean, data C.gpointer) {
+	networkChanged()
+}
@@ -0,0 +1,48 @@
+// 14 october 2026
+
+#include "winapi_windows.h"
+#include "_cgo_export.h"
+
+// GetIpForwardTable() is IPv4 only; the IPv6 version needs Vista
+// an IPv6-only network isn't something XP users will have, though
+BOOL networkOnline(void)
+{
+	MIB_IPFORWARDTABLE *table;
+	ULONG size;
+	DWORD err;
+	DWORD i;
+	BOOL online;
+
+	size = 0;
+	table = NULL;
+	for (;;) {
+		err = GetIpForwardTable(table, &size, FALSE);
+		if (err == NO_ERROR)
+			break;
+		if (table != NULL)
+			free(table);
+		if (err == ERROR_NO_DATA)		// no routes at all
+			return FALSE;
+		// size is now the size needed; because routes can be added between calls, we may have to go around more than once
+		if (err != ERROR_INSUFFICIENT_BUFFER)
+			xpanic("error getting routing table in Online()", err);
+		table = (MIB_IPFORWARDTABLE *) malloc(size);
+		if (table == NULL)
+			xpanic("memory exhausted allocating routing table in Online()", GetLastError());
+	}
+	online = FALSE;
+	for (i = 0; i < table->dwNumEntries; i++)
+		if (table->table[i].dwForwardDest == 0 && table->table[i].dwForwardMask == 0) {
+			online = TRUE;
+			break;
+		}
+	free(table);
+	return online;
+}
+
+// this blocks until the routing table changes, and returns FALSE if it can't wait
+// any address change also changes the routing table, so this catches those too
+BOOL waitNetworkChange(void)
+{
+	return NotifyRouteChange(NULL, NULL) == NO_ERROR;
+}
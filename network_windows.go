@@ -0,0 +1,19 @@
+// 14 october 2026
+
+package ui
+
+// #include "winapi_windows.h"
+import "C"
+
+func online() bool {
+	return C.networkOnline() != C.FALSE
+}
+
+// NotifyRouteChange() either blocks or signals an event handle, neither of which fits our message loop, so a goroutine waits for changes and passes each one over
+func startNetworkMonitor() {
+	go func() {
+		for C.waitNetworkChange() != C.FALSE {
+			Do(networkChanged)
+		}
+	}()
+}
@@ -0,0 +1,24 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"image"
+)
+
+// Notify posts a desktop notification with the given title, body, and icon, for telling the user about something that happened while they were doing something else, such as a long task finishing.
+// The notification is shown by the system, outside any Window, and goes away on its own; clicking it does nothing in the program.
+// icon may be nil, in which case the system shows the program's icon or none at all; otherwise it should be square and at least 48x48.
+// body is plain text.
+//
+// Notify returns an error if the system has no way to show the notification: on Unix systems, if nothing on the session bus provides org.freedesktop.Notifications; on Mac OS X, on versions older than 10.8 or if the program is not in an application bundle; on Windows, if the notification area can't be used.
+// A nil error does not mean the user saw the notification; the system can hide notifications (for instance, in Do Not Disturb or Focus Assist mode) without telling the program.
+//
+// On Windows, the notification is a balloon from an icon package ui adds to the notification area for the purpose and removes when the balloon goes away; Windows 10 and newer show it as a toast.
+// Like other functions in package ui, Notify must be called on the main thread; use Do() if you need to call it elsewhere.
+func Notify(title string, body string, icon image.Image) error {
+	if icon == nil {
+		return notify(title, body, nil)
+	}
+	return notify(title, body, toRGBA(icon))
+}
@@ -0,0 +1,32 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"image"
+	"unsafe"
+)
+
+// #include "objc_darwin.h"
+import "C"
+
+func notify(title string, body string, icon *image.RGBA) error {
+	var pixels unsafe.Pointer
+	var width, height, stride C.intptr_t
+
+	ctitle := C.CString(title)
+	defer C.free(unsafe.Pointer(ctitle))
+	cbody := C.CString(body)
+	defer C.free(unsafe.Pointer(cbody))
+	if icon != nil {
+		pixels = unsafe.Pointer(pixelData(icon))
+		width = C.intptr_t(icon.Rect.Dx())
+		height = C.intptr_t(icon.Rect.Dy())
+		stride = C.intptr_t(icon.Stride)
+	}
+	if C.postNotification(ctitle, cbody, pixels, width, height, stride) == C.NO {
+		return fmt.Errorf("notifications need Mac OS X 10.8 or newer and an application bundle")
+	}
+	return nil
+}
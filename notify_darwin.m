@@ -0,0 +1,56 @@
+// 15 october 2026
+
+#include "objc_darwin.h"
+#import <Cocoa/Cocoa.h>
+
+// NSUserNotification and NSUserNotificationCenter are 10.8 and newer, and we build against the 10.7 SDK, so everything here goes through the runtime
+
+// without a delegate that says otherwise, the notification center only shows notifications while the program is in the background
+@interface goNotificationDelegate : NSObject
+@end
+
+@implementation goNotificationDelegate
+
+- (BOOL)userNotificationCenter:(id)center shouldPresentNotification:(id)notification
+{
+	return YES;
+}
+
+@end
+
+static goNotificationDelegate *notificationDelegate = nil;
+
+// returns NO on 10.7 and for programs that aren't in an application bundle, which the notification center has no way to show
+BOOL postNotification(char *title, char *body, void *pixels, intptr_t width, intptr_t height, intptr_t stride)
+{
+	Class centerClass, notificationClass;
+	id center, notification;
+	NSImage *image;
+
+	centerClass = NSClassFromString(@"NSUserNotificationCenter");
+	notificationClass = NSClassFromString(@"NSUserNotification");
+	if (centerClass == nil || notificationClass == nil)
+		return NO;
+	if ([[NSBundle mainBundle] bundleIdentifier] == nil)
+		return NO;
+	center = [centerClass performSelector:@selector(defaultUserNotificationCenter)];
+	if (center == nil)
+		return NO;
+	if (notificationDelegate == nil) {
+		notificationDelegate = [goNotificationDelegate new];
+		// the notification center does not retain its delegate; ours lives for the rest of the program
+		[center performSelector:@selector(setDelegate:) withObject:notificationDelegate];
+	}
+	notification = [notificationClass new];
+	[notification setValue:[NSString stringWithUTF8String:title] forKey:@"title"];
+	[notification setValue:[NSString stringWithUTF8String:body] forKey:@"informativeText"];
+	// contentImage is 10.9 and newer; on 10.8 the notification shows the program's icon only
+	if (pixels != NULL && [notification respondsToSelector:@selector(setContentImage:)]) {
+		image = (NSImage *) toTableImage(pixels, width, height, stride);
+		[notification setValue:image forKey:@"contentImage"];
+		[image release];
+	}
+	[center performSelector:@selector(deliverNotification:) withObject:notification];
+	[notification release];
+	return YES;
+}
@@ -0,0 +1,45 @@
+// +build !windows,!darwin
+
+// 15 october 2026
+
+#include "gtk_unix.h"
+
+static GDBusConnection *notifyBus = NULL;
+
+#define notificationsName "org.freedesktop.Notifications"
+#define notificationsPath "/org/freedesktop/Notifications"
+
+// returns FALSE if there's no session bus or no notification server on it
+// see https://specifications.freedesktop.org/notification-spec/latest/ for the hints
+gboolean postNotification(gchar *app, gchar *title, gchar *body, GdkPixbuf *icon)
+{
+	GVariantBuilder hints;
+	GVariant *ret;
+	gchar *escaped;
+
+	if (notifyBus == NULL) {
+		notifyBus = g_bus_get_sync(G_BUS_TYPE_SESSION, NULL, NULL);
+		if (notifyBus == NULL)
+			return FALSE;
+	}
+	g_variant_builder_init(&hints, G_VARIANT_TYPE("a{sv}"));
+	if (icon != NULL)
+		g_variant_builder_add(&hints, "{sv}", "image-data",
+			g_variant_new("(iiibii@ay)",
+				gdk_pixbuf_get_width(icon), gdk_pixbuf_get_height(icon),
+				gdk_pixbuf_get_rowstride(icon), gdk_pixbuf_get_has_alpha(icon),
+				gdk_pixbuf_get_bits_per_sample(icon), gdk_pixbuf_get_n_channels(icon),
+				g_variant_new_fixed_array(G_VARIANT_TYPE_BYTE,
+					gdk_pixbuf_get_pixels(icon), gdk_pixbuf_get_byte_length(icon), 1)));
+	// servers that advertise body-markup treat the body as markup, and the rest ignore the escapes
+	escaped = g_markup_escape_text(body, -1);
+	// 0 means this is a new notification rather than a replacement, "" means no icon name, NULL means no actions, and -1 means the server decides when it goes away
+	ret = g_dbus_connection_call_sync(notifyBus, notificationsName, notificationsPath, notificationsName,
+		"Notify", g_variant_new("(susssasa{sv}i)", app, 0, "", title, escaped, NULL, &hints, -1),
+		G_VARIANT_TYPE("(u)"), G_DBUS_CALL_FLAGS_NONE, -1, NULL, NULL);
+	g_free(escaped);
+	if (ret == NULL)
+		return FALSE;
+	g_variant_unref(ret);
+	return TRUE;
+}
@@ -0,0 +1,35 @@
+// +build !windows,!darwin
+
+// 15 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"unsafe"
+)
+
+// #include "gtk_unix.h"
+import "C"
+
+func notify(title string, body string, icon *image.RGBA) error {
+	var pixbuf *C.GdkPixbuf
+
+	capp := togstr(filepath.Base(os.Args[0]))
+	defer freegstr(capp)
+	ctitle := togstr(title)
+	defer freegstr(ctitle)
+	cbody := togstr(body)
+	defer freegstr(cbody)
+	if icon != nil {
+		pixbuf = toGdkPixbuf(icon)
+		defer C.g_object_unref(C.gpointer(unsafe.Pointer(pixbuf)))
+	}
+	if C.postNotification(capp, ctitle, cbody, pixbuf) == C.FALSE {
+		return fmt.Errorf("no notification server on the session bus")
+	}
+	return nil
+}
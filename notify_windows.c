@@ -0,0 +1,81 @@
+// 15 october 2026
+
+#include "winapi_windows.h"
+
+// MinGW-w64 only defines this for Windows XP SP2 and newer, which is also the first version to support it; older versions show no icon in the balloon
+#ifndef NIIF_USER
+#define NIIF_USER 0x00000004
+#endif
+
+#define notifyIconID 1
+
+static BOOL notifyIconAdded = FALSE;
+static HICON notifyIcon = NULL;
+
+// a balloon can only come from a notification area icon, so we add one for each notification and take it out again in notifyIconEvent()
+BOOL postNotification(LPWSTR title, LPWSTR body, HICON icon)
+{
+	NOTIFYICONDATAW nid;
+	HICON prev;
+
+	ZeroMemory(&nid, sizeof (NOTIFYICONDATAW));
+	nid.cbSize = sizeof (NOTIFYICONDATAW);
+	nid.hWnd = msgwin;
+	nid.uID = notifyIconID;
+	nid.uFlags = NIF_MESSAGE | NIF_ICON | NIF_INFO;
+	nid.uCallbackMessage = msgNotifyIcon;
+	nid.hIcon = icon;
+	nid.dwInfoFlags = NIIF_USER;		// the balloon uses hIcon
+	if (icon == NULL) {
+		nid.hIcon = hDefaultIcon;
+		nid.dwInfoFlags = NIIF_INFO;
+	}
+	// the balloon has fixed-size buffers; longer text is cut off
+	wcsncpy(nid.szInfoTitle, title, sizeof nid.szInfoTitle / sizeof nid.szInfoTitle[0] - 1);
+	wcsncpy(nid.szInfo, body, sizeof nid.szInfo / sizeof nid.szInfo[0] - 1);
+	// the balloon needs text to show; a single space is what Microsoft's own examples use for an empty body
+	if (nid.szInfo[0] == L'\0')
+		wcscpy(nid.szInfo, L" ");
+	// NIM_MODIFY replaces the balloon from a previous postNotification() that is still showing
+	if (Shell_NotifyIconW(notifyIconAdded ? NIM_MODIFY : NIM_ADD, &nid) == FALSE) {
+		if (icon != NULL)
+			freeIcons(icon, NULL);
+		return FALSE;
+	}
+	notifyIconAdded = TRUE;
+	prev = notifyIcon;
+	notifyIcon = icon;
+	if (prev != NULL)
+		freeIcons(prev, NULL);
+	return TRUE;
+}
+
+static void removeNotifyIcon(void)
+{
+	NOTIFYICONDATAW nid;
+
+	if (!notifyIconAdded)
+		return;
+	ZeroMemory(&nid, sizeof (NOTIFYICONDATAW));
+	nid.cbSize = sizeof (NOTIFYICONDATAW);
+	nid.hWnd = msgwin;
+	nid.uID = notifyIconID;
+	// this fails if Explorer restarted since, but then the icon is already gone
+	Shell_NotifyIconW(NIM_DELETE, &nid);
+	notifyIconAdded = FALSE;
+	if (notifyIcon != NULL)
+		freeIcons(notifyIcon, NULL);
+	notifyIcon = NULL;
+}
+
+// lParam is the mouse or balloon message
+void notifyIconEvent(LPARAM lParam)
+{
+	switch (lParam) {
+	case NIN_BALLOONTIMEOUT:		// also sent when the user closes the balloon
+	case NIN_BALLOONUSERCLICK:
+	case NIN_BALLOONHIDE:
+	case WM_LBUTTONUP:			// Windows 10 and newer move the toast to the Action Center without sending any of the above, so clicking the leftover icon takes it away
+		removeNotifyIcon();
+	}
+}
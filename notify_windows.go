@@ -0,0 +1,27 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"image"
+	"unsafe"
+)
+
+// #include "winapi_windows.h"
+import "C"
+
+func notify(title string, body string, icon *image.RGBA) error {
+	var big, small C.HICON
+
+	if icon != nil {
+		// the notification area uses the small icon, and the balloon shows the same one on the versions of Windows that predate large balloon icons
+		C.newIcons(unsafe.Pointer(icon), C.intptr_t(icon.Rect.Dx()), C.intptr_t(icon.Rect.Dy()), &big, &small)
+		C.freeIcons(big, nil)
+	}
+	// postNotification() takes ownership of small
+	if C.postNotification(toUTF16(title), toUTF16(body), small) == C.FALSE {
+		return fmt.Errorf("error adding notification area icon for notification")
+	}
+	return nil
+}
@@ -56,6 +56,38 @@ extern void windowHide(id);
 extern void windowClose(id);
 extern id windowContentView(id);
 extern void windowRedraw(id);
+extern void windowSetDocument(id, const char *, BOOL);
+extern void windowSetBorderless(id, BOOL);
+extern BOOL windowBorderless(id);
+extern BOOL windowDragRegionMouseDown(id, id);
+extern void windowSetOwner(id, id);
+extern struct xpoint windowPosition(id);
+extern void windowSetPosition(id, intptr_t, intptr_t);
+extern struct xsize windowSize(id);
+extern void windowSetSize(id, intptr_t, intptr_t);
+extern void windowCenter(id);
+extern void windowMaximize(id);
+extern void windowMinimize(id);
+extern void windowRestore(id);
+extern void windowSetFullscreen(id, BOOL);
+enum {
+	windowStateNormal,
+	windowStateMinimized,
+	windowStateMaximized,
+	windowStateFullscreen,
+};
+extern int windowState(id);
+extern void windowSetTabOrder(id, id *, intptr_t);
+extern void appSetIcon(void *, intptr_t, intptr_t, intptr_t);
+struct windowSnapshot {
+	id bitmap;
+	void *pixels;
+	intptr_t width;
+	intptr_t height;
+	intptr_t stride;
+};
+extern void windowSnapshot(id, struct windowSnapshot *);
+extern void windowFreeSnapshot(struct windowSnapshot *);
 
 /* basicctrls_darwin.m */
 #define textfieldWidth (96)		/* according to Interface Builder */
@@ -67,6 +99,11 @@ extern id newCheckbox(void);
 extern void checkboxSetDelegate(id, void *);
 extern BOOL checkboxChecked(id);
 extern void checkboxSetChecked(id, BOOL);
+extern id newRadioButtons(intptr_t);
+extern void radiobuttonsSetDelegate(id, void *);
+extern void radiobuttonsSetText(id, intptr_t, char *);
+extern intptr_t radiobuttonsSelected(id);
+extern void radiobuttonsSelect(id, intptr_t);
 extern id finishNewTextField(id, BOOL);
 extern id newTextField(void);
 extern id newPasswordField(void);
@@ -77,6 +114,8 @@ extern id textfieldOpenInvalidPopover(id, char *);
 extern void textfieldCloseInvalidPopover(id);
 extern BOOL textfieldEditable(id);
 extern void textfieldSetEditable(id, BOOL);
+extern void textfieldSelect(id, intptr_t, intptr_t);
+extern intptr_t textfieldCursorPos(id);
 extern id newLabel(void);
 extern id newGroup(id);
 extern const char *groupText(id);
@@ -84,18 +123,36 @@ extern void groupSetText(id, char *);
 extern id newTextbox(void);
 extern char *textboxText(id);
 extern void textboxSetText(id, char *);
+extern void textboxSetDelegate(id, void *);
+extern void textboxAppend(id, char *);
+extern BOOL textboxEditable(id);
+extern void textboxSetEditable(id, BOOL);
+extern BOOL textboxWordWrap(id);
+extern void textboxSetWordWrap(id, BOOL);
 extern id newProgressBar(void);
 extern intmax_t progressbarPercent(id);
 extern void progressbarSetPercent(id, intmax_t);
+extern BOOL progressbarIndeterminate(id);
+extern void progressbarSetIndeterminate(id, BOOL);
+extern id newSlider(intmax_t, intmax_t, BOOL);
+extern void sliderSetDelegate(id, void *);
+extern intmax_t sliderValue(id);
+extern void sliderSetValue(id, intmax_t);
+extern void sliderSetTickMarks(id, intmax_t);
 
 /* container_darwin.m */
 extern id newContainerView(void *);
 extern void moveControl(id, intptr_t, intptr_t, intptr_t, intptr_t);
 extern struct xrect containerBounds(id);
+extern void relayoutContainer(id);
+extern void relayoutAllContainers(id);
 
 /* tab_darwin.m */
-extern id newTab(void);
+extern id newTab(void *);
 extern void tabAppend(id, char *, id);
+extern void tabDelete(id, intptr_t);
+extern intptr_t tabSelected(id);
+extern void tabSelect(id, intptr_t);
 extern struct xsize tabPreferredSize(id);
 
 /* table_darwin.m */
@@ -111,12 +168,32 @@ extern void tableMakeDataSource(id, void *);
 extern struct xsize tablePreferredSize(id);
 extern intptr_t tableSelected(id);
 extern void tableSelect(id, intptr_t);
+extern void tableSetDragData(id, id, char **, intptr_t, char *, id, id, intptr_t, intptr_t, char **, intptr_t, BOOL, uintptr_t);
+
+/* tree_darwin.m */
+extern id newTree(void);
+extern void treeMakeDataSource(id, void *);
+extern void treeReload(id);
+extern void treeExpandedPaths(id, void *);
+extern intptr_t *treeSelected(id, intptr_t *);
+extern void treeSelect(id, intptr_t *, intptr_t);
+extern void treeExpand(id, intptr_t *, intptr_t, BOOL);
+extern struct xsize treePreferredSize(id);
+extern intptr_t *treeRowIndexes(id, intptr_t, intptr_t *);
 
 /* control_darwin.m */
 extern void parent(id, id);
+extern void controlDestroy(id);
 extern void controlSetHidden(id, BOOL);
+extern void controlSetTooltip(id, char *);
+extern void controlSetFocus(id);
+extern BOOL controlContains(id, id);
+extern void controlScrollIntoView(id);
+extern void controlSetEnabled(id, BOOL);
 extern void setStandardControlFont(id);
 extern void setSmallControlFont(id);
+extern void setGroupTitleFont(id);
+extern void setScaleFactor(double);
 extern struct xsize controlPreferredSize(id);
 extern id newScrollView(id, BOOL);
 extern struct xalignment alignmentInfo(id, struct xrect);
@@ -125,7 +202,7 @@ extern struct xalignment alignmentInfoFrame(id);
 /* area_darwin.h */
 extern Class getAreaClass(void);
 extern id newArea(void *);
-extern BOOL drawImage(void *, intptr_t, intptr_t, intptr_t, intptr_t, intptr_t);
+extern BOOL drawImage(void *, intptr_t, intptr_t, intptr_t, intptr_t, intptr_t, intptr_t, intptr_t);
 extern const uintptr_t cNSShiftKeyMask;
 extern const uintptr_t cNSControlKeyMask;
 extern const uintptr_t cNSAlternateKeyMask;
@@ -134,13 +211,43 @@ extern uintptr_t modifierFlags(id);
 extern struct xpoint getTranslatedEventPoint(id, id);
 extern intptr_t buttonNumber(id);
 extern intptr_t clickCount(id);
+struct areaPen {
+	double pressure;
+	double tiltX;
+	double tiltY;
+	BOOL eraser;
+};
+extern BOOL penState(id, struct areaPen *);
+extern double doubleClickInterval(void);
 extern uintptr_t pressedMouseButtons(void);
 extern uintptr_t keyCode(id);
+extern uint32_t keyCharacter(id);
 extern void areaRepaint(id, struct xrect);
 extern void areaRepaintAll(id);
 extern void areaTextFieldOpen(id, id, intptr_t, intptr_t);
 extern void areaSetTextField(id, id);
+extern void areaSetFocus(id);
+extern void areaWatchScroll(id);
+extern void areaScrollTo(id, intptr_t, intptr_t);
+extern struct xpoint areaScrollPos(id);
 extern void areaEndTextFieldEditing(id, id);
+extern void areaResetCursorRects(id);
+extern void areaSetKinetic(id, id, BOOL);
+extern double areaPixelRatio(id);
+enum {
+	dropFormatFiles = 1 << 0,
+	dropFormatText = 1 << 1,
+	dropFormatImage = 1 << 2,
+	dropFormatLocal = 1 << 3,
+};
+extern void areaSetDropTarget(id);
+extern uintptr_t dropFormats(id);
+extern void dropSetImage(void *, id);
+extern const uintptr_t cNSDragOperationCopy;
+extern const uintptr_t cNSDragOperationMove;
+extern const uintptr_t cNSDragOperationLink;
+extern const uintptr_t cNSDragOperationGeneric;
+extern void areaStartDrag(id, id, char **, intptr_t, char *, id, id, intptr_t, intptr_t, char **, intptr_t, BOOL, uintptr_t);
 
 
 /* common_darwin.m */
@@ -149,18 +256,127 @@ extern void disableAutocorrect(id);
 /* image_darwin.m */
 extern id toTableImage(void *, intptr_t, intptr_t, intptr_t);
 
+/* cursor_darwin.m */
+extern id namedCursor(char *);
+extern id newImageCursor(void *, intptr_t, intptr_t, intptr_t, intptr_t, intptr_t);
+extern void setCursor(id);
+extern void setOverrideCursor(id);
+
 /* dialog_darwin.m */
-extern void openFile(id, void *);
+extern void openFile(id, BOOL, uintptr_t);
+extern void saveFile(id, char *, BOOL, uintptr_t);
+enum {
+	msgBoxOK,
+	msgBoxYes,
+	msgBoxNo,
+	msgBoxCancel,
+};
+extern void msgBox(id, char *, char *, BOOL, BOOL, BOOL, uintptr_t);
+extern void chooseColor(id, uint8_t, uint8_t, uint8_t, uintptr_t);
+extern id newFont(char *, double, intptr_t, BOOL);
+extern void chooseFont(id, char *, double, intptr_t, BOOL, uintptr_t);
+extern void windowBeginModal(id, id, BOOL);
+extern void windowEndModal(id, id, BOOL);
+
+/* colorbutton_darwin.m */
+extern id toNSColor(uint8_t, uint8_t, uint8_t);
+extern void fromNSColor(id, uint8_t *, uint8_t *, uint8_t *);
+extern id newColorWell(void);
+extern void colorWellSetDelegate(id, void *);
+extern void colorWellColor(id, uint8_t *, uint8_t *, uint8_t *);
+extern void colorWellSetColor(id, uint8_t, uint8_t, uint8_t);
 
 /* warningpopover_darwin.m */
 extern id newWarningPopover(char *);
 extern void warningPopoverShow(id, id);
 
+/* preview_darwin.m */
+extern void addHoverPreview(id, void *, BOOL);
+
+/* popupmenu_darwin.m */
+extern id newPopupMenu(void);
+extern void popupMenuAppend(id, char *, intptr_t, BOOL, BOOL);
+extern void popupMenuAppendSeparator(id);
+extern id popupMenuAppendSubmenu(id, char *, BOOL);
+extern void popupMenuShow(id, void *, id, intptr_t, intptr_t);
+
+/* idle_darwin.m */
+extern uint32_t inhibitIdle(char *);
+extern void uninhibitIdle(uint32_t);
+extern double idleTime(void);
+
+/* power_darwin.m */
+struct powerStatus {
+	BOOL onBattery;
+	intptr_t percent;
+	BOOL lowPower;
+};
+extern void getPowerStatus(struct powerStatus *);
+
+/* network_darwin.m */
+extern BOOL networkOnline(void);
+extern void startNetworkMonitor(void);
+
+/* notify_darwin.m */
+extern BOOL postNotification(char *, char *, void *, intptr_t, intptr_t, intptr_t);
+
+/* locale_darwin.m */
+extern const char *formatNumber(int64_t);
+extern const char *formatDecimal(double, intptr_t);
+extern const char *formatTime(int64_t, BOOL, BOOL);
+extern const char *formatFileSize(int64_t);
+
 /* spinbox_darwin.m */
 extern id newSpinbox(void *, intmax_t, intmax_t);
 extern id spinboxTextField(id);
 extern id spinboxStepper(id);
 extern intmax_t spinboxValue(id);
 extern void spinboxSetValue(id, intmax_t);
+extern void spinboxSetStep(id, intmax_t);
+extern void spinboxDestroy(id);
+
+/* text_darwin.m */
+struct textFrame {
+	void *frame;
+	intptr_t nLines;
+	double width;
+	double height;
+	double lineHeight;		// of the font, for the empty line CTFrame leaves out after a final newline
+	double ascent;
+};
+extern void newTextFrame(struct textFrame *, char *, id, intptr_t, intptr_t);
+struct textLine {
+	intptr_t start;			// in UTF-16 code units
+	intptr_t end;
+	double x;
+	double y;
+	double width;
+	double height;
+	double baseline;
+};
+extern void textFrameLine(struct textFrame *, intptr_t, struct textLine *);
+extern void renderTextFrame(struct textFrame *, void *, intptr_t, intptr_t, intptr_t);
+extern void freeTextFrame(struct textFrame *);
+
+/* clipboard_darwin.m */
+extern void clipboardBegin(uintptr_t);
+extern void clipboardAddData(char *, void *, intptr_t);
+extern void clipboardAddDelayed(char *, intptr_t);
+extern void clipboardEnd(void);
+extern void clipboardItemSetData(id, id, void *, intptr_t);
+extern intptr_t clipboardFormatCount(void);
+extern char *clipboardFormat(intptr_t);
+extern void *clipboardData(char *, intptr_t *);
+extern intptr_t clipboardChangeCount(void);
+
+/* glarea_darwin.m */
+extern id newGLArea(void *);
+extern void glAreaRepaint(id);
+
+/* speech_darwin.m */
+extern BOOL speak(char *);
+extern void stopSpeaking(void);
+extern BOOL startDictation(void);
+extern void stopDictation(void);
 
 #endif
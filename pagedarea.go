@@ -0,0 +1,471 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"time"
+)
+
+// PageHandler supplies the pages shown by a PagedArea.
+// As with AreaHandler, its methods are executed on the main goroutine.
+// A PageHandler can also implement AreaCustomHandler to receive the CustomEvents sent with Post.
+type PageHandler interface {
+	// Pages returns the number of pages.
+	// The PagedArea only calls it again after PagesChanged.
+	Pages() int
+
+	// PaintPage draws part of a page, as AreaHandler.Paint does for a whole Area: cliprect is in the page's own coordinates, with (0,0) at its top-left corner, and the image returned must have the same size as cliprect.
+	PaintPage(page int, cliprect image.Rectangle) image.Image
+
+	// PageMouse is called for mouse events over a page, with e.Pos in the page's coordinates.
+	// Events over the space between pages are not sent, and neither is anything while thumbnails are shown.
+	PageMouse(page int, e MouseEvent)
+
+	// PageKey is called for key events before the PagedArea handles its own keys (see PagedArea); return true to keep the PagedArea from handling the key too.
+	PageKey(e KeyEvent) (handled bool)
+}
+
+// PagedArea is an Area that shows a document as a column of pages of the same size, as document viewers and slide tools do.
+//
+// The pages are drawn one below the other, with a gap between them.
+// The current page is the one whose top is nearest the top of the visible part of the PagedArea.
+// With snapping on (the default), the PagedArea scrolls to the top of the current page shortly after the user stops scrolling, so a page is never left cut off at the top.
+// Page Up and Page Down go to the previous and next page, and Home and End to the first and last.
+//
+// Ctrl with - shows thumbnails of all the pages instead, several to a row, with the current page marked.
+// Clicking a thumbnail goes back to the pages at that page; so do Enter, Escape, and Ctrl with + (or =), at the current page.
+// That is where a pinch would go, but Areas aren't sent touch or trackpad gestures yet.
+//
+// PagedArea sizes its Area itself; SetSize sets the size of the pages.
+type PagedArea interface {
+	Area
+
+	// Page returns the current page.
+	// SetPage makes page the current page, scrolling to it; it panics if there is no such page.
+	// Near the end of the document, the last pages may not be able to reach the top of the visible part; SetPage scrolls as far as it can.
+	Page() int
+	SetPage(page int)
+
+	// OnPageChanged registers a function that is called when the current page changes, whether the user or the program changed it.
+	OnPageChanged(f func())
+
+	// Snap and SetSnap get and set whether the PagedArea snaps to the top of the current page after scrolling.
+	Snap() bool
+	SetSnap(snap bool)
+
+	// Thumbnails and SetThumbnails get and set whether the PagedArea shows thumbnails of the pages instead of the pages themselves.
+	// Thumbnails are made with PaintPage the first time they are shown and kept until RepaintPage or PagesChanged.
+	Thumbnails() bool
+	SetThumbnails(thumbnails bool)
+
+	// PagesChanged tells the PagedArea that the number of pages, or what is on them, has changed.
+	// The current page stays the same if it still exists, and is the last page otherwise.
+	PagesChanged()
+
+	// RepaintPage redraws one page, and its thumbnail.
+	// It panics if there is no such page.
+	RepaintPage(page int)
+}
+
+const (
+	pagedGap       = 16
+	pagedThumbDiv  = 5 // thumbnails are 1/5 of the page size
+	pagedSnapDelay = 150 * time.Millisecond
+)
+
+var (
+	pagedBackground = color.RGBA{0x80, 0x80, 0x80, 0xFF}
+	pagedBorder     = color.RGBA{0x40, 0x40, 0x40, 0xFF}
+	pagedCurrent    = color.RGBA{0x30, 0x70, 0xD0, 0xFF}
+)
+
+type pagedArea struct {
+	Area
+	handler PageHandler
+
+	pageWidth  int
+	pageHeight int
+	pages      int
+
+	page    int
+	changed *event
+
+	snap      bool
+	snapgen   int // incremented on every scroll, so an older snap timer knows it's stale
+	snaptimer *time.Timer
+	setAt     image.Point // where SetPage scrolled to; see Scrolled()
+	hasSetAt  bool
+
+	thumbnails bool
+	thumbs     map[int]*image.RGBA
+}
+
+// NewPagedArea creates a new PagedArea showing the pages from handler, each pageWidth by pageHeight pixels, starting at the first page.
+// It panics if pageWidth or pageHeight is zero or negative.
+func NewPagedArea(pageWidth int, pageHeight int, handler PageHandler) PagedArea {
+	checkPageSize(pageWidth, pageHeight, "NewPagedArea()")
+	p := &pagedArea{
+		handler:    handler,
+		pageWidth:  pageWidth,
+		pageHeight: pageHeight,
+		pages:      handler.Pages(),
+		changed:    newEvent(),
+		snap:       true,
+		thumbs:     make(map[int]*image.RGBA),
+	}
+	p.Area = NewArea(1, 1, p)
+	p.relayout()
+	return p
+}
+
+func checkPageSize(width int, height int, which string) {
+	if width <= 0 || height <= 0 {
+		panic(fmt.Errorf("invalid page size %dx%d given to %s", width, height, which))
+	}
+}
+
+func (p *pagedArea) checkPage(page int, which string) {
+	if page < 0 || page >= p.pages {
+		panic(fmt.Errorf("page %d out of range in PagedArea.%s", page, which))
+	}
+}
+
+func (p *pagedArea) stride() int {
+	return p.pageHeight + pagedGap
+}
+
+func (p *pagedArea) pageRect(page int) image.Rectangle {
+	y := pagedGap + page*p.stride()
+	return image.Rect(pagedGap, y, pagedGap+p.pageWidth, y+p.pageHeight)
+}
+
+// the scroll position that puts page at the top, with the gap above it showing
+func (p *pagedArea) pageTop(page int) int {
+	return page * p.stride()
+}
+
+func (p *pagedArea) pageAt(y int) int {
+	page := (y + p.stride()/2) / p.stride()
+	if page >= p.pages {
+		page = p.pages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	return page
+}
+
+func (p *pagedArea) thumbSize() image.Point {
+	w := p.pageWidth / pagedThumbDiv
+	h := p.pageHeight / pagedThumbDiv
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	return image.Pt(w, h)
+}
+
+// the thumbnails fill the width the pages take
+func (p *pagedArea) thumbColumns() int {
+	cols := (p.pageWidth + pagedGap) / (p.thumbSize().X + pagedGap)
+	if cols < 1 {
+		cols = 1
+	}
+	return cols
+}
+
+func (p *pagedArea) thumbRect(page int) image.Rectangle {
+	ts := p.thumbSize()
+	cols := p.thumbColumns()
+	min := image.Pt(pagedGap+(page%cols)*(ts.X+pagedGap), pagedGap+(page/cols)*(ts.Y+pagedGap))
+	return image.Rectangle{min, min.Add(ts)}
+}
+
+func (p *pagedArea) relayout() {
+	width := p.pageWidth + 2*pagedGap
+	height := pagedGap
+	if p.thumbnails {
+		cols := p.thumbColumns()
+		height += (p.pages + cols - 1) / cols * (p.thumbSize().Y + pagedGap)
+	} else {
+		height += p.pages * p.stride()
+	}
+	if height < 2*pagedGap {
+		height = 2 * pagedGap
+	}
+	p.Area.SetSize(width, height)
+}
+
+// SetSize is overridden to set the page size; the Area's own size follows from it.
+func (p *pagedArea) SetSize(width int, height int) {
+	checkPageSize(width, height, "PagedArea.SetSize()")
+	p.pageWidth = width
+	p.pageHeight = height
+	p.thumbs = make(map[int]*image.RGBA)
+	p.relayout()
+	p.scrollToPage()
+}
+
+func (p *pagedArea) Page() int {
+	return p.page
+}
+
+func (p *pagedArea) SetPage(page int) {
+	p.checkPage(page, "SetPage()")
+	p.setPage(page)
+	if p.thumbnails {
+		p.RepaintAll()
+		return
+	}
+	p.scrollToPage()
+}
+
+func (p *pagedArea) setPage(page int) {
+	if page == p.page {
+		return
+	}
+	p.page = page
+	p.changed.fire()
+}
+
+func (p *pagedArea) scrollToPage() {
+	if p.thumbnails {
+		return
+	}
+	p.ScrollTo(image.Pt(0, p.pageTop(p.page)))
+	p.setAt = p.ScrollPos()
+	p.hasSetAt = true
+}
+
+func (p *pagedArea) OnPageChanged(f func()) {
+	p.changed.set(f)
+}
+
+func (p *pagedArea) Snap() bool {
+	return p.snap
+}
+
+func (p *pagedArea) SetSnap(snap bool) {
+	p.snap = snap
+}
+
+func (p *pagedArea) Thumbnails() bool {
+	return p.thumbnails
+}
+
+func (p *pagedArea) SetThumbnails(thumbnails bool) {
+	if thumbnails == p.thumbnails {
+		return
+	}
+	p.thumbnails = thumbnails
+	p.relayout()
+	if thumbnails {
+		// show the row with the current page
+		p.ScrollTo(image.Pt(0, p.thumbRect(p.page).Min.Y-pagedGap))
+		return
+	}
+	p.scrollToPage()
+}
+
+func (p *pagedArea) PagesChanged() {
+	p.pages = p.handler.Pages()
+	p.thumbs = make(map[int]*image.RGBA)
+	p.relayout()
+	if p.page >= p.pages && p.pages > 0 {
+		p.setPage(p.pages - 1)
+		p.scrollToPage()
+	}
+}
+
+func (p *pagedArea) RepaintPage(page int) {
+	p.checkPage(page, "RepaintPage()")
+	delete(p.thumbs, page)
+	if p.thumbnails {
+		p.Repaint(p.thumbRect(page))
+		return
+	}
+	p.Repaint(p.pageRect(page))
+}
+
+// Scrolled keeps track of the current page and starts the snap timer.
+func (p *pagedArea) Scrolled(pos image.Point) {
+	if p.thumbnails {
+		return
+	}
+	// after SetPage, the position it scrolled to means the page it asked for, even if the last pages can't get to the top
+	if p.hasSetAt && pos == p.setAt {
+		return
+	}
+	p.hasSetAt = false
+	if p.pages == 0 {
+		return
+	}
+	p.setPage(p.pageAt(pos.Y))
+	if !p.snap {
+		return
+	}
+	p.snapgen++
+	gen := p.snapgen
+	if p.snaptimer != nil {
+		p.snaptimer.Stop()
+	}
+	p.snaptimer = time.AfterFunc(pagedSnapDelay, func() {
+		Do(func() {
+			if gen == p.snapgen && p.snap && !p.thumbnails {
+				p.scrollToPage()
+			}
+		})
+	})
+}
+
+func (p *pagedArea) Paint(cliprect image.Rectangle) image.Image {
+	img := image.NewRGBA(cliprect)
+	draw.Draw(img, cliprect, &image.Uniform{pagedBackground}, image.ZP, draw.Src)
+	for page := 0; page < p.pages; page++ {
+		if p.thumbnails {
+			p.paintThumb(img, page)
+		} else {
+			p.paintPage(img, page)
+		}
+	}
+	return img
+}
+
+func (p *pagedArea) paintPage(img *image.RGBA, page int) {
+	pr := p.pageRect(page)
+	strokeRect(img, pr.Inset(-1), 1, pagedBorder)
+	r := pr.Intersect(img.Rect)
+	if r.Empty() {
+		return
+	}
+	src := p.handler.PaintPage(page, r.Sub(pr.Min))
+	draw.Draw(img, r, src, src.Bounds().Min, draw.Src)
+}
+
+func (p *pagedArea) paintThumb(img *image.RGBA, page int) {
+	tr := p.thumbRect(page)
+	if page == p.page {
+		strokeRect(img, tr.Inset(-3), 3, pagedCurrent)
+	} else {
+		strokeRect(img, tr.Inset(-1), 1, pagedBorder)
+	}
+	if tr.Intersect(img.Rect).Empty() {
+		return
+	}
+	thumb, ok := p.thumbs[page]
+	if !ok {
+		thumb = shrinkImage(toRGBA(p.handler.PaintPage(page, image.Rect(0, 0, p.pageWidth, p.pageHeight))), tr.Size())
+		p.thumbs[page] = thumb
+	}
+	draw.Draw(img, tr, thumb, image.ZP, draw.Src)
+}
+
+// shrinkImage averages the pixels of src that fall in each pixel of an image of the given size; it's slow, but thumbnails are only made once
+func shrinkImage(src *image.RGBA, size image.Point) *image.RGBA {
+	dst := image.NewRGBA(image.Rectangle{image.ZP, size})
+	sw := src.Rect.Dx()
+	sh := src.Rect.Dy()
+	for y := 0; y < size.Y; y++ {
+		y0 := y * sh / size.Y
+		y1 := (y + 1) * sh / size.Y
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < size.X; x++ {
+			x0 := x * sw / size.X
+			x1 := (x + 1) * sw / size.X
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := src.RGBAAt(src.Rect.Min.X+sx, src.Rect.Min.Y+sy)
+					r += uint32(c.R)
+					g += uint32(c.G)
+					b += uint32(c.B)
+					a += uint32(c.A)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{uint8(r / n), uint8(g / n), uint8(b / n), uint8(a / n)})
+		}
+	}
+	return dst
+}
+
+func (p *pagedArea) Mouse(e MouseEvent) {
+	for page := 0; page < p.pages; page++ {
+		if p.thumbnails {
+			if e.Down == 1 && e.Pos.In(p.thumbRect(page)) {
+				p.setPage(page)
+				p.SetThumbnails(false)
+				return
+			}
+			continue
+		}
+		pr := p.pageRect(page)
+		if e.Pos.In(pr) {
+			e.Pos = e.Pos.Sub(pr.Min)
+			p.handler.PageMouse(page, e)
+			return
+		}
+	}
+}
+
+func (p *pagedArea) Custom(e CustomEvent) {
+	if c, ok := p.handler.(AreaCustomHandler); ok {
+		c.Custom(e)
+	}
+}
+
+func (p *pagedArea) Key(e KeyEvent) bool {
+	if p.handler.PageKey(e) {
+		return true
+	}
+	if e.Up || p.pages == 0 {
+		return false
+	}
+	if (e.Modifiers & Ctrl) != 0 {
+		switch e.Key {
+		case '-':
+			p.SetThumbnails(true)
+			return true
+		case '=':
+			p.SetThumbnails(false)
+			return true
+		}
+		return false
+	}
+	if p.thumbnails && (e.ExtKey == Escape || e.Key == '\n') {
+		p.SetThumbnails(false)
+		return true
+	}
+	page := p.page
+	switch e.ExtKey {
+	case PageUp:
+		page--
+	case PageDown:
+		page++
+	case Home:
+		page = 0
+	case End:
+		page = p.pages - 1
+	default:
+		return false
+	}
+	if page < 0 {
+		page = 0
+	}
+	if page >= p.pages {
+		page = p.pages - 1
+	}
+	p.SetPage(page)
+	return true
+}
@@ -0,0 +1,137 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"fmt"
+)
+
+// Platform identifies one of the systems package ui runs on.
+// Platforms are bits, so they can be combined with | to name several at once.
+type Platform uint
+
+const (
+	// PlatformGTK is GTK+, which package ui uses everywhere other than Windows and Mac OS X.
+	PlatformGTK Platform = 1 << iota
+	PlatformWindows
+	PlatformMac
+)
+
+func (p Platform) String() string {
+	switch p {
+	case PlatformGTK:
+		return "GTK+"
+	case PlatformWindows:
+		return "Windows"
+	case PlatformMac:
+		return "Mac OS X"
+	}
+	return fmt.Sprintf("Platform(%d)", uint(p))
+}
+
+// CurrentPlatform returns the Platform the program is running on.
+func CurrentPlatform() Platform {
+	return currentPlatform
+}
+
+// OnPlatforms calls f if the program is running on one of platforms, and does nothing otherwise.
+// It is for tweaks that only make sense on some systems, such as w.SetDialogsAttached(false) on Mac OS X.
+func OnPlatforms(platforms Platform, f func()) {
+	if platforms&currentPlatform != 0 {
+		f()
+	}
+}
+
+// PerPlatform holds a value that differs between platforms, such as the spacing of a Stack or the order of a row of buttons, so that layout code can say what it wants on each platform in one place without checking runtime.GOOS.
+// Default is used on platforms whose own field is nil.
+// For example:
+//
+// 	s.SetSpacing(ui.PerPlatform{Default: 6, Mac: 8}.Int())
+// 	buttons := ui.NewHorizontalStack(ui.PerPlatform{
+// 		Default: []ui.Control{ui.Space(), ok, cancel},
+// 		Mac:     []ui.Control{ui.Space(), cancel, ok},
+// 	}.Controls()...)
+//
+// (For the buttons of a dialog box, NewDialogButtons already knows each system's order.)
+// The accessors panic if the value for the current platform is not of the type asked for.
+type PerPlatform struct {
+	Default interface{}
+	GTK     interface{}
+	Windows interface{}
+	Mac     interface{}
+}
+
+// Value returns the value for the current platform, which is nil if neither it nor Default is set.
+func (p PerPlatform) Value() interface{} {
+	var v interface{}
+
+	switch currentPlatform {
+	case PlatformGTK:
+		v = p.GTK
+	case PlatformWindows:
+		v = p.Windows
+	case PlatformMac:
+		v = p.Mac
+	}
+	if v == nil {
+		v = p.Default
+	}
+	return v
+}
+
+func (p PerPlatform) value(what string) interface{} {
+	v := p.Value()
+	if v == nil {
+		panic(fmt.Errorf("no value for %v or Default in PerPlatform.%s()", currentPlatform, what))
+	}
+	return v
+}
+
+// Int returns the value for the current platform as an int.
+func (p PerPlatform) Int() int {
+	v, ok := p.value("Int").(int)
+	if !ok {
+		panic(fmt.Errorf("value %v for %v in PerPlatform.Int() is not an int", p.Value(), currentPlatform))
+	}
+	return v
+}
+
+// Float returns the value for the current platform as a float64; ints are converted.
+func (p PerPlatform) Float() float64 {
+	switch v := p.value("Float").(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	}
+	panic(fmt.Errorf("value %v for %v in PerPlatform.Float() is not a float64 or int", p.Value(), currentPlatform))
+}
+
+// Bool returns the value for the current platform as a bool.
+func (p PerPlatform) Bool() bool {
+	v, ok := p.value("Bool").(bool)
+	if !ok {
+		panic(fmt.Errorf("value %v for %v in PerPlatform.Bool() is not a bool", p.Value(), currentPlatform))
+	}
+	return v
+}
+
+// Text returns the value for the current platform as a string.
+// (It isn't called String so that PerPlatform isn't a fmt.Stringer.)
+func (p PerPlatform) Text() string {
+	v, ok := p.value("Text").(string)
+	if !ok {
+		panic(fmt.Errorf("value %v for %v in PerPlatform.Text() is not a string", p.Value(), currentPlatform))
+	}
+	return v
+}
+
+// Controls returns the value for the current platform as a []Control, for passing to NewHorizontalStack, NewVerticalStack, or NewSimpleGrid.
+// The slices for different platforms usually hold the same Controls in different orders; a Control must not be given to more than one container.
+func (p PerPlatform) Controls() []Control {
+	v, ok := p.value("Controls").([]Control)
+	if !ok {
+		panic(fmt.Errorf("value %v for %v in PerPlatform.Controls() is not a []Control", p.Value(), currentPlatform))
+	}
+	return v
+}
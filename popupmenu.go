@@ -0,0 +1,252 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"image"
+)
+
+// PopupMenu is a context menu: a menu that pops up in response to a right-click (or the Menu key) rather than from a menubar.
+// Items are numbered in the order they are appended, starting at 0; separators count.
+// A PopupMenu can be shown any number of times and changed between showings.
+// The native menu is made from the items each time the menu is shown and thrown away afterward, so changing a PopupMenu, even clearing it and appending everything again, costs nothing and can't flicker; a program can keep one function that defines the whole menu and call it whenever its state changes.
+type PopupMenu interface {
+	// Append adds an item with the given text.
+	// & marks the item's mnemonic as with Button.
+	// f is called on the main thread if the user chooses the item; it can be nil.
+	Append(text string, f func()) (index int)
+
+	// AppendCheckbox adds an item that shows a checkmark when checked.
+	// Choosing the item toggles the checkmark first and then calls f (which can be nil), so Checked(index) gives the new state.
+	AppendCheckbox(text string, checked bool, f func()) (index int)
+
+	// AppendCheckboxFor is like AppendCheckbox, but the checkmark is *value: it is read each time the menu is shown, and choosing the item toggles *value before calling f.
+	// This keeps the item in step with the setting it stands for without having to call SetChecked whenever the setting changes elsewhere.
+	AppendCheckboxFor(text string, value *bool, f func()) (index int)
+
+	// AppendRadioGroup adds an item for each of texts, of which only one can be checked at a time, and returns the index of the first.
+	// The checked item is texts[*selected]; none is if *selected is out of range.
+	// As with AppendCheckboxFor, *selected is read each time the menu is shown; choosing one of the items sets *selected to its index in texts and then calls f (which can be nil).
+	// (Mac OS X shows a checkmark for the checked item rather than a bullet.)
+	AppendRadioGroup(texts []string, selected *int, f func()) (first int)
+
+	// AppendSubmenu adds an item that opens a submenu.
+	// Each time the PopupMenu is shown, build is called with a new empty PopupMenu to fill in as the submenu, so that the submenu can list things that change, such as recently opened files.
+	// The PopupMenu given to build is only good until build returns; the functions of its items can be called after that.
+	AppendSubmenu(text string, build func(submenu PopupMenu)) (index int)
+
+	// AppendSeparator adds a separator line.
+	AppendSeparator()
+
+	// Clear removes all the items.
+	Clear()
+
+	// Checked and SetChecked get and set the checkmark of an item added with AppendCheckbox, AppendCheckboxFor, or AppendRadioGroup.
+	// Checking an item of a radio group unchecks the others; unchecking the checked item of a radio group sets its selected to -1.
+	Checked(index int) bool
+	SetChecked(index int, checked bool)
+
+	// Enabled and SetEnabled get and set whether the user can choose an item; items are enabled when appended.
+	Enabled(index int) bool
+	SetEnabled(index int, enabled bool)
+
+	// Show shows the menu at the mouse pointer.
+	// To show a menu at a particular point in an Area, use the Area's ShowPopupMenu method.
+	// The function of the chosen item, if any, may be called before or after Show returns, depending on the system.
+	Show()
+
+	popup(a *area, pos image.Point) // implemented per-platform; a is nil to show at the mouse pointer
+}
+
+type popupMenuItem struct {
+	text      string
+	separator bool
+	checkbox  bool
+	checked   bool
+	disabled  bool
+	f         func()
+	value     *bool // for AppendCheckboxFor; used instead of checked
+	selected  *int  // for AppendRadioGroup; the item is checked if *selected == radio
+	radio     int
+	submenu   func(PopupMenu)
+}
+
+func (i *popupMenuItem) isChecked() bool {
+	switch {
+	case i.selected != nil:
+		return *i.selected == i.radio
+	case i.value != nil:
+		return *i.value
+	}
+	return i.checked
+}
+
+func (i *popupMenuItem) setChecked(checked bool) {
+	switch {
+	case i.selected != nil:
+		if checked {
+			*i.selected = i.radio
+		} else if *i.selected == i.radio {
+			*i.selected = -1
+		}
+	case i.value != nil:
+		*i.value = checked
+	default:
+		i.checked = checked
+	}
+}
+
+// the native menu is built from items each time the menu is shown and destroyed afterward, so nothing native needs to be kept here
+type popupMenu struct {
+	items []*popupMenuItem
+
+	// the items that can be chosen from the menu being shown, including those of submenus, numbered in the order the per-platform code added them; see choice()
+	shown []popupMenuChoice
+}
+
+// popupMenuChoice is an item of a shown menu: the menu it is in (which is a submenu for items in submenus) and its index there
+type popupMenuChoice struct {
+	m     *popupMenu
+	index int
+}
+
+// NewPopupMenu creates a new empty PopupMenu.
+func NewPopupMenu() PopupMenu {
+	return new(popupMenu)
+}
+
+func (m *popupMenu) item(index int, what string) *popupMenuItem {
+	if index < 0 || index >= len(m.items) {
+		panic(fmt.Errorf("invalid item index %d in PopupMenu.%s()", index, what))
+	}
+	return m.items[index]
+}
+
+func (m *popupMenu) Append(text string, f func()) (index int) {
+	m.items = append(m.items, &popupMenuItem{
+		text: text,
+		f:    f,
+	})
+	return len(m.items) - 1
+}
+
+func (m *popupMenu) AppendCheckbox(text string, checked bool, f func()) (index int) {
+	m.items = append(m.items, &popupMenuItem{
+		text:     text,
+		checkbox: true,
+		checked:  checked,
+		f:        f,
+	})
+	return len(m.items) - 1
+}
+
+func (m *popupMenu) AppendCheckboxFor(text string, value *bool, f func()) (index int) {
+	if value == nil {
+		panic("value passed to PopupMenu.AppendCheckboxFor() cannot be nil")
+	}
+	m.items = append(m.items, &popupMenuItem{
+		text:     text,
+		checkbox: true,
+		value:    value,
+		f:        f,
+	})
+	return len(m.items) - 1
+}
+
+func (m *popupMenu) AppendRadioGroup(texts []string, selected *int, f func()) (first int) {
+	if len(texts) == 0 {
+		panic("no texts given to PopupMenu.AppendRadioGroup()")
+	}
+	if selected == nil {
+		panic("selected passed to PopupMenu.AppendRadioGroup() cannot be nil")
+	}
+	first = len(m.items)
+	for i, text := range texts {
+		m.items = append(m.items, &popupMenuItem{
+			text:     text,
+			checkbox: true,
+			selected: selected,
+			radio:    i,
+			f:        f,
+		})
+	}
+	return first
+}
+
+func (m *popupMenu) AppendSubmenu(text string, build func(submenu PopupMenu)) (index int) {
+	if build == nil {
+		panic("build function passed to PopupMenu.AppendSubmenu() cannot be nil")
+	}
+	m.items = append(m.items, &popupMenuItem{
+		text:    text,
+		submenu: build,
+	})
+	return len(m.items) - 1
+}
+
+func (m *popupMenu) AppendSeparator() {
+	m.items = append(m.items, &popupMenuItem{
+		separator: true,
+	})
+}
+
+func (m *popupMenu) Clear() {
+	m.items = nil
+}
+
+func (m *popupMenu) Checked(index int) bool {
+	return m.item(index, "Checked").isChecked()
+}
+
+func (m *popupMenu) SetChecked(index int, checked bool) {
+	i := m.item(index, "SetChecked")
+	if !i.checkbox {
+		panic(fmt.Errorf("item %d is not a checkbox in PopupMenu.SetChecked()", index))
+	}
+	i.setChecked(checked)
+}
+
+func (m *popupMenu) Enabled(index int) bool {
+	return !m.item(index, "Enabled").disabled
+}
+
+func (m *popupMenu) SetEnabled(index int, enabled bool) {
+	m.item(index, "SetEnabled").disabled = !enabled
+}
+
+func (m *popupMenu) Show() {
+	m.popup(nil, image.ZP)
+}
+
+// choice is called by the per-platform code, on the PopupMenu being shown, for each item it adds to the native menu; in is the menu or submenu holding the item, and the number returned identifies the item to chosen()
+// the per-platform code sets shown to nil before it starts
+func (m *popupMenu) choice(in *popupMenu, index int) int {
+	m.shown = append(m.shown, popupMenuChoice{
+		m:     in,
+		index: index,
+	})
+	return len(m.shown) - 1
+}
+
+// expand makes the submenu of a submenu item, for the per-platform code to add to the native menu
+func (i *popupMenuItem) expand() *popupMenu {
+	sub := new(popupMenu)
+	i.submenu(sub)
+	return sub
+}
+
+// chosen is called by the per-platform code when the user chooses an item; id is what choice() returned for it
+func (m *popupMenu) chosen(id int) {
+	c := m.shown[id]
+	i := c.m.items[c.index]
+	switch {
+	case i.selected != nil:
+		i.setChecked(true)
+	case i.checkbox:
+		i.setChecked(!i.isChecked())
+	}
+	if i.f != nil {
+		i.f()
+	}
+}
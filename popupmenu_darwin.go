@@ -0,0 +1,48 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"image"
+	"unsafe"
+)
+
+// #include "objc_darwin.h"
+import "C"
+
+func (m *popupMenu) popup(a *area, pos image.Point) {
+	menu := C.newPopupMenu()
+	m.shown = nil
+	m.build(menu, m)
+	if a == nil {
+		C.popupMenuShow(menu, unsafe.Pointer(m), nil, 0, 0)
+		return
+	}
+	C.popupMenuShow(menu, unsafe.Pointer(m), a.id, C.intptr_t(pos.X), C.intptr_t(pos.Y))
+}
+
+// build adds the items of in, which is m or one of its submenus, to menu
+func (m *popupMenu) build(menu C.id, in *popupMenu) {
+	for i, item := range in.items {
+		if item.separator {
+			C.popupMenuAppendSeparator(menu)
+			continue
+		}
+		// menus on Mac OS X have no mnemonics
+		ctext := C.CString(stripMnemonic(item.text))
+		if item.submenu != nil {
+			sub := C.popupMenuAppendSubmenu(menu, ctext, toBOOL(!item.disabled))
+			C.free(unsafe.Pointer(ctext))
+			m.build(sub, item.expand())
+			continue
+		}
+		C.popupMenuAppend(menu, ctext, C.intptr_t(m.choice(in, i)), toBOOL(item.isChecked()), toBOOL(!item.disabled))
+		C.free(unsafe.Pointer(ctext))
+	}
+}
+
+//export popupMenuChosen
+func popupMenuChosen(data unsafe.Pointer, index C.intptr_t) {
+	m := (*popupMenu)(data)
+	m.chosen(int(index))
+}
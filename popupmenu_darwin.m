@@ -0,0 +1,105 @@
+// 14 october 2026
+
+#include "objc_darwin.h"
+#include "_cgo_export.h"
+#import <Cocoa/Cocoa.h>
+
+#define toNSMenu(x) ((NSMenu *) (x))
+#define toNSView(x) ((NSView *) (x))
+
+// this is the target of every item in a popup menu and its submenus; the tag of each item is its number from choice() in the Go side
+@interface goPopupMenuTarget : NSObject {
+@public
+	void *gomenu;
+}
+@end
+
+@implementation goPopupMenuTarget
+
+- (IBAction)itemChosen:(id)sender
+{
+	popupMenuChosen(self->gomenu, (intptr_t) [sender tag]);
+}
+
+@end
+
+id newPopupMenu(void)
+{
+	NSMenu *menu;
+
+	menu = [[NSMenu alloc] initWithTitle:@""];
+	// otherwise NSMenu enables and disables the items itself
+	[menu setAutoenablesItems:NO];
+	return menu;
+}
+
+void popupMenuAppend(id menu, char *text, intptr_t index, BOOL checked, BOOL enabled)
+{
+	NSMenuItem *item;
+
+	item = [[NSMenuItem alloc] initWithTitle:[NSString stringWithUTF8String:text]
+		action:@selector(itemChosen:)
+		keyEquivalent:@""];
+	[item setTag:(NSInteger) index];
+	if (checked)
+		[item setState:NSOnState];
+	[item setEnabled:enabled];
+	[toNSMenu(menu) addItem:item];
+	[item release];
+}
+
+void popupMenuAppendSeparator(id menu)
+{
+	[toNSMenu(menu) addItem:[NSMenuItem separatorItem]];
+}
+
+// returns the submenu, to add items to; the item retains it, so it goes away along with menu
+id popupMenuAppendSubmenu(id menu, char *text, BOOL enabled)
+{
+	NSMenuItem *item;
+	id submenu;
+
+	item = [[NSMenuItem alloc] initWithTitle:[NSString stringWithUTF8String:text]
+		action:NULL
+		keyEquivalent:@""];
+	submenu = newPopupMenu();
+	[toNSMenu(submenu) setTitle:[item title]];
+	[item setSubmenu:toNSMenu(submenu)];
+	[toNSMenu(submenu) release];
+	[item setEnabled:enabled];
+	[toNSMenu(menu) addItem:item];
+	[item release];
+	return submenu;
+}
+
+static void setPopupMenuTarget(NSMenu *m, id target)
+{
+	NSMenuItem *item;
+
+	for (item in [m itemArray])
+		if ([item hasSubmenu])
+			setPopupMenuTarget([item submenu], target);
+		else if (![item isSeparatorItem])
+			[item setTarget:target];
+}
+
+// if view is nil, the menu is shown at the mouse pointer and x and y are ignored; otherwise they are in the view's coordinates
+// the menu is released
+void popupMenuShow(id menu, void *gomenu, id view, intptr_t x, intptr_t y)
+{
+	NSMenu *m = toNSMenu(menu);
+	goPopupMenuTarget *target;
+	NSPoint p;
+
+	// NSMenuItem doesn't retain its target, so autorelease it rather than release it, in case the action is sent after the menu is dismissed
+	target = [[goPopupMenuTarget new] autorelease];
+	target->gomenu = gomenu;
+	setPopupMenuTarget(m, target);
+	if (view == nil)
+		p = [NSEvent mouseLocation];		// in screen coordinates, which is what inView:nil wants
+	else
+		p = NSMakePoint((CGFloat) x, (CGFloat) y);		// our views are flipped, so this is the top-left corner
+	// this runs the menu's own event loop and returns when the menu is dismissed
+	[m popUpMenuPositioningItem:nil atLocation:p inView:toNSView(view)];
+	[m release];
+}
@@ -0,0 +1,84 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+#include "gtk_unix.h"
+#include "_cgo_export.h"
+
+static void popupMenuItemActivated(GtkMenuItem *item, gpointer data)
+{
+	popupMenuChosen(data, GPOINTER_TO_INT(g_object_get_data(G_OBJECT(item), "index")));
+}
+
+// radio items are plain check items drawn as radio items; package ui keeps track of which one is checked itself, which GtkRadioMenuItem would fight
+void popupMenuAppend(GtkWidget *menu, gchar *text, gboolean separator, gboolean checkbox, gboolean radio, gboolean checked, gboolean enabled, gint index, void *gomenu)
+{
+	GtkWidget *item;
+
+	if (separator)
+		item = gtk_separator_menu_item_new();
+	else if (checkbox) {
+		item = gtk_check_menu_item_new_with_mnemonic(text);
+		gtk_check_menu_item_set_draw_as_radio(GTK_CHECK_MENU_ITEM(item), radio);
+		// this emits activate, so only connect to it afterward
+		gtk_check_menu_item_set_active(GTK_CHECK_MENU_ITEM(item), checked);
+	} else
+		item = gtk_menu_item_new_with_mnemonic(text);
+	gtk_widget_set_sensitive(item, enabled);
+	if (!separator) {
+		g_object_set_data(G_OBJECT(item), "index", GINT_TO_POINTER(index));
+		g_signal_connect(item, "activate", G_CALLBACK(popupMenuItemActivated), gomenu);
+	}
+	// gtk_widget_show_all() in popupMenuShow() doesn't reach into submenus, so show each item here
+	gtk_widget_show(item);
+	gtk_menu_shell_append(GTK_MENU_SHELL(menu), item);
+}
+
+// returns the submenu, to add items to; it is destroyed along with menu
+GtkWidget *popupMenuAppendSubmenu(GtkWidget *menu, gchar *text, gboolean enabled)
+{
+	GtkWidget *item;
+	GtkWidget *submenu;
+
+	item = gtk_menu_item_new_with_mnemonic(text);
+	submenu = gtk_menu_new();
+	gtk_menu_item_set_submenu(GTK_MENU_ITEM(item), submenu);
+	gtk_widget_set_sensitive(item, enabled);
+	gtk_widget_show(item);
+	gtk_menu_shell_append(GTK_MENU_SHELL(menu), item);
+	return submenu;
+}
+
+// only one menu can be popped up at a time, so this doesn't need to be per-menu
+static GdkPoint popupMenuPoint;
+
+static void popupMenuPosition(GtkMenu *menu, gint *x, gint *y, gboolean *push_in, gpointer data)
+{
+	*x = popupMenuPoint.x;
+	*y = popupMenuPoint.y;
+	*push_in = TRUE;		// keep it on screen
+}
+
+void popupMenuShow(GtkWidget *menu, gboolean atPoint, gint x, gint y)
+{
+	GdkEvent *e;
+	guint button = 0;
+
+	// if we're popping up from a button press, pass its button so that releasing it over an item chooses that item
+	e = gtk_get_current_event();
+	if (e != NULL) {
+		if (e->type == GDK_BUTTON_PRESS)
+			button = e->button.button;
+		gdk_event_free(e);
+	}
+	// selection-done comes after activate (or after the menu is dismissed without choosing anything), so it's safe to destroy the menu then
+	g_signal_connect(menu, "selection-done", G_CALLBACK(gtk_widget_destroy), NULL);
+	gtk_widget_show_all(menu);
+	if (!atPoint) {
+		gtk_menu_popup(GTK_MENU(menu), NULL, NULL, NULL, NULL, button, gtk_get_current_event_time());
+		return;
+	}
+	popupMenuPoint.x = x;
+	popupMenuPoint.y = y;
+	gtk_menu_popup(GTK_MENU(menu), NULL, NULL, popupMenuPosition, NULL, button, gtk_get_current_event_time());
+}
@@ -0,0 +1,55 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+package ui
+
+import (
+	"image"
+	"unsafe"
+)
+
+// #include "gtk_unix.h"
+import "C"
+
+func (m *popupMenu) popup(a *area, pos image.Point) {
+	menu := C.gtk_menu_new()
+	m.shown = nil
+	m.build(menu, m)
+	if a == nil {
+		C.popupMenuShow(menu, C.FALSE, 0, 0)
+		return
+	}
+	// the drawing area's window moves as the Area scrolls, so its origin is Area coordinate (0,0) in screen coordinates
+	var x, y C.gint
+
+	C.gdk_window_get_origin(C.gtk_widget_get_window((*C.GtkWidget)(unsafe.Pointer(a.drawingarea))), &x, &y)
+	C.popupMenuShow(menu, C.TRUE, x+C.gint(pos.X), y+C.gint(pos.Y))
+}
+
+// build adds the items of in, which is m or one of its submenus, to menu
+func (m *popupMenu) build(menu *C.GtkWidget, in *popupMenu) {
+	for i, item := range in.items {
+		ctext := togstr(toUnderlineMnemonic(item.text))
+		if item.submenu != nil {
+			sub := C.popupMenuAppendSubmenu(menu, ctext, togbool(!item.disabled))
+			freegstr(ctext)
+			m.build(sub, item.expand())
+			continue
+		}
+		id := -1
+		if !item.separator {
+			id = m.choice(in, i)
+		}
+		C.popupMenuAppend(menu, ctext,
+			togbool(item.separator), togbool(item.checkbox), togbool(item.selected != nil), togbool(item.isChecked()), togbool(!item.disabled),
+			C.gint(id), unsafe.Pointer(m))
+		freegstr(ctext)
+	}
+}
+
+//export popupMenuChosen
+func popupMenuChosen(data unsafe.Pointer, index C.gint) {
+	m := (*popupMenu)(data)
+	m.chosen(int(index))
+}
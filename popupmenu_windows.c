@@ -0,0 +1,62 @@
+// 14 october 2026
+
+#include "winapi_windows.h"
+#include "_cgo_export.h"
+
+HMENU newPopupMenu(void)
+{
+	HMENU menu;
+
+	menu = CreatePopupMenu();
+	if (menu == NULL)
+		xpanic("error creating popup menu", GetLastError());
+	return menu;
+}
+
+// AppendMenuW() can't make radio items, but CheckMenuRadioItem() turns the checkmark of a checked item into a bullet; an unchecked radio item looks like any other unchecked item
+void popupMenuAppend(HMENU menu, UINT_PTR id, LPWSTR text, UINT flags, BOOL radio)
+{
+	if (AppendMenuW(menu, flags, id, text) == 0)
+		xpanic("error adding item to popup menu", GetLastError());
+	if (radio && (flags & MF_CHECKED) != 0)
+		if (CheckMenuRadioItem(menu, (UINT) id, (UINT) id, (UINT) id, MF_BYCOMMAND) == 0)
+			xpanic("error making popup menu item a radio item", GetLastError());
+}
+
+// returns the submenu, to add items to; DestroyMenu() in popupMenuShow() destroys it along with menu
+HMENU popupMenuAppendSubmenu(HMENU menu, LPWSTR text, UINT flags)
+{
+	HMENU submenu;
+
+	submenu = newPopupMenu();
+	if (AppendMenuW(menu, flags | MF_POPUP, (UINT_PTR) submenu, text) == 0)
+		xpanic("error adding submenu to popup menu", GetLastError());
+	return submenu;
+}
+
+// pt is in screen coordinates; if it is NULL, the menu is shown at the mouse pointer
+// returns the ID of the chosen item or 0 if the menu was dismissed; the menu is destroyed either way
+UINT popupMenuShow(HMENU menu, HWND owner, POINT *pt)
+{
+	POINT cursor;
+	UINT id;
+
+	if (pt == NULL) {
+		if (GetCursorPos(&cursor) == 0)
+			xpanic("error getting mouse position for popup menu", GetLastError());
+		pt = &cursor;
+	}
+	if (owner == NULL)
+		owner = msgwin;
+	// the owner has to be the foreground window or the menu won't go away if the user clicks outside it; the WM_NULL is for the same reason
+	// see the Remarks section of http://msdn.microsoft.com/en-us/library/windows/desktop/ms648002%28v=vs.85%29.aspx
+	SetForegroundWindow(owner);
+	// TPM_RETURNCMD returns the chosen item instead of sending WM_COMMAND, so we don't need a window procedure to handle it; 0 means nothing was chosen (or an error, which we can't tell apart)
+	id = (UINT) TrackPopupMenu(menu,
+		TPM_LEFTALIGN | TPM_TOPALIGN | TPM_RIGHTBUTTON | TPM_RETURNCMD | TPM_NONOTIFY,
+		pt->x, pt->y, 0, owner, NULL);
+	PostMessageW(owner, WM_NULL, 0, 0);
+	if (DestroyMenu(menu) == 0)
+		xpanic("error destroying popup menu", GetLastError());
+	return id;
+}
@@ -0,0 +1,59 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"image"
+)
+
+// #include "winapi_windows.h"
+import "C"
+
+func (m *popupMenu) popup(a *area, pos image.Point) {
+	menu := C.newPopupMenu()
+	m.shown = nil
+	m.build(menu, m)
+	var id C.UINT
+	if a == nil {
+		id = C.popupMenuShow(menu, C.GetActiveWindow(), nil)
+	} else {
+		var pt C.POINT
+
+		pt.x = C.LONG(pos.X)
+		pt.y = C.LONG(pos.Y)
+		C.areaToScreen(a.hwnd, &pt)
+		id = C.popupMenuShow(menu, C.GetAncestor(a.hwnd, C.GA_ROOT), &pt)
+	}
+	if id != 0 {
+		m.chosen(int(id) - 1)
+	}
+}
+
+// build adds the items of in, which is m or one of its submenus, to menu
+func (m *popupMenu) build(menu C.HMENU, in *popupMenu) {
+	for i, item := range in.items {
+		if item.separator {
+			C.popupMenuAppend(menu, 0, nil, C.MF_SEPARATOR, C.FALSE)
+			continue
+		}
+		flags := C.UINT(C.MF_STRING)
+		if item.isChecked() {
+			flags |= C.MF_CHECKED
+		}
+		if item.disabled {
+			flags |= C.MF_GRAYED
+		}
+		// & already marks the mnemonic the way Windows does it
+		if item.submenu != nil {
+			sub := C.popupMenuAppendSubmenu(menu, toUTF16(item.text), flags)
+			m.build(sub, item.expand())
+			continue
+		}
+		radio := C.BOOL(C.FALSE)
+		if item.selected != nil {
+			radio = C.TRUE
+		}
+		// IDs are choice() numbers plus one because TrackPopupMenu() returns 0 for no item
+		C.popupMenuAppend(menu, C.UINT_PTR(m.choice(in, i)+1), toUTF16(item.text), flags, radio)
+	}
+}
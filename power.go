@@ -0,0 +1,70 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"time"
+)
+
+// PowerStatus describes where the computer is getting its power from.
+type PowerStatus struct {
+	// OnBattery is true if the computer is running on battery power, and false if it is plugged in (or has no battery at all).
+	OnBattery bool
+
+	// Percent is how full the battery is, from 0 to 100.
+	// It is -1 if there is no battery or the system can't tell.
+	// If there is more than one battery, it is for all of them together.
+	Percent int
+
+	// LowPower is true if the user or the system has asked programs to save power: Battery Saver on Windows, Low Power Mode on OS X, or the power-saver profile on Unix systems.
+	// Programs should respond by doing less in the background, such as animating less and refreshing less often.
+	// It is always false on systems too old to have such a mode.
+	LowPower bool
+}
+
+// Power returns the current PowerStatus.
+// It returns an error if the system can't tell; on Unix systems this happens if UPower isn't running.
+func Power() (PowerStatus, error) {
+	return power()
+}
+
+// powerWatchInterval is how often a PowerWatcher checks Power().
+// Battery levels change slowly, and plugging in or unplugging doesn't need to take effect instantly, so this can be longer than the one for IdleWatcher.
+const powerWatchInterval = 5 * time.Second
+
+// PowerWatcher tells a program when the PowerStatus changes.
+type PowerWatcher struct {
+	ticker *time.Ticker
+	fe     *ForeignEvent
+	last   PowerStatus
+	known  bool // false until Power() first succeeds
+}
+
+// WatchPower creates a PowerWatcher that calls changed on the main thread with the new PowerStatus whenever it changes.
+// changed is not called for the initial status; call Power() for that.
+// The PowerWatcher checks Power() periodically, so changed may be called a few seconds late; while Power() returns an error, changed is not called, and the first PowerStatus after that counts as a change.
+func WatchPower(changed func(s PowerStatus)) *PowerWatcher {
+	w := &PowerWatcher{
+		ticker: time.NewTicker(powerWatchInterval),
+	}
+	if s, err := Power(); err == nil {
+		w.last = s
+		w.known = true
+	}
+	w.fe = NewForeignEvent(w.ticker.C, func(interface{}) {
+		s, err := Power()
+		if err != nil || (w.known && s == w.last) {
+			return
+		}
+		w.last = s
+		w.known = true
+		changed(s)
+	})
+	return w
+}
+
+// Stop stops the PowerWatcher; changed will not be called again.
+func (w *PowerWatcher) Stop() {
+	w.ticker.Stop()
+	w.fe.Stop()
+}
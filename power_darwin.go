@@ -0,0 +1,17 @@
+// 14 october 2026
+
+package ui
+
+// #include "objc_darwin.h"
+import "C"
+
+func power() (PowerStatus, error) {
+	var s C.struct_powerStatus
+
+	C.getPowerStatus(&s)
+	return PowerStatus{
+		OnBattery: fromBOOL(s.onBattery),
+		Percent:   int(s.percent),
+		LowPower:  fromBOOL(s.lowPower),
+	}, nil
+}
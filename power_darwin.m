@@ -0,0 +1,47 @@
+// 14 october 2026
+
+#include "objc_darwin.h"
+#import <Foundation/Foundation.h>
+#import <IOKit/ps/IOPowerSources.h>
+#import <IOKit/ps/IOPSKeys.h>
+
+void getPowerStatus(struct powerStatus *s)
+{
+	CFTypeRef info;
+	CFArrayRef list;
+	CFIndex i, n;
+	NSDictionary *d;
+	NSInteger cur = 0, max = 0;
+	NSProcessInfo *pi;
+
+	s->onBattery = NO;
+	s->percent = -1;
+	s->lowPower = NO;
+
+	info = IOPSCopyPowerSourcesInfo();
+	if (info != NULL) {
+		s->onBattery = [((NSString *) IOPSGetProvidingPowerSourceType(info)) isEqualToString:@kIOPMBatteryPowerKey];
+		list = IOPSCopyPowerSourcesList(info);
+		if (list != NULL) {
+			n = CFArrayGetCount(list);
+			for (i = 0; i < n; i++) {
+				// this isn't a copy; don't release it
+				d = (NSDictionary *) IOPSGetPowerSourceDescription(info, CFArrayGetValueAtIndex(list, i));
+				// skip UPSes; they're not what the user means by "the battery"
+				if (d == nil || ![[d objectForKey:@kIOPSTypeKey] isEqualToString:@kIOPSInternalBatteryType])
+					continue;
+				cur += [[d objectForKey:@kIOPSCurrentCapacityKey] integerValue];
+				max += [[d objectForKey:@kIOPSMaxCapacityKey] integerValue];
+			}
+			CFRelease(list);
+		}
+		CFRelease(info);
+	}
+	if (max > 0)
+		s->percent = (intptr_t) ((cur * 100 + max / 2) / max);
+
+	// Low Power Mode is 12.0 and newer, and we build against the 10.7 SDK, so we can't call it directly
+	pi = [NSProcessInfo processInfo];
+	if ([pi respondsToSelector:@selector(isLowPowerModeEnabled)])
+		s->lowPower = ((BOOL (*)(id, SEL)) objc_msgSend)(pi, @selector(isLowPowerModeEnabled));
+}
@@ -0,0 +1,68 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+#include "gtk_unix.h"
+
+static GDBusConnection *powerBus = NULL;
+
+#define upowerName "org.freedesktop.UPower"
+
+// returns NULL if the property can't be read
+static GVariant *getProperty(const gchar *name, const gchar *path, const gchar *iface, const gchar *property)
+{
+	GVariant *ret;
+	GVariant *v;
+
+	ret = g_dbus_connection_call_sync(powerBus, name, path, "org.freedesktop.DBus.Properties",
+		"Get", g_variant_new("(ss)", iface, property), G_VARIANT_TYPE("(v)"),
+		G_DBUS_CALL_FLAGS_NONE, -1, NULL, NULL);
+	if (ret == NULL)
+		return NULL;
+	g_variant_get(ret, "(v)", &v);
+	g_variant_unref(ret);
+	return v;
+}
+
+// returns FALSE if UPower isn't running
+// the other two values are optional: the display device (UPower's combination of all the batteries) is 0.99 and newer, and power-profiles-daemon is separate from UPower entirely
+gboolean getPowerStatus(gboolean *onBattery, gint *percent, gboolean *lowPower)
+{
+	GVariant *v;
+
+	if (powerBus == NULL) {
+		powerBus = g_bus_get_sync(G_BUS_TYPE_SYSTEM, NULL, NULL);
+		if (powerBus == NULL)
+			return FALSE;
+	}
+
+	v = getProperty(upowerName, "/org/freedesktop/UPower", upowerName, "OnBattery");
+	if (v == NULL)
+		return FALSE;
+	*onBattery = g_variant_get_boolean(v);
+	g_variant_unref(v);
+
+	*percent = -1;
+	v = getProperty(upowerName, "/org/freedesktop/UPower/devices/DisplayDevice", upowerName ".Device", "IsPresent");
+	if (v != NULL) {
+		if (g_variant_get_boolean(v)) {
+			GVariant *pv;
+
+			pv = getProperty(upowerName, "/org/freedesktop/UPower/devices/DisplayDevice", upowerName ".Device", "Percentage");
+			if (pv != NULL) {
+				*percent = (gint) (g_variant_get_double(pv) + 0.5);
+				g_variant_unref(pv);
+			}
+		}
+		g_variant_unref(v);
+	}
+
+	*lowPower = FALSE;
+	v = getProperty("net.hadess.PowerProfiles", "/net/hadess/PowerProfiles", "net.hadess.PowerProfiles", "ActiveProfile");
+	if (v != NULL) {
+		*lowPower = g_strcmp0(g_variant_get_string(v, NULL), "power-saver") == 0;
+		g_variant_unref(v);
+	}
+
+	return TRUE;
+}
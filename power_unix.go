@@ -0,0 +1,26 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+)
+
+// #include "gtk_unix.h"
+import "C"
+
+func power() (PowerStatus, error) {
+	var onBattery, lowPower C.gboolean
+	var percent C.gint
+
+	if C.getPowerStatus(&onBattery, &percent, &lowPower) == C.FALSE {
+		return PowerStatus{}, fmt.Errorf("UPower is not running on the system bus")
+	}
+	return PowerStatus{
+		OnBattery: fromgbool(onBattery),
+		Percent:   int(percent),
+		LowPower:  fromgbool(lowPower),
+	}, nil
+}
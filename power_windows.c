@@ -0,0 +1,20 @@
+// 14 october 2026
+
+#include "winapi_windows.h"
+#include "_cgo_export.h"
+
+void getPowerStatus(struct powerStatus *s)
+{
+	SYSTEM_POWER_STATUS sps;
+
+	if (GetSystemPowerStatus(&sps) == 0)
+		xpanic("error getting system power status", GetLastError());
+	s->onBattery = sps.ACLineStatus == 0;		// 1 is plugged in; 255 is unknown, which we treat as plugged in
+	s->percent = -1;
+	// 128 is "no system battery"; 255 means unknown in both fields
+	if (sps.BatteryFlag != 128 && sps.BatteryFlag != 255 && sps.BatteryLifePercent != 255)
+		s->percent = (int) sps.BatteryLifePercent;
+	// the fourth byte was Reserved1 before Windows 10 named it SystemStatusFlag for Battery Saver, and MinGW's headers don't agree on which name to use, so get it by position
+	// older versions of Windows always set it to 0, so this is simply false there
+	s->lowPower = ((BYTE *) (&sps))[3] != 0;
+}
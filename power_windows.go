@@ -0,0 +1,17 @@
+// 14 october 2026
+
+package ui
+
+// #include "winapi_windows.h"
+import "C"
+
+func power() (PowerStatus, error) {
+	var s C.struct_powerStatus
+
+	C.getPowerStatus(&s)
+	return PowerStatus{
+		OnBattery: s.onBattery != C.FALSE,
+		Percent:   int(s.percent),
+		LowPower:  s.lowPower != C.FALSE,
+	}, nil
+}
@@ -0,0 +1,19 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"image"
+	"unsafe"
+)
+
+// #include "objc_darwin.h"
+import "C"
+
+// returns a +1 NSImage that preview_darwin.m releases, or nil for no preview
+func toPreviewImage(img *image.RGBA) C.id {
+	if img == nil {
+		return nil
+	}
+	return C.toTableImage(unsafe.Pointer(pixelData(img)), C.intptr_t(img.Rect.Dx()), C.intptr_t(img.Rect.Dy()), C.intptr_t(img.Stride))
+}
@@ -0,0 +1,158 @@
+// 15 october 2026
+
+#import "objc_darwin.h"
+#import "_cgo_export.h"
+#import <Cocoa/Cocoa.h>
+
+#define toNSTableView(x) ((NSTableView *) (x))
+
+// the hover preview popup of Table and Tree (NSOutlineView is a NSTableView, so this handles both)
+// NSTableView has no notion of hovering over a row, so we track the mouse ourselves with a NSTrackingArea and wait out the hover delay with -performSelector:withObject:afterDelay:
+// as with goWarningPopover, the popup is a borderless window and not a NSPopover, because a NSPopover would steal focus from the control
+
+// a bit longer than a tooltip, since the preview covers more of what's under it
+#define previewDelay 0.7
+// the margin between the edge of the popup and the image
+#define previewMargin 4
+
+@interface goHoverPreview : NSObject {
+@public
+	NSTableView *view;
+	void *gocontrol;
+	BOOL tree;
+	NSInteger row;		// under the mouse; -1 for none
+	NSWindow *popup;
+	NSImageView *iv;
+}
+@end
+
+@implementation goHoverPreview
+
+- (void)hide
+{
+	[NSObject cancelPreviousPerformRequestsWithTarget:self selector:@selector(hovered) object:nil];
+	if (self->popup != nil)
+		[self->popup orderOut:self];
+	[self->iv setImage:nil];
+}
+
+- (void)cancel:(NSNotification *)note
+{
+	[self hide];
+	self->row = -1;
+}
+
+- (void)mouseMoved:(NSEvent *)e
+{
+	NSPoint pt;
+	NSInteger r;
+
+	pt = [self->view convertPoint:[e locationInWindow] fromView:nil];
+	r = [self->view rowAtPoint:pt];
+	if (r == self->row)
+		return;
+	[self hide];
+	self->row = r;
+	if (r != -1)
+		[self performSelector:@selector(hovered) withObject:nil afterDelay:previewDelay];
+}
+
+- (void)mouseEntered:(NSEvent *)e
+{
+	[self mouseMoved:e];
+}
+
+- (void)mouseExited:(NSEvent *)e
+{
+	[self cancel:nil];
+}
+
+- (void)hovered
+{
+	NSImage *img;
+	NSRect r;
+	NSPoint mouse;
+	NSSize size;
+	NSRect screen;
+
+	if (self->row == -1)
+		return;
+	if (self->tree)
+		img = (NSImage *) treeHoverPreview(self->gocontrol, (intptr_t) self->row);
+	else
+		img = (NSImage *) tableHoverPreview(self->gocontrol, (intptr_t) self->row);
+	if (img == nil)
+		return;
+
+	if (self->popup == nil) {
+		self->popup = [[NSWindow alloc] initWithContentRect:NSZeroRect
+			styleMask:NSBorderlessWindowMask
+			backing:NSBackingStoreBuffered
+			defer:YES];
+		[self->popup setHasShadow:YES];
+		[self->popup setExcludedFromWindowsMenu:YES];
+		[self->popup setLevel:NSPopUpMenuWindowLevel];
+		[self->popup setHidesOnDeactivate:YES];
+		[self->popup setIgnoresMouseEvents:YES];
+		[self->popup setReleasedWhenClosed:NO];
+		self->iv = [[NSImageView alloc] initWithFrame:NSZeroRect];
+		[self->iv setImageFrameStyle:NSImageFrameNone];
+		[[self->popup contentView] addSubview:self->iv];
+	}
+	size = [img size];
+	[self->iv setImage:img];
+	[img release];		// +1 from toTableImage(); the image view has its own reference now
+	[self->iv setFrame:NSMakeRect(previewMargin, previewMargin, size.width, size.height)];
+
+	// like a tooltip, put the popup just below the row, starting at the mouse
+	// the view is flipped, so the bottom of the row is its maximum Y; it isn't once we go to window coordinates, however
+	r = [self->view convertRect:[self->view rectOfRow:self->row] toView:nil];
+	r.origin = [[self->view window] convertBaseToScreen:r.origin];
+	mouse = [NSEvent mouseLocation];
+	size.width += 2 * previewMargin;
+	size.height += 2 * previewMargin;
+	r = NSMakeRect(mouse.x, r.origin.y - size.height, size.width, size.height);
+	// keep it on the screen; if it doesn't fit below the row, put it above
+	screen = [[[self->view window] screen] visibleFrame];
+	if (NSMinY(r) < NSMinY(screen))
+		r.origin.y += size.height + [self->view rectOfRow:self->row].size.height;
+	if (NSMaxX(r) > NSMaxX(screen))
+		r.origin.x = NSMaxX(screen) - size.width;
+	[self->popup setFrame:r display:YES];
+	[self->popup orderFront:self];
+}
+
+@end
+
+void addHoverPreview(id view, void *gocontrol, BOOL tree)
+{
+	goHoverPreview *p;
+	NSTrackingArea *ta;
+	NSClipView *clip;
+
+	p = [goHoverPreview new];
+	p->view = toNSTableView(view);
+	p->gocontrol = gocontrol;
+	p->tree = tree;
+	p->row = -1;
+	p->popup = nil;
+	p->iv = nil;
+	// NSTrackingInVisibleRect keeps the tracking area the same as the visible part of the view, so we don't need to update it as the view scrolls and resizes
+	ta = [[NSTrackingArea alloc] initWithRect:NSZeroRect
+		options:(NSTrackingMouseMoved | NSTrackingMouseEnteredAndExited | NSTrackingActiveInKeyWindow | NSTrackingInVisibleRect)
+		owner:p
+		userInfo:nil];
+	[toNSTableView(view) addTrackingArea:ta];
+	[ta release];
+	// take the preview down when the user clicks and when the view scrolls
+	[[NSNotificationCenter defaultCenter] addObserver:p
+		selector:@selector(cancel:)
+		name:NSTableViewSelectionDidChangeNotification
+		object:view];
+	clip = [[toNSTableView(view) enclosingScrollView] contentView];
+	[clip setPostsBoundsChangedNotifications:YES];
+	[[NSNotificationCenter defaultCenter] addObserver:p
+		selector:@selector(cancel:)
+		name:NSViewBoundsDidChangeNotification
+		object:clip];
+}
@@ -0,0 +1,44 @@
+// +build !windows,!darwin
+
+// 15 october 2026
+
+package ui
+
+import (
+	"image"
+	"unsafe"
+)
+
+// #include "gtk_unix.h"
+import "C"
+
+// Table and Tree both use GtkTreeView's own tooltips for hover previews: GTK+ already handles the hover delay, following the mouse from row to row, and hiding the tooltip on clicks and scrolls
+// the tooltip window shows an icon and text side by side, so an icon with no text gives us a popup with just the image
+
+func setHoverPreviewTooltip(treeview *C.GtkTreeView) {
+	C.gtk_widget_set_has_tooltip((*C.GtkWidget)(unsafe.Pointer(treeview)), C.TRUE)
+}
+
+// this returns the path of the row at (x, y), which the caller must free, or nil if there is no row there
+// x and y are in the coordinates query-tooltip gives us, which aren't the same as the bin window coordinates the rest of GtkTreeView uses
+func hoverPreviewRow(treeview *C.GtkTreeView, x C.gint, y C.gint, keyboard C.gboolean) *C.GtkTreePath {
+	var path *C.GtkTreePath
+
+	if C.gtk_tree_view_get_tooltip_context(treeview, &x, &y, keyboard, nil, &path, nil) == C.FALSE {
+		return nil
+	}
+	return path
+}
+
+// returns the value for query-tooltip to return
+func showHoverPreview(treeview *C.GtkTreeView, tooltip *C.GtkTooltip, path *C.GtkTreePath, img *image.RGBA) C.gboolean {
+	if img == nil {
+		return C.FALSE
+	}
+	pixbuf := toGdkPixbuf(img)
+	C.gtk_tooltip_set_icon(tooltip, pixbuf) // takes its own reference
+	C.g_object_unref(C.gpointer(unsafe.Pointer(pixbuf)))
+	// this makes GTK+ put the tooltip next to the row and hide it once the mouse leaves the row
+	C.gtk_tree_view_set_tooltip_row(treeview, tooltip, path)
+	return C.TRUE
+}
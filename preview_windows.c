@@ -0,0 +1,223 @@
+// 15 october 2026
+
+#include "winapi_windows.h"
+#include "_cgo_export.h"
+
+// the hover preview popup of Table and Tree
+// there's only one mouse, so there's only ever one preview; all the state is shared
+// the controls tell us which of their items is under the mouse on each WM_MOUSEMOVE, and we use TrackMouseEvent() to find out when the mouse has rested on one for the system hover time
+
+static HWND previewPopup = NULL;
+static HBITMAP previewBitmap = NULL;
+static HWND previewOwner = NULL;		// the control the mouse is in
+static intptr_t previewItem = -1;		// the item of previewOwner under the mouse, or -1 for none
+
+// the margin between the edge of the popup and the image
+#define previewMargin 4
+
+static void paintPreview(HWND hwnd)
+{
+	PAINTSTRUCT ps;
+	HDC dc, idc;
+	HBITMAP previbitmap;
+	BITMAP bi;
+	BLENDFUNCTION bf;
+	RECT r;
+
+	dc = BeginPaint(hwnd, &ps);
+	if (dc == NULL)
+		xpanic("error beginning hover preview paint", GetLastError());
+	if (GetClientRect(hwnd, &r) == 0)
+		xpanic("error getting hover preview client rect for painting", GetLastError());
+	// draw the background like a tooltip does
+	if (FillRect(dc, &r, GetSysColorBrush(COLOR_INFOBK)) == 0)
+		xpanic("error filling hover preview background", GetLastError());
+	if (previewBitmap != NULL) {
+		if (GetObject(previewBitmap, sizeof (BITMAP), &bi) == 0)
+			xpanic("error getting hover preview image size", GetLastError());
+		idc = CreateCompatibleDC(dc);
+		if (idc == NULL)
+			xpanic("error creating compatible DC for hover preview image", GetLastError());
+		previbitmap = (HBITMAP) SelectObject(idc, previewBitmap);
+		if (previbitmap == NULL)
+			xpanic("error selecting hover preview image into compatible DC", GetLastError());
+		ZeroMemory(&bf, sizeof (BLENDFUNCTION));
+		bf.BlendOp = AC_SRC_OVER;
+		bf.BlendFlags = 0;
+		bf.SourceConstantAlpha = 255;		// only use per-pixel alphas
+		bf.AlphaFormat = AC_SRC_ALPHA;	// premultiplied
+		if (AlphaBlend(dc, previewMargin, previewMargin, bi.bmWidth, bi.bmHeight,
+			idc, 0, 0, bi.bmWidth, bi.bmHeight, bf) == FALSE)
+			xpanic("error drawing hover preview image", GetLastError());
+		if (SelectObject(idc, previbitmap) != previewBitmap)
+			xpanic("error deselecting hover preview image from compatible DC", GetLastError());
+		if (DeleteDC(idc) == 0)
+			xpanic("error deleting compatible DC for hover preview image", GetLastError());
+	}
+	EndPaint(hwnd, &ps);
+}
+
+static LRESULT CALLBACK previewWndProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM lParam)
+{
+	switch (uMsg) {
+	case WM_MOUSEACTIVATE:
+		return MA_NOACTIVATE;
+	case WM_NCHITTEST:
+		// let the mouse through to the control underneath so it keeps getting WM_MOUSEMOVE
+		return HTTRANSPARENT;
+	case WM_ERASEBKGND:
+		return 1;		// paintPreview() draws the background
+	case WM_PAINT:
+		paintPreview(hwnd);
+		return 0;
+	default:
+		return DefWindowProcW(hwnd, uMsg, wParam, lParam);
+	}
+	xmissedmsg("hover preview", "previewWndProc()", uMsg);
+	return 0;			// unreached
+}
+
+DWORD makePreviewWindowClass(char **errmsg)
+{
+	WNDCLASSW wc;
+
+	ZeroMemory(&wc, sizeof (WNDCLASSW));
+	wc.style = CS_DROPSHADOW;		// like tooltips; ignored before Windows XP
+	wc.lpszClassName = previewWindowClass;
+	wc.lpfnWndProc = previewWndProc;
+	wc.hInstance = hInstance;
+	wc.hCursor = hArrowCursor;
+	wc.hbrBackground = NULL;				// no brush; we handle WM_ERASEBKGND
+	if (RegisterClassW(&wc) == 0) {
+		*errmsg = "error registering hover preview window class";
+		return GetLastError();
+	}
+	return 0;
+}
+
+static void hidePreview(void)
+{
+	if (previewPopup != NULL)
+		ShowWindow(previewPopup, SW_HIDE);
+	if (previewBitmap != NULL) {
+		if (DeleteObject(previewBitmap) == 0)
+			xpanic("error deleting old hover preview image", GetLastError());
+		previewBitmap = NULL;
+	}
+}
+
+// call this on WM_MOUSEMOVE with the item under the mouse, or -1 for none
+void previewMouseMove(HWND hwnd, intptr_t item)
+{
+	TRACKMOUSEEVENT tme;
+
+	if (hwnd == previewOwner && item == previewItem)
+		return;
+	hidePreview();
+	previewOwner = hwnd;
+	previewItem = item;
+	if (item == -1)
+		return;
+	// WM_MOUSEHOVER is only sent once per TrackMouseEvent(), so we need to ask again for each new item
+	// this doesn't affect the TME_LEAVE tracking the control may be doing itself
+	ZeroMemory(&tme, sizeof (TRACKMOUSEEVENT));
+	tme.cbSize = sizeof (TRACKMOUSEEVENT);
+	tme.dwFlags = TME_HOVER | TME_LEAVE;
+	tme.hwndTrack = hwnd;
+	tme.dwHoverTime = HOVER_DEFAULT;
+	if ((*fv__TrackMouseEvent)(&tme) == 0)
+		xpanic("error tracking mouse hover for hover preview", GetLastError());
+}
+
+// call this on WM_MOUSEHOVER; returns the item to show a preview for, or -1 for none
+intptr_t previewHoveredItem(HWND hwnd)
+{
+	if (hwnd != previewOwner)
+		return -1;
+	return previewItem;
+}
+
+// call this on WM_MOUSELEAVE and anything else that should take the preview down, such as clicks and scrolling
+// the next WM_MOUSEMOVE starts over
+void previewCancel(HWND hwnd)
+{
+	if (hwnd != previewOwner)
+		return;
+	hidePreview();
+	previewOwner = NULL;
+	previewItem = -1;
+}
+
+// shows the image i (dx by dy) under the item whose rectangle, in client coordinates, is given
+void showPreview(HWND hwnd, RECT *item, void *i, intptr_t dx, intptr_t dy)
+{
+	BITMAPINFO bi;
+	VOID *ppvBits;
+	POINT pt;
+	RECT r;
+	HMONITOR monitor;
+	MONITORINFO mi;
+
+	hidePreview();
+	ZeroMemory(&bi, sizeof (BITMAPINFO));
+	bi.bmiHeader.biSize = sizeof (BITMAPINFOHEADER);
+	bi.bmiHeader.biWidth = (LONG) dx;
+	bi.bmiHeader.biHeight = -((LONG) dy);			// negative height to force top-down drawing
+	bi.bmiHeader.biPlanes = 1;
+	bi.bmiHeader.biBitCount = 32;
+	bi.bmiHeader.biCompression = BI_RGB;
+	bi.bmiHeader.biSizeImage = (DWORD) (dx * dy * 4);
+	previewBitmap = CreateDIBSection(NULL, &bi, DIB_RGB_COLORS, &ppvBits, 0, 0);
+	if (previewBitmap == NULL)
+		xpanic("error creating HBITMAP for hover preview image", GetLastError());
+	dotoARGB(i, (void *) ppvBits, FALSE);		// FALSE = premultiplied, for AlphaBlend()
+
+	if (previewPopup == NULL) {
+		previewPopup = CreateWindowExW(WS_EX_TOOLWINDOW | WS_EX_TOPMOST | WS_EX_NOACTIVATE,
+			previewWindowClass, L"",
+			WS_POPUP | WS_BORDER,
+			0, 0, 0, 0,
+			NULL, NULL, hInstance, NULL);
+		if (previewPopup == NULL)
+			xpanic("error creating hover preview popup", GetLastError());
+	}
+
+	r.left = 0;
+	r.top = 0;
+	r.right = (LONG) dx + 2 * previewMargin;
+	r.bottom = (LONG) dy + 2 * previewMargin;
+	if (AdjustWindowRectEx(&r, WS_POPUP | WS_BORDER, FALSE, WS_EX_TOOLWINDOW | WS_EX_TOPMOST | WS_EX_NOACTIVATE) == 0)
+		xpanic("error getting hover preview window size", GetLastError());
+	OffsetRect(&r, -r.left, -r.top);
+
+	// like a tooltip, put the popup just below the item, starting at the mouse
+	if (GetCursorPos(&pt) == 0)
+		xpanic("error getting mouse position for hover preview", GetLastError());
+	if (ScreenToClient(hwnd, &pt) == 0)
+		xpanic("error converting mouse position for hover preview", GetLastError());
+	pt.y = item->bottom;
+	if (ClientToScreen(hwnd, &pt) == 0)
+		xpanic("error converting hover preview position to screen coordinates", GetLastError());
+	OffsetRect(&r, pt.x, pt.y);
+
+	// keep it on the screen; if it doesn't fit below the item, put it above
+	monitor = MonitorFromPoint(pt, MONITOR_DEFAULTTONEAREST);
+	ZeroMemory(&mi, sizeof (MONITORINFO));
+	mi.cbSize = sizeof (MONITORINFO);
+	if (GetMonitorInfoW(monitor, &mi) == 0)
+		xpanic("error getting monitor work area for hover preview", GetLastError());
+	if (r.bottom > mi.rcWork.bottom)
+		OffsetRect(&r, 0, -(r.bottom - r.top) - (item->bottom - item->top));
+	if (r.right > mi.rcWork.right)
+		OffsetRect(&r, mi.rcWork.right - r.right, 0);
+	if (r.left < mi.rcWork.left)
+		OffsetRect(&r, mi.rcWork.left - r.left, 0);
+
+	if (SetWindowPos(previewPopup, HWND_TOPMOST,
+		r.left, r.top, r.right - r.left, r.bottom - r.top,
+		SWP_NOACTIVATE) == 0)
+		xpanic("error moving hover preview popup", GetLastError());
+	if (InvalidateRect(previewPopup, NULL, TRUE) == 0)
+		xpanic("error queueing hover preview redraw", GetLastError());
+	ShowWindow(previewPopup, SW_SHOWNOACTIVATE);
+}
@@ -0,0 +1,31 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"image"
+	"syscall"
+	"unsafe"
+)
+
+// #include "winapi_windows.h"
+import "C"
+
+func makePreviewWindowClass() error {
+	var errmsg *C.char
+
+	err := C.makePreviewWindowClass(&errmsg)
+	if err != 0 || errmsg != nil {
+		return fmt.Errorf("%s: %v", C.GoString(errmsg), syscall.Errno(err))
+	}
+	return nil
+}
+
+// item is the rectangle of the hovered item in client coordinates of hwnd
+func showPreview(hwnd C.HWND, item *C.RECT, img *image.RGBA) {
+	if img == nil {
+		return
+	}
+	C.showPreview(hwnd, item, unsafe.Pointer(img), C.intptr_t(img.Rect.Dx()), C.intptr_t(img.Rect.Dy()))
+}
@@ -29,3 +29,12 @@ func (p *progressbar) SetPercent(percent int) {
 	}
 	C.progressbarSetPercent(p.id, C.intmax_t(percent))
 }
+
+// NSProgressIndicator keeps its value in indeterminate mode, so there's nothing to keep here
+func (p *progressbar) Indeterminate() bool {
+	return fromBOOL(C.progressbarIndeterminate(p.id))
+}
+
+func (p *progressbar) SetIndeterminate(indeterminate bool) {
+	C.progressbarSetIndeterminate(p.id, toBOOL(indeterminate))
+}
@@ -10,11 +10,25 @@ import (
 )
 
 // #include "gtk_unix.h"
+// static gboolean progressbarPulse(gpointer data)
+// {
+// 	gtk_progress_bar_pulse(GTK_PROGRESS_BAR(data));
+// 	return G_SOURCE_CONTINUE;
+// }
+// /* the timer holds a reference so the widget can't be freed out from under it */
+// static guint progressbarStartPulsing(GtkProgressBar *pbar)
+// {
+// 	return g_timeout_add_full(G_PRIORITY_DEFAULT, 100, progressbarPulse, g_object_ref(pbar), g_object_unref);
+// }
 import "C"
 
+// GtkProgressBar only moves one step each time gtk_progress_bar_pulse() is called, so indeterminate mode is a timer that calls it
+// GTK+ doesn't keep the fraction in pulse mode, so we keep it here
 type progressbar struct {
 	*controlSingleWidget
 	pbar		*C.GtkProgressBar
+	percent	int
+	pulser	C.guint		// 0 if not indeterminate
 }
 
 func newProgressBar() ProgressBar {
@@ -27,12 +41,33 @@ func newProgressBar() ProgressBar {
 }
 
 func (p *progressbar) Percent() int {
-	return int(C.gtk_progress_bar_get_fraction(p.pbar) * 100)
+	return p.percent
 }
 
 func (p *progressbar) SetPercent(percent int) {
 	if percent < 0 || percent > 100 {
 		panic(fmt.Errorf("given ProgressBar percentage %d out of range", percent))
 	}
-	C.gtk_progress_bar_set_fraction(p.pbar, C.gdouble(percent) / 100)
+	p.percent = percent
+	if p.pulser != 0 {
+		return
+	}
+	C.gtk_progress_bar_set_fraction(p.pbar, C.gdouble(p.percent) / 100)
+}
+
+func (p *progressbar) Indeterminate() bool {
+	return p.pulser != 0
+}
+
+func (p *progressbar) SetIndeterminate(indeterminate bool) {
+	if indeterminate == (p.pulser != 0) {
+		return
+	}
+	if indeterminate {
+		p.pulser = C.progressbarStartPulsing(p.pbar)
+		return
+	}
+	C.g_source_remove(p.pulser)
+	p.pulser = 0
+	C.gtk_progress_bar_set_fraction(p.pbar, C.gdouble(p.percent) / 100)
 }
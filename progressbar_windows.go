@@ -11,6 +11,9 @@ import "C"
 
 type progressbar struct {
 	*controlSingleHWND
+	// PBM_GETPOS isn't meaningful for a marquee progress bar, so keep our own copy
+	percent			int
+	indeterminate		bool
 }
 
 func newProgressBar() ProgressBar {
@@ -25,19 +28,41 @@ func newProgressBar() ProgressBar {
 		// progress bars are not tab stops
 		return 0
 	}
+	p.fsetFocus = nil		// nor can they take the focus
 	return p
 }
 
 func (p *progressbar) Percent() int {
-	return int(C.SendMessageW(p.hwnd, C.PBM_GETPOS, 0, 0))
+	return p.percent
 }
 
 func (p *progressbar) SetPercent(percent int) {
 	if percent < 0 || percent > 100 {
 		panic(fmt.Errorf("given ProgressBar percentage %d out of range", percent))
 	}
+	p.percent = percent
+	if p.indeterminate {
+		return
+	}
 	// TODO circumvent aero
-	C.SendMessageW(p.hwnd, C.PBM_SETPOS, C.WPARAM(percent), 0)
+	C.SendMessageW(p.hwnd, C.PBM_SETPOS, C.WPARAM(p.percent), 0)
+}
+
+func (p *progressbar) Indeterminate() bool {
+	return p.indeterminate
+}
+
+func (p *progressbar) SetIndeterminate(indeterminate bool) {
+	if indeterminate == p.indeterminate {
+		return
+	}
+	p.indeterminate = indeterminate
+	if p.indeterminate {
+		C.progressbarSetMarquee(p.hwnd, C.TRUE)
+		return
+	}
+	C.progressbarSetMarquee(p.hwnd, C.FALSE)
+	C.SendMessageW(p.hwnd, C.PBM_SETPOS, C.WPARAM(p.percent), 0)
 }
 
 const (
@@ -0,0 +1,96 @@
+// 15 october 2026
+
+package ui
+
+// ProgressDialog is a dialog box that shows the progress of a long operation and lets the user cancel it.
+// The operation itself usually runs on another goroutine; use Do or Window.Post to call the ProgressDialog's methods from there, as they must be called on the main thread.
+type ProgressDialog interface {
+	// SetText sets the text shown under the primary message, such as the name of the file being worked on.
+	SetText(text string)
+
+	// SetPercent sets the percentage shown by the progress bar, as with ProgressBar.SetPercent.
+	// Pass -1 to show that the length of the operation isn't known (see ProgressBar.SetIndeterminate); the next SetPercent with a percentage ends that.
+	SetPercent(percent int)
+
+	// OnCancel registers the event handler called when the user clicks Cancel, presses Escape, or tries to close the dialog box.
+	// The dialog box stays open, with Cancel disabled, so that the handler can stop the operation and call Close once it has; until then, the user sees that cancelling is underway.
+	// If no handler is registered, the user cannot cancel.
+	OnCancel(f func())
+
+	// Close closes the dialog box; the ProgressDialog must not be used after that.
+	// Close must be called when the operation finishes, whether it succeeded, failed, or was cancelled.
+	Close()
+}
+
+// NewProgressDialog opens a ProgressDialog modal to win, which must not be nil, showing primary as its main message.
+// On Mac OS X, it is a sheet unless win's dialog boxes are detached; see Window.SetDialogsAttached.
+// The progress bar starts at 0%.
+func NewProgressDialog(win Window, primary string) ProgressDialog {
+	if win == nil {
+		panic("Window passed to NewProgressDialog() cannot be nil")
+	}
+	p := &progressDialog{
+		owner:  win,
+		text:   NewLabel(""),
+		bar:    NewProgressBar(),
+		cancel: NewButton("Cancel"),
+	}
+	p.cancel.OnClicked(p.cancelled)
+	p.cancel.Disable() // until OnCancel
+	s := NewVerticalStack(NewLabel(primary), p.text, p.bar, NewDialogButtons(nil, p.cancel))
+	s.SetPadded(true)
+	p.w = NewWindow(win.Title(), 360, 120, s)
+	p.w.SetMargined(true)
+	p.w.OnClosing(func() bool {
+		p.cancelled()
+		return false
+	})
+	p.w.RegisterShortcut(Escape, 0, p.cancelled)
+	win.showModal(p.w)
+	return p
+}
+
+type progressDialog struct {
+	owner    Window
+	w        Window
+	text     Label
+	bar      ProgressBar
+	cancel   Button
+	oncancel func()
+}
+
+func (p *progressDialog) SetText(text string) {
+	p.text.SetText(text)
+}
+
+func (p *progressDialog) SetPercent(percent int) {
+	if percent == -1 {
+		p.bar.SetIndeterminate(true)
+		return
+	}
+	p.bar.SetIndeterminate(false)
+	p.bar.SetPercent(percent)
+}
+
+func (p *progressDialog) OnCancel(f func()) {
+	p.oncancel = f
+	if f == nil {
+		p.cancel.Disable()
+		return
+	}
+	p.cancel.Enable()
+}
+
+// Escape and the close button still get here after Cancel is disabled, so check
+func (p *progressDialog) cancelled() {
+	if p.oncancel == nil || !p.cancel.Enabled() {
+		return
+	}
+	p.cancel.Disable()
+	p.oncancel()
+}
+
+func (p *progressDialog) Close() {
+	p.owner.endModal(p.w)
+	p.w.Close()
+}
@@ -0,0 +1,191 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Prompt opens a dialog box that asks the user to type a line of text.
+// primary and secondary are as with MsgBox; the text field starts out containing initial.
+// The dialog box is modal to win, which must not be nil.
+// Some time after the dialog box is closed, Prompt runs f on the main thread, passing the button the user chose and the text in the field.
+// result is DialogOK or DialogCancel; Enter chooses OK and Escape chooses Cancel.
+// On DialogCancel, text is initial.
+// Prompt does not ensure that f remains alive; the programmer is responsible for this.
+func Prompt(win Window, primary string, secondary string, initial string, f func(result DialogResult, text string)) {
+	if win == nil {
+		panic("Window passed to Prompt() cannot be nil")
+	}
+	if f == nil {
+		panic("function passed to Prompt() cannot be nil")
+	}
+	field := NewTextField()
+	field.SetText(initial)
+	d := newPromptDialog(win, primary, secondary, field, false)
+	d.done = func(result DialogResult) {
+		if result != DialogOK {
+			f(result, initial)
+			return
+		}
+		f(result, field.Text())
+	}
+	d.show(true)
+	field.SetFocus()
+}
+
+// PromptMultiline is like Prompt, but asks for any number of lines of text in a Textbox.
+// Enter starts a new line in the Textbox, so only the OK button chooses OK; Escape still chooses Cancel.
+func PromptMultiline(win Window, primary string, secondary string, initial string, f func(result DialogResult, text string)) {
+	if win == nil {
+		panic("Window passed to PromptMultiline() cannot be nil")
+	}
+	if f == nil {
+		panic("function passed to PromptMultiline() cannot be nil")
+	}
+	box := NewTextbox()
+	box.SetText(initial)
+	d := newPromptDialog(win, primary, secondary, box, true)
+	d.done = func(result DialogResult) {
+		if result != DialogOK {
+			f(result, initial)
+			return
+		}
+		f(result, box.Text())
+	}
+	d.show(false)
+	box.SetFocus()
+}
+
+// Choose opens a dialog box that asks the user to pick one of items from a list.
+// primary is as with MsgBox; above the list is a search field that narrows the list down to the items containing what the user types, ignoring case.
+// The dialog box is modal to win, which must not be nil.
+// Some time after the dialog box is closed, Choose runs f on the main thread, passing the index into items of the chosen item, or -1 if the user chose Cancel or pressed Escape.
+// OK chooses the selected item, or the only item left by the search if none is selected; if there is no such item, OK does nothing.
+// Choose does not ensure that f remains alive; the programmer is responsible for this.
+func Choose(win Window, primary string, items []string, f func(index int)) {
+	if win == nil {
+		panic("Window passed to Choose() cannot be nil")
+	}
+	if f == nil {
+		panic("function passed to Choose() cannot be nil")
+	}
+	search := NewTextField()
+	list := NewTable(reflect.TypeOf(chooseItem{}))
+	var shown []int // indices into items of the rows of list
+	filter := func() {
+		s := strings.ToLower(search.Text())
+		shown = shown[:0]
+		list.Lock()
+		data := list.Data().(*[]chooseItem)
+		*data = (*data)[:0]
+		for i, item := range items {
+			if s == "" || strings.Contains(strings.ToLower(item), s) {
+				shown = append(shown, i)
+				*data = append(*data, chooseItem{Item: item})
+			}
+		}
+		list.Unlock()
+	}
+	search.OnChanged(filter)
+	filter()
+	content := NewVerticalStack(search, list)
+	content.SetPadded(true)
+	content.SetStretchy(1)
+	d := newPromptDialog(win, primary, "", content, true)
+	d.accept = func() bool {
+		n := list.Selected()
+		if n == -1 && len(shown) == 1 {
+			n = 0
+		}
+		return n != -1
+	}
+	d.done = func(result DialogResult) {
+		if result != DialogOK {
+			f(-1)
+			return
+		}
+		n := list.Selected()
+		if n == -1 {
+			n = 0 // accept made sure this is the only one
+		}
+		f(shown[n])
+	}
+	d.show(true)
+	search.SetFocus()
+}
+
+type chooseItem struct {
+	Item string
+}
+
+// promptDialog is the Window shared by Prompt, PromptMultiline, and Choose, made out of our own Controls since not every system has these dialog boxes
+type promptDialog struct {
+	owner  Window
+	w      Window
+	accept func() bool // nil to always accept OK
+	done   func(result DialogResult)
+}
+
+func newPromptDialog(owner Window, primary string, secondary string, field Control, stretchy bool) *promptDialog {
+	d := &promptDialog{
+		owner: owner,
+	}
+	ok := NewButton("OK")
+	ok.OnClicked(func() {
+		d.finish(DialogOK)
+	})
+	cancel := NewButton("Cancel")
+	cancel.OnClicked(func() {
+		d.finish(DialogCancel)
+	})
+	buttons := NewDialogButtons(ok, cancel)
+	s := NewVerticalStack(NewLabel(primary))
+	if secondary != "" {
+		s.Append(NewLabel(secondary))
+	}
+	s.Append(field)
+	if stretchy {
+		s.SetStretchy(s.Len() - 1)
+	}
+	s.Append(buttons)
+	s.SetPadded(true)
+	height := 120
+	if stretchy {
+		height = 300
+	}
+	d.w = NewWindow(owner.Title(), 360, height, s)
+	d.w.SetMargined(true)
+	d.w.OnClosing(func() bool {
+		d.finish(DialogCancel)
+		return false // finish() closes it
+	})
+	d.w.RegisterShortcut(Escape, 0, func() {
+		d.finish(DialogCancel)
+	})
+	return d
+}
+
+// enter is whether Enter chooses OK; it can't in a Textbox, which needs it for new lines
+func (d *promptDialog) show(enter bool) {
+	if enter {
+		d.w.RegisterShortcut('\n', 0, func() {
+			d.finish(DialogOK)
+		})
+		d.w.RegisterShortcut(NEnter, 0, func() {
+			d.finish(DialogOK)
+		})
+	}
+	d.owner.showModal(d.w)
+}
+
+func (d *promptDialog) finish(result DialogResult) {
+	if result == DialogOK && d.accept != nil && !d.accept() {
+		return
+	}
+	// the owner has to be usable again before the dialog box goes away, or the system activates some other window
+	d.owner.endModal(d.w)
+	d.w.Close()
+	d.done(result)
+}
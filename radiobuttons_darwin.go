@@ -0,0 +1,60 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// #include "objc_darwin.h"
+import "C"
+
+type radiobuttons struct {
+	*controlSingleObject
+	n        int
+	current  int
+	selected *event
+}
+
+func newRadioButtons(labels []string) *radiobuttons {
+	r := &radiobuttons{
+		controlSingleObject: newControlSingleObject(C.newRadioButtons(C.intptr_t(len(labels)))),
+		n:                   len(labels),
+		selected:            newEvent(),
+	}
+	for i, label := range labels {
+		clabel := C.CString(label)
+		C.radiobuttonsSetText(r.id, C.intptr_t(i), clabel)
+		C.free(unsafe.Pointer(clabel))
+	}
+	C.radiobuttonsSetDelegate(r.id, unsafe.Pointer(r))
+	return r
+}
+
+func (r *radiobuttons) Selected() int {
+	return int(C.radiobuttonsSelected(r.id))
+}
+
+func (r *radiobuttons) SetSelected(index int) {
+	if index < 0 || index >= r.n {
+		panic(fmt.Errorf("index %d out of range in RadioButtons.SetSelected()", index))
+	}
+	C.radiobuttonsSelect(r.id, C.intptr_t(index))
+	r.current = index
+}
+
+func (r *radiobuttons) OnSelected(e func()) {
+	r.selected.set(e)
+}
+
+//export radiobuttonsClicked
+func radiobuttonsClicked(data unsafe.Pointer) {
+	r := (*radiobuttons)(data)
+	// the action is also sent when the selected button is clicked again
+	if r.Selected() == r.current {
+		return
+	}
+	r.current = r.Selected()
+	r.selected.fire()
+}
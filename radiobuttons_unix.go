@@ -0,0 +1,80 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// #include "gtk_unix.h"
+// extern void radiobuttonsToggled(GtkToggleButton *, gpointer);
+import "C"
+
+type radiobuttons struct {
+	*controlSingleWidget
+	box      *C.GtkBox
+	buttons  []*C.GtkToggleButton
+	selected *event
+	setting  bool // so SetSelected() doesn't trigger the event
+}
+
+func newRadioButtons(labels []string) *radiobuttons {
+	widget := C.gtk_box_new(C.GTK_ORIENTATION_VERTICAL, 0)
+	r := &radiobuttons{
+		controlSingleWidget: newControlSingleWidget(widget),
+		box:                 (*C.GtkBox)(unsafe.Pointer(widget)),
+		selected:            newEvent(),
+	}
+	var prev *C.GtkRadioButton // nil for the first one, which starts the group
+
+	for _, label := range labels {
+		clabel := togstr(label)
+		bwid := C.gtk_radio_button_new_with_label_from_widget(prev, clabel)
+		freegstr(clabel)
+		C.gtk_box_pack_start(r.box, bwid, C.FALSE, C.FALSE, 0)
+		prev = (*C.GtkRadioButton)(unsafe.Pointer(bwid))
+		r.buttons = append(r.buttons, (*C.GtkToggleButton)(unsafe.Pointer(bwid)))
+		g_signal_connect(
+			C.gpointer(unsafe.Pointer(bwid)),
+			"toggled",
+			C.GCallback(C.radiobuttonsToggled),
+			C.gpointer(unsafe.Pointer(r)))
+	}
+	// the first button in a group is active by default
+	return r
+}
+
+func (r *radiobuttons) Selected() int {
+	for i, b := range r.buttons {
+		if fromgbool(C.gtk_toggle_button_get_active(b)) {
+			return i
+		}
+	}
+	panic("no radio button selected in RadioButtons.Selected()")
+}
+
+func (r *radiobuttons) SetSelected(index int) {
+	if index < 0 || index >= len(r.buttons) {
+		panic(fmt.Errorf("index %d out of range in RadioButtons.SetSelected()", index))
+	}
+	r.setting = true
+	C.gtk_toggle_button_set_active(r.buttons[index], C.TRUE)
+	r.setting = false
+}
+
+func (r *radiobuttons) OnSelected(e func()) {
+	r.selected.set(e)
+}
+
+//export radiobuttonsToggled
+func radiobuttonsToggled(bwid *C.GtkToggleButton, data C.gpointer) {
+	r := (*radiobuttons)(unsafe.Pointer(data))
+	// toggled is sent to both the newly active button and the previously active one; only count the former
+	if r.setting || !fromgbool(C.gtk_toggle_button_get_active(bwid)) {
+		return
+	}
+	r.selected.fire()
+}
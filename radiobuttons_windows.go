@@ -0,0 +1,142 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// #include "winapi_windows.h"
+import "C"
+
+// this is one BUTTON per choice; like Spinbox, it isn't a single control, so it implements Control itself
+
+type radiobuttons struct {
+	controlstate
+	buttons  []*controlSingleHWNDWithText
+	current  int
+	selected *event
+}
+
+func newRadioButtons(labels []string) *radiobuttons {
+	r := &radiobuttons{
+		selected: newEvent(),
+	}
+	for i, label := range labels {
+		// see newCheckbox() for why we don't use BS_AUTORADIOBUTTON; the tab stop is set by radiobuttonSetChecked()
+		style := C.DWORD(C.BS_RADIOBUTTON)
+		if i == 0 {
+			style |= C.WS_GROUP // start the group the arrow keys move around in
+		}
+		hwnd := C.newControl(buttonclass, style, 0)
+		b := newControlSingleHWNDWithText(hwnd)
+		b.setText(label)
+		C.controlSetControlFont(hwnd)
+		C.setRadioButtonSubclass(hwnd, unsafe.Pointer(r), C.UINT_PTR(i))
+		r.buttons = append(r.buttons, b)
+	}
+	C.radiobuttonSetChecked(r.buttons[0].hwnd, C.TRUE)
+	r.fsetShown = r.setShown
+	r.fsetEnabled = r.setEnabled
+	r.fsetTooltip = r.setTooltip
+	r.fsetFocus = r.setFocus
+	r.fdestroy = r.destroyButtons
+	// there is no single window to give as r.handle, so RadioButtons keeps its place in the tab order and Window.OnFocusChanged() reports nil for it
+	return r
+}
+
+func (r *radiobuttons) Selected() int {
+	return r.current
+}
+
+func (r *radiobuttons) SetSelected(index int) {
+	if index < 0 || index >= len(r.buttons) {
+		panic(fmt.Errorf("index %d out of range in RadioButtons.SetSelected()", index))
+	}
+	C.radiobuttonSetChecked(r.buttons[r.current].hwnd, C.FALSE)
+	C.radiobuttonSetChecked(r.buttons[index].hwnd, C.TRUE)
+	r.current = index
+}
+
+func (r *radiobuttons) OnSelected(e func()) {
+	r.selected.set(e)
+}
+
+//export radiobuttonClicked
+func radiobuttonClicked(data unsafe.Pointer, index C.UINT_PTR) {
+	r := (*radiobuttons)(data)
+	// this is also sent when the arrow keys move to a button, or when an already selected button is clicked again
+	if int(index) == r.current {
+		return
+	}
+	r.SetSelected(int(index))
+	r.selected.fire()
+}
+
+func (r *radiobuttons) setParent(p *controlParent) {
+	r.parent = p
+	for _, b := range r.buttons {
+		b.setParent(p)
+	}
+	r.reapply()
+}
+
+const (
+	// from http://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing; radio buttons are the same height as checkboxes, and the buttons of a group are related controls
+	radiobuttonsYSpacing = 4
+)
+
+func (r *radiobuttons) preferredSize(d *sizing) (width, height int) {
+	for _, b := range r.buttons {
+		if int(b.textlen) > width {
+			width = int(b.textlen)
+		}
+	}
+	width += fromdlgunitsX(checkboxXFromLeftOfBoxToLeftOfLabel, d)
+	height = len(r.buttons)*fromdlgunitsY(checkboxHeight, d) + (len(r.buttons)-1)*fromdlgunitsY(radiobuttonsYSpacing, d)
+	return width, height
+}
+
+func (r *radiobuttons) resize(x int, y int, width int, height int, d *sizing) {
+	bheight := fromdlgunitsY(checkboxHeight, d)
+	spacing := fromdlgunitsY(radiobuttonsYSpacing, d)
+	for _, b := range r.buttons {
+		b.resize(x, y, width, bheight, d)
+		y += bheight + spacing
+	}
+}
+
+func (r *radiobuttons) nTabStops() int {
+	// only the selected button is a tab stop
+	return 1
+}
+
+func (r *radiobuttons) setShown(shown bool) {
+	for _, b := range r.buttons {
+		b.fsetShown(shown)
+	}
+}
+
+func (r *radiobuttons) setEnabled(enabled bool) {
+	for _, b := range r.buttons {
+		b.fsetEnabled(enabled)
+	}
+}
+
+// the selected button is the one that is a tab stop
+func (r *radiobuttons) setFocus() {
+	C.SetFocus(r.buttons[r.current].hwnd)
+}
+
+func (r *radiobuttons) setTooltip(text string) {
+	for _, b := range r.buttons {
+		b.fsetTooltip(text)
+	}
+}
+
+func (r *radiobuttons) destroyButtons() {
+	for _, b := range r.buttons {
+		b.fdestroy()
+	}
+}
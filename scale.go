@@ -0,0 +1,39 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"math"
+)
+
+// SetScaleFactor makes the Controls of every Window, including those made later, factor times their normal size, on top of whatever scaling the system already does for the resolution of the screen.
+// This is for users who want things bigger than the system's own settings make them, and for showing a program to an audience; for example, 1.5 makes everything half again as big.
+// It works by scaling the font Controls use, which their sizes and the spacing between them follow, so Controls stay sharp rather than being stretched.
+//
+// The sizes of Windows (as given to NewWindow and SetSize) are not scaled; make a Window bigger yourself if its contents no longer fit.
+// Areas are not scaled either, as what they show is the program's own drawing: an Area that should follow the factor can multiply its size (with SetSize) and its drawing by ScaleFactor, and repaint itself after calling SetScaleFactor.
+// On GTK+, the font is scaled through the gtk-xft-dpi setting, which GNOME's own text scaling setting also changes; if the user changes that while the program is running, the system's setting wins until SetScaleFactor is called again.
+// SetScaleFactor panics if factor is not a positive number.
+func SetScaleFactor(factor float64) {
+	if !(factor > 0) || math.IsInf(factor, 1) {
+		panic(fmt.Errorf("invalid scale factor %g given to SetScaleFactor()", factor))
+	}
+	if factor == scaleFactor {
+		return
+	}
+	scaleFactor = factor
+	setScaleFactor(factor)
+}
+
+// ScaleFactor returns the factor given to the last call to SetScaleFactor, or 1 if it was never called.
+func ScaleFactor() float64 {
+	return scaleFactor
+}
+
+var scaleFactor = 1.0
+
+// scaled scales a distance that the system doesn't scale for us along with the font, such as the margins and padding of containers on GTK+ and Mac OS X
+func scaled(n int) int {
+	return int(math.Floor(float64(n)*scaleFactor + 0.5))
+}
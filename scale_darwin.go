@@ -0,0 +1,10 @@
+// 15 october 2026
+
+package ui
+
+// #include "objc_darwin.h"
+import "C"
+
+func setScaleFactor(factor float64) {
+	C.setScaleFactor(C.double(factor))
+}
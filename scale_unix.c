@@ -0,0 +1,21 @@
+// +build !windows,!darwin
+
+// 15 october 2026
+
+#include "gtk_unix.h"
+
+// gtk-xft-dpi is the resolution fonts are drawn at, in 1024ths of a dot per inch; GNOME's text scaling works by changing it, and GTK+ widgets measure themselves from their fonts, so they all grow along with it
+// -1 (or anything not positive) means GTK+'s default, which is 96
+void setScaleFactor(gdouble factor)
+{
+	static gint base = 0;
+	GtkSettings *settings;
+
+	settings = gtk_settings_get_default();
+	if (base == 0) {
+		g_object_get(settings, "gtk-xft-dpi", &base, NULL);
+		if (base <= 0)
+			base = 96 * 1024;
+	}
+	g_object_set(settings, "gtk-xft-dpi", (gint) (base * factor), NULL);
+}
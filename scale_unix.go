@@ -0,0 +1,13 @@
+// +build !windows,!darwin
+
+// 15 october 2026
+
+package ui
+
+// #include "gtk_unix.h"
+import "C"
+
+// changing the font resolution makes GTK+ lay every window out again, which picks up the scaled container margins and padding too
+func setScaleFactor(factor float64) {
+	C.setScaleFactor(C.gdouble(factor))
+}
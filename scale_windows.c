@@ -0,0 +1,25 @@
+// 15 october 2026
+
+#include "winapi_windows.h"
+#include "_cgo_export.h"
+
+// every control is given controlFont when it's made, and Windows measures them, their spacing included, in dialog units, which come from controlFont (see beginResize()); so a bigger controlFont scales everything once the controls have it and the Windows are laid out again
+void setScaleFactor(double factor)
+{
+	NONCLIENTMETRICSW ncm;
+	HFONT prev;
+
+	ZeroMemory(&ncm, sizeof (NONCLIENTMETRICSW));
+	ncm.cbSize = sizeof (NONCLIENTMETRICSW);
+	if (SystemParametersInfoW(SPI_GETNONCLIENTMETRICS, sizeof (NONCLIENTMETRICSW), &ncm, sizeof (NONCLIENTMETRICSW)) == 0)
+		xpanic("error getting non-client metrics parameters for scaling the control font", GetLastError());
+	// lfHeight is usually negative, meaning a character height rather than a cell height; scaling works the same either way
+	ncm.lfMessageFont.lfHeight = (LONG) (ncm.lfMessageFont.lfHeight * factor);
+	prev = controlFont;
+	controlFont = CreateFontIndirectW(&ncm.lfMessageFont);
+	if (controlFont == NULL)
+		xpanic("error making scaled control font", GetLastError());
+	rescaleWindows(prev);
+	if (DeleteObject(prev) == 0)
+		xpanic("error deleting previous control font", GetLastError());
+}
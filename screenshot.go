@@ -0,0 +1,49 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"image"
+	"time"
+)
+
+// Screenshots takes pictures of the contents of w at each of sizes (as given to Window.SetSize), so that a program can make the screenshots for its documentation itself and keep them up to date as it changes.
+// The pictures are of the content area only, without the title bar and border the system draws, and are in device pixels, so on a HiDPI screen they are bigger than the sizes by the pixel ratio (see Area.PixelRatio).
+// w must be shown, but doesn't have to be in front of other windows, as each Control is asked to draw itself into the picture.
+//
+// Resizing a Window takes effect some time later on some systems, so Screenshots returns right away and leaves each size a moment to be laid out before taking its picture.
+// Once they are all taken, w is put back to the size it had and f is called on the main thread with the pictures, one for each of sizes in the same order; it can then save them, for instance with image/png.
+// Don't change w until then.
+// To take screenshots with other settings, such as a different SetScaleFactor, change them and call Screenshots again from f.
+// Only the system the program is running on can draw its own look, so screenshots of the other systems have to be taken on them.
+// Screenshots panics if sizes is empty or f is nil.
+func Screenshots(w Window, sizes []image.Point, f func(shots []*image.RGBA)) {
+	if len(sizes) == 0 {
+		panic("no sizes given to Screenshots()")
+	}
+	if f == nil {
+		panic("function passed to Screenshots() cannot be nil")
+	}
+	width, height := w.Size()
+	shots := make([]*image.RGBA, 0, len(sizes))
+	var next func()
+	next = func() {
+		size := sizes[len(shots)]
+		w.SetSize(size.X, size.Y)
+		time.AfterFunc(screenshotDelay, func() {
+			Do(func() {
+				shots = append(shots, w.snapshot())
+				if len(shots) < len(sizes) {
+					next()
+					return
+				}
+				w.SetSize(width, height)
+				f(shots)
+			})
+		})
+	}
+	next()
+}
+
+// how long Screenshots() waits after resizing for the window manager and the layout to catch up; it's a guess, but anything on screen that long has been drawn
+const screenshotDelay = 250 * time.Millisecond
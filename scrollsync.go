@@ -0,0 +1,126 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"image"
+)
+
+// ScrollSync keeps the scroll positions of a group of Areas together, so that scrolling one (by the user or with ScrollTo) scrolls the others to match.
+// This is for things like a line-number gutter beside a text view, the two panes of a side-by-side diff, or the row and column headers of a spreadsheet.
+// Positions are matched exactly, in Area coordinates, so the Areas should be the same size along the directions they share.
+// An Area that can't scroll as far as the others stops at its end, and the others don't follow it back.
+// Only Areas can be in a ScrollSync; the other scrollable Controls have no way to scroll them from a program yet.
+type ScrollSync interface {
+	// Add adds area to the ScrollSync; it follows the others (and they follow it) in the directions given by axes, and is scrolled to match them right away.
+	// Two Areas only follow each other in the directions they both have, so a gutter added with ScrollVertical and a text view added with ScrollHorizontal|ScrollVertical scroll together only vertically.
+	// An Area can only be in one ScrollSync at a time; Add panics if area is already in one, or if axes is 0.
+	Add(area Area, axes ScrollAxes)
+
+	// Remove removes area from the ScrollSync; it keeps its scroll position.
+	// Remove panics if area is not in the ScrollSync.
+	Remove(area Area)
+}
+
+// ScrollAxes says which directions a ScrollSync keeps together.
+type ScrollAxes uint
+
+const (
+	ScrollHorizontal ScrollAxes = 1 << iota
+	ScrollVertical
+)
+
+// NewScrollSync creates a new ScrollSync with no Areas in it.
+func NewScrollSync() ScrollSync {
+	return new(scrollSync)
+}
+
+type scrollSync struct {
+	members []*syncMember
+
+	// set while scrolling the other members, so that one member's scrolling doesn't bounce back and forth between them
+	syncing bool
+}
+
+type syncMember struct {
+	sync *scrollSync
+	area Area
+	axes ScrollAxes
+
+	// the position last seen; on some systems, a ScrollTo sends Scrolled() later rather than before it returns, and an Area that stopped short at its end would otherwise drag the others back when it does
+	last image.Point
+}
+
+func (s *scrollSync) Add(area Area, axes ScrollAxes) {
+	if axes == 0 {
+		panic("no axes given to ScrollSync.Add()")
+	}
+	b := area.base()
+	if b.syncmember != nil {
+		panic("Area given to ScrollSync.Add() is already in a ScrollSync")
+	}
+	m := &syncMember{
+		sync: s,
+		area: area,
+		axes: axes,
+		last: area.ScrollPos(),
+	}
+	b.syncmember = m
+	s.members = append(s.members, m)
+	if len(s.members) > 1 {
+		s.syncing = true
+		defer func() {
+			s.syncing = false
+		}()
+		s.follow(m, s.members[0], s.members[0].area.ScrollPos())
+	}
+}
+
+func (s *scrollSync) Remove(area Area) {
+	b := area.base()
+	for i, m := range s.members {
+		if m == b.syncmember {
+			s.members = append(s.members[:i], s.members[i+1:]...)
+			b.syncmember = nil
+			return
+		}
+	}
+	panic("Area given to ScrollSync.Remove() is not in the ScrollSync")
+}
+
+// called by areabase.scrolled()
+func (s *scrollSync) scrolled(from *syncMember, pos image.Point) {
+	if s.syncing || pos == from.last {
+		return
+	}
+	from.last = pos
+	s.syncing = true
+	defer func() {
+		s.syncing = false
+	}()
+	for _, m := range s.members {
+		if m != from {
+			s.follow(m, from, pos)
+		}
+	}
+}
+
+// scrolls m to match from, which is at pos
+func (s *scrollSync) follow(m *syncMember, from *syncMember, pos image.Point) {
+	axes := m.axes & from.axes
+	if axes == 0 {
+		return
+	}
+	cur := m.area.ScrollPos()
+	target := cur
+	if axes&ScrollHorizontal != 0 {
+		target.X = pos.X
+	}
+	if axes&ScrollVertical != 0 {
+		target.Y = pos.Y
+	}
+	if target != cur {
+		m.area.ScrollTo(target)
+	}
+	m.last = m.area.ScrollPos()
+}
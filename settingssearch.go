@@ -0,0 +1,191 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"strings"
+)
+
+// SettingsSearch is a search field for a window of settings, such as a preferences window: as the user types, it hides the settings that don't mention what was typed, so the user can find a setting without knowing which page or section it is on.
+// Put it above the settings, for instance in a vertical Stack along with them.
+// The settings are looked through again each time the search changes, so settings added later are found too.
+//
+// Settings are taken to be laid out in rows, and a row is kept if any text in it contains the search, ignoring case: the text of a Label, Button, or Checkbox, or the title of a Group the row is in.
+// Each Control in a vertical Stack is a row, as is each row of a SimpleGrid or Grid; a horizontal Stack is a row (or part of one) as a whole, so a Label is kept or hidden along with the Control next to it.
+// A Group or Tab with no rows left in it is hidden as well.
+// Tab pages can't be hidden, so if the selected page has no rows left and another page does, that page is selected instead.
+// (Package ui has no way to change how a Label looks, so the text that matched is not highlighted.)
+//
+// Rows are hidden with Hide, and shown again with Show once the search no longer excludes them; only the rows the SettingsSearch hid itself are shown again, and Controls that were already hidden don't count toward the search.
+// Don't Show or Hide the settings while a search is in progress.
+type SettingsSearch interface {
+	Control
+
+	// Text and SetText get and set the search; SetText searches right away, as if the user had typed text.
+	// Set it to "" to show all the settings again.
+	Text() string
+	SetText(text string)
+
+	// Found returns whether any settings are left by the search, for instance to show a "No results" Label when there aren't; it is true if the search is empty.
+	Found() bool
+
+	// OnSearched registers the event handler called after each search, whether the user typed it or it was set with SetText.
+	OnSearched(f func())
+}
+
+// NewSettingsSearch creates a SettingsSearch that searches settings, which is usually the Control given to the preferences Window (without the SettingsSearch).
+func NewSettingsSearch(settings Control) SettingsSearch {
+	s := &settingsSearch{
+		TextField: NewTextField(),
+		settings:  settings,
+		hidden:    make(map[Control]bool),
+		found:     true,
+		searched:  newEvent(),
+	}
+	s.TextField.OnChanged(s.search)
+	return s
+}
+
+type settingsSearch struct {
+	TextField
+	settings Control
+	hidden   map[Control]bool // the Controls search() hid, which are the only ones it shows again
+	found    bool
+	searched *event
+}
+
+func (s *settingsSearch) SetText(text string) {
+	s.TextField.SetText(text)
+	s.search() // in case setting the text doesn't fire OnChanged
+}
+
+func (s *settingsSearch) Found() bool {
+	return s.found
+}
+
+func (s *settingsSearch) OnSearched(f func()) {
+	s.searched.set(f)
+}
+
+func (s *settingsSearch) search() {
+	query := strings.ToLower(strings.TrimSpace(s.Text()))
+	s.found = s.filter(s.settings, query, query == "")
+	s.searched.fire()
+}
+
+// filter hides the rows in c that don't match query and shows the ones that do, returning whether any did
+// keep is whether every row matches regardless, for the empty search and inside a Group whose title matched
+func (s *settingsSearch) filter(c Control, query string, keep bool) bool {
+	switch c := c.(type) {
+	case *stack:
+		if c.orientation == horizontal {
+			return s.filterRow(c.controls, query, keep)
+		}
+		found := false
+		for _, child := range c.controls {
+			if s.filterRow([]Control{child}, query, keep) {
+				found = true
+			}
+		}
+		return found
+	case *simpleGrid:
+		found := false
+		for _, row := range c.controls {
+			if s.filterRow(row, query, keep) {
+				found = true
+			}
+		}
+		return found
+	case *grid:
+		// a cell that spans several rows belongs to its topmost one
+		rows := make(map[int][]Control)
+		for _, cell := range c.controls {
+			rows[cell.y] = append(rows[cell.y], cell.control)
+		}
+		found := false
+		for _, row := range rows {
+			if s.filterRow(row, query, keep) {
+				found = true
+			}
+		}
+		return found
+	case Tab:
+		selected := c.Selected()
+		first := -1
+		keepSelected := false
+		for i, page := range c.childControls() {
+			if s.filter(page, query, keep) {
+				if first == -1 {
+					first = i
+				}
+				if i == selected {
+					keepSelected = true
+				}
+			}
+		}
+		if !keepSelected && first != -1 {
+			c.Select(first)
+		}
+		return first != -1
+	case Group:
+		keep = keep || settingMatches(c.Text(), query)
+		found := false
+		for _, child := range c.childControls() {
+			if s.filter(child, query, keep) {
+				found = true
+			}
+		}
+		return found
+	}
+	return keep || settingMatches(settingText(c), query)
+}
+
+// filterRow hides or shows all of row together, depending on whether any of it matches
+func (s *settingsSearch) filterRow(row []Control, query string, keep bool) bool {
+	found := false
+	for _, c := range row {
+		if !c.Visible() && !s.hidden[c] { // hidden by the programmer
+			continue
+		}
+		if s.filter(c, query, keep) {
+			found = true
+		}
+	}
+	for _, c := range row {
+		s.setShown(c, found)
+	}
+	return found
+}
+
+func (s *settingsSearch) setShown(c Control, shown bool) {
+	if !shown {
+		if c.Visible() {
+			c.Hide()
+			s.hidden[c] = true
+		}
+		return
+	}
+	if s.hidden[c] {
+		c.Show()
+		delete(s.hidden, c)
+	}
+}
+
+// settingText returns the text of c that the search looks at
+// TextFields and Textboxes have text too, but it's what the user typed, not what the setting is
+func settingText(c Control) string {
+	switch c := c.(type) {
+	case TextField, Textbox:
+		return ""
+	case interface {
+		Text() string
+	}: // Label, Button, and Checkbox
+		return c.Text()
+	}
+	return ""
+}
+
+// query is already lowercase
+func settingMatches(text string, query string) bool {
+	return strings.Contains(strings.ToLower(stripMnemonic(text)), query)
+}
@@ -0,0 +1,72 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"fmt"
+)
+
+type shortcut struct {
+	key    byte
+	extkey ExtKey
+	mods   Modifiers
+	f      func()
+}
+
+// shortcuts implements Window.RegisterShortcut(); each backend's window embeds one and hands it the key presses that come in while the window is active
+// it is only touched on the main thread
+type shortcuts struct {
+	list []shortcut
+}
+
+func (s *shortcuts) RegisterShortcut(key interface{}, mods Modifiers, f func()) {
+	var sc shortcut
+
+	switch k := key.(type) {
+	case ExtKey:
+		sc.extkey = k
+	case byte:
+		sc.key = k
+	case rune: // so untyped character constants like 's' work
+		if k >= 0x80 {
+			panic(fmt.Errorf("invalid key %q given to Window.RegisterShortcut(); only the keys of KeyEvent.Key are allowed", k))
+		}
+		sc.key = byte(k)
+	default:
+		panic(fmt.Errorf("invalid key %v of type %T given to Window.RegisterShortcut(); must be a byte, rune, or ExtKey", key, key))
+	}
+	// KeyEvent.Key is always lowercase; let 'S' mean the same key as 's'
+	if sc.key >= 'A' && sc.key <= 'Z' {
+		sc.key += 'a' - 'A'
+	}
+	sc.mods = mods
+	sc.f = f
+	for i := range s.list {
+		if s.list[i].key == sc.key && s.list[i].extkey == sc.extkey && s.list[i].mods == sc.mods {
+			if f == nil {
+				s.list = append(s.list[:i], s.list[i+1:]...)
+			} else {
+				s.list[i] = sc
+			}
+			return
+		}
+	}
+	if f != nil {
+		s.list = append(s.list, sc)
+	}
+}
+
+// fire runs the shortcut for ke, if there is one, and returns whether there was
+// the backends only need to fill in Key, ExtKey, Modifier, and Modifiers
+func (s *shortcuts) fire(ke KeyEvent) bool {
+	if ke.Up || ke.Modifier != 0 {
+		return false
+	}
+	for _, sc := range s.list {
+		if sc.key == ke.Key && sc.extkey == ke.ExtKey && sc.mods == ke.Modifiers {
+			sc.f()
+			return true
+		}
+	}
+	return false
+}
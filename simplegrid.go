@@ -34,6 +34,7 @@ type SimpleGrid interface {
 }
 
 type simpleGrid struct {
+	controlstate
 	controls                 [][]Control
 	filling                  [][]bool
 	stretchyrow, stretchycol int
@@ -81,6 +82,9 @@ func NewSimpleGrid(nPerRow int, controls ...Control) SimpleGrid {
 		rowheights:  make([]int, nRows),
 		colwidths:   make([]int, nPerRow),
 	}
+	g.fsetShown = g.setShown
+	g.fsetEnabled = g.setEnabled
+	g.fdestroy = chainDestroy(func() {}, g.childControls)		// nothing native of its own
 	return g
 }
 
@@ -113,25 +117,44 @@ func (g *simpleGrid) SetPadded(padded bool) {
 }
 
 func (g *simpleGrid) setParent(parent *controlParent) {
+	g.parent = parent
 	for _, cc := range g.controls {
 		for _, c := range cc {
 			c.setParent(parent)
 		}
 	}
+	g.reapply()
 }
 
-func (g *simpleGrid) containerShow() {
+func (g *simpleGrid) childControls() []Control {
+	var controls []Control
+
+	for _, cc := range g.controls {
+		controls = append(controls, cc...)
+	}
+	return controls
+}
+
+func (g *simpleGrid) setShown(shown bool) {
 	for _, cc := range g.controls {
 		for _, c := range cc {
-			c.containerShow()
+			if shown {
+				c.containerShow()
+			} else {
+				c.containerHide()
+			}
 		}
 	}
 }
 
-func (g *simpleGrid) containerHide() {
+func (g *simpleGrid) setEnabled(enabled bool) {
 	for _, cc := range g.controls {
 		for _, c := range cc {
-			c.containerHide()
+			if enabled {
+				c.containerEnable()
+			} else {
+				c.containerDisable()
+			}
 		}
 	}
 }
@@ -165,9 +188,13 @@ func (g *simpleGrid) resize(x int, y int, width int, height int, d *sizing) {
 		g.colwidths[i] = 0
 	}
 	// 2) get preferred sizes; compute row/column sizes
+	// hidden controls count as 0x0, like a Space
 	for row, xcol := range g.controls {
 		for col, c := range xcol {
-			w, h := c.preferredSize(d)
+			w, h := 0, 0
+			if c.Visible() {
+				w, h = c.preferredSize(d)
+			}
 			g.widths[row][col] = w
 			g.heights[row][col] = h
 			g.rowheights[row] = max(g.rowheights[row], h)
@@ -199,7 +226,9 @@ func (g *simpleGrid) resize(x int, y int, width int, height int, d *sizing) {
 				w = g.colwidths[col]
 				h = g.rowheights[row]
 			}
-			c.resize(x, y, w, h, d)
+			if c.Visible() {
+				c.resize(x, y, w, h, d)
+			}
 			x += g.colwidths[col] + xpadding
 		}
 		x = startx
@@ -232,9 +261,13 @@ func (g *simpleGrid) preferredSize(d *sizing) (width int, height int) {
 		g.colwidths[i] = 0
 	}
 	// 2) get preferred sizes; compute row/column sizes
+	// hidden controls count as 0x0, like a Space
 	for row, xcol := range g.controls {
 		for col, c := range xcol {
-			w, h := c.preferredSize(d)
+			w, h := 0, 0
+			if c.Visible() {
+				w, h = c.preferredSize(d)
+			}
 			g.widths[row][col] = w
 			g.heights[row][col] = h
 			g.rowheights[row] = max(g.rowheights[row], h)
@@ -0,0 +1,88 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// #include "objc_darwin.h"
+import "C"
+
+type slider struct {
+	*controlSingleObject
+	changed  *event
+	min      int
+	max      int
+	vertical bool
+	value    int // the action is sent for fractional movements too; only fire when the integer value changes
+	interval int
+}
+
+func newSlider(min int, max int, vertical bool) Slider {
+	s := &slider{
+		controlSingleObject: newControlSingleObject(C.newSlider(C.intmax_t(min), C.intmax_t(max), toBOOL(vertical))),
+		changed:             newEvent(),
+		min:                 min,
+		max:                 max,
+		vertical:            vertical,
+		value:               min,
+	}
+	s.fpreferredSize = s.xpreferredSize
+	C.sliderSetDelegate(s.id, unsafe.Pointer(s))
+	return s
+}
+
+func (s *slider) Value() int {
+	return int(C.sliderValue(s.id))
+}
+
+func (s *slider) SetValue(value int) {
+	// NSSlider clamps for us
+	C.sliderSetValue(s.id, C.intmax_t(value))
+	s.value = s.Value()
+}
+
+func (s *slider) OnChanged(e func()) {
+	s.changed.set(e)
+}
+
+func (s *slider) TickInterval() int {
+	return s.interval
+}
+
+func (s *slider) SetTickInterval(interval int) {
+	if interval < 0 {
+		panic(fmt.Errorf("negative interval %d given to Slider.SetTickInterval()", interval))
+	}
+	s.interval = interval
+	if interval == 0 || s.max == s.min {
+		C.sliderSetTickMarks(s.id, 0)
+		return
+	}
+	// NSSlider only does evenly spaced tick marks from end to end, so this only lines up with the other systems if interval divides the range
+	C.sliderSetTickMarks(s.id, C.intmax_t((s.max-s.min)/interval+1))
+}
+
+//export sliderChanged
+func sliderChanged(data unsafe.Pointer) {
+	s := (*slider)(data)
+	v := s.Value()
+	if v == s.value {
+		return
+	}
+	s.value = v
+	s.changed.fire()
+}
+
+// sizeToFit only gives us the thickness; this is a reasonable length
+const sliderLength = 96
+
+func (s *slider) xpreferredSize(d *sizing) (width, height int) {
+	width, height = s.controlSingleObject.xpreferredSize(d)
+	if s.vertical {
+		return width, sliderLength
+	}
+	return sliderLength, height
+}
@@ -0,0 +1,116 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// #include "gtk_unix.h"
+// extern void sliderChanged(GtkRange *, gpointer);
+import "C"
+
+type slider struct {
+	*controlSingleWidget
+	scale    *C.GtkScale
+	rangew   *C.GtkRange
+	changed  *event
+	min      int
+	max      int
+	vertical bool
+	interval int
+	setting  bool // so SetValue() doesn't trigger the event
+}
+
+func newSlider(min int, max int, vertical bool) Slider {
+	orientation := C.GtkOrientation(C.GTK_ORIENTATION_HORIZONTAL)
+	if vertical {
+		orientation = C.GTK_ORIENTATION_VERTICAL
+	}
+	widget := C.gtk_scale_new_with_range(orientation, C.gdouble(min), C.gdouble(max), 1)
+	s := &slider{
+		controlSingleWidget: newControlSingleWidget(widget),
+		scale:               (*C.GtkScale)(unsafe.Pointer(widget)),
+		rangew:              (*C.GtkRange)(unsafe.Pointer(widget)),
+		changed:             newEvent(),
+		min:                 min,
+		max:                 max,
+		vertical:            vertical,
+	}
+	s.fpreferredSize = s.xpreferredSize
+	// the other systems don't show the value, and we only deal in integers
+	C.gtk_scale_set_draw_value(s.scale, C.FALSE)
+	C.gtk_range_set_round_digits(s.rangew, 0)
+	if vertical {
+		// vertical GtkScales have their minimum at the top
+		C.gtk_range_set_inverted(s.rangew, C.TRUE)
+	}
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(s.rangew)),
+		"value-changed",
+		C.GCallback(C.sliderChanged),
+		C.gpointer(unsafe.Pointer(s)))
+	return s
+}
+
+func (s *slider) Value() int {
+	return int(C.gtk_range_get_value(s.rangew))
+}
+
+func (s *slider) SetValue(value int) {
+	// GtkRange clamps for us
+	s.setting = true
+	C.gtk_range_set_value(s.rangew, C.gdouble(value))
+	s.setting = false
+}
+
+func (s *slider) OnChanged(e func()) {
+	s.changed.set(e)
+}
+
+func (s *slider) TickInterval() int {
+	return s.interval
+}
+
+func (s *slider) SetTickInterval(interval int) {
+	if interval < 0 {
+		panic(fmt.Errorf("negative interval %d given to Slider.SetTickInterval()", interval))
+	}
+	s.interval = interval
+	C.gtk_scale_clear_marks(s.scale)
+	if interval == 0 {
+		return
+	}
+	pos := C.GtkPositionType(C.GTK_POS_BOTTOM)
+	if s.vertical {
+		pos = C.GTK_POS_RIGHT
+	}
+	for v := s.min; v <= s.max; v += interval {
+		C.gtk_scale_add_mark(s.scale, C.gdouble(v), pos, nil)
+	}
+}
+
+//export sliderChanged
+func sliderChanged(r *C.GtkRange, data C.gpointer) {
+	s := (*slider)(unsafe.Pointer(data))
+	if s.setting {
+		return
+	}
+	s.changed.fire()
+}
+
+// a GtkScale's natural length is only a little longer than its slider, which is too short to be useful
+const sliderMinLength = 150
+
+func (s *slider) xpreferredSize(d *sizing) (width, height int) {
+	width, height = s.controlSingleWidget.xpreferredSize(d)
+	if s.vertical && height < sliderMinLength {
+		height = sliderMinLength
+	} else if !s.vertical && width < sliderMinLength {
+		width = sliderMinLength
+	}
+	return width, height
+}
@@ -0,0 +1,122 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// #include "winapi_windows.h"
+import "C"
+
+type slider struct {
+	*controlSingleHWND
+	changed  *event
+	min      int
+	max      int
+	vertical bool
+	value    int // the last value we saw, to tell if msgSCROLL actually changed anything
+	interval int
+}
+
+func newSlider(min int, max int, vertical bool) Slider {
+	style := C.DWORD(C.TBS_HORZ | C.TBS_NOTICKS | C.WS_TABSTOP)
+	if vertical {
+		style = C.TBS_VERT | C.TBS_NOTICKS | C.WS_TABSTOP
+	}
+	hwnd := C.newControl(C.xTRACKBAR_CLASS, style, 0)
+	s := &slider{
+		controlSingleHWND: newControlSingleHWND(hwnd),
+		changed:           newEvent(),
+		min:               min,
+		max:               max,
+		vertical:          vertical,
+		value:             min,
+	}
+	s.fpreferredSize = s.xpreferredSize
+	C.SendMessageW(s.hwnd, C.TBM_SETRANGEMIN, C.FALSE, C.LPARAM(min))
+	C.SendMessageW(s.hwnd, C.TBM_SETRANGEMAX, C.TRUE, C.LPARAM(max))
+	s.setPos(min)
+	C.setSliderSubclass(s.hwnd, unsafe.Pointer(s))
+	return s
+}
+
+// vertical trackbars have their minimum at the top, so flip them to match the other systems
+func (s *slider) flip(v int) int {
+	if s.vertical {
+		return s.min + s.max - v
+	}
+	return v
+}
+
+func (s *slider) setPos(value int) {
+	C.SendMessageW(s.hwnd, C.TBM_SETPOS, C.TRUE, C.LPARAM(s.flip(value)))
+}
+
+func (s *slider) Value() int {
+	return s.flip(int(C.SendMessageW(s.hwnd, C.TBM_GETPOS, 0, 0)))
+}
+
+func (s *slider) SetValue(value int) {
+	if value < s.min {
+		value = s.min
+	}
+	if value > s.max {
+		value = s.max
+	}
+	s.value = value
+	s.setPos(value)
+}
+
+func (s *slider) OnChanged(e func()) {
+	s.changed.set(e)
+}
+
+func (s *slider) TickInterval() int {
+	return s.interval
+}
+
+func (s *slider) SetTickInterval(interval int) {
+	if interval < 0 {
+		panic(fmt.Errorf("negative interval %d given to Slider.SetTickInterval()", interval))
+	}
+	s.interval = interval
+	// TBS_AUTOTICKS can't be turned on after the trackbar is made, so we switch TBS_NOTICKS and set the ticks ourselves
+	style := C.GetWindowLongPtrW(s.hwnd, C.GWL_STYLE)
+	C.SendMessageW(s.hwnd, C.TBM_CLEARTICS, C.FALSE, 0)
+	if interval == 0 {
+		C.SetWindowLongPtrW(s.hwnd, C.GWL_STYLE, style|C.TBS_NOTICKS)
+	} else {
+		C.SetWindowLongPtrW(s.hwnd, C.GWL_STYLE, style&^C.TBS_NOTICKS)
+		// the first and last ticks are always drawn; TBM_SETTIC only takes the ones in between
+		for v := s.min + interval; v < s.max; v += interval {
+			C.SendMessageW(s.hwnd, C.TBM_SETTIC, 0, C.LPARAM(s.flip(v)))
+		}
+	}
+	C.InvalidateRect(s.hwnd, nil, C.TRUE)
+}
+
+//export sliderChanged
+func sliderChanged(data unsafe.Pointer) {
+	s := (*slider)(data)
+	v := s.Value()
+	if v == s.value {
+		return
+	}
+	s.value = v
+	s.changed.fire()
+}
+
+const (
+	// the sizing and spacing guidelines don't cover trackbars; this is enough for the thumb and a row of ticks, and the length is that of a single-width progress bar
+	sliderLength    = 107
+	sliderThickness = 15
+)
+
+func (s *slider) xpreferredSize(d *sizing) (width, height int) {
+	if s.vertical {
+		return fromdlgunitsX(sliderThickness, d), fromdlgunitsY(sliderLength, d)
+	}
+	return fromdlgunitsX(sliderLength, d), fromdlgunitsY(sliderThickness, d)
+}
@@ -0,0 +1,35 @@
+// 15 october 2026
+
+package ui
+
+// Speak reads text aloud in the system's voice, at the speed and volume the user chose in the system's settings, for accessibility features such as reading a document or a status change to the user.
+// Speak returns right away, and the speech goes on in the background; anything a previous Speak was still saying is cut off.
+// text is plain text.
+//
+// Speak returns an error if the system can't speak: on Unix systems, if Speech Dispatcher, which the desktops use for speech, is not installed or won't start; on Windows, if no speech engine is installed.
+// Speak is not how blind users hear the program: their screen reader already reads the Controls out on its own, and talking over it is confusing.
+// Like other functions in package ui, Speak must be called on the main thread; use Do() if you need to call it elsewhere.
+func Speak(text string) error {
+	return speak(text)
+}
+
+// StopSpeaking stops what Speak is saying, if anything.
+func StopSpeaking() {
+	stopSpeaking()
+}
+
+// StartDictation starts the system's dictation, in which the user speaks and the system types what they say into the Control that has the keyboard focus (usually a TextField or a Textbox), as if they had typed it; it is for a button or menu item that starts dictation without the user having to know the system's shortcut for it.
+// The program gets what is dictated through the usual events of the Control, such as OnChanged; the system shows the user that it is listening.
+// StopDictation stops it again; the user can also stop it themselves, and the system stops on its own after a while without speech.
+//
+// StartDictation returns an error if the system has no dictation: on Mac OS X, on versions older than 10.8 or if the user turned dictation off; on Windows, on versions older than Windows 10, where dictation is voice typing.
+// GTK+ has no dictation, so on Unix systems StartDictation always returns an error.
+// On Windows, voice typing can only be started with its shortcut, Windows+H, so StartDictation presses that; StopDictation does nothing there.
+func StartDictation() error {
+	return startDictation()
+}
+
+// StopDictation stops dictation started by StartDictation, if it is still going.
+func StopDictation() {
+	stopDictation()
+}
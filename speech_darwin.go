@@ -0,0 +1,35 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// #include "objc_darwin.h"
+import "C"
+
+func speak(text string) error {
+	ctext := C.CString(text)
+	defer C.free(unsafe.Pointer(ctext))
+	if C.speak(ctext) == C.NO {
+		return fmt.Errorf("error starting speech synthesizer")
+	}
+	return nil
+}
+
+func stopSpeaking() {
+	C.stopSpeaking()
+}
+
+func startDictation() error {
+	if C.startDictation() == C.NO {
+		return fmt.Errorf("dictation needs Mac OS X 10.8 or newer and has to be turned on in System Preferences")
+	}
+	return nil
+}
+
+func stopDictation() {
+	C.stopDictation()
+}
@@ -0,0 +1,37 @@
+// 15 october 2026
+
+#include "objc_darwin.h"
+#import <Cocoa/Cocoa.h>
+
+// the synthesizer is made the first time it's needed and kept for the rest of the program
+static NSSpeechSynthesizer *synthesizer = nil;
+
+BOOL speak(char *text)
+{
+	if (synthesizer == nil) {
+		// nil is the voice the user chose in System Preferences
+		synthesizer = [[NSSpeechSynthesizer alloc] initWithVoice:nil];
+		if (synthesizer == nil)
+			return NO;
+	}
+	[synthesizer stopSpeaking];
+	return [synthesizer startSpeakingString:[NSString stringWithUTF8String:text]];
+}
+
+void stopSpeaking(void)
+{
+	if (synthesizer != nil)
+		[synthesizer stopSpeaking];
+}
+
+// dictation is what the Start Dictation item AppKit puts in the Edit menu does, which is sending these actions down the responder chain to NSApp
+// they are 10.8 and newer, and we build against the 10.7 SDK, so we look them up by name; on older versions, and with dictation turned off, nothing handles them and sendAction:to:from: returns NO
+BOOL startDictation(void)
+{
+	return [NSApp sendAction:NSSelectorFromString(@"startDictation:") to:nil from:nil];
+}
+
+void stopDictation(void)
+{
+	[NSApp sendAction:NSSelectorFromString(@"stopDictation:") to:nil from:nil];
+}
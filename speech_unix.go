@@ -0,0 +1,121 @@
+// +build !windows,!darwin
+
+// 15 october 2026
+
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// GTK+ has no speech; the desktops use Speech Dispatcher (Orca reads the screen through it), which we talk to in its protocol, SSIP, ourselves rather than needing libspeechd
+// the connection is made the first time it's needed and kept, and made again if it breaks
+var (
+	speechConn   net.Conn
+	speechReader *bufio.Reader
+)
+
+// this is where libspeechd looks, other than for SPEECHD_ADDRESS, which can also name a TCP address we don't bother with
+func speechSocket() string {
+	if addr := os.Getenv("SPEECHD_ADDRESS"); strings.HasPrefix(addr, "unix_socket:") {
+		return strings.TrimPrefix(addr, "unix_socket:")
+	}
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = filepath.Join(os.Getenv("HOME"), ".cache")
+	}
+	return filepath.Join(dir, "speech-dispatcher", "speechd.sock")
+}
+
+func speechConnect() error {
+	if speechConn != nil {
+		return nil
+	}
+	path := speechSocket()
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		// like libspeechd, start Speech Dispatcher if it isn't running; --spawn returns once it's ready
+		if exec.Command("speech-dispatcher", "--spawn").Run() != nil {
+			return fmt.Errorf("error connecting to Speech Dispatcher: %v", err)
+		}
+		conn, err = net.Dial("unix", path)
+		if err != nil {
+			return fmt.Errorf("error connecting to Speech Dispatcher: %v", err)
+		}
+	}
+	speechConn = conn
+	speechReader = bufio.NewReader(conn)
+	return speechCommand("SET self CLIENT_NAME " + os.Getenv("USER") + ":" + filepath.Base(os.Args[0]) + ":main")
+}
+
+func speechDisconnect() {
+	speechConn.Close()
+	speechConn = nil
+	speechReader = nil
+}
+
+// SSIP replies are lines of a three-digit code, then - if more lines follow or a space on the last line; codes starting with 2 are success
+func speechCommand(line string) error {
+	if _, err := speechConn.Write([]byte(line + "\r\n")); err != nil {
+		speechDisconnect()
+		return fmt.Errorf("error sending %q to Speech Dispatcher: %v", line, err)
+	}
+	for {
+		reply, err := speechReader.ReadString('\n')
+		if err != nil {
+			speechDisconnect()
+			return fmt.Errorf("error reading reply to %q from Speech Dispatcher: %v", line, err)
+		}
+		if len(reply) < 4 {
+			speechDisconnect()
+			return fmt.Errorf("malformed reply %q to %q from Speech Dispatcher", reply, line)
+		}
+		if reply[3] == '-' {
+			continue
+		}
+		if reply[0] != '2' {
+			return fmt.Errorf("Speech Dispatcher refused %q: %s", line, strings.TrimSpace(reply))
+		}
+		return nil
+	}
+}
+
+func speak(text string) error {
+	if err := speechConnect(); err != nil {
+		return err
+	}
+	if err := speechCommand("CANCEL self"); err != nil {
+		return err
+	}
+	if err := speechCommand("SPEAK"); err != nil {
+		return err
+	}
+	// the text ends with a line holding only a period, so lines that start with one get another, as in SMTP
+	lines := strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n")
+	for i, l := range lines {
+		if strings.HasPrefix(l, ".") {
+			lines[i] = "." + l
+		}
+	}
+	return speechCommand(strings.Join(lines, "\r\n") + "\r\n.")
+}
+
+func stopSpeaking() {
+	if speechConn != nil {
+		speechCommand("CANCEL self")
+	}
+}
+
+func startDictation() error {
+	return fmt.Errorf("GTK+ has no dictation")
+}
+
+func stopDictation() {
+	// nothing to stop
+}
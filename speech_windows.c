@@ -0,0 +1,47 @@
+// 15 october 2026
+
+#include "winapi_windows.h"
+
+// the voice is made the first time it's needed and kept for the rest of the program, since loading the speech engine takes a while
+static ISpVoice *voice = NULL;
+
+BOOL speak(LPWSTR text)
+{
+	if (voice == NULL)
+		if (CoCreateInstance(&CLSID_SpVoice, NULL, CLSCTX_ALL, &IID_ISpVoice, (LPVOID *) (&voice)) != S_OK) {
+			voice = NULL;
+			return FALSE;
+		}
+	// SPF_PURGEBEFORESPEAK cuts off what was being said; SPF_IS_NOT_XML reads text like "<b>" as is instead of as SAPI markup
+	return ISpVoice_Speak(voice, text, SPF_ASYNC | SPF_PURGEBEFORESPEAK | SPF_IS_NOT_XML, NULL) == S_OK;
+}
+
+void stopSpeaking(void)
+{
+	if (voice != NULL)
+		ISpVoice_Speak(voice, NULL, SPF_PURGEBEFORESPEAK, NULL);
+}
+
+// Windows 10 voice typing has no API, only the Windows+H shortcut, so we press that
+BOOL startDictation(void)
+{
+	HMODULE user32;
+	INPUT in[4];
+	int i;
+
+	// GetDpiForWindow() is Windows 10 only, so its presence is as good a sign of voice typing as any
+	// GetProcAddress() only takes a multibyte string
+	user32 = GetModuleHandleW(L"user32.dll");
+	if (user32 == NULL || GetProcAddress(user32, "GetDpiForWindow") == NULL)
+		return FALSE;
+	ZeroMemory(in, 4 * sizeof (INPUT));
+	for (i = 0; i < 4; i++)
+		in[i].type = INPUT_KEYBOARD;
+	in[0].ki.wVk = VK_LWIN;
+	in[1].ki.wVk = 'H';
+	in[2].ki.wVk = 'H';
+	in[2].ki.dwFlags = KEYEVENTF_KEYUP;
+	in[3].ki.wVk = VK_LWIN;
+	in[3].ki.dwFlags = KEYEVENTF_KEYUP;
+	return SendInput(4, in, sizeof (INPUT)) == 4;
+}
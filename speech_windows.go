@@ -0,0 +1,32 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"fmt"
+)
+
+// #include "winapi_windows.h"
+import "C"
+
+func speak(text string) error {
+	if C.speak(toUTF16(text)) == C.FALSE {
+		return fmt.Errorf("error speaking; is a SAPI speech engine installed?")
+	}
+	return nil
+}
+
+func stopSpeaking() {
+	C.stopSpeaking()
+}
+
+func startDictation() error {
+	if C.startDictation() == C.FALSE {
+		return fmt.Errorf("dictation needs Windows 10 or newer")
+	}
+	return nil
+}
+
+func stopDictation() {
+	// see StartDictation
+}
@@ -3,6 +3,7 @@
 package ui
 
 import (
+	"fmt"
 	"unsafe"
 )
 
@@ -21,14 +22,23 @@ import "C"
 // - proper spacing between edit and spinner: Interface Builder isn't clear; NSDatePicker doesn't spill the beans
 
 type spinbox struct {
+	controlstate
 	id			C.id
 	changed		*event
+	step			int
 }
 
 func newSpinbox(min int, max int) Spinbox {
 	s := new(spinbox)
 	s.id = C.newSpinbox(unsafe.Pointer(s), C.intmax_t(min), C.intmax_t(max))
 	s.changed = newEvent()
+	s.step = 1
+	s.fsetShown = s.setShown
+	s.fsetEnabled = s.setEnabled
+	s.fsetTooltip = s.setTooltip
+	s.fsetFocus = s.setFocus
+	s.fdestroy = s.destroyViews
+	s.handle = uintptr(unsafe.Pointer(s.textfield()))
 	return s
 }
 
@@ -44,6 +54,18 @@ func (s *spinbox) OnChanged(e func()) {
 	s.changed.set(e)
 }
 
+func (s *spinbox) Step() int {
+	return s.step
+}
+
+func (s *spinbox) SetStep(step int) {
+	if step <= 0 {
+		panic(fmt.Errorf("non-positive step %d given to Spinbox.SetStep()", step))
+	}
+	s.step = step
+	C.spinboxSetStep(s.id, C.intmax_t(s.step))
+}
+
 //export spinboxChanged
 func spinboxChanged(data unsafe.Pointer) {
 	s := (*spinbox)(data)
@@ -59,8 +81,10 @@ func (s *spinbox) stepper() C.id {
 }
 
 func (s *spinbox) setParent(p *controlParent) {
+	s.parent = p
 	C.parent(s.textfield(), p.id)
 	C.parent(s.stepper(), p.id)
+	s.reapply()
 }
 
 func (s *spinbox) preferredSize(d *sizing) (width, height int) {
@@ -79,10 +103,26 @@ func (s *spinbox) nTabStops() int {
 	return 1
 }
 
-func (s *spinbox) containerShow() {
-	// only provided for the Windows backend
+func (s *spinbox) setShown(shown bool) {
+	C.controlSetHidden(s.textfield(), toBOOL(!shown))
+	C.controlSetHidden(s.stepper(), toBOOL(!shown))
+}
+
+func (s *spinbox) setEnabled(enabled bool) {
+	C.controlSetEnabled(s.textfield(), toBOOL(enabled))
+	C.controlSetEnabled(s.stepper(), toBOOL(enabled))
+}
+
+func (s *spinbox) setFocus() {
+	C.controlSetFocus(s.textfield())
+}
+
+func (s *spinbox) setTooltip(text string) {
+	setTooltip(s.textfield(), text)
+	setTooltip(s.stepper(), text)
 }
 
-func (s *spinbox) containerHide() {
-	// only provided for the Windows backend
+// this also releases the goSpinbox, which s.id refers to, so s.id can't be used afterward
+func (s *spinbox) destroyViews() {
+	C.spinboxDestroy(s.id)
 }
@@ -123,3 +123,22 @@ void spinboxSetValue(id spinbox, intmax_t value)
 {
 	[togoSpinbox(spinbox) setValue:((NSInteger) value)];
 }
+
+void spinboxSetStep(id spinbox, intmax_t step)
+{
+	[togoSpinbox(spinbox)->stepper setIncrement:((double) step)];
+}
+
+// the views are ours alone, as with controlDestroy(); after this, spinbox is gone too
+void spinboxDestroy(id spinbox)
+{
+	goSpinbox *s;
+
+	s = togoSpinbox(spinbox);
+	[s->textfield setDelegate:nil];
+	[s->stepper setTarget:nil];
+	controlDestroy(s->textfield);
+	controlDestroy(s->stepper);
+	[s->formatter release];
+	[s release];
+}
@@ -5,6 +5,7 @@
 package ui
 
 import (
+	"fmt"
 	"unsafe"
 )
 
@@ -62,6 +63,21 @@ func (s *spinbox) OnChanged(e func()) {
 	s.changed.set(e)
 }
 
+func (s *spinbox) Step() int {
+	var step C.gdouble
+
+	C.gtk_spin_button_get_increments(s.spinbutton, &step, nil)
+	return int(step)
+}
+
+func (s *spinbox) SetStep(step int) {
+	if step <= 0 {
+		panic(fmt.Errorf("non-positive step %d given to Spinbox.SetStep()", step))
+	}
+	// the page increment (Page Up/Page Down) is what gtk_spin_button_new_with_range() sets it to: 10 steps
+	C.gtk_spin_button_set_increments(s.spinbutton, C.gdouble(step), C.gdouble(10 * step))
+}
+
 //export spinboxChanged
 func spinboxChanged(swid *C.GtkSpinButton, data C.gpointer) {
 	s := (*spinbox)(unsafe.Pointer(data))
@@ -3,6 +3,7 @@
 package ui
 
 import (
+	"fmt"
 	"strconv"
 	"unsafe"
 )
@@ -14,15 +15,16 @@ import "C"
 // TODO WS_EX_CLIENTEDGE on the updown?
 
 type spinbox struct {
+	controlstate
 	hwndEdit			C.HWND
 	hwndUpDown		C.HWND
+	tooltip			C.HWND
 	changed			*event
-	// updown state
-	updownVisible		bool
 	// keep these here to avoid having to get them out
 	value			int
 	min				int
 	max				int
+	step				int
 }
 
 func newSpinbox(min int, max int) Spinbox {
@@ -31,10 +33,17 @@ func newSpinbox(min int, max int) Spinbox {
 		C.textfieldStyle | C.ES_NUMBER,
 		C.textfieldExtStyle)
 	s.changed = newEvent()
-	s.updownVisible = true		// initially shown
+	s.fsetShown = s.setShown
+	s.fsetEnabled = s.setEnabled
+	s.fsetTooltip = s.setTooltip
+	s.fsetFocus = s.setFocus
+	s.fdestroy = s.destroyWindows
+	// the up-down control is never focused and comes right after the edit anyway
+	s.handle = uintptr(unsafe.Pointer(s.hwndEdit))
 	s.min = min
 	s.max = max
 	s.value = s.min
+	s.step = 1
 	s.remakeUpDown()
 	C.controlSetControlFont(s.hwndEdit)
 	C.setSpinboxEditSubclass(s.hwndEdit, unsafe.Pointer(s))
@@ -65,6 +74,29 @@ func (s *spinbox) OnChanged(e func()) {
 	s.changed.set(e)
 }
 
+func (s *spinbox) Step() int {
+	return s.step
+}
+
+func (s *spinbox) SetStep(step int) {
+	if step <= 0 {
+		panic(fmt.Errorf("non-positive step %d given to Spinbox.SetStep()", step))
+	}
+	s.step = step
+	s.setAccel()
+}
+
+// the up-down control has no notion of a step; instead it has a table of accelerations, each of which is how much to step by after the button has been held for so many seconds
+// the default table is 1, then 5 after 2 seconds, then 20 after 5 seconds; keep the same shape, multiplied by our step
+func (s *spinbox) setAccel() {
+	accel := [3]C.UDACCEL{
+		{nSec: 0, nInc: C.UINT(s.step)},
+		{nSec: 2, nInc: C.UINT(5 * s.step)},
+		{nSec: 5, nInc: C.UINT(20 * s.step)},
+	}
+	C.SendMessageW(s.hwndUpDown, C.UDM_SETACCEL, C.WPARAM(len(accel)), C.LPARAM(uintptr(unsafe.Pointer(&accel[0]))))
+}
+
 //export spinboxUpDownClicked
 func spinboxUpDownClicked(data unsafe.Pointer, nud *C.NMUPDOWN) {
 	// this is where we do custom increments
@@ -96,14 +128,16 @@ func spinboxEditChanged(data unsafe.Pointer) {
 }
 
 func (s *spinbox) setParent(p *controlParent) {
+	s.parent = p
 	C.controlSetParent(s.hwndEdit, p.hwnd)
 	C.controlSetParent(s.hwndUpDown, p.hwnd)
+	s.reapply()
 }
 
 // an up-down control will only properly position itself the first time
 // stupidly, there are no messages to force a size calculation, nor can I seem to reset the buddy window to force a new position
 // alas, we have to make a new up/down control each time :(
-// TODO we'll need to store a copy of the current position and range for this
+// this is why we keep a copy of the current position, range, and step
 func (s *spinbox) remakeUpDown() {
 	// destroying the previous one, setting the parent properly, and subclassing are handled here
 	s.hwndUpDown = C.newUpDown(s.hwndUpDown, unsafe.Pointer(s))
@@ -112,9 +146,13 @@ func (s *spinbox) remakeUpDown() {
 	C.SendMessageW(s.hwndUpDown, C.UDM_SETBUDDY, C.WPARAM(uintptr(unsafe.Pointer(s.hwndEdit))), 0)
 	C.SendMessageW(s.hwndUpDown, C.UDM_SETRANGE32, C.WPARAM(s.min), C.LPARAM(s.max))
 	C.SendMessageW(s.hwndUpDown, C.UDM_SETPOS32, 0, C.LPARAM(s.value))
-	if s.updownVisible {
+	s.setAccel()
+	if s.shown() {
 		C.ShowWindow(s.hwndUpDown, C.SW_SHOW)
 	}
+	if !s.enabled() {
+		C.EnableWindow(s.hwndUpDown, C.FALSE)
+	}
 }
 
 // use the same height as normal text fields
@@ -133,16 +171,34 @@ func (s *spinbox) nTabStops() int {
 	return 1
 }
 
-// TODO be sure to modify this when we add Show()/Hide()
-func (s *spinbox) containerShow() {
-	C.ShowWindow(s.hwndEdit, C.SW_SHOW)
-	C.ShowWindow(s.hwndUpDown, C.SW_SHOW)
-	s.updownVisible = true
+// remakeUpDown() checks shown() and enabled() to copy these onto the new up-down control
+func (s *spinbox) setShown(shown bool) {
+	show := C.int(C.SW_HIDE)
+	if shown {
+		show = C.SW_SHOW
+	}
+	C.ShowWindow(s.hwndEdit, show)
+	C.ShowWindow(s.hwndUpDown, show)
+}
+
+func (s *spinbox) setEnabled(enabled bool) {
+	C.EnableWindow(s.hwndEdit, toBOOL(enabled))
+	C.EnableWindow(s.hwndUpDown, toBOOL(enabled))
+}
+
+func (s *spinbox) setFocus() {
+	C.SetFocus(s.hwndEdit)
+}
+
+// the up-down control is remade on every resize (see remakeUpDown()), so only the edit gets the tooltip
+func (s *spinbox) setTooltip(text string) {
+	s.tooltip = C.controlSetTooltip(s.hwndEdit, s.tooltip, toUTF16(text))
 }
 
-// TODO be sure to modify this when we add Show()/Hide()
-func (s *spinbox) containerHide() {
-	C.ShowWindow(s.hwndEdit, C.SW_HIDE)
-	C.ShowWindow(s.hwndUpDown, C.SW_HIDE)
-	s.updownVisible = false
+func (s *spinbox) destroyWindows() {
+	if s.tooltip != nil {
+		C.controlDestroy(s.tooltip)
+	}
+	C.controlDestroy(s.hwndUpDown)
+	C.controlDestroy(s.hwndEdit)
 }
@@ -17,36 +17,79 @@ const (
 // A horizontal Stack gives all controls the same height and their preferred widths.
 // A vertical Stack gives all controls the same width and their preferred heights.
 // Any extra space at the end of a Stack is left blank.
-// Some controls may be marked as "stretchy": when the Window they are in changes size, stretchy controls resize to take up the remaining space after non-stretchy controls are laid out. If multiple controls are marked stretchy, they are alloted equal distribution of the remaining space.
+// Some controls may be marked as "stretchy": when the Window they are in changes size, stretchy controls resize to take up the remaining space after non-stretchy controls are laid out. If multiple controls are marked stretchy, they are alloted equal distribution of the remaining space, unless they are given different stretch weights with SetStretch.
+// For instance, to have an Area take up all the space below a row of buttons that keep their natural size, put the Area and a horizontal Stack of the buttons in a vertical Stack and make only the Area stretchy.
 type Stack interface {
 	Control
 
 	// SetStretchy marks a control in a Stack as stretchy.
+	// It is the same as SetStretch(index, 1).
 	// It panics if index is out of range.
 	SetStretchy(index int)
 
+	// Stretch and SetStretch get and set the stretch weight of a control in a Stack.
+	// Stretchy controls split the remaining space in proportion to their weights: a control with weight 2 gets twice as much as one with weight 1.
+	// A weight of 0, the default, means the control is not stretchy and gets its preferred size.
+	// Both panic if index is out of range; SetStretch also panics if weight is negative.
+	Stretch(index int) int
+	SetStretch(index int, weight int)
+
 	// Padded and SetPadded get and set whether the controls of the Stack have padding between them.
 	// The size of the padding is platform-dependent.
 	Padded() bool
 	SetPadded(padded bool)
+
+	// Spacing and SetSpacing get and set an explicit amount of space, in pixels, between the controls of the Stack.
+	// If set, it is used instead of the padding chosen by Padded.
+	// Pass a negative spacing to go back to using Padded; Spacing returns -1 in that case, which is the default.
+	Spacing() int
+	SetSpacing(spacing int)
+
+	// Margin and SetMargin get and set the amount of space, in pixels, left blank around the outside of the Stack.
+	// The default is 0; SetMargin panics if margin is negative.
+	// This is separate from the margin of a Window or Group, which comes on top of it.
+	Margin() int
+	SetMargin(margin int)
+
+	// Len returns the number of controls in the Stack.
+	Len() int
+
+	// Append adds a control to the end of the Stack, and InsertAt adds one before the control at index (or at the end, if index is Len()).
+	// Controls can be added at any time, including after the Stack is in a Window that has been shown; the Window is laid out again to make room.
+	// New controls are not stretchy.
+	// InsertAt panics if index is out of range.
+	Append(control Control)
+	InsertAt(index int, control Control)
+
+	// Delete removes the control at index from the Stack; the controls after it move up to take its place.
+	// As with Tab.Delete, the removed control is destroyed and must not be used again.
+	// Delete panics if index is out of range.
+	Delete(index int)
 }
 
 type stack struct {
+	controlstate
 	orientation   orientation
 	controls      []Control
-	stretchy      []bool
+	stretch       []int
 	width, height []int // caches to avoid reallocating these each time
 	padded	bool
+	spacing       int // -1 to use padded
+	margin        int
 }
 
 func newStack(o orientation, controls ...Control) Stack {
 	s := &stack{
 		orientation: o,
 		controls:    controls,
-		stretchy:    make([]bool, len(controls)),
+		stretch:     make([]int, len(controls)),
 		width:       make([]int, len(controls)),
 		height:      make([]int, len(controls)),
+		spacing:     -1,
 	}
+	s.fsetShown = s.setShown
+	s.fsetEnabled = s.setEnabled
+	s.fdestroy = chainDestroy(func() {}, s.childControls)		// nothing native of its own
 	return s
 }
 
@@ -60,11 +103,28 @@ func NewVerticalStack(controls ...Control) Stack {
 	return newStack(vertical, controls...)
 }
 
+func (s *stack) checkIndex(index int, what string) {
+	if index < 0 || index >= len(s.stretch) {
+		panic(fmt.Errorf("index %d out of range in Stack.%s()", index, what))
+	}
+}
+
 func (s *stack) SetStretchy(index int) {
-	if index < 0 || index > len(s.stretchy) {
-		panic(fmt.Errorf("index %d out of range in Stack.SetStretchy()", index))
+	s.checkIndex(index, "SetStretchy")
+	s.stretch[index] = 1
+}
+
+func (s *stack) Stretch(index int) int {
+	s.checkIndex(index, "Stretch")
+	return s.stretch[index]
+}
+
+func (s *stack) SetStretch(index int, weight int) {
+	s.checkIndex(index, "SetStretch")
+	if weight < 0 {
+		panic(fmt.Errorf("negative weight %d given to Stack.SetStretch()", weight))
 	}
-	s.stretchy[index] = true
+	s.stretch[index] = weight
 }
 
 func (s *stack) Padded() bool {
@@ -75,50 +135,144 @@ func (s *stack) SetPadded(padded bool) {
 	s.padded = padded
 }
 
+func (s *stack) Spacing() int {
+	return s.spacing
+}
+
+func (s *stack) SetSpacing(spacing int) {
+	if spacing < 0 {
+		spacing = -1
+	}
+	s.spacing = spacing
+}
+
+func (s *stack) Margin() int {
+	return s.margin
+}
+
+func (s *stack) SetMargin(margin int) {
+	if margin < 0 {
+		panic(fmt.Errorf("negative margin %d given to Stack.SetMargin()", margin))
+	}
+	s.margin = margin
+}
+
+func (s *stack) Len() int {
+	return len(s.controls)
+}
+
+func (s *stack) Append(control Control) {
+	s.InsertAt(len(s.controls), control)
+}
+
+func (s *stack) InsertAt(index int, control Control) {
+	if index < 0 || index > len(s.controls) {
+		panic(fmt.Errorf("index %d out of range in Stack.InsertAt()", index))
+	}
+	s.controls = append(s.controls, nil)
+	copy(s.controls[index+1:], s.controls[index:])
+	s.controls[index] = control
+	s.stretch = append(s.stretch, 0)
+	copy(s.stretch[index+1:], s.stretch[index:])
+	s.stretch[index] = 0
+	s.width = append(s.width, 0)
+	s.height = append(s.height, 0)
+	s.adopt(control)
+}
+
+func (s *stack) Delete(index int) {
+	s.checkIndex(index, "Delete")
+	control := s.controls[index]
+	s.controls = append(s.controls[:index], s.controls[index+1:]...)
+	s.stretch = append(s.stretch[:index], s.stretch[index+1:]...)
+	s.width = s.width[:len(s.controls)]
+	s.height = s.height[:len(s.controls)]
+	s.orphan(control)
+}
+
+// the space between controls, in pixels
+func (s *stack) padding(d *sizing) (xpadding int, ypadding int) {
+	if s.spacing >= 0 {
+		return s.spacing, s.spacing
+	}
+	if !s.padded {
+		return 0, 0
+	}
+	return d.xpadding, d.ypadding
+}
+
 func (s *stack) setParent(parent *controlParent) {
+	s.parent = parent
 	for _, c := range s.controls {
 		c.setParent(parent)
 	}
+	s.reapply()
+}
+
+func (s *stack) childControls() []Control {
+	return s.controls
+}
+
+func (s *stack) setShown(shown bool) {
+	for _, c := range s.controls {
+		if shown {
+			c.containerShow()
+		} else {
+			c.containerHide()
+		}
+	}
 }
 
-func (s *stack) containerShow() {
+func (s *stack) setEnabled(enabled bool) {
 	for _, c := range s.controls {
-		c.containerShow()
+		if enabled {
+			c.containerEnable()
+		} else {
+			c.containerDisable()
+		}
 	}
 }
 
-func (s *stack) containerHide() {
+// hidden controls are left out of the layout entirely, padding included
+func (s *stack) nVisible() int {
+	n := 0
 	for _, c := range s.controls {
-		c.containerHide()
+		if c.Visible() {
+			n++
+		}
 	}
+	return n
 }
 
 func (s *stack) resize(x int, y int, width int, height int, d *sizing) {
 	var stretchywid, stretchyht int
 
-	if len(s.controls) == 0 { // do nothing if there's nothing to do
+	n := s.nVisible()
+	if n == 0 { // do nothing if there's nothing to do
 		return
 	}
 	// -1) get this Stack's padding
-	xpadding := d.xpadding
-	ypadding := d.ypadding
-	if !s.padded {
-		xpadding = 0
-		ypadding = 0
-	}
-	// 0) inset the available rect by the needed padding
+	xpadding, ypadding := s.padding(d)
+	// 0) inset the available rect by the margin and the needed padding
+	x += s.margin
+	y += s.margin
+	width -= 2 * s.margin
+	height -= 2 * s.margin
 	if s.orientation == horizontal {
-		width -= (len(s.controls) - 1) * xpadding
+		width -= (n - 1) * xpadding
 	} else {
-		height -= (len(s.controls) - 1) * ypadding
+		height -= (n - 1) * ypadding
 	}
 	// 1) get height and width of non-stretchy controls; figure out how much space is alloted to stretchy controls
 	stretchywid = width
 	stretchyht = height
-	nStretchy := 0
+	totalStretch := 0
 	for i, c := range s.controls {
-		if s.stretchy[i] {
-			nStretchy++
+		if !c.Visible() {
+			continue
+		}
+		if s.stretch[i] != 0 {
+			totalStretch += s.stretch[i]
 			continue
 		}
 		w, h := c.preferredSize(d)
@@ -132,23 +286,29 @@ func (s *stack) resize(x int, y int, width int, height int, d *sizing) {
 			stretchyht -= h
 		}
 	}
-	// 2) figure out size of stretchy controls
-	if nStretchy != 0 {
-		if s.orientation == horizontal { // split rest of width
-			stretchywid /= nStretchy
-		} else { // split rest of height
-			stretchyht /= nStretchy
-		}
-	}
-	for i := range s.controls {
-		if !s.stretchy[i] {
+	// 2) figure out size of stretchy controls by splitting the rest of the width or height by weight
+	// the last stretchy control gets whatever is left over from rounding, so the controls fill the Stack exactly
+	given, sofar := 0, 0
+	for i, c := range s.controls {
+		if s.stretch[i] == 0 || !c.Visible() {
 			continue
 		}
-		s.width[i] = stretchywid
-		s.height[i] = stretchyht
+		sofar += s.stretch[i]
+		if s.orientation == horizontal {
+			s.width[i] = stretchywid*sofar/totalStretch - given
+			s.height[i] = stretchyht
+			given += s.width[i]
+		} else {
+			s.width[i] = stretchywid
+			s.height[i] = stretchyht*sofar/totalStretch - given
+			given += s.height[i]
+		}
 	}
 	// 3) now actually place controls
 	for i, c := range s.controls {
+		if !c.Visible() {
+			continue
+		}
 		c.resize(x, y, s.width[i], s.height[i], d)
 		if s.orientation == horizontal {
 			x += s.width[i] + xpadding
@@ -159,7 +319,7 @@ func (s *stack) resize(x int, y int, width int, height int, d *sizing) {
 	return
 }
 
-// The preferred size of a Stack is the sum of the preferred sizes of non-stretchy controls + (the number of stretchy controls * the largest preferred size among all stretchy controls).
+// The preferred size of a Stack is the sum of the preferred sizes of non-stretchy controls + the smallest amount of stretchy space that gives every stretchy control at least its preferred size (with equal weights, that's the number of stretchy controls * the largest preferred size among all stretchy controls) + the margin.
 func (s *stack) preferredSize(d *sizing) (width int, height int) {
 	max := func(a int, b int) int {
 		if a > b {
@@ -168,48 +328,52 @@ func (s *stack) preferredSize(d *sizing) (width int, height int) {
 		return b
 	}
 
-	var nStretchy int
+	var totalStretch int
 	var maxswid, maxsht int
 
-	if len(s.controls) == 0 { // no controls, so return emptiness
-		return 0, 0
-	}
-	xpadding := d.xpadding
-	ypadding := d.ypadding
-	if !s.padded {
-		xpadding = 0
-		ypadding = 0
+	n := s.nVisible()
+	if n == 0 { // no controls, so return emptiness
+		return 2 * s.margin, 2 * s.margin
 	}
+	xpadding, ypadding := s.padding(d)
 	if s.orientation == horizontal {
-		width = (len(s.controls) - 1) * xpadding
+		width = (n - 1) * xpadding
 	} else {
-		height = (len(s.controls) - 1) * ypadding
+		height = (n - 1) * ypadding
 	}
 	for i, c := range s.controls {
+		if c.Visible() {
+			totalStretch += s.stretch[i]
+		}
+	}
+	for i, c := range s.controls {
+		if !c.Visible() {
+			continue
+		}
 		w, h := c.preferredSize(d)
-		if s.stretchy[i] {
-			nStretchy++
-			maxswid = max(maxswid, w)
-			maxsht = max(maxsht, h)
+		if s.stretch[i] != 0 {
+			// the stretchy space needed so that this control gets w (or h) from its share, rounded up
+			maxswid = max(maxswid, (w*totalStretch+s.stretch[i]-1)/s.stretch[i])
+			maxsht = max(maxsht, (h*totalStretch+s.stretch[i]-1)/s.stretch[i])
 		}
 		if s.orientation == horizontal { // max vertical size
-			if !s.stretchy[i] {
+			if s.stretch[i] == 0 {
 				width += w
 			}
 			height = max(height, h)
 		} else {
 			width = max(width, w)
-			if !s.stretchy[i] {
+			if s.stretch[i] == 0 {
 				height += h
 			}
 		}
 	}
 	if s.orientation == horizontal {
-		width += nStretchy * maxswid
+		width += maxswid
 	} else {
-		height += nStretchy * maxsht
+		height += maxsht
 	}
-	return
+	return width + 2*s.margin, height + 2*s.margin
 }
 
 func (s *stack) nTabStops() int {
@@ -3,6 +3,7 @@
 package ui
 
 import (
+	"fmt"
 	"unsafe"
 )
 
@@ -13,13 +14,19 @@ type tab struct {
 	*controlSingleObject
 	tabs			[]*container
 	children		[]Control
+	selected		*event
 }
 
 func newTab() Tab {
 	t := &tab{
-		controlSingleObject:		newControlSingleObject(C.newTab()),
+		selected:		newEvent(),
 	}
+	t.controlSingleObject = newControlSingleObject(C.newTab(unsafe.Pointer(t)))
 	t.fpreferredSize = t.xpreferredSize
+	t.fsetEnabled = chainEnabled(t.fsetEnabled, func() []Control {
+		return t.children
+	})
+	t.fdestroy = chainDestroy(t.fdestroy, t.childControls)
 	return t
 }
 
@@ -27,12 +34,56 @@ func (t *tab) Append(name string, control Control) {
 	c := newContainer(control.resize)
 	t.tabs = append(t.tabs, c)
 	control.setParent(c.parent())
+	if !t.enabled() {
+		control.containerDisable()
+	}
 	t.children = append(t.children, control)
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
 	C.tabAppend(t.id, cname, c.id)
 }
 
+// NSTabView selects another tab item on its own if the current one is removed
+func (t *tab) Delete(index int) {
+	if index < 0 || index >= len(t.tabs) {
+		panic(fmt.Errorf("tab index %d out of range in Tab.Delete()", index))
+	}
+	current := int(C.tabSelected(t.id))
+	t.children[index].destroy()
+	C.tabDelete(t.id, C.intptr_t(index))
+	t.tabs = append(t.tabs[:index], t.tabs[index+1:]...)
+	t.children = append(t.children[:index], t.children[index+1:]...)
+	if index < current {
+		// the same tab is still selected, so NSTabView doesn't tell us, but its index changed
+		t.selected.fire()
+	}
+}
+
+func (t *tab) childControls() []Control {
+	return t.children
+}
+
+func (t *tab) Selected() int {
+	return int(C.tabSelected(t.id))
+}
+
+func (t *tab) Select(index int) {
+	if index < 0 || index >= len(t.tabs) {
+		panic(fmt.Errorf("tab index %d out of range in Tab.Select()", index))
+	}
+	C.tabSelect(t.id, C.intptr_t(index))
+}
+
+func (t *tab) OnSelected(f func()) {
+	t.selected.set(f)
+}
+
+//export tabSelectionChanged
+func tabSelectionChanged(data unsafe.Pointer) {
+	t := (*tab)(data)
+	t.selected.fire()
+}
+
 func (t *tab) xpreferredSize(d *sizing) (width, height int) {
 	s := C.tabPreferredSize(t.id)
 	return int(s.width), int(s.height)
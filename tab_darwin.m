@@ -7,12 +7,31 @@
 #define toNSTabView(x) ((NSTabView *) (x))
 #define toNSView(x) ((NSView *) (x))
 
-id newTab(void)
+@interface goTabDelegate : NSObject <NSTabViewDelegate> {
+@public
+	void *gotab;
+}
+@end
+
+@implementation goTabDelegate
+
+- (void)tabView:(NSTabView *)tv didSelectTabViewItem:(NSTabViewItem *)item
+{
+	tabSelectionChanged(self->gotab);
+}
+
+@end
+
+id newTab(void *gotab)
 {
 	NSTabView *t;
+	goTabDelegate *delegate;
 
 	t = [[NSTabView alloc] initWithFrame:NSZeroRect];
 	setStandardControlFont((id) t);		// safe; same selector provided by NSTabView
+	delegate = [goTabDelegate new];
+	delegate->gotab = gotab;
+	[t setDelegate:delegate];
 	return (id) t;
 }
 
@@ -26,6 +45,31 @@ void tabAppend(id t, char *name, id view)
 	[toNSTabView(t) addTabViewItem:i];
 }
 
+void tabDelete(id t, intptr_t index)
+{
+	NSTabView *tv;
+
+	tv = toNSTabView(t);
+	[tv removeTabViewItem:[tv tabViewItemAtIndex:((NSInteger) index)]];
+}
+
+intptr_t tabSelected(id t)
+{
+	NSTabView *tv;
+	NSTabViewItem *item;
+
+	tv = toNSTabView(t);
+	item = [tv selectedTabViewItem];
+	if (item == nil)
+		return -1;
+	return (intptr_t) [tv indexOfTabViewItem:item];
+}
+
+void tabSelect(id t, intptr_t index)
+{
+	[toNSTabView(t) selectTabViewItemAtIndex:((NSInteger) index)];
+}
+
 struct xsize tabPreferredSize(id control)
 {
 	NSTabView *tv;
@@ -5,10 +5,12 @@
 package ui
 
 import (
+	"fmt"
 	"unsafe"
 )
 
 // #include "gtk_unix.h"
+// extern void tabSelectionChanged(GObject *, GParamSpec *, gpointer);
 import "C"
 
 type tab struct {
@@ -18,6 +20,7 @@ type tab struct {
 
 	tabs []*container
 	children	[]Control
+	selected	*event
 }
 
 func newTab() Tab {
@@ -26,9 +29,20 @@ func newTab() Tab {
 		controlSingleWidget:	newControlSingleWidget(widget),
 		container: (*C.GtkContainer)(unsafe.Pointer(widget)),
 		notebook:  (*C.GtkNotebook)(unsafe.Pointer(widget)),
+		selected:	newEvent(),
 	}
 	// there are no scrolling arrows by default; add them in case there are too many tabs
 	C.gtk_notebook_set_scrollable(t.notebook, C.TRUE)
+	// switch-page is sent before the current page changes, so gtk_notebook_get_current_page() would be wrong in it; watch the page property instead
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(t.notebook)),
+		"notify::page",
+		C.GCallback(C.tabSelectionChanged),
+		C.gpointer(unsafe.Pointer(t)))
+	t.fsetEnabled = chainEnabled(t.fsetEnabled, func() []Control {
+		return t.children
+	})
+	t.fdestroy = chainDestroy(t.fdestroy, t.childControls)
 	return t
 }
 
@@ -39,6 +53,9 @@ func (t *tab) Append(name string, control Control) {
 	C.gtk_container_add(t.container, c.widget)
 	control.setParent(c.parent())
 	c.resize = control.resize
+	if !t.enabled() {
+		control.containerDisable()
+	}
 	t.children = append(t.children, control)
 	cname := togstr(name)
 	defer freegstr(cname)
@@ -48,4 +65,40 @@ func (t *tab) Append(name string, control Control) {
 		cname)
 }
 
+// GtkNotebook selects the next page on its own if the current one is removed
+func (t *tab) Delete(index int) {
+	if index < 0 || index >= len(t.tabs) {
+		panic(fmt.Errorf("tab index %d out of range in Tab.Delete()", index))
+	}
+	// this destroys the container and, with it, the control
+	C.gtk_notebook_remove_page(t.notebook, C.gint(index))
+	t.tabs = append(t.tabs[:index], t.tabs[index+1:]...)
+	t.children = append(t.children[:index], t.children[index+1:]...)
+}
+
+func (t *tab) childControls() []Control {
+	return t.children
+}
+
+func (t *tab) Selected() int {
+	return int(C.gtk_notebook_get_current_page(t.notebook))
+}
+
+func (t *tab) Select(index int) {
+	if index < 0 || index >= len(t.tabs) {
+		panic(fmt.Errorf("tab index %d out of range in Tab.Select()", index))
+	}
+	C.gtk_notebook_set_current_page(t.notebook, C.gint(index))
+}
+
+func (t *tab) OnSelected(f func()) {
+	t.selected.set(f)
+}
+
+//export tabSelectionChanged
+func tabSelectionChanged(obj *C.GObject, pspec *C.GParamSpec, data C.gpointer) {
+	t := (*tab)(unsafe.Pointer(data))
+	t.selected.fire()
+}
+
 // no need to handle resize; the children containers handle that for us
@@ -73,6 +73,23 @@ void tabAppend(HWND hwnd, LPWSTR name)
 		xpanic("error adding tab to Tab", GetLastError());
 }
 
+void tabDelete(HWND hwnd, LRESULT index)
+{
+	if (SendMessageW(hwnd, TCM_DELETEITEM, (WPARAM) index, 0) == FALSE)
+		xpanic("error removing tab from Tab", GetLastError());
+}
+
+LRESULT tabSelected(HWND hwnd)
+{
+	return SendMessageW(hwnd, TCM_GETCURSEL, 0, 0);
+}
+
+void tabSelect(HWND hwnd, LRESULT index)
+{
+	// the return value is the previous selection, which is -1 (and thus indistinguishable from an error) if there was no selection, so don't bother checking it
+	SendMessageW(hwnd, TCM_SETCURSEL, (WPARAM) index, 0);
+}
+
 void tabGetContentRect(HWND hwnd, RECT *r)
 {
 	// not &r; already a pointer (thanks MindChild in irc.efnet.net/#winprog for spotting my failure)
@@ -3,6 +3,7 @@
 package ui
 
 import (
+	"fmt"
 	"unsafe"
 )
 
@@ -19,6 +20,7 @@ On Windows, container controls are just regular controls that notify their paren
 type tab struct {
 	*controlSingleHWND
 	children		[]Control
+	selected		*event
 	chainresize	func(x int, y int, width int, height int, d *sizing)
 }
 
@@ -28,10 +30,15 @@ func newTab() Tab {
 		0) // don't set WS_EX_CONTROLPARENT here; see uitask_windows.c
 	t := &tab{
 		controlSingleHWND:		newControlSingleHWND(hwnd),
+		selected:				newEvent(),
 	}
 	t.fpreferredSize = t.xpreferredSize
 	t.chainresize = t.fresize
 	t.fresize = t.xresize
+	t.fsetEnabled = chainEnabled(t.fsetEnabled, func() []Control {
+		return t.children
+	})
+	t.fdestroy = chainDestroy(t.fdestroy, t.childControls)
 	// count tabs as 1 tab stop; the actual number of tab stops varies
 	C.controlSetControlFont(t.hwnd)
 	C.setTabSubclass(t.hwnd, unsafe.Pointer(t))
@@ -41,6 +48,9 @@ func newTab() Tab {
 // TODO margined
 func (t *tab) Append(name string, control Control) {
 	control.setParent(&controlParent{t.hwnd})
+	if !t.enabled() {
+		control.containerDisable()
+	}
 	t.children = append(t.children, control)
 	// initially hide tab 1..n controls; if we don't, they'll appear over other tabs, resulting in weird behavior
 	if len(t.children) != 1 {
@@ -49,6 +59,60 @@ func (t *tab) Append(name string, control Control) {
 	C.tabAppend(t.hwnd, toUTF16(name))
 }
 
+func (t *tab) Delete(index int) {
+	if index < 0 || index >= len(t.children) {
+		panic(fmt.Errorf("tab index %d out of range in Tab.Delete()", index))
+	}
+	current := int(C.tabSelected(t.hwnd))
+	t.children[index].destroy()
+	C.tabDelete(t.hwnd, C.LRESULT(index))
+	t.children = append(t.children[:index], t.children[index+1:]...)
+	if index < current {
+		// the tab control moves the selection down itself, so the same tab is still selected, but its index changed
+		t.selected.fire()
+		return
+	}
+	if index != current || len(t.children) == 0 {
+		return
+	}
+	// removing the current tab leaves nothing selected, so pick its replacement
+	if index == len(t.children) {
+		index--
+	}
+	C.tabSelect(t.hwnd, C.LRESULT(index))
+	t.children[index].containerShow()
+	t.selected.fire()
+}
+
+func (t *tab) childControls() []Control {
+	return t.children
+}
+
+func (t *tab) Selected() int {
+	return int(C.tabSelected(t.hwnd))
+}
+
+// TCM_SETCURSEL does not send TCN_SELCHANGING or TCN_SELCHANGE, so we have to do their work ourselves
+func (t *tab) Select(index int) {
+	if index < 0 || index >= len(t.children) {
+		panic(fmt.Errorf("tab index %d out of range in Tab.Select()", index))
+	}
+	current := int(C.tabSelected(t.hwnd))
+	if current == index {
+		return
+	}
+	if current != -1 {
+		t.children[current].containerHide()
+	}
+	C.tabSelect(t.hwnd, C.LRESULT(index))
+	t.children[index].containerShow()
+	t.selected.fire()
+}
+
+func (t *tab) OnSelected(f func()) {
+	t.selected.set(f)
+}
+
 //export tabChanging
 func tabChanging(data unsafe.Pointer, current C.LRESULT) {
 	t := (*tab)(data)
@@ -59,6 +123,7 @@ func tabChanging(data unsafe.Pointer, current C.LRESULT) {
 func tabChanged(data unsafe.Pointer, new C.LRESULT) {
 	t := (*tab)(data)
 	t.children[int(new)].containerShow()
+	t.selected.fire()
 }
 
 //export tabTabHasChildren
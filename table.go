@@ -4,16 +4,20 @@ package ui
 
 import (
 	"fmt"
+	"image"
 	"reflect"
 	"sync"
+	"time"
 )
 
 // Table is a Control that displays a list of like-structured data in a grid where each row represents an item and each column represents a bit of data.
-// Tables store and render a slice of struct values.
-// Each field of the struct of type *image.RGBA is rendered as an icon.
-// The Table itself will resize the image to an icon size if needed; the original *image.RGBA will not be modified and the icon size is implementation-defined.
+// Tables created with NewTable store and render a slice of struct values.
+// Each field of the struct whose type implements image.Image (*image.RGBA, *image.NRGBA, image.Image itself, and so on) is rendered as an icon.
+// The Table itself will convert the image and resize it to an icon size if needed; the original image will not be modified and the icon size is implementation-defined.
 // Each field whose type is bool or equivalent to bool is rendered as a checkbox.
+// Fields of type time.Time, time.Duration, and FileSize are rendered as strings with FormatDateTime, FormatDuration, and FormatFileSize, respectively, so they follow the user's locale.
 // All other fields are rendered as strings formatted with package fmt's %v format specifier.
+// Tables created with NewTableFromModel instead get their data from a TableModel; see TableModel for details.
 //
 // Tables are read-only by default, except for checkboxes, which are user-settable.
 //
@@ -35,7 +39,8 @@ type Table interface {
 	RUnlock()
 
 	// Data returns the internal data.
-	// The returned value will contain an object of type pointer to slice of some structure; use a type assertion to get the properly typed object out.
+	// For a Table created with NewTable, the returned value will contain an object of type pointer to slice of some structure; use a type assertion to get the properly typed object out.
+	// For a Table created with NewTableFromModel, the returned value is the TableModel.
 	// Do not call this outside a Lock()..Unlock() or RLock()..RUnlock() pair.
 	Data() interface{}
 
@@ -47,11 +52,76 @@ type Table interface {
 
 	// OnSelected is an event that gets triggered after the selection in the Table changes in whatever way (item selected or item deselected).
 	OnSelected(func())
+
+	// OnHoverPreview registers a function that is called when the mouse rests over a row of the Table.
+	// If the function returns an image, the Table shows it in a small popup next to the row, much like the message previews of some email programs.
+	// The popup goes away when the mouse moves to another row or leaves the Table, or when the user clicks or scrolls; return nil to show nothing.
+	// The function is called on the main thread without the Table locked; lock it yourself if you need Data.
+	OnHoverPreview(f func(row int) image.Image)
+
+	// OnDragRow lets the user drag rows out of the Table and drop them anywhere that accepts drag and drop, such as an Area or another program.
+	// When the user starts dragging a row, f is called on the main thread with its index; it returns the data to drag and the operations the drop target may perform, as with Area.StartDrag, or allowed == 0 to keep that row from being dragged.
+	// done, if not nil, is then called with the operation the drop target performed, or with 0 if the drag was cancelled or refused; if it is DragMove, removing the row is up to you.
+	// If data has no DragImage, GTK+ and Mac OS X show the row being dragged and Windows shows only the mouse pointer.
+	// As with OnHoverPreview, f is called without the Table locked.
+	OnDragRow(f func(row int) (data DragData, allowed DragOperation, done func(op DragOperation)))
+}
+
+// TableColumnType determines how the cells of a column of a TableModel are rendered.
+type TableColumnType uint
+
+const (
+	// TableText columns show the string formatted from the cell value as with NewTable: time.Time, time.Duration, and FileSize values with the Format functions and everything else with package fmt's %v format specifier.
+	TableText TableColumnType = iota
+	// TableImage columns show cell values that implement image.Image as icons, as with NewTable.
+	TableImage
+	// TableCheckbox columns show cell values of type bool as checkboxes.
+	TableCheckbox
+)
+
+// TableColumn describes a column of a TableModel.
+type TableColumn struct {
+	// Name is the text of the column's header.
+	Name string
+	Type TableColumnType
+
+	// Editable is whether the user can change the values of the column.
+	// Editable TableText columns are edited in place; the new value is passed to SetCellValue as a string.
+	// Editable TableCheckbox columns are toggled when clicked; the new value is passed to SetCellValue as a bool.
+	// TableImage columns cannot be edited.
+	// On Windows, a TableText cell is edited by double-clicking it or by selecting it and pressing F2; Enter or clicking elsewhere keeps the change and Escape discards it.
+	Editable bool
+}
+
+// TableModel provides the data for a Table created with NewTableFromModel.
+// All methods of TableModel are called with the Table locked: NumRows and CellValue with the read lock and SetCellValue with the write lock.
+// As with a Table created by NewTable, make changes to the model between Table.Lock() and Table.Unlock() so the Table can update itself.
+type TableModel interface {
+	// NumRows returns the number of rows in the model.
+	NumRows() int
+
+	// NumColumns returns the number of columns in the model and Column describes each one.
+	// These are only called by NewTableFromModel; the columns of a Table cannot change after it has been created.
+	NumColumns() int
+	Column(column int) TableColumn
+
+	// CellValue returns the value of the given cell.
+	// The type of the value must agree with the Type of its column.
+	CellValue(row int, column int) interface{}
+
+	// SetCellValue is called when the user changes the value of a cell in an Editable column.
+	SetCellValue(row int, column int, value interface{})
 }
 
 type tablebase struct {
-	lock sync.RWMutex
-	data interface{}
+	lock    sync.RWMutex
+	data    interface{}
+	model   TableModel
+	columns []TableColumn
+
+	// only touched on the main thread
+	hoverpreview func(row int) image.Image
+	dragrow      func(row int) (DragData, DragOperation, func(DragOperation))
 }
 
 // NewTable creates a new Table.
@@ -64,7 +134,32 @@ func NewTable(ty reflect.Type) Table {
 	b := new(tablebase)
 	// we want a pointer to a slice
 	b.data = reflect.New(reflect.SliceOf(ty)).Interface()
-	return finishNewTable(b, ty)
+	b.model = &structTableModel{
+		data: b.data,
+		ty:   ty,
+	}
+	return finishNewTable(b)
+}
+
+// NewTableFromModel creates a new Table that shows the data in the given TableModel.
+func NewTableFromModel(model TableModel) Table {
+	if model == nil {
+		panic("TableModel passed to NewTableFromModel() cannot be nil")
+	}
+	b := new(tablebase)
+	b.data = model
+	b.model = model
+	return finishNewTable(b)
+}
+
+// finishNewTable() is defined on each backend implementation of Table
+// they should get the list of columns from this, which is only valid during construction
+func (b *tablebase) buildColumns() []TableColumn {
+	b.columns = make([]TableColumn, b.model.NumColumns())
+	for i := range b.columns {
+		b.columns[i] = b.model.Column(i)
+	}
+	return b.columns
 }
 
 func (b *tablebase) Lock() {
@@ -88,3 +183,99 @@ func (b *tablebase) RUnlock() {
 func (b *tablebase) Data() interface{} {
 	return b.data
 }
+
+func (b *tablebase) OnHoverPreview(f func(row int) image.Image) {
+	b.hoverpreview = f
+}
+
+func (b *tablebase) OnDragRow(f func(row int) (data DragData, allowed DragOperation, done func(op DragOperation))) {
+	b.dragrow = f
+}
+
+// fireDragRow returns allowed == 0 if the row can't be dragged
+func (b *tablebase) fireDragRow(row int) (data DragData, allowed DragOperation, done func(op DragOperation)) {
+	if b.dragrow == nil || row < 0 {
+		return DragData{}, 0, nil
+	}
+	data, allowed, done = b.dragrow(row)
+	if allowed == 0 {
+		return DragData{}, 0, nil
+	}
+	checkStartDrag(data, allowed, "the function given to Table.OnDragRow()")
+	return data, allowed, done
+}
+
+// firePreview returns nil if there is no preview to show
+func (b *tablebase) firePreview(row int) *image.RGBA {
+	if b.hoverpreview == nil {
+		return nil
+	}
+	return toRGBA(b.hoverpreview(row))
+}
+
+// structTableModel is the TableModel behind NewTable.
+type structTableModel struct {
+	data interface{}
+	ty   reflect.Type
+}
+
+func (m *structTableModel) slice() reflect.Value {
+	return reflect.Indirect(reflect.ValueOf(m.data))
+}
+
+func (m *structTableModel) NumRows() int {
+	return m.slice().Len()
+}
+
+func (m *structTableModel) NumColumns() int {
+	return m.ty.NumField()
+}
+
+func (m *structTableModel) Column(column int) TableColumn {
+	f := m.ty.Field(column)
+	c := TableColumn{
+		Name: f.Tag.Get("uicolumn"),
+		Type: TableText,
+	}
+	if c.Name == "" {
+		c.Name = f.Name
+	}
+	switch {
+	case f.Type.Implements(reflect.TypeOf((*image.Image)(nil)).Elem()):
+		c.Type = TableImage
+	case f.Type.Kind() == reflect.Bool:
+		c.Type = TableCheckbox
+		c.Editable = true
+	}
+	return c
+}
+
+func (m *structTableModel) CellValue(row int, column int) interface{} {
+	datum := m.slice().Index(row).Field(column)
+	if datum.Kind() == reflect.Bool {
+		// normalize types equivalent to bool
+		return datum.Bool()
+	}
+	return datum.Interface()
+}
+
+func (m *structTableModel) SetCellValue(row int, column int, value interface{}) {
+	datum := m.slice().Index(row).Field(column)
+	if datum.Kind() == reflect.Bool {
+		datum.SetBool(value.(bool))
+		return
+	}
+	panic(fmt.Errorf("attempt to set non-checkbox cell (%d, %d) of Table", row, column))
+}
+
+// tableCellText formats the value of a text cell; see the Table documentation.
+// FileSize is handled by %v, since it's a fmt.Stringer.
+func tableCellText(datum interface{}) string {
+	switch d := datum.(type) {
+	case time.Time:
+		return FormatDateTime(d)
+	case time.Duration:
+		return FormatDuration(d)
+	}
+	return fmt.Sprintf("%v", datum)
+}
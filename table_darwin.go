@@ -3,8 +3,6 @@
 package ui
 
 import (
-	"fmt"
-	"reflect"
 	"unsafe"
 	"image"
 )
@@ -18,9 +16,10 @@ type table struct {
 	*scroller
 
 	selected *event
+	dragdone func(op DragOperation)
 }
 
-func finishNewTable(b *tablebase, ty reflect.Type) Table {
+func finishNewTable(b *tablebase) Table {
 	id := C.newTable()
 	t := &table{
 		scroller:  newScroller(id, true), // border on Table
@@ -30,22 +29,18 @@ func finishNewTable(b *tablebase, ty reflect.Type) Table {
 	t.fpreferredSize = t.xpreferredSize
 	// also sets the delegate
 	C.tableMakeDataSource(t.id, unsafe.Pointer(t))
-	for i := 0; i < ty.NumField(); i++ {
-		colname := ty.Field(i).Tag.Get("uicolumn")
-		if colname == "" {
-			colname = ty.Field(i).Name
-		}
-		cname := C.CString(colname)
+	C.addHoverPreview(t.id, unsafe.Pointer(t), C.NO)
+	columns := b.buildColumns()
+	for i, col := range columns {
+		cname := C.CString(col.Name)
 		coltype := C.colTypeText
-		editable := false
-		switch {
-		case ty.Field(i).Type == reflect.TypeOf((*image.RGBA)(nil)):
+		switch col.Type {
+		case TableImage:
 			coltype = C.colTypeImage
-		case ty.Field(i).Type.Kind() == reflect.Bool:
+		case TableCheckbox:
 			coltype = C.colTypeCheckbox
-			editable = true
 		}
-		C.tableAppendColumn(t.id, C.intptr_t(i), cname, C.int(coltype), toBOOL(editable))
+		C.tableAppendColumn(t.id, C.intptr_t(i), cname, C.int(coltype), toBOOL(col.Editable))
 		C.free(unsafe.Pointer(cname)) // free now (not deferred) to conserve memory
 	}
 	return t
@@ -85,24 +80,23 @@ func goTableDataSource_getValue(data unsafe.Pointer, row C.intptr_t, col C.intpt
 	t := (*table)(data)
 	t.RLock()
 	defer t.RUnlock()
-	d := reflect.Indirect(reflect.ValueOf(t.data))
-	datum := d.Index(int(row)).Field(int(col))
-	switch {
-	case datum.Type() == reflect.TypeOf((*image.RGBA)(nil)):
+	datum := t.model.CellValue(int(row), int(col))
+	switch t.columns[col].Type {
+	case TableImage:
 		*outtype = C.colTypeImage
-		d := datum.Interface().(*image.RGBA)
+		d := toRGBA(datum.(image.Image))
 		img := C.toTableImage(unsafe.Pointer(pixelData(d)), C.intptr_t(d.Rect.Dx()), C.intptr_t(d.Rect.Dy()), C.intptr_t(d.Stride))
 		return unsafe.Pointer(img)
-	case datum.Kind() == reflect.Bool:
+	case TableCheckbox:
 		*outtype = C.colTypeCheckbox
-		if datum.Bool() == true {
+		if datum.(bool) == true {
 			// return a non-nil pointer
 			// outtype isn't Go-side so it'll work
 			return unsafe.Pointer(outtype)
 		}
 		return nil
 	default:
-		s := fmt.Sprintf("%v", datum)
+		s := tableCellText(datum)
 		return unsafe.Pointer(C.CString(s))
 	}
 }
@@ -112,8 +106,7 @@ func goTableDataSource_getRowCount(data unsafe.Pointer) C.intptr_t {
 	t := (*table)(data)
 	t.RLock()
 	defer t.RUnlock()
-	d := reflect.Indirect(reflect.ValueOf(t.data))
-	return C.intptr_t(d.Len())
+	return C.intptr_t(t.model.NumRows())
 }
 
 //export goTableDataSource_toggled
@@ -121,9 +114,15 @@ func goTableDataSource_toggled(data unsafe.Pointer, row C.intptr_t, col C.intptr
 	t := (*table)(data)
 	t.Lock()
 	defer t.Unlock()
-	d := reflect.Indirect(reflect.ValueOf(t.data))
-	datum := d.Index(int(row)).Field(int(col))
-	datum.SetBool(fromBOOL(checked))
+	t.model.SetCellValue(int(row), int(col), fromBOOL(checked))
+}
+
+//export goTableDataSource_edited
+func goTableDataSource_edited(data unsafe.Pointer, row C.intptr_t, col C.intptr_t, text *C.char) {
+	t := (*table)(data)
+	t.Lock()
+	defer t.Unlock()
+	t.model.SetCellValue(int(row), int(col), C.GoString(text))
 }
 
 //export tableSelectionChanged
@@ -136,3 +135,38 @@ func (t *table) xpreferredSize(d *sizing) (width, height int) {
 	s := C.tablePreferredSize(t.id)
 	return int(s.width), int(s.height)
 }
+
+//export tableHoverPreview
+func tableHoverPreview(data unsafe.Pointer, row C.intptr_t) C.id {
+	t := (*table)(data)
+	return toPreviewImage(t.firePreview(int(row)))
+}
+
+//export goTableDataSource_dragRow
+func goTableDataSource_dragRow(data unsafe.Pointer, row C.intptr_t, view C.id, pasteboard C.id) C.BOOL {
+	t := (*table)(data)
+	ddata, allowed, done := t.fireDragRow(int(row))
+	if allowed == 0 {
+		return C.NO
+	}
+	s := newDragStrings(ddata)
+	defer s.free()
+	C.tableSetDragData(view, pasteboard, s.cfiles(), C.intptr_t(len(s.files)), s.text,
+		toDragImage(ddata.Image), toDragImage(ddata.DragImage), C.intptr_t(ddata.Hotspot.X), C.intptr_t(ddata.Hotspot.Y),
+		s.cpromises(), C.intptr_t(len(s.promises)), toBOOL(ddata.Local != nil),
+		nsDragOperations(allowed))
+	t.dragdone = done
+	dragPromises = ddata.FilePromises
+	dragLocal = ddata.Local
+	return C.YES
+}
+
+//export goTableDataSource_dragEnded
+func goTableDataSource_dragEnded(data unsafe.Pointer, op C.uintptr_t) {
+	t := (*table)(data)
+	f := t.dragdone
+	t.dragdone = nil
+	if f != nil {
+		f(dragOperation(op))
+	}
+}
@@ -6,11 +6,14 @@
 
 #define toNSTableView(x) ((NSTableView *) (x))
 
+extern NSString *const localDragType;		// see area_darwin.m
+
 // NSTableColumn provides no provision to store an integer data
 // it does provide an identifier tag, but that's a NSString, and I'd rather not risk the conversion overhead
 @interface goTableColumn : NSTableColumn {
 @public
 	intptr_t gocolnum;
+	int gocoltype;
 }
 @end
 
@@ -20,6 +23,9 @@
 @interface goTableDataSource : NSObject <NSTableViewDataSource, NSTableViewDelegate> {
 @public
 	void *gotable;
+	NSImage *dragImage;		// for Table.OnDragRow(), from tableSetDragData() until the drag begins; nil to show the row
+	NSPoint dragHotspot;
+	intptr_t npromises;		// also from tableSetDragData()
 }
 @end
 
@@ -61,6 +67,11 @@
 	NSNumber *number = (NSNumber *) value;	// thanks to mikeash in irc.freenode.net/#macdev
 
 	colnum = ((goTableColumn *) col)->gocolnum;
+	if (((goTableColumn *) col)->gocoltype == colTypeText) {
+		// the string is copied on the Go side
+		goTableDataSource_edited(self->gotable, (intptr_t) row, colnum, (char *) [((NSString *) value) UTF8String]);
+		return;
+	}
 	goTableDataSource_toggled(self->gotable, (intptr_t) row, colnum, [number boolValue]);
 }
 
@@ -69,6 +80,61 @@
 	tableSelectionChanged(self->gotable);
 }
 
+// Table.OnDragRow(); the Go side calls tableSetDragData() below if the row can be dragged
+- (BOOL)tableView:(NSTableView *)view writeRowsWithIndexes:(NSIndexSet *)rows toPasteboard:(NSPasteboard *)pb
+{
+	return goTableDataSource_dragRow(self->gotable, (intptr_t) [rows firstIndex], (id) view, (id) pb);
+}
+
+- (void)tableView:(NSTableView *)view draggingSession:(NSDraggingSession *)session willBeginAtPoint:(NSPoint)p forRowIndexes:(NSIndexSet *)rows
+{
+	NSImage *image;
+	NSPoint hotspot;
+	NSRect r;
+
+	if (self->dragImage == nil)		// let NSTableView show the row
+		return;
+	image = self->dragImage;
+	self->dragImage = nil;
+	hotspot = self->dragHotspot;
+	// p is in screen coordinates; the dragging frames are in the table view's, which is flipped, so the origin is the top-left corner
+	r = [[view window] convertRectFromScreen:NSMakeRect(p.x, p.y, 0, 0)];
+	p = [view convertPoint:r.origin fromView:nil];
+	[session enumerateDraggingItemsWithOptions:0
+		forView:view
+		classes:[NSArray arrayWithObject:[NSPasteboardItem class]]
+		searchOptions:[NSDictionary dictionary]
+		usingBlock:^(NSDraggingItem *item, NSInteger i, BOOL *stop) {
+			[item setDraggingFrame:NSMakeRect(p.x - hotspot.x, p.y - hotspot.y, [image size].width, [image size].height)
+				contents:image];
+			*stop = YES;
+		}];
+	[image release];
+}
+
+// FilePromises; we pick the names, so dragWritePromiseIn() makes sure they don't replace anything
+- (NSArray *)tableView:(NSTableView *)view namesOfPromisedFilesDroppedAtDestination:(NSURL *)dest forDraggedRowsWithIndexes:(NSIndexSet *)rows
+{
+	NSMutableArray *names;
+	char *name;
+	intptr_t i;
+
+	names = [NSMutableArray array];
+	for (i = 0; i < self->npromises; i++) {
+		name = dragWritePromiseIn(i, (char *) [[dest path] fileSystemRepresentation]);
+		if (name != NULL) {
+			[names addObject:[NSString stringWithUTF8String:name]];
+			free(name);
+		}
+	}
+	return names;
+}
+
+- (void)tableView:(NSTableView *)view draggingSession:(NSDraggingSession *)session endedAtPoint:(NSPoint)p operation:(NSDragOperation)op
+{
+	goTableDataSource_dragEnded(self->gotable, (uintptr_t) op);
+}
+
 @end
 
 id newTable(void)
@@ -93,6 +159,7 @@ void tableAppendColumn(id t, intptr_t colnum, char *name, int type, BOOL editabl
 
 	c = [[goTableColumn alloc] initWithIdentifier:nil];
 	c->gocolnum = colnum;
+	c->gocoltype = type;
 	switch (type) {
 	case colTypeImage:
 		ic = [[NSImageCell alloc] initImageCell:nil];
@@ -193,3 +260,61 @@ void tableSelect(id table, intptr_t row)
 	if (row != -1)
 		[toNSTableView(table) selectRowIndexes:[NSIndexSet indexSetWithIndex:((NSUInteger) row)] byExtendingSelection:NO];
 }
+
+// the data goes on the pasteboard the same way areaStartDrag() puts it there, except that the files share the pasteboard with the rest, as there is only one row
+// dataimage and dragimage are +1 NSImages or nil; this releases dataimage, and dragimage is released when the drag begins
+// promises are offered the way NSTableView has always done it, with NSFilesPromisePboardType, which lists the extensions of the files
+void tableSetDragData(id table, id pasteboard, char **files, intptr_t nfiles, char *text, id dataimage, id dragimage, intptr_t hotx, intptr_t hoty, char **promises, intptr_t npromises, BOOL local, uintptr_t ops)
+{
+	NSTableView *t = toNSTableView(table);
+	NSPasteboard *pb = (NSPasteboard *) pasteboard;
+	goTableDataSource *model;
+	NSMutableArray *types;
+	NSMutableArray *paths;
+	NSMutableArray *extensions;
+	intptr_t i;
+
+	types = [NSMutableArray new];
+	if (nfiles != 0)
+		[types addObject:NSFilenamesPboardType];
+	if (text != NULL)
+		[types addObject:NSPasteboardTypeString];
+	if (dataimage != nil)
+		[types addObject:NSPasteboardTypeTIFF];
+	if (npromises != 0)
+		[types addObject:NSFilesPromisePboardType];
+	if (local)
+		[types addObject:localDragType];
+	[pb declareTypes:types owner:nil];
+	[types release];
+	if (nfiles != 0) {
+		paths = [NSMutableArray new];
+		for (i = 0; i < nfiles; i++)
+			[paths addObject:[NSString stringWithUTF8String:files[i]]];
+		[pb setPropertyList:paths forType:NSFilenamesPboardType];
+		[paths release];
+	}
+	if (text != NULL)
+		[pb setString:[NSString stringWithUTF8String:text] forType:NSPasteboardTypeString];
+	if (dataimage != nil) {
+		[pb setData:[((NSImage *) dataimage) TIFFRepresentation] forType:NSPasteboardTypeTIFF];
+		[dataimage release];
+	}
+	if (npromises != 0) {
+		extensions = [NSMutableArray new];
+		for (i = 0; i < npromises; i++)
+			[extensions addObject:[[NSString stringWithUTF8String:promises[i]] pathExtension]];
+		[pb setPropertyList:extensions forType:NSFilesPromisePboardType];
+		[extensions release];
+	}
+	if (local)
+		[pb setString:@"" forType:localDragType];
+	[t setDraggingSourceOperationMask:(NSDragOperation) ops forLocal:YES];
+	[t setDraggingSourceOperationMask:(NSDragOperation) ops forLocal:NO];
+	model = (goTableDataSource *) [t dataSource];
+	if (model->dragImage != nil)		// in case a drag never began after all
+		[model->dragImage release];
+	model->dragImage = (NSImage *) dragimage;
+	model->dragHotspot = NSMakePoint((CGFloat) hotx, (CGFloat) hoty);
+	model->npromises = npromises;
+}
@@ -17,6 +17,12 @@ void tableAppendColumn(GtkTreeView *table, gint index, gchar *name, GtkCellRende
 	gtk_tree_view_append_column(table, col);
 }
 
+// g_object_set() is variadic, so cgo can't call it directly
+void tableSetRendererEditable(GtkCellRenderer *renderer)
+{
+	g_object_set(renderer, "editable", TRUE, NULL);
+}
+
 /*
 how our GtkTreeIters are stored:
 	stamp: either GOOD_STAMP or BAD_STAMP
@@ -268,3 +274,35 @@ void tableUpdate(goTableModel *t, gint old, gint new)
 			g_signal_emit_by_name(t, "row-deleted", path);
 		}
 }
+
+// for Table.OnDragRow(); x and y are in bin window coordinates, which is what GtkTreeView's mouse events use for the rows
+gint tableRowAt(GtkTreeView *table, gint x, gint y)
+{
+	GtkTreePath *path;
+	gint row;
+
+	if (!gtk_tree_view_get_path_at_pos(table, x, y, &path, NULL, NULL, NULL))
+		return -1;
+	row = gtk_tree_path_get_indices(path)[0];
+	gtk_tree_path_free(path);
+	return row;
+}
+
+// this is what GtkTreeView itself shows for the drags it starts, with the mouse at the same place in the picture of the row as it was in the row
+void tableSetRowDragIcon(GtkTreeView *table, GdkDragContext *context, gint x, gint y)
+{
+	GtkTreePath *path;
+	gint celly;
+	cairo_surface_t *icon;
+	double sx, sy;
+
+	if (!gtk_tree_view_get_path_at_pos(table, x, y, &path, NULL, NULL, &celly))
+		return;
+	icon = gtk_tree_view_create_row_drag_icon(table, path);
+	gtk_tree_path_free(path);
+	cairo_surface_get_device_scale(icon, &sx, &sy);
+	// the + 1 is for the border around the icon
+	cairo_surface_set_device_offset(icon, -(x + 1) * sx, -(celly + 1) * sy);
+	gtk_drag_set_icon_surface(context, icon);
+	cairo_surface_destroy(icon);
+}
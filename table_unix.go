@@ -6,14 +6,18 @@ package ui
 
 import (
 	"fmt"
-	"reflect"
 	"unsafe"
 	"image"
 )
 
 // #include "gtk_unix.h"
 // extern void goTableModel_toggled(GtkCellRendererToggle *, gchar *, gpointer);
+// extern void goTableModel_edited(GtkCellRendererText *, gchar *, gchar *, gpointer);
 // extern void tableSelectionChanged(GtkTreeSelection *, gpointer);
+// extern gboolean tableQueryTooltip(GtkWidget *, gint, gint, gboolean, GtkTooltip *, gpointer);
+// extern gboolean tableButtonPress(GtkWidget *, GdkEvent *, gpointer);
+// extern gboolean tableButtonRelease(GtkWidget *, GdkEvent *, gpointer);
+// extern gboolean tableMotionNotify(GtkWidget *, GdkEvent *, gpointer);
 import "C"
 
 type table struct {
@@ -28,11 +32,18 @@ type table struct {
 
 	selected *event
 
+	// for OnDragRow(); GtkTreeView's own drag and drop works through GtkTreeDragSource and a fixed list of targets, which doesn't fit, so we start drags ourselves
+	drag        *dragSource
+	dragpending bool // the first button is down on a row, but the mouse hasn't moved far enough yet
+	dragx       C.gint
+	dragy       C.gint
+
 	// stuff required by GtkTreeModel
 	nColumns C.gint
 	old      C.gint
 	types    []C.GType
 	crtocol  map[*C.GtkCellRendererToggle]int
+	crttocol map[*C.GtkCellRendererText]int
 }
 
 var (
@@ -41,13 +52,14 @@ var (
 	attribActive = togstr("active")
 )
 
-func finishNewTable(b *tablebase, ty reflect.Type) Table {
+func finishNewTable(b *tablebase) Table {
 	widget := C.gtk_tree_view_new()
 	t := &table{
 		scroller:  newScroller(widget, true, true, false), // natively scrollable; has a border; no overlay
 		tablebase: b,
 		treeview:  (*C.GtkTreeView)(unsafe.Pointer(widget)),
 		crtocol:   make(map[*C.GtkCellRendererToggle]int),
+		crttocol:  make(map[*C.GtkCellRendererText]int),
 		selected:  newEvent(),
 	}
 	model := C.newTableModel(unsafe.Pointer(t))
@@ -59,24 +71,36 @@ func finishNewTable(b *tablebase, ty reflect.Type) Table {
 		"changed",
 		C.GCallback(C.tableSelectionChanged),
 		C.gpointer(unsafe.Pointer(t)))
+	setHoverPreviewTooltip(t.treeview)
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(t.treeview)),
+		"query-tooltip",
+		C.GCallback(C.tableQueryTooltip),
+		C.gpointer(unsafe.Pointer(t)))
+	t.drag = newDragSource(widget)
+	for _, c := range tableDragCallbacks {
+		g_signal_connect(
+			C.gpointer(unsafe.Pointer(t.treeview)),
+			c.name,
+			c.callback,
+			C.gpointer(unsafe.Pointer(t)))
+	}
 	C.gtk_tree_view_set_model(t.treeview, t.modelgtk)
-	for i := 0; i < ty.NumField(); i++ {
-		colname := ty.Field(i).Tag.Get("uicolumn")
-		if colname == "" {
-			colname = ty.Field(i).Name
-		}
-		cname := togstr(colname)
-		switch {
-		case ty.Field(i).Type == reflect.TypeOf((*image.RGBA)(nil)):
+	columns := b.buildColumns()
+	for i, col := range columns {
+		cname := togstr(col.Name)
+		switch col.Type {
+		case TableImage:
 			// can't use GDK_TYPE_PIXBUF here because it's a macro that expands to a function and cgo hates that
 			t.types = append(t.types, C.gdk_pixbuf_get_type())
 			C.tableAppendColumn(t.treeview, C.gint(i), cname,
 				C.gtk_cell_renderer_pixbuf_new(), attribPixbuf)
-		case ty.Field(i).Type.Kind() == reflect.Bool:
+		case TableCheckbox:
 			t.types = append(t.types, C.G_TYPE_BOOLEAN)
 			cr := C.gtk_cell_renderer_toggle_new()
 			crt := (*C.GtkCellRendererToggle)(unsafe.Pointer(cr))
 			t.crtocol[crt] = i
+			C.gtk_cell_renderer_toggle_set_activatable(crt, togbool(col.Editable))
 			g_signal_connect(C.gpointer(unsafe.Pointer(cr)),
 				"toggled",
 				C.GCallback(C.goTableModel_toggled),
@@ -85,20 +109,29 @@ func finishNewTable(b *tablebase, ty reflect.Type) Table {
 				cr, attribActive)
 		default:
 			t.types = append(t.types, C.G_TYPE_STRING)
+			cr := C.gtk_cell_renderer_text_new()
+			if col.Editable {
+				crt := (*C.GtkCellRendererText)(unsafe.Pointer(cr))
+				t.crttocol[crt] = i
+				C.tableSetRendererEditable(cr)
+				g_signal_connect(C.gpointer(unsafe.Pointer(cr)),
+					"edited",
+					C.GCallback(C.goTableModel_edited),
+					C.gpointer(unsafe.Pointer(t)))
+			}
 			C.tableAppendColumn(t.treeview, C.gint(i), cname,
-				C.gtk_cell_renderer_text_new(), attribText)
+				cr, attribText)
 		}
 		freegstr(cname) // free now (not deferred) to conserve memory
 	}
 	// and for some GtkTreeModel boilerplate
-	t.nColumns = C.gint(ty.NumField())
+	t.nColumns = C.gint(len(columns))
 	return t
 }
 
 func (t *table) Lock() {
 	t.tablebase.Lock()
-	d := reflect.Indirect(reflect.ValueOf(t.data))
-	t.old = C.gint(d.Len())
+	t.old = C.gint(t.tablebase.model.NumRows())
 }
 
 func (t *table) Unlock() {
@@ -109,8 +142,7 @@ func (t *table) Unlock() {
 		Do(func() {
 			t.RLock()
 			defer t.RUnlock()
-			d := reflect.Indirect(reflect.ValueOf(t.data))
-			new := C.gint(d.Len())
+			new := C.gint(t.tablebase.model.NumRows())
 			C.tableUpdate(t.model, t.old, new)
 		})
 	}()
@@ -168,22 +200,21 @@ func goTableModel_do_get_value(data unsafe.Pointer, row C.gint, col C.gint, valu
 	t := (*table)(data)
 	t.RLock()
 	defer t.RUnlock()
-	d := reflect.Indirect(reflect.ValueOf(t.data))
-	datum := d.Index(int(row)).Field(int(col))
-	switch {
-	case datum.Type() == reflect.TypeOf((*image.RGBA)(nil)):
-		d := datum.Interface().(*image.RGBA)
+	datum := t.tablebase.model.CellValue(int(row), int(col))
+	switch t.columns[col].Type {
+	case TableImage:
+		d := toRGBA(datum.(image.Image))
 		pixbuf := toIconSizedGdkPixbuf(d)
 		C.g_value_init(value, C.gdk_pixbuf_get_type())
 		object := C.gpointer(unsafe.Pointer(pixbuf))
 		// use g_value_take_object() so the GtkTreeView becomes the pixbuf's owner
 		C.g_value_take_object(value, object)
-	case datum.Kind() == reflect.Bool:
-		d := datum.Interface().(bool)
+	case TableCheckbox:
+		d := datum.(bool)
 		C.g_value_init(value, C.G_TYPE_BOOLEAN)
 		C.g_value_set_boolean(value, togbool(d))
 	default:
-		s := fmt.Sprintf("%v", datum)
+		s := tableCellText(datum)
 		str := togstr(s)
 		defer freegstr(str)
 		C.g_value_init(value, C.G_TYPE_STRING)
@@ -196,8 +227,17 @@ func goTableModel_getRowCount(data unsafe.Pointer) C.gint {
 	t := (*table)(data)
 	t.RLock()
 	defer t.RUnlock()
-	d := reflect.Indirect(reflect.ValueOf(t.data))
-	return C.gint(d.Len())
+	return C.gint(t.tablebase.model.NumRows())
+}
+
+func (t *table) rowFromPathString(pathstr *C.gchar, what string) int {
+	path := C.gtk_tree_path_new_from_string(pathstr)
+	defer C.gtk_tree_path_free(path)
+	if len := C.gtk_tree_path_get_depth(path); len != 1 {
+		panic(fmt.Errorf("invalid path of depth %d given to %s", len, what))
+	}
+	// dereference return value to get our sole member
+	return int(*C.gtk_tree_path_get_indices(path))
 }
 
 //export goTableModel_toggled
@@ -205,16 +245,20 @@ func goTableModel_toggled(cr *C.GtkCellRendererToggle, pathstr *C.gchar, data C.
 	t := (*table)(unsafe.Pointer(data))
 	t.Lock()
 	defer t.Unlock()
-	path := C.gtk_tree_path_new_from_string(pathstr)
-	if len := C.gtk_tree_path_get_depth(path); len != 1 {
-		panic(fmt.Errorf("invalid path of depth %d given to goTableModel_toggled()", len))
-	}
-	// dereference return value to get our sole member
-	row := *C.gtk_tree_path_get_indices(path)
+	row := t.rowFromPathString(pathstr, "goTableModel_toggled()")
 	col := t.crtocol[cr]
-	d := reflect.Indirect(reflect.ValueOf(t.data))
-	datum := d.Index(int(row)).Field(int(col))
-	datum.SetBool(!datum.Bool())
+	old := t.tablebase.model.CellValue(row, col).(bool)
+	t.tablebase.model.SetCellValue(row, col, !old)
+}
+
+//export goTableModel_edited
+func goTableModel_edited(cr *C.GtkCellRendererText, pathstr *C.gchar, newtext *C.gchar, data C.gpointer) {
+	t := (*table)(unsafe.Pointer(data))
+	t.Lock()
+	defer t.Unlock()
+	row := t.rowFromPathString(pathstr, "goTableModel_edited()")
+	col := t.crttocol[cr]
+	t.tablebase.model.SetCellValue(row, col, fromgstr(newtext))
 }
 
 //export tableSelectionChanged
@@ -222,3 +266,70 @@ func tableSelectionChanged(sel *C.GtkTreeSelection, data C.gpointer) {
 	t := (*table)(unsafe.Pointer(data))
 	t.selected.fire()
 }
+
+//export tableQueryTooltip
+func tableQueryTooltip(widget *C.GtkWidget, x C.gint, y C.gint, keyboard C.gboolean, tooltip *C.GtkTooltip, data C.gpointer) C.gboolean {
+	t := (*table)(unsafe.Pointer(data))
+	if t.hoverpreview == nil {
+		return C.FALSE
+	}
+	path := hoverPreviewRow(t.treeview, x, y, keyboard)
+	if path == nil {
+		return C.FALSE
+	}
+	defer C.gtk_tree_path_free(path)
+	row := int(*C.gtk_tree_path_get_indices(path))
+	return showHoverPreview(t.treeview, tooltip, path, t.firePreview(row))
+}
+
+var tableDragCallbacks = []struct {
+	name     string
+	callback C.GCallback
+}{
+	{"button-press-event", C.GCallback(C.tableButtonPress)},
+	{"button-release-event", C.GCallback(C.tableButtonRelease)},
+	{"motion-notify-event", C.GCallback(C.tableMotionNotify)},
+}
+
+//export tableButtonPress
+func tableButtonPress(widget *C.GtkWidget, event *C.GdkEvent, data C.gpointer) C.gboolean {
+	t := (*table)(unsafe.Pointer(data))
+	e := (*C.GdkEventButton)(unsafe.Pointer(event))
+	t.dragpending = false
+	// the column headers have windows of their own
+	if t.dragrow != nil && e._type == C.GDK_BUTTON_PRESS && e.button == 1 && e.window == C.gtk_tree_view_get_bin_window(t.treeview) {
+		t.dragpending = true
+		t.dragx = C.gint(e.x)
+		t.dragy = C.gint(e.y)
+	}
+	return continueEventChain // let the GtkTreeView select the row
+}
+
+//export tableButtonRelease
+func tableButtonRelease(widget *C.GtkWidget, event *C.GdkEvent, data C.gpointer) C.gboolean {
+	t := (*table)(unsafe.Pointer(data))
+	t.dragpending = false
+	return continueEventChain
+}
+
+//export tableMotionNotify
+func tableMotionNotify(widget *C.GtkWidget, event *C.GdkEvent, data C.gpointer) C.gboolean {
+	t := (*table)(unsafe.Pointer(data))
+	e := (*C.GdkEventMotion)(unsafe.Pointer(event))
+	if !t.dragpending || (e.state&C.GDK_BUTTON1_MASK) == 0 {
+		return continueEventChain
+	}
+	if C.gtk_drag_check_threshold(widget, t.dragx, t.dragy, C.gint(e.x), C.gint(e.y)) == C.FALSE {
+		return continueEventChain
+	}
+	t.dragpending = false
+	ddata, allowed, done := t.fireDragRow(int(C.tableRowAt(t.treeview, t.dragx, t.dragy)))
+	if allowed == 0 {
+		return continueEventChain
+	}
+	context := t.drag.begin(widget, ddata, allowed, done, 1, event)
+	if context != nil && ddata.DragImage == nil {
+		C.tableSetRowDragIcon(t.treeview, context, t.dragx, t.dragy)
+	}
+	return C.TRUE // the drag has the mouse now
+}
@@ -13,11 +13,74 @@ void doInitTable(void)
 	initTable(xpanic, fv__TrackMouseEvent);
 }
 
+// for Table.OnDragRow(); as with the hover preview, there's only one mouse, so this is shared
+static HWND dragTable = NULL;		// the Table the first button went down on a row of, until the mouse moves far enough to start a drag or the button goes up
+static POINT dragStart;
+static intptr_t dragRow;
+
+// the rectangle the mouse can move in without starting a drag is the same one DragDetect() uses
+static BOOL dragThresholdPassed(LPARAM lParam)
+{
+	int dx, dy;
+
+	dx = GET_X_LPARAM(lParam) - dragStart.x;
+	dy = GET_Y_LPARAM(lParam) - dragStart.y;
+	if (dx < 0)
+		dx = -dx;
+	if (dy < 0)
+		dy = -dy;
+	return dx > GetSystemMetrics(SM_CXDRAG) / 2 || dy > GetSystemMetrics(SM_CYDRAG) / 2;
+}
+
+// the Table window class doesn't have CS_DBLCLKS, so double-clicks (which start in-place editing) are found by hand, the way the system would
+static HWND clickTable = NULL;
+static struct rowcol clickCell;
+static POINT clickPoint;
+static LONG clickTime;
+
+static BOOL isDoubleClick(HWND hwnd, struct rowcol rc, LPARAM lParam)
+{
+	int dx, dy;
+	BOOL dbl;
+
+	dx = GET_X_LPARAM(lParam) - clickPoint.x;
+	dy = GET_Y_LPARAM(lParam) - clickPoint.y;
+	if (dx < 0)
+		dx = -dx;
+	if (dy < 0)
+		dy = -dy;
+	dbl = clickTable == hwnd &&
+		rc.row == clickCell.row && rc.column == clickCell.column &&
+		(DWORD) (GetMessageTime() - clickTime) <= GetDoubleClickTime() &&
+		dx <= GetSystemMetrics(SM_CXDOUBLECLK) / 2 && dy <= GetSystemMetrics(SM_CYDOUBLECLK) / 2;
+	// a third click starts over rather than making a second double-click
+	clickTable = NULL;
+	if (!dbl && rc.row != -1) {
+		clickTable = hwnd;
+		clickCell = rc;
+		clickPoint.x = GET_X_LPARAM(lParam);
+		clickPoint.y = GET_Y_LPARAM(lParam);
+		clickTime = GetMessageTime();
+	}
+	return dbl;
+}
+
+// the edit control is the only child of the Table that takes the focus, so taking the focus back ends in-place editing; see tableEditSubProc()
+static void tableLeaveEdit(HWND hwnd)
+{
+	if (IsChild(hwnd, GetFocus()))
+		SetFocus(hwnd);
+}
+
 static LRESULT CALLBACK tableSubProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM lParam, UINT_PTR id, DWORD_PTR data)
 {
 	NMHDR *nmhdr = (NMHDR *) lParam;
 	tableNM *tnm = (tableNM *) lParam;
 	void *gotable = (void *) data;
+	struct table *t;
+	struct rowcol rc;
+	intptr_t row, column;
+	LRESULT lResult;
 
 	switch (uMsg) {
 	case msgNOTIFY:
@@ -42,7 +105,73 @@ static LRESULT CALLBACK tableSubProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM
 			tableStopColumnAutosize(t->gotable);
 		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
 */
+	// see preview_windows.c
+	case WM_MOUSEMOVE:
+		if (dragTable == hwnd && (wParam & MK_LBUTTON) != 0 && dragThresholdPassed(lParam)) {
+			dragTable = NULL;
+			previewCancel(hwnd);
+			// this doesn't return until the drag is over
+			tableDragRow(gotable, dragRow);
+			return 0;
+		}
+		t = (struct table *) GetWindowLongPtrW(hwnd, GWLP_USERDATA);
+		rc = lParamToRowColumn(t, lParam);
+		previewMouseMove(hwnd, rc.row);
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_MOUSEHOVER:
+		row = previewHoveredItem(hwnd);
+		if (row != -1)
+			tableHoverPreview(gotable, row);
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_LBUTTONDOWN:
+		previewCancel(hwnd);
+		tableLeaveEdit(hwnd);
+		dragTable = NULL;
+		t = (struct table *) GetWindowLongPtrW(hwnd, GWLP_USERDATA);
+		rc = lParamToRowColumn(t, lParam);
+		if (isDoubleClick(hwnd, rc, lParam)) {
+			// let the Table select the cell first
+			lResult = (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+			tableStartEdit(gotable, rc.row, rc.column);
+			return lResult;
+		}
+		if (rc.row != -1) {
+			dragTable = hwnd;
+			dragStart.x = GET_X_LPARAM(lParam);
+			dragStart.y = GET_Y_LPARAM(lParam);
+			dragRow = rc.row;
+		}
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_LBUTTONUP:
+		dragTable = NULL;
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_KEYDOWN:
+		previewCancel(hwnd);
+		if (wParam == VK_F2) {
+			SendMessageW(hwnd, tableGetSelection, (WPARAM) (&row), (LPARAM) (&column));
+			tableStartEdit(gotable, row, column);
+			return 0;
+		}
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	// the edit control would be left behind by scrolling, so these end in-place editing
+	case WM_RBUTTONDOWN:
+	case WM_MOUSEWHEEL:
+	case WM_VSCROLL:
+	case WM_HSCROLL:
+		tableLeaveEdit(hwnd);
+		// fall through
+	case WM_MOUSELEAVE:
+		previewCancel(hwnd);
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_SIZE:
+		tableLeaveEdit(hwnd);
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
 	case WM_NCDESTROY:
+		previewCancel(hwnd);
+		if (dragTable == hwnd)
+			dragTable = NULL;
+		if (clickTable == hwnd)
+			clickTable = NULL;
 		if ((*fv_RemoveWindowSubclass)(hwnd, tableSubProc, id) == FALSE)
 			xpanic("error removing Table subclass (which was for its own event handler)", GetLastError());
 		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
@@ -59,6 +188,86 @@ void setTableSubclass(HWND hwnd, void *data)
 		xpanic("error subclassing Table to give it its own event handler", GetLastError());
 }
 
+// the edit control for in-place editing; like the Area TextField, it stays around hidden between uses
+static LRESULT CALLBACK tableEditSubProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM lParam, UINT_PTR id, DWORD_PTR data)
+{
+	MSG *msg = (MSG *) lParam;
+
+	switch (uMsg) {
+	case WM_GETDLGCODE:
+		// otherwise IsDialogMessage() keeps Enter and Escape for itself
+		if (msg != NULL && msg->message == WM_KEYDOWN && (msg->wParam == VK_RETURN || msg->wParam == VK_ESCAPE))
+			return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam) | DLGC_WANTALLKEYS;
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_KEYDOWN:
+		switch (wParam) {
+		case VK_ESCAPE:
+			tableEditDone((void *) data, FALSE);
+			// fall through
+		case VK_RETURN:
+			// this ends editing through WM_KILLFOCUS below
+			SetFocus(GetParent(hwnd));
+			return 0;
+		}
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_CHAR:
+		// a single-line edit control beeps at these, as it has no use for them
+		if (wParam == L'\r' || wParam == 0x1B)
+			return 0;
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_KILLFOCUS:
+		ShowWindow(hwnd, SW_HIDE);
+		tableEditDone((void *) data, TRUE);
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_NCDESTROY:
+		if ((*fv_RemoveWindowSubclass)(hwnd, tableEditSubProc, id) == FALSE)
+			xpanic("error removing Table edit control subclass (which was for in-place editing)", GetLastError());
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	default:
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	}
+	xmissedmsg("Table edit control", "tableEditSubProc()", uMsg);
+	return 0;		// unreached
+}
+
+HWND newTableEdit(HWND table, void *gotable)
+{
+	HWND edit;
+
+	// WS_BORDER rather than WS_EX_CLIENTEDGE; the cell is too small for the latter
+	edit = CreateWindowExW(0,
+		L"edit", L"",
+		ES_AUTOHSCROLL | ES_LEFT | WS_BORDER | WS_CHILD,
+		0, 0, 0, 0,
+		table, NULL, hInstance, NULL);
+	if (edit == NULL)
+		xpanic("error making Table edit control", GetLastError());
+	if ((*fv_SetWindowSubclass)(edit, tableEditSubProc, 0, (DWORD_PTR) gotable) == FALSE)
+		xpanic("error subclassing Table edit control to give it its own event handler", GetLastError());
+	return edit;
+}
+
+// returns FALSE if the cell isn't visible
+BOOL tableOpenEdit(HWND hwnd, HWND edit, intptr_t row, intptr_t column)
+{
+	struct table *t;
+	struct rowcol rc;
+	RECT r;
+
+	t = (struct table *) GetWindowLongPtrW(hwnd, GWLP_USERDATA);
+	rc.row = row;
+	rc.column = column;
+	if (!rowColumnToClientRect(t, rc, &r))
+		return FALSE;
+	if (MoveWindow(edit, r.left, r.top, r.right - r.left, r.bottom - r.top, TRUE) == 0)
+		xpanic("error moving Table edit control over cell", GetLastError());
+	SendMessageW(edit, EM_SETSEL, 0, (LPARAM) -1);
+	ShowWindow(edit, SW_SHOW);
+	// don't check SetFocus()'s error; see wintable/select.h
+	SetFocus(edit);
+	return TRUE;
+}
+
 // TODO rename all of these functions to start with gotable, and all the exported ones in Go too
 void gotableSetRowCount(HWND hwnd, intptr_t count)
 {
@@ -86,3 +295,22 @@ void tableSelectItem(HWND hwnd, intptr_t index)
 {
 	SendMessageW(hwnd, tableSetSelection, (WPARAM) (&index), (LPARAM) NULL);
 }
+
+// the rectangle of the whole visible part of the row; returns FALSE if the row isn't visible
+BOOL tableRowRect(HWND hwnd, intptr_t row, RECT *r)
+{
+	struct table *t;
+	struct rowcol rc;
+	RECT client;
+
+	t = (struct table *) GetWindowLongPtrW(hwnd, GWLP_USERDATA);
+	rc.row = row;
+	rc.column = 0;
+	if (!rowColumnToClientRect(t, rc, r))
+		return FALSE;
+	if (GetClientRect(hwnd, &client) == 0)
+		xpanic("error getting Table client rect for row rect", GetLastError());
+	r->left = client.left;
+	r->right = client.right;
+	return TRUE;
+}
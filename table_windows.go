@@ -10,7 +10,6 @@ package ui
 
 import (
 	"fmt"
-	"reflect"
 	"unsafe"
 	"sync"
 	"image"
@@ -28,11 +27,18 @@ type table struct {
 	chainresize		func(x int, y int, width int, height int, d *sizing)
 	free			map[C.uintptr_t]bool
 	freeLock		sync.Mutex
+
+	// for in-place editing of TableText columns
+	edit       C.HWND
+	editing    bool
+	editRow    int
+	editColumn int
 }
 
-func finishNewTable(b *tablebase, ty reflect.Type) Table {
+func finishNewTable(b *tablebase) Table {
+	// WS_CLIPCHILDREN so the Table doesn't draw over the edit control used for in-place editing
 	hwnd := C.newControl(C.xtableWindowClass,
-		C.WS_HSCROLL|C.WS_VSCROLL|C.WS_TABSTOP,
+		C.WS_HSCROLL|C.WS_VSCROLL|C.WS_TABSTOP|C.WS_CLIPCHILDREN,
 		C.WS_EX_CLIENTEDGE)		// WS_EX_CLIENTEDGE without WS_BORDER will show the canonical visual styles border (thanks to MindChild in irc.efnet.net/#winprog)
 	t := &table{
 		controlSingleHWND:		newControlSingleHWND(hwnd),
@@ -46,23 +52,23 @@ func finishNewTable(b *tablebase, ty reflect.Type) Table {
 	C.setTableSubclass(t.hwnd, unsafe.Pointer(t))
 	// TODO listview didn't need this; someone mentioned (TODO) it uses the small caption font???
 	C.controlSetControlFont(t.hwnd)
-	for i := 0; i < ty.NumField(); i++ {
+	// wintable can't edit cells itself, so we put this over the cell instead; see tableStartEdit()
+	t.edit = C.newTableEdit(t.hwnd, unsafe.Pointer(t))
+	C.controlSetControlFont(t.edit)
+	columns := b.buildColumns()
+	for _, col := range columns {
 		coltype := C.WPARAM(C.tableColumnText)
-		switch {
-		case ty.Field(i).Type == reflect.TypeOf((*image.RGBA)(nil)):
+		switch col.Type {
+		case TableImage:
 			coltype = C.tableColumnImage
-		case ty.Field(i).Type.Kind() == reflect.Bool:
+		case TableCheckbox:
 			coltype = C.tableColumnCheckbox
 		}
-		colname := ty.Field(i).Tag.Get("uicolumn")
-		if colname == "" {
-			colname = ty.Field(i).Name
-		}
-		ccolname := toUTF16(colname)
+		ccolname := toUTF16(col.Name)
 		C.SendMessageW(t.hwnd, C.tableAddColumn, coltype, C.LPARAM(uintptr(unsafe.Pointer(ccolname))))
 		// TODO free ccolname
 	}
-	t.colcount = C.int(ty.NumField())
+	t.colcount = C.int(len(columns))
 	return t
 }
 
@@ -74,7 +80,7 @@ func (t *table) Unlock() {
 		Do(func() {
 			t.RLock()
 			defer t.RUnlock()
-			C.gotableSetRowCount(t.hwnd, C.intptr_t(reflect.Indirect(reflect.ValueOf(t.data)).Len()))
+			C.gotableSetRowCount(t.hwnd, C.intptr_t(t.model.NumRows()))
 		})
 	}()
 }
@@ -100,24 +106,23 @@ func tableGetCell(data unsafe.Pointer, tnm *C.tableNM) C.LRESULT {
 	t := (*table)(data)
 	t.RLock()
 	defer t.RUnlock()
-	d := reflect.Indirect(reflect.ValueOf(t.data))
-	datum := d.Index(int(tnm.row)).Field(int(tnm.column))
-	switch {
-	case datum.Type() == reflect.TypeOf((*image.RGBA)(nil)):
-		i := datum.Interface().(*image.RGBA)
+	datum := t.model.CellValue(int(tnm.row), int(tnm.column))
+	switch t.columns[tnm.column].Type {
+	case TableImage:
+		i := toRGBA(datum.(image.Image))
 		hbitmap := C.toBitmap(unsafe.Pointer(i), C.intptr_t(i.Rect.Dx()), C.intptr_t(i.Rect.Dy()))
 		bitmap := C.uintptr_t(uintptr(unsafe.Pointer(hbitmap)))
 		t.freeLock.Lock()
 		t.free[bitmap] = true		// bitmap freed with C.freeBitmap()
 		t.freeLock.Unlock()
 		return C.LRESULT(bitmap)
-	case datum.Kind() == reflect.Bool:
-		if datum.Bool() == true {
+	case TableCheckbox:
+		if datum.(bool) == true {
 			return C.TRUE
 		}
 		return C.FALSE
 	default:
-		s := fmt.Sprintf("%v", datum)
+		s := tableCellText(datum)
 		text := C.uintptr_t(uintptr(unsafe.Pointer(toUTF16(s))))
 		t.freeLock.Lock()
 		t.free[text] = false		// text freed with C.free()
@@ -170,15 +175,59 @@ func tableToggled(data unsafe.Pointer, row C.intptr_t, col C.intptr_t) {
 	t := (*table)(data)
 	t.Lock()
 	defer t.Unlock()
-	d := reflect.Indirect(reflect.ValueOf(t.data))
-	datum := d.Index(int(row)).Field(int(col))
-	if datum.Kind() == reflect.Bool {
-		datum.SetBool(!datum.Bool())
+	if t.columns[col].Type == TableCheckbox {
+		if !t.columns[col].Editable {
+			return
+		}
+		old := t.model.CellValue(int(row), int(col)).(bool)
+		t.model.SetCellValue(int(row), int(col), !old)
 		return
 	}
 	panic(fmt.Errorf("tableSetHot() on non-checkbox at (%d, %d)", row, col))
 }
 
+// this is called on a double-click or F2; only TableText columns can be edited in place (TableCheckbox columns are toggled by tableToggled() instead)
+//export tableStartEdit
+func tableStartEdit(data unsafe.Pointer, row C.intptr_t, col C.intptr_t) {
+	t := (*table)(data)
+	if row < 0 || col < 0 {		// no selection
+		return
+	}
+	if t.columns[col].Type != TableText || !t.columns[col].Editable {
+		return
+	}
+	t.RLock()
+	text := tableCellText(t.model.CellValue(int(row), int(col)))
+	t.RUnlock()
+	C.setWindowText(t.edit, toUTF16(text))
+	if C.tableOpenEdit(t.hwnd, t.edit, row, col) == C.FALSE {		// cell scrolled out of view
+		return
+	}
+	t.editing = true
+	t.editRow = int(row)
+	t.editColumn = int(col)
+}
+
+// this is called when the edit control loses the focus, which is also how Enter ends editing; Escape calls this with commit false first
+//export tableEditDone
+func tableEditDone(data unsafe.Pointer, commit C.BOOL) {
+	t := (*table)(data)
+	if !t.editing {
+		return
+	}
+	t.editing = false
+	if commit == C.FALSE {
+		return
+	}
+	text := getWindowText(t.edit)
+	t.Lock()
+	defer t.Unlock()
+	if t.editRow >= t.model.NumRows() {		// row deleted while editing
+		return
+	}
+	t.model.SetCellValue(t.editRow, t.editColumn, text)
+}
+
 //export tableSelectionChanged
 func tableSelectionChanged(data unsafe.Pointer) {
 	t := (*table)(data)
@@ -202,3 +251,28 @@ func (t *table) xresize(x int, y int, width int, height int, d *sizing) {
 	defer t.RUnlock()
 	t.autoresize()
 }
+
+//export tableDragRow
+func tableDragRow(data unsafe.Pointer, row C.intptr_t) {
+	t := (*table)(data)
+	ddata, allowed, done := t.fireDragRow(int(row))
+	if allowed == 0 {
+		return
+	}
+	op := startDrag(ddata, allowed, C.MK_LBUTTON)
+	if done != nil {
+		done(op)
+	}
+}
+
+//export tableHoverPreview
+func tableHoverPreview(data unsafe.Pointer, row C.intptr_t) {
+	var r C.RECT
+
+	t := (*table)(data)
+	img := t.firePreview(int(row))
+	if img == nil || C.tableRowRect(t.hwnd, row, &r) == C.FALSE {
+		return
+	}
+	showPreview(t.hwnd, &r, img)
+}
@@ -0,0 +1,122 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// TextAlign is how the lines of a TextLayout line up with each other.
+type TextAlign uint
+
+const (
+	TextAlignLeft TextAlign = iota
+	TextAlignCenter
+	TextAlignRight
+)
+
+// TextLayout is a block of text laid out in a font, ready to be measured and drawn into the image an AreaHandler's Paint returns.
+// The system does the work, so the text is shaped properly (ligatures, right-to-left scripts, combining characters, and so on), with Pango on GTK+, GDI on Windows, and Core Text on Mac OS X.
+// As such, the same TextLayout can measure and look slightly different from one system to the next.
+// TextLayouts must only be used on the main goroutine, as with Paint.
+type TextLayout struct {
+	text  string
+	font  FontDescriptor
+	width int
+	align TextAlign
+
+	// filled in by layoutText(), which is defined on each backend, when first needed
+	laidout bool
+	lines   []TextLine
+	size    image.Point
+}
+
+// TextLine describes one line of a TextLayout.
+// All positions are in pixels relative to the top-left corner of the TextLayout.
+type TextLine struct {
+	// Start and End are the byte offsets in the text of the line, which is text[Start:End].
+	// A line ends either where the text wraps or at a newline; in the latter case, End is the offset of the newline, which is part of neither line.
+	Start int
+	End   int
+
+	// X and Y are the top-left corner of the line, and Width and Height its size.
+	// X is 0 unless the TextLayout is aligned other than left.
+	X      int
+	Y      int
+	Width  int
+	Height int
+
+	// Baseline is the distance from Y to the baseline the characters sit on.
+	Baseline int
+}
+
+// NewTextLayout lays out text in font.
+// If width is not negative, lines longer than width pixels are wrapped between words (or between characters, for words that don't fit on a line of their own); otherwise, the text is only broken at newlines.
+// An empty font.Family is the system's default font, and a zero font.Size is 12 points.
+func NewTextLayout(text string, font FontDescriptor, width int) *TextLayout {
+	if font.Size <= 0 {
+		font.Size = 12
+	}
+	return &TextLayout{
+		text:  text,
+		font:  font,
+		width: width,
+	}
+}
+
+// SetAlign sets how the lines of the TextLayout line up; the default is TextAlignLeft.
+// The lines are aligned within width, if given to NewTextLayout, or within the longest line otherwise.
+func (t *TextLayout) SetAlign(align TextAlign) {
+	t.align = align
+	t.laidout = false
+}
+
+func (t *TextLayout) layout() {
+	if !t.laidout {
+		t.lines, t.size = layoutText(t)
+		t.laidout = true
+	}
+}
+
+// Size returns the size of the laid out text in pixels.
+// The width is the width passed to NewTextLayout, if not negative, or the width of the longest line otherwise.
+func (t *TextLayout) Size() (width int, height int) {
+	t.layout()
+	return t.size.X, t.size.Y
+}
+
+// Lines returns the lines of the TextLayout, from top to bottom.
+// Empty text has one line.
+func (t *TextLayout) Lines() []TextLine {
+	t.layout()
+	lines := make([]TextLine, len(t.lines))
+	copy(lines, t.lines)
+	return lines
+}
+
+// Draw draws the TextLayout into dst with its top-left corner at pt, in color c, blending it with what is already there.
+// Only the part of the text inside dst.Rect is drawn, so Paint can draw straight into the image it returns; remember that image's Rect starts at cliprect.Min, not 0,0.
+func (t *TextLayout) Draw(dst *image.RGBA, pt image.Point, c color.Color) {
+	t.layout()
+	r := image.Rectangle{pt, pt.Add(t.size)}
+	if !r.Overlaps(dst.Rect) {
+		return
+	}
+	mask := renderText(t)
+	draw.DrawMask(dst, r, image.NewUniform(c), image.ZP, mask, image.ZP, draw.Over)
+}
+
+// for backends whose systems count in UTF-16 code units: utf16Offsets(s)[i] is the byte offset in s of UTF-16 code unit i
+// there is one more entry than there are code units, for the end of the string
+func utf16Offsets(s string) []int {
+	offsets := make([]int, 0, len(s)+1)
+	for i, r := range s {
+		offsets = append(offsets, i)
+		if r >= 0x10000 { // surrogate pair
+			offsets = append(offsets, i)
+		}
+	}
+	return append(offsets, len(s))
+}
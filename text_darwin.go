@@ -0,0 +1,84 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"image"
+	"math"
+	"strings"
+	"unsafe"
+)
+
+// #include "objc_darwin.h"
+import "C"
+
+func newTextFrame(t *TextLayout) *C.struct_textFrame {
+	cfamily := (*C.char)(nil)
+	if t.font.Family != "" {
+		cfamily = C.CString(t.font.Family)
+		defer C.free(unsafe.Pointer(cfamily))
+	}
+	font := C.newFont(cfamily, C.double(t.font.Size), C.intptr_t(t.font.weight()), toBOOL(t.font.Italic))
+	ctext := C.CString(t.text)
+	defer C.free(unsafe.Pointer(ctext))
+	f := new(C.struct_textFrame)
+	C.newTextFrame(f, ctext, font, C.intptr_t(t.width), C.intptr_t(t.align))
+	return f
+}
+
+func layoutText(t *TextLayout) (lines []TextLine, size image.Point) {
+	var l C.struct_textLine
+
+	f := newTextFrame(t)
+	defer C.freeTextFrame(f)
+	offsets := utf16Offsets(t.text)
+	for i := C.intptr_t(0); i < f.nLines; i++ {
+		C.textFrameLine(f, i, &l)
+		line := TextLine{
+			Start:    offsets[l.start],
+			End:      offsets[l.end],
+			X:        int(l.x),
+			Y:        int(math.Floor(float64(l.y))),
+			Width:    int(math.Ceil(float64(l.width))),
+			Height:   int(math.Ceil(float64(l.height))),
+			Baseline: int(math.Ceil(float64(l.baseline))),
+		}
+		// Core Text includes the newline in the line; TextLine doesn't
+		if line.End > line.Start && t.text[line.End-1] == '\n' {
+			line.End--
+		}
+		lines = append(lines, line)
+	}
+	size = image.Pt(int(f.width), int(f.height))
+	// Core Text has no line for empty text or after a final newline, but the other systems do
+	if len(lines) == 0 || strings.HasSuffix(t.text, "\n") {
+		x := 0
+		switch t.align {
+		case TextAlignCenter:
+			x = size.X / 2
+		case TextAlignRight:
+			x = size.X
+		}
+		lines = append(lines, TextLine{
+			Start:    len(t.text),
+			End:      len(t.text),
+			X:        x,
+			Y:        size.Y,
+			Height:   int(f.lineHeight),
+			Baseline: int(f.ascent),
+		})
+		size.Y += int(f.lineHeight)
+	}
+	return lines, size
+}
+
+func renderText(t *TextLayout) *image.Alpha {
+	mask := image.NewAlpha(image.Rect(0, 0, t.size.X, t.size.Y))
+	if t.size.X == 0 || t.size.Y == 0 {
+		return mask
+	}
+	f := newTextFrame(t)
+	defer C.freeTextFrame(f)
+	C.renderTextFrame(f, unsafe.Pointer(&mask.Pix[0]), C.intptr_t(t.size.X), C.intptr_t(t.size.Y), C.intptr_t(mask.Stride))
+	return mask
+}
@@ -0,0 +1,97 @@
+// 15 october 2026
+
+#import "objc_darwin.h"
+#import <Cocoa/Cocoa.h>
+#import <ApplicationServices/ApplicationServices.h>
+
+#define toNSFont(x) ((NSFont *) (x))
+
+static const CTTextAlignment textAligns[] = {
+	kCTLeftTextAlignment,			// TextAlignLeft
+	kCTCenterTextAlignment,		// TextAlignCenter
+	kCTRightTextAlignment,		// TextAlignRight
+};
+
+// width is -1 for no wrapping
+void newTextFrame(struct textFrame *t, char *text, id font, intptr_t width, intptr_t align)
+{
+	NSDictionary *attrs;
+	NSAttributedString *str;
+	CTFramesetterRef framesetter;
+	CGSize size;
+	CGMutablePathRef path;
+	CTTextAlignment ctalign;
+	CTParagraphStyleSetting setting;
+	CTParagraphStyleRef ctstyle;
+
+	ctalign = textAligns[align];
+	setting.spec = kCTParagraphStyleSpecifierAlignment;
+	setting.valueSize = sizeof (CTTextAlignment);
+	setting.value = &ctalign;
+	ctstyle = CTParagraphStyleCreate(&setting, 1);
+	attrs = [NSDictionary dictionaryWithObjectsAndKeys:
+		toNSFont(font), (id) kCTFontAttributeName,
+		(id) ctstyle, (id) kCTParagraphStyleAttributeName,
+		nil];
+	str = [[NSAttributedString alloc] initWithString:[NSString stringWithUTF8String:text] attributes:attrs];
+	framesetter = CTFramesetterCreateWithAttributedString((CFAttributedStringRef) str);
+	size = CTFramesetterSuggestFrameSizeWithConstraints(framesetter, CFRangeMake(0, 0), NULL,
+		CGSizeMake((width < 0) ? CGFLOAT_MAX : (CGFloat) width, CGFLOAT_MAX), NULL);
+	t->width = ceil(size.width);
+	if (width >= 0)
+		t->width = (double) width;
+	t->height = ceil(size.height);
+	path = CGPathCreateMutable();
+	CGPathAddRect(path, NULL, CGRectMake(0, 0, (CGFloat) t->width, (CGFloat) t->height));
+	t->frame = (void *) CTFramesetterCreateFrame(framesetter, CFRangeMake(0, 0), path, NULL);
+	t->nLines = (intptr_t) CFArrayGetCount(CTFrameGetLines((CTFrameRef) t->frame));
+	t->ascent = ceil([toNSFont(font) ascender]);
+	t->lineHeight = t->ascent + ceil(-[toNSFont(font) descender]) + ceil([toNSFont(font) leading]);
+	CGPathRelease(path);
+	CFRelease(framesetter);
+	[str release];
+	CFRelease(ctstyle);
+}
+
+// Core Text's origin is the bottom-left corner and its line origins are on the baseline; TextLine wants the top-left corner of each line
+void textFrameLine(struct textFrame *t, intptr_t i, struct textLine *l)
+{
+	CTLineRef line;
+	CGPoint origin;
+	CGFloat ascent, descent, leading;
+	double width;
+	CFRange range;
+
+	line = (CTLineRef) CFArrayGetValueAtIndex(CTFrameGetLines((CTFrameRef) t->frame), (CFIndex) i);
+	CTFrameGetLineOrigins((CTFrameRef) t->frame, CFRangeMake((CFIndex) i, 1), &origin);
+	width = CTLineGetTypographicBounds(line, &ascent, &descent, &leading);
+	width -= CTLineGetTrailingWhitespaceWidth(line);
+	range = CTLineGetStringRange(line);
+	l->start = (intptr_t) range.location;
+	l->end = (intptr_t) (range.location + range.length);
+	l->x = origin.x;
+	l->y = t->height - origin.y - ascent;
+	l->width = width;
+	l->height = ascent + descent + leading;
+	l->baseline = ascent;
+}
+
+// pix is the Pix of an image.Alpha; the bitmap context draws straight into it
+// CGBitmapContext memory goes from the top row down, so this comes out the right way up even though Core Text draws from the bottom up
+void renderTextFrame(struct textFrame *t, void *pix, intptr_t width, intptr_t height, intptr_t stride)
+{
+	CGContextRef c;
+
+	c = CGBitmapContextCreate(pix, (size_t) width, (size_t) height, 8, (size_t) stride, NULL, kCGImageAlphaOnly);
+	if (c == NULL)
+		return;
+	// the bitmap can be taller than the frame, if an empty line was added after a final newline
+	CGContextTranslateCTM(c, 0, (CGFloat) height - (CGFloat) t->height);
+	CTFrameDraw((CTFrameRef) t->frame, c);
+	CGContextRelease(c);
+}
+
+void freeTextFrame(struct textFrame *t)
+{
+	CFRelease((CTFrameRef) t->frame);
+}
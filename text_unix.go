@@ -0,0 +1,109 @@
+// +build !windows,!darwin
+
+// 15 october 2026
+
+package ui
+
+import (
+	"image"
+	"unsafe"
+)
+
+// #include "gtk_unix.h"
+// #include <pango/pangocairo.h>
+// static PangoLayout *newTextLayout(cairo_t *cr, char *text, PangoFontDescription *desc, int width, PangoAlignment align)
+// {
+// 	PangoLayout *layout;
+//
+// 	layout = pango_cairo_create_layout(cr);
+// 	pango_layout_set_text(layout, text, -1);
+// 	pango_layout_set_font_description(layout, desc);
+// 	if (width >= 0) {
+// 		pango_layout_set_width(layout, width * PANGO_SCALE);
+// 		pango_layout_set_wrap(layout, PANGO_WRAP_WORD_CHAR);
+// 	}
+// 	pango_layout_set_alignment(layout, align);
+// 	return layout;
+// }
+import "C"
+
+var textAligns = [...]C.PangoAlignment{
+	TextAlignLeft:   C.PANGO_ALIGN_LEFT,
+	TextAlignCenter: C.PANGO_ALIGN_CENTER,
+	TextAlignRight:  C.PANGO_ALIGN_RIGHT,
+}
+
+// the layout depends on the cairo context only for its font options and resolution, so any surface will do for measuring; this is the same as drawing later
+func newPangoLayout(t *TextLayout, cr *C.cairo_t) *C.PangoLayout {
+	ctext := togstr(t.text)
+	defer freegstr(ctext)
+	desc := toPangoFontDescription(t.font)
+	defer C.pango_font_description_free(desc)
+	if t.font.Family == "" {
+		// leaving the family unset gets the default from fontconfig
+		C.pango_font_description_unset_fields(desc, C.PANGO_FONT_MASK_FAMILY)
+	}
+	return C.newTextLayout(cr, (*C.char)(unsafe.Pointer(ctext)), desc, C.int(t.width), textAligns[t.align])
+}
+
+func layoutText(t *TextLayout) (lines []TextLine, size image.Point) {
+	var logical C.PangoRectangle
+
+	surface := C.cairo_image_surface_create(C.CAIRO_FORMAT_A8, 1, 1)
+	cr := C.cairo_create(surface)
+	layout := newPangoLayout(t, cr)
+	defer C.cairo_surface_destroy(surface)
+	defer C.cairo_destroy(cr)
+	defer C.g_object_unref(C.gpointer(unsafe.Pointer(layout)))
+
+	iter := C.pango_layout_get_iter(layout)
+	for {
+		line := C.pango_layout_iter_get_line_readonly(iter)
+		C.pango_layout_iter_get_line_extents(iter, nil, &logical)
+		baseline := C.pango_layout_iter_get_baseline(iter)
+		lines = append(lines, TextLine{
+			Start:    int(line.start_index),
+			End:      int(line.start_index + line.length),
+			X:        int(C.pango_units_to_double(logical.x)),
+			Y:        int(C.pango_units_to_double(logical.y)),
+			Width:    int(C.pango_units_to_double(logical.width) + 0.5),
+			Height:   int(C.pango_units_to_double(logical.height) + 0.5),
+			Baseline: int(C.pango_units_to_double(baseline-logical.y) + 0.5),
+		})
+		if C.pango_layout_iter_next_line(iter) == C.FALSE {
+			break
+		}
+	}
+	C.pango_layout_iter_free(iter)
+
+	C.pango_layout_get_pixel_extents(layout, nil, &logical)
+	size.X = int(logical.x + logical.width)
+	if t.width >= 0 {
+		size.X = t.width
+	}
+	size.Y = int(logical.y + logical.height)
+	return lines, size
+}
+
+// an A8 surface drawn on with an opaque source holds just the coverage of the text, which is what image.Alpha is
+func renderText(t *TextLayout) *image.Alpha {
+	mask := image.NewAlpha(image.Rect(0, 0, t.size.X, t.size.Y))
+	if t.size.X == 0 || t.size.Y == 0 {
+		return mask
+	}
+	surface := C.cairo_image_surface_create(C.CAIRO_FORMAT_A8, C.int(t.size.X), C.int(t.size.Y))
+	defer C.cairo_surface_destroy(surface)
+	cr := C.cairo_create(surface)
+	layout := newPangoLayout(t, cr)
+	C.cairo_set_source_rgba(cr, 0, 0, 0, 1)
+	C.pango_cairo_show_layout(cr, layout)
+	C.g_object_unref(C.gpointer(unsafe.Pointer(layout)))
+	C.cairo_destroy(cr)
+	C.cairo_surface_flush(surface)
+	stride := int(C.cairo_image_surface_get_stride(surface))
+	pix := C.GoBytes(unsafe.Pointer(C.cairo_image_surface_get_data(surface)), C.int(stride*t.size.Y))
+	for y := 0; y < t.size.Y; y++ {
+		copy(mask.Pix[y*mask.Stride:(y+1)*mask.Stride], pix[y*stride:])
+	}
+	return mask
+}
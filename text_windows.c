@@ -0,0 +1,110 @@
+// 15 october 2026
+
+#include "winapi_windows.h"
+#include "_cgo_export.h"
+
+// TextLayout measures and draws with plain GDI; TextOutW() and GetTextExtentExPointW() still shape complex scripts through Uniscribe
+// DirectWrite would be better, but it's C++-only COM and Vista-only
+void newTextDC(struct textDC *t, LOGFONTW *lf, INT pointSize)
+{
+	NONCLIENTMETRICSW ncm;
+	TEXTMETRICW tm;
+
+	t->dc = CreateCompatibleDC(NULL);
+	if (t->dc == NULL)
+		xpanic("error creating DC for TextLayout", GetLastError());
+	// an empty family means the system font, which is the one controls use; see initWindows() in init_windows.c
+	if (lf->lfFaceName[0] == L'\0') {
+		ZeroMemory(&ncm, sizeof (NONCLIENTMETRICSW));
+		ncm.cbSize = sizeof (NONCLIENTMETRICSW);
+		if (SystemParametersInfoW(SPI_GETNONCLIENTMETRICS, sizeof (NONCLIENTMETRICSW), &ncm, sizeof (NONCLIENTMETRICSW)) == 0)
+			xpanic("error getting system font for TextLayout", GetLastError());
+		memcpy(lf->lfFaceName, ncm.lfMessageFont.lfFaceName, sizeof (lf->lfFaceName));
+	}
+	// same as in doChooseFont() in dialog_windows.c
+	lf->lfHeight = -MulDiv(pointSize, GetDeviceCaps(t->dc, LOGPIXELSY), 720);
+	// not ClearType; we want one coverage value per pixel, not one per subpixel
+	lf->lfQuality = ANTIALIASED_QUALITY;
+	t->font = CreateFontIndirectW(lf);
+	if (t->font == NULL)
+		xpanic("error creating font for TextLayout", GetLastError());
+	t->prevfont = (HFONT) SelectObject(t->dc, t->font);
+	if (t->prevfont == NULL)
+		xpanic("error selecting font into TextLayout DC", GetLastError());
+	if (GetTextMetricsW(t->dc, &tm) == 0)
+		xpanic("error getting text metrics for TextLayout", GetLastError());
+	t->lineHeight = tm.tmHeight + tm.tmExternalLeading;
+	t->ascent = tm.tmAscent;
+	t->bitmap = NULL;
+}
+
+// returns how many of the n characters of text fit in width pixels, and the width of those that do in *fitWidth
+int textFit(struct textDC *t, LPWSTR text, int n, int width, LONG *fitWidth)
+{
+	SIZE size;
+	INT fit;
+
+	*fitWidth = 0;
+	if (n == 0)
+		return 0;
+	if (GetTextExtentExPointW(t->dc, text, n, width, &fit, NULL, &size) == 0)
+		xpanic("error measuring text for TextLayout", GetLastError());
+	if (fit == n) {
+		*fitWidth = size.cx;
+		return n;
+	}
+	if (fit != 0 && GetTextExtentPoint32W(t->dc, text, fit, &size) == 0)
+		xpanic("error measuring text for TextLayout", GetLastError());
+	if (fit != 0)
+		*fitWidth = size.cx;
+	return fit;
+}
+
+// the text is drawn white on black, so any one color channel of the result is the coverage
+void *beginTextRender(struct textDC *t, int width, int height)
+{
+	BITMAPINFO bi;
+	RECT r;
+
+	ZeroMemory(&bi, sizeof (BITMAPINFO));
+	bi.bmiHeader.biSize = sizeof (BITMAPINFOHEADER);
+	bi.bmiHeader.biWidth = (LONG) width;
+	bi.bmiHeader.biHeight = -((LONG) height);		// negative height to force top-down drawing
+	bi.bmiHeader.biPlanes = 1;
+	bi.bmiHeader.biBitCount = 32;
+	bi.bmiHeader.biCompression = BI_RGB;
+	t->bitmap = CreateDIBSection(t->dc, &bi, DIB_RGB_COLORS, &(t->bits), NULL, 0);
+	if (t->bitmap == NULL)
+		xpanic("error creating bitmap for drawing TextLayout", GetLastError());
+	t->prevbitmap = (HBITMAP) SelectObject(t->dc, t->bitmap);
+	if (t->prevbitmap == NULL)
+		xpanic("error selecting bitmap into TextLayout DC", GetLastError());
+	r.left = 0;
+	r.top = 0;
+	r.right = width;
+	r.bottom = height;
+	if (FillRect(t->dc, &r, (HBRUSH) GetStockObject(BLACK_BRUSH)) == 0)
+		xpanic("error clearing bitmap for drawing TextLayout", GetLastError());
+	SetTextColor(t->dc, RGB(255, 255, 255));
+	SetBkMode(t->dc, TRANSPARENT);
+	return t->bits;
+}
+
+void textOut(struct textDC *t, int x, int y, LPWSTR text, int n)
+{
+	if (TextOutW(t->dc, x, y, text, n) == 0)
+		xpanic("error drawing TextLayout line", GetLastError());
+}
+
+void freeTextDC(struct textDC *t)
+{
+	if (t->bitmap != NULL) {
+		SelectObject(t->dc, t->prevbitmap);
+		DeleteObject(t->bitmap);
+	}
+	SelectObject(t->dc, t->prevfont);
+	if (DeleteObject(t->font) == 0)
+		xpanic("error deleting TextLayout font", GetLastError());
+	if (DeleteDC(t->dc) == 0)
+		xpanic("error deleting TextLayout DC", GetLastError());
+}
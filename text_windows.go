@@ -0,0 +1,152 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"image"
+	"unicode/utf16"
+	"unsafe"
+)
+
+// #include "winapi_windows.h"
+import "C"
+
+// GDI doesn't wrap or align text the way we want (DrawTextW() can't tell us where it wrapped), so we do that ourselves and only ask GDI to measure and draw single lines
+
+func newTextDC(t *TextLayout) *C.struct_textDC {
+	var lf C.LOGFONTW
+
+	dc := new(C.struct_textDC)
+	toLOGFONTW(t.font, &lf)
+	C.newTextDC(dc, &lf, C.INT(t.font.Size*10+0.5))
+	return dc
+}
+
+func layoutText(t *TextLayout) (lines []TextLine, size image.Point) {
+	dc := newTextDC(t)
+	defer C.freeTextDC(dc)
+
+	text := utf16.Encode([]rune(t.text))
+	offsets := utf16Offsets(t.text)
+	// one extra so &text[n] is always valid, even for empty lines at the end
+	text = append(text, 0)
+	width := C.int(t.width)
+	if t.width < 0 {
+		width = 0x7FFFFFFF
+	}
+
+	y := 0
+	start := 0
+	for {
+		end := start
+		for end < len(text)-1 && text[end] != '\n' {
+			end++
+		}
+		// wrap the paragraph text[start:end] into lines
+		for {
+			var w C.LONG
+
+			n := int(C.textFit(dc, C.LPWSTR(unsafe.Pointer(&text[start])), C.int(end-start), width, &w))
+			next := start + n
+			if next < end {
+				next = wrapPoint(text, start, next)
+				// measure again without the part that didn't fit and the spaces at the break
+				trimmed := next
+				for trimmed > start && text[trimmed-1] == ' ' {
+					trimmed--
+				}
+				C.textFit(dc, C.LPWSTR(unsafe.Pointer(&text[start])), C.int(trimmed-start), 0x7FFFFFFF, &w)
+			}
+			lines = append(lines, TextLine{
+				Start:    offsets[start],
+				End:      offsets[next],
+				Y:        y,
+				Width:    int(w),
+				Height:   int(dc.lineHeight),
+				Baseline: int(dc.ascent),
+			})
+			y += int(dc.lineHeight)
+			start = next
+			if start >= end {
+				break
+			}
+		}
+		if end >= len(text)-1 {
+			break
+		}
+		start = end + 1 // skip the newline
+	}
+
+	size.X = t.width
+	if t.width < 0 {
+		size.X = 0
+		for _, l := range lines {
+			if l.Width > size.X {
+				size.X = l.Width
+			}
+		}
+	}
+	size.Y = y
+	for i := range lines {
+		switch t.align {
+		case TextAlignCenter:
+			lines[i].X = (size.X - lines[i].Width) / 2
+		case TextAlignRight:
+			lines[i].X = size.X - lines[i].Width
+		}
+	}
+	return lines, size
+}
+
+// fit is where the line would have to break to fit, which is after start
+// break after the last run of spaces before fit, or at fit itself (but after at least one character) if there are none, as with a long word or a URL
+func wrapPoint(text []uint16, start int, fit int) int {
+	for i := fit; i > start; i-- {
+		if text[i-1] == ' ' {
+			// keep the spaces at the break on this line, so the next one doesn't start with them
+			for text[i] == ' ' {
+				i++
+			}
+			return i
+		}
+	}
+	if fit == start {
+		fit++
+	}
+	// don't split a surrogate pair
+	if text[fit] >= 0xDC00 && text[fit] < 0xE000 {
+		if fit-1 > start {
+			return fit - 1
+		}
+		return fit + 1
+	}
+	return fit
+}
+
+func renderText(t *TextLayout) *image.Alpha {
+	mask := image.NewAlpha(image.Rect(0, 0, t.size.X, t.size.Y))
+	if t.size.X == 0 || t.size.Y == 0 {
+		return mask
+	}
+	dc := newTextDC(t)
+	defer C.freeTextDC(dc)
+	bits := C.beginTextRender(dc, C.int(t.size.X), C.int(t.size.Y))
+	for _, l := range t.lines {
+		start, end := l.Start, l.End
+		// trailing spaces would draw nothing anyway
+		for end > start && t.text[end-1] == ' ' {
+			end--
+		}
+		if end == start {
+			continue
+		}
+		text := utf16.Encode([]rune(t.text[start:end]))
+		C.textOut(dc, C.int(l.X), C.int(l.Y), C.LPWSTR(unsafe.Pointer(&text[0])), C.int(len(text)))
+	}
+	C.GdiFlush()
+	pix := C.GoBytes(bits, C.int(t.size.X*t.size.Y*4))
+	for i := range mask.Pix {
+		mask.Pix[i] = pix[i*4]
+	}
+	return mask
+}
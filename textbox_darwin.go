@@ -11,13 +11,16 @@ import "C"
 
 type textbox struct {
 	*scroller
+	changed		*event
 }
 
 func newTextbox() Textbox {
 	id := C.newTextbox()
 	t := &textbox{
 		scroller:		newScroller(id, true),		// border on Textbox (TODO confirm type)
+		changed:		newEvent(),
 	}
+	C.textboxSetDelegate(t.id, unsafe.Pointer(t))
 	// TODO preferred size
 	return t
 }
@@ -31,3 +34,35 @@ func (t *textbox) SetText(text string) {
 	defer C.free(unsafe.Pointer(ctext))
 	C.textboxSetText(t.id, ctext)
 }
+
+func (t *textbox) Append(text string) {
+	ctext := C.CString(text)
+	defer C.free(unsafe.Pointer(ctext))
+	C.textboxAppend(t.id, ctext)
+}
+
+func (t *textbox) OnChanged(f func()) {
+	t.changed.set(f)
+}
+
+//export textboxChanged
+func textboxChanged(data unsafe.Pointer) {
+	t := (*textbox)(data)
+	t.changed.fire()
+}
+
+func (t *textbox) ReadOnly() bool {
+	return !fromBOOL(C.textboxEditable(t.id))
+}
+
+func (t *textbox) SetReadOnly(readonly bool) {
+	C.textboxSetEditable(t.id, toBOOL(!readonly))
+}
+
+func (t *textbox) WordWrap() bool {
+	return fromBOOL(C.textboxWordWrap(t.id))
+}
+
+func (t *textbox) SetWordWrap(wrap bool) {
+	C.textboxSetWordWrap(t.id, toBOOL(wrap))
+}
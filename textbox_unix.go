@@ -9,11 +9,14 @@ import (
 )
 
 // #include "gtk_unix.h"
+// extern void textboxChanged(GtkTextBuffer *, gpointer);
 import "C"
 
 type textbox struct {
 	*scroller
 	textview		*C.GtkTextView
+	changed		*event
+	setting		bool		// ::changed is also emitted for our own changes; this tells textboxChanged() to ignore those
 }
 
 func newTextbox() Textbox {
@@ -21,7 +24,13 @@ func newTextbox() Textbox {
 	t := &textbox{
 		scroller:		newScroller(widget, true, true, false),		// natively scrollable, has a border, no overlay
 		textview:		(*C.GtkTextView)(unsafe.Pointer(widget)),
+		changed:		newEvent(),
 	}
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(C.gtk_text_view_get_buffer(t.textview))),
+		"changed",
+		C.GCallback(C.textboxChanged),
+		C.gpointer(unsafe.Pointer(t)))
 	return t
 }
 
@@ -42,5 +51,57 @@ func (t *textbox) SetText(text string) {
 	ctext := togstr(text)
 	defer freegstr(ctext)
 	buf := C.gtk_text_view_get_buffer(t.textview)
+	t.setting = true
 	C.gtk_text_buffer_set_text(buf, ctext, -1)		// null-terminated
+	t.setting = false
+}
+
+func (t *textbox) Append(text string) {
+	var end C.GtkTextIter
+
+	ctext := togstr(text)
+	defer freegstr(ctext)
+	buf := C.gtk_text_view_get_buffer(t.textview)
+	C.gtk_text_buffer_get_end_iter(buf, &end)
+	t.setting = true
+	C.gtk_text_buffer_insert(buf, &end, ctext, -1)		// null-terminated
+	t.setting = false
+	// end was moved to the end of the inserted text
+	C.gtk_text_buffer_place_cursor(buf, &end)
+	// use the mark version; the iter version doesn't work until the new text has been laid out
+	C.gtk_text_view_scroll_mark_onscreen(t.textview, C.gtk_text_buffer_get_insert(buf))
+}
+
+func (t *textbox) OnChanged(f func()) {
+	t.changed.set(f)
+}
+
+func (t *textbox) ReadOnly() bool {
+	return !fromgbool(C.gtk_text_view_get_editable(t.textview))
+}
+
+func (t *textbox) SetReadOnly(readonly bool) {
+	C.gtk_text_view_set_editable(t.textview, togbool(!readonly))
+}
+
+func (t *textbox) WordWrap() bool {
+	return C.gtk_text_view_get_wrap_mode(t.textview) != C.GTK_WRAP_NONE
+}
+
+func (t *textbox) SetWordWrap(wrap bool) {
+	if wrap {
+		// wrap at word boundaries, but break words that are too long for a line by themselves
+		C.gtk_text_view_set_wrap_mode(t.textview, C.GTK_WRAP_WORD_CHAR)
+		return
+	}
+	C.gtk_text_view_set_wrap_mode(t.textview, C.GTK_WRAP_NONE)
+}
+
+//export textboxChanged
+func textboxChanged(buf *C.GtkTextBuffer, data C.gpointer) {
+	t := (*textbox)(unsafe.Pointer(data))
+	if t.setting {
+		return
+	}
+	t.changed.fire()
 }
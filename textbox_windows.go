@@ -2,25 +2,34 @@
 
 package ui
 
+import (
+	"unsafe"
+)
+
 // #include "winapi_windows.h"
 import "C"
 
 type textbox struct {
 	*controlSingleHWNDWithText
+	changed		*event
+	wordWrap		bool
+	setting		bool		// EN_CHANGE is also sent for our own changes; this tells textboxChanged() to ignore those
 }
 
 // TODO autohide scrollbars
 func newTextbox() Textbox {
 	hwnd := C.newControl(editclass,
 		// TODO ES_AUTOHSCROLL/ES_AUTOVSCROLL as well?
-		// TODO word wrap
+		// word wrap is on if WS_HSCROLL isn't given; see textboxRemake()
 		C.ES_LEFT | C.ES_MULTILINE | C.ES_NOHIDESEL | C.ES_WANTRETURN | C.WS_HSCROLL | C.WS_VSCROLL,
 		C.WS_EX_CLIENTEDGE)
 	t := &textbox{
 		controlSingleHWNDWithText:		newControlSingleHWNDWithText(hwnd),
+		changed:						newEvent(),
 	}
 	t.fpreferredSize = t.xpreferredSize
 	C.controlSetControlFont(t.hwnd)
+	C.setTextboxSubclass(t.hwnd, unsafe.Pointer(t))
 	return t
 }
 
@@ -29,7 +38,59 @@ func (t *textbox) Text() string {
 }
 
 func (t *textbox) SetText(text string) {
+	t.setting = true
 	t.setText(text)
+	t.setting = false
+}
+
+func (t *textbox) Append(text string) {
+	t.setting = true
+	C.textboxAppend(t.hwnd, toUTF16(text))
+	t.setting = false
+}
+
+func (t *textbox) OnChanged(f func()) {
+	t.changed.set(f)
+}
+
+func (t *textbox) ReadOnly() bool {
+	return C.textfieldReadOnly(t.hwnd) != 0
+}
+
+func (t *textbox) SetReadOnly(readonly bool) {
+	if readonly {
+		C.textfieldSetReadOnly(t.hwnd, C.TRUE)
+		return
+	}
+	C.textfieldSetReadOnly(t.hwnd, C.FALSE)
+}
+
+func (t *textbox) WordWrap() bool {
+	return t.wordWrap
+}
+
+func (t *textbox) SetWordWrap(wrap bool) {
+	if wrap == t.wordWrap {
+		return
+	}
+	t.wordWrap = wrap
+	text := t.text()
+	if wrap {
+		t.hwnd = C.textboxRemake(t.hwnd, C.TRUE)
+	} else {
+		t.hwnd = C.textboxRemake(t.hwnd, C.FALSE)
+	}
+	C.setTextboxSubclass(t.hwnd, unsafe.Pointer(t))
+	t.SetText(text)
+}
+
+//export textboxChanged
+func textboxChanged(data unsafe.Pointer) {
+	t := (*textbox)(data)
+	if t.setting {
+		return
+	}
+	t.changed.fire()
 }
 
 // just reuse the preferred textfield width
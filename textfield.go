@@ -0,0 +1,44 @@
+// 15 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// TextField positions are byte offsets into Text(), as with slicing it, but GTK+ counts characters and Windows and Mac OS X count UTF-16 code units; these convert between the two
+// wide is whether the system counts UTF-16 code units
+
+func toTextFieldPos(text string, pos int, wide bool) int {
+	if !wide {
+		return utf8.RuneCountInString(text[:pos])
+	}
+	n := 0
+	for _, r := range text[:pos] {
+		n += len(utf16.Encode([]rune{r}))
+	}
+	return n
+}
+
+func fromTextFieldPos(text string, n int, wide bool) int {
+	for i, r := range text {
+		if n <= 0 {
+			return i
+		}
+		if wide {
+			n -= len(utf16.Encode([]rune{r}))
+		} else {
+			n--
+		}
+	}
+	return len(text)
+}
+
+// checkTextFieldRange panics if text[start:end] would
+func checkTextFieldRange(text string, start int, end int) {
+	if start < 0 || end < start || end > len(text) {
+		panic(fmt.Errorf("invalid range [%d:%d] for text of length %d in TextField.Select()", start, end, len(text)))
+	}
+}
@@ -12,7 +12,9 @@ import "C"
 type textfield struct {
 	*controlSingleObject
 	changed *event
+	entered *event
 	invalid C.id
+	reason  string // for InvalidReason(); invalid is the popover
 	chainpreferredSize	func(d *sizing) (int, int)
 }
 
@@ -20,6 +22,7 @@ func finishNewTextField(id C.id) *textfield {
 	t := &textfield{
 		controlSingleObject:		newControlSingleObject(id),
 		changed: newEvent(),
+		entered: newEvent(),
 	}
 	C.textfieldSetDelegate(t.id, unsafe.Pointer(t))
 	t.chainpreferredSize = t.fpreferredSize
@@ -49,7 +52,28 @@ func (t *textfield) OnChanged(f func()) {
 	t.changed.set(f)
 }
 
+func (t *textfield) OnEnterPressed(f func()) {
+	t.entered.set(f)
+}
+
+// NSText positions count UTF-16 code units; see toTextFieldPos()
+
+func (t *textfield) Select(start int, end int) {
+	text := t.Text()
+	checkTextFieldRange(text, start, end)
+	C.textfieldSelect(t.id, C.intptr_t(toTextFieldPos(text, start, true)), C.intptr_t(toTextFieldPos(text, end, true)))
+}
+
+func (t *textfield) SelectAll() {
+	C.textfieldSelect(t.id, 0, -1)
+}
+
+func (t *textfield) CursorPos() int {
+	return fromTextFieldPos(t.Text(), int(C.textfieldCursorPos(t.id)), true)
+}
+
 func (t *textfield) Invalid(reason string) {
+	t.reason = reason
 	if t.invalid != nil {
 		C.textfieldCloseInvalidPopover(t.invalid)
 		t.invalid = nil
@@ -62,6 +86,10 @@ func (t *textfield) Invalid(reason string) {
 	t.invalid = C.textfieldOpenInvalidPopover(t.id, creason)
 }
 
+func (t *textfield) InvalidReason() string {
+	return t.reason
+}
+
 // note that the property here is editable, which is the opposite of read-only
 
 func (t *textfield) ReadOnly() bool {
@@ -78,6 +106,12 @@ func textfieldChanged(data unsafe.Pointer) {
 	t.changed.fire()
 }
 
+//export textfieldEntered
+func textfieldEntered(data unsafe.Pointer) {
+	t := (*textfield)(data)
+	t.entered.fire()
+}
+
 func (t *textfield) xpreferredSize(d *sizing) (width, height int) {
 	_, height = t.chainpreferredSize(d)
 	// the returned width is based on the contents; use this instead
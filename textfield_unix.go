@@ -10,11 +10,18 @@ import (
 
 // #include "gtk_unix.h"
 // extern void textfieldChanged(GtkEditable *, gpointer);
+// extern void textfieldActivated(GtkEntry *, gpointer);
 // /* because cgo doesn't like GTK_STOCK_DIALOG_ERROR */
 // static inline void setErrorIcon(GtkEntry *entry)
 // {
 // 	gtk_entry_set_icon_from_stock(entry, GTK_ENTRY_ICON_SECONDARY, GTK_STOCK_DIALOG_ERROR);
 // }
+// /* ::input-purpose is GTK+ 3.6 and newer; input methods use it to stop showing and learning what's typed */
+// static inline void setPasswordPurpose(GtkEntry *entry)
+// {
+// 	if (g_object_class_find_property(G_OBJECT_GET_CLASS(entry), "input-purpose") != NULL)
+// 		g_object_set(entry, "input-purpose", 8, NULL);		/* GTK_INPUT_PURPOSE_PASSWORD */
+// }
 import "C"
 
 type textfield struct {
@@ -22,6 +29,8 @@ type textfield struct {
 	editable	*C.GtkEditable
 	entry   *C.GtkEntry
 	changed *event
+	entered *event
+	invalid string
 }
 
 func startNewTextField() *textfield {
@@ -31,12 +40,18 @@ func startNewTextField() *textfield {
 		editable:	(*C.GtkEditable)(unsafe.Pointer(widget)),
 		entry:   (*C.GtkEntry)(unsafe.Pointer(widget)),
 		changed: newEvent(),
+		entered: newEvent(),
 	}
 	g_signal_connect(
 		C.gpointer(unsafe.Pointer(t.widget)),
 		"changed",
 		C.GCallback(C.textfieldChanged),
 		C.gpointer(unsafe.Pointer(t)))
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(t.widget)),
+		"activate",
+		C.GCallback(C.textfieldActivated),
+		C.gpointer(unsafe.Pointer(t)))
 	return t
 }
 
@@ -47,6 +62,7 @@ func newTextField() *textfield {
 func newPasswordField() *textfield {
 	t := startNewTextField()
 	C.gtk_entry_set_visibility(t.entry, C.FALSE)
+	C.setPasswordPurpose(t.entry)
 	return t
 }
 
@@ -64,7 +80,28 @@ func (t *textfield) OnChanged(f func()) {
 	t.changed.set(f)
 }
 
+func (t *textfield) OnEnterPressed(f func()) {
+	t.entered.set(f)
+}
+
+// GtkEditable positions count characters; see toTextFieldPos()
+
+func (t *textfield) Select(start int, end int) {
+	text := t.Text()
+	checkTextFieldRange(text, start, end)
+	C.gtk_editable_select_region(t.editable, C.gint(toTextFieldPos(text, start, false)), C.gint(toTextFieldPos(text, end, false)))
+}
+
+func (t *textfield) SelectAll() {
+	C.gtk_editable_select_region(t.editable, 0, -1)
+}
+
+func (t *textfield) CursorPos() int {
+	return fromTextFieldPos(t.Text(), int(C.gtk_editable_get_position(t.editable)), false)
+}
+
 func (t *textfield) Invalid(reason string) {
+	t.invalid = reason
 	if reason == "" {
 		C.gtk_entry_set_icon_from_stock(t.entry, C.GTK_ENTRY_ICON_SECONDARY, nil)
 		return
@@ -76,6 +113,10 @@ func (t *textfield) Invalid(reason string) {
 	C.gtk_widget_error_bell(t.widget)
 }
 
+func (t *textfield) InvalidReason() string {
+	return t.invalid
+}
+
 // note that the property here is editable, which is the opposite of read-only
 
 func (t *textfield) ReadOnly() bool {
@@ -91,3 +132,9 @@ func textfieldChanged(editable *C.GtkEditable, data C.gpointer) {
 	t := (*textfield)(unsafe.Pointer(data))
 	t.changed.fire()
 }
+
+//export textfieldActivated
+func textfieldActivated(entry *C.GtkEntry, data C.gpointer) {
+	t := (*textfield)(unsafe.Pointer(data))
+	t.entered.fire()
+}
@@ -12,6 +12,8 @@ import "C"
 type textfield struct {
 	*controlSingleHWNDWithText
 	changed  *event
+	entered  *event
+	invalid  string
 }
 
 var editclass = toUTF16("EDIT")
@@ -23,6 +25,7 @@ func startNewTextField(style C.DWORD) *textfield {
 	t := &textfield{
 		controlSingleHWNDWithText:		newControlSingleHWNDWithText(hwnd),
 		changed: newEvent(),
+		entered: newEvent(),
 	}
 	t.fpreferredSize = t.xpreferredSize
 	C.controlSetControlFont(t.hwnd)
@@ -35,7 +38,9 @@ func newTextField() *textfield {
 }
 
 func newPasswordField() *textfield {
-	return startNewTextField(C.ES_PASSWORD)
+	t := startNewTextField(C.ES_PASSWORD)
+	C.textfieldDisableIME(t.hwnd)
+	return t
 }
 
 func (t *textfield) Text() string {
@@ -50,7 +55,32 @@ func (t *textfield) OnChanged(f func()) {
 	t.changed.set(f)
 }
 
+func (t *textfield) OnEnterPressed(f func()) {
+	t.entered.set(f)
+}
+
+// edit control positions count UTF-16 code units; see toTextFieldPos()
+
+func (t *textfield) Select(start int, end int) {
+	text := t.Text()
+	checkTextFieldRange(text, start, end)
+	C.SendMessageW(t.hwnd, C.EM_SETSEL, C.WPARAM(toTextFieldPos(text, start, true)), C.LPARAM(toTextFieldPos(text, end, true)))
+}
+
+func (t *textfield) SelectAll() {
+	C.SendMessageW(t.hwnd, C.EM_SETSEL, 0, -1)
+}
+
+// EM_GETSEL only gives the selection; the caret is at its end unless the user selected backward, which the edit control doesn't tell us
+func (t *textfield) CursorPos() int {
+	var start, end C.DWORD
+
+	C.SendMessageW(t.hwnd, C.EM_GETSEL, C.WPARAM(uintptr(unsafe.Pointer(&start))), C.LPARAM(uintptr(unsafe.Pointer(&end))))
+	return fromTextFieldPos(t.Text(), int(end), true)
+}
+
 func (t *textfield) Invalid(reason string) {
+	t.invalid = reason
 	if reason == "" {
 		C.textfieldHideInvalidBalloonTip(t.hwnd)
 		return
@@ -58,6 +88,10 @@ func (t *textfield) Invalid(reason string) {
 	C.textfieldSetAndShowInvalidBalloonTip(t.hwnd, toUTF16(reason))
 }
 
+func (t *textfield) InvalidReason() string {
+	return t.invalid
+}
+
 func (t *textfield) ReadOnly() bool {
 	return C.textfieldReadOnly(t.hwnd) != 0
 }
@@ -76,6 +110,12 @@ func textfieldChanged(data unsafe.Pointer) {
 	t.changed.fire()
 }
 
+//export textfieldEntered
+func textfieldEntered(data unsafe.Pointer) {
+	t := (*textfield)(data)
+	t.entered.fire()
+}
+
 const (
 	// from http://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
 	textfieldWidth  = 107 // this is actually the shorter progress bar width, but Microsoft only indicates as wide as necessary
@@ -0,0 +1,680 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"time"
+)
+
+// TimelineClip is a clip on one track of a Timeline.
+type TimelineClip struct {
+	// Track is the index of the track the clip is on, counting from 0 at the top.
+	Track int
+
+	// Start and Length give the span of time the clip covers.
+	// Start cannot be negative and Length must be positive.
+	Start  time.Duration
+	Length time.Duration
+
+	// Color is the color the clip is filled with; the zero value chooses a default.
+	Color color.RGBA
+
+	// Label, if not nil, is drawn at the left end of the clip, clipped to it.
+	// As with GraphNode, render the clip's title into an image yourself, for instance with a TextLayout.
+	Label *image.RGBA
+}
+
+// TimelineChangeKind says what the user did to a clip in a TimelineChange.
+type TimelineChangeKind uint
+
+const (
+	TimelineClipMoved   TimelineChangeKind = iota // Start or Track changed
+	TimelineClipResized                           // Start and/or Length changed by dragging an edge
+	TimelineClipRemoved
+)
+
+// TimelineChange describes a change the user made to a clip of a Timeline.
+type TimelineChange struct {
+	Kind TimelineChangeKind
+	Clip int
+}
+
+// Timeline is an Area that shows clips laid out in time on a number of tracks, with a time ruler along the top and a playhead, as in media editors and automation tools.
+//
+// The user can click a clip to select it, drag it to move it in time or to another track, and drag either of its ends to resize it; Delete removes the selected clip.
+// Clicking or dragging in the ruler moves the playhead and sends scrub events.
+// Dragging with the middle mouse button scrolls in time; Ctrl with + (or =), -, and 0 zooms in, zooms out, and resets the zoom, keeping the playhead where it is.
+// While moving or resizing, clip edges snap to the edges of other clips and to the playhead when they come within a few pixels of them, and otherwise to the snap interval (see SetSnap); hold Alt to turn snapping off.
+// Clips on the same track may overlap; the one added last is drawn on top.
+//
+// The ruler does not have time labels; Timeline draws no text of its own.
+// Tracks are a fixed height; the Area is not resized as tracks are added, so use SetSize to make it tall enough.
+type Timeline interface {
+	Area
+
+	// Tracks and SetTracks get and set the number of tracks.
+	// SetTracks panics if a clip is on a track that would no longer exist.
+	Tracks() int
+	SetTracks(n int)
+
+	// AddClip adds a clip and returns its ID; IDs are never reused.
+	// Clip returns a copy of a clip, SetClip replaces one, and RemoveClip removes one.
+	// These panic if there is no such clip or if the clip is invalid.
+	AddClip(c TimelineClip) (id int)
+	Clip(id int) TimelineClip
+	SetClip(id int, c TimelineClip)
+	RemoveClip(id int)
+
+	// Clips returns the IDs of all the clips, in drawing order.
+	Clips() []int
+
+	// OnChanged registers a function that is called after the user moves, resizes, or removes a clip.
+	// It is not called for changes made by the program.
+	OnChanged(f func(c TimelineChange))
+
+	// Selected returns the selected clip, if any; Select selects a clip, or deselects all clips if id is negative.
+	Selected() (id int, ok bool)
+	Select(id int)
+
+	// OnSelected is an event that gets triggered after the selection changes in whatever way.
+	OnSelected(f func())
+
+	// Playhead and SetPlayhead get and set the position of the playhead.
+	Playhead() time.Duration
+	SetPlayhead(t time.Duration)
+
+	// OnScrub registers a function that is called with the new position of the playhead each time the user moves it.
+	// It is not called by SetPlayhead.
+	OnScrub(f func(t time.Duration))
+
+	// Scale and SetScale get and set the zoom, as the amount of time covered by one pixel; the default is 10ms.
+	// SetScale clamps the scale to the range [1µs,1min].
+	Scale() time.Duration
+	SetScale(scale time.Duration)
+
+	// Offset and SetOffset get and set the time at the left edge of the Area.
+	// SetOffset clamps negative times to zero.
+	Offset() time.Duration
+	SetOffset(t time.Duration)
+
+	// Snap and SetSnap get and set the interval that clip edges snap to; 0 (the default) only snaps to other clips and the playhead.
+	Snap() time.Duration
+	SetSnap(interval time.Duration)
+}
+
+type timelineDrag uint
+
+const (
+	timelineDragNone timelineDrag = iota
+	timelineDragPlayhead
+	timelineDragMove
+	timelineDragStart // the left edge of a clip
+	timelineDragEnd   // the right edge of a clip
+	timelineDragPan
+)
+
+const (
+	timelineRulerHeight  = 24
+	timelineTrackHeight  = 40
+	timelineEdgeSlop     = 5 // how close to a clip's edge counts as grabbing the edge
+	timelineSnapDistance = 6
+	timelineMinTickGap   = 50
+	timelineDefaultScale = 10 * time.Millisecond
+)
+
+type timeline struct {
+	Area
+
+	tracks   int
+	clips    map[int]*TimelineClip
+	order    []int
+	next     int
+	selected int // -1 if none
+
+	playhead time.Duration
+	scale    time.Duration
+	offset   time.Duration
+	snap     time.Duration
+
+	changed    func(c TimelineChange)
+	scrub      func(t time.Duration)
+	onselected *event
+
+	drag       timelineDrag
+	dragStart  image.Point
+	dragNow    image.Point
+	dragClip   int
+	dragOrig   TimelineClip
+	dragOffset time.Duration // for timelineDragPan
+}
+
+// NewTimeline creates a new Timeline of the given size with the given number of tracks and no clips.
+func NewTimeline(width int, height int, tracks int) Timeline {
+	if tracks < 0 {
+		panic(fmt.Errorf("invalid track count %d in NewTimeline()", tracks))
+	}
+	t := &timeline{
+		tracks:     tracks,
+		clips:      make(map[int]*TimelineClip),
+		selected:   -1,
+		scale:      timelineDefaultScale,
+		onselected: newEvent(),
+	}
+	t.Area = NewArea(width, height, t)
+	return t
+}
+
+func (t *timeline) Tracks() int {
+	return t.tracks
+}
+
+func (t *timeline) SetTracks(n int) {
+	if n < 0 {
+		panic(fmt.Errorf("invalid track count %d in Timeline.SetTracks()", n))
+	}
+	for id, c := range t.clips {
+		if c.Track >= n {
+			panic(fmt.Errorf("clip %d is on track %d, which would be removed by Timeline.SetTracks(%d)", id, c.Track, n))
+		}
+	}
+	t.tracks = n
+	t.RepaintAll()
+}
+
+func (t *timeline) checkClip(c TimelineClip, what string) {
+	if c.Track < 0 || c.Track >= t.tracks {
+		panic(fmt.Errorf("track %d out of range in Timeline.%s()", c.Track, what))
+	}
+	if c.Start < 0 || c.Length <= 0 {
+		panic(fmt.Errorf("invalid span (start %v, length %v) in Timeline.%s()", c.Start, c.Length, what))
+	}
+}
+
+func (t *timeline) clip(id int, what string) *TimelineClip {
+	c, ok := t.clips[id]
+	if !ok {
+		panic(fmt.Errorf("no clip with ID %d in Timeline.%s()", id, what))
+	}
+	return c
+}
+
+func (t *timeline) AddClip(c TimelineClip) (id int) {
+	t.checkClip(c, "AddClip")
+	id = t.next
+	t.next++
+	cc := c
+	t.clips[id] = &cc
+	t.order = append(t.order, id)
+	t.RepaintAll()
+	return id
+}
+
+func (t *timeline) Clip(id int) TimelineClip {
+	return *t.clip(id, "Clip")
+}
+
+func (t *timeline) SetClip(id int, c TimelineClip) {
+	t.checkClip(c, "SetClip")
+	*t.clip(id, "SetClip") = c
+	t.RepaintAll()
+}
+
+func (t *timeline) RemoveClip(id int) {
+	t.clip(id, "RemoveClip")
+	t.removeClip(id)
+}
+
+func (t *timeline) removeClip(id int) {
+	delete(t.clips, id)
+	for i, o := range t.order {
+		if o == id {
+			t.order = append(t.order[:i], t.order[i+1:]...)
+			break
+		}
+	}
+	if t.drag != timelineDragNone && t.dragClip == id {
+		t.drag = timelineDragNone
+	}
+	if t.selected == id {
+		t.selected = -1
+		t.onselected.fire()
+	}
+	t.RepaintAll()
+}
+
+func (t *timeline) Clips() []int {
+	return append([]int(nil), t.order...)
+}
+
+func (t *timeline) OnChanged(f func(c TimelineChange)) {
+	t.changed = f
+}
+
+func (t *timeline) notify(kind TimelineChangeKind, id int) {
+	if t.changed != nil {
+		t.changed(TimelineChange{Kind: kind, Clip: id})
+	}
+}
+
+func (t *timeline) Selected() (id int, ok bool) {
+	return t.selected, t.selected >= 0
+}
+
+func (t *timeline) Select(id int) {
+	if id >= 0 {
+		t.clip(id, "Select")
+	} else {
+		id = -1
+	}
+	t.selected = id
+	t.onselected.fire()
+	t.RepaintAll()
+}
+
+func (t *timeline) OnSelected(f func()) {
+	t.onselected.set(f)
+}
+
+func (t *timeline) Playhead() time.Duration {
+	return t.playhead
+}
+
+func (t *timeline) SetPlayhead(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	t.playhead = d
+	t.RepaintAll()
+}
+
+func (t *timeline) OnScrub(f func(t time.Duration)) {
+	t.scrub = f
+}
+
+func (t *timeline) Scale() time.Duration {
+	return t.scale
+}
+
+func (t *timeline) SetScale(scale time.Duration) {
+	t.scale = clampDuration(scale, time.Microsecond, time.Minute)
+	t.RepaintAll()
+}
+
+func (t *timeline) Offset() time.Duration {
+	return t.offset
+}
+
+func (t *timeline) SetOffset(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	t.offset = d
+	t.RepaintAll()
+}
+
+func (t *timeline) Snap() time.Duration {
+	return t.snap
+}
+
+func (t *timeline) SetSnap(interval time.Duration) {
+	if interval < 0 {
+		interval = 0
+	}
+	t.snap = interval
+}
+
+func clampDuration(d time.Duration, min time.Duration, max time.Duration) time.Duration {
+	if d < min {
+		return min
+	}
+	if d > max {
+		return max
+	}
+	return d
+}
+
+// coordinate conversion; x is in Area coordinates
+
+func (t *timeline) toX(d time.Duration) int {
+	return int((d - t.offset) / t.scale)
+}
+
+func (t *timeline) toTime(x int) time.Duration {
+	return t.offset + time.Duration(x)*t.scale
+}
+
+// trackAt returns the track under y, which may be out of range
+func (t *timeline) trackAt(y int) int {
+	y -= timelineRulerHeight
+	if y < 0 {
+		return (y - timelineTrackHeight + 1) / timelineTrackHeight
+	}
+	return y / timelineTrackHeight
+}
+
+func (t *timeline) clipRect(c *TimelineClip) image.Rectangle {
+	r := image.Rect(t.toX(c.Start), timelineRulerHeight+c.Track*timelineTrackHeight+2,
+		t.toX(c.Start+c.Length), timelineRulerHeight+(c.Track+1)*timelineTrackHeight-2)
+	if r.Dx() < 2 { // keep tiny clips visible and grabbable
+		r.Max.X = r.Min.X + 2
+	}
+	return r
+}
+
+// zoomAt changes the scale, keeping the time at x where it is
+func (t *timeline) zoomAt(x int, scale time.Duration) {
+	at := t.toTime(x)
+	t.scale = clampDuration(scale, time.Microsecond, time.Minute)
+	t.SetOffset(at - time.Duration(x)*t.scale)
+}
+
+// clipAt returns the topmost clip under p and which part of it was hit
+func (t *timeline) clipAt(p image.Point) (id int, part timelineDrag, ok bool) {
+	for i := len(t.order) - 1; i >= 0; i-- {
+		id := t.order[i]
+		r := t.clipRect(t.clips[id])
+		if !p.In(r) {
+			continue
+		}
+		// for narrow clips, leave the middle third for moving
+		slop := timelineEdgeSlop
+		if r.Dx()/3 < slop {
+			slop = r.Dx() / 3
+		}
+		switch {
+		case p.X < r.Min.X+slop:
+			return id, timelineDragStart, true
+		case p.X >= r.Max.X-slop:
+			return id, timelineDragEnd, true
+		}
+		return id, timelineDragMove, true
+	}
+	return 0, timelineDragNone, false
+}
+
+// snapTime returns where an edge at d snaps to, and how far that is from d
+func (t *timeline) snapTime(d time.Duration) (time.Duration, time.Duration) {
+	abs := func(d time.Duration) time.Duration {
+		if d < 0 {
+			return -d
+		}
+		return d
+	}
+
+	best := d
+	bestDist := time.Duration(timelineSnapDistance) * t.scale
+	found := false
+	try := func(to time.Duration) {
+		if dist := abs(to - d); dist <= bestDist {
+			best, bestDist, found = to, dist, true
+		}
+	}
+	try(t.playhead)
+	for id, c := range t.clips {
+		if id != t.dragClip {
+			try(c.Start)
+			try(c.Start + c.Length)
+		}
+	}
+	if !found && t.snap > 0 {
+		best = (d + t.snap/2) / t.snap * t.snap
+	}
+	return best, abs(best - d)
+}
+
+func (t *timeline) movePlayhead(x int) {
+	t.SetPlayhead(t.toTime(x))
+	if t.scrub != nil {
+		t.scrub(t.playhead)
+	}
+}
+
+func (t *timeline) Mouse(me MouseEvent) {
+	switch {
+	case me.Down == 1:
+		t.mouseDown(me)
+	case me.Down == 2:
+		t.drag = timelineDragPan
+		t.dragStart = me.Pos
+		t.dragOffset = t.offset
+	case me.Up != 0:
+		t.mouseUp(me)
+	case me.Down == 0 && len(me.Held) != 0:
+		t.mouseDrag(me)
+	}
+}
+
+func (t *timeline) mouseDown(me MouseEvent) {
+	t.dragStart = me.Pos
+	t.dragNow = me.Pos
+	if me.Pos.Y < timelineRulerHeight {
+		t.drag = timelineDragPlayhead
+		t.movePlayhead(me.Pos.X)
+		return
+	}
+	id, part, ok := t.clipAt(me.Pos)
+	if !ok {
+		if t.selected >= 0 {
+			t.Select(-1)
+		}
+		return
+	}
+	if t.selected != id {
+		t.Select(id)
+	}
+	t.drag = part
+	t.dragClip = id
+	t.dragOrig = *t.clips[id]
+}
+
+func (t *timeline) mouseDrag(me MouseEvent) {
+	t.dragNow = me.Pos
+	switch t.drag {
+	case timelineDragPlayhead:
+		t.movePlayhead(me.Pos.X)
+		return
+	case timelineDragPan:
+		t.SetOffset(t.dragOffset - time.Duration(me.Pos.X-t.dragStart.X)*t.scale)
+		return
+	case timelineDragNone:
+		return
+	}
+
+	snap := (me.Modifiers & Alt) == 0
+	delta := time.Duration(me.Pos.X-t.dragStart.X) * t.scale
+	minLength := 4 * t.scale
+	c := t.dragOrig
+	switch t.drag {
+	case timelineDragMove:
+		c.Start += delta
+		if snap {
+			// snap whichever edge is closer to something
+			s, ds := t.snapTime(c.Start)
+			e, de := t.snapTime(c.Start + c.Length)
+			if ds <= de {
+				c.Start = s
+			} else {
+				c.Start = e - c.Length
+			}
+		}
+		c.Track += t.trackAt(me.Pos.Y) - t.trackAt(t.dragStart.Y)
+		if c.Track < 0 {
+			c.Track = 0
+		}
+		if c.Track >= t.tracks {
+			c.Track = t.tracks - 1
+		}
+	case timelineDragStart:
+		end := c.Start + c.Length
+		c.Start += delta
+		if snap {
+			c.Start, _ = t.snapTime(c.Start)
+		}
+		if c.Start > end-minLength {
+			c.Start = end - minLength
+		}
+		c.Length = end - c.Start
+	case timelineDragEnd:
+		end := c.Start + c.Length + delta
+		if snap {
+			end, _ = t.snapTime(end)
+		}
+		c.Length = end - c.Start
+		if c.Length < minLength {
+			c.Length = minLength
+		}
+	}
+	if c.Start < 0 {
+		if t.drag == timelineDragStart {
+			c.Length += c.Start
+		}
+		c.Start = 0
+	}
+	*t.clips[t.dragClip] = c
+	t.RepaintAll()
+}
+
+func (t *timeline) mouseUp(me MouseEvent) {
+	drag := t.drag
+	t.drag = timelineDragNone
+	if drag != timelineDragMove && drag != timelineDragStart && drag != timelineDragEnd {
+		return
+	}
+	c, ok := t.clips[t.dragClip]
+	if !ok || *c == t.dragOrig {
+		return
+	}
+	if drag == timelineDragMove {
+		t.notify(TimelineClipMoved, t.dragClip)
+	} else {
+		t.notify(TimelineClipResized, t.dragClip)
+	}
+}
+
+func (t *timeline) Key(ke KeyEvent) bool {
+	if ke.Up {
+		return false
+	}
+	if ke.ExtKey == Delete || ke.Key == '\b' {
+		id, ok := t.Selected()
+		if !ok {
+			return false
+		}
+		t.removeClip(id)
+		t.notify(TimelineClipRemoved, id)
+		return true
+	}
+	if (ke.Modifiers & Ctrl) != 0 {
+		x := t.toX(t.playhead)
+		switch ke.Key {
+		case '=':
+			t.zoomAt(x, t.scale*4/5)
+			return true
+		case '-':
+			t.zoomAt(x, t.scale*5/4+1)
+			return true
+		case '0':
+			t.zoomAt(x, timelineDefaultScale)
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	timelineBackground = color.RGBA{0xF0, 0xF0, 0xF0, 0xFF}
+	timelineTrackAlt   = color.RGBA{0xE4, 0xE4, 0xE4, 0xFF}
+	timelineRuler      = color.RGBA{0xD0, 0xD0, 0xD0, 0xFF}
+	timelineTick       = color.RGBA{0x60, 0x60, 0x60, 0xFF}
+	timelineClipFill   = color.RGBA{0x80, 0xA8, 0xE0, 0xFF}
+	timelineClipBorder = color.RGBA{0x40, 0x40, 0x40, 0xFF}
+	timelineSelected   = color.RGBA{0x30, 0x70, 0xD0, 0xFF}
+	timelinePlayhead   = color.RGBA{0xD0, 0x20, 0x20, 0xFF}
+)
+
+// the ruler's tick spacing is the first of these that is at least timelineMinTickGap pixels wide
+var timelineTickSteps = []time.Duration{
+	time.Microsecond, 2 * time.Microsecond, 5 * time.Microsecond,
+	10 * time.Microsecond, 20 * time.Microsecond, 50 * time.Microsecond,
+	100 * time.Microsecond, 200 * time.Microsecond, 500 * time.Microsecond,
+	time.Millisecond, 2 * time.Millisecond, 5 * time.Millisecond,
+	10 * time.Millisecond, 20 * time.Millisecond, 50 * time.Millisecond,
+	100 * time.Millisecond, 200 * time.Millisecond, 500 * time.Millisecond,
+	time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second, 30 * time.Second,
+	time.Minute, 2 * time.Minute, 5 * time.Minute, 10 * time.Minute, 30 * time.Minute,
+	time.Hour,
+}
+
+func (t *timeline) Paint(cliprect image.Rectangle) image.Image {
+	img := image.NewRGBA(cliprect)
+	draw.Draw(img, cliprect, &image.Uniform{timelineBackground}, image.ZP, draw.Src)
+	for i := 1; i < t.tracks; i += 2 {
+		r := image.Rect(cliprect.Min.X, timelineRulerHeight+i*timelineTrackHeight,
+			cliprect.Max.X, timelineRulerHeight+(i+1)*timelineTrackHeight)
+		draw.Draw(img, r, &image.Uniform{timelineTrackAlt}, image.ZP, draw.Src)
+	}
+	t.drawRuler(img)
+	for _, id := range t.order {
+		t.drawClip(img, t.clips[id], id == t.selected)
+	}
+	x := t.toX(t.playhead)
+	draw.Draw(img, image.Rect(x, 0, x+1, cliprect.Max.Y), &image.Uniform{timelinePlayhead}, image.ZP, draw.Src)
+	for i := 0; i < 6; i++ { // a small triangle in the ruler
+		draw.Draw(img, image.Rect(x-5+i, i, x+6-i, i+1), &image.Uniform{timelinePlayhead}, image.ZP, draw.Src)
+	}
+	return img
+}
+
+func (t *timeline) drawRuler(img *image.RGBA) {
+	r := image.Rect(img.Rect.Min.X, 0, img.Rect.Max.X, timelineRulerHeight)
+	if !r.Overlaps(img.Rect) {
+		return
+	}
+	draw.Draw(img, r, &image.Uniform{timelineRuler}, image.ZP, draw.Src)
+	step := timelineTickSteps[len(timelineTickSteps)-1]
+	for _, s := range timelineTickSteps {
+		if s/t.scale >= timelineMinTickGap {
+			step = s
+			break
+		}
+	}
+	// every fifth tick is a long one
+	first := t.toTime(img.Rect.Min.X) / step
+	for n := first; ; n++ {
+		x := t.toX(n * step)
+		if x >= img.Rect.Max.X {
+			break
+		}
+		h := timelineRulerHeight / 3
+		if n%5 == 0 {
+			h = timelineRulerHeight * 2 / 3
+		}
+		draw.Draw(img, image.Rect(x, timelineRulerHeight-h, x+1, timelineRulerHeight), &image.Uniform{timelineTick}, image.ZP, draw.Src)
+	}
+	draw.Draw(img, image.Rect(r.Min.X, timelineRulerHeight-1, r.Max.X, timelineRulerHeight), &image.Uniform{timelineTick}, image.ZP, draw.Src)
+}
+
+func (t *timeline) drawClip(img *image.RGBA, c *TimelineClip, selected bool) {
+	r := t.clipRect(c)
+	if !r.Overlaps(img.Rect) {
+		return
+	}
+	fill := c.Color
+	if fill == (color.RGBA{}) {
+		fill = timelineClipFill
+	}
+	draw.Draw(img, r, &image.Uniform{fill}, image.ZP, draw.Src)
+	if c.Label != nil {
+		lr := c.Label.Rect.Sub(c.Label.Rect.Min).Add(r.Min.Add(image.Pt(4, 4))).Intersect(r.Inset(1))
+		draw.Draw(img, lr, c.Label, c.Label.Rect.Min, draw.Over)
+	}
+	if selected {
+		strokeRect(img, r, 2, timelineSelected)
+	} else {
+		strokeRect(img, r, 1, timelineClipBorder)
+	}
+}
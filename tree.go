@@ -0,0 +1,512 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"fmt"
+	"image"
+	"sync"
+)
+
+// TreePath identifies a node of a Tree by the index of the node and each of its ancestors among their siblings, outermost first.
+// For example, TreePath{2, 0} is the first child of the third top-level node.
+// The empty (nil) TreePath refers to the invisible root whose children are the top-level nodes.
+type TreePath []int
+
+// TreeModel provides the data for a Tree.
+// All methods of TreeModel are called with the Tree's read lock held.
+// As with Table, make changes to the model between Tree.Lock() and Tree.Unlock() so the Tree can update itself.
+type TreeModel interface {
+	// NumChildren returns the number of children of the given node.
+	// NumChildren(nil) returns the number of top-level nodes.
+	NumChildren(parent TreePath) int
+
+	// NodeText returns the text shown for the given node.
+	NodeText(node TreePath) string
+
+	// NodeImage returns the icon shown next to the given node, or nil for no icon.
+	// As with Table, any image.Image will do, and the image is converted and resized to an implementation-defined icon size if needed.
+	NodeImage(node TreePath) image.Image
+}
+
+// TreeLoader is an optional interface a TreeModel can implement to load the children of nodes only when they are first expanded, for data that is slow to get, such as a directory tree on a network share.
+type TreeLoader interface {
+	// NeedsLoad reports whether the children of node have yet to be loaded.
+	// While it returns true and NumChildren(node) returns 0, the node can be expanded and has a single placeholder child that reads "Loading..." (with a busy indicator, where the system has one).
+	// The placeholder is not part of the model: it is never selected and never passed to any TreeModel method or event handler.
+	// NeedsLoad is not asked about the invisible root; load the top-level nodes before creating the Tree.
+	NeedsLoad(node TreePath) bool
+
+	// LoadChildren is called on a new goroutine, without the lock held, when a node for which NeedsLoad returns true is expanded.
+	// It does the slow work of getting the children and returns a function that adds them to the model.
+	// The Tree calls that function on the main thread with the write lock held and then updates itself as with Unlock(); afterward NeedsLoad(node) should return false.
+	// If the node is collapsed before LoadChildren returns, cancel is closed and whatever LoadChildren returns is ignored; LoadChildren should stop as soon as it can.
+	// The node will be loaded again the next time it is expanded.
+	// If LoadChildren returns nil (for instance, because of an error), the node is collapsed, so the user can try again.
+	// node is the path of the node at the time LoadChildren was called; models that change while a load is in progress need to account for that in the returned function.
+	LoadChildren(node TreePath, cancel <-chan struct{}) (commit func())
+}
+
+// TreeIdentifier is an optional interface a TreeModel can implement to give its nodes IDs that stay the same when nodes are added, removed, or moved.
+// With IDs, a Tree keeps nodes expanded and selected across updates even when their paths change, and its expansion and selection can be saved with Tree.State and restored later with Tree.SetState, even by a later run of the program.
+type TreeIdentifier interface {
+	// NodeID returns the ID of node.
+	// Every node must have a different ID; the empty string is not a valid ID.
+	NodeID(node TreePath) string
+}
+
+// TreeState is the expansion and selection of a Tree, by node ID; see TreeIdentifier.
+// It has only exported fields of basic types, so it can be saved with encoding/json or the like.
+type TreeState struct {
+	Expanded []string
+	Selected string // empty if nothing is selected
+}
+
+// Tree is a Control that displays hierarchical data as an outline that the user can expand and collapse.
+// Trees get their data from a TreeModel, which can load the children of nodes on demand by also implementing TreeLoader.
+//
+// Trees maintain a sync.RWMutex-compatible sync.Locker for their model, as Table does; use Tree.Lock()/Tree.Unlock() to make changes and Tree.RLock()/Tree.RUnlock() to merely read values.
+// When the Tree updates after Unlock(), expanded nodes and the selected node stay that way if their paths still exist in the model, or, if the model implements TreeIdentifier, wherever nodes with the same IDs now are.
+type Tree interface {
+	Control
+
+	// Lock and Unlock lock and unlock the model for reading or writing.
+	// RLock and RUnlock lock and unlock the model for reading only.
+	// These methods have identical semantics to the analogous methods of sync.RWMutex.
+	// In addition, Unlock() will request an update of the Tree to account for whatever was changed.
+	Lock()
+	Unlock()
+	RLock()
+	RUnlock()
+
+	// Model returns the TreeModel passed to NewTree.
+	Model() TreeModel
+
+	// Selected and Select get and set the currently selected node.
+	// Selected returns nil if no node is selected.
+	// Pass nil to Select to deselect all nodes.
+	// Select expands the ancestors of the node if needed.
+	Selected() TreePath
+	Select(path TreePath)
+
+	// Expand and Collapse expand and collapse the given node.
+	// Expand also expands every ancestor of the node.
+	Expand(path TreePath)
+	Collapse(path TreePath)
+
+	// OnSelected is an event that gets triggered after the selection in the Tree changes in whatever way (node selected or node deselected).
+	OnSelected(f func())
+
+	// OnActivated is an event that gets triggered when the user activates the selected node, usually by double-clicking it or pressing Enter.
+	OnActivated(f func())
+
+	// OnExpanded and OnCollapsed register functions that are called with the path of a node after it is expanded or collapsed, whether by the user or by a call to Expand or Collapse.
+	OnExpanded(f func(path TreePath))
+	OnCollapsed(f func(path TreePath))
+
+	// OnHoverPreview registers a function that is called when the mouse rests over a node of the Tree.
+	// It works just like Table.OnHoverPreview.
+	OnHoverPreview(f func(node TreePath) image.Image)
+
+	// State returns the IDs of the expanded nodes and of the selected node.
+	// SetState expands the nodes and selects the node with the IDs in s; IDs that are not in the model are ignored, and nodes that are already expanded are left that way.
+	// NodePath returns the path of the node with the given ID, or nil if there is none; it may have to look through the whole model to find it.
+	// All three panic if the Tree's TreeModel does not implement TreeIdentifier.
+	State() TreeState
+	SetState(s TreeState)
+	NodePath(id string) TreePath
+
+	expandedNodes() []TreePath // implemented per-platform; the expanded nodes as the view has them, which can be out of date with the model until the update after Unlock()
+}
+
+type treebase struct {
+	lock      sync.RWMutex
+	model     TreeModel
+	selected  *event
+	activated *event
+
+	// these take arguments, so they can't be events; they are only ever touched on the main thread
+	expanded     func(path TreePath)
+	collapsed    func(path TreePath)
+	hoverpreview func(node TreePath) image.Image
+
+	// the TreeLoader loads in progress; also only touched on the main thread
+	loads []*treeLoad
+	tree  Tree // the backend Tree, to update it when a load is done
+
+	// the IDs of the nodes the view has shown since it was last rebuilt, keyed by TreePath.key(), so the view's state can be carried across a rebuild even though the model has already changed by then
+	// only used if the model implements TreeIdentifier; only touched on the main thread
+	ids map[string]string
+}
+
+type treeLoad struct {
+	node   TreePath
+	cancel chan struct{}
+}
+
+// the text of the placeholder child of a node whose children are loading; see TreeLoader
+const treePlaceholderText = "Loading..."
+
+// NewTree creates a new Tree that shows the data in the given TreeModel.
+func NewTree(model TreeModel) Tree {
+	if model == nil {
+		panic("TreeModel passed to NewTree() cannot be nil")
+	}
+	b := &treebase{
+		model:     model,
+		selected:  newEvent(),
+		activated: newEvent(),
+	}
+	b.tree = finishNewTree(b)
+	return b.tree
+}
+
+func (b *treebase) Lock() {
+	b.lock.Lock()
+}
+
+// Unlock() is defined on each backend implementation of Tree
+// they should all call this, however
+func (b *treebase) unlock() {
+	b.lock.Unlock()
+}
+
+func (b *treebase) RLock() {
+	b.lock.RLock()
+}
+
+func (b *treebase) RUnlock() {
+	b.lock.RUnlock()
+}
+
+func (b *treebase) Model() TreeModel {
+	return b.model
+}
+
+func (b *treebase) OnSelected(f func()) {
+	b.selected.set(f)
+}
+
+func (b *treebase) OnActivated(f func()) {
+	b.activated.set(f)
+}
+
+func (b *treebase) OnExpanded(f func(path TreePath)) {
+	b.expanded = f
+}
+
+func (b *treebase) OnCollapsed(f func(path TreePath)) {
+	b.collapsed = f
+}
+
+func (b *treebase) OnHoverPreview(f func(node TreePath) image.Image) {
+	b.hoverpreview = f
+}
+
+// firePreview returns nil if there is no preview to show
+func (b *treebase) firePreview(node TreePath) *image.RGBA {
+	if b.hoverpreview == nil {
+		return nil
+	}
+	b.RLock()
+	placeholder := b.isPlaceholder(node)
+	b.RUnlock()
+	if placeholder {
+		return nil
+	}
+	return toRGBA(b.hoverpreview(node))
+}
+
+// the backends call these for every expansion and collapse that isn't part of a rebuild, so loads start and stop here too
+func (b *treebase) fireExpanded(path TreePath) {
+	b.startLoad(path)
+	if b.expanded != nil {
+		b.expanded(path)
+	}
+}
+
+func (b *treebase) fireCollapsed(path TreePath) {
+	b.cancelLoads(path)
+	if b.collapsed != nil {
+		b.collapsed(path)
+	}
+}
+
+// needsPlaceholder reports whether node should get a placeholder child; see TreeLoader.
+// The caller must hold the read lock.
+func (b *treebase) needsPlaceholder(node TreePath) bool {
+	l, ok := b.model.(TreeLoader)
+	return ok && len(node) != 0 && b.model.NumChildren(node) == 0 && l.NeedsLoad(node)
+}
+
+// isPlaceholder reports whether path refers to a placeholder child rather than to a node of the model.
+// The caller must hold the read lock.
+func (b *treebase) isPlaceholder(path TreePath) bool {
+	return len(path) != 0 && path[len(path)-1] == 0 && b.needsPlaceholder(path[:len(path)-1])
+}
+
+func (b *treebase) startLoad(node TreePath) {
+	b.RLock()
+	needs := b.needsPlaceholder(node)
+	b.RUnlock()
+	if !needs {
+		return
+	}
+	for _, l := range b.loads {
+		if l.node.equal(node) { // already loading
+			return
+		}
+	}
+	l := &treeLoad{
+		node:   node,
+		cancel: make(chan struct{}),
+	}
+	b.loads = append(b.loads, l)
+	loader := b.model.(TreeLoader)
+	go func() {
+		commit := loader.LoadChildren(node, l.cancel)
+		Do(func() {
+			if !b.finishLoad(l) { // canceled
+				return
+			}
+			if commit == nil {
+				b.tree.Collapse(node)
+				return
+			}
+			b.Lock()
+			commit()
+			b.tree.Unlock()
+		})
+	}()
+}
+
+// finishLoad removes l from the loads in progress and returns false if it was already canceled
+func (b *treebase) finishLoad(l *treeLoad) bool {
+	for i := range b.loads {
+		if b.loads[i] == l {
+			b.loads = append(b.loads[:i], b.loads[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// collapsing a node hides all of its descendants, so their loads are canceled too
+func (b *treebase) cancelLoads(node TreePath) {
+	n := 0
+	for _, l := range b.loads {
+		if len(l.node) >= len(node) && l.node[:len(node)].equal(node) {
+			close(l.cancel)
+			continue
+		}
+		b.loads[n] = l
+		n++
+	}
+	b.loads = b.loads[:n]
+}
+
+func (b *treebase) identifier() TreeIdentifier {
+	idm, ok := b.model.(TreeIdentifier)
+	if !ok {
+		panic(fmt.Errorf("TreeModel %T used with Tree.State(), Tree.SetState(), or Tree.NodePath() does not implement TreeIdentifier", b.model))
+	}
+	return idm
+}
+
+// rememberID is called by the backends whenever the view gets a node from the model.
+// The caller must hold the read lock.
+func (b *treebase) rememberID(node TreePath) {
+	idm, ok := b.model.(TreeIdentifier)
+	if !ok {
+		return
+	}
+	if b.ids == nil {
+		b.ids = make(map[string]string)
+	}
+	b.ids[node.key()] = idm.NodeID(node)
+}
+
+// idOf returns the ID of node as the view knows it.
+// The caller must hold the read lock.
+func (b *treebase) idOf(idm TreeIdentifier, node TreePath) string {
+	if id, ok := b.ids[node.key()]; ok {
+		return id
+	}
+	return idm.NodeID(node)
+}
+
+func (b *treebase) State() TreeState {
+	var s TreeState
+
+	idm := b.identifier()
+	b.RLock()
+	defer b.RUnlock()
+	for _, p := range b.tree.expandedNodes() {
+		s.Expanded = append(s.Expanded, b.idOf(idm, p))
+	}
+	if p := b.tree.Selected(); p != nil {
+		s.Selected = b.idOf(idm, p)
+	}
+	return s
+}
+
+func (b *treebase) SetState(s TreeState) {
+	b.identifier()
+	b.RLock()
+	expanded, selected := b.findIDs(s)
+	b.RUnlock()
+	// Expand() and Select() can cause events that take the lock themselves
+	for _, p := range expanded {
+		b.tree.Expand(p)
+	}
+	if selected != nil {
+		b.tree.Select(selected)
+	}
+}
+
+func (b *treebase) NodePath(id string) TreePath {
+	b.identifier()
+	b.RLock()
+	defer b.RUnlock()
+	return b.nodePath(id)
+}
+
+// the caller must hold the read lock
+func (b *treebase) nodePath(id string) TreePath {
+	idm := b.model.(TreeIdentifier)
+	var find func(parent TreePath) TreePath
+	find = func(parent TreePath) TreePath {
+		n := b.model.NumChildren(parent)
+		for i := 0; i < n; i++ {
+			p := parent.child(i)
+			if idm.NodeID(p) == id {
+				return p
+			}
+			if found := find(p); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	return find(nil)
+}
+
+// findIDs returns the paths of the nodes in s.
+// Expanded nodes are only looked for inside other expanded nodes, so this doesn't have to walk the whole model.
+// The caller must hold the read lock.
+func (b *treebase) findIDs(s TreeState) (expanded []TreePath, selected TreePath) {
+	idm := b.model.(TreeIdentifier)
+	want := make(map[string]bool, len(s.Expanded))
+	for _, id := range s.Expanded {
+		want[id] = true
+	}
+	var walk func(parent TreePath)
+	walk = func(parent TreePath) {
+		n := b.model.NumChildren(parent)
+		for i := 0; i < n; i++ {
+			p := parent.child(i)
+			id := idm.NodeID(p)
+			if s.Selected != "" && id == s.Selected {
+				selected = p
+			}
+			if want[id] {
+				expanded = append(expanded, p)
+				walk(p)
+			}
+		}
+	}
+	walk(nil)
+	if selected == nil && s.Selected != "" {
+		selected = b.nodePath(s.Selected)
+	}
+	return expanded, selected
+}
+
+// treeViewState is what a rebuild carries over from the old view to the new one
+type treeViewState struct {
+	expanded []TreePath
+	selected TreePath
+	ids      *TreeState // nil if the model does not implement TreeIdentifier
+}
+
+// saveView gets the state of the view before a rebuild and forgets the old IDs.
+// The caller must hold the read lock.
+func (b *treebase) saveView() *treeViewState {
+	s := &treeViewState{
+		expanded: b.tree.expandedNodes(),
+		selected: b.tree.Selected(),
+	}
+	if idm, ok := b.model.(TreeIdentifier); ok {
+		s.ids = new(TreeState)
+		for _, p := range s.expanded {
+			s.ids.Expanded = append(s.ids.Expanded, b.idOf(idm, p))
+		}
+		if s.selected != nil {
+			s.ids.Selected = b.idOf(idm, s.selected)
+		}
+	}
+	b.ids = nil
+	return s
+}
+
+// restoreView puts the state saved by saveView() back after a rebuild and returns whether the selection changed.
+// The caller must hold the read lock.
+func (b *treebase) restoreView(s *treeViewState) bool {
+	if s.ids == nil {
+		for _, p := range s.expanded {
+			if b.validPath(p) {
+				b.tree.Expand(p)
+			}
+		}
+		if s.selected != nil && b.validPath(s.selected) {
+			b.tree.Select(s.selected)
+		}
+		return !s.selected.equal(b.tree.Selected())
+	}
+	expanded, selected := b.findIDs(*s.ids)
+	for _, p := range expanded {
+		b.tree.Expand(p)
+	}
+	if selected != nil {
+		b.tree.Select(selected)
+	}
+	// a node that moved is still the same node
+	return (s.ids.Selected != "" && selected == nil) || !selected.equal(b.tree.Selected())
+}
+
+// validPath reports whether path still refers to a node in the model.
+// The caller must hold the read lock.
+func (b *treebase) validPath(path TreePath) bool {
+	for i := range path {
+		if path[i] < 0 || path[i] >= b.model.NumChildren(path[:i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// child returns the path of the nth child of parent.
+// The returned path does not share storage with parent.
+func (p TreePath) child(n int) TreePath {
+	c := make(TreePath, len(p)+1)
+	copy(c, p)
+	c[len(p)] = n
+	return c
+}
+
+// key returns a string that can be used to key maps by TreePath
+func (p TreePath) key() string {
+	return fmt.Sprint([]int(p))
+}
+
+func (p TreePath) equal(q TreePath) bool {
+	if len(p) != len(q) {
+		return false
+	}
+	for i := range p {
+		if p[i] != q[i] {
+			return false
+		}
+	}
+	return true
+}
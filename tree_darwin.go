@@ -0,0 +1,220 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"unsafe"
+)
+
+// #include "objc_darwin.h"
+import "C"
+
+type tree struct {
+	*treebase
+
+	*scroller
+
+	// set while reloading so the reload doesn't generate events
+	rebuilding bool
+}
+
+func finishNewTree(b *treebase) Tree {
+	id := C.newTree()
+	t := &tree{
+		scroller: newScroller(id, true), // border on Tree
+		treebase: b,
+	}
+	t.fpreferredSize = t.xpreferredSize
+	// also sets the delegate
+	C.treeMakeDataSource(t.id, unsafe.Pointer(t))
+	C.addHoverPreview(t.id, unsafe.Pointer(t), C.YES)
+	return t
+}
+
+func (t *tree) Unlock() {
+	t.unlock()
+	// there's a possibility that user actions can happen at this point, before the view is updated
+	// alas, this is something we have to deal with, because Unlock() can be called from any thread
+	go func() {
+		Do(func() {
+			t.RLock()
+			defer t.RUnlock()
+			t.rebuild()
+		})
+	}()
+}
+
+func (t *tree) rebuild() {
+	s := t.saveView()
+	t.rebuilding = true
+	C.treeReload(t.id)
+	changed := t.restoreView(s)
+	t.rebuilding = false
+	if changed {
+		t.selected.fire()
+	}
+}
+
+// this has to look at what NSOutlineView has now, not at the model, which may have already changed
+func (t *tree) expandedNodes() []TreePath {
+	var expanded []TreePath
+
+	C.treeExpandedPaths(t.id, unsafe.Pointer(&expanded))
+	return expanded
+}
+
+func fromIndexes(indexes *C.intptr_t, n C.intptr_t) TreePath {
+	if n == 0 {
+		return nil
+	}
+	ind := (*[1 << 16]C.intptr_t)(unsafe.Pointer(indexes))[:n:n]
+	p := make(TreePath, n)
+	for i := range p {
+		p[i] = int(ind[i])
+	}
+	return p
+}
+
+// the returned pointer is only valid as long as p is
+func toIndexes(p TreePath) (*C.intptr_t, C.intptr_t) {
+	if len(p) == 0 {
+		return nil, 0
+	}
+	ind := make([]C.intptr_t, len(p))
+	for i := range p {
+		ind[i] = C.intptr_t(p[i])
+	}
+	return &ind[0], C.intptr_t(len(ind))
+}
+
+func (t *tree) Selected() TreePath {
+	var n C.intptr_t
+
+	indexes := C.treeSelected(t.id, &n)
+	if indexes == nil {
+		return nil
+	}
+	defer C.free(unsafe.Pointer(indexes))
+	return fromIndexes(indexes, n)
+}
+
+func (t *tree) Select(path TreePath) {
+	indexes, n := toIndexes(path)
+	C.treeSelect(t.id, indexes, n)
+}
+
+func (t *tree) Expand(path TreePath) {
+	indexes, n := toIndexes(path)
+	C.treeExpand(t.id, indexes, n, C.YES)
+}
+
+func (t *tree) Collapse(path TreePath) {
+	indexes, n := toIndexes(path)
+	C.treeExpand(t.id, indexes, n, C.NO)
+}
+
+//export goTreeDataSource_numChildren
+func goTreeDataSource_numChildren(data unsafe.Pointer, indexes *C.intptr_t, n C.intptr_t) C.intptr_t {
+	t := (*tree)(data)
+	t.RLock()
+	defer t.RUnlock()
+	p := fromIndexes(indexes, n)
+	if t.isPlaceholder(p) {
+		return 0
+	}
+	// NSOutlineView asks this of every node it shows
+	if p != nil {
+		t.rememberID(p)
+	}
+	if t.needsPlaceholder(p) {
+		return 1
+	}
+	return C.intptr_t(t.model.NumChildren(p))
+}
+
+//export goTreeDataSource_text
+func goTreeDataSource_text(data unsafe.Pointer, indexes *C.intptr_t, n C.intptr_t) *C.char {
+	t := (*tree)(data)
+	t.RLock()
+	defer t.RUnlock()
+	p := fromIndexes(indexes, n)
+	// freed on the Objective-C side
+	// NSOutlineView has no busy indicator of its own, so placeholders are just text
+	if t.isPlaceholder(p) {
+		return C.CString(treePlaceholderText)
+	}
+	return C.CString(t.model.NodeText(p))
+}
+
+//export goTreeDataSource_image
+func goTreeDataSource_image(data unsafe.Pointer, indexes *C.intptr_t, n C.intptr_t) C.id {
+	t := (*tree)(data)
+	t.RLock()
+	defer t.RUnlock()
+	p := fromIndexes(indexes, n)
+	if t.isPlaceholder(p) {
+		return nil
+	}
+	d := toRGBA(t.model.NodeImage(p))
+	if d == nil {
+		return nil
+	}
+	return C.toTableImage(unsafe.Pointer(pixelData(d)), C.intptr_t(d.Rect.Dx()), C.intptr_t(d.Rect.Dy()), C.intptr_t(d.Stride))
+}
+
+//export goTreeDataSource_selectable
+func goTreeDataSource_selectable(data unsafe.Pointer, indexes *C.intptr_t, n C.intptr_t) C.BOOL {
+	t := (*tree)(data)
+	t.RLock()
+	defer t.RUnlock()
+	return toBOOL(!t.isPlaceholder(fromIndexes(indexes, n)))
+}
+
+//export treeAppendExpanded
+func treeAppendExpanded(out unsafe.Pointer, indexes *C.intptr_t, n C.intptr_t) {
+	paths := (*[]TreePath)(out)
+	*paths = append(*paths, fromIndexes(indexes, n))
+}
+
+//export treeSelectionChanged
+func treeSelectionChanged(data unsafe.Pointer) {
+	t := (*tree)(data)
+	if t.rebuilding {
+		return
+	}
+	t.selected.fire()
+}
+
+//export treeActivated
+func treeActivated(data unsafe.Pointer) {
+	t := (*tree)(data)
+	t.activated.fire()
+}
+
+//export treeExpandedChanged
+func treeExpandedChanged(data unsafe.Pointer, indexes *C.intptr_t, n C.intptr_t, expanded C.BOOL) {
+	t := (*tree)(data)
+	if t.rebuilding {
+		return
+	}
+	if fromBOOL(expanded) {
+		t.fireExpanded(fromIndexes(indexes, n))
+	} else {
+		t.fireCollapsed(fromIndexes(indexes, n))
+	}
+}
+
+//export treeHoverPreview
+func treeHoverPreview(data unsafe.Pointer, row C.intptr_t) C.id {
+	var n C.intptr_t
+
+	t := (*tree)(data)
+	indexes := C.treeRowIndexes(t.id, row, &n)
+	defer C.free(unsafe.Pointer(indexes))
+	return toPreviewImage(t.firePreview(fromIndexes(indexes, n)))
+}
+
+func (t *tree) xpreferredSize(d *sizing) (width, height int) {
+	s := C.treePreferredSize(t.id)
+	return int(s.width), int(s.height)
+}
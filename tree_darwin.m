@@ -0,0 +1,313 @@
+// 14 october 2026
+
+#import "objc_darwin.h"
+#import "_cgo_export.h"
+#import <Cocoa/Cocoa.h>
+
+#define toNSOutlineView(x) ((NSOutlineView *) (x))
+
+// NSOutlineView compares items by pointer, so every node needs exactly one object for as long as the outline view knows about it
+// we use NSIndexPaths, which are our TreePaths in Objective-C form, and keep them unique with a dictionary that is emptied on each reload
+
+// returns a malloc()'d array that the caller must free(), or NULL for the root
+static intptr_t *toIndexes(NSIndexPath *path, intptr_t *n)
+{
+	NSUInteger i, len;
+	intptr_t *ind;
+
+	*n = 0;
+	if (path == nil)
+		return NULL;
+	len = [path length];
+	ind = (intptr_t *) malloc(len * sizeof (intptr_t));
+	if (ind == NULL)
+		[NSException raise:@"memory exhausted converting NSIndexPath in Tree" format:@""];
+	for (i = 0; i < len; i++)
+		ind[i] = (intptr_t) [path indexAtPosition:i];
+	*n = (intptr_t) len;
+	return ind;
+}
+
+@interface goTreeDataSource : NSObject <NSOutlineViewDataSource, NSOutlineViewDelegate> {
+@public
+	void *gotree;
+	NSMutableDictionary *items;
+}
+@end
+
+@implementation goTreeDataSource
+
+- (NSIndexPath *)uniqueItem:(NSIndexPath *)path
+{
+	NSIndexPath *p;
+
+	p = (NSIndexPath *) [self->items objectForKey:path];
+	if (p == nil) {
+		[self->items setObject:path forKey:path];
+		p = path;
+	}
+	return p;
+}
+
+- (NSIndexPath *)itemForIndexes:(intptr_t *)indexes count:(intptr_t)n
+{
+	NSUInteger *ind;
+	NSIndexPath *p;
+	intptr_t i;
+
+	ind = (NSUInteger *) malloc(n * sizeof (NSUInteger));
+	if (ind == NULL)
+		[NSException raise:@"memory exhausted converting TreePath in Tree" format:@""];
+	for (i = 0; i < n; i++)
+		ind[i] = (NSUInteger) indexes[i];
+	p = [NSIndexPath indexPathWithIndexes:ind length:((NSUInteger) n)];
+	free(ind);
+	return [self uniqueItem:p];
+}
+
+- (NSInteger)outlineView:(NSOutlineView *)ov numberOfChildrenOfItem:(id)item
+{
+	intptr_t *ind;
+	intptr_t n, ret;
+
+	ind = toIndexes((NSIndexPath *) item, &n);
+	ret = goTreeDataSource_numChildren(self->gotree, ind, n);
+	free(ind);
+	return (NSInteger) ret;
+}
+
+- (id)outlineView:(NSOutlineView *)ov child:(NSInteger)index ofItem:(id)item
+{
+	NSIndexPath *p;
+
+	if (item == nil)
+		p = [NSIndexPath indexPathWithIndex:((NSUInteger) index)];
+	else
+		p = [((NSIndexPath *) item) indexPathByAddingIndex:((NSUInteger) index)];
+	return (id) [self uniqueItem:p];
+}
+
+- (BOOL)outlineView:(NSOutlineView *)ov isItemExpandable:(id)item
+{
+	return [self outlineView:ov numberOfChildrenOfItem:item] != 0;
+}
+
+- (id)outlineView:(NSOutlineView *)ov objectValueForTableColumn:(NSTableColumn *)col byItem:(id)item
+{
+	intptr_t *ind;
+	intptr_t n;
+	char *str;
+	NSString *s;
+
+	ind = toIndexes((NSIndexPath *) item, &n);
+	str = goTreeDataSource_text(self->gotree, ind, n);
+	free(ind);
+	s = [NSString stringWithUTF8String:str];
+	free(str);		// allocated with C.CString() on the Go side
+	return (id) s;
+}
+
+// NSBrowserCell can show an image alongside its text, which NSTextFieldCell can't
+- (void)outlineView:(NSOutlineView *)ov willDisplayCell:(id)cell forTableColumn:(NSTableColumn *)col item:(id)item
+{
+	intptr_t *ind;
+	intptr_t n;
+
+	ind = toIndexes((NSIndexPath *) item, &n);
+	// TODO free the returned image when done somehow
+	[((NSBrowserCell *) cell) setImage:((NSImage *) goTreeDataSource_image(self->gotree, ind, n))];
+	free(ind);
+}
+
+// placeholders can't be selected; see TreeLoader
+- (BOOL)outlineView:(NSOutlineView *)ov shouldSelectItem:(id)item
+{
+	intptr_t *ind;
+	intptr_t n;
+	BOOL ret;
+
+	ind = toIndexes((NSIndexPath *) item, &n);
+	ret = goTreeDataSource_selectable(self->gotree, ind, n);
+	free(ind);
+	return ret;
+}
+
+- (void)outlineViewSelectionDidChange:(NSNotification *)note
+{
+	treeSelectionChanged(self->gotree);
+}
+
+- (void)expandedChanged:(NSNotification *)note expanded:(BOOL)expanded
+{
+	intptr_t *ind;
+	intptr_t n;
+
+	ind = toIndexes((NSIndexPath *) [[note userInfo] objectForKey:@"NSObject"], &n);
+	treeExpandedChanged(self->gotree, ind, n, expanded);
+	free(ind);
+}
+
+- (void)outlineViewItemDidExpand:(NSNotification *)note
+{
+	[self expandedChanged:note expanded:YES];
+}
+
+- (void)outlineViewItemDidCollapse:(NSNotification *)note
+{
+	[self expandedChanged:note expanded:NO];
+}
+
+- (IBAction)doubleClicked:(id)sender
+{
+	if ([toNSOutlineView(sender) clickedRow] != -1)
+		treeActivated(self->gotree);
+}
+
+@end
+
+id newTree(void)
+{
+	NSOutlineView *o;
+	NSTableColumn *c;
+	NSBrowserCell *bc;
+
+	o = [[NSOutlineView alloc] initWithFrame:NSZeroRect];
+	[o setAllowsColumnReordering:NO];
+	[o setAllowsColumnResizing:NO];
+	[o setAllowsMultipleSelection:NO];
+	[o setAllowsEmptySelection:YES];
+	[o setAllowsColumnSelection:NO];
+	[o setHeaderView:nil];
+	c = [[NSTableColumn alloc] initWithIdentifier:nil];
+	bc = [[NSBrowserCell alloc] init];
+	[bc setLeaf:YES];			// otherwise NSBrowserCell draws its own arrow
+	[bc setEditable:NO];
+	[c setDataCell:bc];
+	[c setEditable:NO];
+	[c setResizingMask:NSTableColumnAutoresizingMask];
+	setStandardControlFont((id) [c dataCell]);
+	[o addTableColumn:c];
+	[o setOutlineTableColumn:c];
+	[o setColumnAutoresizingStyle:NSTableViewUniformColumnAutoresizingStyle];
+	return (id) o;
+}
+
+// also sets the delegate and the double-click action
+void treeMakeDataSource(id tree, void *gotree)
+{
+	goTreeDataSource *model;
+
+	model = [goTreeDataSource new];
+	model->gotree = gotree;
+	model->items = [NSMutableDictionary new];
+	[toNSOutlineView(tree) setDataSource:model];
+	[toNSOutlineView(tree) setDelegate:model];
+	[toNSOutlineView(tree) setTarget:model];
+	[toNSOutlineView(tree) setDoubleAction:@selector(doubleClicked:)];
+}
+
+void treeReload(id tree)
+{
+	goTreeDataSource *model;
+
+	model = (goTreeDataSource *) [toNSOutlineView(tree) dataSource];
+	[model->items removeAllObjects];
+	[toNSOutlineView(tree) reloadData];
+}
+
+// expanded items are always visible rows (or children of visible rows), so we can just walk the rows
+void treeExpandedPaths(id tree, void *out)
+{
+	NSOutlineView *o;
+	NSInteger i, n;
+	id item;
+	intptr_t *ind;
+	intptr_t len;
+
+	o = toNSOutlineView(tree);
+	n = [o numberOfRows];
+	for (i = 0; i < n; i++) {
+		item = [o itemAtRow:i];
+		if (![o isItemExpanded:item])
+			continue;
+		ind = toIndexes((NSIndexPath *) item, &len);
+		treeAppendExpanded(out, ind, len);
+		free(ind);
+	}
+}
+
+// the caller must free() the result
+intptr_t *treeRowIndexes(id tree, intptr_t row, intptr_t *n)
+{
+	return toIndexes((NSIndexPath *) [toNSOutlineView(tree) itemAtRow:((NSInteger) row)], n);
+}
+
+// returns NULL if nothing is selected; otherwise the caller must free() the result
+intptr_t *treeSelected(id tree, intptr_t *n)
+{
+	NSOutlineView *o;
+	NSInteger row;
+
+	o = toNSOutlineView(tree);
+	row = [o selectedRow];
+	*n = 0;
+	if (row == -1)
+		return NULL;
+	return toIndexes((NSIndexPath *) [o itemAtRow:row], n);
+}
+
+static void expandTo(NSOutlineView *o, intptr_t *indexes, intptr_t n)
+{
+	goTreeDataSource *model;
+	intptr_t i;
+
+	model = (goTreeDataSource *) [o dataSource];
+	for (i = 1; i <= n; i++)
+		[o expandItem:[model itemForIndexes:indexes count:i]];
+}
+
+// n == 0 deselects
+void treeSelect(id tree, intptr_t *indexes, intptr_t n)
+{
+	NSOutlineView *o;
+	goTreeDataSource *model;
+	NSInteger row;
+
+	o = toNSOutlineView(tree);
+	model = (goTreeDataSource *) [o dataSource];
+	[o deselectAll:o];
+	if (n == 0)
+		return;
+	expandTo(o, indexes, n - 1);
+	row = [o rowForItem:[model itemForIndexes:indexes count:n]];
+	if (row == -1)
+		return;
+	[o selectRowIndexes:[NSIndexSet indexSetWithIndex:((NSUInteger) row)] byExtendingSelection:NO];
+	[o scrollRowToVisible:row];
+}
+
+void treeExpand(id tree, intptr_t *indexes, intptr_t n, BOOL expand)
+{
+	NSOutlineView *o;
+	goTreeDataSource *model;
+
+	o = toNSOutlineView(tree);
+	model = (goTreeDataSource *) [o dataSource];
+	if (expand) {
+		expandTo(o, indexes, n);
+		return;
+	}
+	[o collapseItem:[model itemForIndexes:indexes count:n]];
+}
+
+// like tablePreferredSize() in table_darwin.m: the column width and 5 rows
+struct xsize treePreferredSize(id control)
+{
+	NSOutlineView *o;
+	struct xsize s;
+
+	o = toNSOutlineView(control);
+	s.width = (intptr_t) [[[o tableColumns] objectAtIndex:0] width];
+	s.height = 5 * (intptr_t) [o rowHeight];
+	return s;
+}
@@ -0,0 +1,118 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+#include "gtk_unix.h"
+#include "_cgo_export.h"
+
+// the model columns
+enum {
+	treeColumnImage,
+	treeColumnText,
+	treeColumnBusy,		// TRUE for the placeholder children of nodes whose children are loading; see TreeLoader
+	treeColumnPulse,
+	nTreeColumns,
+};
+
+// gtk_tree_store_new() and gtk_tree_store_set() are variadic, so cgo can't call them directly
+GtkTreeStore *newTreeStore(void)
+{
+	// can't use GDK_TYPE_PIXBUF in Go because it's a macro that expands to a function and cgo hates that; here it's fine
+	return gtk_tree_store_new(nTreeColumns, GDK_TYPE_PIXBUF, G_TYPE_STRING, G_TYPE_BOOLEAN, G_TYPE_UINT);
+}
+
+void treeStoreAppend(GtkTreeStore *store, GtkTreeIter *iter, GtkTreeIter *parent, gchar *text, GdkPixbuf *pixbuf)
+{
+	gtk_tree_store_append(store, iter, parent);
+	gtk_tree_store_set(store, iter,
+		treeColumnImage, pixbuf,		// the store takes its own reference
+		treeColumnText, text,			// and makes its own copy
+		-1);
+}
+
+static gboolean treePulseRow(GtkTreeModel *model, GtkTreePath *path, GtkTreeIter *iter, gpointer data)
+{
+	gboolean *any = (gboolean *) data;
+	gboolean busy;
+	guint pulse;
+
+	gtk_tree_model_get(model, iter,
+		treeColumnBusy, &busy,
+		treeColumnPulse, &pulse,
+		-1);
+	if (busy) {
+		gtk_tree_store_set(GTK_TREE_STORE(model), iter, treeColumnPulse, pulse + 1, -1);
+		*any = TRUE;
+	}
+	return FALSE;		// keep going
+}
+
+// GtkCellRendererSpinner doesn't animate itself; we have to keep bumping its pulse property
+// this runs for as long as there are placeholders in the store
+static gboolean treePulse(gpointer data)
+{
+	GtkTreeModel *model = GTK_TREE_MODEL(data);
+	gboolean any = FALSE;
+
+	gtk_tree_model_foreach(model, treePulseRow, &any);
+	if (!any) {
+		g_object_set_data(G_OBJECT(model), "goTreePulsing", NULL);
+		return FALSE;
+	}
+	return TRUE;
+}
+
+// GtkSpinner's default animation has 12 steps a second
+#define treePulseInterval 83
+
+void treeStoreAppendPlaceholder(GtkTreeStore *store, GtkTreeIter *parent, gchar *text)
+{
+	GtkTreeIter iter;
+
+	gtk_tree_store_append(store, &iter, parent);
+	gtk_tree_store_set(store, &iter,
+		treeColumnText, text,
+		treeColumnBusy, TRUE,
+		treeColumnPulse, 0,
+		-1);
+	if (g_object_get_data(G_OBJECT(store), "goTreePulsing") != NULL)
+		return;
+	g_object_set_data(G_OBJECT(store), "goTreePulsing", GINT_TO_POINTER(TRUE));
+	// the timer holds a reference so the store outlives it
+	g_timeout_add_full(G_PRIORITY_DEFAULT, treePulseInterval, treePulse, g_object_ref(store), g_object_unref);
+}
+
+static gboolean treeSelectable(GtkTreeSelection *sel, GtkTreeModel *model, GtkTreePath *path, gboolean selected, gpointer data)
+{
+	GtkTreeIter iter;
+	gboolean busy;
+
+	if (gtk_tree_model_get_iter(model, &iter, path) == FALSE)
+		return TRUE;
+	gtk_tree_model_get(model, &iter, treeColumnBusy, &busy, -1);
+	return !busy;
+}
+
+// trees have a single column with an optional icon and the text in it, like the GtkFileChooser sidebar
+void treeAppendColumn(GtkTreeView *tree)
+{
+	GtkTreeViewColumn *col;
+	GtkCellRenderer *r;
+
+	col = gtk_tree_view_column_new();
+	r = gtk_cell_renderer_spinner_new();
+	gtk_tree_view_column_pack_start(col, r, FALSE);
+	gtk_tree_view_column_add_attribute(col, r, "visible", treeColumnBusy);
+	gtk_tree_view_column_add_attribute(col, r, "active", treeColumnBusy);
+	gtk_tree_view_column_add_attribute(col, r, "pulse", treeColumnPulse);
+	r = gtk_cell_renderer_pixbuf_new();
+	gtk_tree_view_column_pack_start(col, r, FALSE);
+	gtk_tree_view_column_add_attribute(col, r, "pixbuf", treeColumnImage);
+	r = gtk_cell_renderer_text_new();
+	gtk_tree_view_column_pack_start(col, r, TRUE);
+	gtk_tree_view_column_add_attribute(col, r, "text", treeColumnText);
+	gtk_tree_view_append_column(tree, col);
+	gtk_tree_view_set_headers_visible(tree, FALSE);
+	// placeholders can't be selected
+	gtk_tree_selection_set_select_function(gtk_tree_view_get_selection(tree), treeSelectable, NULL, NULL);
+}
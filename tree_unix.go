@@ -0,0 +1,266 @@
+// +build !windows,!darwin
+
+// 14 october 2026
+
+package ui
+
+import (
+	"unsafe"
+)
+
+// #include "gtk_unix.h"
+// extern void treeSelectionChanged(GtkTreeSelection *, gpointer);
+// extern void treeRowActivated(GtkTreeView *, GtkTreePath *, GtkTreeViewColumn *, gpointer);
+// extern void treeRowExpanded(GtkTreeView *, GtkTreeIter *, GtkTreePath *, gpointer);
+// extern void treeRowCollapsed(GtkTreeView *, GtkTreeIter *, GtkTreePath *, gpointer);
+// extern gboolean treeQueryTooltip(GtkWidget *, gint, gint, gboolean, GtkTooltip *, gpointer);
+import "C"
+
+// rather than implement GtkTreeModel again (GtkTreeIter can't hold a path of arbitrary depth), we copy the TreeModel into a GtkTreeStore and rebuild it on each Unlock()
+type tree struct {
+	*treebase
+
+	*scroller
+	treeview *C.GtkTreeView
+
+	store      *C.GtkTreeStore
+	storemodel *C.GtkTreeModel
+	selection  *C.GtkTreeSelection
+
+	// set while rebuilding the GtkTreeStore so the rebuild doesn't generate events
+	rebuilding bool
+}
+
+func finishNewTree(b *treebase) Tree {
+	store := C.newTreeStore()
+	storemodel := (*C.GtkTreeModel)(unsafe.Pointer(store))
+	widget := C.gtk_tree_view_new_with_model(storemodel)
+	t := &tree{
+		scroller:   newScroller(widget, true, true, false), // natively scrollable; has a border; no overlay
+		treebase:   b,
+		treeview:   (*C.GtkTreeView)(unsafe.Pointer(widget)),
+		store:      store,
+		storemodel: storemodel,
+	}
+	C.treeAppendColumn(t.treeview)
+	t.selection = C.gtk_tree_view_get_selection(t.treeview)
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(t.selection)),
+		"changed",
+		C.GCallback(C.treeSelectionChanged),
+		C.gpointer(unsafe.Pointer(t)))
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(t.treeview)),
+		"row-activated",
+		C.GCallback(C.treeRowActivated),
+		C.gpointer(unsafe.Pointer(t)))
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(t.treeview)),
+		"row-expanded",
+		C.GCallback(C.treeRowExpanded),
+		C.gpointer(unsafe.Pointer(t)))
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(t.treeview)),
+		"row-collapsed",
+		C.GCallback(C.treeRowCollapsed),
+		C.gpointer(unsafe.Pointer(t)))
+	setHoverPreviewTooltip(t.treeview)
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(t.treeview)),
+		"query-tooltip",
+		C.GCallback(C.treeQueryTooltip),
+		C.gpointer(unsafe.Pointer(t)))
+	t.RLock()
+	t.populate(nil, nil)
+	t.RUnlock()
+	return t
+}
+
+// the caller must hold the read lock
+func (t *tree) populate(parent TreePath, parentiter *C.GtkTreeIter) {
+	n := t.model.NumChildren(parent)
+	for i := 0; i < n; i++ {
+		var iter C.GtkTreeIter
+		var pixbuf *C.GdkPixbuf
+
+		path := parent.child(i)
+		t.rememberID(path)
+		text := togstr(t.model.NodeText(path))
+		if img := toRGBA(t.model.NodeImage(path)); img != nil {
+			pixbuf = toIconSizedGdkPixbuf(img)
+		}
+		C.treeStoreAppend(t.store, &iter, parentiter, text, pixbuf)
+		freegstr(text)
+		if pixbuf != nil {
+			C.g_object_unref(C.gpointer(unsafe.Pointer(pixbuf)))
+		}
+		t.populate(path, &iter)
+		if t.needsPlaceholder(path) {
+			ctext := togstr(treePlaceholderText)
+			C.treeStoreAppendPlaceholder(t.store, &iter, ctext)
+			freegstr(ctext)
+		}
+	}
+}
+
+func (t *tree) Unlock() {
+	t.unlock()
+	// there's a possibility that user actions can happen at this point, before the view is updated
+	// alas, this is something we have to deal with, because Unlock() can be called from any thread
+	go func() {
+		Do(func() {
+			t.RLock()
+			defer t.RUnlock()
+			t.rebuild()
+		})
+	}()
+}
+
+func (t *tree) rebuild() {
+	s := t.saveView()
+	t.rebuilding = true
+	C.gtk_tree_store_clear(t.store)
+	t.populate(nil, nil)
+	changed := t.restoreView(s)
+	t.rebuilding = false
+	if changed {
+		t.selected.fire()
+	}
+}
+
+func (t *tree) expandedNodes() []TreePath {
+	var expanded []TreePath
+
+	t.expandedPaths(nil, nil, &expanded)
+	return expanded
+}
+
+// GtkTreeView does have gtk_tree_view_map_expanded_rows(), but walking the store ourselves is simpler than marshaling a callback
+func (t *tree) expandedPaths(parent TreePath, parentiter *C.GtkTreeIter, out *[]TreePath) {
+	var iter C.GtkTreeIter
+
+	if C.gtk_tree_model_iter_children(t.storemodel, &iter, parentiter) == C.FALSE {
+		return
+	}
+	for i := 0; ; i++ {
+		path := parent.child(i)
+		gpath := toGtkTreePath(path)
+		if C.gtk_tree_view_row_expanded(t.treeview, gpath) != C.FALSE {
+			*out = append(*out, path)
+			// collapsed nodes can't have expanded children as far as the user is concerned, so only look inside expanded ones
+			child := iter
+			t.expandedPaths(path, &child, out)
+		}
+		C.gtk_tree_path_free(gpath)
+		if C.gtk_tree_model_iter_next(t.storemodel, &iter) == C.FALSE {
+			break
+		}
+	}
+}
+
+func toGtkTreePath(p TreePath) *C.GtkTreePath {
+	path := C.gtk_tree_path_new()
+	for _, i := range p {
+		C.gtk_tree_path_append_index(path, C.gint(i))
+	}
+	return path
+}
+
+func fromGtkTreePath(path *C.GtkTreePath) TreePath {
+	n := int(C.gtk_tree_path_get_depth(path))
+	if n == 0 {
+		return nil
+	}
+	indices := (*[1 << 16]C.gint)(unsafe.Pointer(C.gtk_tree_path_get_indices(path)))[:n:n]
+	p := make(TreePath, n)
+	for i := range p {
+		p[i] = int(indices[i])
+	}
+	return p
+}
+
+func (t *tree) Selected() TreePath {
+	var iter C.GtkTreeIter
+
+	if C.gtk_tree_selection_get_selected(t.selection, nil, &iter) == C.FALSE {
+		return nil
+	}
+	path := C.gtk_tree_model_get_path(t.storemodel, &iter)
+	defer C.gtk_tree_path_free(path)
+	return fromGtkTreePath(path)
+}
+
+func (t *tree) Select(path TreePath) {
+	C.gtk_tree_selection_unselect_all(t.selection)
+	if path == nil {
+		return
+	}
+	if len(path) > 1 {
+		parent := toGtkTreePath(path[:len(path)-1])
+		C.gtk_tree_view_expand_to_path(t.treeview, parent)
+		C.gtk_tree_path_free(parent)
+	}
+	gpath := toGtkTreePath(path)
+	defer C.gtk_tree_path_free(gpath)
+	C.gtk_tree_selection_select_path(t.selection, gpath)
+	C.gtk_tree_view_scroll_to_cell(t.treeview, gpath, nil, C.FALSE, 0, 0)
+}
+
+func (t *tree) Expand(path TreePath) {
+	gpath := toGtkTreePath(path)
+	defer C.gtk_tree_path_free(gpath)
+	C.gtk_tree_view_expand_to_path(t.treeview, gpath)
+}
+
+func (t *tree) Collapse(path TreePath) {
+	gpath := toGtkTreePath(path)
+	defer C.gtk_tree_path_free(gpath)
+	C.gtk_tree_view_collapse_row(t.treeview, gpath)
+}
+
+//export treeSelectionChanged
+func treeSelectionChanged(sel *C.GtkTreeSelection, data C.gpointer) {
+	t := (*tree)(unsafe.Pointer(data))
+	if t.rebuilding {
+		return
+	}
+	t.selected.fire()
+}
+
+//export treeRowActivated
+func treeRowActivated(tv *C.GtkTreeView, path *C.GtkTreePath, col *C.GtkTreeViewColumn, data C.gpointer) {
+	t := (*tree)(unsafe.Pointer(data))
+	t.activated.fire()
+}
+
+//export treeRowExpanded
+func treeRowExpanded(tv *C.GtkTreeView, iter *C.GtkTreeIter, path *C.GtkTreePath, data C.gpointer) {
+	t := (*tree)(unsafe.Pointer(data))
+	if t.rebuilding {
+		return
+	}
+	t.fireExpanded(fromGtkTreePath(path))
+}
+
+//export treeRowCollapsed
+func treeRowCollapsed(tv *C.GtkTreeView, iter *C.GtkTreeIter, path *C.GtkTreePath, data C.gpointer) {
+	t := (*tree)(unsafe.Pointer(data))
+	if t.rebuilding {
+		return
+	}
+	t.fireCollapsed(fromGtkTreePath(path))
+}
+
+//export treeQueryTooltip
+func treeQueryTooltip(widget *C.GtkWidget, x C.gint, y C.gint, keyboard C.gboolean, tooltip *C.GtkTooltip, data C.gpointer) C.gboolean {
+	t := (*tree)(unsafe.Pointer(data))
+	if t.hoverpreview == nil {
+		return C.FALSE
+	}
+	path := hoverPreviewRow(t.treeview, x, y, keyboard)
+	if path == nil {
+		return C.FALSE
+	}
+	defer C.gtk_tree_path_free(path)
+	return showHoverPreview(t.treeview, tooltip, path, t.firePreview(fromGtkTreePath(path)))
+}
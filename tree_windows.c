@@ -0,0 +1,225 @@
+// 14 october 2026
+
+#include "winapi_windows.h"
+#include "_cgo_export.h"
+
+// provided for cgo's benefit
+LPWSTR xWC_TREEVIEW = WC_TREEVIEWW;
+
+// the lParam of placeholder items; real items have 0
+#define treePlaceholderParam 1
+
+static LRESULT CALLBACK treeSubProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM lParam, UINT_PTR id, DWORD_PTR data)
+{
+	NMHDR *nmhdr = (NMHDR *) lParam;
+	NMTREEVIEWW *nmtv = (NMTREEVIEWW *) lParam;
+	TVHITTESTINFO ht;
+	HTREEITEM item;
+
+	switch (uMsg) {
+	case msgNOTIFY:
+		switch (nmhdr->code) {
+		case TVN_SELCHANGINGW:
+			// placeholders can't be selected; see treeInsertPlaceholder()
+			return nmtv->itemNew.lParam == treePlaceholderParam;
+		case TVN_SELCHANGEDW:
+			treeSelectionChanged((void *) data);
+			return 0;
+		case TVN_ITEMEXPANDEDW:
+			treeExpandedChanged((void *) data, nmtv->itemNew.hItem, (nmtv->action & TVE_EXPAND) != 0);
+			return 0;
+		case NM_DBLCLK:
+		case NM_RETURN:
+			treeActivated((void *) data);
+			return 0;		// allow default processing (expanding/collapsing on double-click)
+		}
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	// see preview_windows.c
+	case WM_MOUSEMOVE:
+		ZeroMemory(&ht, sizeof (TVHITTESTINFO));
+		ht.pt.x = GET_X_LPARAM(lParam);
+		ht.pt.y = GET_Y_LPARAM(lParam);
+		item = (HTREEITEM) SendMessageW(hwnd, TVM_HITTEST, 0, (LPARAM) (&ht));
+		// only the icon and text count as the item; the indentation and expand button don't
+		if ((ht.flags & TVHT_ONITEM) == 0)
+			item = NULL;
+		previewMouseMove(hwnd, item == NULL ? -1 : (intptr_t) item);
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_MOUSEHOVER:
+		item = (HTREEITEM) previewHoveredItem(hwnd);
+		if (item != (HTREEITEM) (-1))
+			treeHoverPreview((void *) data, item);
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_MOUSELEAVE:
+	case WM_LBUTTONDOWN:
+	case WM_RBUTTONDOWN:
+	case WM_MOUSEWHEEL:
+	case WM_VSCROLL:
+	case WM_HSCROLL:
+	case WM_KEYDOWN:
+		previewCancel(hwnd);
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	case WM_NCDESTROY:
+		previewCancel(hwnd);
+		if ((*fv_RemoveWindowSubclass)(hwnd, treeSubProc, id) == FALSE)
+			xpanic("error removing Tree subclass (which was for its own event handler)", GetLastError());
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	default:
+		return (*fv_DefSubclassProc)(hwnd, uMsg, wParam, lParam);
+	}
+	xmissedmsg("Tree", "treeSubProc()", uMsg);
+	return 0;		// unreached
+}
+
+void setTreeSubclass(HWND hwnd, void *data)
+{
+	if ((*fv_SetWindowSubclass)(hwnd, treeSubProc, 0, (DWORD_PTR) data) == FALSE)
+		xpanic("error subclassing Tree to give it its own event handler", GetLastError());
+}
+
+// parent is NULL for top-level items; image is -1 for no image
+HTREEITEM treeInsertItem(HWND hwnd, HTREEITEM parent, LPWSTR text, int image)
+{
+	TVINSERTSTRUCTW tvis;
+	HTREEITEM item;
+
+	ZeroMemory(&tvis, sizeof (TVINSERTSTRUCTW));
+	tvis.hParent = parent;
+	if (parent == NULL)
+		tvis.hParent = TVI_ROOT;
+	tvis.hInsertAfter = TVI_LAST;
+	tvis.item.mask = TVIF_TEXT | TVIF_IMAGE | TVIF_SELECTEDIMAGE;
+	tvis.item.pszText = text;		// copied by the tree view
+	tvis.item.iImage = I_IMAGENONE;
+	if (image != -1)
+		tvis.item.iImage = image;
+	tvis.item.iSelectedImage = tvis.item.iImage;
+	item = (HTREEITEM) SendMessageW(hwnd, TVM_INSERTITEMW, 0, (LPARAM) (&tvis));
+	if (item == NULL)
+		xpanic("error adding item to Tree", GetLastError());
+	return item;
+}
+
+// the placeholder child of a node whose children are loading; see TreeLoader
+// tree views have no busy indicator of their own, so this is just text
+void treeInsertPlaceholder(HWND hwnd, HTREEITEM parent, LPWSTR text)
+{
+	TVINSERTSTRUCTW tvis;
+
+	ZeroMemory(&tvis, sizeof (TVINSERTSTRUCTW));
+	tvis.hParent = parent;
+	tvis.hInsertAfter = TVI_LAST;
+	tvis.item.mask = TVIF_TEXT | TVIF_IMAGE | TVIF_SELECTEDIMAGE | TVIF_PARAM;
+	tvis.item.pszText = text;
+	tvis.item.iImage = I_IMAGENONE;
+	tvis.item.iSelectedImage = I_IMAGENONE;
+	tvis.item.lParam = treePlaceholderParam;
+	if (SendMessageW(hwnd, TVM_INSERTITEMW, 0, (LPARAM) (&tvis)) == 0)
+		xpanic("error adding placeholder item to Tree", GetLastError());
+}
+
+// returns NULL if there is no such child
+HTREEITEM treeChild(HWND hwnd, HTREEITEM parent, intptr_t n)
+{
+	HTREEITEM item;
+
+	if (parent == NULL)
+		item = (HTREEITEM) SendMessageW(hwnd, TVM_GETNEXTITEM, TVGN_ROOT, 0);
+	else
+		item = (HTREEITEM) SendMessageW(hwnd, TVM_GETNEXTITEM, TVGN_CHILD, (LPARAM) parent);
+	for (; item != NULL && n > 0; n--)
+		item = (HTREEITEM) SendMessageW(hwnd, TVM_GETNEXTITEM, TVGN_NEXT, (LPARAM) item);
+	return item;
+}
+
+// returns NULL for top-level items
+HTREEITEM treeParent(HWND hwnd, HTREEITEM item)
+{
+	return (HTREEITEM) SendMessageW(hwnd, TVM_GETNEXTITEM, TVGN_PARENT, (LPARAM) item);
+}
+
+intptr_t treeItemIndex(HWND hwnd, HTREEITEM item)
+{
+	intptr_t n;
+
+	n = 0;
+	for (;;) {
+		item = (HTREEITEM) SendMessageW(hwnd, TVM_GETNEXTITEM, TVGN_PREVIOUS, (LPARAM) item);
+		if (item == NULL)
+			break;
+		n++;
+	}
+	return n;
+}
+
+HTREEITEM treeSelectedItem(HWND hwnd)
+{
+	return (HTREEITEM) SendMessageW(hwnd, TVM_GETNEXTITEM, TVGN_CARET, 0);
+}
+
+// item can be NULL to deselect
+void treeSelectItem(HWND hwnd, HTREEITEM item)
+{
+	if (SendMessageW(hwnd, TVM_SELECTITEM, TVGN_CARET, (LPARAM) item) == FALSE)
+		xpanic("error selecting item in Tree", GetLastError());
+	if (item != NULL)
+		SendMessageW(hwnd, TVM_ENSUREVISIBLE, 0, (LPARAM) item);
+}
+
+// the rectangle of the item's icon and text; returns FALSE if the item isn't visible
+BOOL treeItemRect(HWND hwnd, HTREEITEM item, RECT *r)
+{
+	// TVM_GETITEMRECT takes the item in the RECT itself
+	*((HTREEITEM *) r) = item;
+	return SendMessageW(hwnd, TVM_GETITEMRECT, (WPARAM) TRUE, (LPARAM) r) != FALSE;
+}
+
+BOOL treeIsExpanded(HWND hwnd, HTREEITEM item)
+{
+	return (SendMessageW(hwnd, TVM_GETITEMSTATE, (WPARAM) item, TVIS_EXPANDED) & TVIS_EXPANDED) != 0;
+}
+
+// note that this does NOT send TVN_ITEMEXPANDED
+void treeExpand(HWND hwnd, HTREEITEM item, BOOL expand)
+{
+	WPARAM code = TVE_COLLAPSE;
+
+	if (expand)
+		code = TVE_EXPAND;
+	// the return value is FALSE both on failure and if the item has no children, so we can't check it
+	SendMessageW(hwnd, TVM_EXPAND, code, (LPARAM) item);
+}
+
+void treeDeleteAll(HWND hwnd)
+{
+	if (SendMessageW(hwnd, TVM_DELETEITEM, 0, (LPARAM) TVI_ROOT) == FALSE)
+		xpanic("error removing old items from Tree", GetLastError());
+}
+
+// replaces the Tree's image list with a new, empty one and returns it, along with the size of each image
+HIMAGELIST treeNewImageList(HWND hwnd, int *width, int *height)
+{
+	HIMAGELIST il, old;
+
+	*width = GetSystemMetrics(SM_CXSMICON);
+	*height = GetSystemMetrics(SM_CYSMICON);
+	il = (*fv_ImageList_Create)(*width, *height, ILC_COLOR32, 0, 16);
+	if (il == NULL)
+		xpanic("error creating image list for Tree", GetLastError());
+	old = (HIMAGELIST) SendMessageW(hwnd, TVM_SETIMAGELIST, TVSIL_NORMAL, (LPARAM) il);
+	if (old != NULL)
+		if ((*fv_ImageList_Destroy)(old) == 0)
+			xpanic("error destroying old Tree image list", GetLastError());
+	return il;
+}
+
+// the bitmap is copied; the caller still owns it
+int treeAddImage(HIMAGELIST il, HBITMAP bitmap)
+{
+	int n;
+
+	n = (*fv_ImageList_Add)(il, bitmap, NULL);
+	if (n == -1)
+		xpanic("error adding image to Tree image list", GetLastError());
+	return n;
+}
@@ -0,0 +1,244 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"image"
+	"unsafe"
+)
+
+// #include "winapi_windows.h"
+import "C"
+
+// TODO destroy the image list when the control is destroyed
+type tree struct {
+	*treebase
+	*controlSingleHWND
+
+	// set while rebuilding the items so the rebuild doesn't generate events
+	rebuilding bool
+}
+
+func finishNewTree(b *treebase) Tree {
+	hwnd := C.newControl(C.xWC_TREEVIEW,
+		C.TVS_HASBUTTONS|C.TVS_HASLINES|C.TVS_LINESATROOT|C.TVS_SHOWSELALWAYS|C.WS_HSCROLL|C.WS_VSCROLL|C.WS_TABSTOP,
+		C.WS_EX_CLIENTEDGE) // WS_EX_CLIENTEDGE without WS_BORDER will show the canonical visual styles border (thanks to MindChild in irc.efnet.net/#winprog)
+	t := &tree{
+		treebase:          b,
+		controlSingleHWND: newControlSingleHWND(hwnd),
+	}
+	t.fpreferredSize = t.xpreferredSize
+	C.controlSetControlFont(t.hwnd)
+	C.setTreeSubclass(t.hwnd, unsafe.Pointer(t))
+	t.RLock()
+	t.populateAll()
+	t.RUnlock()
+	return t
+}
+
+// the caller must hold the read lock
+func (t *tree) populateAll() {
+	var width, height C.int
+
+	il := C.treeNewImageList(t.hwnd, &width, &height)
+	t.populate(nil, nil, il, int(width), int(height))
+}
+
+func (t *tree) populate(parent TreePath, parentitem C.HTREEITEM, il C.HIMAGELIST, width int, height int) {
+	n := t.model.NumChildren(parent)
+	for i := 0; i < n; i++ {
+		path := parent.child(i)
+		t.rememberID(path)
+		imgindex := C.int(-1)
+		if img := toRGBA(t.model.NodeImage(path)); img != nil {
+			img = iconSized(img, width, height)
+			hbitmap := C.toBitmap(unsafe.Pointer(img), C.intptr_t(width), C.intptr_t(height))
+			imgindex = C.treeAddImage(il, hbitmap)
+			C.freeBitmap(C.uintptr_t(uintptr(unsafe.Pointer(hbitmap))))
+		}
+		item := C.treeInsertItem(t.hwnd, parentitem, toUTF16(t.model.NodeText(path)), imgindex)
+		t.populate(path, item, il, width, height)
+		if t.needsPlaceholder(path) {
+			C.treeInsertPlaceholder(t.hwnd, item, toUTF16(treePlaceholderText))
+		}
+	}
+}
+
+// image lists require all their images to be the same size, so we have to do the scaling ourselves
+func iconSized(img *image.RGBA, width int, height int) *image.RGBA {
+	dx, dy := img.Rect.Dx(), img.Rect.Dy()
+	if dx == width && dy == height {
+		return img
+	}
+	out := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			out.Set(x, y, img.At(img.Rect.Min.X+x*dx/width, img.Rect.Min.Y+y*dy/height))
+		}
+	}
+	return out
+}
+
+func (t *tree) Unlock() {
+	t.unlock()
+	// there's a possibility that user actions can happen at this point, before the view is updated
+	// alas, this is something we have to deal with, because Unlock() can be called from any thread
+	go func() {
+		Do(func() {
+			t.RLock()
+			defer t.RUnlock()
+			t.rebuild()
+		})
+	}()
+}
+
+func (t *tree) rebuild() {
+	s := t.saveView()
+	t.rebuilding = true
+	C.treeDeleteAll(t.hwnd)
+	t.populateAll()
+	changed := t.restoreView(s)
+	t.rebuilding = false
+	if changed {
+		t.selected.fire()
+	}
+}
+
+func (t *tree) expandedNodes() []TreePath {
+	var expanded []TreePath
+
+	t.expandedPaths(nil, nil, &expanded)
+	return expanded
+}
+
+func (t *tree) expandedPaths(parent TreePath, parentitem C.HTREEITEM, out *[]TreePath) {
+	for i := 0; ; i++ {
+		item := C.treeChild(t.hwnd, parentitem, C.intptr_t(i))
+		if item == nil {
+			break
+		}
+		if C.treeIsExpanded(t.hwnd, item) != C.FALSE {
+			path := parent.child(i)
+			*out = append(*out, path)
+			t.expandedPaths(path, item, out)
+		}
+	}
+}
+
+func (t *tree) item(path TreePath) C.HTREEITEM {
+	item := C.HTREEITEM(nil)
+	for _, i := range path {
+		item = C.treeChild(t.hwnd, item, C.intptr_t(i))
+		if item == nil {
+			break
+		}
+	}
+	return item
+}
+
+func (t *tree) path(item C.HTREEITEM) TreePath {
+	var p TreePath
+
+	for item != nil {
+		p = append(TreePath{int(C.treeItemIndex(t.hwnd, item))}, p...)
+		item = C.treeParent(t.hwnd, item)
+	}
+	return p
+}
+
+func (t *tree) Selected() TreePath {
+	item := C.treeSelectedItem(t.hwnd)
+	if item == nil {
+		return nil
+	}
+	return t.path(item)
+}
+
+func (t *tree) Select(path TreePath) {
+	if path == nil {
+		C.treeSelectItem(t.hwnd, nil)
+		return
+	}
+	// TVM_SELECTITEM expands the parents for us
+	item := t.item(path)
+	if item != nil {
+		C.treeSelectItem(t.hwnd, item)
+	}
+}
+
+// TVM_EXPAND does not send TVN_ITEMEXPANDED, so we have to send the events ourselves
+func (t *tree) expand(path TreePath, events bool) {
+	for i := 1; i <= len(path); i++ {
+		item := t.item(path[:i])
+		if item == nil {
+			return
+		}
+		if C.treeIsExpanded(t.hwnd, item) != C.FALSE {
+			continue
+		}
+		C.treeExpand(t.hwnd, item, C.TRUE)
+		if events {
+			t.fireExpanded(path[:i])
+		}
+	}
+}
+
+func (t *tree) Expand(path TreePath) {
+	t.expand(path, !t.rebuilding)
+}
+
+func (t *tree) Collapse(path TreePath) {
+	item := t.item(path)
+	if item == nil || C.treeIsExpanded(t.hwnd, item) == C.FALSE {
+		return
+	}
+	C.treeExpand(t.hwnd, item, C.FALSE)
+	if !t.rebuilding {
+		t.fireCollapsed(path)
+	}
+}
+
+//export treeSelectionChanged
+func treeSelectionChanged(data unsafe.Pointer) {
+	t := (*tree)(data)
+	if t.rebuilding {
+		return
+	}
+	t.selected.fire()
+}
+
+//export treeActivated
+func treeActivated(data unsafe.Pointer) {
+	t := (*tree)(data)
+	t.activated.fire()
+}
+
+//export treeExpandedChanged
+func treeExpandedChanged(data unsafe.Pointer, item C.HTREEITEM, expanded C.BOOL) {
+	t := (*tree)(data)
+	if t.rebuilding {
+		return
+	}
+	if expanded != C.FALSE {
+		t.fireExpanded(t.path(item))
+	} else {
+		t.fireCollapsed(t.path(item))
+	}
+}
+
+//export treeHoverPreview
+func treeHoverPreview(data unsafe.Pointer, item C.HTREEITEM) {
+	var r C.RECT
+
+	t := (*tree)(data)
+	img := t.firePreview(t.path(item))
+	if img == nil || C.treeItemRect(t.hwnd, item, &r) == C.FALSE {
+		return
+	}
+	showPreview(t.hwnd, &r, img)
+}
+
+func (t *tree) xpreferredSize(d *sizing) (width, height int) {
+	// same as Table; see table_windows.go
+	return fromdlgunitsX(tableWidth, d), fromdlgunitsY(tableHeight, d)
+}
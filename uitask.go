@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"runtime"
 	"sync"
+	"time"
 	"unsafe"
 )
 
@@ -55,6 +56,14 @@ func Stop() {
 	}()
 }
 
+var starttime = time.Now()
+
+// eventTime returns the value for the Timestamp field of MouseEvent, KeyEvent, and DropEvent.
+// time.Since() uses the monotonic clock, so changes to the system clock do not affect it.
+func eventTime() time.Duration {
+	return time.Since(starttime)
+}
+
 func uiissueloop() {
 	for f := range issuer {
 		issue(f)
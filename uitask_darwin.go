@@ -8,7 +8,7 @@ import (
 )
 
 // #cgo CFLAGS: -mmacosx-version-min=10.7 -DMACOSX_DEPLOYMENT_TARGET=10.7
-// #cgo LDFLAGS: -mmacosx-version-min=10.7 -lobjc -framework Foundation -framework AppKit
+// #cgo LDFLAGS: -mmacosx-version-min=10.7 -lobjc -framework Foundation -framework AppKit -framework IOKit -framework ApplicationServices -framework SystemConfiguration -framework OpenGL -framework CoreServices
 // #include "objc_darwin.h"
 import "C"
 
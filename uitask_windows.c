@@ -3,15 +3,43 @@
 #include "winapi_windows.h"
 #include "_cgo_export.h"
 
+// the character typed by the key press being dispatched as msgAreaKeyDown, for KeyEvent.Rune; 0 if none
+// this is only valid during that dispatch, so no other message can clobber it
+uint32_t areaKeyRune = 0;
+
+// TranslateMessage() posts the WM_CHARs for a key press right away, so we can take them out of the queue before anything else sees them
+// this lets Windows handle dead keys and the keyboard layout for us; ToUnicode() would mess up the dead key state
+static uint32_t takeKeyRune(MSG *msg)
+{
+	MSG ch;
+	uint32_t r = 0;
+
+	TranslateMessage(msg);
+	// a character outside the BMP comes as two WM_CHARs, one for each half of the surrogate pair
+	while (PeekMessageW(&ch, msg->hwnd, WM_CHAR, WM_DEADCHAR, PM_REMOVE) != 0)
+		if (ch.message == WM_CHAR) {
+			if (IS_LOW_SURROGATE(ch.wParam) && IS_HIGH_SURROGATE(r))
+				r = 0x10000 + ((r - 0xD800) << 10) + (ch.wParam - 0xDC00);
+			else
+				r = (uint32_t) ch.wParam;
+		}
+	// we never called TranslateMessage() before, so Alt+key never gave us WM_SYSCHARs; keep it that way
+	while (PeekMessageW(&ch, msg->hwnd, WM_SYSCHAR, WM_SYSDEADCHAR, PM_REMOVE) != 0)
+		;
+	return r;
+}
+
 void uimsgloop_area(HWND active, HWND focus, MSG *msg)
 {
 	MSG copy;
+	BOOL handled;
 
 	copy = *msg;
 	switch (copy.message) {
 	case WM_KEYDOWN:
 	case WM_SYSKEYDOWN:			// Alt+[anything] and F10 send these instead
 		copy.message = msgAreaKeyDown;
+		areaKeyRune = takeKeyRune(msg);
 		break;
 	case WM_KEYUP:
 	case WM_SYSKEYUP:
@@ -21,8 +49,10 @@ void uimsgloop_area(HWND active, HWND focus, MSG *msg)
 		goto notkey;
 	}
 	// if we handled the key, don't do the default behavior
-	// don't call TranslateMessage(); we do our own keyboard handling
-	if (DispatchMessage(&copy) != FALSE)
+	// TranslateMessage() is only called above, to get KeyEvent.Rune; we do our own keyboard handling otherwise
+	handled = DispatchMessage(&copy) != FALSE;
+	areaKeyRune = 0;
+	if (handled)
 		return;
 notkey:
 	if (IsDialogMessage(active, msg) != 0)
@@ -62,6 +92,8 @@ void uimsgloop(void)
 	BOOL dodlgmessage;
 
 	for (;;) {
+		// there is no message for the focus moving between windows of a top-level window, so check between messages; this catches every move, as each one happens while handling some message
+		windowCheckFocus();
 		SetLastError(0);
 		res = GetMessageW(&msg, NULL, 0, 0);
 		if (res < 0)
@@ -74,6 +106,10 @@ void uimsgloop(void)
 			continue;
 		}
 
+		// Window.RegisterShortcut() shortcuts come before everything else, even the focused control
+		if ((msg.message == WM_KEYDOWN || msg.message == WM_SYSKEYDOWN) && windowShortcut(active, msg.wParam, msg.lParam))
+			continue;
+
 		// bit of logic involved here:
 		// we don't want dialog messages passed into Areas, so we don't call IsDialogMessageW() there
 		// as for Tabs, we can't have both WS_TABSTOP and WS_EX_CONTROLPARENT set at the same time, so we hotswap the two styles to get the behavior we want
@@ -114,12 +150,26 @@ static LRESULT CALLBACK msgwinproc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARAM l
 
 	if (sharedWndProc(hwnd, uMsg, wParam, lParam, &shared))
 		return shared;
+	if (clipboardMessage(uMsg, wParam, lParam))
+		return 0;
 	switch (uMsg) {
 	case msgRequest:
 		doissue((void *) lParam);
 		return 0;
 	case msgOpenFileDone:
-		finishOpenFile((WCHAR *) wParam, (void *) lParam);
+		finishOpenFile((WCHAR *) wParam, (uintptr_t) lParam);
+		return 0;
+	case msgMsgBoxDone:
+		finishMsgBox((int) wParam, (uintptr_t) lParam);
+		return 0;
+	case msgChooseFontDone:
+		finishChooseFont((struct chooseFontData *) lParam);
+		return 0;
+	case msgChooseColorDone:
+		finishChooseColor((BOOL) (wParam >> 24), (COLORREF) (wParam & 0xFFFFFF), (uintptr_t) lParam);
+		return 0;
+	case msgNotifyIcon:
+		notifyIconEvent(lParam);
 		return 0;
 	default:
 		return DefWindowProcW(hwnd, uMsg, wParam, lParam);
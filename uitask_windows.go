@@ -9,7 +9,7 @@ import (
 )
 
 // #cgo CFLAGS: --std=c99
-// #cgo LDFLAGS: -luser32 -lkernel32 -lgdi32 -luxtheme -lmsimg32 -lcomdlg32 -lole32 -loleaut32 -loleacc -luuid
+// #cgo LDFLAGS: -luser32 -lkernel32 -lgdi32 -luxtheme -lmsimg32 -lcomdlg32 -lole32 -loleaut32 -loleacc -luuid -lshell32 -limm32 -liphlpapi -lshlwapi -lopengl32
 // #include "winapi_windows.h"
 import "C"
 
@@ -36,6 +36,12 @@ func uiinit() error {
 	if err := makeAreaWindowClass(); err != nil {
 		return fmt.Errorf("error creating Area window class: %v", err)
 	}
+	if err := makePreviewWindowClass(); err != nil {
+		return fmt.Errorf("error creating hover preview window class: %v", err)
+	}
+	if err := makeGLAreaWindowClass(); err != nil {
+		return fmt.Errorf("error creating GLArea window class: %v", err)
+	}
 	// this depends on the common controls having been initialized already
 	C.doInitTable()
 	return nil
@@ -0,0 +1,465 @@
+// 14 october 2026
+
+package ui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// Waveform is an Area that shows audio samples as a waveform, one strip per channel, with a selection and a playhead.
+//
+// The user can click to move the playhead (which sends scrub events), drag to select a range of samples, and Shift-click to extend the selection (or select from the playhead).
+// Dragging with the middle mouse button scrolls; Ctrl with + (or =), -, and 0 zooms in, zooms out, and zooms to fit the whole recording in the Area.
+//
+// SetSamples builds a cache of peaks at several resolutions once, so drawing takes about the same time at any zoom level no matter how long the recording is.
+type Waveform interface {
+	Area
+
+	// SetSamples replaces the audio shown with the given channels of samples, which should be in the range [-1,1].
+	// All channels must be the same length; SetSamples panics otherwise.
+	// The Waveform keeps the slices, so don't change them afterward; call SetSamples again instead.
+	// SetSamples clears the selection, moves the playhead to the start, and zooms to fit.
+	SetSamples(sampleRate int, channels [][]float32)
+
+	// SampleRate returns the sample rate passed to SetSamples, and Len the number of samples in each channel.
+	SampleRate() int
+	Len() int
+
+	// Zoom and SetZoom get and set the number of samples shown in each pixel column; SetZoom clamps it to at least 1.
+	Zoom() float64
+	SetZoom(samplesPerPixel float64)
+
+	// Offset and SetOffset get and set the first sample shown at the left edge of the Area.
+	Offset() int
+	SetOffset(sample int)
+
+	// Selection and SetSelection get and set the selected range of samples, [start,end); if start == end, nothing is selected.
+	// SetSelection swaps start and end if needed and clamps them to [0,Len()].
+	Selection() (start int, end int)
+	SetSelection(start int, end int)
+
+	// OnSelected is an event that gets triggered after the user changes the selection.
+	OnSelected(f func())
+
+	// Playhead and SetPlayhead get and set the sample the playhead is at.
+	Playhead() int
+	SetPlayhead(sample int)
+
+	// OnScrub registers a function that is called with the new position of the playhead each time the user moves it.
+	// It is not called by SetPlayhead.
+	OnScrub(f func(sample int))
+}
+
+// peaks are kept in levels; level 0 has the minimum and maximum of each waveformBlock samples, and each level after that has them for twice as many
+const waveformBlock = 16
+
+type waveformLevel struct {
+	min []float32
+	max []float32
+}
+
+type waveformDrag uint
+
+const (
+	waveformDragNone waveformDrag = iota
+	waveformDragSelect
+	waveformDragPan
+)
+
+const waveformDragThreshold = 3 // pixels before a click becomes a selection
+
+type waveform struct {
+	Area
+	width  int
+	height int
+
+	rate     int
+	channels [][]float32
+	peaks    [][]waveformLevel // per channel
+	length   int
+
+	zoom     float64 // samples per pixel
+	offset   int
+	selStart int
+	selEnd   int
+	playhead int
+
+	onselected *event
+	scrub      func(sample int)
+
+	drag       waveformDrag
+	dragStart  image.Point
+	dragAnchor int // sample where the selection drag started
+	dragMoved  bool
+	dragOffset int // for waveformDragPan
+}
+
+// NewWaveform creates a new, empty Waveform of the given size.
+func NewWaveform(width int, height int) Waveform {
+	w := &waveform{
+		width:      width,
+		height:     height,
+		zoom:       1,
+		onselected: newEvent(),
+	}
+	w.Area = NewArea(width, height, w)
+	return w
+}
+
+// SetSize is overridden because zooming to fit needs the width and drawing needs the height.
+func (w *waveform) SetSize(width int, height int) {
+	w.width = width
+	w.height = height
+	w.Area.SetSize(width, height)
+}
+
+func (w *waveform) SetSamples(sampleRate int, channels [][]float32) {
+	length := 0
+	if len(channels) != 0 {
+		length = len(channels[0])
+	}
+	for _, c := range channels {
+		if len(c) != length {
+			panic("channels of different lengths passed to Waveform.SetSamples()")
+		}
+	}
+	w.rate = sampleRate
+	w.channels = channels
+	w.length = length
+	w.peaks = make([][]waveformLevel, len(channels))
+	for i, c := range channels {
+		w.peaks[i] = buildPeaks(c)
+	}
+	w.selStart, w.selEnd = 0, 0
+	w.playhead = 0
+	w.fit()
+}
+
+func buildPeaks(s []float32) []waveformLevel {
+	var levels []waveformLevel
+
+	n := (len(s) + waveformBlock - 1) / waveformBlock
+	l := waveformLevel{
+		min: make([]float32, n),
+		max: make([]float32, n),
+	}
+	for i := 0; i < n; i++ {
+		end := (i + 1) * waveformBlock
+		if end > len(s) {
+			end = len(s)
+		}
+		lo, hi := s[i*waveformBlock], s[i*waveformBlock]
+		for _, v := range s[i*waveformBlock : end] {
+			if v < lo {
+				lo = v
+			}
+			if v > hi {
+				hi = v
+			}
+		}
+		l.min[i], l.max[i] = lo, hi
+	}
+	levels = append(levels, l)
+	for len(l.min) > 1 {
+		n := (len(l.min) + 1) / 2
+		next := waveformLevel{
+			min: make([]float32, n),
+			max: make([]float32, n),
+		}
+		for i := 0; i < n; i++ {
+			lo, hi := l.min[2*i], l.max[2*i]
+			if 2*i+1 < len(l.min) {
+				if l.min[2*i+1] < lo {
+					lo = l.min[2*i+1]
+				}
+				if l.max[2*i+1] > hi {
+					hi = l.max[2*i+1]
+				}
+			}
+			next.min[i], next.max[i] = lo, hi
+		}
+		levels = append(levels, next)
+		l = next
+	}
+	return levels
+}
+
+// peakRange returns the minimum and maximum of samples [s0,s1) of a channel, using the coarsest level whose blocks fit in the range (so each pixel column looks at only a few blocks)
+func (w *waveform) peakRange(ch int, s0 int, s1 int) (lo float32, hi float32, ok bool) {
+	if s0 < 0 {
+		s0 = 0
+	}
+	if s1 > w.length {
+		s1 = w.length
+	}
+	if s1 <= s0 {
+		return 0, 0, false
+	}
+	if s1-s0 < waveformBlock {
+		s := w.channels[ch][s0:s1]
+		lo, hi = s[0], s[0]
+		for _, v := range s {
+			if v < lo {
+				lo = v
+			}
+			if v > hi {
+				hi = v
+			}
+		}
+		return lo, hi, true
+	}
+	k := 0
+	for k+1 < len(w.peaks[ch]) && (waveformBlock<<uint(k+1)) <= s1-s0 {
+		k++
+	}
+	level := w.peaks[ch][k]
+	bs := waveformBlock << uint(k)
+	b0, b1 := s0/bs, (s1+bs-1)/bs
+	if b1 > len(level.min) {
+		b1 = len(level.min)
+	}
+	lo, hi = level.min[b0], level.max[b0]
+	for b := b0 + 1; b < b1; b++ {
+		if level.min[b] < lo {
+			lo = level.min[b]
+		}
+		if level.max[b] > hi {
+			hi = level.max[b]
+		}
+	}
+	return lo, hi, true
+}
+
+func (w *waveform) SampleRate() int {
+	return w.rate
+}
+
+func (w *waveform) Len() int {
+	return w.length
+}
+
+func (w *waveform) Zoom() float64 {
+	return w.zoom
+}
+
+func (w *waveform) SetZoom(samplesPerPixel float64) {
+	w.zoom = math.Max(1, samplesPerPixel)
+	w.RepaintAll()
+}
+
+func (w *waveform) fit() {
+	w.offset = 0
+	if w.width <= 0 {
+		w.SetZoom(1)
+		return
+	}
+	w.SetZoom(float64(w.length) / float64(w.width))
+}
+
+// zoomAt changes the zoom, keeping the sample at x where it is
+func (w *waveform) zoomAt(x int, samplesPerPixel float64) {
+	at := w.toSample(x)
+	w.zoom = math.Max(1, samplesPerPixel)
+	w.SetOffset(at - int(float64(x)*w.zoom))
+}
+
+func (w *waveform) Offset() int {
+	return w.offset
+}
+
+func (w *waveform) SetOffset(sample int) {
+	if sample < 0 {
+		sample = 0
+	}
+	w.offset = sample
+	w.RepaintAll()
+}
+
+func (w *waveform) clamp(sample int) int {
+	if sample < 0 {
+		return 0
+	}
+	if sample > w.length {
+		return w.length
+	}
+	return sample
+}
+
+func (w *waveform) Selection() (start int, end int) {
+	return w.selStart, w.selEnd
+}
+
+func (w *waveform) SetSelection(start int, end int) {
+	if start > end {
+		start, end = end, start
+	}
+	w.selStart, w.selEnd = w.clamp(start), w.clamp(end)
+	w.RepaintAll()
+}
+
+func (w *waveform) OnSelected(f func()) {
+	w.onselected.set(f)
+}
+
+func (w *waveform) Playhead() int {
+	return w.playhead
+}
+
+func (w *waveform) SetPlayhead(sample int) {
+	w.playhead = w.clamp(sample)
+	w.RepaintAll()
+}
+
+func (w *waveform) OnScrub(f func(sample int)) {
+	w.scrub = f
+}
+
+func (w *waveform) toX(sample int) int {
+	return int(math.Floor(float64(sample-w.offset) / w.zoom))
+}
+
+func (w *waveform) toSample(x int) int {
+	return w.offset + int(math.Floor(float64(x)*w.zoom))
+}
+
+func (w *waveform) movePlayhead(x int) {
+	w.SetPlayhead(w.toSample(x))
+	if w.scrub != nil {
+		w.scrub(w.playhead)
+	}
+}
+
+func (w *waveform) Mouse(me MouseEvent) {
+	switch {
+	case me.Down == 1:
+		w.dragStart = me.Pos
+		w.dragMoved = false
+		w.drag = waveformDragSelect
+		w.dragAnchor = w.clamp(w.toSample(me.Pos.X))
+		if (me.Modifiers & Shift) != 0 {
+			// extend the selection from whichever end is farther away, or select from the playhead
+			at := w.toSample(me.Pos.X)
+			w.dragAnchor = w.playhead
+			if w.selStart != w.selEnd {
+				w.dragAnchor = w.selStart
+				if at-w.selStart < w.selEnd-at {
+					w.dragAnchor = w.selEnd
+				}
+			}
+			w.dragMoved = true
+			w.SetSelection(w.dragAnchor, at)
+			w.onselected.fire()
+		}
+	case me.Down == 2:
+		w.drag = waveformDragPan
+		w.dragStart = me.Pos
+		w.dragOffset = w.offset
+	case me.Up != 0:
+		if w.drag == waveformDragSelect && !w.dragMoved {
+			// a click rather than a drag: move the playhead and deselect
+			if w.selStart != w.selEnd {
+				w.SetSelection(0, 0)
+				w.onselected.fire()
+			}
+			w.movePlayhead(me.Pos.X)
+		}
+		w.drag = waveformDragNone
+	case me.Down == 0 && len(me.Held) != 0:
+		switch w.drag {
+		case waveformDragSelect:
+			d := me.Pos.X - w.dragStart.X
+			if d > waveformDragThreshold || d < -waveformDragThreshold {
+				w.dragMoved = true
+			}
+			if w.dragMoved {
+				w.SetSelection(w.dragAnchor, w.toSample(me.Pos.X))
+				w.onselected.fire()
+			}
+		case waveformDragPan:
+			w.SetOffset(w.dragOffset - int(float64(me.Pos.X-w.dragStart.X)*w.zoom))
+		}
+	}
+}
+
+func (w *waveform) Key(ke KeyEvent) bool {
+	if ke.Up || (ke.Modifiers&Ctrl) == 0 {
+		return false
+	}
+	x := w.toX(w.playhead)
+	switch ke.Key {
+	case '=':
+		w.zoomAt(x, w.zoom/1.5)
+		return true
+	case '-':
+		w.zoomAt(x, w.zoom*1.5)
+		return true
+	case '0':
+		w.fit()
+		return true
+	}
+	return false
+}
+
+var (
+	waveformBackground = color.RGBA{0x20, 0x20, 0x20, 0xFF}
+	waveformCenter     = color.RGBA{0x50, 0x50, 0x50, 0xFF}
+	waveformPeak       = color.RGBA{0x60, 0xC0, 0x60, 0xFF}
+	waveformSelection  = color.RGBA{0x30, 0x30, 0x30, 0x30} // white at 19%, alpha-premultiplied
+	waveformDivider    = color.RGBA{0x60, 0x60, 0x60, 0xFF}
+	waveformPlayhead   = color.RGBA{0xD0, 0x20, 0x20, 0xFF}
+)
+
+func (w *waveform) Paint(cliprect image.Rectangle) image.Image {
+	img := image.NewRGBA(cliprect)
+	draw.Draw(img, cliprect, &image.Uniform{waveformBackground}, image.ZP, draw.Src)
+	nch := len(w.channels)
+	if nch == 0 {
+		return img
+	}
+	strip := w.height / nch
+	for ch := 0; ch < nch; ch++ {
+		top := ch * strip
+		if ch != 0 {
+			draw.Draw(img, image.Rect(cliprect.Min.X, top, cliprect.Max.X, top+1), &image.Uniform{waveformDivider}, image.ZP, draw.Src)
+		}
+		r := image.Rect(cliprect.Min.X, top, cliprect.Max.X, top+strip)
+		if !r.Overlaps(cliprect) {
+			continue
+		}
+		w.drawChannel(img, ch, r)
+	}
+	if w.selStart != w.selEnd {
+		r := image.Rect(w.toX(w.selStart), cliprect.Min.Y, w.toX(w.selEnd)+1, cliprect.Max.Y)
+		draw.Draw(img, r, &image.Uniform{waveformSelection}, image.ZP, draw.Over)
+	}
+	x := w.toX(w.playhead)
+	draw.Draw(img, image.Rect(x, cliprect.Min.Y, x+1, cliprect.Max.Y), &image.Uniform{waveformPlayhead}, image.ZP, draw.Src)
+	return img
+}
+
+// drawChannel draws one channel in the strip r, whose X range is that of the clip rectangle, one vertical line per pixel column from the minimum to the maximum
+func (w *waveform) drawChannel(img *image.RGBA, ch int, r image.Rectangle) {
+	mid := (r.Min.Y + r.Max.Y) / 2
+	half := float32(r.Dy()/2 - 1)
+	toY := func(v float32) int {
+		if v > 1 {
+			v = 1
+		} else if v < -1 {
+			v = -1
+		}
+		return mid - int(v*half)
+	}
+	draw.Draw(img, image.Rect(r.Min.X, mid, r.Max.X, mid+1), &image.Uniform{waveformCenter}, image.ZP, draw.Src)
+	peak := &image.Uniform{waveformPeak}
+	for x := r.Min.X; x < r.Max.X; x++ {
+		lo, hi, ok := w.peakRange(ch, w.toSample(x), w.toSample(x+1))
+		if !ok {
+			continue
+		}
+		// hi is the top since y grows downward
+		draw.Draw(img, image.Rect(x, toY(hi), x+1, toY(lo)+1), peak, image.ZP, draw.Src)
+	}
+}
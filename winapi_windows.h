@@ -17,14 +17,20 @@ enum {
 	msgRequest = WM_APP + 1,		// + 1 just to be safe
 	msgCOMMAND,				// WM_COMMAND proxy; see forwardCommand() in controls_windows.go
 	msgNOTIFY,					// WM_NOTIFY proxy
+	msgSCROLL,					// WM_HSCROLL/WM_VSCROLL proxy, for trackbars
 	msgAreaSizeChanged,
 	msgAreaGetScroll,
 	msgAreaRepaint,
 	msgAreaRepaintAll,
+	msgAreaScrollTo,
 	msgTabCurrentTabHasChildren,
 	msgAreaKeyDown,
 	msgAreaKeyUp,
 	msgOpenFileDone,
+	msgMsgBoxDone,
+	msgChooseColorDone,
+	msgChooseFontDone,
+	msgNotifyIcon,
 };
 
 // uitask_windows.c
@@ -32,6 +38,7 @@ extern void uimsgloop(void);
 extern void issue(void *);
 extern HWND msgwin;
 extern DWORD makemsgwin(char **);
+extern uint32_t areaKeyRune;
 
 // comctl32_windows.c
 extern DWORD initCommonControls(char **);
@@ -41,30 +48,45 @@ extern BOOL (*WINAPI fv_RemoveWindowSubclass)(HWND, SUBCLASSPROC, UINT_PTR);
 extern LRESULT (*WINAPI fv_DefSubclassProc)(HWND, UINT, WPARAM, LPARAM);
 // these are listed as WINAPI on MSDN
 extern BOOL (*WINAPI fv__TrackMouseEvent)(LPTRACKMOUSEEVENT);
+extern HIMAGELIST (*WINAPI fv_ImageList_Create)(int, int, UINT, int, int);
+extern int (*WINAPI fv_ImageList_Add)(HIMAGELIST, HBITMAP, HBITMAP);
+extern BOOL (*WINAPI fv_ImageList_Destroy)(HIMAGELIST);
 
 // control_windows.c
 extern HWND newControl(LPWSTR, DWORD, DWORD);
 extern void controlSetParent(HWND, HWND);
+extern void controlDestroy(HWND);
 extern void controlSetControlFont(HWND);
 extern void moveWindow(HWND, int, int, int, int);
 extern LONG controlTextLength(HWND, LPWSTR);
+extern HWND controlSetTooltip(HWND, HWND, LPWSTR);
+extern void controlPopTooltip(HWND);
 
 // basicctrls_windows.c
 extern void setButtonSubclass(HWND, void *);
 extern void setCheckboxSubclass(HWND, void *);
 extern BOOL checkboxChecked(HWND);
 extern void checkboxSetChecked(HWND, BOOL);
+extern void setRadioButtonSubclass(HWND, void *, UINT_PTR);
+extern void radiobuttonSetChecked(HWND, BOOL);
 #define textfieldStyle (ES_AUTOHSCROLL | ES_LEFT | ES_NOHIDESEL | WS_TABSTOP)
 #define textfieldExtStyle (WS_EX_CLIENTEDGE)
 extern void setTextFieldSubclass(HWND, void *);
 extern void textfieldSetAndShowInvalidBalloonTip(HWND, WCHAR *);
 extern void textfieldHideInvalidBalloonTip(HWND);
+extern void textfieldDisableIME(HWND);
 extern int textfieldReadOnly(HWND);
 extern void textfieldSetReadOnly(HWND, BOOL);
+extern void setTextboxSubclass(HWND, void *);
+extern void textboxAppend(HWND, LPWSTR);
+extern HWND textboxRemake(HWND, BOOL);
 extern void setGroupSubclass(HWND, void *);
 extern HWND newUpDown(HWND, void *);
 extern void setSpinboxEditSubclass(HWND, void *);
 extern LPWSTR xPROGRESS_CLASS;
+extern void progressbarSetMarquee(HWND, BOOL);
+extern void setSliderSubclass(HWND, void *);
+extern LPWSTR xTRACKBAR_CLASS;
 
 // init_windows.c
 extern HINSTANCE hInstance;
@@ -83,6 +105,25 @@ extern DWORD initWindows(char **);
 extern DWORD makeWindowWindowClass(char **);
 extern HWND newWindow(LPWSTR, int, int, void *);
 extern void windowClose(HWND);
+extern void relayoutWindow(HWND);
+extern void rescaleWindows(HFONT);
+extern HBITMAP windowSnapshot(HWND, void **, LONG *, LONG *);
+extern BOOL windowInDragRegion(HWND, void *, LPARAM);
+extern void windowSetBorderless(HWND, BOOL);
+extern void windowSetOwner(HWND, HWND);
+extern void windowPosition(HWND, LONG *, LONG *);
+extern void windowSetPosition(HWND, int, int);
+extern void windowSetSize(HWND, int, int);
+extern void windowCenter(HWND);
+extern LONG_PTR windowBorderStyle(LONG_PTR, BOOL);
+extern void windowSetFullscreen(HWND, BOOL, WINDOWPLACEMENT *, LONG_PTR *);
+extern void newIcons(void *, intptr_t, intptr_t, HICON *, HICON *);
+extern void freeIcons(HICON, HICON);
+extern void windowSetIcons(HWND, HICON, HICON);
+extern void setAppIcons(HICON, HICON);
+extern BOOL windowShortcut(HWND, WPARAM, LPARAM);
+extern void windowCheckFocus(void);
+extern void windowSetTabOrder(HWND *, intptr_t);
 
 // common_windows.c
 extern LRESULT getWindowTextLen(HWND);
@@ -93,11 +134,15 @@ extern void *getWindowData(HWND, UINT, WPARAM, LPARAM, LRESULT *);
 extern int windowClassOf(HWND, ...);
 extern BOOL sharedWndProc(HWND, UINT, WPARAM, LPARAM, LRESULT *);
 extern void paintControlBackground(HWND, HDC);
+extern DWORD idleTime(void);
 
 // tab_windows.go
 extern LPWSTR xWC_TABCONTROL;
 extern void setTabSubclass(HWND, void *);
 extern void tabAppend(HWND, LPWSTR);
+extern void tabDelete(HWND, LRESULT);
+extern LRESULT tabSelected(HWND);
+extern void tabSelect(HWND, LRESULT);
 extern void tabGetContentRect(HWND, RECT *);
 extern LONG tabGetTabHeight(HWND);
 extern void tabEnterChildren(HWND);
@@ -108,6 +153,9 @@ extern void tabLeaveChildren(HWND);
 extern LPWSTR xtableWindowClass;
 extern void doInitTable(void);
 extern void setTableSubclass(HWND, void *);
+extern BOOL tableRowRect(HWND, intptr_t, RECT *);
+extern HWND newTableEdit(HWND, void *);
+extern BOOL tableOpenEdit(HWND, HWND, intptr_t, intptr_t);
 extern void gotableSetRowCount(HWND, intptr_t);
 /* TODO
 extern void tableAutosizeColumns(HWND, int);
@@ -115,6 +163,23 @@ extern void tableAutosizeColumns(HWND, int);
 extern intptr_t tableSelectedItem(HWND);
 extern void tableSelectItem(HWND, intptr_t);
 
+// tree_windows.c
+extern LPWSTR xWC_TREEVIEW;
+extern void setTreeSubclass(HWND, void *);
+extern BOOL treeItemRect(HWND, HTREEITEM, RECT *);
+extern HTREEITEM treeInsertItem(HWND, HTREEITEM, LPWSTR, int);
+extern void treeInsertPlaceholder(HWND, HTREEITEM, LPWSTR);
+extern HTREEITEM treeChild(HWND, HTREEITEM, intptr_t);
+extern HTREEITEM treeParent(HWND, HTREEITEM);
+extern intptr_t treeItemIndex(HWND, HTREEITEM);
+extern HTREEITEM treeSelectedItem(HWND);
+extern void treeSelectItem(HWND, HTREEITEM);
+extern BOOL treeIsExpanded(HWND, HTREEITEM);
+extern void treeExpand(HWND, HTREEITEM, BOOL);
+extern void treeDeleteAll(HWND);
+extern HIMAGELIST treeNewImageList(HWND, int *, int *);
+extern int treeAddImage(HIMAGELIST, HBITMAP);
+
 // container_windows.c
 extern RECT containerBounds(HWND);
 extern void calculateBaseUnits(HWND, int *, int *, LONG *);
@@ -127,12 +192,132 @@ extern HWND newArea(void *);
 extern HWND newAreaTextField(HWND, void *);
 extern void areaOpenTextField(HWND, HWND, int, int, int, int);
 extern void areaMarkTextFieldDone(HWND);
+extern void areaToScreen(HWND, POINT *);
+extern void areaSetKinetic(HWND, BOOL);
+struct areaPen {
+	double pressure;
+	double tiltX;
+	double tiltY;
+	BOOL eraser;
+};
+
+// drop_windows.c
+enum {
+	dropFormatFiles = 1 << 0,
+	dropFormatText = 1 << 1,
+	dropFormatImage = 1 << 2,
+	dropFormatLocal = 1 << 3,
+};
+extern void areaSetDropTarget(HWND, void *);
+extern void *newDragData(void);
+extern void dragDataAdd(void *, UINT, void *, uintptr_t);
+extern void dragDataAddFiles(void *, WCHAR *, uintptr_t);
+extern void dragDataAddPromises(void *, WCHAR *, ULONG);
+extern HGLOBAL dragPromiseGlobal(void *, uintptr_t);
+extern void dragDataSetLocal(void *);
+extern void dragDataSetImage(void *, HBITMAP, LONG, LONG, LONG, LONG);
+extern DWORD doDrag(void *, DWORD, DWORD);
+
+// popupmenu_windows.c
+extern HMENU newPopupMenu(void);
+extern void popupMenuAppend(HMENU, UINT_PTR, LPWSTR, UINT, BOOL);
+extern HMENU popupMenuAppendSubmenu(HMENU, LPWSTR, UINT);
+extern UINT popupMenuShow(HMENU, HWND, POINT *);
+
+// power_windows.c
+struct powerStatus {
+	BOOL onBattery;
+	int percent;
+	BOOL lowPower;
+};
+extern void getPowerStatus(struct powerStatus *);
+
+// network_windows.c
+extern BOOL networkOnline(void);
+extern BOOL waitNetworkChange(void);
+
+// notify_windows.c
+extern BOOL postNotification(LPWSTR, LPWSTR, HICON);
+extern void notifyIconEvent(LPARAM);
+
+// locale_windows.c
+extern WCHAR *formatNumber(LPWSTR, UINT);
+extern WCHAR *formatTime(SYSTEMTIME *, BOOL, BOOL);
+extern WCHAR *formatFileSize(LONGLONG);
 
 // image_windows.c
 extern HBITMAP toBitmap(void *, intptr_t, intptr_t);
+extern HBITMAP toAlphaBitmap(void *, intptr_t, intptr_t);
 extern void freeBitmap(uintptr_t);
 
+// preview_windows.c
+#define previewWindowClass L"gouipreview"
+extern DWORD makePreviewWindowClass(char **);
+extern void previewMouseMove(HWND, intptr_t);
+extern intptr_t previewHoveredItem(HWND);
+extern void previewCancel(HWND);
+extern void showPreview(HWND, RECT *, void *, intptr_t, intptr_t);
+
+// cursor_windows.c
+extern HCURSOR hOverrideCursor;
+extern HCURSOR loadSystemCursor(WORD);
+extern HCURSOR newImageCursor(void *, intptr_t, intptr_t, DWORD, DWORD);
+extern void setOverrideCursor(HCURSOR);
+
 // dialog_windows.c
-extern void openFile(HWND, void *);
+extern void openFile(HWND, uintptr_t);
+extern void saveFile(HWND, LPWSTR, uintptr_t);
+extern void msgBox(HWND, LPWSTR, LPWSTR, UINT, uintptr_t);
+extern void chooseColor(HWND, COLORREF, uintptr_t);
+struct chooseFontData {
+	HWND parent;
+	LOGFONTW font;
+	INT pointSize;		// in tenths of a point, as in CHOOSEFONTW
+	BOOL chosen;
+	uintptr_t f;		// handle from addDialogFunc()
+};
+extern void chooseFont(HWND, struct chooseFontData *);
+
+// colorbutton_windows.c
+extern void colorButtonSetColor(HWND, COLORREF);
+
+// text_windows.c
+struct textDC {
+	HDC dc;
+	HFONT font;
+	HFONT prevfont;
+	HBITMAP bitmap;		// only when drawing
+	HBITMAP prevbitmap;
+	void *bits;
+	LONG lineHeight;
+	LONG ascent;
+};
+extern void newTextDC(struct textDC *, LOGFONTW *, INT);
+extern int textFit(struct textDC *, LPWSTR, int, int, LONG *);
+extern void *beginTextRender(struct textDC *, int, int);
+extern void textOut(struct textDC *, int, int, LPWSTR, int);
+extern void freeTextDC(struct textDC *);
+
+// clipboard_windows.c
+extern BOOL clipboardOpen(void);
+extern void clipboardClose(void);
+extern void clipboardEmpty(void);
+extern void clipboardSet(UINT, void *, SIZE_T);
+extern void *clipboardGet(UINT, SIZE_T *);
+extern void startClipboardViewer(void);
+extern BOOL clipboardMessage(UINT, WPARAM, LPARAM);
+
+// glarea_windows.c
+#define glAreaWindowClass L"gouiglarea"
+extern DWORD makeGLAreaWindowClass(char **);
+extern HWND newGLArea(void *);
+
+// speech_windows.c
+extern BOOL speak(LPWSTR);
+extern void stopSpeaking(void);
+extern BOOL startDictation(void);
+
+// scale_windows.c
+extern void setScaleFactor(double);
 
 #endif
@@ -2,9 +2,17 @@
 
 package ui
 
+import (
+	"image"
+)
+
 // Window represents a top-level window on screen that contains other Controls.
 // Windows in package ui can only contain one control; the Stack, Grid, and SimpleGrid layout Controls allow you to pack multiple Controls in a Window.
 // Note that a Window is not itself a Control.
+//
+// A program can have any number of Windows open at once, and can make new ones at any time on the main thread (from an event handler or Do).
+// Each Window lives until it is closed; closing a Window, even the last one, does not stop package ui.
+// To stop when some primary Window is closed, call Stop from its OnClosing handler; for one Window per document, keep a count of the open Windows and call Stop when it reaches zero (except on Mac OS X, where programs conventionally keep running with no Windows open until the user quits them).
 type Window interface {
 	// Title and SetTitle get and set the Window's title, respectively.
 	Title() string
@@ -24,16 +32,151 @@ type Window interface {
 	// On systems where whole applications own windows, OnClosing is also triggered when the user asks to close the application.
 	// If this handler returns true, the Window is closed as defined by Close above.
 	// If this handler returns false, the Window is not closed.
+	// If no handler is registered, the Window is not closed either, so every Window needs one.
+	// To ask about unsaved changes, return false and show the question with Confirm; since Confirm's f runs after OnClosing has returned, call Close from there if the user chooses to close anyway.
+	// Document does all this for Windows that edit a file.
+	// Cleanup that has to happen before the Window goes away, such as stopping ForeignEvents that refer to its Controls, can also be done here before returning true.
 	OnClosing(func() bool)
 
+	// SetOwner makes the Window belong to owner, as a tool palette or inspector belongs to the Window it works on: it stays in front of owner, is minimized along with it, and is closed along with it (without OnClosing being called).
+	// On Mac OS X, it also moves along with owner.
+	// Pass nil to make the Window independent again.
+	// SetOwner panics if owner is the Window itself.
+	SetOwner(owner Window)
+
+	// Position and SetPosition get and set the position of the top-left corner of the Window's frame (its title bar and border, if any, included) on the screen.
+	// Screen coordinates have (0,0) at the top-left corner of the primary monitor and grow down and to the right; other monitors may have negative coordinates.
+	// Size and SetSize get and set the size of the Window's content area, as given to NewWindow; the title bar and border are extra.
+	// To save the geometry of a Window between runs, save Position and Size when the Window is closed and set them again after NewWindow.
+	// Window managers on some Unix systems ignore SetPosition, or only take it as a suggestion; there is nothing package ui can do about that.
+	Position() (x int, y int)
+	SetPosition(x int, y int)
+	Size() (width int, height int)
+	SetSize(width int, height int)
+
+	// Center moves the Window to the center of the screen it is on (or would be on, if it hasn't been shown yet).
+	Center()
+
+	// OnMoved and OnResized register event handlers that are triggered after the Window has moved or changed size, whether by the user or by the program.
+	// When the user drags the Window or its border, they may be triggered many times along the way.
+	// Use Position and Size in the handler to get the new position or size.
+	// Minimizing the Window does not trigger them.
+	OnMoved(f func())
+	OnResized(f func())
+
+	// Maximize, Minimize, and Restore do what the buttons in the Window's title bar do.
+	// Restore returns a minimized Window to how it was before it was minimized, and a maximized Window to its normal size and position.
+	// On Mac OS X, Maximize zooms the Window, which fills the screen except for the menu bar and the Dock.
+	// Call these on a shown Window; what they do to a hidden one differs between systems.
+	// Maximize and Restore do nothing while the Window is fullscreen (call SetFullscreen(false) first), except that Restore still brings back a minimized fullscreen Window.
+	Maximize()
+	Minimize()
+	Restore()
+
+	// SetFullscreen makes the Window cover the whole of the screen it is on, with no title bar or border and nothing of the system's (taskbar, menu bar, or Dock) in front of it, or puts it back the way it was.
+	// This is for media viewers and presentations made with an Area; see also SetOverrideCursor for hiding the cursor.
+	// On Mac OS X, the Window moves to a space of its own, as with the system's full screen button, with an animation; the user can leave it from the menu bar, which slides down at the top of the screen.
+	// Elsewhere, the program has to give the user a way out, such as the Escape key.
+	SetFullscreen(fullscreen bool)
+
+	// State returns whether the Window is minimized, maximized, or fullscreen.
+	// A minimized Window is WindowMinimized, even if it was maximized or fullscreen before.
+	// Maximizing, restoring, and changing fullscreen trigger OnResized, so use that to keep track of State.
+	State() WindowState
+
+	// SetIcon sets the icon shown in the Window's title bar, and on Windows and some Unix systems, for the Window in the taskbar, in place of the program's icon (see SetAppIcon).
+	// Pass nil to show the program's icon again.
+	// SetIcon does nothing on Mac OS X, where windows have no icons of their own; a Window showing a document has the document's icon instead (see Document).
+	SetIcon(icon image.Image)
+
 	// Margined and SetMargined get and set whether the contents of the Window have a margin around them.
 	// The size of the margin is platform-dependent.
 	Margined() bool
 	SetMargined(margined bool)
 
+	// Borderless and SetBorderless get and set whether the Window is shown without the system's title bar and border, for programs that draw their own.
+	// The size of the Window's content area is kept when this changes.
+	// A borderless Window cannot be moved or resized by the user except through a drag region (see SetDragRegion) or, on some systems, the keyboard.
+	Borderless() bool
+	SetBorderless(borderless bool)
+
+	// SetDragRegion sets the parts of the Window that act as its title bar: dragging them moves the Window, and double-clicking them maximizes or restores it (or does whatever else the user has told the system a title bar double-click does).
+	// f is called with a point relative to the top-left corner of the Window's content area (margin included) and returns whether that point is in the region; image.Point.In makes a rectangular region easy.
+	// The region only gets clicks that no Control takes, so put only Labels and empty space there; Buttons and the like in the region still work as usual, which is how a custom title bar has its own close button.
+	// On Mac OS X, Labels take their own clicks, so leave some empty space in the region there.
+	// Pass nil to remove the region.
+	// A drag region is meant for borderless Windows, but works with any.
+	SetDragRegion(f func(pos image.Point) bool)
+
+	// DialogsAttached and SetDialogsAttached get and set whether the dialog boxes modal to the Window are attached to it.
+	// On Mac OS X, attached dialog boxes are sheets: they slide out from under the Window's title bar, move with it, and only block the Window; detached ones are separate windows in the middle of the Window that block the whole program until they are closed.
+	// This applies to OpenFile, SaveFile, MsgBox, MsgBoxError, Confirm, Prompt, PromptMultiline, Choose, and NewProgressDialog; the ChooseColor and ChooseFont panels are never attached.
+	// Elsewhere, dialog boxes are always separate windows kept in front of the Window that block only the Window, so the setting has no effect (some window managers draw them attached anyway).
+	// The default is attached; change it before opening a dialog box, as one already open stays as it is.
+	DialogsAttached() bool
+	SetDialogsAttached(attached bool)
+
+	// Post sends a CustomEvent carrying data to the Window, and OnCustomEvent registers the event handler that receives it.
+	// This is for code built around event handlers that needs to take in data from elsewhere, such as a network connection or a device read on another goroutine; see also ForeignEvent.
+	// Post can be called from any goroutine, and returns without waiting for the event to be handled.
+	// CustomEvents are handled on the main goroutine, one at a time between the events from the system and never during one, in the order they were posted (for events posted from the same goroutine); one posted from an event handler is handled after that handler returns.
+	// Where a CustomEvent falls among system events that happen around the same time is up to the system; compare Timestamps if that matters.
+	// CustomEvents posted with no handler registered are discarded.
+	// The handler is still called after the Window is closed, so it must not touch the Window's Controls then.
+	// Post must not be called before Go().
+	Post(data interface{})
+	OnCustomEvent(f func(e CustomEvent))
+
+	// RegisterShortcut makes f run when key is pressed with exactly the Modifiers in mods while the Window is active, no matter which Control has the keyboard focus, Areas included.
+	// key is a byte or rune with one of the values of KeyEvent.Key (uppercase letters are taken as lowercase), or an ExtKey; like KeyEvent.Key, it names a key by its position on the keyboard.
+	// For example, w.RegisterShortcut('s', Ctrl, save) and w.RegisterShortcut(F5, 0, reload).
+	// As noted under Modifiers, the usual shortcut modifier is Super on Mac OS X and Ctrl elsewhere; a program that wants both has to choose based on runtime.GOOS.
+	// Registering the same key and Modifiers again replaces the old f; pass nil to remove the shortcut.
+	// Shortcuts the system takes for itself (such as Alt+F4 on Windows) never reach the Window.
+	// RegisterShortcut panics if key is of any other type.
+	RegisterShortcut(key interface{}, mods Modifiers, f func())
+
+	// SetTabOrder sets the order in which Tab moves the keyboard focus through controls; Shift+Tab goes through them backwards.
+	// On GTK+ and Windows, tab order only applies among Controls in the same Stack, Grid, SimpleGrid, Group, or Tab page; Controls listed from different containers are ordered within each container, and Controls not listed keep the place the system gives them, after the listed ones that share their container.
+	// On Mac OS X, the listed Controls form the whole order, with the last leading back to the first, and Controls not listed can only be reached by clicking.
+	// Call SetTabOrder again with different Controls to change the order; the Controls must already be in the Window.
+	SetTabOrder(controls ...Control)
+
+	// OnFocusChanged registers an event handler called when the keyboard focus moves to a different Control in the Window.
+	// c is the Control that has the focus now, or nil if the focus has left the Window's Controls.
+	// For Controls in Groups and Tabs, c is the innermost Control with the focus.
+	// Calling SetFocus on a Control also causes the handler to be called.
+	OnFocusChanged(f func(c Control))
+
+	// FocusFirstInvalid gives the keyboard focus to the first TextField in the Window that is marked invalid (see TextField.Invalid), in the order the Controls were added to their containers, and returns true; if there is none, it does nothing and returns false.
+	// If that TextField is on a Tab page that isn't selected, the page is selected first, and it is scrolled into view (see Control.ScrollIntoView), so that the user sees what is wrong.
+	// Disabled TextFields are skipped.
+	// Call it after checking the input of a form, such as when the user clicks OK, to take the user straight to the first thing to fix.
+	FocusFirstInvalid() bool
+
+	// SimulateColorBlindness is a debugging aid for designers: it makes the Areas in the Window draw as someone with kind of color blindness would see them, so that the colors chosen for custom drawing can be checked for things like red and green meaning different things.
+	// Pass NormalVision to turn it off again.
+	// The Areas are repainted right away; Areas added to the Window later draw normally until SimulateColorBlindness is called again.
+	// Only Areas are affected, as the rest of the Window is drawn by the system; Windows 10 and newer, and GNOME through its accessibility settings, have color filters of their own that affect the whole screen.
+	// Don't leave it on in a released program: the simulation is applied to every pixel of every paint, so it slows Areas down.
+	SimulateColorBlindness(kind ColorBlindness)
+
+	snapshot() *image.RGBA // for Screenshots(); the content area as it is now
+
 	windowDialog
+	windowDocument
 }
 
+// WindowState is the state of a Window; see Window.State.
+type WindowState uint
+
+const (
+	WindowNormal WindowState = iota
+	WindowMinimized
+	WindowMaximized
+	WindowFullscreen
+)
+
 // NewWindow creates a new Window with the given title text, size, and control.
 func NewWindow(title string, width int, height int, control Control) Window {
 	return newWindow(title, width, height, control)
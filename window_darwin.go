@@ -3,6 +3,7 @@
 package ui
 
 import (
+	"image"
 	"unsafe"
 )
 
@@ -13,9 +14,20 @@ type window struct {
 	id C.id
 
 	closing *event
+	moved   *event
+	resized *event
+
+	customHandler    // Post() and OnCustomEvent()
+	shortcuts        // RegisterShortcut()
+	focuser          // OnFocusChanged()
+	dialogAttachment // DialogsAttached() and SetDialogsAttached()
 
 	child			Control
 	container		*container
+
+	dragregion	func(pos image.Point) bool
+
+	modalattached	bool		// for dialog boxes made of our own Controls; see showModal()
 }
 
 func newWindow(title string, width int, height int, control Control) *window {
@@ -26,6 +38,8 @@ func newWindow(title string, width int, height int, control Control) *window {
 	w := &window{
 		id:        id,
 		closing:   newEvent(),
+		moved:     newEvent(),
+		resized:   newEvent(),
 		child:		control,
 	}
 	C.windowSetDelegate(w.id, unsafe.Pointer(w))
@@ -63,6 +77,89 @@ func (w *window) OnClosing(e func() bool) {
 	w.closing.setbool(e)
 }
 
+func (w *window) Position() (x int, y int) {
+	p := C.windowPosition(w.id)
+	return int(p.x), int(p.y)
+}
+
+func (w *window) SetPosition(x int, y int) {
+	C.windowSetPosition(w.id, C.intptr_t(x), C.intptr_t(y))
+}
+
+func (w *window) Size() (width int, height int) {
+	s := C.windowSize(w.id)
+	return int(s.width), int(s.height)
+}
+
+func (w *window) SetSize(width int, height int) {
+	C.windowSetSize(w.id, C.intptr_t(width), C.intptr_t(height))
+}
+
+func (w *window) Center() {
+	C.windowCenter(w.id)
+}
+
+func (w *window) OnMoved(f func()) {
+	w.moved.set(f)
+}
+
+func (w *window) OnResized(f func()) {
+	w.resized.set(f)
+}
+
+//export windowMoved
+func windowMoved(xw unsafe.Pointer) {
+	w := (*window)(unsafe.Pointer(xw))
+	w.moved.fire()
+}
+
+//export windowResized
+func windowResized(xw unsafe.Pointer) {
+	w := (*window)(unsafe.Pointer(xw))
+	w.resized.fire()
+}
+
+func (w *window) Maximize() {
+	C.windowMaximize(w.id)
+}
+
+func (w *window) Minimize() {
+	C.windowMinimize(w.id)
+}
+
+func (w *window) Restore() {
+	C.windowRestore(w.id)
+}
+
+func (w *window) SetFullscreen(fullscreen bool) {
+	C.windowSetFullscreen(w.id, toBOOL(fullscreen))
+}
+
+func (w *window) State() WindowState {
+	switch C.windowState(w.id) {
+	case C.windowStateMinimized:
+		return WindowMinimized
+	case C.windowStateMaximized:
+		return WindowMaximized
+	case C.windowStateFullscreen:
+		return WindowFullscreen
+	}
+	return WindowNormal
+}
+
+// windows have no icons of their own on Mac OS X
+func (w *window) SetIcon(icon image.Image) {
+	// do nothing
+}
+
+func setAppIcon(img *image.RGBA) {
+	if img == nil {
+		C.appSetIcon(nil, 0, 0, 0)
+		return
+	}
+	C.appSetIcon(unsafe.Pointer(pixelData(img)), C.intptr_t(img.Rect.Dx()), C.intptr_t(img.Rect.Dy()), C.intptr_t(img.Stride))
+}
+
 func (w *window) Margined() bool {
 	return w.container.margined
 }
@@ -81,4 +178,95 @@ func windowClosing(xw unsafe.Pointer) C.BOOL {
 	return C.NO
 }
 
-// no need for windowResized; the child container takes care of that
+func (w *window) SetOwner(owner Window) {
+	if owner == Window(w) {
+		panic("Window cannot own itself in Window.SetOwner()")
+	}
+	var id C.id
+	if owner != nil {
+		id = owner.(*window).id
+	}
+	C.windowSetOwner(w.id, id)
+}
+
+func (w *window) Borderless() bool {
+	return C.windowBorderless(w.id) != C.NO
+}
+
+func (w *window) SetBorderless(borderless bool) {
+	C.windowSetBorderless(w.id, toBOOL(borderless))
+}
+
+func (w *window) SetDragRegion(f func(pos image.Point) bool) {
+	w.dragregion = f
+}
+
+//export windowInDragRegion
+func windowInDragRegion(xw unsafe.Pointer, x C.intptr_t, y C.intptr_t) C.BOOL {
+	w := (*window)(unsafe.Pointer(xw))
+	if w.dragregion == nil {
+		return C.NO
+	}
+	return toBOOL(w.dragregion(image.Pt(int(x), int(y))))
+}
+
+//export windowKeyDown
+func windowKeyDown(xw unsafe.Pointer, e C.id) C.BOOL {
+	w := (*window)(unsafe.Pointer(xw))
+	ke, ok := fromKeycode(uintptr(C.keyCode(e)))
+	if !ok {
+		return C.NO
+	}
+	ke.Modifiers = parseModifiers(e)
+	return toBOOL(w.shortcuts.fire(ke))
+}
+
+func (w *window) FocusFirstInvalid() bool {
+	return focusFirstInvalid(w.child)
+}
+
+func (w *window) SimulateColorBlindness(kind ColorBlindness) {
+	simulateColorBlindness(w.child, kind)
+}
+
+func (w *window) snapshot() *image.RGBA {
+	var s C.struct_windowSnapshot
+
+	C.windowSnapshot(w.id, &s)
+	defer C.windowFreeSnapshot(&s)
+	img := image.NewRGBA(image.Rect(0, 0, int(s.width), int(s.height)))
+	pix := C.GoBytes(s.pixels, C.int(s.stride*s.height))
+	for y := 0; y < int(s.height); y++ {
+		copy(img.Pix[y*img.Stride:(y+1)*img.Stride], pix[y*int(s.stride):])
+	}
+	return img
+}
+
+func (w *window) SetTabOrder(controls ...Control) {
+	handles := tabOrderHandles(controls)
+	if len(handles) == 0 {
+		return
+	}
+	views := make([]C.id, len(handles))
+	for i, h := range handles {
+		views[i] = C.id(unsafe.Pointer(h))
+	}
+	C.windowSetTabOrder(w.id, &views[0], C.intptr_t(len(views)))
+}
+
+//export windowFocusChanged
+func windowFocusChanged(xw unsafe.Pointer, responder C.id) {
+	w := (*window)(unsafe.Pointer(xw))
+	// the window itself is first responder when nothing else is
+	w.focusMoved(w.child, uintptr(unsafe.Pointer(responder)))
+}
+
+// no need to lay out the Window contents here; the child container takes care of that
+
+// Mac OS X windows show the document name alone; the edited state and the file are shown by the close button and the proxy icon
+func (w *window) setDocument(name string, appname string, filename string, edited bool) {
+	w.SetTitle(name)
+	cfilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cfilename))
+	C.windowSetDocument(w.id, cfilename, toBOOL(edited))
+}
@@ -6,6 +6,7 @@
 
 #define toNSWindow(x) ((NSWindow *) (x))
 #define toNSView(x) ((NSView *) (x))
+#define toNSEvent(x) ((NSEvent *) (x))
 
 @interface goWindowDelegate : NSObject <NSWindowDelegate> {
 @public
@@ -13,6 +14,63 @@
 }
 @end
 
+// borderless windows can't become key (and so can't be typed into) unless we say so
+@interface goWindow : NSWindow
+@end
+
+@implementation goWindow
+
+- (BOOL)canBecomeKeyWindow
+{
+	return YES;
+}
+
+- (BOOL)canBecomeMainWindow
+{
+	return YES;
+}
+
+// Window.RegisterShortcut() shortcuts get first crack at key presses, before the focused view and the menus
+// key equivalents (anything with Command or Control) go through -performKeyEquivalent: before they get here, so check both
+- (BOOL)goShortcut:(NSEvent *)e
+{
+	goWindowDelegate *d;
+
+	d = (goWindowDelegate *) [self delegate];
+	if (d == nil)
+		return NO;
+	return windowKeyDown(d->gowin, (id) e);
+}
+
+- (BOOL)performKeyEquivalent:(NSEvent *)e
+{
+	if ([self goShortcut:e])
+		return YES;
+	return [super performKeyEquivalent:e];
+}
+
+- (void)sendEvent:(NSEvent *)e
+{
+	if ([e type] == NSKeyDown && [self goShortcut:e])
+		return;
+	[super sendEvent:e];
+}
+
+// every change of first responder goes through here, whether from the user (clicks, Tab) or from code
+- (BOOL)makeFirstResponder:(NSResponder *)r
+{
+	BOOL res;
+	goWindowDelegate *d;
+
+	res = [super makeFirstResponder:r];
+	d = (goWindowDelegate *) [self delegate];
+	if (d != nil)
+		windowFocusChanged(d->gowin, (id) [self firstResponder]);
+	return res;
+}
+
+@end
+
 @implementation goWindowDelegate
 
 - (BOOL)windowShouldClose:(id)win
@@ -20,6 +78,41 @@
 	return windowClosing(self->gowin);
 }
 
+- (void)windowDidMove:(NSNotification *)note
+{
+	// minimizing and restoring don't send this
+	windowMoved(self->gowin);
+}
+
+- (void)windowDidResize:(NSNotification *)note
+{
+	windowResized(self->gowin);
+}
+
+// see windowSetFullscreen()
+- (void)windowDidExitFullScreen:(NSNotification *)note
+{
+	NSWindow *w;
+
+	w = toNSWindow([note object]);
+	[w setCollectionBehavior:([w collectionBehavior] & ~NSWindowCollectionBehaviorFullScreenPrimary)];
+}
+
+// child windows aren't closed with their parent on their own; see windowSetOwner()
+- (void)windowWillClose:(NSNotification *)note
+{
+	NSWindow *w;
+	NSArray *children;
+	NSWindow *child;
+
+	w = toNSWindow([note object]);
+	// make a copy; closing a child removes it from the array
+	children = [[w childWindows] copy];
+	for (child in children)
+		[child close];
+	[children release];
+}
+
 @end
 
 id newWindow(intptr_t width, intptr_t height)
@@ -27,7 +120,7 @@ id newWindow(intptr_t width, intptr_t height)
 	NSWindow *w;
 	NSTextView *tv;
 
-	w = [[NSWindow alloc] initWithContentRect:NSMakeRect(0, 0, (CGFloat) width, (CGFloat) height)
+	w = [[goWindow alloc] initWithContentRect:NSMakeRect(0, 0, (CGFloat) width, (CGFloat) height)
 		styleMask:(NSTitledWindowMask | NSClosableWindowMask | NSMiniaturizableWindowMask | NSResizableWindowMask)
 		backing:NSBackingStoreBuffered
 		defer:YES];
@@ -75,6 +168,18 @@ void windowHide(id win)
 	[toNSWindow(win) orderOut:toNSWindow(win)];
 }
 
+// the key view loop goes through views of any nesting, so unlike GTK+ and Windows there is no need to work per container
+// the last listed view leads back to the first, and views not listed are left out of the loop
+void windowSetTabOrder(id win, id *views, intptr_t n)
+{
+	intptr_t i;
+
+	[toNSWindow(win) setAutorecalculatesKeyViewLoop:NO];
+	for (i = 0; i < n; i++)
+		[toNSView(views[i]) setNextKeyView:toNSView(views[(i + 1) % n])];
+	[toNSWindow(win) setInitialFirstResponder:toNSView(views[0])];
+}
+
 void windowClose(id win)
 {
 	[toNSWindow(win) close];
@@ -84,3 +189,261 @@ id windowContentView(id win)
 {
 	return (id) [toNSWindow(win) contentView];
 }
+
+void windowSetDocument(id win, const char *filename, BOOL edited)
+{
+	// an empty string removes the proxy icon
+	[toNSWindow(win) setRepresentedFilename:[NSString stringWithUTF8String:filename]];
+	[toNSWindow(win) setDocumentEdited:edited];
+}
+
+#define borderStyles (NSTitledWindowMask | NSClosableWindowMask | NSResizableWindowMask)
+
+void windowSetBorderless(id win, BOOL borderless)
+{
+	NSWindow *w;
+	NSRect content;
+	NSUInteger style;
+
+	w = toNSWindow(win);
+	content = [w contentRectForFrameRect:[w frame]];
+	style = [w styleMask];
+	if (borderless)
+		style &= ~borderStyles;			// NSBorderlessWindowMask is 0
+	else
+		style |= borderStyles;
+	[w setStyleMask:style];
+	[w setFrame:[w frameRectForContentRect:content] display:YES];
+}
+
+BOOL windowBorderless(id win)
+{
+	return ([toNSWindow(win) styleMask] & NSTitledWindowMask) == 0;
+}
+
+// called by the content view's mouseDown: (see container_darwin.m); returns NO if the click wasn't in the drag region
+// performWindowDragWithEvent: is 10.11, so we move the window ourselves, the way the documentation for borderless windows used to suggest
+BOOL windowDragRegionMouseDown(id win, id event)
+{
+	NSWindow *w;
+	NSEvent *e;
+	goWindowDelegate *d;
+	NSView *content;
+	NSPoint p, start, now, origin;
+
+	w = toNSWindow(win);
+	e = toNSEvent(event);
+	d = (goWindowDelegate *) [w delegate];
+	if (![d isKindOfClass:[goWindowDelegate class]])		// not one of our Windows
+		return NO;
+	content = [w contentView];
+	p = [content convertPoint:[e locationInWindow] fromView:nil];
+	// the content view isn't flipped
+	if (!windowInDragRegion(d->gowin, (intptr_t) p.x, (intptr_t) ([content bounds].size.height - p.y)))
+		return NO;
+	if ([e clickCount] == 2) {
+		// this is the setting in the Dock pane of System Preferences
+		// (the perform versions beep if the window has no title bar buttons, so don't use those)
+		if ([[NSUserDefaults standardUserDefaults] boolForKey:@"AppleMiniaturizeOnDoubleClick"])
+			[w miniaturize:w];
+		else
+			[w zoom:w];
+		return YES;
+	}
+	start = [NSEvent mouseLocation];
+	origin = [w frame].origin;
+	for (;;) {
+		e = [w nextEventMatchingMask:(NSLeftMouseDraggedMask | NSLeftMouseUpMask)];
+		if ([e type] == NSLeftMouseUp)
+			break;
+		now = [NSEvent mouseLocation];
+		[w setFrameOrigin:NSMakePoint(origin.x + (now.x - start.x), origin.y + (now.y - start.y))];
+	}
+	return YES;
+}
+
+// child windows stay in front of their parent, move with it, and are hidden when it is minimized
+void windowSetOwner(id win, id owner)
+{
+	NSWindow *w;
+	NSWindow *parent;
+
+	w = toNSWindow(win);
+	parent = [w parentWindow];
+	if (parent != nil)
+		[parent removeChildWindow:w];
+	if (owner != nil)
+		[toNSWindow(owner) addChildWindow:w ordered:NSWindowAbove];
+}
+
+// Mac OS X screen coordinates start at the bottom-left of the primary screen (the one with the menu bar, which is the first in [NSScreen screens]); ours start at its top-left
+static CGFloat primaryScreenHeight(void)
+{
+	return [[[NSScreen screens] objectAtIndex:0] frame].size.height;
+}
+
+struct xpoint windowPosition(id win)
+{
+	NSRect frame;
+	struct xpoint p;
+
+	frame = [toNSWindow(win) frame];
+	p.x = (intptr_t) frame.origin.x;
+	p.y = (intptr_t) (primaryScreenHeight() - (frame.origin.y + frame.size.height));
+	return p;
+}
+
+void windowSetPosition(id win, intptr_t x, intptr_t y)
+{
+	[toNSWindow(win) setFrameTopLeftPoint:NSMakePoint((CGFloat) x, primaryScreenHeight() - (CGFloat) y)];
+}
+
+struct xsize windowSize(id win)
+{
+	NSWindow *w;
+	NSRect content;
+	struct xsize s;
+
+	w = toNSWindow(win);
+	content = [w contentRectForFrameRect:[w frame]];
+	s.width = (intptr_t) content.size.width;
+	s.height = (intptr_t) content.size.height;
+	return s;
+}
+
+void windowSetSize(id win, intptr_t width, intptr_t height)
+{
+	NSWindow *w;
+	NSRect frame;
+	NSRect content;
+
+	// setContentSize: keeps the bottom-left corner where it is; keep the top-left corner instead, as the other systems do
+	w = toNSWindow(win);
+	frame = [w frame];
+	content = [w contentRectForFrameRect:frame];
+	content.origin.y += content.size.height - (CGFloat) height;
+	content.size = NSMakeSize((CGFloat) width, (CGFloat) height);
+	[w setFrame:[w frameRectForContentRect:content] display:YES];
+}
+
+void windowCenter(id win)
+{
+	// this puts it a little above center, as the Human Interface Guidelines ask
+	[toNSWindow(win) center];
+}
+
+static BOOL isFullscreen(NSWindow *w)
+{
+	return ([w styleMask] & NSFullScreenWindowMask) != 0;
+}
+
+// zoom: toggles, so check first
+void windowMaximize(id win)
+{
+	NSWindow *w;
+
+	w = toNSWindow(win);
+	if (isFullscreen(w) || [w isZoomed])
+		return;
+	[w zoom:w];
+}
+
+void windowMinimize(id win)
+{
+	[toNSWindow(win) miniaturize:toNSWindow(win)];
+}
+
+void windowRestore(id win)
+{
+	NSWindow *w;
+
+	w = toNSWindow(win);
+	if ([w isMiniaturized])
+		[w deminiaturize:w];
+	else if (!isFullscreen(w) && [w isZoomed])
+		[w zoom:w];
+}
+
+// toggleFullScreen: only works on windows that allow the system's full screen button
+// we don't want that button on every Window, so allow it only until the window comes back out (see windowDidExitFullScreen: above)
+void windowSetFullscreen(id win, BOOL fullscreen)
+{
+	NSWindow *w;
+
+	w = toNSWindow(win);
+	if (fullscreen == isFullscreen(w))
+		return;
+	if (fullscreen)
+		[w setCollectionBehavior:([w collectionBehavior] | NSWindowCollectionBehaviorFullScreenPrimary)];
+	[w toggleFullScreen:w];
+}
+
+int windowState(id win)
+{
+	NSWindow *w;
+
+	w = toNSWindow(win);
+	if ([w isMiniaturized])
+		return windowStateMinimized;
+	if (isFullscreen(w))
+		return windowStateFullscreen;
+	if ([w isZoomed])
+		return windowStateMaximized;
+	return windowStateNormal;
+}
+
+// nil goes back to the bundle's icon, or the generic one
+void appSetIcon(void *pixels, intptr_t width, intptr_t height, intptr_t stride)
+{
+	NSImage *image;
+
+	image = nil;
+	if (pixels != NULL)
+		image = (NSImage *) toTableImage(pixels, width, height, stride);
+	[NSApp setApplicationIconImage:image];
+	// NSApp keeps its own reference
+	[image release];
+}
+
+// cacheDisplayInRect:toBitmapImageRep: has the content view draw itself and its subviews into the bitmap, so the Window doesn't have to be in front of other windows
+// the bitmap it makes for us is in device pixels but in whatever format AppKit likes, so it's drawn again into one whose format we know (see dropSetImage() in area_darwin.m)
+void windowSnapshot(id win, struct windowSnapshot *s)
+{
+	NSView *cv;
+	NSRect r;
+	NSBitmapImageRep *cached, *bitmap;
+	NSGraphicsContext *context;
+
+	cv = [toNSWindow(win) contentView];
+	r = [cv bounds];
+	cached = [cv bitmapImageRepForCachingDisplayInRect:r];
+	[cv cacheDisplayInRect:r toBitmapImageRep:cached];
+	bitmap = [[NSBitmapImageRep alloc]
+		initWithBitmapDataPlanes:NULL		// let it allocate the memory
+		pixelsWide:[cached pixelsWide]
+		pixelsHigh:[cached pixelsHigh]
+		bitsPerSample:8
+		samplesPerPixel:4
+		hasAlpha:YES
+		isPlanar:NO
+		colorSpaceName:NSDeviceRGBColorSpace
+		bitmapFormat:0
+		bytesPerRow:0
+		bitsPerPixel:32];
+	context = [NSGraphicsContext graphicsContextWithBitmapImageRep:bitmap];
+	[NSGraphicsContext saveGraphicsState];
+	[NSGraphicsContext setCurrentContext:context];
+	[cached drawInRect:NSMakeRect(0, 0, (CGFloat) [bitmap pixelsWide], (CGFloat) [bitmap pixelsHigh])];
+	[context flushGraphics];
+	[NSGraphicsContext restoreGraphicsState];
+	s->bitmap = (id) bitmap;
+	s->pixels = (void *) [bitmap bitmapData];
+	s->width = (intptr_t) [bitmap pixelsWide];
+	s->height = (intptr_t) [bitmap pixelsHigh];
+	s->stride = (intptr_t) [bitmap bytesPerRow];
+}
+
+void windowFreeSnapshot(struct windowSnapshot *s)
+{
+	[((NSBitmapImageRep *) (s->bitmap)) release];
+}
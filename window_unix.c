@@ -0,0 +1,63 @@
+// +build !windows,!darwin
+
+// 15 october 2026
+
+#include "gtk_unix.h"
+#include "_cgo_export.h"
+
+// GTK+ focus chains are per container, so give each container that has listed children a chain of its own: the listed children in order, then the rest in the order GTK+ already had them
+void windowSetTabOrder(GtkWidget **widgets, gintptr n)
+{
+	gintptr i, j;
+	GtkWidget *parent;
+	GList *chain, *children, *l;
+
+	for (i = 0; i < n; i++) {
+		parent = gtk_widget_get_parent(widgets[i]);
+		if (parent == NULL || !GTK_IS_CONTAINER(parent))
+			continue;
+		// only build each container's chain once, at its first listed child
+		for (j = 0; j < i; j++)
+			if (gtk_widget_get_parent(widgets[j]) == parent)
+				break;
+		if (j != i)
+			continue;
+		chain = NULL;
+		for (j = i; j < n; j++)
+			if (gtk_widget_get_parent(widgets[j]) == parent && g_list_find(chain, widgets[j]) == NULL)
+				chain = g_list_append(chain, widgets[j]);
+		children = gtk_container_get_children(GTK_CONTAINER(parent));
+		for (l = children; l != NULL; l = l->next)
+			if (g_list_find(chain, l->data) == NULL)
+				chain = g_list_append(chain, l->data);
+		g_list_free(children);
+		gtk_container_set_focus_chain(GTK_CONTAINER(parent), chain);
+		g_list_free(chain);
+	}
+}
+
+// gtk_widget_draw() draws a widget and everything in it into any cairo context, whether or not the widget is in front of other windows
+// the content area doesn't draw the Window's background, so that comes first; the Window itself isn't drawn, as with client-side decorations that would bring in the title bar
+// the pixbuf is in device pixels; cairo_scale() rather than a device scale on the surface keeps gdk_pixbuf_get_from_surface() working in them too
+GdkPixbuf *windowSnapshot(GtkWindow *window)
+{
+	GtkWidget *child;
+	GtkAllocation a;
+	gint scale;
+	cairo_surface_t *surface;
+	cairo_t *cr;
+	GdkPixbuf *pixbuf;
+
+	child = gtk_bin_get_child(GTK_BIN(window));
+	gtk_widget_get_allocation(child, &a);
+	scale = gtk_widget_get_scale_factor(child);
+	surface = cairo_image_surface_create(CAIRO_FORMAT_ARGB32, a.width * scale, a.height * scale);
+	cr = cairo_create(surface);
+	cairo_scale(cr, scale, scale);
+	gtk_render_background(gtk_widget_get_style_context(GTK_WIDGET(window)), cr, 0, 0, a.width, a.height);
+	gtk_widget_draw(child, cr);
+	cairo_destroy(cr);
+	pixbuf = gdk_pixbuf_get_from_surface(surface, 0, 0, a.width * scale, a.height * scale);
+	cairo_surface_destroy(surface);
+	return pixbuf;
+}
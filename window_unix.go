@@ -5,11 +5,35 @@
 package ui
 
 import (
+	"image"
 	"unsafe"
 )
 
 // #include "gtk_unix.h"
 // extern gboolean windowClosing(GtkWidget *, GdkEvent *, gpointer);
+// extern gboolean windowButtonPress(GtkWidget *, GdkEvent *, gpointer);
+// extern gboolean windowKeyPress(GtkWidget *, GdkEvent *, gpointer);
+// extern gboolean windowConfigure(GtkWidget *, GdkEvent *, gpointer);
+// extern void windowSetFocus(GtkWindow *, GtkWidget *, gpointer);
+// static void windowCenter(GtkWindow *w)
+// {
+// 	GdkWindow *gw;
+// 	GdkScreen *screen;
+// 	GdkRectangle r;
+// 	gint width, height;
+//
+// 	gw = gtk_widget_get_window(GTK_WIDGET(w));
+// 	if (gw == NULL || !gtk_widget_get_visible(GTK_WIDGET(w))) {
+// 		/* not shown yet; have it placed there when it is */
+// 		gtk_window_set_position(w, GTK_WIN_POS_CENTER);
+// 		return;
+// 	}
+// 	screen = gtk_window_get_screen(w);
+// 	gdk_screen_get_monitor_workarea(screen, gdk_screen_get_monitor_at_window(screen, gw), &r);
+// 	/* this is the content size; the frame is usually only a little bigger, so this is close enough */
+// 	gtk_window_get_size(w, &width, &height);
+// 	gtk_window_move(w, r.x + (r.width - width) / 2, r.y + (r.height - height) / 2);
+// }
 import "C"
 
 type window struct {
@@ -21,9 +45,22 @@ type window struct {
 	group *C.GtkWindowGroup
 
 	closing *event
+	moved   *event
+	resized *event
+
+	customHandler    // Post() and OnCustomEvent()
+	shortcuts        // RegisterShortcut()
+	focuser          // OnFocusChanged()
+	dialogAttachment // DialogsAttached() and SetDialogsAttached()
+
+	// configure-event doesn't say what changed, so compare with what it was
+	lastx, lasty          C.gint
+	lastwidth, lastheight C.gint
 
 	child			Control
 	container		*container
+
+	dragregion	func(pos image.Point) bool
 }
 
 func newWindow(title string, width int, height int, control Control) *window {
@@ -36,6 +73,8 @@ func newWindow(title string, width int, height int, control Control) *window {
 		bin:     (*C.GtkBin)(unsafe.Pointer(widget)),
 		window:  (*C.GtkWindow)(unsafe.Pointer(widget)),
 		closing: newEvent(),
+		moved:   newEvent(),
+		resized: newEvent(),
 		child:	control,
 	}
 	C.gtk_window_set_title(w.window, ctitle)
@@ -44,6 +83,31 @@ func newWindow(title string, width int, height int, control Control) *window {
 		"delete-event",
 		C.GCallback(C.windowClosing),
 		C.gpointer(unsafe.Pointer(w)))
+	// Labels and our containers have no GdkWindow of their own, so clicks on them come here; see windowButtonPress()
+	C.gtk_widget_add_events(w.widget, C.GDK_BUTTON_PRESS_MASK)
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(w.window)),
+		"button-press-event",
+		C.GCallback(C.windowButtonPress),
+		C.gpointer(unsafe.Pointer(w)))
+	// GtkWindow passes key presses on to the focused widget in its own handler, which runs after this one, so shortcuts come first
+	g_signal_connect(
+		C.gpointer(unsafe.Pointer(w.window)),
+		"key-press-event",
+		C.GCallback(C.windowKeyPress),
+		C.gpointer(unsafe.Pointer(w)))
+	// after, so GtkWindow has taken in the new size and position
+	g_signal_connect_after(
+		C.gpointer(unsafe.Pointer(w.window)),
+		"configure-event",
+		C.GCallback(C.windowConfigure),
+		C.gpointer(unsafe.Pointer(w)))
+	// after, so gtk_window_get_focus() would agree
+	g_signal_connect_after(
+		C.gpointer(unsafe.Pointer(w.window)),
+		"set-focus",
+		C.GCallback(C.windowSetFocus),
+		C.gpointer(unsafe.Pointer(w)))
 	C.gtk_window_resize(w.window, C.gint(width), C.gint(height))
 	w.container = newContainer()
 	w.child.setParent(w.container.parent())
@@ -81,6 +145,131 @@ func (w *window) OnClosing(e func() bool) {
 	w.closing.setbool(e)
 }
 
+func (w *window) Position() (x int, y int) {
+	var cx, cy C.gint
+
+	C.gtk_window_get_position(w.window, &cx, &cy)
+	return int(cx), int(cy)
+}
+
+func (w *window) SetPosition(x int, y int) {
+	C.gtk_window_move(w.window, C.gint(x), C.gint(y))
+}
+
+func (w *window) Size() (width int, height int) {
+	var cwidth, cheight C.gint
+
+	C.gtk_window_get_size(w.window, &cwidth, &cheight)
+	return int(cwidth), int(cheight)
+}
+
+func (w *window) SetSize(width int, height int) {
+	C.gtk_window_resize(w.window, C.gint(width), C.gint(height))
+}
+
+func (w *window) Center() {
+	C.windowCenter(w.window)
+}
+
+func (w *window) OnMoved(f func()) {
+	w.moved.set(f)
+}
+
+func (w *window) OnResized(f func()) {
+	w.resized.set(f)
+}
+
+//export windowConfigure
+func windowConfigure(wid *C.GtkWidget, e *C.GdkEvent, data C.gpointer) C.gboolean {
+	var x, y, width, height C.gint
+
+	w := (*window)(unsafe.Pointer(data))
+	if C.gdk_window_get_state(C.gtk_widget_get_window(wid))&C.GDK_WINDOW_STATE_ICONIFIED != 0 {
+		return C.GDK_EVENT_PROPAGATE
+	}
+	C.gtk_window_get_position(w.window, &x, &y)
+	C.gtk_window_get_size(w.window, &width, &height)
+	moved := x != w.lastx || y != w.lasty
+	resized := width != w.lastwidth || height != w.lastheight
+	w.lastx, w.lasty = x, y
+	w.lastwidth, w.lastheight = width, height
+	if moved {
+		w.moved.fire()
+	}
+	if resized {
+		w.resized.fire()
+	}
+	return C.GDK_EVENT_PROPAGATE
+}
+
+func (w *window) Maximize() {
+	if w.State() == WindowFullscreen {
+		return
+	}
+	C.gtk_window_maximize(w.window)
+}
+
+func (w *window) Minimize() {
+	C.gtk_window_iconify(w.window)
+}
+
+func (w *window) Restore() {
+	switch w.State() {
+	case WindowMinimized:
+		C.gtk_window_deiconify(w.window)
+	case WindowMaximized:
+		C.gtk_window_unmaximize(w.window)
+	}
+}
+
+func (w *window) SetFullscreen(fullscreen bool) {
+	// the window manager puts the window back where it was afterward
+	if fullscreen {
+		C.gtk_window_fullscreen(w.window)
+	} else {
+		C.gtk_window_unfullscreen(w.window)
+	}
+}
+
+// the window manager changes the state whenever it gets around to it, and the GdkWindow knows the latest
+func (w *window) State() WindowState {
+	gw := C.gtk_widget_get_window(w.widget)
+	if gw == nil { // not shown yet
+		return WindowNormal
+	}
+	state := C.gdk_window_get_state(gw)
+	switch {
+	case state&C.GDK_WINDOW_STATE_ICONIFIED != 0:
+		return WindowMinimized
+	case state&C.GDK_WINDOW_STATE_FULLSCREEN != 0:
+		return WindowFullscreen
+	case state&C.GDK_WINDOW_STATE_MAXIMIZED != 0:
+		return WindowMaximized
+	}
+	return WindowNormal
+}
+
+func (w *window) SetIcon(icon image.Image) {
+	if icon == nil {
+		C.gtk_window_set_icon(w.window, nil)
+		return
+	}
+	pixbuf := toGdkPixbuf(toRGBA(icon))
+	defer C.g_object_unref(C.gpointer(unsafe.Pointer(pixbuf)))
+	C.gtk_window_set_icon(w.window, pixbuf)
+}
+
+// GTK+ applies the default icon to the windows already open that use it, too
+func setAppIcon(img *image.RGBA) {
+	if img == nil {
+		C.gtk_window_set_default_icon_list(nil)
+		return
+	}
+	pixbuf := toGdkPixbuf(img)
+	defer C.g_object_unref(C.gpointer(unsafe.Pointer(pixbuf)))
+	C.gtk_window_set_default_icon(pixbuf)
+}
+
 func (w *window) Margined() bool {
 	return w.container.margined
 }
@@ -99,4 +288,103 @@ func windowClosing(wid *C.GtkWidget, e *C.GdkEvent, data C.gpointer) C.gboolean
 	return C.GDK_EVENT_STOP // keeps window alive
 }
 
-// no need for windowResized; the child container takes care of that
+func (w *window) SetOwner(owner Window) {
+	if owner == Window(w) {
+		panic("Window cannot own itself in Window.SetOwner()")
+	}
+	if owner == nil {
+		C.gtk_window_set_transient_for(w.window, nil)
+		C.gtk_window_set_destroy_with_parent(w.window, C.FALSE)
+		return
+	}
+	// the window manager does the rest, though not all window managers minimize transient windows with their parent
+	C.gtk_window_set_transient_for(w.window, owner.(*window).window)
+	C.gtk_window_set_destroy_with_parent(w.window, C.TRUE)
+}
+
+func (w *window) Borderless() bool {
+	return !fromgbool(C.gtk_window_get_decorated(w.window))
+}
+
+func (w *window) SetBorderless(borderless bool) {
+	// the default size and gtk_window_resize() are of the content area already, so there's nothing else to do
+	C.gtk_window_set_decorated(w.window, togbool(!borderless))
+}
+
+func (w *window) SetDragRegion(f func(pos image.Point) bool) {
+	w.dragregion = f
+}
+
+//export windowKeyPress
+func windowKeyPress(wid *C.GtkWidget, e *C.GdkEvent, data C.gpointer) C.gboolean {
+	w := (*window)(unsafe.Pointer(data))
+	ke, ok := toKeyEvent((*C.GdkEventKey)(unsafe.Pointer(e)), false)
+	if ok && w.shortcuts.fire(ke) {
+		return C.GDK_EVENT_STOP
+	}
+	return C.GDK_EVENT_PROPAGATE
+}
+
+func (w *window) FocusFirstInvalid() bool {
+	return focusFirstInvalid(w.child)
+}
+
+func (w *window) SimulateColorBlindness(kind ColorBlindness) {
+	simulateColorBlindness(w.child, kind)
+}
+
+func (w *window) snapshot() *image.RGBA {
+	pixbuf := C.windowSnapshot(w.window)
+	defer C.g_object_unref(C.gpointer(unsafe.Pointer(pixbuf)))
+	return fromGdkPixbuf(pixbuf)
+}
+
+func (w *window) SetTabOrder(controls ...Control) {
+	handles := tabOrderHandles(controls)
+	if len(handles) == 0 {
+		return
+	}
+	widgets := make([]*C.GtkWidget, len(handles))
+	for i, h := range handles {
+		widgets[i] = (*C.GtkWidget)(unsafe.Pointer(h))
+	}
+	C.windowSetTabOrder(&widgets[0], C.gintptr(len(widgets)))
+}
+
+//export windowSetFocus
+func windowSetFocus(win *C.GtkWindow, widget *C.GtkWidget, data C.gpointer) {
+	w := (*window)(unsafe.Pointer(data))
+	// widget is NULL when the focus is unset
+	w.focusMoved(w.child, uintptr(unsafe.Pointer(widget)))
+}
+
+//export windowButtonPress
+func windowButtonPress(wid *C.GtkWidget, e *C.GdkEvent, data C.gpointer) C.gboolean {
+	w := (*window)(unsafe.Pointer(data))
+	be := (*C.GdkEventButton)(unsafe.Pointer(e))
+	// events from child GdkWindows (such as a GtkEntry's) are in their coordinates, not ours; those widgets take their clicks anyway
+	if w.dragregion == nil || be.button != 1 || be.window != C.gtk_widget_get_window(wid) {
+		return C.GDK_EVENT_PROPAGATE
+	}
+	if !w.dragregion(image.Pt(int(be.x), int(be.y))) {
+		return C.GDK_EVENT_PROPAGATE
+	}
+	switch be._type {
+	case C.GDK_BUTTON_PRESS:
+		C.gtk_window_begin_move_drag(w.window, C.gint(be.button), C.gint(be.x_root), C.gint(be.y_root), be.time)
+	case C.GDK_2BUTTON_PRESS:
+		// TODO honor gtk-titlebar-double-click (GTK+ 3.14)
+		if C.gdk_window_get_state(be.window)&C.GDK_WINDOW_STATE_MAXIMIZED != 0 {
+			C.gtk_window_unmaximize(w.window)
+		} else {
+			C.gtk_window_maximize(w.window)
+		}
+	}
+	return C.GDK_EVENT_STOP
+}
+
+// no need to lay out the Window contents here; the child container takes care of that
+
+func (w *window) setDocument(name string, appname string, filename string, edited bool) {
+	w.SetTitle(documentTitle(name, appname, edited))
+}
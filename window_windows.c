@@ -12,6 +12,7 @@ static LRESULT CALLBACK windowWndProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARA
 	void *data;
 	RECT r;
 	LRESULT lResult;
+	WINDOWPOS *wp;
 
 	data = (void *) getWindowData(hwnd, uMsg, wParam, lParam, &lResult);
 	if (data == NULL)
@@ -33,10 +34,25 @@ static LRESULT CALLBACK windowWndProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARA
 		if (GetClientRect(hwnd, &r) == 0)
 			xpanic("error getting client rect for Window in WM_SIZE", GetLastError());
 		windowResize(data, &r);
+		// a minimized window is moved off-screen and shrunk; that isn't what OnMoved and OnResized are for
+		wp = (WINDOWPOS *) lParam;
+		if (IsIconic(hwnd) == 0) {
+			if ((wp->flags & SWP_NOMOVE) == 0)
+				windowMoved(data);
+			if ((wp->flags & SWP_NOSIZE) == 0)
+				windowResized(data);
+		}
 		return 0;
 	case WM_CLOSE:
 		windowClosing(data);
 		return 0;
+	case WM_NCHITTEST:
+		// DefWindowProcW() does the moving and the double-click for HTCAPTION on its own
+		// child controls get WM_NCHITTEST first; static controls (Labels) return HTTRANSPARENT, so they come here too
+		lResult = DefWindowProcW(hwnd, uMsg, wParam, lParam);
+		if (lResult == HTCLIENT && windowInDragRegion(hwnd, data, lParam))
+			return HTCAPTION;
+		return lResult;
 	default:
 		return DefWindowProcW(hwnd, uMsg, wParam, lParam);
 	}
@@ -44,6 +60,62 @@ static LRESULT CALLBACK windowWndProc(HWND hwnd, UINT uMsg, WPARAM wParam, LPARA
 	return 0;		// unreached
 }
 
+// called by uimsgloop() for every key press in the active window, which may not be one of ours (a common dialog, for instance)
+BOOL windowShortcut(HWND active, WPARAM wParam, LPARAM lParam)
+{
+	void *data;
+
+	if (windowClassOf(active, windowclass, NULL) != 0)
+		return FALSE;
+	data = (void *) GetWindowLongPtrW(active, GWLP_USERDATA);
+	if (data == NULL)		// not set up yet
+		return FALSE;
+	return windowKeyDown(data, wParam, lParam);
+}
+
+// called by uimsgloop() before every message; see there
+void windowCheckFocus(void)
+{
+	static HWND lastFocus = NULL;
+	HWND focus, root;
+	void *data;
+
+	focus = GetFocus();
+	if (focus == lastFocus)
+		return;
+	lastFocus = focus;
+	if (focus == NULL)
+		return;
+	root = GetAncestor(focus, GA_ROOT);
+	if (root == NULL || windowClassOf(root, windowclass, NULL) != 0)
+		return;
+	data = (void *) GetWindowLongPtrW(root, GWLP_USERDATA);
+	if (data == NULL)
+		return;
+	windowFocusChanged(data, focus);
+}
+
+// SetWindowPos() with SWP_NOMOVE | SWP_NOSIZE only changes the Z-order, which is also the order IsDialogMessage() uses for Tab; each window goes after the previous listed one with the same parent
+void windowSetTabOrder(HWND *hwnds, intptr_t n)
+{
+	intptr_t i, j;
+	HWND after;
+
+	for (i = 0; i < n; i++) {
+		after = NULL;
+		for (j = i - 1; j >= 0; j--)
+			if (GetParent(hwnds[j]) == GetParent(hwnds[i])) {
+				after = hwnds[j];
+				break;
+			}
+		// the first listed of each parent goes first
+		if (after == NULL)
+			after = HWND_TOP;
+		if (SetWindowPos(hwnds[i], after, 0, 0, 0, 0, SWP_NOMOVE | SWP_NOSIZE | SWP_NOACTIVATE | SWP_NOOWNERZORDER) == 0)
+			xpanic("error setting tab order", GetLastError());
+	}
+}
+
 DWORD makeWindowWindowClass(char **errmsg)
 {
 	WNDCLASSW wc;
@@ -62,10 +134,46 @@ DWORD makeWindowWindowClass(char **errmsg)
 	return 0;
 }
 
+// width and height are of the client area, as on the other systems
+static void clientToWindowSize(HWND hwnd, DWORD style, DWORD exstyle, int *width, int *height)
+{
+	RECT r;
+
+	r.left = 0;
+	r.top = 0;
+	r.right = *width;
+	r.bottom = *height;
+	if (AdjustWindowRectEx(&r, style, FALSE, exstyle) == 0)
+		xpanic("error computing Window size from content size", GetLastError());
+	*width = r.right - r.left;
+	*height = r.bottom - r.top;
+}
+
+static HICON hAppIcon = NULL;
+static HICON hAppIconSmall = NULL;
+
+// the class icons are what windows without WM_SETICON icons of their own show, and what the taskbar and Alt+Tab show for them
+static void setClassIcons(HWND hwnd)
+{
+	HICON big;
+
+	big = hAppIcon;
+	if (big == NULL)
+		big = hDefaultIcon;
+	SetLastError(0);		// SetClassLongPtrW() returns the previous value, which can be 0
+	if (SetClassLongPtrW(hwnd, GCLP_HICON, (LONG_PTR) big) == 0 && GetLastError() != 0)
+		xpanic("error setting program icon", GetLastError());
+	// a NULL small icon has Windows make one from the big one, as it did before SetAppIcon()
+	SetLastError(0);
+	if (SetClassLongPtrW(hwnd, GCLP_HICONSM, (LONG_PTR) hAppIconSmall) == 0 && GetLastError() != 0)
+		xpanic("error setting program small icon", GetLastError());
+}
+
 HWND newWindow(LPWSTR title, int width, int height, void *data)
 {
 	HWND hwnd;
 
+	clientToWindowSize(NULL, WS_OVERLAPPEDWINDOW, 0, &width, &height);
 	hwnd = CreateWindowExW(
 		0,
 		windowclass, title,
@@ -75,6 +183,8 @@ HWND newWindow(LPWSTR title, int width, int height, void *data)
 		NULL, NULL, hInstance, data);
 	if (hwnd == NULL)
 		xpanic("Window creation failed", GetLastError());
+	// SetClassLongPtrW() needs a window of the class, and setAppIcons() can be called when there are none
+	setClassIcons(hwnd);
 	return hwnd;
 }
 
@@ -83,3 +193,275 @@ void windowClose(HWND hwnd)
 	if (DestroyWindow(hwnd) == 0)
 		xpanic("error destroying window", GetLastError());
 }
+
+BOOL windowInDragRegion(HWND hwnd, void *data, LPARAM lParam)
+{
+	POINT pt;
+
+	pt.x = GET_X_LPARAM(lParam);
+	pt.y = GET_Y_LPARAM(lParam);
+	if (ScreenToClient(hwnd, &pt) == 0)
+		xpanic("error converting mouse position to Window coordinates for drag region", GetLastError());
+	return windowDragRegion(data, pt.x, pt.y);
+}
+
+// despite its name, GWLP_HWNDPARENT sets the owner of a top-level window (see http://blogs.msdn.com/b/oldnewthing/archive/2010/03/15/9978691.aspx)
+void windowSetOwner(HWND hwnd, HWND owner)
+{
+	SetLastError(0);		// the previous owner may be NULL
+	if (SetWindowLongPtrW(hwnd, GWLP_HWNDPARENT, (LONG_PTR) owner) == 0 && GetLastError() != 0)
+		xpanic("error setting Window owner", GetLastError());
+}
+
+#define borderStyles (WS_CAPTION | WS_THICKFRAME)
+
+LONG_PTR windowBorderStyle(LONG_PTR style, BOOL borderless)
+{
+	if (borderless)
+		return (style & ~borderStyles) | WS_POPUP;
+	return (style & ~WS_POPUP) | borderStyles;
+}
+
+// keeps the client size the same, as promised by Window.SetBorderless()
+void windowSetBorderless(HWND hwnd, BOOL borderless)
+{
+	LONG_PTR style;
+	RECT r;
+
+	style = windowBorderStyle(GetWindowLongPtrW(hwnd, GWL_STYLE), borderless);
+	if (GetClientRect(hwnd, &r) == 0)
+		xpanic("error getting Window client rect for changing border", GetLastError());
+	if (AdjustWindowRectEx(&r, (DWORD) style, FALSE, (DWORD) GetWindowLongPtrW(hwnd, GWL_EXSTYLE)) == 0)
+		xpanic("error computing new Window size for changing border", GetLastError());
+	SetLastError(0);		// SetWindowLongPtrW() can return 0 on success
+	if (SetWindowLongPtrW(hwnd, GWL_STYLE, style) == 0 && GetLastError() != 0)
+		xpanic("error changing Window border", GetLastError());
+	// SWP_FRAMECHANGED is needed for the new style to take effect
+	if (SetWindowPos(hwnd, NULL, 0, 0, r.right - r.left, r.bottom - r.top, SWP_NOMOVE | SWP_NOZORDER | SWP_NOOWNERZORDER | SWP_NOACTIVATE | SWP_FRAMECHANGED) == 0)
+		xpanic("error resizing Window for changing border", GetLastError());
+}
+
+// the Controls of a Window are laid out from WM_WINDOWPOSCHANGED, and SWP_FRAMECHANGED makes SetWindowPos() send it even though nothing moved
+void relayoutWindow(HWND control)
+{
+	HWND hwnd;
+
+	hwnd = GetAncestor(control, GA_ROOT);
+	if (hwnd == NULL)
+		xpanic("error getting Window to lay out again", GetLastError());
+	if (SetWindowPos(hwnd, NULL, 0, 0, 0, 0, SWP_NOMOVE | SWP_NOSIZE | SWP_NOZORDER | SWP_NOOWNERZORDER | SWP_NOACTIVATE | SWP_FRAMECHANGED) == 0)
+		xpanic("error laying out Window again", GetLastError());
+}
+
+static BOOL CALLBACK rescaleControl(HWND hwnd, LPARAM lParam)
+{
+	// controls with a font of their own keep it
+	if ((HFONT) SendMessageW(hwnd, WM_GETFONT, 0, 0) == (HFONT) lParam)
+		SendMessageW(hwnd, WM_SETFONT, (WPARAM) controlFont, (LPARAM) TRUE);
+	return TRUE;
+}
+
+// this also sees the other top-level windows of the thread, such as tooltips, which need the new font too, as the old one is about to be deleted
+static BOOL CALLBACK rescaleWindow(HWND hwnd, LPARAM lParam)
+{
+	rescaleControl(hwnd, lParam);
+	EnumChildWindows(hwnd, rescaleControl, lParam);
+	if (windowClassOf(hwnd, windowclass, NULL) == 0)
+		relayoutWindow(hwnd);
+	return TRUE;
+}
+
+// for SetScaleFactor(): gives the controls that have prev, the old controlFont, the new one, and lays each Window out again with it
+// our Windows all belong to this thread
+void rescaleWindows(HFONT prev)
+{
+	EnumThreadWindows(GetCurrentThreadId(), rescaleWindow, (LPARAM) prev);
+}
+
+void windowPosition(HWND hwnd, LONG *x, LONG *y)
+{
+	RECT r;
+
+	if (GetWindowRect(hwnd, &r) == 0)
+		xpanic("error getting Window position", GetLastError());
+	*x = r.left;
+	*y = r.top;
+}
+
+void windowSetPosition(HWND hwnd, int x, int y)
+{
+	if (SetWindowPos(hwnd, NULL, x, y, 0, 0, SWP_NOSIZE | SWP_NOZORDER | SWP_NOOWNERZORDER | SWP_NOACTIVATE) == 0)
+		xpanic("error moving Window", GetLastError());
+}
+
+void windowSetSize(HWND hwnd, int width, int height)
+{
+	clientToWindowSize(hwnd, (DWORD) GetWindowLongPtrW(hwnd, GWL_STYLE), (DWORD) GetWindowLongPtrW(hwnd, GWL_EXSTYLE), &width, &height);
+	if (SetWindowPos(hwnd, NULL, 0, 0, width, height, SWP_NOMOVE | SWP_NOZORDER | SWP_NOOWNERZORDER | SWP_NOACTIVATE) == 0)
+		xpanic("error resizing Window", GetLastError());
+}
+
+// centers in the work area (the part the taskbar doesn't cover) of the monitor the window is mostly on
+void windowCenter(HWND hwnd)
+{
+	MONITORINFO mi;
+	RECT r;
+
+	ZeroMemory(&mi, sizeof (MONITORINFO));
+	mi.cbSize = sizeof (MONITORINFO);
+	if (GetMonitorInfoW(MonitorFromWindow(hwnd, MONITOR_DEFAULTTONEAREST), &mi) == 0)
+		xpanic("error getting monitor of Window to center it on", GetLastError());
+	if (GetWindowRect(hwnd, &r) == 0)
+		xpanic("error getting Window size to center it", GetLastError());
+	windowSetPosition(hwnd,
+		mi.rcWork.left + ((mi.rcWork.right - mi.rcWork.left) - (r.right - r.left)) / 2,
+		mi.rcWork.top + ((mi.rcWork.bottom - mi.rcWork.top) - (r.bottom - r.top)) / 2);
+}
+
+// this is Raymond Chen's recipe (http://blogs.msdn.com/b/oldnewthing/archive/2010/04/12/9994016.aspx): a window without a border that covers the whole monitor is what Windows considers fullscreen, and the taskbar gets out of its way on its own
+// prev and prevstyle are filled in when going fullscreen and used when coming back
+void windowSetFullscreen(HWND hwnd, BOOL fullscreen, WINDOWPLACEMENT *prev, LONG_PTR *prevstyle)
+{
+	MONITORINFO mi;
+
+	if (!fullscreen) {
+		SetLastError(0);		// SetWindowLongPtrW() can return 0 on success
+		if (SetWindowLongPtrW(hwnd, GWL_STYLE, *prevstyle) == 0 && GetLastError() != 0)
+			xpanic("error restoring Window border after fullscreen", GetLastError());
+		if (SetWindowPlacement(hwnd, prev) == 0)
+			xpanic("error restoring Window placement after fullscreen", GetLastError());
+		// SWP_FRAMECHANGED is needed for the new style to take effect
+		if (SetWindowPos(hwnd, NULL, 0, 0, 0, 0, SWP_NOMOVE | SWP_NOSIZE | SWP_NOZORDER | SWP_NOOWNERZORDER | SWP_FRAMECHANGED) == 0)
+			xpanic("error redrawing Window border after fullscreen", GetLastError());
+		return;
+	}
+	ZeroMemory(prev, sizeof (WINDOWPLACEMENT));
+	prev->length = sizeof (WINDOWPLACEMENT);
+	if (GetWindowPlacement(hwnd, prev) == 0)
+		xpanic("error getting Window placement before fullscreen", GetLastError());
+	*prevstyle = GetWindowLongPtrW(hwnd, GWL_STYLE);
+	ZeroMemory(&mi, sizeof (MONITORINFO));
+	mi.cbSize = sizeof (MONITORINFO);
+	if (GetMonitorInfoW(MonitorFromWindow(hwnd, MONITOR_DEFAULTTONEAREST), &mi) == 0)
+		xpanic("error getting monitor of Window to make it fullscreen on", GetLastError());
+	SetLastError(0);
+	if (SetWindowLongPtrW(hwnd, GWL_STYLE, *prevstyle & ~WS_OVERLAPPEDWINDOW) == 0 && GetLastError() != 0)
+		xpanic("error removing Window border for fullscreen", GetLastError());
+	if (SetWindowPos(hwnd, HWND_TOP,
+		mi.rcMonitor.left, mi.rcMonitor.top,
+		mi.rcMonitor.right - mi.rcMonitor.left, mi.rcMonitor.bottom - mi.rcMonitor.top,
+		SWP_NOOWNERZORDER | SWP_FRAMECHANGED) == 0)
+		xpanic("error making Window fullscreen", GetLastError());
+}
+
+// the big icon is for Alt+Tab and the taskbar and the small one for the title bar; CopyImage() scales to each
+void newIcons(void *i, intptr_t dx, intptr_t dy, HICON *big, HICON *small)
+{
+	ICONINFO ii;
+	HICON icon;
+
+	ZeroMemory(&ii, sizeof (ICONINFO));
+	ii.fIcon = TRUE;
+	ii.hbmColor = toBitmap(i, dx, dy);
+	// as with cursors, the mask is required even though the alpha channel is used instead
+	ii.hbmMask = CreateBitmap((int) dx, (int) dy, 1, 1, NULL);
+	if (ii.hbmMask == NULL)
+		xpanic("error creating icon mask bitmap in newIcons()", GetLastError());
+	icon = CreateIconIndirect(&ii);
+	if (icon == NULL)
+		xpanic("error creating icon in newIcons()", GetLastError());
+	freeBitmap((uintptr_t) ii.hbmColor);
+	freeBitmap((uintptr_t) ii.hbmMask);
+	*big = (HICON) CopyImage(icon, IMAGE_ICON, GetSystemMetrics(SM_CXICON), GetSystemMetrics(SM_CYICON), 0);
+	if (*big == NULL)
+		xpanic("error scaling big icon in newIcons()", GetLastError());
+	*small = (HICON) CopyImage(icon, IMAGE_ICON, GetSystemMetrics(SM_CXSMICON), GetSystemMetrics(SM_CYSMICON), 0);
+	if (*small == NULL)
+		xpanic("error scaling small icon in newIcons()", GetLastError());
+	if (DestroyIcon(icon) == 0)
+		xpanic("error destroying unscaled icon in newIcons()", GetLastError());
+}
+
+void freeIcons(HICON big, HICON small)
+{
+	if (big != NULL && DestroyIcon(big) == 0)
+		xpanic("error destroying big icon", GetLastError());
+	if (small != NULL && DestroyIcon(small) == 0)
+		xpanic("error destroying small icon", GetLastError());
+}
+
+// NULL icons remove the window's own icons, so it shows the class icons again
+void windowSetIcons(HWND hwnd, HICON big, HICON small)
+{
+	// the return value is the previous icon; there is no error return
+	SendMessageW(hwnd, WM_SETICON, ICON_BIG, (LPARAM) big);
+	SendMessageW(hwnd, WM_SETICON, ICON_SMALL, (LPARAM) small);
+}
+
+static BOOL CALLBACK setAppIconsEnum(HWND hwnd, LPARAM lParam)
+{
+	if (windowClassOf(hwnd, windowclass, NULL) == 0) {
+		setClassIcons(hwnd);
+		return FALSE;		// the class icons are shared by all windows of the class, so one is enough
+	}
+	return TRUE;
+}
+
+void setAppIcons(HICON big, HICON small)
+{
+	HICON prevbig, prevsmall;
+
+	prevbig = hAppIcon;
+	prevsmall = hAppIconSmall;
+	hAppIcon = big;
+	hAppIconSmall = small;
+	// if there are no Windows yet, newWindow() sets the class icons instead
+	// the return value only says whether setAppIconsEnum() stopped early
+	EnumThreadWindows(GetCurrentThreadId(), setAppIconsEnum, 0);
+	freeIcons(prevbig, prevsmall);
+}
+
+// MinGW-w64 only defines this for Windows 8.1 and newer, but it works with PrintWindow() from Windows XP on
+#ifndef PW_CLIENTONLY
+#define PW_CLIENTONLY 0x00000001
+#endif
+
+// PrintWindow() has the Window draw itself and its controls into our DC, so it doesn't have to be in front of other windows; PW_CLIENTONLY leaves out the title bar and border
+// the result is a top-down 32-bit DIB section whose bits are returned in *bits; the caller deletes it when done
+HBITMAP windowSnapshot(HWND hwnd, void **bits, LONG *width, LONG *height)
+{
+	RECT r;
+	BITMAPINFO bi;
+	HDC dc;
+	HBITMAP bitmap;
+	HGDIOBJ prev;
+
+	if (GetClientRect(hwnd, &r) == 0)
+		xpanic("error getting Window client rect for screenshot", GetLastError());
+	*width = r.right - r.left;
+	*height = r.bottom - r.top;
+	ZeroMemory(&bi, sizeof (BITMAPINFO));
+	bi.bmiHeader.biSize = sizeof (BITMAPINFOHEADER);
+	bi.bmiHeader.biWidth = *width;
+	bi.bmiHeader.biHeight = -(*height);			// negative height to force top-down drawing
+	bi.bmiHeader.biPlanes = 1;
+	bi.bmiHeader.biBitCount = 32;
+	bi.bmiHeader.biCompression = BI_RGB;
+	bitmap = CreateDIBSection(NULL, &bi, DIB_RGB_COLORS, bits, NULL, 0);
+	if (bitmap == NULL)
+		xpanic("error creating DIB section for screenshot", GetLastError());
+	dc = CreateCompatibleDC(NULL);
+	if (dc == NULL)
+		xpanic("error creating DC for screenshot", GetLastError());
+	prev = SelectObject(dc, bitmap);
+	if (prev == NULL)
+		xpanic("error selecting DIB section into DC for screenshot", GetLastError());
+	if (PrintWindow(hwnd, dc, PW_CLIENTONLY) == 0)
+		xpanic("error drawing Window for screenshot", GetLastError());
+	GdiFlush();
+	if (SelectObject(dc, prev) != bitmap)
+		xpanic("error deselecting DIB section from DC for screenshot", GetLastError());
+	if (DeleteDC(dc) == 0)
+		xpanic("error deleting DC for screenshot", GetLastError());
+	return bitmap;
+}
@@ -4,6 +4,7 @@ package ui
 
 import (
 	"fmt"
+	"image"
 	"syscall"
 	"unsafe"
 )
@@ -16,9 +17,26 @@ type window struct {
 	shownbefore bool
 
 	closing *event
+	moved   *event
+	resized *event
+
+	customHandler    // Post() and OnCustomEvent()
+	shortcuts        // RegisterShortcut()
+	focuser          // OnFocusChanged()
+	dialogAttachment // DialogsAttached() and SetDialogsAttached()
 
 	child			Control
 	margined		bool
+	borderless		bool
+	dragregion		func(pos image.Point) bool
+
+	// what to go back to after fullscreen; see windowSetFullscreen()
+	fullscreen		bool
+	prevplacement	C.WINDOWPLACEMENT
+	prevstyle		C.LONG_PTR
+
+	bigicon		C.HICON
+	smallicon		C.HICON
 }
 
 func makeWindowWindowClass() error {
@@ -34,6 +52,8 @@ func makeWindowWindowClass() error {
 func newWindow(title string, width int, height int, control Control) *window {
 	w := &window{
 		closing:   newEvent(),
+		moved:     newEvent(),
+		resized:   newEvent(),
 		child:	control,
 	}
 	w.hwnd = C.newWindow(toUTF16(title), C.int(width), C.int(height), unsafe.Pointer(w))
@@ -69,12 +89,164 @@ func (w *window) Hide() {
 
 func (w *window) Close() {
 	C.windowClose(w.hwnd)
+	C.freeIcons(w.bigicon, w.smallicon)
 }
 
 func (w *window) OnClosing(e func() bool) {
 	w.closing.setbool(e)
 }
 
+func (w *window) Position() (x int, y int) {
+	var cx, cy C.LONG
+
+	C.windowPosition(w.hwnd, &cx, &cy)
+	return int(cx), int(cy)
+}
+
+func (w *window) SetPosition(x int, y int) {
+	C.windowSetPosition(w.hwnd, C.int(x), C.int(y))
+}
+
+func (w *window) Size() (width int, height int) {
+	var r C.RECT
+
+	r = C.containerBounds(w.hwnd)
+	return int(r.right - r.left), int(r.bottom - r.top)
+}
+
+func (w *window) SetSize(width int, height int) {
+	C.windowSetSize(w.hwnd, C.int(width), C.int(height))
+}
+
+func (w *window) Center() {
+	C.windowCenter(w.hwnd)
+}
+
+func (w *window) OnMoved(f func()) {
+	w.moved.set(f)
+}
+
+func (w *window) OnResized(f func()) {
+	w.resized.set(f)
+}
+
+//export windowMoved
+func windowMoved(data unsafe.Pointer) {
+	w := (*window)(data)
+	w.moved.fire()
+}
+
+func (w *window) FocusFirstInvalid() bool {
+	return focusFirstInvalid(w.child)
+}
+
+func (w *window) SimulateColorBlindness(kind ColorBlindness) {
+	simulateColorBlindness(w.child, kind)
+}
+
+func (w *window) snapshot() *image.RGBA {
+	var bits unsafe.Pointer
+	var width, height C.LONG
+
+	bitmap := C.windowSnapshot(w.hwnd, &bits, &width, &height)
+	defer C.DeleteObject(C.HGDIOBJ(bitmap))
+	img := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	pix := C.GoBytes(bits, C.int(width*height*4))
+	// the DIB section is BGRX, and PrintWindow() doesn't say what goes in the X
+	for i := 0; i < len(pix); i += 4 {
+		img.Pix[i+0] = pix[i+2]
+		img.Pix[i+1] = pix[i+1]
+		img.Pix[i+2] = pix[i+0]
+		img.Pix[i+3] = 0xFF
+	}
+	return img
+}
+
+func (w *window) SetTabOrder(controls ...Control) {
+	handles := tabOrderHandles(controls)
+	if len(handles) == 0 {
+		return
+	}
+	hwnds := make([]C.HWND, len(handles))
+	for i, h := range handles {
+		hwnds[i] = C.HWND(unsafe.Pointer(h))
+	}
+	C.windowSetTabOrder(&hwnds[0], C.intptr_t(len(hwnds)))
+}
+
+//export windowFocusChanged
+func windowFocusChanged(data unsafe.Pointer, focus C.HWND) {
+	w := (*window)(data)
+	w.focusMoved(w.child, uintptr(unsafe.Pointer(focus)))
+}
+
+//export windowResized
+func windowResized(data unsafe.Pointer) {
+	w := (*window)(data)
+	w.resized.fire()
+}
+
+func (w *window) Maximize() {
+	if w.fullscreen {
+		return
+	}
+	C.ShowWindow(w.hwnd, C.SW_MAXIMIZE)
+}
+
+func (w *window) Minimize() {
+	C.ShowWindow(w.hwnd, C.SW_MINIMIZE)
+}
+
+func (w *window) Restore() {
+	// SW_RESTORE on a minimized window brings back what it was before, fullscreen included, since that's just a big window as far as Windows is concerned
+	if w.fullscreen && C.IsIconic(w.hwnd) == 0 {
+		return
+	}
+	C.ShowWindow(w.hwnd, C.SW_RESTORE)
+}
+
+func (w *window) SetFullscreen(fullscreen bool) {
+	if fullscreen == w.fullscreen {
+		return
+	}
+	w.fullscreen = fullscreen
+	C.windowSetFullscreen(w.hwnd, toBOOL(fullscreen), &w.prevplacement, &w.prevstyle)
+}
+
+func (w *window) State() WindowState {
+	switch {
+	case C.IsIconic(w.hwnd) != 0:
+		return WindowMinimized
+	case w.fullscreen:
+		return WindowFullscreen
+	case C.IsZoomed(w.hwnd) != 0:
+		return WindowMaximized
+	}
+	return WindowNormal
+}
+
+func (w *window) SetIcon(icon image.Image) {
+	var big, small C.HICON
+
+	if icon != nil {
+		img := toRGBA(icon)
+		C.newIcons(unsafe.Pointer(img), C.intptr_t(img.Rect.Dx()), C.intptr_t(img.Rect.Dy()), &big, &small)
+	}
+	C.windowSetIcons(w.hwnd, big, small)
+	C.freeIcons(w.bigicon, w.smallicon)
+	w.bigicon = big
+	w.smallicon = small
+}
+
+func setAppIcon(img *image.RGBA) {
+	var big, small C.HICON
+
+	if img != nil {
+		C.newIcons(unsafe.Pointer(img), C.intptr_t(img.Rect.Dx()), C.intptr_t(img.Rect.Dy()), &big, &small)
+	}
+	C.setAppIcons(big, small)
+}
+
 func (w *window) Margined() bool {
 	return w.margined
 }
@@ -83,6 +255,55 @@ func (w *window) SetMargined(margined bool) {
 	w.margined = margined
 }
 
+func (w *window) SetOwner(owner Window) {
+	var hwnd C.HWND
+
+	if owner == Window(w) {
+		panic("Window cannot own itself in Window.SetOwner()")
+	}
+	if owner != nil {
+		hwnd = owner.(*window).hwnd
+	}
+	C.windowSetOwner(w.hwnd, hwnd)
+}
+
+func (w *window) Borderless() bool {
+	return w.borderless
+}
+
+func (w *window) SetBorderless(borderless bool) {
+	w.borderless = borderless
+	if w.fullscreen {
+		// takes effect when leaving fullscreen
+		w.prevstyle = C.windowBorderStyle(w.prevstyle, toBOOL(borderless))
+		return
+	}
+	C.windowSetBorderless(w.hwnd, toBOOL(borderless))
+}
+
+func (w *window) SetDragRegion(f func(pos image.Point) bool) {
+	w.dragregion = f
+}
+
+//export windowDragRegion
+func windowDragRegion(data unsafe.Pointer, x C.LONG, y C.LONG) C.BOOL {
+	w := (*window)(data)
+	if w.dragregion == nil {
+		return C.FALSE
+	}
+	return toBOOL(w.dragregion(image.Pt(int(x), int(y))))
+}
+
+//export windowKeyDown
+func windowKeyDown(data unsafe.Pointer, wParam C.WPARAM, lParam C.LPARAM) C.BOOL {
+	w := (*window)(data)
+	ke, ok := toKeyEvent(C.FALSE, wParam, lParam)
+	if !ok {
+		return C.FALSE
+	}
+	return toBOOL(w.shortcuts.fire(ke))
+}
+
 //export windowResize
 func windowResize(data unsafe.Pointer, r *C.RECT) {
 	w := (*window)(data)
@@ -99,5 +320,10 @@ func windowClosing(data unsafe.Pointer) {
 	close := w.closing.fire()
 	if close {
 		C.windowClose(w.hwnd)
+		C.freeIcons(w.bigicon, w.smallicon)
 	}
 }
+
+func (w *window) setDocument(name string, appname string, filename string, edited bool) {
+	w.SetTitle(documentTitle(name, appname, edited))
+}
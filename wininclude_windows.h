@@ -24,3 +24,10 @@
 #include <vssym32.h>
 #include <stdarg.h>
 #include <oleacc.h>
+#include <ole2.h>
+#include <shellapi.h>
+#include <shlobj.h>
+#include <imm.h>
+#include <iphlpapi.h>
+#include <shlwapi.h>
+#include <sapi.h>
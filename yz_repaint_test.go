@@ -56,7 +56,7 @@ func newRepainter(times int) *repainter {
 	return r
 }
 
-func (r *repainter) Paint(rect image.Rectangle) *image.RGBA {
+func (r *repainter) Paint(rect image.Rectangle) image.Image {
 	return r.img.SubImage(rect).(*image.RGBA)
 }
 
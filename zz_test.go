@@ -10,6 +10,10 @@ import (
 	"image"
 	"image/color"
 	"image/draw"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -45,18 +49,110 @@ type colnametest struct {
 }
 
 type dtype struct {
-	Name    string
-	Address string
+	Name     string
+	Address  string
+	Size     FileSize
+	Modified time.Time
 }
 
 var ddata = []dtype{
-	{"alpha", "beta"},
-	{"gamma", "delta"},
-	{"epsilon", "zeta"},
-	{"eta", "theta"},
-	{"iota", "kappa"},
+	{"alpha", "beta", 12, time.Date(2014, time.July, 28, 9, 30, 0, 0, time.Local)},
+	{"gamma", "delta", 3456, time.Date(2014, time.August, 31, 14, 5, 10, 0, time.Local)},
+	{"epsilon", "zeta", 789012, time.Date(2014, time.October, 23, 23, 59, 59, 0, time.Local)},
+	{"eta", "theta", 34567890, time.Date(2015, time.January, 6, 0, 0, 0, 0, time.Local)},
+	{"iota", "kappa", 12345678901, time.Date(2026, time.October, 14, 12, 0, 0, 0, time.Local)},
 }
 
+type modeltest struct {
+	names []string
+	done  []bool
+}
+
+func (m *modeltest) NumRows() int    { return len(m.names) }
+func (m *modeltest) NumColumns() int { return 3 }
+func (m *modeltest) Column(column int) TableColumn {
+	switch column {
+	case 0:
+		return TableColumn{Name: "Index", Type: TableText}
+	case 1:
+		return TableColumn{Name: "Name (Editable)", Type: TableText, Editable: true}
+	}
+	return TableColumn{Name: "Done", Type: TableCheckbox, Editable: true}
+}
+func (m *modeltest) CellValue(row int, column int) interface{} {
+	switch column {
+	case 0:
+		return row
+	case 1:
+		return m.names[row]
+	}
+	return m.done[row]
+}
+func (m *modeltest) SetCellValue(row int, column int, value interface{}) {
+	switch column {
+	case 1:
+		m.names[row] = value.(string)
+	case 2:
+		m.done[row] = value.(bool)
+	}
+}
+
+// a tree three levels deep where each node has as many children as its index + 1
+type treetest struct{}
+
+func (treetest) NumChildren(parent TreePath) int {
+	if len(parent) == 0 {
+		return 3
+	}
+	if len(parent) == 3 {
+		return 0
+	}
+	return parent[len(parent)-1] + 1
+}
+func (treetest) NodeText(node TreePath) string      { return fmt.Sprint(node) }
+func (treetest) NodeImage(node TreePath) image.Image { return nil }
+
+// a tree of unlimited depth where each node has three children, loaded a second after the node is first expanded
+type lazytreetest struct {
+	loaded map[string]bool // keyed by fmt.Sprint(node)
+}
+
+func (l *lazytreetest) NumChildren(parent TreePath) int {
+	if len(parent) == 0 || l.loaded[fmt.Sprint(parent)] {
+		return 3
+	}
+	return 0
+}
+func (l *lazytreetest) NodeText(node TreePath) string       { return fmt.Sprint(node) }
+func (l *lazytreetest) NodeImage(node TreePath) image.Image { return nil }
+func (l *lazytreetest) NeedsLoad(node TreePath) bool        { return !l.loaded[fmt.Sprint(node)] }
+func (l *lazytreetest) NodeID(node TreePath) string         { return fmt.Sprint(node) }
+
+func (l *lazytreetest) LoadChildren(node TreePath, cancel <-chan struct{}) func() {
+	select {
+	case <-time.After(time.Second):
+	case <-cancel:
+		println("load canceled")
+		return nil
+	}
+	return func() {
+		l.loaded[fmt.Sprint(node)] = true
+	}
+}
+
+// ten pages, each a different shade of gray with a darker band whose height is the page number
+type pagestest struct{}
+
+func (pagestest) Pages() int { return 10 }
+func (pagestest) PaintPage(page int, r image.Rectangle) image.Image {
+	i := image.NewRGBA(r)
+	draw.Draw(i, r, &image.Uniform{color.Gray{uint8(255 - page*10)}}, image.ZP, draw.Src)
+	draw.Draw(i, image.Rect(0, 0, 200, 10*(page+1)).Intersect(r), &image.Uniform{color.Gray{64}}, image.ZP, draw.Src)
+	return i
+}
+func (pagestest) PageMouse(page int, e MouseEvent) { fmt.Println("page", page, e.Pos) }
+func (pagestest) PageKey(e KeyEvent) bool          { return false }
+
 type testwin struct {
 	t          Tab
 	w          Window
@@ -71,6 +167,7 @@ type testwin struct {
 	festop     Button
 	vedit      TextField
 	openbtn    Button
+	msgbtn     Button
 	fnlabel    Label
 	icons      []icon
 	icontbl    Table
@@ -92,17 +189,86 @@ type testwin struct {
 	wsmall Window
 }
 
+// cgo can't be used in tests, so this can't call OpenGL; it only shows that the callbacks come
+type glareatest struct {
+	renders int
+}
+
+func (g *glareatest) Resized(width int, height int) {
+	fmt.Println("GLArea resized to", width, height)
+}
+
+func (g *glareatest) Render() {
+	g.renders++
+	if g.renders%100 == 0 {
+		fmt.Println("GLArea rendered", g.renders, "times")
+	}
+}
+
+// each repaint is a shade darker, to show that the whole cliprect is drawn every time
+type paintintotest struct {
+	areaHandler
+	shade uint8
+}
+
+func (p *paintintotest) PaintInto(buf *image.RGBA, cliprect image.Rectangle) {
+	p.shade -= 8
+	draw.Draw(buf, cliprect, &image.Uniform{color.Gray{p.shade}}, image.ZP, draw.Src)
+}
+
+// clicking repaints two opposite corners at once, which should come as one Paint with a region of two rectangles
+type regiontest struct {
+	areaHandler
+	a Area
+}
+
+func (r *regiontest) PaintRegion(region []image.Rectangle) {
+	fmt.Println("painting region", region)
+}
+
+func (r *regiontest) Mouse(me MouseEvent) {
+	if me.Up != 0 {
+		r.a.Repaint(image.Rect(0, 0, 20, 20))
+		r.a.Repaint(image.Rect(180, 180, 200, 200))
+	}
+}
+
 type areaHandler struct {
 	handled bool
 }
 
-func (a *areaHandler) Paint(r image.Rectangle) *image.RGBA {
+func (a *areaHandler) Paint(r image.Rectangle) image.Image {
 	i := image.NewRGBA(r)
 	draw.Draw(i, r, &image.Uniform{color.RGBA{128, 0, 128, 255}}, image.ZP, draw.Src)
+	t := NewTextLayout("The quick brown fox jumps over the lazy dog.\nΓειά σου κόσμε! مرحبا بالعالم", FontDescriptor{Size: 14}, 200)
+	t.SetAlign(TextAlignCenter)
+	t.Draw(i, image.Pt(10, 10), color.White)
+	for _, l := range t.Lines() {
+		draw.Draw(i, image.Rect(10+l.X, 10+l.Y+l.Baseline, 10+l.X+l.Width, 11+l.Y+l.Baseline).Intersect(r), &image.Uniform{color.RGBA{255, 255, 0, 255}}, image.ZP, draw.Src)
+	}
 	return i
 }
 func (a *areaHandler) Mouse(me MouseEvent)  { fmt.Printf("%#v\n", me) }
-func (a *areaHandler) Key(ke KeyEvent) bool { fmt.Printf("%#v %q\n", ke, ke.Key); return a.handled }
+func (a *areaHandler) Key(ke KeyEvent) bool { fmt.Printf("%#v %q %q\n", ke, ke.Key, ke.Rune); return a.handled }
+func (a *areaHandler) DragEnter(e DropEvent) bool { fmt.Printf("enter %#v\n", e); return true }
+func (a *areaHandler) DragOver(e DropEvent) bool  { return true }
+func (a *areaHandler) DragLeave()                 { fmt.Println("leave") }
+func (a *areaHandler) FocusGained()               { fmt.Println("focus gained") }
+func (a *areaHandler) FocusLost()                 { fmt.Println("focus lost") }
+func (a *areaHandler) MouseEntered()              { fmt.Println("entered") }
+func (a *areaHandler) MouseLeft()                 { fmt.Println("left") }
+func (a *areaHandler) MouseHovered(p image.Point) { fmt.Println("hovered", p) }
+func (a *areaHandler) Pen(me MouseEvent, pen PenState) { fmt.Printf("pen %#v %#v\n", me, pen) }
+func (a *areaHandler) PixelRatioChanged(ratio float64) {
+	fmt.Println("pixel ratio changed to", ratio)
+}
+func (a *areaHandler) Tooltip(p image.Point) string {
+	if p.X < 100 && p.Y < 100 {
+		return fmt.Sprintf("top left corner at %v", p)
+	}
+	return ""
+}
+func (a *areaHandler) Drop(e DropEvent) bool      { fmt.Printf("drop %v %v %q %v local %v op %d\n", e.Pos, e.Files, e.Text, e.Image != nil, e.Local, e.Operation); return true }
 
 func (tw *testwin) openFile(fn string) {
 	if fn == "" {
@@ -140,11 +306,121 @@ func (tw *testwin) addfe() {
 			tw.vedit.Invalid("")
 		}
 	})
+	copybtn := NewButton("Copy")
+	copybtn.OnClicked(func() {
+		SetClipboard(
+			ClipboardItem{Format: ClipboardHTML, Data: []byte("<b>bold</b> copy")},
+			ClipboardItem{Format: ClipboardText, Data: []byte("bold copy")},
+			ClipboardItem{Format: "application/x-goui-test", Render: func() []byte {
+				fmt.Println("rendering application/x-goui-test")
+				return []byte("delayed")
+			}})
+	})
+	pastebtn := NewButton("Paste")
+	pastebtn.OnClicked(func() {
+		for _, f := range ClipboardFormats() {
+			data, _ := Clipboard(f)
+			fmt.Printf("%s: %q\n", f, data)
+		}
+	})
+	WatchClipboard(func() {
+		fmt.Println("clipboard changed")
+	})
+	invalidbtn := NewButton("Focus First Invalid")
+	invalidbtn.OnClicked(func() {
+		if !tw.w.FocusFirstInvalid() {
+			fmt.Println("nothing invalid (type \"bad\" in the text field above the checkbox)")
+		}
+	})
 	tw.openbtn = NewButton("Open")
 	tw.openbtn.OnClicked(func() {
 		OpenFile(tw.w, tw.openFile)
 	})
 	tw.fnlabel = NewLabel("<no file selected>")
+	tw.msgbtn = NewButton("Confirm")
+	tw.msgbtn.OnClicked(func() {
+		Confirm(tw.w, "Do you want to continue?", "This is a test of Confirm().", func(result DialogResult) {
+			MsgBox(tw.w, fmt.Sprintf("You chose %d", result), "", nil)
+		})
+	})
+	promptbtn := NewButton("Prompt")
+	promptbtn.OnClicked(func() {
+		Prompt(tw.w, "What is your name?", "This is a test of Prompt().", "Nobody", func(result DialogResult, text string) {
+			fmt.Printf("Prompt() returned %d %q\n", result, text)
+		})
+	})
+	multibtn := NewButton("Prompt Multiline")
+	multibtn.OnClicked(func() {
+		PromptMultiline(tw.w, "Enter some notes", "", "line 1\nline 2", func(result DialogResult, text string) {
+			fmt.Printf("PromptMultiline() returned %d %q\n", result, text)
+		})
+	})
+	choosebtn := NewButton("Choose")
+	choosebtn.OnClicked(func() {
+		fruits := []string{"Apple", "Banana", "Cherry", "Grape", "Lemon", "Mango", "Orange", "Peach", "Pear", "Pineapple"}
+		Choose(tw.w, "Pick a fruit", fruits, func(index int) {
+			if index == -1 {
+				fmt.Println("Choose() cancelled")
+				return
+			}
+			fmt.Printf("Choose() returned %d %q\n", index, fruits[index])
+		})
+	})
+	colorbtn := NewColorButton(color.NRGBA{0x33, 0x66, 0x99, 0xFF})
+	colorbtn.OnChanged(func() {
+		fmt.Printf("ColorButton changed to %v\n", colorbtn.Color())
+	})
+	choosecolorbtn := NewButton("Choose Color")
+	choosecolorbtn.OnClicked(func() {
+		ChooseColor(tw.w, colorbtn.Color(), func(c color.Color) {
+			fmt.Printf("ChooseColor() returned %v\n", c)
+			if c != nil {
+				colorbtn.SetColor(c)
+			}
+		})
+	})
+	font := FontDescriptor{Family: "Sans", Size: 12}
+	choosefontbtn := NewButton("Choose Font")
+	choosefontbtn.OnClicked(func() {
+		ChooseFont(tw.w, font, func(f *FontDescriptor) {
+			if f == nil {
+				fmt.Println("ChooseFont() cancelled")
+				return
+			}
+			fmt.Printf("ChooseFont() returned %v (%#v)\n", f, *f)
+			font = *f
+			choosefontbtn.SetText(font.String())
+		})
+	})
+	attached := NewCheckbox("Attached Dialogs")
+	attached.SetChecked(tw.w.DialogsAttached())
+	attached.OnToggled(func() {
+		tw.w.SetDialogsAttached(attached.Checked())
+	})
+	progressbtn := NewButton("Progress Dialog")
+	progressbtn.OnClicked(func() {
+		pd := NewProgressDialog(tw.w, "Counting to 100...")
+		stop := make(chan struct{})
+		pd.OnCancel(func() {
+			close(stop)
+		})
+		go func() {
+			for i := 0; i <= 100; i++ {
+				select {
+				case <-stop:
+					Do(pd.Close)
+					return
+				case <-time.After(50 * time.Millisecond):
+				}
+				n := i
+				Do(func() {
+					pd.SetText(fmt.Sprintf("step %d", n))
+					pd.SetPercent(n)
+				})
+			}
+			Do(pd.Close)
+		}()
+	})
 	tw.festack = newVerticalStack(tw.festart,
 		tw.felabel,
 		tw.festop,
@@ -153,20 +429,134 @@ func (tw *testwin) addfe() {
 		tw.vedit,
 		Space(),
 		NewCheckbox("This is a checkbox test"),
-		tw.openbtn, tw.fnlabel)
+		tw.openbtn, tw.fnlabel, tw.msgbtn,
+		NewHorizontalStack(promptbtn, multibtn, choosebtn),
+		NewHorizontalStack(colorbtn, choosecolorbtn),
+		choosefontbtn,
+		NewHorizontalStack(attached, progressbtn),
+		NewHorizontalStack(invalidbtn, copybtn, pastebtn),
+		NewDialogButtons(NewButton("Save"), NewButton("Cancel"), NewButton("Don't Save")))
+	tw.festack.SetSpacing(PerPlatform{Default: -1, Mac: 8}.Int())
+	OnPlatforms(PlatformGTK|PlatformWindows, func() {
+		fmt.Println("running on", CurrentPlatform(), "(not Mac OS X)")
+	})
 	tw.festack.SetStretchy(4)
 	tw.festack.SetStretchy(6)
 	sb := NewSpinbox(0, 100)
+	sb.SetStep(5)
 	sp := NewProgressBar()
+	sl := NewHorizontalSlider(0, 100)
+	sl.SetTickInterval(10)
 	sb.OnChanged(func() {
 		sp.SetPercent(sb.Value())
+		sl.SetValue(sb.Value())
+	})
+	sl.OnChanged(func() {
+		sp.SetPercent(sl.Value())
+		sb.SetValue(sl.Value())
+	})
+	menu := NewPopupMenu()
+	menu.Append("Item", func() {
+		fmt.Println("item chosen")
+	})
+	menudisabled := menu.Append("Disabled & Ampersand", nil)
+	menu.SetEnabled(menudisabled, false)
+	menu.AppendSeparator()
+	var menucheck int
+	menucheck = menu.AppendCheckbox("Checkbox", true, func() {
+		fmt.Println("checkbox now", menu.Checked(menucheck))
+	})
+	var menubound bool
+	menu.AppendCheckboxFor("Bound Checkbox", &menubound, func() {
+		fmt.Println("bound checkbox now", menubound)
+	})
+	menu.AppendSeparator()
+	menuradio := 1
+	menu.AppendRadioGroup([]string{"Radio 1", "Radio 2", "Radio 3"}, &menuradio, func() {
+		fmt.Println("menu radio now", menuradio)
+	})
+	menu.AppendSeparator()
+	var menurecent []string
+	menu.Append("Open Another", func() {
+		menurecent = append(menurecent, fmt.Sprintf("file%d.txt", len(menurecent)+1))
 	})
-	tw.festack2 = newVerticalStack(sb, sp, Space(), Space(), NewTextbox())
-	tw.festack2.SetStretchy(3)
+	menu.AppendSubmenu("Open &Recent", func(sub PopupMenu) {
+		if len(menurecent) == 0 {
+			sub.SetEnabled(sub.Append("No Recent Files", nil), false)
+			return
+		}
+		for i := len(menurecent) - 1; i >= 0; i-- {
+			name := menurecent[i]
+			sub.Append(name, func() {
+				fmt.Println("open recent", name)
+			})
+		}
+	})
+	menubtn := NewButton("Popup Menu")
+	menubtn.OnClicked(menu.Show)
+	log := NewTextbox()
+	log.SetReadOnly(true)
+	log.SetWordWrap(true)
+	radio := NewRadioButtons("Radio 1", "Radio 2", "Radio 3")
+	radio.OnSelected(func() {
+		log.Append(fmt.Sprintf("radio %d\n", radio.Selected()))
+	})
+	indeterminate := NewCheckbox("Indeterminate")
+	indeterminate.OnToggled(func() {
+		sp.SetIndeterminate(indeterminate.Checked())
+	})
+	hidelog := NewCheckbox("Hide Log")
+	hidelog.OnToggled(func() {
+		if hidelog.Checked() {
+			log.Hide()
+		} else {
+			log.Show()
+		}
+	})
+	disableradio := NewCheckbox("Disable Radio Buttons")
+	disableradio.OnToggled(func() {
+		if disableradio.Checked() {
+			radio.Disable()
+		} else {
+			radio.Enable()
+		}
+	})
+	busybtn := NewButton("Busy for 2 Seconds")
+	busybtn.OnClicked(func() {
+		Busy(func() {
+			time.Sleep(2 * time.Second)
+		})
+	})
+	notifybtn := NewButton("&Notify")
+	notifybtn.OnClicked(func() {
+		if err := Notify("Hello", "This is a test of Notify().", nil); err != nil {
+			log.Append(fmt.Sprintf("notify error: %v\n", err))
+		}
+	})
+	notifybtn.SetTooltip("Shows a desktop notification.")
+	speakbtn := NewButton("Speak Log")
+	speakbtn.OnClicked(func() {
+		if err := Speak(log.Text()); err != nil {
+			log.Append(fmt.Sprintf("speak error: %v\n", err))
+		}
+	})
+	dictatebtn := NewButton("Dictate into Log")
+	dictatebtn.OnClicked(func() {
+		log.SetFocus()
+		if err := StartDictation(); err != nil {
+			log.Append(fmt.Sprintf("dictation error: %v\n", err))
+		}
+	})
+	radio.SetTooltip("These are radio buttons.")
+	tw.festack2 = newVerticalStack(sb, sp, sl, Space(), Space(), log, menubtn, radio, indeterminate, hidelog, disableradio, busybtn, notifybtn, speakbtn, dictatebtn)
 	tw.festack2.SetStretchy(4)
+	tw.festack2.SetStretchy(5)
 	tw.festack = newHorizontalStack(tw.festack, tw.festack2)
 	tw.festack.SetStretchy(0)
-	tw.festack.SetStretchy(1)
+	tw.festack.SetStretch(1, 2)		// the second column has the log; give it more room
+	if *spaced {
+		tw.festack.SetMargin(8)
+	}
 	tw.t.Append("Foreign Events", tw.festack)
 }
 
@@ -183,6 +573,40 @@ func (tw *testwin) make(done chan struct{}) {
 		done <- struct{}{}
 		return true
 	})
+	tw.w.RegisterShortcut(F5, 0, func() {
+		println("F5 shortcut pressed")
+	})
+	tw.w.RegisterShortcut('s', Ctrl|Shift, func() {
+		println("Ctrl+Shift+S shortcut pressed")
+	})
+	colorblindness := NormalVision
+	tw.w.RegisterShortcut(F9, 0, func() {
+		colorblindness = (colorblindness + 1) % (Tritanopia + 1)
+		println("simulating", colorblindness.String())
+		tw.w.SimulateColorBlindness(colorblindness)
+	})
+	tw.w.RegisterShortcut(F11, 0, func() {
+		Screenshots(tw.w, []image.Point{{400, 300}, {800, 600}}, func(shots []*image.RGBA) {
+			for i, shot := range shots {
+				f, err := os.Create(filepath.Join(os.TempDir(), fmt.Sprintf("uiscreenshot%d.png", i)))
+				if err != nil {
+					println("screenshot error:", err.Error())
+					return
+				}
+				png.Encode(f, shot)
+				f.Close()
+				println("saved", f.Name())
+			}
+		})
+	})
+	tw.w.RegisterShortcut('=', Ctrl, func() {
+		SetScaleFactor(ScaleFactor() + 0.25)
+	})
+	tw.w.RegisterShortcut('-', Ctrl, func() {
+		if ScaleFactor() > 0.5 {
+			SetScaleFactor(ScaleFactor() - 0.25)
+		}
+	})
 	tw.roenter = NewTextField()
 	tw.roro = NewTextField()
 	tw.roro.SetReadOnly(true)
@@ -193,6 +617,7 @@ func (tw *testwin) make(done chan struct{}) {
 	s.SetStretchy(2)
 	tw.t.Append("Read-Only", s)
 	tw.icons = readIcons() // repainter uses these
+	SetAppIcon(firstimg)
 	tw.repainter = newRepainter(15)
 	tw.t.Append("Repaint", tw.repainter.grid)
 	tw.addfe()
@@ -211,6 +636,27 @@ func (tw *testwin) make(done chan struct{}) {
 		}
 		tw.w.SetTitle(s)
 	})
+	tw.icontbl.OnHoverPreview(func(row int) image.Image {
+		return tw.icons[row].Icon
+	})
+	tw.icontbl.OnDragRow(func(row int) (DragData, DragOperation, func(DragOperation)) {
+		data := DragData{
+			Text:      fmt.Sprintf("row %d", row),
+			DragImage: tw.icons[row].Icon,
+			Hotspot:   image.Pt(8, 8),
+			Local:     row,
+			FilePromises: []FilePromise{{
+				Name: fmt.Sprintf("row %d.png", row),
+				Write: func(w io.Writer) error {
+					fmt.Println("writing row", row, "to a file")
+					return png.Encode(w, tw.icons[row].Icon)
+				},
+			}},
+		}
+		return data, DragCopy | DragMove, func(op DragOperation) {
+			fmt.Println("row", row, "dragged with operation", op)
+		}
+	})
 	tw.t.Append("Image List Table", tw.icontbl)
 	tw.group2 = NewGroup("Group", NewButton("Button in Group"))
 	tw.t.Append("Empty Group", NewGroup("Group", Space()))
@@ -227,13 +673,53 @@ func (tw *testwin) make(done chan struct{}) {
 	tw.simpleGrid.SetFilling(1, 2)
 	tw.simpleGrid.SetStretchy(1, 1)
 	tw.t.Append("Simple Grid", tw.simpleGrid)
+	form := NewGrid()
+	form.SetPadded(*spaced)
+	formname := NewTextField()
+	formpassword := NewPasswordField()
+	formnotes := NewTextbox()
+	formfocus := NewButton("Focus Name (tab order is backwards)")
+	formfocus.OnClicked(func() {
+		formname.SetFocus()
+	})
+	form.Attach(NewLabel("&Name"), 0, 0, false, RightBottom, false, Center, 1, 1)
+	form.Attach(formname, 1, 0, true, Fill, false, Fill, 1, 1)
+	form.Attach(NewLabel("&Password"), 0, 1, false, RightBottom, false, Center, 1, 1)
+	form.Attach(formpassword, 1, 1, true, Fill, false, Fill, 1, 1)
+	form.Attach(NewLabel("N&otes"), 0, 2, false, RightBottom, false, LeftTop, 1, 1)
+	form.Attach(formnotes, 1, 2, true, Fill, true, Fill, 1, 1)
+	form.Attach(formfocus, 0, 3, false, Fill, false, Fill, 2, 1)
+	tw.t.Append("Grid", form)
+	tw.w.SetTabOrder(formfocus, formnotes, formpassword, formname)
+	tw.w.OnFocusChanged(func(c Control) {
+		fmt.Printf("focus changed to %T\n", c)
+	})
 	tw.t.Append("Blank Tab", NewTab())
 	tw.nt = NewTab()
 	tw.nt.Append("Tab 1", Space())
 	tw.nt.Append("Tab 2", Space())
-	tw.t.Append("Tab", tw.nt)
+	ntadd := NewButton("Add Tab")
+	ntdel := NewButton("Delete Selected Tab")
+	ntlabel := NewLabel("")
+	ntadd.OnClicked(func() {
+		tw.nt.Append("New Tab", Space())
+	})
+	ntdel.OnClicked(func() {
+		if n := tw.nt.Selected(); n != -1 {
+			tw.nt.Delete(n)
+		}
+	})
+	tw.nt.OnSelected(func() {
+		ntlabel.SetText(fmt.Sprintf("selected %d", tw.nt.Selected()))
+	})
+	ntstack := newVerticalStack(tw.nt, newHorizontalStack(ntadd, ntdel, ntlabel))
+	ntstack.SetStretchy(0)
+	tw.t.Append("Tab", ntstack)
 	tw.t.Append("Space", Space())
 	tw.a = NewArea(200, 200, &areaHandler{false})
+	tw.a.SetHiDPI(true)
+	fmt.Println("Area pixel ratio", tw.a.PixelRatio())
+	fmt.Println("double-click", DoubleClickTime(), DoubleClickDistance())
 	tw.t.Append("Area", tw.a)
 	tw.spw = newHorizontalStack(
 		NewButton("hello"),
@@ -251,6 +737,129 @@ func (tw *testwin) make(done chan struct{}) {
 		NewTable(reflect.TypeOf(struct{ A, B, C int }{})),
 		NewLabel("hello ÉÀÔ"))
 	tw.t.Append("Pref Height", tw.sph)
+	tree := NewTree(treetest{})
+	treelabel := NewLabel("")
+	tree.OnSelected(func() {
+		treelabel.SetText(fmt.Sprintf("selected %v", tree.Selected()))
+	})
+	tree.OnActivated(func() {
+		treelabel.SetText(fmt.Sprintf("activated %v", tree.Selected()))
+	})
+	tree.OnExpanded(func(path TreePath) {
+		treelabel.SetText(fmt.Sprintf("expanded %v", path))
+	})
+	tree.OnCollapsed(func(path TreePath) {
+		treelabel.SetText(fmt.Sprintf("collapsed %v", path))
+	})
+	tree.OnHoverPreview(func(node TreePath) image.Image {
+		return tw.icons[node[len(node)-1]%len(tw.icons)].Icon
+	})
+	treestack := newVerticalStack(tree, treelabel)
+	treestack.SetStretchy(0)
+	tw.t.Append("Tree", treestack)
+	lazytree := NewTree(&lazytreetest{
+		loaded: make(map[string]bool),
+	})
+	var lazystate TreeState
+	lazysave := NewButton("Save State")
+	lazysave.OnClicked(func() {
+		lazystate = lazytree.State()
+		fmt.Printf("saved %#v\n", lazystate)
+	})
+	lazyrestore := NewButton("Restore State")
+	lazyrestore.OnClicked(func() {
+		lazytree.SetState(lazystate)
+	})
+	lazystack := newVerticalStack(lazytree, newHorizontalStack(lazysave, lazyrestore))
+	lazystack.SetStretchy(0)
+	tw.t.Append("Lazy Tree", lazystack)
+	tw.t.Append("Model Table", NewTableFromModel(&modeltest{
+		names: []string{"alpha", "beta", "gamma"},
+		done:  []bool{false, true, false},
+	}))
+	graph := NewGraph()
+	gsrc := graph.AddNode(GraphNode{Pos: image.Pt(20, 20), Outputs: 2})
+	gdst := graph.AddNode(GraphNode{Pos: image.Pt(220, 60), Inputs: 3, Outputs: 1})
+	graph.Connect(GraphConnection{From: GraphPort{gsrc, 0}, To: GraphPort{gdst, 1}})
+	graph.OnChanged(func(c GraphChange) {
+		fmt.Printf("graph %#v\n", c)
+	})
+	tw.t.Append("Graph", NewGraphEditor(400, 300, graph))
+	tl := NewTimeline(400, 150, 3)
+	tl.AddClip(TimelineClip{Track: 0, Start: time.Second, Length: 2 * time.Second})
+	tl.AddClip(TimelineClip{Track: 2, Start: 500 * time.Millisecond, Length: time.Second})
+	tl.SetSnap(250 * time.Millisecond)
+	tl.OnChanged(func(c TimelineChange) {
+		fmt.Printf("timeline %#v\n", c)
+	})
+	tl.OnScrub(func(t time.Duration) {
+		fmt.Println("scrub", t)
+	})
+	tw.t.Append("Timeline", tl)
+	gc := NewGantt(400, 150)
+	gnow := gc.Origin()
+	gdesign := gc.AddTask(GanttTask{Start: gnow.Add(8 * time.Hour), End: gnow.Add(32 * time.Hour), Progress: 0.5})
+	gbuild := gc.AddTask(GanttTask{Start: gnow.Add(32 * time.Hour), End: gnow.Add(80 * time.Hour)})
+	gc.AddDependency(GanttDependency{From: gdesign, To: gbuild})
+	gc.OnRescheduled(func(id int) {
+		fmt.Printf("gantt %d %#v\n", id, gc.Task(id))
+	})
+	tw.t.Append("Gantt", gc)
+	wf := NewWaveform(400, 150)
+	wsamples := make([]float32, 60*44100) // a minute of sawtooth that fades in every second
+	for i := range wsamples {
+		wsamples[i] = (float32(i%100)/50 - 1) * float32(i%44100) / 44100
+	}
+	wf.SetSamples(44100, [][]float32{wsamples})
+	wf.OnSelected(func() {
+		fmt.Println(wf.Selection())
+	})
+	wf.OnScrub(func(sample int) {
+		fmt.Println("waveform scrub", sample)
+	})
+	tw.t.Append("Waveform", wf)
+	lframes := make(chan LiveFrame)
+	go func() { // a bar sweeping across a 200x100 region of interest, much faster than the LiveView redraws
+		for i := 0; ; i++ {
+			img := image.NewGray(image.Rect(0, 0, 200, 100))
+			for y := 0; y < 100; y++ {
+				img.Pix[y*img.Stride+i%200] = 0xFF
+			}
+			lframes <- LiveFrame{Image: img, ROI: image.Rect(100, 25, 300, 125)}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	lv := NewLiveView(400, 150, lframes)
+	lv.SetMaxRate(30)
+	tw.t.Append("Live View", lv)
+	pa := NewPagedArea(200, 260, pagestest{})
+	pa.OnPageChanged(func() {
+		fmt.Println("page changed", pa.Page())
+	})
+	tw.t.Append("Paged Area", pa)
+	tw.t.Append("GL Area", NewGLArea(200, 200, new(glareatest)))
+	tw.t.Append("PaintInto", NewArea(200, 200, new(paintintotest)))
+	settings := NewVerticalStack(
+		NewCheckbox("Show line &numbers"),
+		NewCheckbox("Wrap long lines"),
+		newHorizontalStack(NewLabel("Tab width:"), NewSpinbox(1, 16)),
+		NewGroup("Saving", NewVerticalStack(
+			NewCheckbox("Save automatically"),
+			NewCheckbox("Keep backup copies"))))
+	noresults := NewLabel("No results")
+	noresults.Hide()
+	settingssearch := NewSettingsSearch(settings)
+	settingssearch.OnSearched(func() {
+		if settingssearch.Found() {
+			noresults.Hide()
+		} else {
+			noresults.Show()
+		}
+	})
+	tw.t.Append("Settings Search", NewVerticalStack(settingssearch, noresults, settings))
+	rt := new(regiontest)
+	rt.a = NewArea(200, 200, rt)
+	tw.t.Append("Paint Region", rt.a)
 	stack1 := newHorizontalStack(NewLabel("Test"), NewTextField())
 	stack1.SetStretchy(1)
 	stack2 := newHorizontalStack(NewLabel("ÉÀÔ"), NewTextField())
@@ -288,6 +897,14 @@ func (tw *testwin) make(done chan struct{}) {
 	})
 	tw.t.Append("Checkbox", tw.c)
 	tw.e = NewTextField()
+	tw.e.OnEnterPressed(func() {
+		text := tw.e.Text()
+		pos := tw.e.CursorPos()
+		fmt.Printf("enter pressed with caret at %d: %q|%q\n", pos, text[:pos], text[pos:])
+		// select the word before the caret, for checking Select()
+		start := strings.LastIndex(text[:pos], " ") + 1
+		tw.e.Select(start, pos)
+	})
 	tw.t.Append("Text Field", tw.e)
 	tw.e2 = NewPasswordField()
 	tw.t.Append("Password Field", tw.e2)
@@ -298,6 +915,7 @@ func (tw *testwin) make(done chan struct{}) {
 				NewButton("Small"),
 				NewButton("Small 2"),
 				NewArea(200, 200, &areaHandler{true})))
+		tw.wsmall.SetOwner(tw.w)
 		tw.wsmall.Show()
 	}
 }